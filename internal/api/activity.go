@@ -0,0 +1,108 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// ActivityKind identifies which kind of event an Activity entry holds
+type ActivityKind string
+
+const (
+	ActivityUpdate           ActivityKind = "update"
+	ActivityApproval         ActivityKind = "approval"
+	ActivityComment          ActivityKind = "comment"
+	ActivityChangesRequested ActivityKind = "changes_requested"
+	ActivityUnknown          ActivityKind = "unknown"
+)
+
+// Activity is one entry of a pull request's activity stream. Bitbucket
+// tags each entry by the key it is stored under, so exactly one of the
+// pointer fields is set; use Kind to tell which.
+type Activity struct {
+	Update           *PRUpdate  `json:"update,omitempty"`
+	Approval         *PRReview  `json:"approval,omitempty"`
+	Comment          *PRComment `json:"comment,omitempty"`
+	ChangesRequested *PRReview  `json:"changes_requested,omitempty"`
+}
+
+// PRUpdate records a change to a pull request: it being opened, pushed
+// to, edited, merged or declined. State is the state after the update.
+type PRUpdate struct {
+	State       PRState   `json:"state"`
+	Title       string    `json:"title"`
+	Description string    `json:"description"`
+	Reason      string    `json:"reason,omitempty"`
+	Author      User      `json:"author"`
+	Date        time.Time `json:"date"`
+	Source      PRRef     `json:"source"`
+	Destination PRRef     `json:"destination"`
+}
+
+// PRReview records an approval or a request for changes
+type PRReview struct {
+	User User      `json:"user"`
+	Date time.Time `json:"date"`
+}
+
+// Kind returns which kind of event the entry holds
+func (a Activity) Kind() ActivityKind {
+	switch {
+	case a.Update != nil:
+		return ActivityUpdate
+	case a.Approval != nil:
+		return ActivityApproval
+	case a.Comment != nil:
+		return ActivityComment
+	case a.ChangesRequested != nil:
+		return ActivityChangesRequested
+	default:
+		return ActivityUnknown
+	}
+}
+
+// Date returns when the event happened
+func (a Activity) Date() time.Time {
+	switch a.Kind() {
+	case ActivityUpdate:
+		return a.Update.Date
+	case ActivityApproval:
+		return a.Approval.Date
+	case ActivityComment:
+		return a.Comment.CreatedOn
+	case ActivityChangesRequested:
+		return a.ChangesRequested.Date
+	default:
+		return time.Time{}
+	}
+}
+
+// Actor returns the user behind the event, or nil for unknown entries
+func (a Activity) Actor() *User {
+	switch a.Kind() {
+	case ActivityUpdate:
+		return &a.Update.Author
+	case ActivityApproval:
+		return &a.Approval.User
+	case ActivityComment:
+		return &a.Comment.User
+	case ActivityChangesRequested:
+		return &a.ChangesRequested.User
+	default:
+		return nil
+	}
+}
+
+// GetPullRequestActivity retrieves the first page of a pull request's
+// activity stream, newest first. Use NextPage to fetch the rest.
+func (c *Client) GetPullRequestActivity(ctx context.Context, workspace, repoSlug string, prID int64) (*Paginated[Activity], error) {
+	path := fmt.Sprintf("/repositories/%s/%s/pullrequests/%d/activity", workspace, repoSlug, prID)
+
+	resp, err := c.Get(ctx, path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return ParseResponse[*Paginated[Activity]](resp)
+}
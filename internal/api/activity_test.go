@@ -0,0 +1,86 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestGetPullRequestActivity(t *testing.T) {
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/repositories/myworkspace/myrepo/pullrequests/42/activity" {
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Query().Get("ctx") == "page2" {
+			w.Write([]byte(`{"values": [
+				{"update": {"state": "OPEN", "title": "Add feature", "author": {"display_name": "Alice"}, "date": "2024-01-01T09:00:00+00:00"}}
+			]}`))
+			return
+		}
+		fmt.Fprintf(w, `{"next": "%s/repositories/myworkspace/myrepo/pullrequests/42/activity?ctx=page2", "values": [
+			{"changes_requested": {"user": {"display_name": "Dave"}, "date": "2024-01-03T10:00:00.123456+00:00"}},
+			{"approval": {"user": {"display_name": "Bob"}, "date": "2024-01-02T10:00:00+00:00"}},
+			{"comment": {"id": 7, "content": {"raw": "Looks good"}, "user": {"display_name": "Carol"}, "created_on": "2024-01-01T12:00:00+00:00"}}
+		]}`, server.URL)
+	}))
+	defer server.Close()
+
+	client := NewClient(WithBaseURL(server.URL), WithToken("test-token"))
+	page, err := client.GetPullRequestActivity(context.Background(), "myworkspace", "myrepo", 42)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	wantKinds := []ActivityKind{ActivityChangesRequested, ActivityApproval, ActivityComment}
+	wantActors := []string{"Dave", "Bob", "Carol"}
+	if len(page.Values) != len(wantKinds) {
+		t.Fatalf("expected %d entries, got %d", len(wantKinds), len(page.Values))
+	}
+	for i, a := range page.Values {
+		if a.Kind() != wantKinds[i] {
+			t.Errorf("entry %d: kind = %s, want %s", i, a.Kind(), wantKinds[i])
+		}
+		if a.Actor() == nil || a.Actor().DisplayName != wantActors[i] {
+			t.Errorf("entry %d: unexpected actor %+v", i, a.Actor())
+		}
+		if a.Date().IsZero() {
+			t.Errorf("entry %d: date not parsed", i)
+		}
+	}
+
+	next, err := NextPage(context.Background(), client, page)
+	if err != nil {
+		t.Fatalf("unexpected error fetching next page: %v", err)
+	}
+	if len(next.Values) != 1 || next.Values[0].Kind() != ActivityUpdate || next.Values[0].Update.State != PRStateOpen {
+		t.Fatalf("unexpected next page: %+v", next.Values)
+	}
+	if want := time.Date(2024, 1, 1, 9, 0, 0, 0, time.UTC); !next.Values[0].Date().Equal(want) {
+		t.Errorf("update date = %v, want %v", next.Values[0].Date(), want)
+	}
+
+	last, err := NextPage(context.Background(), client, next)
+	if err != nil || last != nil {
+		t.Errorf("expected no page after the last one, got %+v, %v", last, err)
+	}
+}
+
+func TestActivityKindUnknown(t *testing.T) {
+	var a Activity
+	if a.Kind() != ActivityUnknown || a.Actor() != nil || !a.Date().IsZero() {
+		t.Errorf("empty activity should be unknown with no actor or date")
+	}
+}
+
+func TestNextPageRejectsOtherHosts(t *testing.T) {
+	client := NewClient(WithBaseURL("https://api.bitbucket.org/2.0"))
+	page := &Paginated[Activity]{Next: "https://evil.example.com/2.0/steal"}
+	if _, err := NextPage(context.Background(), client, page); err == nil {
+		t.Error("expected an error for a next link on another host")
+	}
+}
@@ -0,0 +1,73 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// DownloadAvatar fetches the image at an entity's links.avatar.href and
+// returns its bytes and content type. Redirects to the avatar CDN are
+// followed; credentials are only sent to Bitbucket hosts.
+func (c *Client) DownloadAvatar(ctx context.Context, avatarURL string) ([]byte, string, error) {
+	if avatarURL == "" {
+		return nil, "", fmt.Errorf("no avatar URL available")
+	}
+
+	u, err := url.Parse(avatarURL)
+	if err != nil || u.Scheme == "" || u.Host == "" {
+		return nil, "", fmt.Errorf("invalid avatar URL: %s", avatarURL)
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return nil, "", fmt.Errorf("could not create request: %w", err)
+	}
+	httpReq.Header.Set("User-Agent", UserAgent)
+	httpReq.Header.Set("Accept", "image/*")
+	if c.isBitbucketHost(u.Host) {
+		c.setAuth(httpReq)
+	}
+
+	httpResp, err := c.httpClient.Do(httpReq)
+	if err != nil {
+		return nil, "", fmt.Errorf("request failed: %w", err)
+	}
+	defer httpResp.Body.Close()
+
+	data, err := io.ReadAll(httpResp.Body)
+	if err != nil {
+		return nil, "", fmt.Errorf("could not read response body: %w", err)
+	}
+
+	if httpResp.StatusCode >= 400 {
+		return nil, "", &APIError{
+			StatusCode: httpResp.StatusCode,
+			Message:    http.StatusText(httpResp.StatusCode),
+			RequestID:  RequestID(httpResp.Header),
+		}
+	}
+
+	contentType := httpResp.Header.Get("Content-Type")
+	if contentType == "" {
+		contentType = http.DetectContentType(data)
+	}
+	if !strings.HasPrefix(contentType, "image/") {
+		return nil, "", fmt.Errorf("avatar URL returned %s instead of an image", contentType)
+	}
+
+	return data, contentType, nil
+}
+
+// isBitbucketHost reports whether host belongs to Bitbucket or to the
+// client's configured API host
+func (c *Client) isBitbucketHost(host string) bool {
+	if base, err := url.Parse(c.baseURL); err == nil && strings.EqualFold(base.Host, host) {
+		return true
+	}
+	host = strings.ToLower(host)
+	return host == "bitbucket.org" || strings.HasSuffix(host, ".bitbucket.org")
+}
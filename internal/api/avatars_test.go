@@ -0,0 +1,76 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestDownloadAvatar(t *testing.T) {
+	png := []byte("\x89PNG\r\n\x1a\nfake-image")
+
+	cdn := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/avatar.png":
+			w.Header().Set("Content-Type", "image/png")
+			w.Write(png)
+		case "/login":
+			w.Header().Set("Content-Type", "text/html")
+			w.Write([]byte("<html>login</html>"))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer cdn.Close()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got := r.Header.Get("Authorization"); got != "Bearer test-token" {
+			t.Errorf("expected bearer auth on API host, got %q", got)
+		}
+		switch r.URL.Path {
+		case "/avatar":
+			http.Redirect(w, r, cdn.URL+"/avatar.png", http.StatusFound)
+		case "/html":
+			http.Redirect(w, r, cdn.URL+"/login", http.StatusFound)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(WithBaseURL(server.URL), WithToken("test-token"))
+
+	tests := []struct {
+		name     string
+		url      string
+		wantType string
+		wantErr  bool
+	}{
+		{name: "follows redirect to CDN", url: server.URL + "/avatar", wantType: "image/png"},
+		{name: "non-image response", url: server.URL + "/html", wantErr: true},
+		{name: "not found", url: server.URL + "/missing", wantErr: true},
+		{name: "empty URL", url: "", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, contentType, err := client.DownloadAvatar(context.Background(), tt.url)
+			if tt.wantErr {
+				if err == nil {
+					t.Error("expected error but got nil")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if contentType != tt.wantType {
+				t.Errorf("expected content type %q, got %q", tt.wantType, contentType)
+			}
+			if string(data) != string(png) {
+				t.Errorf("unexpected data %q", data)
+			}
+		})
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"net/url"
 	"strconv"
+	"strings"
 )
 
 // BranchFull represents a Bitbucket branch with full details
@@ -113,3 +114,82 @@ func (c *Client) DeleteBranch(ctx context.Context, workspace, repoSlug, branchNa
 	_, err := c.Delete(ctx, path)
 	return err
 }
+
+// IsBranchMerged reports whether every commit on branch is already reachable
+// from base, i.e. the branch has no commits ahead of base.
+func (c *Client) IsBranchMerged(ctx context.Context, workspace, repoSlug, branch, base string) (bool, error) {
+	path := fmt.Sprintf("/repositories/%s/%s/commits/%s", workspace, repoSlug, url.PathEscape(branch))
+
+	query := url.Values{}
+	query.Set("exclude", base)
+	query.Set("pagelen", "1")
+
+	resp, err := c.Get(ctx, path, query)
+	if err != nil {
+		return false, err
+	}
+
+	result, err := ParseResponse[*Paginated[Commit]](resp)
+	if err != nil {
+		return false, err
+	}
+
+	return len(result.Values) == 0, nil
+}
+
+// BranchingModel is a repository's branching model: the branches work is
+// merged into and the prefixes used for each kind of branch
+type BranchingModel struct {
+	Development *BranchingModelBranch `json:"development,omitempty"`
+	Production  *BranchingModelBranch `json:"production,omitempty"`
+	BranchTypes []BranchType          `json:"branch_types"`
+}
+
+// BranchingModelBranch is the development or production branch of a
+// branching model. Branch is nil when the named branch does not exist.
+type BranchingModelBranch struct {
+	Name          string      `json:"name"`
+	UseMainbranch bool        `json:"use_mainbranch"`
+	Branch        *BranchFull `json:"branch,omitempty"`
+}
+
+// BranchType is a kind of branch in a branching model and its name prefix
+type BranchType struct {
+	Kind   string `json:"kind"` // feature, bugfix, release or hotfix
+	Prefix string `json:"prefix"`
+}
+
+// GetBranchingModel retrieves the branching model in effect for a
+// repository, which may be inherited from its project
+func (c *Client) GetBranchingModel(ctx context.Context, workspace, repoSlug string) (*BranchingModel, error) {
+	path := fmt.Sprintf("/repositories/%s/%s/branching-model", workspace, repoSlug)
+
+	resp, err := c.Get(ctx, path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return ParseResponse[*BranchingModel](resp)
+}
+
+// Prefix returns the branch name prefix for kind, or "" if the model has
+// no such branch type
+func (m *BranchingModel) Prefix(kind string) string {
+	for _, t := range m.BranchTypes {
+		if strings.EqualFold(t.Kind, kind) {
+			return t.Prefix
+		}
+	}
+	return ""
+}
+
+// TypeOf returns the kind of branch name according to its prefix, or "" if
+// it has none of the model's prefixes
+func (m *BranchingModel) TypeOf(name string) string {
+	for _, t := range m.BranchTypes {
+		if t.Prefix != "" && strings.HasPrefix(name, t.Prefix) {
+			return t.Kind
+		}
+	}
+	return ""
+}
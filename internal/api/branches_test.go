@@ -850,3 +850,134 @@ func TestListBranchesPagination(t *testing.T) {
 		t.Errorf("expected 2 values, got %d", len(result.Values))
 	}
 }
+
+func TestIsBranchMerged(t *testing.T) {
+	tests := []struct {
+		name        string
+		branch      string
+		base        string
+		response    string
+		statusCode  int
+		expectedURL string
+		want        bool
+		wantErr     bool
+	}{
+		{
+			name:        "fully merged",
+			branch:      "feature/done",
+			base:        "main",
+			response:    `{"pagelen": 1, "values": []}`,
+			statusCode:  http.StatusOK,
+			expectedURL: "/repositories/myworkspace/myrepo/commits/feature/done",
+			want:        true,
+		},
+		{
+			name:        "has unmerged commits",
+			branch:      "feature/wip",
+			base:        "main",
+			response:    `{"pagelen": 1, "values": [{"hash": "abc123"}], "next": "https://example.com/next"}`,
+			statusCode:  http.StatusOK,
+			expectedURL: "/repositories/myworkspace/myrepo/commits/feature/wip",
+			want:        false,
+		},
+		{
+			name:       "branch not found",
+			branch:     "missing",
+			base:       "main",
+			response:   `{"error": {"message": "Branch not found"}}`,
+			statusCode: http.StatusNotFound,
+			wantErr:    true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var receivedReq *http.Request
+
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				receivedReq = r
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(tt.statusCode)
+				w.Write([]byte(tt.response))
+			}))
+			defer server.Close()
+
+			client := NewClient(WithBaseURL(server.URL), WithToken("test-token"))
+
+			got, err := client.IsBranchMerged(context.Background(), "myworkspace", "myrepo", tt.branch, tt.base)
+
+			if tt.wantErr {
+				if err == nil {
+					t.Error("expected error but got nil")
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if got != tt.want {
+				t.Errorf("expected merged=%v, got %v", tt.want, got)
+			}
+
+			if !strings.HasSuffix(receivedReq.URL.Path, tt.expectedURL) {
+				t.Errorf("expected URL path to end with %q, got %q", tt.expectedURL, receivedReq.URL.Path)
+			}
+
+			if got := receivedReq.URL.Query().Get("exclude"); got != tt.base {
+				t.Errorf("expected exclude=%q, got %q", tt.base, got)
+			}
+		})
+	}
+}
+
+func TestGetBranchingModel(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/repositories/ws/repo/branching-model" {
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+		w.Write([]byte(`{
+			"type": "branching_model",
+			"development": {"name": "develop", "use_mainbranch": false, "branch": {"name": "develop", "type": "branch"}},
+			"production": {"name": "main", "use_mainbranch": true},
+			"branch_types": [{"kind": "feature", "prefix": "feature/"}, {"kind": "bugfix", "prefix": "bugfix/"}]
+		}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(WithBaseURL(server.URL), WithToken("test-token"))
+	model, err := client.GetBranchingModel(context.Background(), "ws", "repo")
+	if err != nil {
+		t.Fatalf("GetBranchingModel() error: %v", err)
+	}
+	if model.Development == nil || model.Development.Name != "develop" || model.Development.Branch == nil {
+		t.Errorf("development = %+v", model.Development)
+	}
+	if model.Production == nil || !model.Production.UseMainbranch || model.Production.Branch != nil {
+		t.Errorf("production = %+v", model.Production)
+	}
+	if len(model.BranchTypes) != 2 || model.BranchTypes[0].Prefix != "feature/" {
+		t.Errorf("branch types = %+v", model.BranchTypes)
+	}
+}
+
+func TestBranchingModelPrefixes(t *testing.T) {
+	model := &BranchingModel{BranchTypes: []BranchType{
+		{Kind: "feature", Prefix: "feature/"},
+		{Kind: "hotfix", Prefix: "hotfix/"},
+	}}
+
+	if got := model.Prefix("Feature"); got != "feature/" {
+		t.Errorf("Prefix(Feature) = %q", got)
+	}
+	if got := model.Prefix("release"); got != "" {
+		t.Errorf("Prefix(release) = %q, want empty", got)
+	}
+	if got := model.TypeOf("hotfix/crash"); got != "hotfix" {
+		t.Errorf("TypeOf(hotfix/crash) = %q", got)
+	}
+	if got := model.TypeOf("crash"); got != "" {
+		t.Errorf("TypeOf(crash) = %q, want empty", got)
+	}
+}
@@ -4,11 +4,14 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
 	"net/url"
+	"reflect"
 	"strings"
+	"sync"
 	"time"
 )
 
@@ -30,6 +33,17 @@ type Client struct {
 	token      string
 	username   string // For Basic Auth with API tokens
 	apiToken   string // For Basic Auth with API tokens
+	apiVersion string // APIVersionCloud or APIVersionServer
+	optionErr  error  // An option that failed, returned by every request
+
+	authMu  sync.Mutex     // Guards token, which refresh may replace
+	refresh TokenRefresher // Set by WithTokenRefresher
+
+	userMu      sync.Mutex
+	currentUser *User // Cached by CurrentUser
+
+	rateMu    sync.Mutex
+	rateLimit *RateLimit // Last reported by a response
 }
 
 // ClientOption is a functional option for configuring the client
@@ -94,13 +108,32 @@ type APIError struct {
 	Message    string            `json:"message"`
 	Detail     string            `json:"detail"`
 	Fields     map[string]string `json:"fields,omitempty"`
+
+	// RequestID identifies the failed request to Atlassian support
+	RequestID string `json:"request_id,omitempty"`
 }
 
 func (e *APIError) Error() string {
+	msg := fmt.Sprintf("API error %d: %s", e.StatusCode, e.Message)
 	if e.Detail != "" {
-		return fmt.Sprintf("API error %d: %s - %s", e.StatusCode, e.Message, e.Detail)
+		msg += " - " + e.Detail
+	}
+	if e.RequestID != "" {
+		msg += fmt.Sprintf(" (request ID %s)", e.RequestID)
 	}
-	return fmt.Sprintf("API error %d: %s", e.StatusCode, e.Message)
+	return msg
+}
+
+// RequestID returns the ID Bitbucket gave a request, from the headers of
+// its response, or "" if there is none. Bitbucket Cloud sends X-Request-Id
+// and Bitbucket Server X-AREQUESTID.
+func RequestID(h http.Header) string {
+	for _, name := range []string{"X-Request-Id", "X-Arequestid"} {
+		if id := strings.TrimSpace(h.Get(name)); id != "" {
+			return id
+		}
+	}
+	return ""
 }
 
 // Request represents an API request
@@ -110,30 +143,179 @@ type Request struct {
 	Query   url.Values
 	Body    interface{}
 	Headers map[string]string
+
+	// RawBody, when set, is sent as the request body instead of Body being
+	// encoded as JSON. Its Content-Type should be given in Headers.
+	RawBody io.Reader
 }
 
 // Response represents an API response
 type Response struct {
 	StatusCode int
+	Status     string // e.g. "200 OK"
+	Proto      string // e.g. "HTTP/1.1"
 	Headers    http.Header
 	Body       []byte
 }
 
-// Do performs an API request
+// Do performs an API request. Error statuses are returned as *APIError
+// alongside the response.
 func (c *Client) Do(ctx context.Context, req *Request) (*Response, error) {
+	resp, err := c.DoRaw(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	// Check for errors
+	if resp.StatusCode >= 400 {
+		return resp, newAPIError(resp.StatusCode, resp.Headers, resp.Body)
+	}
+
+	return resp, nil
+}
+
+// DoRaw performs an API request and returns the response whatever its
+// status, so callers can inspect error responses themselves. Only failures
+// to send the request or read the response are returned as errors. A 401
+// is retried once with a refreshed token if the client has a refresher.
+func (c *Client) DoRaw(ctx context.Context, req *Request) (*Response, error) {
+	sent := c.accessToken()
+	resp, err := c.send(ctx, req)
+	if err != nil || resp.StatusCode != http.StatusUnauthorized || !c.canRefresh(req) {
+		return resp, err
+	}
+	if !c.refreshAccessToken(ctx, sent) {
+		return resp, nil
+	}
+	if seeker, ok := req.RawBody.(io.Seeker); ok {
+		if _, err := seeker.Seek(0, io.SeekStart); err != nil {
+			return resp, nil
+		}
+	}
+	return c.send(ctx, req)
+}
+
+// send performs one attempt of a DoRaw request
+func (c *Client) send(ctx context.Context, req *Request) (*Response, error) {
+	httpReq, err := c.newHTTPRequest(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	// Execute request
+	httpResp, err := c.httpClient.Do(httpReq)
+	if err != nil {
+		return nil, fmt.Errorf("request failed: %w", err)
+	}
+	defer httpResp.Body.Close()
+	c.recordRateLimit(httpResp.Header)
+
+	// Read response body
+	respBody, err := io.ReadAll(httpResp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("could not read response body: %w", err)
+	}
+
+	if c.IsServer() && httpResp.StatusCode < 400 {
+		respBody = normalizeServerBody(serverPath(req.Path), httpReq.URL, respBody)
+	}
+
+	return &Response{
+		StatusCode: httpResp.StatusCode,
+		Status:     httpResp.Status,
+		Proto:      httpResp.Proto,
+		Headers:    httpResp.Header,
+		Body:       respBody,
+	}, nil
+}
+
+// GetRaw performs a GET request and returns the status, headers and body
+// without interpreting them. Unlike Get, error statuses are not turned into
+// an *APIError.
+func (c *Client) GetRaw(ctx context.Context, path string, query url.Values) (*Response, error) {
+	return c.DoRaw(ctx, &Request{
+		Method: http.MethodGet,
+		Path:   path,
+		Query:  query,
+	})
+}
+
+// RelativePath converts an absolute API URL, such as a "next" link, into a
+// path and query for this client. It fails for URLs outside the client's
+// base URL so credentials are never sent to another host.
+func (c *Client) RelativePath(rawURL string) (string, url.Values, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return "", nil, fmt.Errorf("invalid URL: %w", err)
+	}
+	base, err := url.Parse(c.baseURL)
+	if err != nil {
+		return "", nil, fmt.Errorf("invalid base URL: %w", err)
+	}
+	// Paths are kept escaped, as requests are built from escaped paths
+	path, basePath := u.EscapedPath(), base.EscapedPath()
+	if u.Host != base.Host || !strings.HasPrefix(path, basePath) {
+		return "", nil, fmt.Errorf("URL %s is not on %s", rawURL, c.baseURL)
+	}
+	return strings.TrimPrefix(path, basePath), u.Query(), nil
+}
+
+// maxErrorBodySize bounds how much of an error response DoStream reads
+const maxErrorBodySize = 1 << 20
+
+// DoStream performs an API request and returns the response body unread,
+// so large responses can be copied to their destination without being held
+// in memory. The caller must close the body. Error responses are still
+// returned as *APIError.
+func (c *Client) DoStream(ctx context.Context, req *Request) (io.ReadCloser, error) {
+	httpReq, err := c.newHTTPRequest(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	httpResp, err := c.httpClient.Do(httpReq)
+	if err != nil {
+		return nil, fmt.Errorf("request failed: %w", err)
+	}
+	c.recordRateLimit(httpResp.Header)
+
+	if httpResp.StatusCode >= 400 {
+		defer httpResp.Body.Close()
+		respBody, _ := io.ReadAll(io.LimitReader(httpResp.Body, maxErrorBodySize))
+		return nil, newAPIError(httpResp.StatusCode, httpResp.Header, respBody)
+	}
+
+	return httpResp.Body, nil
+}
+
+// newHTTPRequest builds the HTTP request for req, with headers and auth set
+func (c *Client) newHTTPRequest(ctx context.Context, req *Request) (*http.Request, error) {
+	if c.optionErr != nil {
+		return nil, c.optionErr
+	}
+
+	path, query := req.Path, req.Query
+	if c.IsServer() {
+		path = serverPath(path)
+		var err error
+		if query, err = serverQuery(path, query); err != nil {
+			return nil, err
+		}
+	}
+
 	// Build URL
-	reqURL, err := url.Parse(c.baseURL + "/" + strings.TrimPrefix(req.Path, "/"))
+	reqURL, err := url.Parse(c.baseURL + "/" + strings.TrimPrefix(path, "/"))
 	if err != nil {
 		return nil, fmt.Errorf("invalid request URL: %w", err)
 	}
 
-	if req.Query != nil {
-		reqURL.RawQuery = req.Query.Encode()
+	if query != nil {
+		reqURL.RawQuery = query.Encode()
 	}
 
 	// Build request body
-	var bodyReader io.Reader
-	if req.Body != nil {
+	bodyReader := req.RawBody
+	if bodyReader == nil && req.Body != nil {
 		bodyBytes, err := json.Marshal(req.Body)
 		if err != nil {
 			return nil, fmt.Errorf("could not marshal request body: %w", err)
@@ -151,67 +333,65 @@ func (c *Client) Do(ctx context.Context, req *Request) (*Response, error) {
 	httpReq.Header.Set("User-Agent", UserAgent)
 	httpReq.Header.Set("Accept", "application/json")
 
-	if req.Body != nil {
+	if req.Body != nil && req.RawBody == nil {
 		httpReq.Header.Set("Content-Type", "application/json")
 	}
 
-	// Set authentication
-	if c.username != "" && c.apiToken != "" {
-		// Basic Auth for Atlassian API tokens
-		httpReq.SetBasicAuth(c.username, c.apiToken)
-	} else if c.token != "" {
-		// Bearer token for OAuth or Access Tokens
-		httpReq.Header.Set("Authorization", "Bearer "+c.token)
-	}
+	c.setAuth(httpReq)
 
 	for key, value := range req.Headers {
 		httpReq.Header.Set(key, value)
 	}
 
-	// Execute request
-	httpResp, err := c.httpClient.Do(httpReq)
-	if err != nil {
-		return nil, fmt.Errorf("request failed: %w", err)
-	}
-	defer httpResp.Body.Close()
+	return httpReq, nil
+}
 
-	// Read response body
-	respBody, err := io.ReadAll(httpResp.Body)
-	if err != nil {
-		return nil, fmt.Errorf("could not read response body: %w", err)
+// newAPIError builds an APIError from an error response, using the message
+// from Bitbucket's error envelope when there is one
+func newAPIError(statusCode int, header http.Header, body []byte) *APIError {
+	apiErr := &APIError{
+		StatusCode: statusCode,
+		Message:    http.StatusText(statusCode),
+		RequestID:  RequestID(header),
 	}
 
-	resp := &Response{
-		StatusCode: httpResp.StatusCode,
-		Headers:    httpResp.Header,
-		Body:       respBody,
+	// Try to parse error response
+	var errResp struct {
+		Error struct {
+			Message string            `json:"message"`
+			Detail  string            `json:"detail"`
+			Fields  map[string]string `json:"fields"`
+		} `json:"error"`
+	}
+	if json.Unmarshal(body, &errResp) == nil && errResp.Error.Message != "" {
+		apiErr.Message = errResp.Error.Message
+		apiErr.Detail = errResp.Error.Detail
+		apiErr.Fields = errResp.Error.Fields
+		return apiErr
 	}
 
-	// Check for errors
-	if httpResp.StatusCode >= 400 {
-		apiErr := &APIError{
-			StatusCode: httpResp.StatusCode,
-			Message:    http.StatusText(httpResp.StatusCode),
-		}
+	// Bitbucket Server reports a list of errors instead
+	var serverResp struct {
+		Errors []struct {
+			Message string `json:"message"`
+		} `json:"errors"`
+	}
+	if json.Unmarshal(body, &serverResp) == nil && len(serverResp.Errors) > 0 && serverResp.Errors[0].Message != "" {
+		apiErr.Message = serverResp.Errors[0].Message
+	}
 
-		// Try to parse error response
-		var errResp struct {
-			Error struct {
-				Message string            `json:"message"`
-				Detail  string            `json:"detail"`
-				Fields  map[string]string `json:"fields"`
-			} `json:"error"`
-		}
-		if json.Unmarshal(respBody, &errResp) == nil && errResp.Error.Message != "" {
-			apiErr.Message = errResp.Error.Message
-			apiErr.Detail = errResp.Error.Detail
-			apiErr.Fields = errResp.Error.Fields
-		}
+	return apiErr
+}
 
-		return resp, apiErr
+// setAuth adds the client's credentials to an outgoing request
+func (c *Client) setAuth(httpReq *http.Request) {
+	if c.username != "" && c.apiToken != "" {
+		// Basic Auth for Atlassian API tokens
+		httpReq.SetBasicAuth(c.username, c.apiToken)
+	} else if token := c.accessToken(); token != "" {
+		// Bearer token for OAuth or Access Tokens
+		httpReq.Header.Set("Authorization", "Bearer "+token)
 	}
-
-	return resp, nil
 }
 
 // Get performs a GET request
@@ -241,6 +421,15 @@ func (c *Client) Put(ctx context.Context, path string, body interface{}) (*Respo
 	})
 }
 
+// Patch performs a PATCH request for partial updates
+func (c *Client) Patch(ctx context.Context, path string, body interface{}) (*Response, error) {
+	return c.Do(ctx, &Request{
+		Method: http.MethodPatch,
+		Path:   path,
+		Body:   body,
+	})
+}
+
 // Delete performs a DELETE request
 func (c *Client) Delete(ctx context.Context, path string) (*Response, error) {
 	return c.Do(ctx, &Request{
@@ -259,9 +448,39 @@ type Paginated[T any] struct {
 	Values   []T    `json:"values"`
 }
 
-// ParseResponse parses a JSON response into the given type
+// NextPage fetches the page after page by following its next link, which
+// works for endpoints that paginate with opaque cursors rather than page
+// numbers. It returns nil when page is the last one.
+func NextPage[T any](ctx context.Context, c *Client, page *Paginated[T]) (*Paginated[T], error) {
+	if page == nil || page.Next == "" {
+		return nil, nil
+	}
+
+	path, query, err := c.RelativePath(page.Next)
+	if err != nil {
+		return nil, fmt.Errorf("invalid next page: %w", err)
+	}
+
+	resp, err := c.Get(ctx, path, query)
+	if err != nil {
+		return nil, err
+	}
+
+	return ParseResponse[*Paginated[T]](resp)
+}
+
+// ParseResponse parses a JSON response into the given type. A successful
+// response with no body, such as a 204 No Content, parses as the zero value;
+// when T is a pointer it points to a zero value rather than being nil, so
+// callers can read its fields.
 func ParseResponse[T any](resp *Response) (T, error) {
 	var result T
+	if len(bytes.TrimSpace(resp.Body)) == 0 && resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		if t := reflect.TypeFor[T](); t.Kind() == reflect.Pointer {
+			result = reflect.New(t.Elem()).Interface().(T)
+		}
+		return result, nil
+	}
 	if err := json.Unmarshal(resp.Body, &result); err != nil {
 		return result, fmt.Errorf("could not parse response: %w", err)
 	}
@@ -270,11 +489,12 @@ func ParseResponse[T any](resp *Response) (T, error) {
 
 // User represents a Bitbucket user
 type User struct {
-	UUID        string `json:"uuid"`
-	Username    string `json:"username"`
-	DisplayName string `json:"display_name"`
-	Nickname    string `json:"nickname"`
-	AccountID   string `json:"account_id"`
+	UUID        string    `json:"uuid"`
+	Username    string    `json:"username"`
+	DisplayName string    `json:"display_name"`
+	Nickname    string    `json:"nickname"`
+	AccountID   string    `json:"account_id"`
+	CreatedOn   time.Time `json:"created_on,omitzero"` // Only set when a user is fetched on its own
 	Links       struct {
 		Avatar struct {
 			Href string `json:"href"`
@@ -285,7 +505,13 @@ type User struct {
 	} `json:"links"`
 }
 
-// GetCurrentUser returns the authenticated user
+// Authenticated reports whether the client has credentials to send
+func (c *Client) Authenticated() bool {
+	return c.accessToken() != "" || (c.username != "" && c.apiToken != "")
+}
+
+// GetCurrentUser returns the authenticated user. It always queries the API;
+// use CurrentUser when a cached result is acceptable.
 func (c *Client) GetCurrentUser(ctx context.Context) (*User, error) {
 	resp, err := c.Get(ctx, "/user", nil)
 	if err != nil {
@@ -294,3 +520,41 @@ func (c *Client) GetCurrentUser(ctx context.Context) (*User, error) {
 
 	return ParseResponse[*User](resp)
 }
+
+// ErrUserNotFound is returned, along with the API error, by GetUser when
+// Bitbucket has no user by that name
+var ErrUserNotFound = errors.New("user not found")
+
+// GetUser returns a user by UUID ({...}), account ID or username. Bitbucket
+// has deprecated looking users up by username and no longer resolves most
+// of them; look such users up in a workspace's member list instead.
+func (c *Client) GetUser(ctx context.Context, id string) (*User, error) {
+	resp, err := c.Get(ctx, "/users/"+url.PathEscape(id), nil)
+	if err != nil {
+		var apiErr *APIError
+		if errors.As(err, &apiErr) && (apiErr.StatusCode == http.StatusNotFound || apiErr.StatusCode == http.StatusGone) {
+			return nil, fmt.Errorf("%w: %s: %w", ErrUserNotFound, id, err)
+		}
+		return nil, err
+	}
+
+	return ParseResponse[*User](resp)
+}
+
+// CurrentUser returns the authenticated user, fetching it once and reusing
+// the result for the lifetime of the client.
+func (c *Client) CurrentUser(ctx context.Context) (*User, error) {
+	c.userMu.Lock()
+	defer c.userMu.Unlock()
+
+	if c.currentUser != nil {
+		return c.currentUser, nil
+	}
+
+	user, err := c.GetCurrentUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+	c.currentUser = user
+	return user, nil
+}
@@ -3,9 +3,11 @@ package api
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"io"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 )
 
@@ -331,6 +333,45 @@ func TestParseResponse_ReturnsErrorOnInvalidJSON(t *testing.T) {
 	}
 }
 
+func TestParseResponse_EmptyBody(t *testing.T) {
+	type TestData struct {
+		ID string `json:"id"`
+	}
+
+	tests := []struct {
+		name string
+		resp *Response
+	}{
+		{name: "204 No Content", resp: &Response{StatusCode: http.StatusNoContent}},
+		{name: "200 with empty body", resp: &Response{StatusCode: http.StatusOK, Body: []byte("  \n")}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			value, err := ParseResponse[TestData](tt.resp)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if value.ID != "" {
+				t.Errorf("expected the zero value, got %+v", value)
+			}
+
+			ptr, err := ParseResponse[*TestData](tt.resp)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if ptr == nil || ptr.ID != "" {
+				t.Errorf("expected a pointer to the zero value, got %+v", ptr)
+			}
+		})
+	}
+
+	// An empty body is only a success on a 2xx response
+	if _, err := ParseResponse[TestData](&Response{StatusCode: http.StatusNotModified}); err == nil {
+		t.Error("expected an error for an empty 304 response")
+	}
+}
+
 func TestAPIError_ErrorString(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -529,6 +570,92 @@ func TestClientPut_ConvenienceMethod(t *testing.T) {
 	}
 }
 
+func TestClientPatch_ConvenienceMethod(t *testing.T) {
+	var receivedReq *http.Request
+	var receivedBody map[string]string
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		receivedReq = r
+		json.NewDecoder(r.Body).Decode(&receivedBody)
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"updated": true}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(WithBaseURL(server.URL))
+
+	body := map[string]string{"name": "patched"}
+	resp, err := client.Patch(context.Background(), "/items/123", body)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if receivedReq.Method != http.MethodPatch {
+		t.Errorf("expected method PATCH, got %s", receivedReq.Method)
+	}
+	if ct := receivedReq.Header.Get("Content-Type"); ct != "application/json" {
+		t.Errorf("expected Content-Type application/json, got %q", ct)
+	}
+	if receivedBody["name"] != "patched" {
+		t.Errorf("expected body name=patched, got %v", receivedBody)
+	}
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("expected status code %d, got %d", http.StatusOK, resp.StatusCode)
+	}
+}
+
+func TestClientDoStream_ReturnsBodyUnread(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got := r.Header.Get("Accept"); got != "text/plain" {
+			t.Errorf("expected Accept text/plain, got %q", got)
+		}
+		w.Write([]byte("line 1\nline 2\n"))
+	}))
+	defer server.Close()
+
+	client := NewClient(WithBaseURL(server.URL), WithToken("test-token"))
+
+	body, err := client.DoStream(context.Background(), &Request{
+		Method:  http.MethodGet,
+		Path:    "/file",
+		Headers: map[string]string{"Accept": "text/plain"},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer body.Close()
+
+	data, err := io.ReadAll(body)
+	if err != nil {
+		t.Fatalf("failed to read body: %v", err)
+	}
+	if string(data) != "line 1\nline 2\n" {
+		t.Errorf("unexpected body %q", data)
+	}
+}
+
+func TestClientDoStream_ReturnsAPIError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte(`{"error": {"message": "File not found"}}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(WithBaseURL(server.URL), WithToken("test-token"))
+
+	body, err := client.DoStream(context.Background(), &Request{Method: http.MethodGet, Path: "/missing"})
+	if body != nil {
+		t.Error("expected no body on error")
+	}
+	apiErr, ok := err.(*APIError)
+	if !ok {
+		t.Fatalf("expected *APIError, got %T: %v", err, err)
+	}
+	if apiErr.StatusCode != http.StatusNotFound || apiErr.Message != "File not found" {
+		t.Errorf("unexpected error: %+v", apiErr)
+	}
+}
+
 func TestClientDelete_ConvenienceMethod(t *testing.T) {
 	var receivedReq *http.Request
 
@@ -552,3 +679,152 @@ func TestClientDelete_ConvenienceMethod(t *testing.T) {
 		t.Errorf("expected status code %d, got %d", http.StatusNoContent, resp.StatusCode)
 	}
 }
+
+func TestClientCurrentUser_CachesResult(t *testing.T) {
+	requests := 0
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if r.URL.Path != "/user" {
+			t.Errorf("expected path /user, got %s", r.URL.Path)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"uuid": "{user-1}", "display_name": "Test User"}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(WithBaseURL(server.URL), WithToken("test-token"))
+
+	for i := 0; i < 3; i++ {
+		user, err := client.CurrentUser(context.Background())
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if user.UUID != "{user-1}" {
+			t.Errorf("expected uuid {user-1}, got %s", user.UUID)
+		}
+	}
+	if requests != 1 {
+		t.Errorf("expected 1 request for cached user, got %d", requests)
+	}
+
+	if _, err := client.GetCurrentUser(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if requests != 2 {
+		t.Errorf("expected GetCurrentUser to bypass the cache, got %d requests", requests)
+	}
+}
+
+func TestClientGetUser(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.EscapedPath() {
+		case "/users/%7Buser-1%7D":
+			w.Header().Set("Content-Type", "application/json")
+			w.Write([]byte(`{"uuid": "{user-1}", "nickname": "jdoe", "created_on": "2020-05-01T10:00:00+00:00"}`))
+		case "/users/forbidden":
+			w.WriteHeader(http.StatusForbidden)
+			w.Write([]byte(`{"error": {"message": "Access denied"}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"error": {"message": "User not found"}}`))
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(WithBaseURL(server.URL), WithToken("test-token"))
+
+	user, err := client.GetUser(context.Background(), "{user-1}")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if user.Nickname != "jdoe" || user.CreatedOn.Year() != 2020 {
+		t.Errorf("unexpected user: %+v", user)
+	}
+
+	_, err = client.GetUser(context.Background(), "nobody")
+	var apiErr *APIError
+	if !errors.Is(err, ErrUserNotFound) || !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusNotFound {
+		t.Errorf("expected ErrUserNotFound wrapping a 404, got %v", err)
+	}
+
+	_, err = client.GetUser(context.Background(), "forbidden")
+	if err == nil || errors.Is(err, ErrUserNotFound) {
+		t.Errorf("expected a plain API error for 403, got %v", err)
+	}
+}
+
+func TestClientGetRaw_ReturnsErrorResponses(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Request-Id", "abc")
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte(`{"error": {"message": "missing"}}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(WithBaseURL(server.URL), WithToken("test-token"))
+
+	resp, err := client.GetRaw(context.Background(), "/thing", nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if resp.StatusCode != http.StatusNotFound || resp.Status != "404 Not Found" {
+		t.Errorf("unexpected status %d %q", resp.StatusCode, resp.Status)
+	}
+	if resp.Headers.Get("X-Request-Id") != "abc" {
+		t.Errorf("expected response headers, got %v", resp.Headers)
+	}
+	if !strings.Contains(string(resp.Body), "missing") {
+		t.Errorf("expected response body, got %q", resp.Body)
+	}
+}
+
+func TestClientDo_APIErrorRequestID(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Request-Id", "9f1c2b7e")
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write([]byte(`{"error": {"message": "Something went wrong"}}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(WithBaseURL(server.URL), WithToken("test-token"))
+
+	_, err := client.Get(context.Background(), "/thing", nil)
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("expected *APIError, got %v", err)
+	}
+	if apiErr.RequestID != "9f1c2b7e" {
+		t.Errorf("expected request ID 9f1c2b7e, got %q", apiErr.RequestID)
+	}
+	if want := "API error 500: Something went wrong (request ID 9f1c2b7e)"; apiErr.Error() != want {
+		t.Errorf("expected %q, got %q", want, apiErr.Error())
+	}
+
+	data, _ := json.Marshal(apiErr)
+	if !strings.Contains(string(data), `"request_id":"9f1c2b7e"`) {
+		t.Errorf("expected request_id in JSON, got %s", data)
+	}
+}
+
+func TestClientRelativePath(t *testing.T) {
+	client := NewClient(WithBaseURL("https://api.bitbucket.org/2.0"))
+
+	path, query, err := client.RelativePath("https://api.bitbucket.org/2.0/repositories/ws?page=2")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if path != "/repositories/ws" || query.Get("page") != "2" {
+		t.Errorf("got %q %v", path, query)
+	}
+
+	// Escaped slashes in branch names stay escaped
+	path, _, err = client.RelativePath("https://api.bitbucket.org/2.0/repositories/ws/repo/diffstat/feature%2Fx..main?page=2")
+	if err != nil || path != "/repositories/ws/repo/diffstat/feature%2Fx..main" {
+		t.Errorf("got %q, %v, want the path still escaped", path, err)
+	}
+
+	if _, _, err := client.RelativePath("https://evil.example.com/2.0/repositories/ws"); err == nil {
+		t.Error("expected an error for a URL on another host")
+	}
+}
@@ -0,0 +1,87 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// CommitListOptions are options for listing commits
+type CommitListOptions struct {
+	Branch string    // Branch or commit to list history from (default: main branch)
+	Since  time.Time // Only commits dated at or after this time
+	Until  time.Time // Only commits dated before this time
+	Page   int       // Page number
+	Limit  int       // Number of items per page (pagelen)
+}
+
+// ListCommits lists commits in a repository, newest first
+func (c *Client) ListCommits(ctx context.Context, workspace, repoSlug string, opts *CommitListOptions) (*Paginated[Commit], error) {
+	path := fmt.Sprintf("/repositories/%s/%s/commits", workspace, repoSlug)
+
+	query := url.Values{}
+	if opts != nil {
+		if opts.Branch != "" {
+			path += "/" + url.PathEscape(opts.Branch)
+		}
+		var q Query
+		q.DateRange("date", opts.Since, opts.Until)
+		if s := q.String(); s != "" {
+			query.Set("q", s)
+		}
+		if opts.Page > 0 {
+			query.Set("page", strconv.Itoa(opts.Page))
+		}
+		if opts.Limit > 0 {
+			query.Set("pagelen", strconv.Itoa(opts.Limit))
+		}
+	}
+
+	resp, err := c.Get(ctx, path, query)
+	if err != nil {
+		return nil, err
+	}
+
+	return ParseResponse[*Paginated[Commit]](resp)
+}
+
+// GetCommit gets a single commit by hash, or by any ref Bitbucket can
+// resolve such as a branch or tag
+func (c *Client) GetCommit(ctx context.Context, workspace, repoSlug, hash string) (*Commit, error) {
+	path := fmt.Sprintf("/repositories/%s/%s/commit/%s", workspace, repoSlug, url.PathEscape(hash))
+
+	resp, err := c.Get(ctx, path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return ParseResponse[*Commit](resp)
+}
+
+// ListCommitComments lists the comments on a commit. They have the same
+// shape as pull request comments.
+func (c *Client) ListCommitComments(ctx context.Context, workspace, repoSlug, hash string) (*Paginated[PRComment], error) {
+	path := fmt.Sprintf("/repositories/%s/%s/commit/%s/comments", workspace, repoSlug, url.PathEscape(hash))
+
+	resp, err := c.Get(ctx, path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return ParseResponse[*Paginated[PRComment]](resp)
+}
+
+// AddCommitComment adds a comment to a commit. As on pull requests, Path
+// and Line in opts make it an inline comment and ParentID a reply.
+func (c *Client) AddCommitComment(ctx context.Context, workspace, repoSlug, hash string, opts *AddPRCommentOptions) (*PRComment, error) {
+	path := fmt.Sprintf("/repositories/%s/%s/commit/%s/comments", workspace, repoSlug, url.PathEscape(hash))
+
+	resp, err := c.Post(ctx, path, newCommentRequest(opts))
+	if err != nil {
+		return nil, err
+	}
+
+	return ParseResponse[*PRComment](resp)
+}
@@ -0,0 +1,173 @@
+package api
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestListCommits(t *testing.T) {
+	since := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	until := time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name      string
+		opts      *CommitListOptions
+		wantPath  string
+		wantQuery string
+	}{
+		{
+			name:     "no options",
+			opts:     nil,
+			wantPath: "/repositories/ws/repo/commits",
+		},
+		{
+			name:      "branch and date window",
+			opts:      &CommitListOptions{Branch: "release/1.0", Since: since, Until: until, Limit: 50},
+			wantPath:  "/repositories/ws/repo/commits/release/1.0",
+			wantQuery: "date >= 2024-01-01T00:00:00Z AND date < 2024-02-01T00:00:00Z",
+		},
+		{
+			name:      "since only, converted to UTC",
+			opts:      &CommitListOptions{Since: time.Date(2024, 1, 1, 2, 0, 0, 0, time.FixedZone("CEST", 2*60*60))},
+			wantPath:  "/repositories/ws/repo/commits",
+			wantQuery: "date >= 2024-01-01T00:00:00Z",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path != tt.wantPath {
+					t.Errorf("expected path %q, got %q", tt.wantPath, r.URL.Path)
+				}
+				if got := r.URL.Query().Get("q"); got != tt.wantQuery {
+					t.Errorf("expected q=%q, got %q", tt.wantQuery, got)
+				}
+				w.Header().Set("Content-Type", "application/json")
+				w.Write([]byte(`{"values": [{"hash": "abc123", "message": "Fix bug\n", "date": "2024-01-15T10:00:00+00:00", "author": {"raw": "Dev <dev@example.com>"}}]}`))
+			}))
+			defer server.Close()
+
+			client := NewClient(WithBaseURL(server.URL), WithToken("test-token"))
+			result, err := client.ListCommits(context.Background(), "ws", "repo", tt.opts)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if len(result.Values) != 1 || result.Values[0].Hash != "abc123" || result.Values[0].Author.Raw != "Dev <dev@example.com>" {
+				t.Errorf("unexpected commits: %+v", result.Values)
+			}
+		})
+	}
+}
+
+func TestGetCommit(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/repositories/ws/repo/commit/abc123" {
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"hash": "abc123", "message": "Signed", "signature": {"type": "ssh", "verified": true, "key_id": "SHA256:xyz"}}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(WithBaseURL(server.URL), WithToken("test-token"))
+
+	commit, err := client.GetCommit(context.Background(), "ws", "repo", "abc123")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if commit.Signature == nil || !commit.Signature.Verified || commit.Signature.Type != "ssh" {
+		t.Errorf("expected a verified ssh signature, got %+v", commit.Signature)
+	}
+}
+
+func TestGetCommit_Unsigned(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"hash": "abc123", "message": "Unsigned"}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(WithBaseURL(server.URL), WithToken("test-token"))
+
+	commit, err := client.GetCommit(context.Background(), "ws", "repo", "abc123")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if commit.Signature != nil {
+		t.Errorf("expected no signature, got %+v", commit.Signature)
+	}
+}
+
+func TestListCommitComments(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet || r.URL.Path != "/repositories/ws/repo/commit/abc123/comments" {
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"values": [{"id": 7, "content": {"raw": "Why?"}, "inline": {"to": 12, "path": "main.go"}}]}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(WithBaseURL(server.URL), WithToken("test-token"))
+
+	result, err := client.ListCommitComments(context.Background(), "ws", "repo", "abc123")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(result.Values) != 1 || result.Values[0].Content.Raw != "Why?" || result.Values[0].Inline == nil || result.Values[0].Inline.Path != "main.go" {
+		t.Errorf("unexpected comments: %+v", result.Values)
+	}
+}
+
+func TestAddCommitComment(t *testing.T) {
+	tests := []struct {
+		name string
+		opts *AddPRCommentOptions
+		want string
+	}{
+		{
+			name: "general comment",
+			opts: &AddPRCommentOptions{Content: "Nice"},
+			want: `{"content":{"raw":"Nice"}}`,
+		},
+		{
+			name: "inline comment",
+			opts: &AddPRCommentOptions{Content: "Off by one", Path: "src/main.go", Line: 42},
+			want: `{"content":{"raw":"Off by one"},"inline":{"to":42,"path":"src/main.go"}}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.Method != http.MethodPost || r.URL.Path != "/repositories/ws/repo/commit/abc123/comments" {
+					t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+				}
+				body, _ := io.ReadAll(r.Body)
+				if got := strings.TrimSpace(string(body)); got != tt.want {
+					t.Errorf("body = %s, want %s", got, tt.want)
+				}
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(http.StatusCreated)
+				w.Write([]byte(`{"id": 8, "content": {"raw": "Nice"}}`))
+			}))
+			defer server.Close()
+
+			client := NewClient(WithBaseURL(server.URL), WithToken("test-token"))
+
+			comment, err := client.AddCommitComment(context.Background(), "ws", "repo", "abc123", tt.opts)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if comment.ID != 8 {
+				t.Errorf("expected comment 8, got %d", comment.ID)
+			}
+		})
+	}
+}
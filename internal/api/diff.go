@@ -0,0 +1,51 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/url"
+	"strings"
+)
+
+// escapeDiffSpec escapes each ref of a diff spec, so branch names such as
+// feature/login stay a single path segment. Git refs can't contain "..",
+// so splitting on it is safe.
+func escapeDiffSpec(spec string) string {
+	refs := strings.Split(spec, "..")
+	for i, ref := range refs {
+		refs[i] = url.PathEscape(ref)
+	}
+	return strings.Join(refs, "..")
+}
+
+// GetDiff returns the unified diff described by spec as a stream. A spec of
+// the form source..destination, where either side is a branch, tag or
+// commit, gives the changes on source since it diverged from destination,
+// as a pull request from source into destination would show them. A single
+// commit gives the changes it made to its first parent. The caller must
+// close the returned reader.
+func (c *Client) GetDiff(ctx context.Context, workspace, repoSlug, spec string) (io.ReadCloser, error) {
+	path := fmt.Sprintf("/repositories/%s/%s/diff/%s", workspace, repoSlug, escapeDiffSpec(spec))
+
+	return c.DoStream(ctx, plainTextRequest(path))
+}
+
+// GetDiffStat retrieves per-file change counts for the diff described by
+// spec, which has the same form as for GetDiff, following every page
+func (c *Client) GetDiffStat(ctx context.Context, workspace, repoSlug, spec string) ([]DiffStatEntry, error) {
+	path := fmt.Sprintf("/repositories/%s/%s/diffstat/%s", workspace, repoSlug, escapeDiffSpec(spec))
+
+	// As for pull requests, a large page keeps the common case to a single
+	// request
+	query := url.Values{}
+	query.Set("pagelen", "500")
+
+	return Collect(Iterate(ctx, c, func(ctx context.Context) (*Paginated[DiffStatEntry], error) {
+		resp, err := c.Get(ctx, path, query)
+		if err != nil {
+			return nil, err
+		}
+		return ParseResponse[*Paginated[DiffStatEntry]](resp)
+	}))
+}
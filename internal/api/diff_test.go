@@ -0,0 +1,60 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestGetDiff(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// The slash in the branch name must stay escaped
+		if r.URL.EscapedPath() != "/repositories/ws/repo/diff/feature%2Flogin..main" {
+			t.Errorf("unexpected path: %s", r.URL.EscapedPath())
+		}
+		if accept := r.Header.Get("Accept"); accept != "text/plain" {
+			t.Errorf("expected Accept text/plain, got %q", accept)
+		}
+		w.Write([]byte("diff --git a/x b/x\n"))
+	}))
+	defer server.Close()
+
+	client := NewClient(WithBaseURL(server.URL), WithToken("test-token"))
+	diff, err := client.GetDiff(context.Background(), "ws", "repo", "feature/login..main")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer diff.Close()
+
+	data, _ := io.ReadAll(diff)
+	if string(data) != "diff --git a/x b/x\n" {
+		t.Errorf("unexpected diff: %q", data)
+	}
+}
+
+func TestGetDiffStat(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.EscapedPath() != "/repositories/ws/repo/diffstat/v1.2..release%2F1.x" {
+			t.Errorf("unexpected path: %s", r.URL.EscapedPath())
+		}
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Query().Get("page") == "2" {
+			w.Write([]byte(`{"values": [{"status": "added", "lines_added": 5, "new": {"path": "new.go"}}]}`))
+			return
+		}
+		fmt.Fprintf(w, `{"values": [{"status": "modified", "lines_added": 2, "lines_removed": 1, "new": {"path": "main.go"}}], "next": "http://%s%s?page=2"}`, r.Host, r.URL.EscapedPath())
+	}))
+	defer server.Close()
+
+	client := NewClient(WithBaseURL(server.URL), WithToken("test-token"))
+	entries, err := client.GetDiffStat(context.Background(), "ws", "repo", "v1.2..release/1.x")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(entries) != 2 || entries[0].Path() != "main.go" || entries[0].LinesAdded != 2 || entries[1].Path() != "new.go" {
+		t.Errorf("expected the files from both pages, got %+v", entries)
+	}
+}
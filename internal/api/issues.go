@@ -3,8 +3,12 @@ package api
 import (
 	"context"
 	"fmt"
+	"io"
+	"net/http"
 	"net/url"
+	"slices"
 	"strconv"
+	"strings"
 	"time"
 )
 
@@ -41,6 +45,10 @@ type Issue struct {
 	Links      *IssueLinks `json:"links,omitempty"`
 }
 
+// IssueStates lists the states an issue can be in, in the order Bitbucket's
+// issue tracker presents them
+var IssueStates = []string{"new", "open", "resolved", "on hold", "invalid", "duplicate", "wontfix", "closed"}
+
 // IssueCommentLinks contains links related to an issue comment
 type IssueCommentLinks struct {
 	Self *Link `json:"self,omitempty"`
@@ -62,7 +70,7 @@ type IssueListOptions struct {
 	State    string // Filter by state
 	Kind     string // Filter by kind
 	Priority string // Filter by priority
-	Assignee string // Filter by assignee
+	Assignee string // Filter by assignee username or UUID
 	Q        string // Search query
 	Sort     string // Sort field
 	Page     int    // Page number
@@ -128,36 +136,26 @@ func (c *Client) ListIssues(ctx context.Context, workspace, repoSlug string, opt
 
 	query := url.Values{}
 	if opts != nil {
-		// Build query filter using Bitbucket query language
-		var q string
+		// A raw query replaces the individual filters
+		var q Query
 		if opts.Q != "" {
-			q = opts.Q
+			q.Raw(opts.Q)
 		} else {
-			var filters []string
 			if opts.State != "" {
-				filters = append(filters, fmt.Sprintf("state=\"%s\"", opts.State))
+				q.Equals("state", opts.State)
 			}
 			if opts.Kind != "" {
-				filters = append(filters, fmt.Sprintf("kind=\"%s\"", opts.Kind))
+				q.Equals("kind", opts.Kind)
 			}
 			if opts.Priority != "" {
-				filters = append(filters, fmt.Sprintf("priority=\"%s\"", opts.Priority))
+				q.Equals("priority", opts.Priority)
 			}
 			if opts.Assignee != "" {
-				filters = append(filters, fmt.Sprintf("assignee.username=\"%s\"", opts.Assignee))
-			}
-			if len(filters) > 0 {
-				for i, f := range filters {
-					if i == 0 {
-						q = f
-					} else {
-						q += " AND " + f
-					}
-				}
+				q.User("assignee", opts.Assignee)
 			}
 		}
-		if q != "" {
-			query.Set("q", q)
+		if s := q.String(); s != "" {
+			query.Set("q", s)
 		}
 
 		if opts.Sort != "" {
@@ -256,6 +254,23 @@ func (c *Client) UpdateIssue(ctx context.Context, workspace, repoSlug string, is
 	return ParseResponse[*Issue](resp)
 }
 
+// UpdateIssueState moves an issue to a new state and returns the updated
+// issue. The state is checked against IssueStates before any request is made.
+func (c *Client) UpdateIssueState(ctx context.Context, workspace, repoSlug string, issueID int, state string) (*Issue, error) {
+	if !slices.Contains(IssueStates, state) {
+		return nil, fmt.Errorf("invalid issue state %q: must be one of %s", state, strings.Join(IssueStates, ", "))
+	}
+
+	path := fmt.Sprintf("/repositories/%s/%s/issues/%d", workspace, repoSlug, issueID)
+
+	resp, err := c.Put(ctx, path, map[string]string{"state": state})
+	if err != nil {
+		return nil, err
+	}
+
+	return ParseResponse[*Issue](resp)
+}
+
 // DeleteIssue deletes an issue
 func (c *Client) DeleteIssue(ctx context.Context, workspace, repoSlug string, issueID int) error {
 	path := fmt.Sprintf("/repositories/%s/%s/issues/%d", workspace, repoSlug, issueID)
@@ -290,3 +305,35 @@ func (c *Client) CreateIssueComment(ctx context.Context, workspace, repoSlug str
 
 	return ParseResponse[*IssueComment](resp)
 }
+
+// IssueAttachment is a file attached to an issue
+type IssueAttachment struct {
+	Name  string `json:"name"`
+	Links struct {
+		Self Link `json:"self"`
+	} `json:"links"`
+}
+
+// ListIssueAttachments lists the files attached to an issue
+func (c *Client) ListIssueAttachments(ctx context.Context, workspace, repoSlug string, issueID int) (*Paginated[IssueAttachment], error) {
+	path := fmt.Sprintf("/repositories/%s/%s/issues/%d/attachments", workspace, repoSlug, issueID)
+
+	resp, err := c.Get(ctx, path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return ParseResponse[*Paginated[IssueAttachment]](resp)
+}
+
+// GetIssueAttachment returns the content of a file attached to an issue as
+// a stream. A missing attachment is an *APIError with status 404. The
+// caller must close the returned reader.
+func (c *Client) GetIssueAttachment(ctx context.Context, workspace, repoSlug string, issueID int, filename string) (io.ReadCloser, error) {
+	path := fmt.Sprintf("/repositories/%s/%s/issues/%d/attachments/%s", workspace, repoSlug, issueID, url.PathEscape(filename))
+
+	return c.DoStream(ctx, &Request{
+		Method: http.MethodGet,
+		Path:   path,
+	})
+}
@@ -1579,3 +1579,90 @@ func TestListIssuesPagination(t *testing.T) {
 		t.Errorf("expected 2 values, got %d", len(result.Values))
 	}
 }
+
+func TestUpdateIssueState(t *testing.T) {
+	var receivedBody map[string]string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPut {
+			t.Errorf("expected PUT method, got %s", r.Method)
+		}
+		if r.URL.Path != "/repositories/ws/repo/issues/42" {
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+		if err := json.NewDecoder(r.Body).Decode(&receivedBody); err != nil {
+			t.Fatalf("failed to decode request body: %v", err)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"id": 42, "state": "duplicate"}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(WithBaseURL(server.URL), WithToken("test-token"))
+
+	issue, err := client.UpdateIssueState(context.Background(), "ws", "repo", 42, "duplicate")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if receivedBody["state"] != "duplicate" || len(receivedBody) != 1 {
+		t.Errorf("request body = %v, want only the state", receivedBody)
+	}
+	if issue.State != "duplicate" {
+		t.Errorf("issue state = %q, want duplicate", issue.State)
+	}
+
+	if _, err := client.UpdateIssueState(context.Background(), "ws", "repo", 42, "done"); err == nil {
+		t.Error("expected an error for an unknown state")
+	}
+}
+
+func TestListIssueAttachments(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/repositories/ws/repo/issues/7/attachments" {
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"values": [{"name": "crash log.txt"}, {"name": "screenshot.png"}]}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(WithBaseURL(server.URL), WithToken("test-token"))
+
+	result, err := client.ListIssueAttachments(context.Background(), "ws", "repo", 7)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(result.Values) != 2 || result.Values[0].Name != "crash log.txt" {
+		t.Errorf("unexpected attachments: %+v", result.Values)
+	}
+}
+
+func TestGetIssueAttachment(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.EscapedPath() {
+		case "/repositories/ws/repo/issues/7/attachments/crash%20log%20%231.txt":
+			w.Write([]byte("panic: oops"))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"type": "error", "error": {"message": "Not found"}}`))
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(WithBaseURL(server.URL), WithToken("test-token"))
+
+	body, err := client.GetIssueAttachment(context.Background(), "ws", "repo", 7, "crash log #1.txt")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer body.Close()
+	content, _ := io.ReadAll(body)
+	if string(content) != "panic: oops" {
+		t.Errorf("content = %q", content)
+	}
+
+	_, err = client.GetIssueAttachment(context.Background(), "ws", "repo", 7, "missing.txt")
+	apiErr, ok := err.(*APIError)
+	if !ok || apiErr.StatusCode != http.StatusNotFound {
+		t.Errorf("error = %v, want a 404 APIError", err)
+	}
+}
@@ -0,0 +1,82 @@
+package api
+
+import (
+	"context"
+	"iter"
+)
+
+// Iterate yields the values of a paginated listing one at a time. first
+// fetches the first page; the pages after it are fetched by following their
+// next links, each only once the values before it have been used. Nothing is
+// fetched until the range starts, and breaking out of the range stops the
+// fetching, so a caller that needs the first n values makes no more requests
+// than those values take.
+//
+// A failed fetch is yielded as an error with the zero value and ends the
+// iteration.
+func Iterate[T any](ctx context.Context, c *Client, first func(context.Context) (*Paginated[T], error)) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		page, err := first(ctx)
+		for {
+			if err != nil {
+				var zero T
+				yield(zero, err)
+				return
+			}
+			if page == nil {
+				return
+			}
+			for _, v := range page.Values {
+				if !yield(v, nil) {
+					return
+				}
+			}
+			page, err = NextPage(ctx, c, page)
+		}
+	}
+}
+
+// Collect gathers every value of an iteration, such as one from Iterate,
+// stopping at the first error
+func Collect[T any](seq iter.Seq2[T, error]) ([]T, error) {
+	var values []T
+	for v, err := range seq {
+		if err != nil {
+			return nil, err
+		}
+		values = append(values, v)
+	}
+	return values, nil
+}
+
+// IterPullRequests iterates over the pull requests of a repository,
+// fetching pages as they are needed. opts.Limit sets the page size.
+func (c *Client) IterPullRequests(ctx context.Context, workspace, repoSlug string, opts *PRListOptions) iter.Seq2[PullRequest, error] {
+	return Iterate(ctx, c, func(ctx context.Context) (*Paginated[PullRequest], error) {
+		return c.ListPullRequests(ctx, workspace, repoSlug, opts)
+	})
+}
+
+// IterRepositories iterates over the repositories of a workspace, fetching
+// pages as they are needed. opts.Limit sets the page size.
+func (c *Client) IterRepositories(ctx context.Context, workspace string, opts *RepositoryListOptions) iter.Seq2[RepositoryFull, error] {
+	return Iterate(ctx, c, func(ctx context.Context) (*Paginated[RepositoryFull], error) {
+		return c.ListRepositories(ctx, workspace, opts)
+	})
+}
+
+// IterBranches iterates over the branches of a repository, fetching pages
+// as they are needed. opts.Limit sets the page size.
+func (c *Client) IterBranches(ctx context.Context, workspace, repoSlug string, opts *BranchListOptions) iter.Seq2[BranchFull, error] {
+	return Iterate(ctx, c, func(ctx context.Context) (*Paginated[BranchFull], error) {
+		return c.ListBranches(ctx, workspace, repoSlug, opts)
+	})
+}
+
+// IterIssues iterates over the issues of a repository, fetching pages as
+// they are needed. opts.Limit sets the page size.
+func (c *Client) IterIssues(ctx context.Context, workspace, repoSlug string, opts *IssueListOptions) iter.Seq2[Issue, error] {
+	return Iterate(ctx, c, func(ctx context.Context) (*Paginated[Issue], error) {
+		return c.ListIssues(ctx, workspace, repoSlug, opts)
+	})
+}
@@ -0,0 +1,136 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync/atomic"
+	"testing"
+)
+
+// newPagedServer serves pull requests 1..total, two per page, and counts
+// the requests made
+func newPagedServer(t *testing.T, total int, requests *atomic.Int32) *Client {
+	t.Helper()
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		page := 1
+		if p := r.URL.Query().Get("page"); p != "" {
+			page, _ = strconv.Atoi(p)
+		}
+		if page > 2 && total == -1 {
+			w.WriteHeader(http.StatusInternalServerError)
+			fmt.Fprint(w, `{"type": "error", "error": {"message": "boom"}}`)
+			return
+		}
+
+		first := (page-1)*2 + 1
+		values := ""
+		for id := first; id < first+2 && (total < 0 || id <= total); id++ {
+			if values != "" {
+				values += ","
+			}
+			values += fmt.Sprintf(`{"id": %d}`, id)
+		}
+		next := ""
+		if total < 0 || first+2 <= total {
+			next = fmt.Sprintf(`"next": "%s/repositories/ws/repo/pullrequests?page=%d",`, server.URL, page+1)
+		}
+		fmt.Fprintf(w, `{%s "values": [%s]}`, next, values)
+	}))
+	t.Cleanup(server.Close)
+	return NewClient(WithBaseURL(server.URL), WithToken("test-token"))
+}
+
+func TestIterPullRequests(t *testing.T) {
+	var requests atomic.Int32
+	client := newPagedServer(t, 5, &requests)
+
+	var ids []int64
+	for pr, err := range client.IterPullRequests(context.Background(), "ws", "repo", nil) {
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		ids = append(ids, pr.ID)
+	}
+
+	if fmt.Sprint(ids) != "[1 2 3 4 5]" {
+		t.Errorf("ids = %v, want [1 2 3 4 5]", ids)
+	}
+	if n := requests.Load(); n != 3 {
+		t.Errorf("made %d requests, want 3", n)
+	}
+}
+
+func TestIterPullRequests_EarlyStop(t *testing.T) {
+	var requests atomic.Int32
+	client := newPagedServer(t, 100, &requests)
+
+	seq := client.IterPullRequests(context.Background(), "ws", "repo", nil)
+	if n := requests.Load(); n != 0 {
+		t.Fatalf("made %d requests before ranging, want 0", n)
+	}
+
+	count := 0
+	for _, err := range seq {
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		count++
+		if count == 3 {
+			break
+		}
+	}
+
+	// The third value is on the second page; the third page is never fetched
+	if n := requests.Load(); n != 2 {
+		t.Errorf("made %d requests, want 2", n)
+	}
+}
+
+func TestIterPullRequests_Error(t *testing.T) {
+	var requests atomic.Int32
+	// Pages past the second fail
+	client := newPagedServer(t, -1, &requests)
+
+	var ids []int64
+	var gotErr error
+	for pr, err := range client.IterPullRequests(context.Background(), "ws", "repo", nil) {
+		if err != nil {
+			gotErr = err
+			continue
+		}
+		ids = append(ids, pr.ID)
+	}
+
+	if len(ids) != 4 {
+		t.Errorf("got %d values before the error, want 4", len(ids))
+	}
+	if gotErr == nil {
+		t.Error("expected the failed page to be yielded as an error")
+	}
+	if n := requests.Load(); n != 3 {
+		t.Errorf("made %d requests, want 3; the iteration should end at the error", n)
+	}
+}
+
+func TestCollect(t *testing.T) {
+	var requests atomic.Int32
+	client := newPagedServer(t, 5, &requests)
+
+	prs, err := Collect(client.IterPullRequests(context.Background(), "ws", "repo", nil))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(prs) != 5 || prs[4].ID != 5 {
+		t.Errorf("got %+v, want pull requests 1 to 5", prs)
+	}
+
+	client = newPagedServer(t, -1, &requests)
+	if prs, err := Collect(client.IterPullRequests(context.Background(), "ws", "repo", nil)); err == nil || prs != nil {
+		t.Errorf("Collect() = %d values, %v, want the failed page's error", len(prs), err)
+	}
+}
@@ -0,0 +1,174 @@
+package api
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// MergeCheck is a condition a pull request has to meet before it is merged.
+// Bitbucket Cloud doesn't report merge checks on the pull request, so they
+// are worked out by EvaluateMergeChecks from the destination branch's
+// restrictions and the state of the pull request.
+type MergeCheck struct {
+	Name     string `json:"name"`
+	Passed   bool   `json:"passed"`
+	Blocking bool   `json:"blocking"` // Bitbucket refuses the merge while the check fails
+	Detail   string `json:"detail,omitempty"`
+}
+
+// Branch restriction kinds that are merge checks
+const (
+	RestrictionEnforceMergeChecks   = "enforce_merge_checks"
+	RestrictionRequireApprovals     = "require_approvals_to_merge"
+	RestrictionRequirePassingBuilds = "require_passing_builds_to_merge"
+	RestrictionRequireTasksComplete = "require_tasks_to_be_completed"
+	RestrictionRequireNoChanges     = "require_no_changes_requested"
+)
+
+// EvaluateMergeChecks works out the merge checks of a pull request.
+// restrictions are the repository's branch restrictions; only those matching
+// the destination branch by glob count. diffStat is the pull request's
+// diffstat and statuses the build statuses of its latest commit.
+//
+// A check for merge conflicts is always included and is always blocking. The
+// checks from branch restrictions are only blocking when the branch also has
+// an enforce_merge_checks restriction, which needs a Premium plan; otherwise
+// Bitbucket shows them as warnings. A pull request whose branch has no
+// restrictions only gets the conflict check.
+func EvaluateMergeChecks(pr *PullRequest, restrictions []BranchRestriction, diffStat []DiffStatEntry, statuses []CommitStatus) []MergeCheck {
+	checks := []MergeCheck{conflictCheck(diffStat)}
+
+	// The strictest value wins when several patterns match the branch
+	required := map[string]int{}
+	enforced := false
+	for _, r := range restrictions {
+		if !restrictionMatches(r, pr.Destination.Branch.Name) {
+			continue
+		}
+		if r.Kind == RestrictionEnforceMergeChecks {
+			enforced = true
+			continue
+		}
+		value := 0
+		if r.Value != nil {
+			value = *r.Value
+		}
+		if current, ok := required[r.Kind]; !ok || value > current {
+			required[r.Kind] = value
+		}
+	}
+
+	if n, ok := required[RestrictionRequireApprovals]; ok {
+		approved := 0
+		for _, p := range pr.Participants {
+			if p.Approved {
+				approved++
+			}
+		}
+		checks = append(checks, MergeCheck{
+			Name:   "Minimum approvals",
+			Passed: approved >= n,
+			Detail: fmt.Sprintf("%d of %d approvals", approved, n),
+		})
+	}
+
+	if _, ok := required[RestrictionRequireNoChanges]; ok {
+		var requesters []string
+		for _, p := range pr.Participants {
+			if p.State == "changes_requested" {
+				requesters = append(requesters, p.User.DisplayName)
+			}
+		}
+		check := MergeCheck{Name: "No changes requested", Passed: len(requesters) == 0}
+		if !check.Passed {
+			check.Detail = "changes requested by " + strings.Join(requesters, ", ")
+		}
+		checks = append(checks, check)
+	}
+
+	if _, ok := required[RestrictionRequireTasksComplete]; ok {
+		check := MergeCheck{Name: "All tasks resolved", Passed: pr.TaskCount == 0}
+		if !check.Passed {
+			check.Detail = fmt.Sprintf("%d open tasks", pr.TaskCount)
+			if pr.TaskCount == 1 {
+				check.Detail = "1 open task"
+			}
+		}
+		checks = append(checks, check)
+	}
+
+	if n, ok := required[RestrictionRequirePassingBuilds]; ok {
+		checks = append(checks, buildsCheck(statuses, n))
+	}
+
+	for i := 1; i < len(checks); i++ {
+		checks[i].Blocking = enforced
+	}
+	return checks
+}
+
+// conflictCheck reports the files of a diffstat with merge conflicts
+func conflictCheck(diffStat []DiffStatEntry) MergeCheck {
+	var conflicted []string
+	for _, e := range diffStat {
+		// e.g. "merge conflict", "local deleted", "rename conflict"
+		if strings.Contains(e.Status, "conflict") || strings.HasSuffix(e.Status, " deleted") {
+			conflicted = append(conflicted, e.Path())
+		}
+	}
+
+	check := MergeCheck{Name: "No merge conflicts", Passed: len(conflicted) == 0, Blocking: true}
+	if !check.Passed {
+		check.Detail = "conflicts in " + strings.Join(conflicted, ", ")
+	}
+	return check
+}
+
+// buildsCheck requires n successful builds and none failed or in progress,
+// as Bitbucket does
+func buildsCheck(statuses []CommitStatus, n int) MergeCheck {
+	successful, unfinished, failed := 0, 0, 0
+	for _, s := range statuses {
+		switch s.State {
+		case "SUCCESSFUL":
+			successful++
+		case "INPROGRESS":
+			unfinished++
+		default:
+			failed++
+		}
+	}
+
+	check := MergeCheck{Name: "Passing builds", Passed: successful >= n && unfinished == 0 && failed == 0}
+	switch {
+	case failed > 0:
+		check.Detail = fmt.Sprintf("%d failed", failed)
+	case unfinished > 0:
+		check.Detail = fmt.Sprintf("%d in progress", unfinished)
+	default:
+		check.Detail = fmt.Sprintf("%d of %d successful builds", successful, n)
+	}
+	return check
+}
+
+// restrictionMatches reports whether a glob restriction applies to branch.
+// Restrictions on branch types from the branching model can't be resolved
+// to branch names here and never match.
+func restrictionMatches(r BranchRestriction, branch string) bool {
+	if r.BranchMatchKind != "" && r.BranchMatchKind != "glob" {
+		return false
+	}
+	return globMatch(r.Pattern, branch)
+}
+
+// globMatch matches a branch against a Bitbucket branch pattern, where *
+// matches any run of characters, slashes included
+func globMatch(pattern, branch string) bool {
+	parts := strings.Split(pattern, "*")
+	for i, part := range parts {
+		parts[i] = regexp.QuoteMeta(part)
+	}
+	re, err := regexp.Compile("^" + strings.Join(parts, ".*") + "$")
+	return err == nil && re.MatchString(branch)
+}
@@ -0,0 +1,87 @@
+package api
+
+import (
+	"reflect"
+	"testing"
+)
+
+func intPtr(n int) *int { return &n }
+
+func TestEvaluateMergeChecks(t *testing.T) {
+	pr := &PullRequest{
+		Destination: PRRef{Branch: Branch{Name: "release/1.0"}},
+		Participants: []Participant{
+			{User: User{DisplayName: "Ann"}, Approved: true},
+			{User: User{DisplayName: "Bob"}, State: "changes_requested"},
+		},
+		TaskCount: 1,
+	}
+	restrictions := []BranchRestriction{
+		{Kind: RestrictionRequireApprovals, BranchMatchKind: "glob", Pattern: "release/*", Value: intPtr(1)},
+		{Kind: RestrictionRequireApprovals, BranchMatchKind: "glob", Pattern: "*", Value: intPtr(2)},
+		{Kind: RestrictionRequireNoChanges, BranchMatchKind: "glob", Pattern: "release/*"},
+		{Kind: RestrictionRequireTasksComplete, BranchMatchKind: "glob", Pattern: "main"},
+		{Kind: RestrictionRequirePassingBuilds, BranchMatchKind: "branching_model", BranchType: "release", Value: intPtr(1)},
+		{Kind: RestrictionEnforceMergeChecks, BranchMatchKind: "glob", Pattern: "release/*"},
+	}
+	diffStat := []DiffStatEntry{
+		{Status: "modified", New: &DiffStatFile{Path: "a.go"}},
+		{Status: "merge conflict", New: &DiffStatFile{Path: "b.go"}},
+	}
+
+	got := EvaluateMergeChecks(pr, restrictions, diffStat, nil)
+	want := []MergeCheck{
+		{Name: "No merge conflicts", Blocking: true, Detail: "conflicts in b.go"},
+		{Name: "Minimum approvals", Blocking: true, Detail: "1 of 2 approvals"},
+		{Name: "No changes requested", Blocking: true, Detail: "changes requested by Bob"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("EvaluateMergeChecks() =\n%+v\nwant\n%+v", got, want)
+	}
+}
+
+func TestEvaluateMergeChecks_NotEnforced(t *testing.T) {
+	pr := &PullRequest{Destination: PRRef{Branch: Branch{Name: "main"}}, TaskCount: 2}
+	restrictions := []BranchRestriction{
+		{Kind: RestrictionRequireTasksComplete, Pattern: "main"},
+		{Kind: RestrictionRequirePassingBuilds, Pattern: "main", Value: intPtr(1)},
+	}
+	statuses := []CommitStatus{{State: "SUCCESSFUL"}, {State: "INPROGRESS"}}
+
+	got := EvaluateMergeChecks(pr, restrictions, nil, statuses)
+	want := []MergeCheck{
+		{Name: "No merge conflicts", Passed: true, Blocking: true},
+		{Name: "All tasks resolved", Detail: "2 open tasks"},
+		{Name: "Passing builds", Detail: "1 in progress"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("EvaluateMergeChecks() =\n%+v\nwant\n%+v", got, want)
+	}
+}
+
+func TestEvaluateMergeChecks_NoRestrictions(t *testing.T) {
+	pr := &PullRequest{Destination: PRRef{Branch: Branch{Name: "main"}}}
+	got := EvaluateMergeChecks(pr, nil, nil, nil)
+	if len(got) != 1 || !got[0].Passed {
+		t.Errorf("EvaluateMergeChecks() = %+v, want only a passing conflict check", got)
+	}
+}
+
+func TestGlobMatch(t *testing.T) {
+	tests := []struct {
+		pattern, branch string
+		want            bool
+	}{
+		{"main", "main", true},
+		{"main", "main2", false},
+		{"*", "feature/a/b", true},
+		{"release/*", "release/1.0", true},
+		{"release/*", "hotfix/1.0", false},
+		{"v1.*", "v1x0", false},
+	}
+	for _, tt := range tests {
+		if got := globMatch(tt.pattern, tt.branch); got != tt.want {
+			t.Errorf("globMatch(%q, %q) = %v, want %v", tt.pattern, tt.branch, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,116 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// OAuthTokenURL is the Bitbucket Cloud endpoint that issues OAuth tokens
+const OAuthTokenURL = "https://bitbucket.org/site/oauth2/access_token"
+
+// OAuthToken is a token issued by the OAuth token endpoint
+type OAuthToken struct {
+	AccessToken  string `json:"access_token"`
+	TokenType    string `json:"token_type"`
+	ExpiresIn    int    `json:"expires_in"`
+	RefreshToken string `json:"refresh_token"`
+	Scopes       string `json:"scopes"`
+}
+
+// TokenRefresher returns a new access token after the current one was
+// rejected
+type TokenRefresher func(ctx context.Context) (string, error)
+
+// WithTokenRefresher lets the client recover from an access token that
+// expired after it was loaded. When a request is rejected with 401, the
+// client calls refresh once and retries the request with the new token; a
+// second 401 is returned as usual. Only use it with OAuth credentials that
+// have a refresh token.
+func WithTokenRefresher(refresh TokenRefresher) ClientOption {
+	return func(c *Client) {
+		c.refresh = refresh
+	}
+}
+
+// RefreshOAuthToken exchanges an OAuth refresh token for a new access token
+// at tokenURL, authenticating as the OAuth consumer
+func RefreshOAuthToken(ctx context.Context, tokenURL, clientID, clientSecret, refreshToken string) (*OAuthToken, error) {
+	data := url.Values{}
+	data.Set("grant_type", "refresh_token")
+	data.Set("refresh_token", refreshToken)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, tokenURL, strings.NewReader(data.Encode()))
+	if err != nil {
+		return nil, fmt.Errorf("could not create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("User-Agent", UserAgent)
+	req.SetBasicAuth(clientID, clientSecret)
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("could not read response body: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("token refresh failed with status %d", resp.StatusCode)
+	}
+
+	var token OAuthToken
+	if err := json.Unmarshal(body, &token); err != nil {
+		return nil, fmt.Errorf("could not parse token response: %w", err)
+	}
+	if token.AccessToken == "" {
+		return nil, fmt.Errorf("token refresh returned no access token")
+	}
+	return &token, nil
+}
+
+// accessToken returns the bearer token requests are currently sent with
+func (c *Client) accessToken() string {
+	c.authMu.Lock()
+	defer c.authMu.Unlock()
+	return c.token
+}
+
+// refreshAccessToken replaces rejected, the token a request was sent with,
+// with a new one. It reports whether the request is worth retrying, which
+// is also the case when another request already replaced the token.
+func (c *Client) refreshAccessToken(ctx context.Context, rejected string) bool {
+	c.authMu.Lock()
+	defer c.authMu.Unlock()
+	if c.token != rejected {
+		return true
+	}
+
+	token, err := c.refresh(ctx)
+	if err != nil || token == "" {
+		return false
+	}
+	c.token = token
+	return true
+}
+
+// canRefresh reports whether a 401 response to req may be retried with a
+// refreshed token: the client must have a refresher and use bearer tokens,
+// and the request body must be replayable
+func (c *Client) canRefresh(req *Request) bool {
+	if c.refresh == nil || c.username != "" {
+		return false
+	}
+	if req.RawBody == nil {
+		return true
+	}
+	_, ok := req.RawBody.(io.Seeker)
+	return ok
+}
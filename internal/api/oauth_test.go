@@ -0,0 +1,139 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+)
+
+func TestDoRetriesWithRefreshedToken(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		if r.Header.Get("Authorization") != "Bearer fresh" {
+			w.WriteHeader(http.StatusUnauthorized)
+			w.Write([]byte(`{"type": "error", "error": {"message": "Access token expired."}}`))
+			return
+		}
+		body, _ := io.ReadAll(r.Body)
+		if r.Method == http.MethodPost && string(body) != `{"name":"x"}` {
+			t.Errorf("expected the body to be sent again, got %q", body)
+		}
+		w.Write([]byte(`{"ok": true}`))
+	}))
+	defer server.Close()
+
+	refreshes := 0
+	client := NewClient(WithBaseURL(server.URL), WithToken("stale"), WithTokenRefresher(func(ctx context.Context) (string, error) {
+		refreshes++
+		return "fresh", nil
+	}))
+
+	resp, err := client.Post(context.Background(), "/thing", map[string]string{"name": "x"})
+	if err != nil {
+		t.Fatalf("expected the retry to succeed, got %v", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("expected 200, got %d", resp.StatusCode)
+	}
+	if refreshes != 1 || requests.Load() != 2 {
+		t.Errorf("expected 1 refresh and 2 requests, got %d and %d", refreshes, requests.Load())
+	}
+
+	// The new token is kept for later requests
+	if _, err := client.Get(context.Background(), "/thing", nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if refreshes != 1 || requests.Load() != 3 {
+		t.Errorf("expected no further refresh, got %d refreshes and %d requests", refreshes, requests.Load())
+	}
+}
+
+func TestDoReturnsSecondUnauthorized(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	defer server.Close()
+
+	refreshes := 0
+	client := NewClient(WithBaseURL(server.URL), WithToken("stale"), WithTokenRefresher(func(ctx context.Context) (string, error) {
+		refreshes++
+		return "also-rejected", nil
+	}))
+
+	_, err := client.Get(context.Background(), "/thing", nil)
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusUnauthorized {
+		t.Fatalf("expected a 401 APIError, got %v", err)
+	}
+	if refreshes != 1 || requests.Load() != 2 {
+		t.Errorf("expected 1 refresh and 2 requests, got %d and %d", refreshes, requests.Load())
+	}
+}
+
+func TestDoDoesNotRefreshWithoutRefreshableCredentials(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	defer server.Close()
+
+	refresher := func(ctx context.Context) (string, error) {
+		t.Error("refresher should not be called")
+		return "", nil
+	}
+
+	tests := []struct {
+		name   string
+		client *Client
+		req    *Request
+	}{
+		{"plain token", NewClient(WithBaseURL(server.URL), WithToken("t")), &Request{Method: http.MethodGet, Path: "/thing"}},
+		{"basic auth", NewClient(WithBaseURL(server.URL), WithBasicAuth("me", "secret"), WithTokenRefresher(refresher)), &Request{Method: http.MethodGet, Path: "/thing"}},
+		{"unreplayable body", NewClient(WithBaseURL(server.URL), WithToken("t"), WithTokenRefresher(refresher)), &Request{Method: http.MethodPost, Path: "/thing", RawBody: io.MultiReader(strings.NewReader("x"))}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			requests.Store(0)
+			if _, err := tt.client.Do(context.Background(), tt.req); err == nil {
+				t.Fatal("expected an error")
+			}
+			if requests.Load() != 1 {
+				t.Errorf("expected 1 request, got %d", requests.Load())
+			}
+		})
+	}
+}
+
+func TestRefreshOAuthToken(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id, secret, ok := r.BasicAuth()
+		if !ok || id != "key" || secret != "shh" {
+			t.Errorf("expected the consumer credentials, got %q %q", id, secret)
+		}
+		if err := r.ParseForm(); err != nil {
+			t.Fatalf("failed to parse form: %v", err)
+		}
+		if r.Form.Get("grant_type") != "refresh_token" || r.Form.Get("refresh_token") != "r1" {
+			t.Errorf("unexpected form: %v", r.Form)
+		}
+		w.Write([]byte(`{"access_token": "a2", "refresh_token": "r2", "expires_in": 7200}`))
+	}))
+	defer server.Close()
+
+	token, err := RefreshOAuthToken(context.Background(), server.URL, "key", "shh", "r1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if token.AccessToken != "a2" || token.RefreshToken != "r2" {
+		t.Errorf("unexpected token: %+v", token)
+	}
+}
@@ -0,0 +1,146 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+)
+
+// Repository permission levels, lowest first
+const (
+	PermissionRead  = "read"
+	PermissionWrite = "write"
+	PermissionAdmin = "admin"
+)
+
+// PermissionLevels lists the permissions that can be granted on a
+// repository, lowest first
+var PermissionLevels = []string{PermissionRead, PermissionWrite, PermissionAdmin}
+
+// RepoUserPermission is an explicit permission granted to a user on a
+// repository
+type RepoUserPermission struct {
+	Permission string `json:"permission"`
+	User       *User  `json:"user"`
+}
+
+// RepoGroupPermission is an explicit permission granted to a workspace group
+// on a repository
+type RepoGroupPermission struct {
+	Permission string `json:"permission"`
+	Group      *Group `json:"group"`
+}
+
+// UserRepoPermission is the authenticated user's effective permission on a
+// repository, including access granted through groups and the workspace
+type UserRepoPermission struct {
+	Permission string      `json:"permission"`
+	Repository *Repository `json:"repository"`
+}
+
+// RepoPermissionListOptions are options for listing repository permissions
+type RepoPermissionListOptions struct {
+	Query string // Filter query, e.g. permission="admin"
+	Page  int    // Page number
+	Limit int    // Number of items per page (pagelen)
+}
+
+// repoPermissionQuery builds the query shared by the permissions-config
+// endpoints
+func repoPermissionQuery(opts *RepoPermissionListOptions) url.Values {
+	query := url.Values{}
+	if opts != nil {
+		if opts.Query != "" {
+			query.Set("q", opts.Query)
+		}
+		if opts.Page > 0 {
+			query.Set("page", strconv.Itoa(opts.Page))
+		}
+		if opts.Limit > 0 {
+			query.Set("pagelen", strconv.Itoa(opts.Limit))
+		}
+	}
+	return query
+}
+
+// ListRepoUserPermissions lists the users with explicit permissions on a
+// repository. It requires admin access to the repository.
+func (c *Client) ListRepoUserPermissions(ctx context.Context, workspace, repoSlug string, opts *RepoPermissionListOptions) (*Paginated[RepoUserPermission], error) {
+	path := fmt.Sprintf("/repositories/%s/%s/permissions-config/users", workspace, repoSlug)
+
+	resp, err := c.Get(ctx, path, repoPermissionQuery(opts))
+	if err != nil {
+		return nil, err
+	}
+
+	return ParseResponse[*Paginated[RepoUserPermission]](resp)
+}
+
+// ListRepoGroupPermissions lists the groups with explicit permissions on a
+// repository. It requires admin access to the repository.
+func (c *Client) ListRepoGroupPermissions(ctx context.Context, workspace, repoSlug string, opts *RepoPermissionListOptions) (*Paginated[RepoGroupPermission], error) {
+	path := fmt.Sprintf("/repositories/%s/%s/permissions-config/groups", workspace, repoSlug)
+
+	resp, err := c.Get(ctx, path, repoPermissionQuery(opts))
+	if err != nil {
+		return nil, err
+	}
+
+	return ParseResponse[*Paginated[RepoGroupPermission]](resp)
+}
+
+// SetRepoUserPermission grants a user a permission on a repository,
+// replacing any explicit permission they already had
+func (c *Client) SetRepoUserPermission(ctx context.Context, workspace, repoSlug, userUUID, permission string) (*RepoUserPermission, error) {
+	path := fmt.Sprintf("/repositories/%s/%s/permissions-config/users/%s", workspace, repoSlug, url.PathEscape(userUUID))
+
+	resp, err := c.Put(ctx, path, map[string]string{"permission": permission})
+	if err != nil {
+		return nil, err
+	}
+
+	return ParseResponse[*RepoUserPermission](resp)
+}
+
+// RemoveRepoUserPermission removes a user's explicit permission on a
+// repository. They may keep access through a group or the workspace.
+func (c *Client) RemoveRepoUserPermission(ctx context.Context, workspace, repoSlug, userUUID string) error {
+	path := fmt.Sprintf("/repositories/%s/%s/permissions-config/users/%s", workspace, repoSlug, url.PathEscape(userUUID))
+
+	_, err := c.Delete(ctx, path)
+	return err
+}
+
+// SetRepoGroupPermission grants a workspace group a permission on a
+// repository, replacing any explicit permission it already had
+func (c *Client) SetRepoGroupPermission(ctx context.Context, workspace, repoSlug, groupSlug, permission string) (*RepoGroupPermission, error) {
+	path := fmt.Sprintf("/repositories/%s/%s/permissions-config/groups/%s", workspace, repoSlug, url.PathEscape(groupSlug))
+
+	resp, err := c.Put(ctx, path, map[string]string{"permission": permission})
+	if err != nil {
+		return nil, err
+	}
+
+	return ParseResponse[*RepoGroupPermission](resp)
+}
+
+// RemoveRepoGroupPermission removes a group's explicit permission on a
+// repository
+func (c *Client) RemoveRepoGroupPermission(ctx context.Context, workspace, repoSlug, groupSlug string) error {
+	path := fmt.Sprintf("/repositories/%s/%s/permissions-config/groups/%s", workspace, repoSlug, url.PathEscape(groupSlug))
+
+	_, err := c.Delete(ctx, path)
+	return err
+}
+
+// ListUserRepoPermissions lists the authenticated user's permission on each
+// repository they can access, across all workspaces
+func (c *Client) ListUserRepoPermissions(ctx context.Context, opts *RepoPermissionListOptions) (*Paginated[UserRepoPermission], error) {
+	resp, err := c.Get(ctx, "/user/permissions/repositories", repoPermissionQuery(opts))
+	if err != nil {
+		return nil, err
+	}
+
+	return ParseResponse[*Paginated[UserRepoPermission]](resp)
+}
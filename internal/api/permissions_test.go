@@ -0,0 +1,143 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestListRepoUserPermissions(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/repositories/myworkspace/myrepo/permissions-config/users" {
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+		if got := r.URL.Query().Get("pagelen"); got != "100" {
+			t.Errorf("expected pagelen=100, got %q", got)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"values": [{"type": "repository_user_permission", "permission": "admin", "user": {"uuid": "{a}", "display_name": "Alice"}}]}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(WithBaseURL(server.URL), WithToken("test-token"))
+	result, err := client.ListRepoUserPermissions(context.Background(), "myworkspace", "myrepo", &RepoPermissionListOptions{Limit: 100})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(result.Values) != 1 {
+		t.Fatalf("expected 1 permission, got %d", len(result.Values))
+	}
+	got := result.Values[0]
+	if got.Permission != PermissionAdmin || got.User == nil || got.User.UUID != "{a}" {
+		t.Errorf("unexpected permission: %+v", got)
+	}
+}
+
+func TestListRepoGroupPermissions(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/repositories/myworkspace/myrepo/permissions-config/groups" {
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"values": [{"type": "repository_group_permission", "permission": "read", "group": {"slug": "devs", "name": "Developers"}}]}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(WithBaseURL(server.URL), WithToken("test-token"))
+	result, err := client.ListRepoGroupPermissions(context.Background(), "myworkspace", "myrepo", nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(result.Values) != 1 || result.Values[0].Group == nil || result.Values[0].Group.Slug != "devs" {
+		t.Fatalf("unexpected permissions: %+v", result.Values)
+	}
+}
+
+func TestListRepoUserPermissions_Forbidden(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+		w.Write([]byte(`{"type": "error", "error": {"message": "Your credentials lack one or more required privilege scopes."}}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(WithBaseURL(server.URL), WithToken("test-token"))
+	_, err := client.ListRepoUserPermissions(context.Background(), "myworkspace", "myrepo", nil)
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusForbidden {
+		t.Fatalf("expected a 403 APIError, got %v", err)
+	}
+}
+
+func TestSetRepoUserPermission(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPut {
+			t.Errorf("expected PUT, got %s", r.Method)
+		}
+		if r.URL.EscapedPath() != "/repositories/myworkspace/myrepo/permissions-config/users/%7Babc%7D" {
+			t.Errorf("unexpected path %s", r.URL.EscapedPath())
+		}
+		var body map[string]string
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Fatalf("failed to decode body: %v", err)
+		}
+		if body["permission"] != "write" {
+			t.Errorf("expected permission=write, got %q", body["permission"])
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"permission": "write", "user": {"uuid": "{abc}"}}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(WithBaseURL(server.URL), WithToken("test-token"))
+	got, err := client.SetRepoUserPermission(context.Background(), "myworkspace", "myrepo", "{abc}", PermissionWrite)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got.Permission != PermissionWrite {
+		t.Errorf("expected write, got %q", got.Permission)
+	}
+}
+
+func TestRemoveRepoGroupPermission(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodDelete {
+			t.Errorf("expected DELETE, got %s", r.Method)
+		}
+		if r.URL.Path != "/repositories/myworkspace/myrepo/permissions-config/groups/devs" {
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	client := NewClient(WithBaseURL(server.URL), WithToken("test-token"))
+	if err := client.RemoveRepoGroupPermission(context.Background(), "myworkspace", "myrepo", "devs"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestListUserRepoPermissions(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/user/permissions/repositories" {
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+		if got := r.URL.Query().Get("q"); got != `permission="admin"` {
+			t.Errorf("expected the query to be passed through, got %q", got)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"values": [{"type": "repository_permission", "permission": "admin", "repository": {"full_name": "ws/repo"}}]}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(WithBaseURL(server.URL), WithToken("test-token"))
+	result, err := client.ListUserRepoPermissions(context.Background(), &RepoPermissionListOptions{Query: `permission="admin"`})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(result.Values) != 1 || result.Values[0].Repository == nil || result.Values[0].Repository.FullName != "ws/repo" {
+		t.Fatalf("unexpected permissions: %+v", result.Values)
+	}
+}
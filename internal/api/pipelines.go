@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"net/http"
 	"net/url"
+	"strconv"
 	"time"
 )
 
@@ -96,6 +97,7 @@ type PipelineImage struct {
 type PipelineListOptions struct {
 	Status string // Filter by status
 	Sort   string // Sort field
+	Limit  int    // Number of items per page (pagelen)
 }
 
 // PipelineRunOptions are options for triggering a new pipeline run
@@ -115,6 +117,9 @@ func (c *Client) ListPipelines(ctx context.Context, workspace, repoSlug string,
 		if opts.Sort != "" {
 			query.Set("sort", opts.Sort)
 		}
+		if opts.Limit > 0 {
+			query.Set("pagelen", strconv.Itoa(opts.Limit))
+		}
 	}
 
 	resp, err := c.Get(ctx, path, query)
@@ -85,11 +85,12 @@ func TestListPipelines(t *testing.T) {
 			name:        "list with all filters",
 			workspace:   "myworkspace",
 			repoSlug:    "myrepo",
-			opts:        &PipelineListOptions{Status: "FAILED", Sort: "created_on"},
+			opts:        &PipelineListOptions{Status: "FAILED", Sort: "created_on", Limit: 25},
 			expectedURL: "/repositories/myworkspace/myrepo/pipelines",
 			expectedQuery: map[string]string{
-				"status": "FAILED",
-				"sort":   "created_on",
+				"status":  "FAILED",
+				"sort":    "created_on",
+				"pagelen": "25",
 			},
 			response: `{
 				"size": 5,
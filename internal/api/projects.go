@@ -45,6 +45,15 @@ type ProjectCreateOptions struct {
 	IsPrivate   bool   `json:"is_private"`
 }
 
+// ProjectUpdateOptions are options for updating a project. Only non-nil
+// fields are sent, so unchanged settings are left as they are.
+type ProjectUpdateOptions struct {
+	Key         *string `json:"key,omitempty"`
+	Name        *string `json:"name,omitempty"`
+	Description *string `json:"description,omitempty"`
+	IsPrivate   *bool   `json:"is_private,omitempty"`
+}
+
 // ListProjects lists projects in a workspace
 func (c *Client) ListProjects(ctx context.Context, workspaceSlug string, opts *ProjectListOptions) (*Paginated[ProjectFull], error) {
 	path := fmt.Sprintf("/workspaces/%s/projects", workspaceSlug)
@@ -109,6 +118,18 @@ func (c *Client) UpdateProject(ctx context.Context, workspaceSlug, projectKey st
 	return ParseResponse[*ProjectFull](resp)
 }
 
+// EditProject updates only the fields set in opts on an existing project
+func (c *Client) EditProject(ctx context.Context, workspaceSlug, projectKey string, opts *ProjectUpdateOptions) (*ProjectFull, error) {
+	path := fmt.Sprintf("/workspaces/%s/projects/%s", workspaceSlug, projectKey)
+
+	resp, err := c.Put(ctx, path, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	return ParseResponse[*ProjectFull](resp)
+}
+
 // DeleteProject deletes a project
 func (c *Client) DeleteProject(ctx context.Context, workspaceSlug, projectKey string) error {
 	path := fmt.Sprintf("/workspaces/%s/projects/%s", workspaceSlug, projectKey)
@@ -116,3 +137,32 @@ func (c *Client) DeleteProject(ctx context.Context, workspaceSlug, projectKey st
 	_, err := c.Delete(ctx, path)
 	return err
 }
+
+// ListProjectRepositories lists the repositories in a project. Any query in
+// opts is combined with the project filter.
+func (c *Client) ListProjectRepositories(ctx context.Context, workspaceSlug, projectKey string, opts *RepositoryListOptions) (*Paginated[RepositoryFull], error) {
+	listOpts := RepositoryListOptions{}
+	if opts != nil {
+		listOpts = *opts
+	}
+
+	listOpts.Project = projectKey
+
+	return c.ListRepositories(ctx, workspaceSlug, &listOpts)
+}
+
+// GetWorkspaceProjects returns every project in a workspace, fetching all
+// pages
+func (c *Client) GetWorkspaceProjects(ctx context.Context, workspaceSlug string) ([]ProjectFull, error) {
+	var projects []ProjectFull
+	pages := Iterate(ctx, c, func(ctx context.Context) (*Paginated[ProjectFull], error) {
+		return c.ListProjects(ctx, workspaceSlug, &ProjectListOptions{Limit: 100})
+	})
+	for project, err := range pages {
+		if err != nil {
+			return nil, err
+		}
+		projects = append(projects, project)
+	}
+	return projects, nil
+}
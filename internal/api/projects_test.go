@@ -833,3 +833,114 @@ func TestListProjectsPagination(t *testing.T) {
 		t.Errorf("expected 2 values, got %d", len(result.Values))
 	}
 }
+
+func TestListProjectRepositories(t *testing.T) {
+	tests := []struct {
+		name          string
+		opts          *RepositoryListOptions
+		expectedQuery map[string]string
+	}{
+		{
+			name:          "project filter only",
+			opts:          nil,
+			expectedQuery: map[string]string{"q": `project.key="PROJ"`},
+		},
+		{
+			name: "combined with query, sort and pagination",
+			opts: &RepositoryListOptions{Query: `name ~ "api"`, Sort: "name", Page: 2, Limit: 10},
+			expectedQuery: map[string]string{
+				"q":       `project.key="PROJ" AND (name ~ "api")`,
+				"sort":    "name",
+				"page":    "2",
+				"pagelen": "10",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path != "/repositories/myworkspace" {
+					t.Errorf("expected path /repositories/myworkspace, got %s", r.URL.Path)
+				}
+				for key, want := range tt.expectedQuery {
+					if got := r.URL.Query().Get(key); got != want {
+						t.Errorf("expected query %s=%q, got %q", key, want, got)
+					}
+				}
+				w.Header().Set("Content-Type", "application/json")
+				w.Write([]byte(`{"size": 1, "values": [{"slug": "api-server", "full_name": "myworkspace/api-server"}]}`))
+			}))
+			defer server.Close()
+
+			client := NewClient(WithBaseURL(server.URL), WithToken("test-token"))
+			result, err := client.ListProjectRepositories(context.Background(), "myworkspace", "PROJ", tt.opts)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if len(result.Values) != 1 || result.Values[0].Slug != "api-server" {
+				t.Errorf("unexpected result: %+v", result.Values)
+			}
+			if tt.opts != nil && tt.opts.Query != `name ~ "api"` {
+				t.Errorf("caller options were modified: %q", tt.opts.Query)
+			}
+		})
+	}
+}
+
+func TestEditProject(t *testing.T) {
+	description := "New description"
+	private := false
+
+	tests := []struct {
+		name     string
+		opts     *ProjectUpdateOptions
+		wantBody map[string]interface{}
+	}{
+		{
+			name:     "description only",
+			opts:     &ProjectUpdateOptions{Description: &description},
+			wantBody: map[string]interface{}{"description": "New description"},
+		},
+		{
+			name:     "make public",
+			opts:     &ProjectUpdateOptions{IsPrivate: &private},
+			wantBody: map[string]interface{}{"is_private": false},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var body map[string]interface{}
+
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.Method != http.MethodPut {
+					t.Errorf("expected PUT method, got %s", r.Method)
+				}
+				if r.URL.Path != "/workspaces/myworkspace/projects/PROJ" {
+					t.Errorf("unexpected path %s", r.URL.Path)
+				}
+				if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+					t.Fatalf("failed to decode body: %v", err)
+				}
+				w.Header().Set("Content-Type", "application/json")
+				w.Write([]byte(`{"key": "PROJ", "name": "Project"}`))
+			}))
+			defer server.Close()
+
+			client := NewClient(WithBaseURL(server.URL), WithToken("test-token"))
+			if _, err := client.EditProject(context.Background(), "myworkspace", "PROJ", tt.opts); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if len(body) != len(tt.wantBody) {
+				t.Errorf("expected body %v, got %v", tt.wantBody, body)
+			}
+			for key, want := range tt.wantBody {
+				if body[key] != want {
+					t.Errorf("expected %s=%v, got %v", key, want, body[key])
+				}
+			}
+		})
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"io"
 	"net/http"
 	"net/url"
 	"strconv"
@@ -28,6 +29,9 @@ const (
 	MergeStrategyFastForward MergeStrategy = "fast_forward"
 )
 
+// MergeStrategies lists the merge strategies bb can request
+var MergeStrategies = []MergeStrategy{MergeStrategyMergeCommit, MergeStrategySquash, MergeStrategyFastForward}
+
 // Link represents a hyperlink
 type Link struct {
 	Href string `json:"href"`
@@ -49,16 +53,41 @@ type PRLinks struct {
 
 // Commit represents a git commit
 type Commit struct {
-	Hash  string `json:"hash"`
-	Links struct {
+	Hash      string           `json:"hash"`
+	Message   string           `json:"message,omitempty"`
+	Date      time.Time        `json:"date,omitzero"`
+	Author    *CommitAuthor    `json:"author,omitempty"`
+	Signature *CommitSignature `json:"signature,omitempty"`
+	Parents   []Commit         `json:"parents,omitempty"` // Usually only the hash and links are set
+	Links     struct {
 		Self Link `json:"self"`
 		HTML Link `json:"html"`
 	} `json:"links"`
 }
 
-// Branch represents a git branch
+// CommitSignature is Bitbucket's verification of a GPG- or SSH-signed
+// commit. It is nil for unsigned commits and on responses that leave it out.
+type CommitSignature struct {
+	Type     string `json:"type,omitempty"` // "gpg" or "ssh"
+	Verified bool   `json:"verified"`
+	KeyID    string `json:"key_id,omitempty"`
+	Signer   *User  `json:"signer,omitempty"`
+	Reason   string `json:"reason,omitempty"` // Why verification failed
+}
+
+// CommitAuthor is the author of a commit. Raw is the git author string;
+// User is set when it matches a Bitbucket account.
+type CommitAuthor struct {
+	Raw  string `json:"raw"`
+	User *User  `json:"user,omitempty"`
+}
+
+// Branch represents a git branch. On pull request destinations Bitbucket
+// also reports which merge strategies the branch allows.
 type Branch struct {
-	Name string `json:"name"`
+	Name                 string          `json:"name"`
+	MergeStrategies      []MergeStrategy `json:"merge_strategies,omitempty"`
+	DefaultMergeStrategy MergeStrategy   `json:"default_merge_strategy,omitempty"`
 }
 
 // Repository represents a Bitbucket repository
@@ -110,6 +139,10 @@ type PullRequest struct {
 	Reviewers         []User       `json:"reviewers,omitempty"`
 	CommentCount      int          `json:"comment_count"`
 	TaskCount         int          `json:"task_count"`
+
+	// MergeChecks aren't part of Bitbucket's response; commands that need
+	// them fill them in with EvaluateMergeChecks
+	MergeChecks []MergeCheck `json:"merge_checks,omitempty"`
 }
 
 // PRComment represents a comment on a pull request
@@ -131,18 +164,37 @@ type PRComment struct {
 	Parent *struct {
 		ID int64 `json:"id"`
 	} `json:"parent,omitempty"`
-	Links struct {
+	Deleted    bool               `json:"deleted,omitempty"`
+	Resolution *CommentResolution `json:"resolution,omitempty"`
+	Reactions  []CommentReaction  `json:"reactions,omitempty"` // only in responses that include them
+	Links      struct {
 		Self Link `json:"self"`
 		HTML Link `json:"html"`
 	} `json:"links"`
 }
 
+// CommentResolution records who resolved a comment thread and when
+type CommentResolution struct {
+	User      *User     `json:"user,omitempty"`
+	CreatedOn time.Time `json:"created_on"`
+}
+
+// CommentReaction counts the users who reacted to a comment with an emoji.
+// Emoji is either the emoji itself or its shortcode, such as "thumbsup".
+type CommentReaction struct {
+	Emoji string `json:"emoji"`
+	Count int    `json:"count"`
+}
+
 // PRListOptions are options for listing pull requests
 type PRListOptions struct {
-	State  PRState // Filter by state (OPEN, MERGED, DECLINED)
-	Author string  // Filter by author username
-	Page   int     // Page number
-	Limit  int     // Number of items per page (pagelen)
+	State    PRState   // Filter by state (OPEN, MERGED, DECLINED)
+	Author   string    // Filter by author username or UUID
+	Reviewer string    // Filter by reviewer username or UUID
+	Since    time.Time // Only PRs created at or after this time
+	Until    time.Time // Only PRs created before this time
+	Page     int       // Page number
+	Limit    int       // Number of items per page (pagelen)
 }
 
 // PRCreateOptions are options for creating a pull request
@@ -156,6 +208,15 @@ type PRCreateOptions struct {
 	Reviewers         []string `json:"-"` // List of user UUIDs
 }
 
+// PRUpdateOptions are options for updating a pull request. Only the fields
+// that are set are sent, so the others are left unchanged.
+type PRUpdateOptions struct {
+	Title             *string
+	Description       *string // an empty description clears it
+	DestinationBranch *string
+	CloseSourceBranch *bool
+}
+
 // prCreateRequest is the actual API request body for creating a PR
 type prCreateRequest struct {
 	Title             string `json:"title"`
@@ -195,9 +256,16 @@ func (c *Client) ListPullRequests(ctx context.Context, workspace, repoSlug strin
 		if opts.State != "" {
 			query.Set("state", string(opts.State))
 		}
+		var q Query
 		if opts.Author != "" {
-			// Use q parameter for author filtering
-			query.Set("q", fmt.Sprintf("author.username=\"%s\"", opts.Author))
+			q.User("author", opts.Author)
+		}
+		if opts.Reviewer != "" {
+			q.User("reviewers", opts.Reviewer)
+		}
+		q.DateRange("created_on", opts.Since, opts.Until)
+		if s := q.String(); s != "" {
+			query.Set("q", s)
 		}
 		if opts.Page > 0 {
 			query.Set("page", strconv.Itoa(opts.Page))
@@ -262,6 +330,21 @@ func (c *Client) CreatePullRequest(ctx context.Context, workspace, repoSlug stri
 	return ParseResponse[*PullRequest](resp)
 }
 
+// GetDefaultReviewers lists the default reviewers configured for a repository
+func (c *Client) GetDefaultReviewers(ctx context.Context, workspace, repoSlug string) (*Paginated[User], error) {
+	path := fmt.Sprintf("/repositories/%s/%s/default-reviewers", workspace, repoSlug)
+
+	query := url.Values{}
+	query.Set("pagelen", "100")
+
+	resp, err := c.Get(ctx, path, query)
+	if err != nil {
+		return nil, err
+	}
+
+	return ParseResponse[*Paginated[User]](resp)
+}
+
 // MergePullRequest merges a pull request
 func (c *Client) MergePullRequest(ctx context.Context, workspace, repoSlug string, prID int64, opts *PRMergeOptions) (*PullRequest, error) {
 	path := fmt.Sprintf("/repositories/%s/%s/pullrequests/%d/merge", workspace, repoSlug, prID)
@@ -339,17 +422,26 @@ func (c *Client) RequestChanges(ctx context.Context, workspace, repoSlug string,
 	return ParseResponse[*Participant](resp)
 }
 
-// GetPullRequestDiff retrieves the diff of a pull request
-func (c *Client) GetPullRequestDiff(ctx context.Context, workspace, repoSlug string, prID int64) (string, error) {
-	path := fmt.Sprintf("/repositories/%s/%s/pullrequests/%d/diff", workspace, repoSlug, prID)
-
-	resp, err := c.Do(ctx, &Request{
+// plainTextRequest builds a GET request for an endpoint that serves text
+// rather than JSON, such as a diff or patch. Bitbucket negotiates the format
+// from the Accept header.
+func plainTextRequest(path string) *Request {
+	return &Request{
 		Method: http.MethodGet,
 		Path:   path,
 		Headers: map[string]string{
 			"Accept": "text/plain",
 		},
-	})
+	}
+}
+
+// GetPullRequestDiff retrieves the diff of a pull request. The whole diff
+// is held in memory, which can be hundreds of megabytes for large pull
+// requests; use GetPullRequestDiffReader to stream it instead.
+func (c *Client) GetPullRequestDiff(ctx context.Context, workspace, repoSlug string, prID int64) (string, error) {
+	path := fmt.Sprintf("/repositories/%s/%s/pullrequests/%d/diff", workspace, repoSlug, prID)
+
+	resp, err := c.Do(ctx, plainTextRequest(path))
 	if err != nil {
 		return "", err
 	}
@@ -357,6 +449,101 @@ func (c *Client) GetPullRequestDiff(ctx context.Context, workspace, repoSlug str
 	return string(resp.Body), nil
 }
 
+// GetPullRequestDiffReader returns the diff of a pull request as a stream.
+// The caller must close the returned reader.
+func (c *Client) GetPullRequestDiffReader(ctx context.Context, workspace, repoSlug string, prID int64) (io.ReadCloser, error) {
+	path := fmt.Sprintf("/repositories/%s/%s/pullrequests/%d/diff", workspace, repoSlug, prID)
+
+	return c.DoStream(ctx, plainTextRequest(path))
+}
+
+// GetPullRequestPatch retrieves the pull request's commits as a series of
+// patches in git format-patch style, suitable for git am. Like
+// GetPullRequestDiff it holds the whole patch in memory; use
+// GetPullRequestPatchReader to stream it instead.
+func (c *Client) GetPullRequestPatch(ctx context.Context, workspace, repoSlug string, prID int64) (string, error) {
+	path := fmt.Sprintf("/repositories/%s/%s/pullrequests/%d/patch", workspace, repoSlug, prID)
+
+	resp, err := c.Do(ctx, plainTextRequest(path))
+	if err != nil {
+		return "", err
+	}
+
+	return string(resp.Body), nil
+}
+
+// GetPullRequestPatchReader returns the pull request's patch series as a
+// stream. The caller must close the returned reader.
+func (c *Client) GetPullRequestPatchReader(ctx context.Context, workspace, repoSlug string, prID int64) (io.ReadCloser, error) {
+	path := fmt.Sprintf("/repositories/%s/%s/pullrequests/%d/patch", workspace, repoSlug, prID)
+
+	return c.DoStream(ctx, plainTextRequest(path))
+}
+
+// DiffStatFile identifies one side of a changed file in a diffstat
+type DiffStatFile struct {
+	Path string `json:"path"`
+	Type string `json:"type"`
+}
+
+// DiffStatEntry summarizes the changes to a single file
+type DiffStatEntry struct {
+	Status       string        `json:"status"` // added, removed, modified, renamed
+	LinesAdded   int           `json:"lines_added"`
+	LinesRemoved int           `json:"lines_removed"`
+	Old          *DiffStatFile `json:"old"`
+	New          *DiffStatFile `json:"new"`
+}
+
+// Path returns the most relevant path for the entry: the new path unless the
+// file was removed.
+func (d DiffStatEntry) Path() string {
+	if d.New != nil && d.New.Path != "" {
+		return d.New.Path
+	}
+	if d.Old != nil {
+		return d.Old.Path
+	}
+	return ""
+}
+
+// GetPullRequestDiffStat retrieves per-file change counts for a pull
+// request, following every page so that each changed file is included
+func (c *Client) GetPullRequestDiffStat(ctx context.Context, workspace, repoSlug string, prID int64) ([]DiffStatEntry, error) {
+	path := fmt.Sprintf("/repositories/%s/%s/pullrequests/%d/diffstat", workspace, repoSlug, prID)
+
+	// diffstat accepts larger pages than most endpoints, which keeps the
+	// common case to a single request
+	query := url.Values{}
+	query.Set("pagelen", "500")
+
+	return Collect(Iterate(ctx, c, func(ctx context.Context) (*Paginated[DiffStatEntry], error) {
+		resp, err := c.Get(ctx, path, query)
+		if err != nil {
+			return nil, err
+		}
+		return ParseResponse[*Paginated[DiffStatEntry]](resp)
+	}))
+}
+
+// ListPullRequestCommits lists the commits on a pull request's source
+// branch that are not on its destination, newest first
+func (c *Client) ListPullRequestCommits(ctx context.Context, workspace, repoSlug string, prID int64, limit int) (*Paginated[Commit], error) {
+	path := fmt.Sprintf("/repositories/%s/%s/pullrequests/%d/commits", workspace, repoSlug, prID)
+
+	query := url.Values{}
+	if limit > 0 {
+		query.Set("pagelen", strconv.Itoa(limit))
+	}
+
+	resp, err := c.Get(ctx, path, query)
+	if err != nil {
+		return nil, err
+	}
+
+	return ParseResponse[*Paginated[Commit]](resp)
+}
+
 // ListPRComments lists comments on a pull request
 func (c *Client) ListPRComments(ctx context.Context, workspace, repoSlug string, prID int64) (*Paginated[PRComment], error) {
 	path := fmt.Sprintf("/repositories/%s/%s/pullrequests/%d/comments", workspace, repoSlug, prID)
@@ -391,10 +578,9 @@ type addPRCommentRequest struct {
 	} `json:"inline,omitempty"`
 }
 
-// AddPRComment adds a comment to a pull request
-func (c *Client) AddPRComment(ctx context.Context, workspace, repoSlug string, prID int64, opts *AddPRCommentOptions) (*PRComment, error) {
-	path := fmt.Sprintf("/repositories/%s/%s/pullrequests/%d/comments", workspace, repoSlug, prID)
-
+// newCommentRequest builds the request body for a new pull request or
+// commit comment
+func newCommentRequest(opts *AddPRCommentOptions) addPRCommentRequest {
 	reqBody := addPRCommentRequest{}
 	reqBody.Content.Raw = opts.Content
 
@@ -410,8 +596,42 @@ func (c *Client) AddPRComment(ctx context.Context, workspace, repoSlug string, p
 			Path string `json:"path"`
 		}{To: opts.Line, Path: opts.Path}
 	}
+	return reqBody
+}
 
-	resp, err := c.Post(ctx, path, reqBody)
+// AddPRComment adds a comment to a pull request
+func (c *Client) AddPRComment(ctx context.Context, workspace, repoSlug string, prID int64, opts *AddPRCommentOptions) (*PRComment, error) {
+	path := fmt.Sprintf("/repositories/%s/%s/pullrequests/%d/comments", workspace, repoSlug, prID)
+
+	resp, err := c.Post(ctx, path, newCommentRequest(opts))
+	if err != nil {
+		return nil, err
+	}
+
+	return ParseResponse[*PRComment](resp)
+}
+
+// GetPRComment gets a single comment on a pull request
+func (c *Client) GetPRComment(ctx context.Context, workspace, repoSlug string, prID, commentID int64) (*PRComment, error) {
+	path := fmt.Sprintf("/repositories/%s/%s/pullrequests/%d/comments/%d", workspace, repoSlug, prID, commentID)
+
+	resp, err := c.Get(ctx, path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return ParseResponse[*PRComment](resp)
+}
+
+// UpdatePRComment replaces the text of a comment on a pull request. Only the
+// comment's author can edit it.
+func (c *Client) UpdatePRComment(ctx context.Context, workspace, repoSlug string, prID, commentID int64, content string) (*PRComment, error) {
+	path := fmt.Sprintf("/repositories/%s/%s/pullrequests/%d/comments/%d", workspace, repoSlug, prID, commentID)
+
+	reqBody := addPRCommentRequest{}
+	reqBody.Content.Raw = content
+
+	resp, err := c.Put(ctx, path, reqBody)
 	if err != nil {
 		return nil, err
 	}
@@ -419,33 +639,152 @@ func (c *Client) AddPRComment(ctx context.Context, workspace, repoSlug string, p
 	return ParseResponse[*PRComment](resp)
 }
 
-// UpdatePullRequest updates an existing pull request
-func (c *Client) UpdatePullRequest(ctx context.Context, workspace, repoSlug string, prID int64, opts *PRCreateOptions) (*PullRequest, error) {
+// DeletePRComment deletes a comment on a pull request. Only the comment's
+// author can delete it.
+func (c *Client) DeletePRComment(ctx context.Context, workspace, repoSlug string, prID, commentID int64) error {
+	path := fmt.Sprintf("/repositories/%s/%s/pullrequests/%d/comments/%d", workspace, repoSlug, prID, commentID)
+
+	_, err := c.Delete(ctx, path)
+	return err
+}
+
+// UpdatePullRequest updates the fields of an existing pull request that are
+// set in opts. Bitbucket requires the title on every update, so without
+// opts.Title the current title is fetched and resent.
+func (c *Client) UpdatePullRequest(ctx context.Context, workspace, repoSlug string, prID int64, opts *PRUpdateOptions) (*PullRequest, error) {
 	path := fmt.Sprintf("/repositories/%s/%s/pullrequests/%d", workspace, repoSlug, prID)
 
-	// Build update body - only include fields that should be updated
 	body := map[string]interface{}{}
-	if opts.Title != "" {
-		body["title"] = opts.Title
+	if opts.Title != nil {
+		body["title"] = *opts.Title
+	} else {
+		pr, err := c.GetPullRequest(ctx, workspace, repoSlug, prID)
+		if err != nil {
+			return nil, err
+		}
+		body["title"] = pr.Title
 	}
-	if opts.Description != "" {
-		body["description"] = opts.Description
+	if opts.Description != nil {
+		body["description"] = *opts.Description
 	}
-	if opts.DestinationBranch != "" {
+	if opts.DestinationBranch != nil {
 		body["destination"] = map[string]interface{}{
 			"branch": map[string]string{
-				"name": opts.DestinationBranch,
+				"name": *opts.DestinationBranch,
 			},
 		}
 	}
-	body["close_source_branch"] = opts.CloseSourceBranch
+	if opts.CloseSourceBranch != nil {
+		body["close_source_branch"] = *opts.CloseSourceBranch
+	}
 
-	if len(opts.Reviewers) > 0 {
-		reviewers := make([]map[string]string, len(opts.Reviewers))
-		for i, uuid := range opts.Reviewers {
-			reviewers[i] = map[string]string{"uuid": uuid}
+	resp, err := c.Put(ctx, path, body)
+	if err != nil {
+		return nil, err
+	}
+
+	return ParseResponse[*PullRequest](resp)
+}
+
+// WatchPullRequest subscribes a user to notifications for a pull request.
+//
+// Bitbucket Cloud has no watch endpoint for pull requests, so this adds the
+// user as a reviewer, which is the closest equivalent. The pull request
+// author cannot be added as a reviewer and gets an error instead.
+func (c *Client) WatchPullRequest(ctx context.Context, workspace, repoSlug string, prID int64, userUUID string) (*PullRequest, error) {
+	pr, err := c.GetPullRequest(ctx, workspace, repoSlug, prID)
+	if err != nil {
+		return nil, err
+	}
+
+	if pr.Author.UUID == userUUID {
+		return nil, fmt.Errorf("the author of pull request #%d is already subscribed and cannot be added as a reviewer", prID)
+	}
+
+	uuids := make([]string, 0, len(pr.Reviewers)+1)
+	for _, r := range pr.Reviewers {
+		if r.UUID == userUUID {
+			return pr, nil
+		}
+		uuids = append(uuids, r.UUID)
+	}
+
+	return c.setPullRequestReviewers(ctx, workspace, repoSlug, pr, append(uuids, userUUID))
+}
+
+// AddPullRequestReviewers adds users to the reviewers of a pull request,
+// keeping the reviewers it already has. Users who already review it are
+// skipped, and nothing is sent if all of them do. The author cannot review
+// their own pull request and gets an error instead.
+func (c *Client) AddPullRequestReviewers(ctx context.Context, workspace, repoSlug string, prID int64, userUUIDs []string) (*PullRequest, error) {
+	pr, err := c.GetPullRequest(ctx, workspace, repoSlug, prID)
+	if err != nil {
+		return nil, err
+	}
+
+	uuids := make([]string, 0, len(pr.Reviewers)+len(userUUIDs))
+	seen := make(map[string]bool, len(pr.Reviewers))
+	for _, r := range pr.Reviewers {
+		uuids = append(uuids, r.UUID)
+		seen[r.UUID] = true
+	}
+
+	added := false
+	for _, uuid := range userUUIDs {
+		if uuid == pr.Author.UUID {
+			return nil, fmt.Errorf("the author of pull request #%d cannot be added as a reviewer", prID)
+		}
+		if seen[uuid] {
+			continue
 		}
-		body["reviewers"] = reviewers
+		seen[uuid] = true
+		uuids = append(uuids, uuid)
+		added = true
+	}
+	if !added {
+		return pr, nil
+	}
+
+	return c.setPullRequestReviewers(ctx, workspace, repoSlug, pr, uuids)
+}
+
+// UnwatchPullRequest reverses WatchPullRequest by removing the user from the
+// pull request's reviewers.
+func (c *Client) UnwatchPullRequest(ctx context.Context, workspace, repoSlug string, prID int64, userUUID string) (*PullRequest, error) {
+	pr, err := c.GetPullRequest(ctx, workspace, repoSlug, prID)
+	if err != nil {
+		return nil, err
+	}
+
+	uuids := make([]string, 0, len(pr.Reviewers))
+	found := false
+	for _, r := range pr.Reviewers {
+		if r.UUID == userUUID {
+			found = true
+			continue
+		}
+		uuids = append(uuids, r.UUID)
+	}
+	if !found {
+		return pr, nil
+	}
+
+	return c.setPullRequestReviewers(ctx, workspace, repoSlug, pr, uuids)
+}
+
+// setPullRequestReviewers replaces the reviewer list of a pull request.
+// The title is resent because Bitbucket requires it on every update.
+func (c *Client) setPullRequestReviewers(ctx context.Context, workspace, repoSlug string, pr *PullRequest, uuids []string) (*PullRequest, error) {
+	path := fmt.Sprintf("/repositories/%s/%s/pullrequests/%d", workspace, repoSlug, pr.ID)
+
+	reviewers := make([]map[string]string, len(uuids))
+	for i, uuid := range uuids {
+		reviewers[i] = map[string]string{"uuid": uuid}
+	}
+
+	body := map[string]interface{}{
+		"title":     pr.Title,
+		"reviewers": reviewers,
 	}
 
 	resp, err := c.Put(ctx, path, body)
@@ -3,6 +3,8 @@ package api
 import (
 	"context"
 	"encoding/json"
+	"errors"
+	"fmt"
 	"io"
 	"net/http"
 	"net/http/httptest"
@@ -291,6 +293,24 @@ func TestCreatePullRequest(t *testing.T) {
 			statusCode: http.StatusCreated,
 			wantID:     789,
 		},
+		{
+			name: "cross-repo PR from fork",
+			opts: &PRCreateOptions{
+				Title:             "From fork",
+				SourceBranch:      "fix/typo",
+				SourceRepo:        "contributor/repo",
+				DestinationBranch: "main",
+			},
+			response: `{
+				"id": 790,
+				"title": "From fork",
+				"state": "OPEN",
+				"created_on": "2024-01-01T00:00:00Z",
+				"updated_on": "2024-01-01T00:00:00Z"
+			}`,
+			statusCode: http.StatusCreated,
+			wantID:     790,
+		},
 		{
 			name: "PR creation fails - branch not found",
 			opts: &PRCreateOptions{
@@ -362,6 +382,15 @@ func TestCreatePullRequest(t *testing.T) {
 				if branch["name"] != tt.opts.SourceBranch {
 					t.Errorf("expected source branch %q, got %q", tt.opts.SourceBranch, branch["name"])
 				}
+
+				repo, hasRepo := source["repository"].(map[string]interface{})
+				if tt.opts.SourceRepo == "" {
+					if hasRepo {
+						t.Errorf("expected no source repository, got %v", repo)
+					}
+				} else if repo["full_name"] != tt.opts.SourceRepo {
+					t.Errorf("expected source repository %q, got %v", tt.opts.SourceRepo, repo["full_name"])
+				}
 			}
 
 			// Verify result
@@ -986,10 +1015,11 @@ func TestUpdatePullRequest(t *testing.T) {
 
 	client := NewClient(WithBaseURL(server.URL), WithToken("test-token"))
 
-	opts := &PRCreateOptions{
-		Title:             "Updated Title",
-		Description:       "Updated description",
-		DestinationBranch: "develop",
+	title := "Updated Title"
+	description := ""
+	opts := &PRUpdateOptions{
+		Title:       &title,
+		Description: &description,
 	}
 
 	result, err := client.UpdatePullRequest(context.Background(), "workspace", "repo", 700, opts)
@@ -1017,11 +1047,72 @@ func TestUpdatePullRequest(t *testing.T) {
 		t.Errorf("expected title 'Updated Title', got %v", body["title"])
 	}
 
+	// An empty description is sent to clear it; fields that aren't set are
+	// left out so they stay unchanged
+	if description, ok := body["description"]; !ok || description != "" {
+		t.Errorf("expected an empty description, got %v", body["description"])
+	}
+	for _, field := range []string{"destination", "close_source_branch", "reviewers"} {
+		if _, ok := body[field]; ok {
+			t.Errorf("expected %s not to be sent, got %v", field, body[field])
+		}
+	}
+
 	if result.Title != "Updated Title" {
 		t.Errorf("expected result title 'Updated Title', got %q", result.Title)
 	}
 }
 
+func TestUpdatePullRequest_ResendsTitle(t *testing.T) {
+	var receivedBody []byte
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.Method == http.MethodPut {
+			receivedBody, _ = io.ReadAll(r.Body)
+		}
+		w.Write([]byte(`{"id": 700, "title": "Current Title"}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(WithBaseURL(server.URL), WithToken("test-token"))
+
+	description := "New description"
+	branch := "develop"
+	opts := &PRUpdateOptions{
+		Description:       &description,
+		DestinationBranch: &branch,
+	}
+
+	if _, err := client.UpdatePullRequest(context.Background(), "workspace", "repo", 700, opts); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var body struct {
+		Title       *string `json:"title"`
+		Description string  `json:"description"`
+		Destination struct {
+			Branch struct {
+				Name string `json:"name"`
+			} `json:"branch"`
+		} `json:"destination"`
+	}
+	if err := json.Unmarshal(receivedBody, &body); err != nil {
+		t.Fatalf("failed to parse body: %v", err)
+	}
+
+	// Bitbucket rejects updates without a title, so the current one is sent
+	if body.Title == nil || *body.Title != "Current Title" {
+		t.Errorf("expected the current title to be resent, got %v", body.Title)
+	}
+	if body.Description != "New description" {
+		t.Errorf("expected description 'New description', got %q", body.Description)
+	}
+	if body.Destination.Branch.Name != "develop" {
+		t.Errorf("expected destination branch 'develop', got %q", body.Destination.Branch.Name)
+	}
+}
+
 func TestListPRComments(t *testing.T) {
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		if !strings.Contains(r.URL.Path, "/comments") {
@@ -1117,6 +1208,73 @@ func TestAddPRComment(t *testing.T) {
 	}
 }
 
+func TestUpdatePRComment(t *testing.T) {
+	var receivedBody []byte
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPut {
+			t.Errorf("expected PUT, got %s", r.Method)
+		}
+		if r.URL.Path != "/repositories/workspace/repo/pullrequests/900/comments/100" {
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+		receivedBody, _ = io.ReadAll(r.Body)
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"id": 100, "content": {"raw": "Edited"}}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(WithBaseURL(server.URL), WithToken("test-token"))
+
+	comment, err := client.UpdatePRComment(context.Background(), "workspace", "repo", 900, 100, "Edited")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if string(receivedBody) != `{"content":{"raw":"Edited"}}` {
+		t.Errorf("unexpected body: %s", receivedBody)
+	}
+	if comment.Content.Raw != "Edited" {
+		t.Errorf("expected content 'Edited', got %q", comment.Content.Raw)
+	}
+}
+
+func TestDeletePRComment(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodDelete {
+			t.Errorf("expected DELETE, got %s", r.Method)
+		}
+		if r.URL.Path != "/repositories/workspace/repo/pullrequests/900/comments/100" {
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	client := NewClient(WithBaseURL(server.URL), WithToken("test-token"))
+
+	if err := client.DeletePRComment(context.Background(), "workspace", "repo", 900, 100); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestDeletePRComment_Forbidden(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusForbidden)
+		w.Write([]byte(`{"type": "error", "error": {"message": "You cannot delete this comment"}}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(WithBaseURL(server.URL), WithToken("test-token"))
+
+	err := client.DeletePRComment(context.Background(), "workspace", "repo", 900, 100)
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusForbidden {
+		t.Fatalf("expected a 403 APIError, got %v", err)
+	}
+}
+
 func TestGetPullRequestStatuses(t *testing.T) {
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		if !strings.Contains(r.URL.Path, "/statuses") {
@@ -1172,3 +1330,508 @@ func TestGetPullRequestStatuses(t *testing.T) {
 		t.Errorf("expected second status state 'INPROGRESS', got %q", statuses.Values[1].State)
 	}
 }
+
+func TestGetPullRequestDiffStat(t *testing.T) {
+	tests := []struct {
+		name       string
+		prID       int64
+		response   string
+		statusCode int
+		wantCount  int
+		wantErr    bool
+	}{
+		{
+			name: "mixed changes",
+			prID: 42,
+			response: `{
+				"pagelen": 500,
+				"size": 3,
+				"values": [
+					{"status": "modified", "lines_added": 10, "lines_removed": 2, "old": {"path": "main.go"}, "new": {"path": "main.go"}},
+					{"status": "added", "lines_added": 5, "lines_removed": 0, "old": null, "new": {"path": "new.go"}},
+					{"status": "removed", "lines_added": 0, "lines_removed": 7, "old": {"path": "old.go"}, "new": null}
+				]
+			}`,
+			statusCode: http.StatusOK,
+			wantCount:  3,
+		},
+		{
+			name:       "PR not found",
+			prID:       999,
+			response:   `{"error": {"message": "Pull request not found"}}`,
+			statusCode: http.StatusNotFound,
+			wantErr:    true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var receivedReq *http.Request
+
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				receivedReq = r
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(tt.statusCode)
+				w.Write([]byte(tt.response))
+			}))
+			defer server.Close()
+
+			client := NewClient(WithBaseURL(server.URL), WithToken("test-token"))
+
+			result, err := client.GetPullRequestDiffStat(context.Background(), "workspace", "repo", tt.prID)
+
+			if tt.wantErr {
+				if err == nil {
+					t.Error("expected error but got nil")
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			expectedPath := "/repositories/workspace/repo/pullrequests/42/diffstat"
+			if receivedReq.URL.Path != expectedPath {
+				t.Errorf("expected path %s, got %s", expectedPath, receivedReq.URL.Path)
+			}
+
+			if len(result) != tt.wantCount {
+				t.Fatalf("expected %d entries, got %d", tt.wantCount, len(result))
+			}
+
+			if result[0].LinesAdded != 10 || result[0].LinesRemoved != 2 {
+				t.Errorf("unexpected line counts: %+v", result[0])
+			}
+			if got := result[1].Path(); got != "new.go" {
+				t.Errorf("expected added path new.go, got %s", got)
+			}
+			if got := result[2].Path(); got != "old.go" {
+				t.Errorf("expected removed path old.go, got %s", got)
+			}
+		})
+	}
+}
+
+func TestGetPullRequestDiffStat_Pages(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Query().Get("page") == "2" {
+			fmt.Fprint(w, `{"values": [{"status": "added", "new": {"path": "b.go"}}]}`)
+			return
+		}
+		if got := r.URL.Query().Get("pagelen"); got != "500" {
+			t.Errorf("expected pagelen=500, got %q", got)
+		}
+		fmt.Fprintf(w, `{"values": [{"status": "added", "new": {"path": "a.go"}}], "next": "http://%s%s?page=2"}`, r.Host, r.URL.Path)
+	}))
+	defer server.Close()
+
+	client := NewClient(WithBaseURL(server.URL), WithToken("test-token"))
+	entries, err := client.GetPullRequestDiffStat(context.Background(), "workspace", "repo", 42)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(entries) != 2 || entries[0].Path() != "a.go" || entries[1].Path() != "b.go" {
+		t.Errorf("expected the files from both pages, got %+v", entries)
+	}
+}
+
+func TestGetDefaultReviewers(t *testing.T) {
+	tests := []struct {
+		name       string
+		response   string
+		statusCode int
+		wantUUIDs  []string
+		wantErr    bool
+	}{
+		{
+			name: "two default reviewers",
+			response: `{
+				"pagelen": 100,
+				"values": [
+					{"uuid": "{user-1}", "display_name": "Alice", "nickname": "alice"},
+					{"uuid": "{user-2}", "display_name": "Bob", "nickname": "bob"}
+				]
+			}`,
+			statusCode: http.StatusOK,
+			wantUUIDs:  []string{"{user-1}", "{user-2}"},
+		},
+		{
+			name:       "no default reviewers",
+			response:   `{"pagelen": 100, "values": []}`,
+			statusCode: http.StatusOK,
+			wantUUIDs:  []string{},
+		},
+		{
+			name:       "forbidden",
+			response:   `{"error": {"message": "Access denied"}}`,
+			statusCode: http.StatusForbidden,
+			wantErr:    true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var receivedReq *http.Request
+
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				receivedReq = r
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(tt.statusCode)
+				w.Write([]byte(tt.response))
+			}))
+			defer server.Close()
+
+			client := NewClient(WithBaseURL(server.URL), WithToken("test-token"))
+
+			result, err := client.GetDefaultReviewers(context.Background(), "workspace", "repo")
+
+			if tt.wantErr {
+				if err == nil {
+					t.Error("expected error but got nil")
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			expectedPath := "/repositories/workspace/repo/default-reviewers"
+			if receivedReq.URL.Path != expectedPath {
+				t.Errorf("expected path %s, got %s", expectedPath, receivedReq.URL.Path)
+			}
+
+			if len(result.Values) != len(tt.wantUUIDs) {
+				t.Fatalf("expected %d reviewers, got %d", len(tt.wantUUIDs), len(result.Values))
+			}
+			for i, uuid := range tt.wantUUIDs {
+				if result.Values[i].UUID != uuid {
+					t.Errorf("reviewer %d: expected UUID %s, got %s", i, uuid, result.Values[i].UUID)
+				}
+			}
+		})
+	}
+}
+
+func TestWatchPullRequest(t *testing.T) {
+	const prJSON = `{
+		"id": 7,
+		"title": "Add feature",
+		"author": {"uuid": "{author}"},
+		"reviewers": [{"uuid": "{existing}"}]
+	}`
+
+	tests := []struct {
+		name          string
+		userUUID      string
+		unwatch       bool
+		prResponse    string
+		wantPut       bool
+		wantReviewers []string
+		wantErr       bool
+	}{
+		{
+			name:          "watch adds user as reviewer",
+			userUUID:      "{me}",
+			prResponse:    prJSON,
+			wantPut:       true,
+			wantReviewers: []string{"{existing}", "{me}"},
+		},
+		{
+			name:       "watch is a no-op when already a reviewer",
+			userUUID:   "{existing}",
+			prResponse: prJSON,
+			wantPut:    false,
+		},
+		{
+			name:       "author cannot watch",
+			userUUID:   "{author}",
+			prResponse: prJSON,
+			wantErr:    true,
+		},
+		{
+			name:          "unwatch removes user",
+			userUUID:      "{existing}",
+			unwatch:       true,
+			prResponse:    prJSON,
+			wantPut:       true,
+			wantReviewers: []string{},
+		},
+		{
+			name:       "unwatch is a no-op when not a reviewer",
+			userUUID:   "{me}",
+			unwatch:    true,
+			prResponse: prJSON,
+			wantPut:    false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var putReq *http.Request
+			var putBody map[string]interface{}
+
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				if r.Method == http.MethodPut {
+					putReq = r
+					body, _ := io.ReadAll(r.Body)
+					json.Unmarshal(body, &putBody)
+				}
+				w.WriteHeader(http.StatusOK)
+				w.Write([]byte(tt.prResponse))
+			}))
+			defer server.Close()
+
+			client := NewClient(WithBaseURL(server.URL), WithToken("test-token"))
+
+			var err error
+			if tt.unwatch {
+				_, err = client.UnwatchPullRequest(context.Background(), "workspace", "repo", 7, tt.userUUID)
+			} else {
+				_, err = client.WatchPullRequest(context.Background(), "workspace", "repo", 7, tt.userUUID)
+			}
+
+			if tt.wantErr {
+				if err == nil {
+					t.Error("expected error but got nil")
+				}
+				if putReq != nil {
+					t.Error("expected no update request")
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if !tt.wantPut {
+				if putReq != nil {
+					t.Error("expected no update request")
+				}
+				return
+			}
+
+			if putReq == nil {
+				t.Fatal("expected an update request")
+			}
+			if putReq.URL.Path != "/repositories/workspace/repo/pullrequests/7" {
+				t.Errorf("unexpected path: %s", putReq.URL.Path)
+			}
+			if putBody["title"] != "Add feature" {
+				t.Errorf("expected title to be resent, got %v", putBody["title"])
+			}
+
+			reviewers, _ := putBody["reviewers"].([]interface{})
+			var got []string
+			for _, r := range reviewers {
+				got = append(got, r.(map[string]interface{})["uuid"].(string))
+			}
+			if len(got) != len(tt.wantReviewers) {
+				t.Fatalf("expected reviewers %v, got %v", tt.wantReviewers, got)
+			}
+			for i := range got {
+				if got[i] != tt.wantReviewers[i] {
+					t.Errorf("expected reviewers %v, got %v", tt.wantReviewers, got)
+				}
+			}
+		})
+	}
+}
+
+func TestListPullRequestsDateRange(t *testing.T) {
+	since := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	until := time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name      string
+		opts      *PRListOptions
+		wantQuery string
+	}{
+		{
+			name:      "window only",
+			opts:      &PRListOptions{Since: since, Until: until},
+			wantQuery: "created_on >= 2024-01-01T00:00:00Z AND created_on < 2024-02-01T00:00:00Z",
+		},
+		{
+			name:      "combined with author",
+			opts:      &PRListOptions{Author: "jdoe", Since: since},
+			wantQuery: `author.username="jdoe" AND created_on >= 2024-01-01T00:00:00Z`,
+		},
+		{
+			name:      "reviewer by UUID",
+			opts:      &PRListOptions{Reviewer: "{abc-123}"},
+			wantQuery: `reviewers.uuid="{abc-123}"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if got := r.URL.Query().Get("q"); got != tt.wantQuery {
+					t.Errorf("expected q=%q, got %q", tt.wantQuery, got)
+				}
+				w.Header().Set("Content-Type", "application/json")
+				w.Write([]byte(`{"values": []}`))
+			}))
+			defer server.Close()
+
+			client := NewClient(WithBaseURL(server.URL), WithToken("test-token"))
+			if _, err := client.ListPullRequests(context.Background(), "ws", "repo", tt.opts); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+		})
+	}
+}
+
+func TestGetPullRequestPatch(t *testing.T) {
+	patch := `From abc123 Mon Sep 17 00:00:00 2001
+From: Jane Doe <jane@example.com>
+Subject: [PATCH] Add feature
+
+---
+ file.txt | 1 +
+`
+	var gotPath, gotAccept string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPath = r.URL.Path
+		gotAccept = r.Header.Get("Accept")
+		w.Header().Set("Content-Type", "text/plain")
+		w.Write([]byte(patch))
+	}))
+	defer server.Close()
+
+	client := NewClient(WithBaseURL(server.URL), WithToken("test-token"))
+
+	result, err := client.GetPullRequestPatch(context.Background(), "workspace", "repo", 42)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result != patch {
+		t.Errorf("patch = %q, want %q", result, patch)
+	}
+	if gotPath != "/repositories/workspace/repo/pullrequests/42/patch" {
+		t.Errorf("path = %s, want the patch endpoint", gotPath)
+	}
+	if gotAccept != "text/plain" {
+		t.Errorf("Accept = %q, want text/plain", gotAccept)
+	}
+
+	reader, err := client.GetPullRequestPatchReader(context.Background(), "workspace", "repo", 42)
+	if err != nil {
+		t.Fatalf("GetPullRequestPatchReader() error: %v", err)
+	}
+	defer reader.Close()
+	streamed, err := io.ReadAll(reader)
+	if err != nil {
+		t.Fatalf("failed to read patch stream: %v", err)
+	}
+	if string(streamed) != patch {
+		t.Errorf("streamed patch = %q, want %q", streamed, patch)
+	}
+	if gotAccept != "text/plain" {
+		t.Errorf("streamed Accept = %q, want text/plain", gotAccept)
+	}
+}
+
+func TestAddPullRequestReviewers(t *testing.T) {
+	const prJSON = `{
+		"id": 7,
+		"title": "Add feature",
+		"author": {"uuid": "{author}"},
+		"reviewers": [{"uuid": "{existing}"}]
+	}`
+
+	tests := []struct {
+		name          string
+		add           []string
+		wantPut       bool
+		wantReviewers []string
+		wantErr       bool
+	}{
+		{
+			name:          "keeps existing reviewers",
+			add:           []string{"{alice}", "{bob}"},
+			wantPut:       true,
+			wantReviewers: []string{"{existing}", "{alice}", "{bob}"},
+		},
+		{
+			name:          "skips users who already review",
+			add:           []string{"{existing}", "{alice}", "{alice}"},
+			wantPut:       true,
+			wantReviewers: []string{"{existing}", "{alice}"},
+		},
+		{
+			name:    "no-op when everyone already reviews",
+			add:     []string{"{existing}"},
+			wantPut: false,
+		},
+		{
+			name:    "author cannot be added",
+			add:     []string{"{alice}", "{author}"},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var putBody *struct {
+				Title     string `json:"title"`
+				Reviewers []struct {
+					UUID string `json:"uuid"`
+				} `json:"reviewers"`
+			}
+
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				if r.Method == http.MethodPut {
+					body, _ := io.ReadAll(r.Body)
+					if err := json.Unmarshal(body, &putBody); err != nil {
+						t.Errorf("invalid update body: %v", err)
+					}
+				}
+				w.Write([]byte(prJSON))
+			}))
+			defer server.Close()
+
+			client := NewClient(WithBaseURL(server.URL), WithToken("test-token"))
+			_, err := client.AddPullRequestReviewers(context.Background(), "workspace", "repo", 7, tt.add)
+
+			if tt.wantErr {
+				if err == nil {
+					t.Error("expected error but got nil")
+				}
+				if putBody != nil {
+					t.Error("expected no update request")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if !tt.wantPut {
+				if putBody != nil {
+					t.Error("expected no update request")
+				}
+				return
+			}
+			if putBody == nil {
+				t.Fatal("expected an update request")
+			}
+			if putBody.Title != "Add feature" {
+				t.Errorf("expected the title to be resent, got %q", putBody.Title)
+			}
+			var got []string
+			for _, r := range putBody.Reviewers {
+				got = append(got, r.UUID)
+			}
+			if strings.Join(got, ",") != strings.Join(tt.wantReviewers, ",") {
+				t.Errorf("expected reviewers %v, got %v", tt.wantReviewers, got)
+			}
+		})
+	}
+}
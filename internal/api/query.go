@@ -0,0 +1,94 @@
+package api
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Query builds a Bitbucket query language expression for the q parameter.
+// Clauses are AND-ed together in the order they are added, and values are
+// quoted and escaped, so input such as a name containing a quote can't
+// change the meaning of the query. The zero value is an empty query.
+type Query struct {
+	clauses []queryClause
+}
+
+// queryClause is one AND-ed part of a Query
+type queryClause struct {
+	expr     string
+	compound bool // Contains OR, so needs parentheses next to other clauses
+}
+
+// quoteQueryValue quotes s as a query language string literal
+func quoteQueryValue(s string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(s) + `"`
+}
+
+// Equals matches field exactly against value
+func (q *Query) Equals(field, value string) {
+	q.clauses = append(q.clauses, queryClause{expr: field + "=" + quoteQueryValue(value)})
+}
+
+// Contains matches field containing value, ignoring case
+func (q *Query) Contains(field, value string) {
+	q.clauses = append(q.clauses, queryClause{expr: field + "~" + quoteQueryValue(value)})
+}
+
+// AnyOf matches field against any of values
+func (q *Query) AnyOf(field string, values ...string) {
+	if len(values) == 0 {
+		return
+	}
+	exprs := make([]string, len(values))
+	for i, v := range values {
+		exprs[i] = field + "=" + quoteQueryValue(v)
+	}
+	q.clauses = append(q.clauses, queryClause{expr: strings.Join(exprs, " OR "), compound: len(exprs) > 1})
+}
+
+// User matches the user in field, such as "author" or "reviewers". A UUID
+// ({...}) is matched exactly; anything else is treated as a username.
+func (q *Query) User(field, user string) {
+	if strings.HasPrefix(user, "{") && strings.HasSuffix(user, "}") {
+		q.Equals(field+".uuid", user)
+		return
+	}
+	q.Equals(field+".username", user)
+}
+
+// DateRange restricts field to [since, until). Zero times leave that side
+// of the range open. Times are sent in UTC, which is how Bitbucket compares
+// them.
+func (q *Query) DateRange(field string, since, until time.Time) {
+	if !since.IsZero() {
+		q.clauses = append(q.clauses, queryClause{expr: fmt.Sprintf("%s >= %s", field, since.UTC().Format(time.RFC3339))})
+	}
+	if !until.IsZero() {
+		q.clauses = append(q.clauses, queryClause{expr: fmt.Sprintf("%s < %s", field, until.UTC().Format(time.RFC3339))})
+	}
+}
+
+// Raw adds an expression written by the caller, such as a --query flag,
+// unchanged. It is parenthesized when combined with other clauses so an OR
+// inside it can't escape. An empty expression is ignored.
+func (q *Query) Raw(expr string) {
+	if expr = strings.TrimSpace(expr); expr != "" {
+		q.clauses = append(q.clauses, queryClause{expr: expr, compound: true})
+	}
+}
+
+// String returns the expression, or "" if no clauses were added
+func (q *Query) String() string {
+	if len(q.clauses) == 1 {
+		return q.clauses[0].expr
+	}
+	exprs := make([]string, len(q.clauses))
+	for i, c := range q.clauses {
+		exprs[i] = c.expr
+		if c.compound {
+			exprs[i] = "(" + c.expr + ")"
+		}
+	}
+	return strings.Join(exprs, " AND ")
+}
@@ -0,0 +1,99 @@
+package api
+
+import (
+	"testing"
+	"time"
+)
+
+func TestQuery(t *testing.T) {
+	since := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name  string
+		build func(q *Query)
+		want  string
+	}{
+		{
+			name:  "empty",
+			build: func(q *Query) {},
+			want:  "",
+		},
+		{
+			name:  "single clause",
+			build: func(q *Query) { q.Equals("state", "open") },
+			want:  `state="open"`,
+		},
+		{
+			name: "clauses are AND-ed in order",
+			build: func(q *Query) {
+				q.Equals("state", "open")
+				q.Equals("kind", "bug")
+			},
+			want: `state="open" AND kind="bug"`,
+		},
+		{
+			name:  "quotes and backslashes are escaped",
+			build: func(q *Query) { q.Contains("name", `say "hi" \o/`) },
+			want:  `name~"say \"hi\" \\o/"`,
+		},
+		{
+			name:  "a quote can't close the value early",
+			build: func(q *Query) { q.Equals("author.username", `x" OR state="MERGED`) },
+			want:  `author.username="x\" OR state=\"MERGED"`,
+		},
+		{
+			name:  "user by username",
+			build: func(q *Query) { q.User("author", "jdoe") },
+			want:  `author.username="jdoe"`,
+		},
+		{
+			name:  "user by UUID",
+			build: func(q *Query) { q.User("reviewers", "{abc-123}") },
+			want:  `reviewers.uuid="{abc-123}"`,
+		},
+		{
+			name:  "open-ended date range",
+			build: func(q *Query) { q.DateRange("created_on", since, time.Time{}) },
+			want:  "created_on >= 2024-01-01T00:00:00Z",
+		},
+		{
+			name:  "lone AnyOf is not parenthesized",
+			build: func(q *Query) { q.AnyOf("permission", "write", "admin") },
+			want:  `permission="write" OR permission="admin"`,
+		},
+		{
+			name: "AnyOf is parenthesized next to other clauses",
+			build: func(q *Query) {
+				q.Equals("is_private", "true")
+				q.AnyOf("permission", "write", "admin")
+			},
+			want: `is_private="true" AND (permission="write" OR permission="admin")`,
+		},
+		{
+			name: "raw expressions keep their OR inside parentheses",
+			build: func(q *Query) {
+				q.Equals("project.key", "PROJ")
+				q.Raw(`name ~ "api" OR name ~ "web"`)
+			},
+			want: `project.key="PROJ" AND (name ~ "api" OR name ~ "web")`,
+		},
+		{
+			name: "empty raw expression is ignored",
+			build: func(q *Query) {
+				q.Equals("state", "open")
+				q.Raw("  ")
+			},
+			want: `state="open"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var q Query
+			tt.build(&q)
+			if got := q.String(); got != tt.want {
+				t.Errorf("String() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
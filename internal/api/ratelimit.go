@@ -0,0 +1,89 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// RateLimit is the request quota Bitbucket reported in the X-RateLimit-*
+// headers of a response. Bitbucket does not always send every header.
+type RateLimit struct {
+	Limit     int       `json:"limit,omitempty"`     // Requests allowed per window; 0 if not reported
+	Remaining int       `json:"remaining"`           // Requests left in the window; -1 if not reported
+	Reset     time.Time `json:"reset,omitzero"`      // When the window resets; zero if not reported
+	Resource  string    `json:"resource,omitempty"`  // The quota the limit applies to
+	NearLimit bool      `json:"near_limit,omitzero"` // Set by Bitbucket when less than 20% remains
+}
+
+// parseRateLimit reads the rate limit headers of a response. It returns nil
+// if there are none.
+func parseRateLimit(h http.Header) *RateLimit {
+	found := false
+	header := func(name string) string {
+		v := strings.TrimSpace(h.Get(name))
+		if v != "" {
+			found = true
+		}
+		return v
+	}
+
+	rl := &RateLimit{Remaining: -1}
+	if n, err := strconv.Atoi(header("X-RateLimit-Limit")); err == nil {
+		rl.Limit = n
+	}
+	if n, err := strconv.Atoi(header("X-RateLimit-Remaining")); err == nil {
+		rl.Remaining = n
+	}
+	if secs, err := strconv.ParseInt(header("X-RateLimit-Reset"), 10, 64); err == nil {
+		rl.Reset = time.Unix(secs, 0)
+	}
+	rl.Resource = header("X-RateLimit-Resource")
+	rl.NearLimit, _ = strconv.ParseBool(header("X-RateLimit-NearLimit"))
+
+	if !found {
+		return nil
+	}
+	return rl
+}
+
+// recordRateLimit remembers the rate limit reported by a response
+func (c *Client) recordRateLimit(h http.Header) {
+	rl := parseRateLimit(h)
+	if rl == nil {
+		return
+	}
+	c.rateMu.Lock()
+	c.rateLimit = rl
+	c.rateMu.Unlock()
+}
+
+// LastRateLimit returns the rate limit reported by the most recent response
+// that carried one, or nil if none has.
+func (c *Client) LastRateLimit() *RateLimit {
+	c.rateMu.Lock()
+	defer c.rateMu.Unlock()
+	if c.rateLimit == nil {
+		return nil
+	}
+	rl := *c.rateLimit
+	return &rl
+}
+
+// GetRateLimitStatus returns the remaining request quota. Bitbucket has no
+// endpoint for this, so it is taken from the headers of the last response,
+// making a lightweight request first if nothing has been sent yet. It
+// returns nil if Bitbucket does not report a limit.
+func (c *Client) GetRateLimitStatus(ctx context.Context) (*RateLimit, error) {
+	if rl := c.LastRateLimit(); rl != nil {
+		return rl, nil
+	}
+
+	// Any response carries the headers, so the status doesn't matter
+	if _, err := c.GetRaw(ctx, "/user", nil); err != nil {
+		return nil, err
+	}
+	return c.LastRateLimit(), nil
+}
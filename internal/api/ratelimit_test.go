@@ -0,0 +1,85 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestParseRateLimit(t *testing.T) {
+	if rl := parseRateLimit(http.Header{"Content-Type": {"application/json"}}); rl != nil {
+		t.Errorf("parseRateLimit(no headers) = %+v, want nil", rl)
+	}
+
+	h := http.Header{}
+	h.Set("X-RateLimit-Limit", "1000")
+	h.Set("X-RateLimit-Remaining", "42")
+	h.Set("X-RateLimit-Reset", "1700000000")
+	h.Set("X-RateLimit-Resource", "api")
+	h.Set("X-RateLimit-NearLimit", "true")
+	rl := parseRateLimit(h)
+	if rl == nil {
+		t.Fatal("parseRateLimit() = nil")
+	}
+	if rl.Limit != 1000 || rl.Remaining != 42 || rl.Resource != "api" || !rl.NearLimit {
+		t.Errorf("parseRateLimit() = %+v", rl)
+	}
+	if !rl.Reset.Equal(time.Unix(1700000000, 0)) {
+		t.Errorf("Reset = %v", rl.Reset)
+	}
+
+	partial := http.Header{}
+	partial.Set("X-RateLimit-Limit", "1000")
+	if rl := parseRateLimit(partial); rl == nil || rl.Remaining != -1 || !rl.Reset.IsZero() {
+		t.Errorf("parseRateLimit(limit only) = %+v, want an unknown remaining count", rl)
+	}
+}
+
+func TestGetRateLimitStatus(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		if r.URL.Path != "/user" {
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+		w.Header().Set("X-RateLimit-Limit", "1000")
+		w.Header().Set("X-RateLimit-Remaining", "999")
+		// Token types that can't read /user still get the headers
+		w.WriteHeader(http.StatusForbidden)
+	}))
+	defer server.Close()
+
+	client := NewClient(WithBaseURL(server.URL), WithToken("test-token"))
+	if rl := client.LastRateLimit(); rl != nil {
+		t.Errorf("LastRateLimit() before any request = %+v, want nil", rl)
+	}
+
+	for i := 0; i < 2; i++ {
+		rl, err := client.GetRateLimitStatus(context.Background())
+		if err != nil {
+			t.Fatalf("GetRateLimitStatus() error: %v", err)
+		}
+		if rl == nil || rl.Limit != 1000 || rl.Remaining != 999 {
+			t.Errorf("GetRateLimitStatus() = %+v", rl)
+		}
+	}
+	if n := calls.Load(); n != 1 {
+		t.Errorf("made %d requests, want 1 (the second call should reuse the recorded headers)", n)
+	}
+}
+
+func TestGetRateLimitStatus_NotReported(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"uuid": "{me}"}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(WithBaseURL(server.URL))
+	rl, err := client.GetRateLimitStatus(context.Background())
+	if err != nil || rl != nil {
+		t.Errorf("GetRateLimitStatus() = %+v, %v; want nil, nil", rl, err)
+	}
+}
@@ -2,7 +2,9 @@ package api
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"net/http"
 	"net/url"
 	"strconv"
 	"time"
@@ -74,11 +76,12 @@ type MainBranch struct {
 
 // RepositoryListOptions are options for listing repositories
 type RepositoryListOptions struct {
-	Role  string // Filter by role: owner, admin, contributor, member
-	Sort  string // Sort field: name, -updated_on, etc.
-	Query string // Filter query (Bitbucket query language)
-	Page  int    // Page number
-	Limit int    // Number of items per page (pagelen)
+	Role    string // Filter by role: owner, admin, contributor, member
+	Sort    string // Sort field: name, -updated_on, etc.
+	Query   string // Filter query (Bitbucket query language)
+	Project string // Only repositories in the project with this key
+	Page    int    // Page number
+	Limit   int    // Number of items per page (pagelen)
 }
 
 // RepositoryCreateOptions are options for creating a repository
@@ -109,6 +112,20 @@ type repositoryCreateRequest struct {
 	HasWiki   bool `json:"has_wiki,omitempty"`
 }
 
+// RepositoryUpdateOptions are options for updating a repository. Only
+// non-nil fields are sent, so unchanged settings are left as they are.
+type RepositoryUpdateOptions struct {
+	Description *string     `json:"description,omitempty"`
+	IsPrivate   *bool       `json:"is_private,omitempty"`
+	ForkPolicy  *string     `json:"fork_policy,omitempty"` // allow_forks, no_public_forks, no_forks
+	Project     *ProjectRef `json:"project,omitempty"`     // moves the repository to another project
+}
+
+// ProjectRef refers to a project by key in request bodies
+type ProjectRef struct {
+	Key string `json:"key"`
+}
+
 // forkRepositoryRequest is the API request body for forking a repository
 type forkRepositoryRequest struct {
 	Name      string `json:"name,omitempty"`
@@ -129,8 +146,13 @@ func (c *Client) ListRepositories(ctx context.Context, workspace string, opts *R
 		if opts.Sort != "" {
 			query.Set("sort", opts.Sort)
 		}
-		if opts.Query != "" {
-			query.Set("q", opts.Query)
+		var q Query
+		if opts.Project != "" {
+			q.Equals("project.key", opts.Project)
+		}
+		q.Raw(opts.Query)
+		if s := q.String(); s != "" {
+			query.Set("q", s)
 		}
 		if opts.Page > 0 {
 			query.Set("page", strconv.Itoa(opts.Page))
@@ -190,6 +212,18 @@ func (c *Client) CreateRepository(ctx context.Context, workspace string, opts *R
 	return ParseResponse[*RepositoryFull](resp)
 }
 
+// UpdateRepository changes the settings of a repository
+func (c *Client) UpdateRepository(ctx context.Context, workspace, repoSlug string, opts *RepositoryUpdateOptions) (*RepositoryFull, error) {
+	path := fmt.Sprintf("/repositories/%s/%s", workspace, repoSlug)
+
+	resp, err := c.Put(ctx, path, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	return ParseResponse[*RepositoryFull](resp)
+}
+
 // DeleteRepository deletes a repository
 func (c *Client) DeleteRepository(ctx context.Context, workspace, repoSlug string) error {
 	path := fmt.Sprintf("/repositories/%s/%s", workspace, repoSlug)
@@ -219,3 +253,124 @@ func (c *Client) ForkRepository(ctx context.Context, workspace, repoSlug string,
 
 	return ParseResponse[*RepositoryFull](resp)
 }
+
+// ForkListOptions are options for listing the forks of a repository
+type ForkListOptions struct {
+	Sort  string // Sort field: -created_on, name, etc.
+	Page  int    // Page number
+	Limit int    // Number of items per page (pagelen)
+}
+
+// ListRepositoryForks lists the forks of a repository
+func (c *Client) ListRepositoryForks(ctx context.Context, workspace, repoSlug string, opts *ForkListOptions) (*Paginated[RepositoryFull], error) {
+	path := fmt.Sprintf("/repositories/%s/%s/forks", workspace, repoSlug)
+
+	query := url.Values{}
+	if opts != nil {
+		if opts.Sort != "" {
+			query.Set("sort", opts.Sort)
+		}
+		if opts.Page > 0 {
+			query.Set("page", strconv.Itoa(opts.Page))
+		}
+		if opts.Limit > 0 {
+			query.Set("pagelen", strconv.Itoa(opts.Limit))
+		}
+	}
+
+	resp, err := c.Get(ctx, path, query)
+	if err != nil {
+		return nil, err
+	}
+
+	return ParseResponse[*Paginated[RepositoryFull]](resp)
+}
+
+// ErrWatchNotSupported is returned when Bitbucket rejects a change to the
+// watch state of a repository. The watchers endpoint is documented as
+// read-only, so watching may have to be done in the web UI.
+var ErrWatchNotSupported = errors.New("changing repository watch state is not supported by the Bitbucket API; use the Watch button in the web UI")
+
+// WatcherListOptions are options for listing repository watchers
+type WatcherListOptions struct {
+	Page  int // Page number
+	Limit int // Number of items per page (pagelen)
+}
+
+// ListWatchers lists the users watching a repository
+func (c *Client) ListWatchers(ctx context.Context, workspace, repoSlug string, opts *WatcherListOptions) (*Paginated[User], error) {
+	path := fmt.Sprintf("/repositories/%s/%s/watchers", workspace, repoSlug)
+
+	query := url.Values{}
+	if opts != nil {
+		if opts.Page > 0 {
+			query.Set("page", strconv.Itoa(opts.Page))
+		}
+		if opts.Limit > 0 {
+			query.Set("pagelen", strconv.Itoa(opts.Limit))
+		}
+	}
+
+	resp, err := c.Get(ctx, path, query)
+	if err != nil {
+		return nil, err
+	}
+
+	return ParseResponse[*Paginated[User]](resp)
+}
+
+// IsWatchingRepository reports whether the user is watching a repository
+func (c *Client) IsWatchingRepository(ctx context.Context, workspace, repoSlug, userUUID string) (bool, error) {
+	for page := 1; ; page++ {
+		result, err := c.ListWatchers(ctx, workspace, repoSlug, &WatcherListOptions{Page: page, Limit: 100})
+		if err != nil {
+			return false, err
+		}
+		for _, u := range result.Values {
+			if u.UUID == userUUID {
+				return true, nil
+			}
+		}
+		if result.Next == "" || len(result.Values) == 0 {
+			return false, nil
+		}
+	}
+}
+
+// WatchRepository makes the user watch a repository. It reports whether
+// the user was already watching, in which case nothing is changed.
+func (c *Client) WatchRepository(ctx context.Context, workspace, repoSlug, userUUID string) (bool, error) {
+	return c.setWatching(ctx, workspace, repoSlug, userUUID, true)
+}
+
+// UnwatchRepository stops the user watching a repository. It reports
+// whether the user was watching before the call.
+func (c *Client) UnwatchRepository(ctx context.Context, workspace, repoSlug, userUUID string) (bool, error) {
+	return c.setWatching(ctx, workspace, repoSlug, userUUID, false)
+}
+
+func (c *Client) setWatching(ctx context.Context, workspace, repoSlug, userUUID string, watch bool) (bool, error) {
+	watching, err := c.IsWatchingRepository(ctx, workspace, repoSlug, userUUID)
+	if err != nil {
+		return false, err
+	}
+	if watching == watch {
+		return watching, nil
+	}
+
+	path := fmt.Sprintf("/repositories/%s/%s/watchers/%s", workspace, repoSlug, url.PathEscape(userUUID))
+	method := http.MethodPut
+	if !watch {
+		method = http.MethodDelete
+	}
+
+	if _, err := c.Do(ctx, &Request{Method: method, Path: path}); err != nil {
+		var apiErr *APIError
+		if errors.As(err, &apiErr) && (apiErr.StatusCode == http.StatusNotFound || apiErr.StatusCode == http.StatusMethodNotAllowed) {
+			return watching, ErrWatchNotSupported
+		}
+		return watching, err
+	}
+
+	return watching, nil
+}
@@ -3,6 +3,7 @@ package api
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"io"
 	"net/http"
 	"net/http/httptest"
@@ -1052,3 +1053,178 @@ func TestCreateRepositoryRequiredFields(t *testing.T) {
 		t.Error("expected is_private to be present in body")
 	}
 }
+
+func TestUpdateRepository(t *testing.T) {
+	private := true
+	policy := "no_forks"
+	var body map[string]interface{}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPut {
+			t.Errorf("expected PUT method, got %s", r.Method)
+		}
+		if r.URL.Path != "/repositories/myworkspace/myrepo" {
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Fatalf("failed to decode body: %v", err)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"full_name": "myworkspace/myrepo", "is_private": true, "fork_policy": "no_forks"}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(WithBaseURL(server.URL), WithToken("test-token"))
+	repo, err := client.UpdateRepository(context.Background(), "myworkspace", "myrepo", &RepositoryUpdateOptions{
+		IsPrivate:  &private,
+		ForkPolicy: &policy,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !repo.IsPrivate || repo.ForkPolicy != "no_forks" {
+		t.Errorf("unexpected repository: %+v", repo)
+	}
+
+	want := map[string]interface{}{"is_private": true, "fork_policy": "no_forks"}
+	if len(body) != len(want) || body["is_private"] != true || body["fork_policy"] != "no_forks" {
+		t.Errorf("expected body %v, got %v", want, body)
+	}
+}
+
+func TestListRepositoryForks(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/repositories/myworkspace/myrepo/forks" {
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+		if got := r.URL.Query().Get("sort"); got != "-created_on" {
+			t.Errorf("expected sort=-created_on, got %q", got)
+		}
+		if got := r.URL.Query().Get("pagelen"); got != "10" {
+			t.Errorf("expected pagelen=10, got %q", got)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"size": 1, "values": [{"full_name": "alice/myrepo", "owner": {"display_name": "Alice"}, "parent": {"full_name": "myworkspace/myrepo"}}]}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(WithBaseURL(server.URL), WithToken("test-token"))
+	result, err := client.ListRepositoryForks(context.Background(), "myworkspace", "myrepo", &ForkListOptions{Sort: "-created_on", Limit: 10})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(result.Values) != 1 || result.Values[0].FullName != "alice/myrepo" {
+		t.Fatalf("unexpected forks: %+v", result.Values)
+	}
+	if result.Values[0].Parent == nil || result.Values[0].Parent.FullName != "myworkspace/myrepo" {
+		t.Errorf("expected parent myworkspace/myrepo, got %+v", result.Values[0].Parent)
+	}
+}
+
+func TestListWatchers(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/repositories/myworkspace/myrepo/watchers" {
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+		if got := r.URL.Query().Get("pagelen"); got != "25" {
+			t.Errorf("expected pagelen=25, got %q", got)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"size": 2, "values": [{"uuid": "{a}", "display_name": "Alice"}, {"uuid": "{b}", "display_name": "Bob"}]}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(WithBaseURL(server.URL), WithToken("test-token"))
+	result, err := client.ListWatchers(context.Background(), "myworkspace", "myrepo", &WatcherListOptions{Limit: 25})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(result.Values) != 2 || result.Values[1].DisplayName != "Bob" {
+		t.Errorf("unexpected watchers: %+v", result.Values)
+	}
+}
+
+func TestWatchRepository(t *testing.T) {
+	tests := []struct {
+		name         string
+		watchers     string
+		writeStatus  int
+		unwatch      bool
+		wantPrevious bool
+		wantMethod   string
+		wantErr      error
+	}{
+		{
+			name:       "starts watching",
+			watchers:   `{"values": [{"uuid": "{other}"}]}`,
+			wantMethod: http.MethodPut,
+		},
+		{
+			name:         "already watching",
+			watchers:     `{"values": [{"uuid": "{me}"}]}`,
+			wantPrevious: true,
+		},
+		{
+			name:         "stops watching",
+			watchers:     `{"values": [{"uuid": "{me}"}]}`,
+			unwatch:      true,
+			wantPrevious: true,
+			wantMethod:   http.MethodDelete,
+		},
+		{
+			name:        "write endpoint unsupported",
+			watchers:    `{"values": []}`,
+			writeStatus: http.StatusMethodNotAllowed,
+			wantMethod:  http.MethodPut,
+			wantErr:     ErrWatchNotSupported,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var writeMethod string
+
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.Method == http.MethodGet {
+					w.Header().Set("Content-Type", "application/json")
+					w.Write([]byte(tt.watchers))
+					return
+				}
+				writeMethod = r.Method
+				if r.URL.Path != "/repositories/myworkspace/myrepo/watchers/{me}" {
+					t.Errorf("unexpected write path %s", r.URL.Path)
+				}
+				if tt.writeStatus != 0 {
+					w.WriteHeader(tt.writeStatus)
+					return
+				}
+				w.WriteHeader(http.StatusNoContent)
+			}))
+			defer server.Close()
+
+			client := NewClient(WithBaseURL(server.URL), WithToken("test-token"))
+
+			var previous bool
+			var err error
+			if tt.unwatch {
+				previous, err = client.UnwatchRepository(context.Background(), "myworkspace", "myrepo", "{me}")
+			} else {
+				previous, err = client.WatchRepository(context.Background(), "myworkspace", "myrepo", "{me}")
+			}
+
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Errorf("expected error %v, got %v", tt.wantErr, err)
+				}
+			} else if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if previous != tt.wantPrevious {
+				t.Errorf("expected previous state %v, got %v", tt.wantPrevious, previous)
+			}
+			if writeMethod != tt.wantMethod {
+				t.Errorf("expected write method %q, got %q", tt.wantMethod, writeMethod)
+			}
+		})
+	}
+}
@@ -0,0 +1,92 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+)
+
+// BranchRestriction is a rule limiting what can be done to matching branches
+type BranchRestriction struct {
+	ID              int     `json:"id,omitempty"`
+	Kind            string  `json:"kind"`                        // push, force, delete, restrict_merges, ...
+	BranchMatchKind string  `json:"branch_match_kind,omitempty"` // glob or branching_model
+	Pattern         string  `json:"pattern,omitempty"`
+	BranchType      string  `json:"branch_type,omitempty"` // with branching_model: feature, release, ...
+	Value           *int    `json:"value,omitempty"`       // e.g. the number of approvals a merge needs
+	Users           []User  `json:"users"`
+	Groups          []Group `json:"groups"`
+}
+
+// Group is a workspace user group, as referenced by branch restrictions and
+// repository permissions
+type Group struct {
+	Slug string `json:"slug"`
+	Name string `json:"name,omitempty"`
+}
+
+// BranchRestrictionListOptions are options for listing branch restrictions
+type BranchRestrictionListOptions struct {
+	Kind    string // Only restrictions of this kind
+	Pattern string // Only restrictions with this exact pattern
+	Page    int    // Page number
+	Limit   int    // Number of items per page (pagelen)
+}
+
+// ListBranchRestrictions lists the branch restrictions of a repository
+func (c *Client) ListBranchRestrictions(ctx context.Context, workspace, repoSlug string, opts *BranchRestrictionListOptions) (*Paginated[BranchRestriction], error) {
+	path := fmt.Sprintf("/repositories/%s/%s/branch-restrictions", workspace, repoSlug)
+
+	query := url.Values{}
+	if opts != nil {
+		if opts.Kind != "" {
+			query.Set("kind", opts.Kind)
+		}
+		if opts.Pattern != "" {
+			query.Set("pattern", opts.Pattern)
+		}
+		if opts.Page > 0 {
+			query.Set("page", strconv.Itoa(opts.Page))
+		}
+		if opts.Limit > 0 {
+			query.Set("pagelen", strconv.Itoa(opts.Limit))
+		}
+	}
+
+	resp, err := c.Get(ctx, path, query)
+	if err != nil {
+		return nil, err
+	}
+
+	return ParseResponse[*Paginated[BranchRestriction]](resp)
+}
+
+// CreateBranchRestriction adds a branch restriction to a repository. Empty
+// Users and Groups mean the restriction applies to everyone.
+func (c *Client) CreateBranchRestriction(ctx context.Context, workspace, repoSlug string, restriction *BranchRestriction) (*BranchRestriction, error) {
+	path := fmt.Sprintf("/repositories/%s/%s/branch-restrictions", workspace, repoSlug)
+
+	body := *restriction
+	if body.Users == nil {
+		body.Users = []User{}
+	}
+	if body.Groups == nil {
+		body.Groups = []Group{}
+	}
+
+	resp, err := c.Post(ctx, path, body)
+	if err != nil {
+		return nil, err
+	}
+
+	return ParseResponse[*BranchRestriction](resp)
+}
+
+// DeleteBranchRestriction removes a branch restriction by ID
+func (c *Client) DeleteBranchRestriction(ctx context.Context, workspace, repoSlug string, id int) error {
+	path := fmt.Sprintf("/repositories/%s/%s/branch-restrictions/%d", workspace, repoSlug, id)
+
+	_, err := c.Delete(ctx, path)
+	return err
+}
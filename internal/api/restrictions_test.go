@@ -0,0 +1,76 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestListBranchRestrictions(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/repositories/myworkspace/myrepo/branch-restrictions" {
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+		if got := r.URL.Query().Get("kind"); got != "push" {
+			t.Errorf("expected kind=push, got %q", got)
+		}
+		if got := r.URL.Query().Get("pattern"); got != "*" {
+			t.Errorf("expected pattern=*, got %q", got)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"values": [{"id": 7, "kind": "push", "pattern": "*", "users": [], "groups": []}]}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(WithBaseURL(server.URL), WithToken("test-token"))
+	result, err := client.ListBranchRestrictions(context.Background(), "myworkspace", "myrepo", &BranchRestrictionListOptions{Kind: "push", Pattern: "*"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(result.Values) != 1 || result.Values[0].ID != 7 {
+		t.Errorf("unexpected restrictions: %+v", result.Values)
+	}
+}
+
+func TestCreateBranchRestriction(t *testing.T) {
+	var body map[string]interface{}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			t.Errorf("expected POST method, got %s", r.Method)
+		}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Fatalf("failed to decode body: %v", err)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte(`{"id": 12, "kind": "push", "pattern": "*"}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(WithBaseURL(server.URL), WithToken("test-token"))
+	created, err := client.CreateBranchRestriction(context.Background(), "myworkspace", "myrepo", &BranchRestriction{
+		Kind:            "push",
+		BranchMatchKind: "glob",
+		Pattern:         "*",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if created.ID != 12 {
+		t.Errorf("expected ID 12, got %d", created.ID)
+	}
+
+	// Empty lists must be sent so that the restriction applies to everyone
+	if users, ok := body["users"].([]interface{}); !ok || len(users) != 0 {
+		t.Errorf("expected empty users list, got %v", body["users"])
+	}
+	if groups, ok := body["groups"].([]interface{}); !ok || len(groups) != 0 {
+		t.Errorf("expected empty groups list, got %v", body["groups"])
+	}
+	if _, ok := body["id"]; ok {
+		t.Errorf("id should not be sent on create")
+	}
+}
@@ -0,0 +1,439 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// API versions a Client can speak
+const (
+	// APIVersionCloud is the Bitbucket Cloud REST API
+	APIVersionCloud = "2.0"
+
+	// APIVersionServer is the Bitbucket Server and Data Center REST API
+	APIVersionServer = "1.0"
+)
+
+// WithAPIVersion selects the REST API the client talks to. With
+// APIVersionServer, requests for repositories, branches and pull requests
+// are written against the Cloud API as usual and translated to their
+// Server equivalents, and the responses are converted back to the Cloud
+// shapes, including pagination. Other endpoints are sent unchanged.
+func WithAPIVersion(version string) ClientOption {
+	return func(c *Client) {
+		c.apiVersion = version
+	}
+}
+
+// IsServer reports whether the client talks to Bitbucket Server or Data
+// Center rather than Bitbucket Cloud
+func (c *Client) IsServer() bool {
+	return c.apiVersion == APIVersionServer
+}
+
+// ServerBaseURL returns the REST API base URL of a Bitbucket Server host
+func ServerBaseURL(host string) string {
+	return "https://" + host + "/rest/api/1.0"
+}
+
+// serverResource is the kind of object a Server endpoint returns
+type serverResource int
+
+const (
+	serverOther serverResource = iota
+	serverRepository
+	serverBranch
+	serverPullRequest
+)
+
+// serverRoute rewrites one Cloud path pattern to its Server equivalent
+type serverRoute struct {
+	cloud    *regexp.Regexp
+	server   string
+	resource serverResource
+}
+
+var serverRoutes = []serverRoute{
+	{regexp.MustCompile(`^/repositories/([^/]+)$`), "/projects/$1/repos", serverRepository},
+	{regexp.MustCompile(`^/repositories/([^/]+)/([^/]+)$`), "/projects/$1/repos/$2", serverRepository},
+	{regexp.MustCompile(`^/repositories/([^/]+)/([^/]+)/refs/branches$`), "/projects/$1/repos/$2/branches", serverBranch},
+	{regexp.MustCompile(`^/repositories/([^/]+)/([^/]+)/pullrequests$`), "/projects/$1/repos/$2/pull-requests", serverPullRequest},
+	{regexp.MustCompile(`^/repositories/([^/]+)/([^/]+)/pullrequests/(\d+)$`), "/projects/$1/repos/$2/pull-requests/$3", serverPullRequest},
+	{regexp.MustCompile(`^/repositories/([^/]+)/([^/]+)/pullrequests/(\d+)/(.+)$`), "/projects/$1/repos/$2/pull-requests/$3/$4", serverOther},
+}
+
+// serverResources recognises Server paths, including those of next-page
+// links, which are already in Server form
+var serverResources = []struct {
+	path     *regexp.Regexp
+	resource serverResource
+}{
+	{regexp.MustCompile(`^/projects/[^/]+/repos(/[^/]+)?$`), serverRepository},
+	{regexp.MustCompile(`^/projects/[^/]+/repos/[^/]+/branches$`), serverBranch},
+	{regexp.MustCompile(`^/projects/[^/]+/repos/[^/]+/pull-requests(/\d+)?$`), serverPullRequest},
+}
+
+// serverPath translates a Cloud API path to the Server API. Paths with no
+// Server equivalent are returned unchanged.
+func serverPath(path string) string {
+	for _, r := range serverRoutes {
+		if r.cloud.MatchString(path) {
+			return r.cloud.ReplaceAllString(path, r.server)
+		}
+	}
+	return path
+}
+
+// serverResourceOf returns the kind of object a Server path returns
+func serverResourceOf(path string) serverResource {
+	for _, r := range serverResources {
+		if r.path.MatchString(path) {
+			return r.resource
+		}
+	}
+	return serverOther
+}
+
+// serverQuery translates Cloud query parameters for a request to the Server
+// path: page and pagelen become start and limit, and the Bitbucket query
+// language filters and sort orders bb uses are rewritten as their Server
+// parameters. A filter or sort with no Server equivalent is an error rather
+// than being dropped, which would return unfiltered results.
+func serverQuery(path string, query url.Values) (url.Values, error) {
+	if query == nil {
+		return nil, nil
+	}
+	resource := serverResourceOf(path)
+
+	out := url.Values{}
+	for key, values := range query {
+		switch key {
+		case "page", "pagelen", "fields":
+		case "q":
+			if err := serverFilter(resource, query.Get(key), out); err != nil {
+				return nil, err
+			}
+		case "sort":
+			if err := serverSort(resource, query.Get(key), out); err != nil {
+				return nil, err
+			}
+		case "role":
+			return nil, fmt.Errorf("filtering by role is not supported on Bitbucket Server")
+		default:
+			out[key] = values
+		}
+	}
+
+	limit, _ := strconv.Atoi(query.Get("pagelen"))
+	if limit > 0 {
+		out.Set("limit", strconv.Itoa(limit))
+	}
+	if page, _ := strconv.Atoi(query.Get("page")); page > 1 {
+		if limit <= 0 {
+			limit = 25 // Server's default page size
+		}
+		out.Set("start", strconv.Itoa((page-1)*limit))
+	}
+	return out, nil
+}
+
+// serverClause matches one clause of a query built by Query: a field
+// compared to a quoted string, possibly in parentheses
+var serverClause = regexp.MustCompile(`^\(?\s*([\w.]+)\s*(=|~)\s*"((?:[^"\\]|\\.)*)"\s*\)?$`)
+
+// serverFilter adds the Server parameters for the clauses of q, a query
+// language expression, to out
+func serverFilter(resource serverResource, q string, out url.Values) error {
+	participants := 0
+	for _, clause := range strings.Split(q, " AND ") {
+		clause = strings.TrimSpace(clause)
+		if clause == "" {
+			continue
+		}
+		m := serverClause.FindStringSubmatch(clause)
+		if m == nil {
+			return fmt.Errorf("filter %s is not supported on Bitbucket Server", clause)
+		}
+		field, op := m[1], m[2]
+		value := strings.NewReplacer(`\"`, `"`, `\\`, `\`).Replace(m[3])
+
+		switch {
+		case resource == serverBranch && field == "name" && op == "~":
+			out.Set("filterText", value)
+		case resource == serverPullRequest && field == "state" && op == "=":
+			out.Set("state", value)
+		case resource == serverPullRequest && (field == "source.branch.name" || field == "destination.branch.name") && op == "=" && out.Get("at") == "":
+			out.Set("at", "refs/heads/"+value)
+			out.Set("direction", "OUTGOING")
+			if field == "destination.branch.name" {
+				out.Set("direction", "INCOMING")
+			}
+		case resource == serverPullRequest && (field == "author.username" || field == "reviewers.username") && op == "=":
+			participants++
+			role := "AUTHOR"
+			if field == "reviewers.username" {
+				role = "REVIEWER"
+			}
+			n := strconv.Itoa(participants)
+			out.Set("role."+n, role)
+			out.Set("username."+n, value)
+		default:
+			return fmt.Errorf("filter %s is not supported on Bitbucket Server", clause)
+		}
+	}
+	return nil
+}
+
+// serverSort adds the Server parameter for a Cloud sort order to out
+func serverSort(resource serverResource, sort string, out url.Values) error {
+	switch {
+	case resource == serverBranch && sort == "name":
+		out.Set("orderBy", "ALPHABETICAL")
+	case resource == serverBranch && sort == "-target.date":
+		out.Set("orderBy", "MODIFICATION")
+	case resource == serverRepository && sort == "name":
+		// Server lists repositories by name already
+	default:
+		return fmt.Errorf("sorting by %s is not supported on Bitbucket Server", sort)
+	}
+	return nil
+}
+
+// serverPage is Server's pagination envelope
+type serverPage struct {
+	Start         int               `json:"start"`
+	Limit         int               `json:"limit"`
+	IsLastPage    bool              `json:"isLastPage"`
+	NextPageStart int               `json:"nextPageStart"`
+	Values        []json.RawMessage `json:"values"`
+}
+
+// normalizeServerBody converts the Server response to a request for path,
+// sent as reqURL, into the shape the Cloud API would have returned. Bodies
+// it does not recognise are returned unchanged.
+func normalizeServerBody(path string, reqURL *url.URL, body []byte) []byte {
+	resource := serverResourceOf(path)
+	if resource == serverOther || len(body) == 0 {
+		return body
+	}
+
+	var probe map[string]json.RawMessage
+	if json.Unmarshal(body, &probe) != nil {
+		return body
+	}
+
+	if _, ok := probe["isLastPage"]; !ok {
+		var obj map[string]any
+		if json.Unmarshal(body, &obj) != nil {
+			return body
+		}
+		return marshalOr(normalizeServerObject(resource, obj), body)
+	}
+
+	var page serverPage
+	if json.Unmarshal(body, &page) != nil {
+		return body
+	}
+
+	values := make([]any, 0, len(page.Values))
+	for _, raw := range page.Values {
+		var obj map[string]any
+		if json.Unmarshal(raw, &obj) != nil {
+			continue
+		}
+		values = append(values, normalizeServerObject(resource, obj))
+	}
+
+	out := map[string]any{
+		"pagelen": page.Limit,
+		"values":  values,
+	}
+	if page.Limit > 0 {
+		out["page"] = page.Start/page.Limit + 1
+	}
+	if !page.IsLastPage {
+		next := *reqURL
+		q := next.Query()
+		q.Set("start", strconv.Itoa(page.NextPageStart))
+		if page.Limit > 0 {
+			q.Set("limit", strconv.Itoa(page.Limit))
+		}
+		next.RawQuery = q.Encode()
+		out["next"] = next.String()
+	}
+	return marshalOr(out, body)
+}
+
+func normalizeServerObject(resource serverResource, obj map[string]any) map[string]any {
+	switch resource {
+	case serverRepository:
+		return serverRepositoryToCloud(obj)
+	case serverBranch:
+		return serverBranchToCloud(obj)
+	case serverPullRequest:
+		return serverPullRequestToCloud(obj)
+	}
+	return obj
+}
+
+func serverRepositoryToCloud(s map[string]any) map[string]any {
+	project := asMap(s["project"])
+	key, _ := project["key"].(string)
+	slug, _ := s["slug"].(string)
+	public, _ := s["public"].(bool)
+
+	links := asMap(s["links"])
+	var clone []any
+	for _, l := range asSlice(links["clone"]) {
+		link := asMap(l)
+		name, _ := link["name"].(string)
+		if name == "http" {
+			name = "https"
+		}
+		clone = append(clone, map[string]any{"href": link["href"], "name": name})
+	}
+
+	repo := map[string]any{
+		"uuid":        idString(s["id"]),
+		"name":        s["name"],
+		"slug":        slug,
+		"full_name":   key + "/" + slug,
+		"description": s["description"],
+		"is_private":  !public,
+		"project":     map[string]any{"key": key, "name": project["name"]},
+		"workspace":   map[string]any{"slug": key, "name": project["name"]},
+		"links": map[string]any{
+			"html":  map[string]any{"href": firstHref(links["self"])},
+			"clone": clone,
+		},
+	}
+	if origin := asMap(s["origin"]); origin != nil {
+		parent := serverRepositoryToCloud(origin)
+		repo["parent"] = map[string]any{"name": parent["name"], "slug": parent["slug"], "full_name": parent["full_name"]}
+	}
+	return repo
+}
+
+func serverBranchToCloud(s map[string]any) map[string]any {
+	return map[string]any{
+		"name":   s["displayId"],
+		"type":   "branch",
+		"target": map[string]any{"hash": s["latestCommit"], "type": "commit"},
+	}
+}
+
+func serverPullRequestToCloud(s map[string]any) map[string]any {
+	pr := map[string]any{
+		"id":          s["id"],
+		"title":       s["title"],
+		"description": s["description"],
+		"state":       s["state"],
+		"author":      serverUserToCloud(asMap(asMap(s["author"])["user"])),
+		"source":      serverRefToCloud(asMap(s["fromRef"])),
+		"destination": serverRefToCloud(asMap(s["toRef"])),
+		"created_on":  serverTime(s["createdDate"]),
+		"updated_on":  serverTime(s["updatedDate"]),
+		"links":       map[string]any{"html": map[string]any{"href": firstHref(asMap(s["links"])["self"])}},
+	}
+
+	var participants, reviewers []any
+	for _, r := range asSlice(s["reviewers"]) {
+		reviewer := asMap(r)
+		user := serverUserToCloud(asMap(reviewer["user"]))
+		approved, _ := reviewer["approved"].(bool)
+		p := map[string]any{"user": user, "role": "REVIEWER", "approved": approved}
+		if status, _ := reviewer["status"].(string); status == "NEEDS_WORK" {
+			p["state"] = "changes_requested"
+		} else if approved {
+			p["state"] = "approved"
+		}
+		participants = append(participants, p)
+		reviewers = append(reviewers, user)
+	}
+	pr["participants"] = participants
+	pr["reviewers"] = reviewers
+
+	if props := asMap(s["properties"]); props != nil {
+		pr["comment_count"] = props["commentCount"]
+		pr["task_count"] = props["openTaskCount"]
+	}
+	return pr
+}
+
+func serverRefToCloud(ref map[string]any) map[string]any {
+	out := map[string]any{
+		"branch": map[string]any{"name": ref["displayId"]},
+		"commit": map[string]any{"hash": ref["latestCommit"]},
+	}
+	if repo := asMap(ref["repository"]); repo != nil {
+		r := serverRepositoryToCloud(repo)
+		out["repository"] = map[string]any{"name": r["name"], "slug": r["slug"], "full_name": r["full_name"], "uuid": r["uuid"]}
+	}
+	return out
+}
+
+func serverUserToCloud(u map[string]any) map[string]any {
+	if u == nil {
+		return map[string]any{}
+	}
+	return map[string]any{
+		"uuid":         idString(u["id"]),
+		"username":     u["name"],
+		"nickname":     u["slug"],
+		"display_name": u["displayName"],
+	}
+}
+
+// serverTime converts a Server timestamp in milliseconds to RFC 3339
+func serverTime(v any) any {
+	ms, ok := v.(float64)
+	if !ok {
+		return nil
+	}
+	return time.UnixMilli(int64(ms)).UTC().Format(time.RFC3339)
+}
+
+// firstHref returns the href of the first link in a Server link list
+func firstHref(v any) string {
+	for _, l := range asSlice(v) {
+		if href, ok := asMap(l)["href"].(string); ok {
+			return href
+		}
+	}
+	return ""
+}
+
+func asMap(v any) map[string]any {
+	m, _ := v.(map[string]any)
+	return m
+}
+
+func asSlice(v any) []any {
+	s, _ := v.([]any)
+	return s
+}
+
+// marshalOr marshals v, falling back to the original body on failure
+func marshalOr(v any, body []byte) []byte {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return body
+	}
+	return data
+}
+
+// idString formats a numeric Server ID as a string
+func idString(v any) string {
+	switch id := v.(type) {
+	case float64:
+		return strconv.FormatFloat(id, 'f', -1, 64)
+	case string:
+		return id
+	}
+	return ""
+}
@@ -0,0 +1,241 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+func newServerTestClient(t *testing.T, handler http.HandlerFunc) *Client {
+	t.Helper()
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+	return NewClient(WithBaseURL(server.URL+"/rest/api/1.0"), WithToken("test-token"), WithAPIVersion(APIVersionServer))
+}
+
+func TestServerPath(t *testing.T) {
+	tests := []struct {
+		cloud string
+		want  string
+	}{
+		{"/repositories/PROJ", "/projects/PROJ/repos"},
+		{"/repositories/PROJ/repo", "/projects/PROJ/repos/repo"},
+		{"/repositories/PROJ/repo/refs/branches", "/projects/PROJ/repos/repo/branches"},
+		{"/repositories/PROJ/repo/pullrequests", "/projects/PROJ/repos/repo/pull-requests"},
+		{"/repositories/PROJ/repo/pullrequests/7", "/projects/PROJ/repos/repo/pull-requests/7"},
+		{"/repositories/PROJ/repo/pullrequests/7/merge", "/projects/PROJ/repos/repo/pull-requests/7/merge"},
+		{"/user", "/user"},
+	}
+	for _, tt := range tests {
+		if got := serverPath(tt.cloud); got != tt.want {
+			t.Errorf("serverPath(%q) = %q, want %q", tt.cloud, got, tt.want)
+		}
+	}
+}
+
+func TestServerQuery(t *testing.T) {
+	const prs = "/projects/PROJ/repos/repo/pull-requests"
+	tests := []struct {
+		name    string
+		path    string
+		query   url.Values
+		want    url.Values
+		wantErr string
+	}{
+		{
+			name:  "pagination",
+			path:  prs,
+			query: url.Values{"page": {"3"}, "pagelen": {"10"}, "state": {"OPEN"}},
+			want:  url.Values{"start": {"20"}, "limit": {"10"}, "state": {"OPEN"}},
+		},
+		{
+			name:  "pull request filters",
+			path:  prs,
+			query: url.Values{"q": {`author.username="ann" AND reviewers.username="bob" AND source.branch.name="feature/x" AND state="OPEN"`}},
+			want: url.Values{
+				"role.1": {"AUTHOR"}, "username.1": {"ann"},
+				"role.2": {"REVIEWER"}, "username.2": {"bob"},
+				"at": {"refs/heads/feature/x"}, "direction": {"OUTGOING"},
+				"state": {"OPEN"},
+			},
+		},
+		{
+			name:  "branch filter and sort",
+			path:  "/projects/PROJ/repos/repo/branches",
+			query: url.Values{"q": {`name ~ "rel\"ease"`}, "sort": {"-target.date"}},
+			want:  url.Values{"filterText": {`rel"ease`}, "orderBy": {"MODIFICATION"}},
+		},
+		{
+			name:    "unsupported filter",
+			path:    prs,
+			query:   url.Values{"q": {`created_on >= 2024-01-01T00:00:00Z`}},
+			wantErr: "filter created_on >= 2024-01-01T00:00:00Z is not supported on Bitbucket Server",
+		},
+		{
+			name:    "unsupported sort",
+			path:    "/projects/PROJ/repos",
+			query:   url.Values{"sort": {"-updated_on"}},
+			wantErr: "sorting by -updated_on is not supported",
+		},
+		{
+			name:    "role",
+			path:    "/projects/PROJ/repos",
+			query:   url.Values{"role": {"admin"}},
+			wantErr: "role is not supported",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := serverQuery(tt.path, tt.query)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Errorf("serverQuery() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("serverQuery() error: %v", err)
+			}
+			if got.Encode() != tt.want.Encode() {
+				t.Errorf("serverQuery() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	if got, err := serverQuery(prs, nil); got != nil || err != nil {
+		t.Errorf("serverQuery(nil) = %v, %v, want nil", got, err)
+	}
+}
+
+func TestServerListRepositoriesPaginates(t *testing.T) {
+	client := newServerTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/rest/api/1.0/projects/PROJ/repos" {
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+		repo := `{"id": 1, "slug": "%s", "name": "%s", "public": false,
+			"project": {"key": "PROJ", "name": "Project"},
+			"links": {"self": [{"href": "https://git.example.com/projects/PROJ/repos/%s/browse"}],
+				"clone": [{"href": "https://git.example.com/scm/proj/%s.git", "name": "http"}, {"href": "ssh://git@git.example.com:7999/proj/%s.git", "name": "ssh"}]}}`
+		if r.URL.Query().Get("start") == "2" {
+			fmt.Fprintf(w, `{"start": 2, "limit": 2, "isLastPage": true, "values": [`+repo+`]}`, "c", "c", "c", "c", "c")
+			return
+		}
+		if got := r.URL.Query().Get("limit"); got != "2" {
+			t.Errorf("limit = %q, want 2", got)
+		}
+		fmt.Fprintf(w, `{"start": 0, "limit": 2, "isLastPage": false, "nextPageStart": 2, "values": [`+repo+`,`+repo+`]}`,
+			"a", "a", "a", "a", "a", "b", "b", "b", "b", "b")
+	})
+	ctx := context.Background()
+
+	page, err := client.ListRepositories(ctx, "PROJ", &RepositoryListOptions{Limit: 2})
+	if err != nil {
+		t.Fatalf("ListRepositories() error: %v", err)
+	}
+	if len(page.Values) != 2 || page.Next == "" || page.Page != 1 {
+		t.Fatalf("first page = %+v", page)
+	}
+	repo := page.Values[0]
+	if repo.FullName != "PROJ/a" || !repo.IsPrivate || repo.Project == nil || repo.Project.Key != "PROJ" {
+		t.Errorf("repository = %+v", repo)
+	}
+	if repo.Links.HTML.Href != "https://git.example.com/projects/PROJ/repos/a/browse" {
+		t.Errorf("html link = %q", repo.Links.HTML.Href)
+	}
+	if len(repo.Links.Clone) != 2 || repo.Links.Clone[0].Name != "https" {
+		t.Errorf("clone links = %+v", repo.Links.Clone)
+	}
+
+	next, err := NextPage(ctx, client, page)
+	if err != nil {
+		t.Fatalf("NextPage() error: %v", err)
+	}
+	if len(next.Values) != 1 || next.Values[0].Slug != "c" || next.Next != "" {
+		t.Errorf("second page = %+v", next)
+	}
+}
+
+func TestServerGetPullRequest(t *testing.T) {
+	client := newServerTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/rest/api/1.0/projects/PROJ/repos/repo/pull-requests/7" {
+			http.NotFound(w, r)
+			return
+		}
+		fmt.Fprint(w, `{
+			"id": 7, "title": "Add feature", "description": "Details", "state": "OPEN",
+			"createdDate": 1700000000000, "updatedDate": 1700000360000,
+			"author": {"user": {"id": 3, "name": "alice", "slug": "alice", "displayName": "Alice A"}},
+			"fromRef": {"displayId": "feature/x", "latestCommit": "abc123", "repository": {"slug": "repo", "project": {"key": "PROJ"}}},
+			"toRef": {"displayId": "main", "latestCommit": "def456"},
+			"reviewers": [
+				{"user": {"name": "bob", "displayName": "Bob"}, "approved": true, "status": "APPROVED"},
+				{"user": {"name": "carol", "displayName": "Carol"}, "approved": false, "status": "NEEDS_WORK"}
+			],
+			"properties": {"commentCount": 4},
+			"links": {"self": [{"href": "https://git.example.com/projects/PROJ/repos/repo/pull-requests/7"}]}
+		}`)
+	})
+
+	pr, err := client.GetPullRequest(context.Background(), "PROJ", "repo", 7)
+	if err != nil {
+		t.Fatalf("GetPullRequest() error: %v", err)
+	}
+	if pr.ID != 7 || pr.Title != "Add feature" || pr.State != PRStateOpen {
+		t.Errorf("pull request = %+v", pr)
+	}
+	if pr.Author.DisplayName != "Alice A" || pr.Author.Username != "alice" {
+		t.Errorf("author = %+v", pr.Author)
+	}
+	if pr.Source.Branch.Name != "feature/x" || pr.Source.Commit.Hash != "abc123" || pr.Destination.Branch.Name != "main" {
+		t.Errorf("refs = %+v -> %+v", pr.Source, pr.Destination)
+	}
+	if pr.Source.Repository == nil || pr.Source.Repository.FullName != "PROJ/repo" {
+		t.Errorf("source repository = %+v", pr.Source.Repository)
+	}
+	if pr.CreatedOn.UnixMilli() != 1700000000000 {
+		t.Errorf("created_on = %v", pr.CreatedOn)
+	}
+	if len(pr.Participants) != 2 || pr.Participants[0].State != "approved" || pr.Participants[1].State != "changes_requested" {
+		t.Errorf("participants = %+v", pr.Participants)
+	}
+	if pr.CommentCount != 4 || pr.Links.HTML.Href == "" {
+		t.Errorf("comment count %d, html %q", pr.CommentCount, pr.Links.HTML.Href)
+	}
+}
+
+func TestServerListBranches(t *testing.T) {
+	client := newServerTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"start": 0, "limit": 25, "isLastPage": true, "values": [
+			{"id": "refs/heads/main", "displayId": "main", "latestCommit": "abc123", "isDefault": true}
+		]}`)
+	})
+
+	branches, err := client.ListBranches(context.Background(), "PROJ", "repo", nil)
+	if err != nil {
+		t.Fatalf("ListBranches() error: %v", err)
+	}
+	if len(branches.Values) != 1 || branches.Values[0].Name != "main" || branches.Values[0].Target == nil || branches.Values[0].Target.Hash != "abc123" {
+		t.Errorf("branches = %+v", branches.Values)
+	}
+}
+
+func TestServerErrors(t *testing.T) {
+	client := newServerTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		fmt.Fprint(w, `{"errors": [{"message": "Repository PROJ/nope does not exist."}]}`)
+	})
+
+	_, err := client.GetRepository(context.Background(), "PROJ", "nope")
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusNotFound {
+		t.Fatalf("GetRepository() error = %v, want a 404 APIError", err)
+	}
+	if apiErr.Message != "Repository PROJ/nope does not exist." {
+		t.Errorf("message = %q", apiErr.Message)
+	}
+}
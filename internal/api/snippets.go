@@ -3,7 +3,6 @@ package api
 import (
 	"bytes"
 	"context"
-	"encoding/json"
 	"fmt"
 	"io"
 	"mime/multipart"
@@ -93,7 +92,12 @@ func (c *Client) GetSnippet(ctx context.Context, workspace, encodedID string) (*
 func (c *Client) CreateSnippet(ctx context.Context, workspace string, title string, isPrivate bool, files map[string]string) (*Snippet, error) {
 	path := fmt.Sprintf("/snippets/%s", workspace)
 
-	body, contentType, err := buildSnippetMultipartBody(title, isPrivate, files)
+	// Bitbucket rejects snippets without files; fail before sending the request
+	if len(files) == 0 {
+		return nil, fmt.Errorf("at least one file is required to create a snippet")
+	}
+
+	body, contentType, err := buildSnippetMultipartBody(title, isPrivate, files, nil)
 	if err != nil {
 		return nil, fmt.Errorf("could not build multipart body: %w", err)
 	}
@@ -110,7 +114,7 @@ func (c *Client) CreateSnippet(ctx context.Context, workspace string, title stri
 func (c *Client) UpdateSnippet(ctx context.Context, workspace, encodedID string, title string, files map[string]string) (*Snippet, error) {
 	path := fmt.Sprintf("/snippets/%s/%s", workspace, url.PathEscape(encodedID))
 
-	body, contentType, err := buildSnippetMultipartBody(title, false, files)
+	body, contentType, err := buildSnippetMultipartBody(title, false, files, nil)
 	if err != nil {
 		return nil, fmt.Errorf("could not build multipart body: %w", err)
 	}
@@ -123,6 +127,75 @@ func (c *Client) UpdateSnippet(ctx context.Context, workspace, encodedID string,
 	return ParseResponse[*Snippet](resp)
 }
 
+// EditSnippetFiles adds, replaces and deletes individual files of a snippet.
+// Files present in add are uploaded (replacing any file with the same name),
+// files named in remove are deleted, and all other files are left untouched.
+// The snippet is fetched first so that deleting a file it does not contain
+// is reported instead of being silently ignored.
+func (c *Client) EditSnippetFiles(ctx context.Context, workspace, encodedID, title string, add map[string]string, remove []string) (*Snippet, error) {
+	current, err := c.GetSnippet(ctx, workspace, encodedID)
+	if err != nil {
+		return nil, err
+	}
+
+	// A file named twice is deleted once, and must count once below
+	var unique []string
+	seen := make(map[string]bool)
+	for _, name := range remove {
+		if _, ok := current.Files[name]; !ok {
+			return nil, fmt.Errorf("snippet %s has no file named %q", encodedID, name)
+		}
+		if _, ok := add[name]; ok {
+			return nil, fmt.Errorf("file %q cannot be both added and deleted", name)
+		}
+		if !seen[name] {
+			seen[name] = true
+			unique = append(unique, name)
+		}
+	}
+	remove = unique
+
+	if len(remove) > 0 && len(remove) == len(current.Files) && len(add) == 0 {
+		return nil, fmt.Errorf("cannot delete every file; a snippet needs at least one file")
+	}
+
+	path := fmt.Sprintf("/snippets/%s/%s", workspace, url.PathEscape(encodedID))
+
+	body, contentType, err := buildSnippetMultipartBody(title, false, add, remove)
+	if err != nil {
+		return nil, fmt.Errorf("could not build multipart body: %w", err)
+	}
+
+	resp, err := c.doMultipart(ctx, http.MethodPut, path, body, contentType)
+	if err != nil {
+		return nil, err
+	}
+
+	return ParseResponse[*Snippet](resp)
+}
+
+// SetSnippetVisibility makes a snippet private or public. Only the
+// is_private field is sent, so the files and title are left untouched.
+func (c *Client) SetSnippetVisibility(ctx context.Context, workspace, encodedID string, isPrivate bool) (*Snippet, error) {
+	path := fmt.Sprintf("/snippets/%s/%s", workspace, url.PathEscape(encodedID))
+
+	body := &bytes.Buffer{}
+	writer := multipart.NewWriter(body)
+	if err := writer.WriteField("is_private", strconv.FormatBool(isPrivate)); err != nil {
+		return nil, fmt.Errorf("could not build multipart body: %w", err)
+	}
+	if err := writer.Close(); err != nil {
+		return nil, fmt.Errorf("could not build multipart body: %w", err)
+	}
+
+	resp, err := c.doMultipart(ctx, http.MethodPut, path, body, writer.FormDataContentType())
+	if err != nil {
+		return nil, err
+	}
+
+	return ParseResponse[*Snippet](resp)
+}
+
 // DeleteSnippet deletes a snippet by encoded ID
 func (c *Client) DeleteSnippet(ctx context.Context, workspace, encodedID string) error {
 	path := fmt.Sprintf("/snippets/%s/%s", workspace, url.PathEscape(encodedID))
@@ -131,7 +204,21 @@ func (c *Client) DeleteSnippet(ctx context.Context, workspace, encodedID string)
 	return err
 }
 
-// GetSnippetFileContent retrieves the content of a file in a snippet
+// ListSnippetCommits lists the revisions of a snippet, newest first.
+// Snippets are git repositories, so each edit is a commit.
+func (c *Client) ListSnippetCommits(ctx context.Context, workspace, encodedID string) (*Paginated[Commit], error) {
+	path := fmt.Sprintf("/snippets/%s/%s/commits", workspace, url.PathEscape(encodedID))
+
+	resp, err := c.Get(ctx, path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return ParseResponse[*Paginated[Commit]](resp)
+}
+
+// GetSnippetFileContent retrieves the content of a file in a snippet. The
+// whole file is held in memory; use GetSnippetFileReader for large files.
 func (c *Client) GetSnippetFileContent(ctx context.Context, workspace, encodedID, filePath string) ([]byte, error) {
 	path := fmt.Sprintf("/snippets/%s/%s/files/%s", workspace, url.PathEscape(encodedID), url.PathEscape(filePath))
 
@@ -143,8 +230,41 @@ func (c *Client) GetSnippetFileContent(ctx context.Context, workspace, encodedID
 	return resp.Body, nil
 }
 
-// buildSnippetMultipartBody creates a multipart form body for snippet create/update
-func buildSnippetMultipartBody(title string, isPrivate bool, files map[string]string) (*bytes.Buffer, string, error) {
+// GetSnippetFileReader returns the content of a file in a snippet as a
+// stream. The caller must close the returned reader.
+func (c *Client) GetSnippetFileReader(ctx context.Context, workspace, encodedID, filePath string) (io.ReadCloser, error) {
+	path := fmt.Sprintf("/snippets/%s/%s/files/%s", workspace, url.PathEscape(encodedID), url.PathEscape(filePath))
+
+	return c.DoStream(ctx, &Request{
+		Method: http.MethodGet,
+		Path:   path,
+	})
+}
+
+// GetSnippetFileSize returns the size in bytes of a file in a snippet, or
+// -1 if Bitbucket does not report it
+func (c *Client) GetSnippetFileSize(ctx context.Context, workspace, encodedID, filePath string) (int64, error) {
+	path := fmt.Sprintf("/snippets/%s/%s/files/%s", workspace, url.PathEscape(encodedID), url.PathEscape(filePath))
+
+	resp, err := c.Do(ctx, &Request{
+		Method: http.MethodHead,
+		Path:   path,
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	size, err := strconv.ParseInt(resp.Headers.Get("Content-Length"), 10, 64)
+	if err != nil {
+		return -1, nil
+	}
+	return size, nil
+}
+
+// buildSnippetMultipartBody creates a multipart form body for snippet create/update.
+// Each name in deleted is sent as a plain "file" field without content, which
+// Bitbucket interprets as a request to delete that file.
+func buildSnippetMultipartBody(title string, isPrivate bool, files map[string]string, deleted []string) (*bytes.Buffer, string, error) {
 	body := &bytes.Buffer{}
 	writer := multipart.NewWriter(body)
 
@@ -173,6 +293,13 @@ func buildSnippetMultipartBody(title string, isPrivate bool, files map[string]st
 		}
 	}
 
+	// Mark deleted files
+	for _, filename := range deleted {
+		if err := writer.WriteField("file", filename); err != nil {
+			return nil, "", err
+		}
+	}
+
 	if err := writer.Close(); err != nil {
 		return nil, "", err
 	}
@@ -199,8 +326,8 @@ func (c *Client) doMultipart(ctx context.Context, method, path string, body *byt
 	httpReq.Header.Set("Accept", "application/json")
 	httpReq.Header.Set("Content-Type", contentType)
 
-	if c.token != "" {
-		httpReq.Header.Set("Authorization", "Bearer "+c.token)
+	if token := c.accessToken(); token != "" {
+		httpReq.Header.Set("Authorization", "Bearer "+token)
 	}
 
 	// Execute request
@@ -224,26 +351,7 @@ func (c *Client) doMultipart(ctx context.Context, method, path string, body *byt
 
 	// Check for errors
 	if httpResp.StatusCode >= 400 {
-		apiErr := &APIError{
-			StatusCode: httpResp.StatusCode,
-			Message:    http.StatusText(httpResp.StatusCode),
-		}
-
-		// Try to parse error response
-		var errResp struct {
-			Error struct {
-				Message string            `json:"message"`
-				Detail  string            `json:"detail"`
-				Fields  map[string]string `json:"fields"`
-			} `json:"error"`
-		}
-		if json.Unmarshal(respBody, &errResp) == nil && errResp.Error.Message != "" {
-			apiErr.Message = errResp.Error.Message
-			apiErr.Detail = errResp.Error.Detail
-			apiErr.Fields = errResp.Error.Fields
-		}
-
-		return resp, apiErr
+		return resp, newAPIError(httpResp.StatusCode, httpResp.Header, respBody)
 	}
 
 	return resp, nil
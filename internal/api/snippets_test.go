@@ -2,6 +2,8 @@ package api
 
 import (
 	"context"
+	"fmt"
+	"io"
 	"net/http"
 	"net/http/httptest"
 	"strings"
@@ -1175,3 +1177,304 @@ func TestCreateSnippetMultipartBody(t *testing.T) {
 		t.Fatalf("unexpected error: %v", err)
 	}
 }
+
+func TestCreateSnippetRequiresFiles(t *testing.T) {
+	requested := false
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requested = true
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer server.Close()
+
+	client := NewClient(WithBaseURL(server.URL), WithToken("test-token"))
+
+	_, err := client.CreateSnippet(context.Background(), "myworkspace", "Empty", false, nil)
+	if err == nil {
+		t.Fatal("expected error for snippet without files")
+	}
+	if !strings.Contains(err.Error(), "at least one file") {
+		t.Errorf("unexpected error message: %v", err)
+	}
+	if requested {
+		t.Error("expected no request to be sent")
+	}
+}
+
+func TestEditSnippetFiles(t *testing.T) {
+	const current = `{
+		"id": 5,
+		"title": "Scripts",
+		"files": {
+			"keep.py": {},
+			"old.go": {},
+			"update.sh": {}
+		}
+	}`
+
+	tests := []struct {
+		name        string
+		add         map[string]string
+		remove      []string
+		wantErr     bool
+		wantUpload  map[string]string
+		wantDeleted []string
+	}{
+		{
+			name:        "add, replace and delete",
+			add:         map[string]string{"new.go": "package new", "update.sh": "echo v2"},
+			remove:      []string{"old.go"},
+			wantUpload:  map[string]string{"new.go": "package new", "update.sh": "echo v2"},
+			wantDeleted: []string{"old.go"},
+		},
+		{
+			name:    "delete unknown file",
+			remove:  []string{"missing.txt"},
+			wantErr: true,
+		},
+		{
+			name:    "add and delete same file",
+			add:     map[string]string{"old.go": "x"},
+			remove:  []string{"old.go"},
+			wantErr: true,
+		},
+		{
+			name:    "delete every file",
+			remove:  []string{"keep.py", "old.go", "update.sh"},
+			wantErr: true,
+		},
+		{
+			name:    "delete every file with a name repeated",
+			remove:  []string{"keep.py", "old.go", "update.sh", "old.go"},
+			wantErr: true,
+		},
+		{
+			name:        "delete a file named twice",
+			remove:      []string{"old.go", "update.sh", "old.go"},
+			wantUpload:  map[string]string{},
+			wantDeleted: []string{"old.go", "update.sh"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var putSeen bool
+			uploads := map[string]string{}
+			var deleted []string
+
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				if r.Method == http.MethodGet {
+					w.Write([]byte(current))
+					return
+				}
+
+				putSeen = true
+				if r.URL.Path != "/snippets/myworkspace/5" {
+					t.Errorf("unexpected path: %s", r.URL.Path)
+				}
+
+				reader, err := r.MultipartReader()
+				if err != nil {
+					t.Fatalf("expected multipart body: %v", err)
+				}
+				for {
+					part, err := reader.NextPart()
+					if err == io.EOF {
+						break
+					}
+					if err != nil {
+						t.Fatalf("failed to read part: %v", err)
+					}
+					data, _ := io.ReadAll(part)
+					if part.FormName() != "file" {
+						continue
+					}
+					if part.FileName() != "" {
+						uploads[part.FileName()] = string(data)
+					} else {
+						// A file field without a file part marks a deletion
+						deleted = append(deleted, string(data))
+					}
+				}
+
+				w.Write([]byte(`{"id": 5, "title": "Scripts"}`))
+			}))
+			defer server.Close()
+
+			client := NewClient(WithBaseURL(server.URL), WithToken("test-token"))
+
+			_, err := client.EditSnippetFiles(context.Background(), "myworkspace", "5", "", tt.add, tt.remove)
+
+			if tt.wantErr {
+				if err == nil {
+					t.Error("expected error but got nil")
+				}
+				if putSeen {
+					t.Error("expected no update request")
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if len(uploads) != len(tt.wantUpload) {
+				t.Errorf("expected uploads %v, got %v", tt.wantUpload, uploads)
+			}
+			for name, content := range tt.wantUpload {
+				if uploads[name] != content {
+					t.Errorf("file %s: expected %q, got %q", name, content, uploads[name])
+				}
+			}
+			if _, sent := uploads["keep.py"]; sent {
+				t.Error("untouched file keep.py should not be sent")
+			}
+
+			if len(deleted) != len(tt.wantDeleted) || (len(deleted) > 0 && deleted[0] != tt.wantDeleted[0]) {
+				t.Errorf("expected delete markers %v, got %v", tt.wantDeleted, deleted)
+			}
+		})
+	}
+}
+
+func TestSetSnippetVisibility(t *testing.T) {
+	for _, isPrivate := range []bool{true, false} {
+		var fields map[string]string
+		var fileParts int
+		// t.Fatalf must not be called from the handler's goroutine, so a
+		// malformed body is recorded and reported after the request
+		var bodyErr error
+
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Method != http.MethodPut {
+				t.Errorf("expected PUT, got %s", r.Method)
+			}
+			if r.URL.Path != "/snippets/myworkspace/abc" {
+				t.Errorf("unexpected path: %s", r.URL.Path)
+			}
+
+			reader, err := r.MultipartReader()
+			if err != nil {
+				bodyErr = fmt.Errorf("expected multipart body: %w", err)
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+			fields = map[string]string{}
+			for {
+				part, err := reader.NextPart()
+				if err == io.EOF {
+					break
+				}
+				if err != nil {
+					bodyErr = fmt.Errorf("failed to read part: %w", err)
+					http.Error(w, err.Error(), http.StatusBadRequest)
+					return
+				}
+				data, _ := io.ReadAll(part)
+				if part.FileName() != "" {
+					fileParts++
+				}
+				fields[part.FormName()] = string(data)
+			}
+
+			w.Header().Set("Content-Type", "application/json")
+			w.Write([]byte(`{"id": 1, "is_private": ` + fields["is_private"] + `}`))
+		}))
+
+		client := NewClient(WithBaseURL(server.URL), WithToken("test-token"))
+		snippet, err := client.SetSnippetVisibility(context.Background(), "myworkspace", "abc", isPrivate)
+		server.Close()
+		if bodyErr != nil {
+			t.Fatal(bodyErr)
+		}
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		// Sending any file or title field would replace the snippet's content
+		want := "false"
+		if isPrivate {
+			want = "true"
+		}
+		if len(fields) != 1 || fields["is_private"] != want {
+			t.Errorf("expected only is_private=%s, got %v", want, fields)
+		}
+		if fileParts != 0 {
+			t.Errorf("expected no file parts, got %d", fileParts)
+		}
+		if snippet.IsPrivate != isPrivate {
+			t.Errorf("expected IsPrivate %v, got %v", isPrivate, snippet.IsPrivate)
+		}
+	}
+}
+
+func TestListSnippetCommits(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			t.Errorf("expected GET, got %s", r.Method)
+		}
+		if r.URL.Path != "/snippets/myworkspace/abc123/commits" {
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{
+			"values": [
+				{"hash": "2222222222", "message": "Fix typo\n", "date": "2024-03-02T10:00:00+00:00", "author": {"raw": "Jane <jane@example.com>"}},
+				{"hash": "1111111111", "message": "Initial version", "date": "2024-03-01T10:00:00+00:00"}
+			]
+		}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(WithBaseURL(server.URL), WithToken("test-token"))
+
+	result, err := client.ListSnippetCommits(context.Background(), "myworkspace", "abc123")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(result.Values) != 2 {
+		t.Fatalf("expected 2 commits, got %d", len(result.Values))
+	}
+	first := result.Values[0]
+	if first.Hash != "2222222222" || first.Message != "Fix typo\n" {
+		t.Errorf("unexpected first commit: %+v", first)
+	}
+	if first.Author == nil || first.Author.Raw != "Jane <jane@example.com>" {
+		t.Errorf("expected author to be parsed, got %+v", first.Author)
+	}
+	if first.Date.IsZero() {
+		t.Error("expected date to be parsed")
+	}
+}
+
+func TestGetSnippetFileSize(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodHead {
+			t.Errorf("expected HEAD, got %s", r.Method)
+		}
+		switch r.URL.Path {
+		case "/snippets/myworkspace/abc123/files/main.go":
+			w.Header().Set("Content-Length", "1234")
+		case "/snippets/myworkspace/abc123/files/missing.go":
+			w.WriteHeader(http.StatusNotFound)
+		default:
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(WithBaseURL(server.URL), WithToken("test-token"))
+
+	size, err := client.GetSnippetFileSize(context.Background(), "myworkspace", "abc123", "main.go")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if size != 1234 {
+		t.Errorf("expected size 1234, got %d", size)
+	}
+
+	if _, err := client.GetSnippetFileSize(context.Background(), "myworkspace", "abc123", "missing.go"); err == nil {
+		t.Error("expected an error for a missing file")
+	}
+}
@@ -0,0 +1,65 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// FileCommit describes a commit made with CommitFiles
+type FileCommit struct {
+	Branch  string            // Branch to commit to; the main branch if empty
+	Message string            // Commit message
+	Files   map[string]string // Content of files to add or replace, by path
+	Delete  []string          // Paths of files to delete
+}
+
+// srcPath returns the API path of a file at a commit, branch or tag, with
+// every segment escaped so refs and file names with slashes or spaces work
+func srcPath(workspace, repoSlug, ref, filePath string) string {
+	segments := strings.Split(strings.Trim(filePath, "/"), "/")
+	for i, seg := range segments {
+		segments[i] = url.PathEscape(seg)
+	}
+	return fmt.Sprintf("/repositories/%s/%s/src/%s/%s", workspace, repoSlug, url.PathEscape(ref), strings.Join(segments, "/"))
+}
+
+// GetFileContent returns the content of a file at a commit, branch or tag.
+// The whole file is held in memory, so it is meant for small files.
+func (c *Client) GetFileContent(ctx context.Context, workspace, repoSlug, ref, filePath string) ([]byte, error) {
+	resp, err := c.Get(ctx, srcPath(workspace, repoSlug, ref, filePath), nil)
+	if err != nil {
+		return nil, err
+	}
+	return resp.Body, nil
+}
+
+// CommitFiles commits changes to files directly on a branch, without a
+// clone. Branch restrictions apply as they do to a push.
+func (c *Client) CommitFiles(ctx context.Context, workspace, repoSlug string, commit *FileCommit) error {
+	if len(commit.Files) == 0 && len(commit.Delete) == 0 {
+		return fmt.Errorf("no files to commit")
+	}
+
+	form := url.Values{}
+	form.Set("message", commit.Message)
+	if commit.Branch != "" {
+		form.Set("branch", commit.Branch)
+	}
+	for path, content := range commit.Files {
+		form.Set(path, content)
+	}
+	for _, path := range commit.Delete {
+		form.Add("files", path)
+	}
+
+	_, err := c.Do(ctx, &Request{
+		Method:  http.MethodPost,
+		Path:    fmt.Sprintf("/repositories/%s/%s/src", workspace, repoSlug),
+		Headers: map[string]string{"Content-Type": "application/x-www-form-urlencoded"},
+		RawBody: strings.NewReader(form.Encode()),
+	})
+	return err
+}
@@ -0,0 +1,68 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+)
+
+func TestGetFileContent(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.EscapedPath() != "/repositories/myworkspace/myrepo/src/feature%2Fx/docs/read%20me.md" {
+			t.Errorf("unexpected path %s", r.URL.EscapedPath())
+		}
+		w.Write([]byte("# Hello\n"))
+	}))
+	defer server.Close()
+
+	client := NewClient(WithBaseURL(server.URL), WithToken("test-token"))
+	data, err := client.GetFileContent(context.Background(), "myworkspace", "myrepo", "feature/x", "docs/read me.md")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if string(data) != "# Hello\n" {
+		t.Errorf("unexpected content %q", data)
+	}
+}
+
+func TestCommitFiles(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/repositories/myworkspace/myrepo/src" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		if err := r.ParseForm(); err != nil {
+			t.Errorf("failed to parse form: %v", err)
+		}
+		if got := r.PostForm.Get("message"); got != "Update docs" {
+			t.Errorf("message = %q", got)
+		}
+		if got := r.PostForm.Get("branch"); got != "main" {
+			t.Errorf("branch = %q", got)
+		}
+		if got := r.PostForm.Get("docs/a.md"); got != "a \"quoted\" line\n" {
+			t.Errorf("docs/a.md = %q", got)
+		}
+		if got := r.PostForm["files"]; !slices.Equal(got, []string{"old.md"}) {
+			t.Errorf("files = %q", got)
+		}
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer server.Close()
+
+	client := NewClient(WithBaseURL(server.URL), WithToken("test-token"))
+	err := client.CommitFiles(context.Background(), "myworkspace", "myrepo", &FileCommit{
+		Branch:  "main",
+		Message: "Update docs",
+		Files:   map[string]string{"docs/a.md": "a \"quoted\" line\n"},
+		Delete:  []string{"old.md"},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if err := client.CommitFiles(context.Background(), "myworkspace", "myrepo", &FileCommit{Message: "Nothing"}); err == nil {
+		t.Error("expected an error for a commit without files")
+	}
+}
@@ -0,0 +1,62 @@
+package api
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net/http"
+	"os"
+)
+
+// WithInsecureSkipVerify turns off TLS certificate verification, for
+// Bitbucket Server instances with self-signed certificates. Anyone on the
+// network path can then read and change requests, including the token, so
+// prefer WithCACert.
+func WithInsecureSkipVerify() ClientOption {
+	return func(c *Client) {
+		c.tlsConfig().InsecureSkipVerify = true
+	}
+}
+
+// WithCACert trusts the PEM-encoded CA certificates in path in addition to
+// the system roots. A file that cannot be read or holds no certificates makes
+// every request fail with that error.
+func WithCACert(path string) ClientOption {
+	return func(c *Client) {
+		pem, err := os.ReadFile(path)
+		if err != nil {
+			c.optionErr = fmt.Errorf("failed to read CA certificate: %w", err)
+			return
+		}
+
+		pool, err := x509.SystemCertPool()
+		if err != nil || pool == nil {
+			pool = x509.NewCertPool()
+		}
+		if !pool.AppendCertsFromPEM(pem) {
+			c.optionErr = fmt.Errorf("no PEM certificates found in %s", path)
+			return
+		}
+		c.tlsConfig().RootCAs = pool
+	}
+}
+
+// tlsConfig returns the TLS configuration of the client's transport, giving
+// the client its own copy of the default transport first so the change does
+// not leak into other clients
+func (c *Client) tlsConfig() *tls.Config {
+	transport, ok := c.httpClient.Transport.(*http.Transport)
+	if !ok || transport == nil {
+		transport = http.DefaultTransport.(*http.Transport).Clone()
+	} else {
+		transport = transport.Clone()
+	}
+	if transport.TLSClientConfig == nil {
+		transport.TLSClientConfig = &tls.Config{}
+	}
+
+	httpClient := *c.httpClient
+	httpClient.Transport = transport
+	c.httpClient = &httpClient
+	return transport.TLSClientConfig
+}
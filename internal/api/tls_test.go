@@ -0,0 +1,82 @@
+package api
+
+import (
+	"context"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func newTLSTestServer(t *testing.T) *httptest.Server {
+	t.Helper()
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"username": "tester"}`))
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestTLS_SelfSignedRejectedByDefault(t *testing.T) {
+	server := newTLSTestServer(t)
+	client := NewClient(WithBaseURL(server.URL), WithToken("test-token"))
+
+	if _, err := client.Get(context.Background(), "/user", nil); err == nil {
+		t.Fatal("expected a certificate error from a self-signed server")
+	}
+}
+
+func TestWithInsecureSkipVerify(t *testing.T) {
+	server := newTLSTestServer(t)
+	client := NewClient(WithBaseURL(server.URL), WithToken("test-token"), WithInsecureSkipVerify())
+
+	if _, err := client.Get(context.Background(), "/user", nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// The default transport must not have been changed
+	if other := NewClient(WithBaseURL(server.URL)); other.httpClient.Transport != nil {
+		t.Error("expected other clients to keep the default transport")
+	}
+	if _, err := NewClient(WithBaseURL(server.URL)).Get(context.Background(), "/user", nil); err == nil {
+		t.Error("expected a second client to still verify certificates")
+	}
+}
+
+func TestWithCACert(t *testing.T) {
+	server := newTLSTestServer(t)
+
+	path := filepath.Join(t.TempDir(), "ca.pem")
+	cert := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+	if err := os.WriteFile(path, cert, 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	client := NewClient(WithBaseURL(server.URL), WithToken("test-token"), WithCACert(path))
+	if _, err := client.Get(context.Background(), "/user", nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestWithCACert_InvalidFile(t *testing.T) {
+	server := newTLSTestServer(t)
+
+	missing := filepath.Join(t.TempDir(), "missing.pem")
+	client := NewClient(WithBaseURL(server.URL), WithCACert(missing))
+	if _, err := client.Get(context.Background(), "/user", nil); err == nil || !strings.Contains(err.Error(), "failed to read CA certificate") {
+		t.Errorf("expected a read error, got %v", err)
+	}
+
+	notPEM := filepath.Join(t.TempDir(), "ca.pem")
+	if err := os.WriteFile(notPEM, []byte("not a certificate"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	client = NewClient(WithBaseURL(server.URL), WithCACert(notPEM))
+	if _, err := client.Get(context.Background(), "/user", nil); err == nil || !strings.Contains(err.Error(), "no PEM certificates") {
+		t.Errorf("expected a no certificates error, got %v", err)
+	}
+}
@@ -0,0 +1,40 @@
+package alias
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/rbansal42/bitbucket-cli/internal/iostreams"
+)
+
+// NewCmdAlias creates the alias command and its subcommands
+func NewCmdAlias(streams *iostreams.IOStreams) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "alias <command>",
+		Short: "Create command shortcuts",
+		Long: `Create shortcuts for bb commands.
+
+An alias expands to a bb command when it is used as the first argument to
+bb. Arguments given after the alias are appended to the expansion, or
+substituted for $1, $2 and so on, with $@ standing for all of them.
+
+An expansion starting with ! is run by sh instead, with the alias
+arguments available as $1, $2 and $@.
+
+Aliases are stored in the config file.`,
+		Example: `  # Squash merge with a short command
+  bb alias set prm 'pr merge --squash'
+  bb prm 42
+
+  # Use placeholders for arguments
+  bb alias set bugs 'issue list --kind bug --assignee $1'
+
+  # Run a shell command
+  bb alias set mine '!bb pr list --author @me --json | jq ".[].title"'`,
+	}
+
+	cmd.AddCommand(NewCmdSet(streams))
+	cmd.AddCommand(NewCmdList(streams))
+	cmd.AddCommand(NewCmdDelete(streams))
+
+	return cmd
+}
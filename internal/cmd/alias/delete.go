@@ -0,0 +1,57 @@
+package alias
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/rbansal42/bitbucket-cli/internal/config"
+	"github.com/rbansal42/bitbucket-cli/internal/iostreams"
+)
+
+// NewCmdDelete creates the alias delete command
+func NewCmdDelete(streams *iostreams.IOStreams) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "delete <alias>",
+		Short:   "Delete an alias",
+		Aliases: []string{"rm"},
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			name := args[0]
+
+			cfg, err := config.LoadConfig()
+			if err != nil {
+				return fmt.Errorf("could not load config: %w", err)
+			}
+
+			expansion, ok := cfg.Aliases[name]
+			if !ok {
+				return fmt.Errorf("no such alias %q", name)
+			}
+			delete(cfg.Aliases, name)
+
+			if err := config.SaveConfig(cfg); err != nil {
+				return fmt.Errorf("could not save config: %w", err)
+			}
+
+			streams.Success("Deleted alias %s; it was %s", name, expansion)
+			return nil
+		},
+		ValidArgsFunction: func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+			if len(args) > 0 {
+				return nil, cobra.ShellCompDirectiveNoFileComp
+			}
+			cfg, err := config.LoadConfig()
+			if err != nil {
+				return nil, cobra.ShellCompDirectiveNoFileComp
+			}
+			names := make([]string, 0, len(cfg.Aliases))
+			for name, expansion := range cfg.Aliases {
+				names = append(names, name+"\t"+expansion)
+			}
+			return names, cobra.ShellCompDirectiveNoFileComp
+		},
+	}
+
+	return cmd
+}
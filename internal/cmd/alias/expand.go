@@ -0,0 +1,167 @@
+package alias
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// maxExpansionDepth bounds how many aliases can expand into one another
+// before the chain is treated as a loop
+const maxExpansionDepth = 10
+
+// IsShellAlias reports whether an expansion runs an external command
+// rather than a bb command
+func IsShellAlias(expansion string) bool {
+	return strings.HasPrefix(expansion, "!")
+}
+
+// Expand expands the alias named by args[0], following aliases that expand
+// to other aliases. It returns the resulting bb arguments, or the shell
+// command to run when the alias is a shell alias. ok is false when args[0]
+// is not an alias.
+func Expand(aliases map[string]string, args []string) (expanded []string, shellCmd string, ok bool, err error) {
+	if len(args) == 0 {
+		return nil, "", false, nil
+	}
+	if _, ok := aliases[args[0]]; !ok {
+		return nil, "", false, nil
+	}
+
+	seen := []string{}
+	for {
+		expansion, isAlias := aliases[args[0]]
+		if !isAlias {
+			return args, "", true, nil
+		}
+		if len(seen) >= maxExpansionDepth || slices.Contains(seen, args[0]) {
+			return nil, "", true, fmt.Errorf("alias %q expands recursively: %s", seen[0], strings.Join(append(seen, args[0]), " -> "))
+		}
+		seen = append(seen, args[0])
+
+		if IsShellAlias(expansion) {
+			// Arguments are passed to the shell, which expands $1 and $@
+			// itself
+			return args[1:], strings.TrimPrefix(expansion, "!"), true, nil
+		}
+
+		words, err := SplitArgs(expansion)
+		if err != nil {
+			return nil, "", true, fmt.Errorf("invalid alias %q: %w", args[0], err)
+		}
+		args = substituteArgs(words, args[1:])
+		if len(args) == 0 {
+			return nil, "", true, fmt.Errorf("alias %q expands to nothing", seen[len(seen)-1])
+		}
+	}
+}
+
+// substituteArgs replaces $1..$N in words with the matching argument and $@
+// with all of them. Arguments not consumed by a placeholder are appended, so
+// an alias without placeholders passes everything through.
+func substituteArgs(words, args []string) []string {
+	var result []string
+	used := 0
+	sawAll := false
+
+	for _, word := range words {
+		if word == "$@" {
+			result = append(result, args...)
+			sawAll = true
+			continue
+		}
+		result = append(result, replacePositional(word, args, &used))
+	}
+
+	if !sawAll && used < len(args) {
+		result = append(result, args[used:]...)
+	}
+	return result
+}
+
+// replacePositional replaces each $N in word with args[N-1]. used is raised
+// to the highest N seen.
+func replacePositional(word string, args []string, used *int) string {
+	if !strings.Contains(word, "$") {
+		return word
+	}
+
+	var b strings.Builder
+	for i := 0; i < len(word); i++ {
+		if word[i] != '$' {
+			b.WriteByte(word[i])
+			continue
+		}
+		j := i + 1
+		for j < len(word) && word[j] >= '0' && word[j] <= '9' {
+			j++
+		}
+		n, err := strconv.Atoi(word[i+1 : j])
+		if err != nil || n == 0 {
+			b.WriteByte('$')
+			continue
+		}
+		if n <= len(args) {
+			b.WriteString(args[n-1])
+		}
+		*used = max(*used, n)
+		i = j - 1
+	}
+	return b.String()
+}
+
+// SplitArgs splits s into words the way a POSIX shell would for simple
+// commands, honouring single quotes, double quotes and backslash escapes.
+func SplitArgs(s string) ([]string, error) {
+	var words []string
+	var cur strings.Builder
+	inWord := false
+	var quote byte
+
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case quote == '\'':
+			if c == '\'' {
+				quote = 0
+			} else {
+				cur.WriteByte(c)
+			}
+		case quote == '"':
+			switch {
+			case c == '"':
+				quote = 0
+			case c == '\\' && i+1 < len(s) && strings.IndexByte(`"\$`, s[i+1]) >= 0:
+				i++
+				cur.WriteByte(s[i])
+			default:
+				cur.WriteByte(c)
+			}
+		case c == '\'' || c == '"':
+			quote = c
+			inWord = true
+		case c == '\\' && i+1 < len(s):
+			i++
+			cur.WriteByte(s[i])
+			inWord = true
+		case c == ' ' || c == '\t' || c == '\n':
+			if inWord {
+				words = append(words, cur.String())
+				cur.Reset()
+				inWord = false
+			}
+		default:
+			cur.WriteByte(c)
+			inWord = true
+		}
+	}
+
+	if quote != 0 {
+		return nil, fmt.Errorf("unterminated %c quote", quote)
+	}
+	if inWord {
+		words = append(words, cur.String())
+	}
+	return words, nil
+}
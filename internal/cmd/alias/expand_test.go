@@ -0,0 +1,112 @@
+package alias
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+)
+
+func TestSplitArgs(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    []string
+		wantErr bool
+	}{
+		{in: "pr merge --squash", want: []string{"pr", "merge", "--squash"}},
+		{in: `pr create --title "WIP: $1"`, want: []string{"pr", "create", "--title", "WIP: $1"}},
+		{in: `issue list -s 'on hold'`, want: []string{"issue", "list", "-s", "on hold"}},
+		{in: `a\ b "c\"d"`, want: []string{"a b", `c"d`}},
+		{in: `x ""`, want: []string{"x", ""}},
+		{in: `pr "list`, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		got, err := SplitArgs(tt.in)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("SplitArgs(%q) error = %v, wantErr %v", tt.in, err, tt.wantErr)
+			continue
+		}
+		if !tt.wantErr && !slices.Equal(got, tt.want) {
+			t.Errorf("SplitArgs(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestExpand(t *testing.T) {
+	aliases := map[string]string{
+		"prm":   "pr merge --squash",
+		"prs":   "pr list --author $1 --state $2",
+		"all":   "pr list $@ --json",
+		"chain": "prm --yes",
+		"sh":    `!echo "$1"`,
+		"loop1": "loop2",
+		"loop2": "loop1 x",
+	}
+
+	tests := []struct {
+		name      string
+		args      []string
+		want      []string
+		wantShell string
+		wantOK    bool
+		wantErr   string
+	}{
+		{name: "not an alias", args: []string{"pr", "list"}},
+		{name: "passes arguments through", args: []string{"prm", "42"}, want: []string{"pr", "merge", "--squash", "42"}, wantOK: true},
+		{name: "positional", args: []string{"prs", "alice", "OPEN", "--web"}, want: []string{"pr", "list", "--author", "alice", "--state", "OPEN", "--web"}, wantOK: true},
+		{name: "all arguments", args: []string{"all", "-L", "5"}, want: []string{"pr", "list", "-L", "5", "--json"}, wantOK: true},
+		{name: "chained", args: []string{"chain", "7"}, want: []string{"pr", "merge", "--squash", "--yes", "7"}, wantOK: true},
+		{name: "shell", args: []string{"sh", "hi"}, want: []string{"hi"}, wantShell: `echo "$1"`, wantOK: true},
+		{name: "loop", args: []string{"loop1"}, wantOK: true, wantErr: "recursively"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, shell, ok, err := Expand(aliases, tt.args)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("Expand() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Expand() error: %v", err)
+			}
+			if ok != tt.wantOK || shell != tt.wantShell || !slices.Equal(got, tt.want) {
+				t.Errorf("Expand() = %q, %q, %v; want %q, %q, %v", got, shell, ok, tt.want, tt.wantShell, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestValidateAlias(t *testing.T) {
+	root := &cobra.Command{Use: "bb"}
+	root.AddCommand(&cobra.Command{Use: "pr", Aliases: []string{"prs"}})
+
+	existing := map[string]string{"co": "pr checkout", "back": "fwd"}
+
+	tests := []struct {
+		name, expansion string
+		wantErr         bool
+	}{
+		{"prm", "pr merge", false},
+		{"co2", "co --force", false},
+		{"sh", "!anything goes", false},
+		{"pr", "pr list", true},
+		{"prs", "pr list", true},
+		{"help", "pr list", true},
+		{"-x", "pr list", true},
+		{"bad", "nope", true},
+		{"fwd", "back", true},
+		{"empty", "!", true},
+	}
+
+	for _, tt := range tests {
+		err := validateAlias(root, existing, tt.name, tt.expansion)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("validateAlias(%q, %q) error = %v, wantErr %v", tt.name, tt.expansion, err, tt.wantErr)
+		}
+	}
+}
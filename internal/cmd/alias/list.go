@@ -0,0 +1,48 @@
+package alias
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/spf13/cobra"
+
+	"github.com/rbansal42/bitbucket-cli/internal/cmdutil"
+	"github.com/rbansal42/bitbucket-cli/internal/config"
+	"github.com/rbansal42/bitbucket-cli/internal/iostreams"
+)
+
+// NewCmdList creates the alias list command
+func NewCmdList(streams *iostreams.IOStreams) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "list",
+		Short:   "List your aliases",
+		Aliases: []string{"ls"},
+		Args:    cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cfg, err := config.LoadConfig()
+			if err != nil {
+				return fmt.Errorf("could not load config: %w", err)
+			}
+
+			if len(cfg.Aliases) == 0 {
+				fmt.Fprintln(streams.ErrOut, "No aliases configured")
+				return nil
+			}
+
+			names := make([]string, 0, len(cfg.Aliases))
+			for name := range cfg.Aliases {
+				names = append(names, name)
+			}
+			slices.Sort(names)
+
+			t := cmdutil.NewTableWriter(streams, "ALIAS", "EXPANSION")
+			t.SetFlexColumn(1)
+			for _, name := range names {
+				t.AddRow(name, cfg.Aliases[name])
+			}
+			return t.Render()
+		},
+	}
+
+	return cmd
+}
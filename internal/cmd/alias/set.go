@@ -0,0 +1,119 @@
+package alias
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/rbansal42/bitbucket-cli/internal/config"
+	"github.com/rbansal42/bitbucket-cli/internal/iostreams"
+)
+
+// NewCmdSet creates the alias set command
+func NewCmdSet(streams *iostreams.IOStreams) *cobra.Command {
+	var shell bool
+
+	cmd := &cobra.Command{
+		Use:   "set <alias> <expansion>",
+		Short: "Create a shortcut for a bb command",
+		Long: `Define a word that expands to a full bb command when invoked.
+
+The expansion may use $1, $2, ... for positional arguments and $@ for all
+arguments; arguments that aren't used by a placeholder are appended. Quote
+the expansion so your shell passes it as one argument.
+
+Expansions starting with ! (or set with --shell) are run with sh, so they
+can use pipes and other programs.
+
+An alias cannot shadow a built-in command.`,
+		Example: `  bb alias set prm 'pr merge --squash'
+  bb alias set co 'pr checkout'
+  bb alias set prs 'pr list --author $1'
+  bb alias set --shell igrep 'bb issue list --json | grep "$1"'`,
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			name, expansion := args[0], strings.TrimSpace(args[1])
+			if shell && !IsShellAlias(expansion) {
+				expansion = "!" + expansion
+			}
+
+			cfg, err := config.LoadConfig()
+			if err != nil {
+				return fmt.Errorf("could not load config: %w", err)
+			}
+
+			if err := validateAlias(cmd.Root(), cfg.Aliases, name, expansion); err != nil {
+				return err
+			}
+
+			_, existed := cfg.Aliases[name]
+			if cfg.Aliases == nil {
+				cfg.Aliases = make(map[string]string)
+			}
+			cfg.Aliases[name] = expansion
+
+			if err := config.SaveConfig(cfg); err != nil {
+				return fmt.Errorf("could not save config: %w", err)
+			}
+
+			if existed {
+				streams.Success("Changed alias %s to %s", name, expansion)
+			} else {
+				streams.Success("Added alias %s for %s", name, expansion)
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVarP(&shell, "shell", "s", false, "Run the expansion with sh, as if it started with !")
+
+	return cmd
+}
+
+// validateAlias checks that name can be defined as expansion: it must not
+// shadow a command, must expand to a real command or shell command, and must
+// not form a loop with existing aliases
+func validateAlias(root *cobra.Command, aliases map[string]string, name, expansion string) error {
+	if name == "" || strings.HasPrefix(name, "-") || strings.ContainsAny(name, " \t\n") {
+		return fmt.Errorf("invalid alias name %q", name)
+	}
+	if isCommand(root, name) {
+		return fmt.Errorf("%q is already a bb command and cannot be used as an alias", name)
+	}
+	if expansion == "" || expansion == "!" {
+		return fmt.Errorf("alias expansion cannot be empty")
+	}
+	if IsShellAlias(expansion) {
+		return nil
+	}
+
+	candidate := make(map[string]string, len(aliases)+1)
+	for k, v := range aliases {
+		candidate[k] = v
+	}
+	candidate[name] = expansion
+
+	expanded, shellCmd, _, err := Expand(candidate, []string{name})
+	if err != nil {
+		return err
+	}
+	if shellCmd == "" && !isCommand(root, expanded[0]) {
+		return fmt.Errorf("expansion %q does not start with a bb command or alias", expansion)
+	}
+	return nil
+}
+
+// isCommand reports whether name is a top-level bb command or one of its
+// aliases
+func isCommand(root *cobra.Command, name string) bool {
+	if name == "help" {
+		return true
+	}
+	for _, c := range root.Commands() {
+		if c.Name() == name || c.HasAlias(name) {
+			return true
+		}
+	}
+	return false
+}
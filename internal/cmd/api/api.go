@@ -2,32 +2,45 @@ package api
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
+	"net/url"
 	"os"
+	"os/signal"
 	"strings"
-	"time"
 
 	"github.com/spf13/cobra"
 
-	"github.com/rbansal42/bitbucket-cli/internal/config"
+	"github.com/rbansal42/bitbucket-cli/internal/api"
+	"github.com/rbansal42/bitbucket-cli/internal/cmdutil"
 	"github.com/rbansal42/bitbucket-cli/internal/iostreams"
 )
 
+type apiOptions struct {
+	streams     *iostreams.IOStreams
+	endpoint    string
+	method      string
+	headers     []string
+	inputFile   string
+	rawFields   []string
+	jsonFields  []string
+	silent      bool
+	includeResp bool
+	paginate    bool
+	maxPages    int
+	inputJSONL  string // one request body per line
+	failFast    bool
+	concurrency int
+}
+
 // NewCmdAPI creates the api command
 func NewCmdAPI(streams *iostreams.IOStreams) *cobra.Command {
-	var (
-		method      string
-		headers     []string
-		inputFile   string
-		rawFields   []string
-		jsonFields  []string
-		silent      bool
-		includeResp bool
-		paginate    bool
-	)
+	opts := &apiOptions{
+		streams: streams,
+	}
 
 	cmd := &cobra.Command{
 		Use:   "api <endpoint>",
@@ -35,15 +48,32 @@ func NewCmdAPI(streams *iostreams.IOStreams) *cobra.Command {
 		Long: `Make an authenticated request to the Bitbucket API.
 
 The endpoint argument should be the path of the API endpoint to call,
-such as "repositories/workspace/repo" or "user".
+such as "repositories/workspace/repo" or "user". A full API URL, such as
+the "next" link from an earlier response, is also accepted.
 
-The default HTTP method is GET. Pass --method to specify a different method.
+Requests use the same credentials as every other command, including
+BB_TOKEN when it is set.
+
+The default HTTP method is GET. Pass --method to specify a different method
+(GET, POST, PUT, PATCH, DELETE or HEAD).
 
 Placeholder values in the endpoint will be substituted with values from
 the current repository context when available.
 
 Pass request body using --field for URL-encoded data, --json for JSON data,
-or --input for reading from a file.`,
+or --input for reading from a file.
+
+Each --json field has one of these forms:
+  key=value      a string
+  key:=value     a raw JSON value: a number, true/false, null, array or object
+  key@file.json  the JSON contained in a file
+
+Use --input-jsonl to send many requests at once: each non-blank line of the
+file is a JSON request body, sent to the endpoint with --method. Up to
+--concurrency requests run at a time. Each result is printed as a line of
+JSON with the input line number, the status, and the response body or
+error. Failed requests don't stop the others unless --fail-fast is given;
+the command exits with an error if any request failed.`,
 		Example: `  # Get the current user
   bb api user
 
@@ -54,265 +84,358 @@ or --input for reading from a file.`,
   bb api repositories/myworkspace/myrepo/issues --method POST \
     --json title="Bug report" --json priority="major"
 
+  # Send typed values
+  bb api repositories/myworkspace/myrepo --method PUT \
+    --json is_private:=true --json description="Internal tools"
+
+  # Partially update a resource from a JSON file
+  bb api repositories/myworkspace/myrepo --method PATCH --input changes.json
+
+  # Create one issue per line of a file, four requests at a time
+  bb api repositories/myworkspace/myrepo/issues --method POST --input-jsonl issues.jsonl
+
+  # Fetch every page of results, up to 10 pages
+  bb api repositories/myworkspace --paginate --max-pages 10
+
   # Get raw response with headers
   bb api user --include`,
 		Args: cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			endpoint := args[0]
-
-			// Ensure endpoint starts with a slash or is a full URL
-			if !strings.HasPrefix(endpoint, "/") && !strings.HasPrefix(endpoint, "http") {
-				endpoint = "/" + endpoint
-			}
-
-			// Construct full URL if not already a full URL
-			var url string
-			if strings.HasPrefix(endpoint, "http") {
-				url = endpoint
-			} else {
-				url = "https://api.bitbucket.org/2.0" + endpoint
-			}
+			opts.endpoint = args[0]
 
-			// Get authentication token
-			token, err := getAuthToken()
+			client, err := cmdutil.GetAPIClient()
 			if err != nil {
-				return fmt.Errorf("authentication required: %w\nRun 'bb auth login' to authenticate", err)
+				return err
 			}
 
-			// Prepare request body
-			var body io.Reader
-			contentType := ""
-
-			if inputFile != "" {
-				// Read from file
-				if inputFile == "-" {
-					body = os.Stdin
-				} else {
-					data, err := os.ReadFile(inputFile)
-					if err != nil {
-						return fmt.Errorf("could not read input file: %w", err)
-					}
-					body = bytes.NewReader(data)
-				}
-				contentType = "application/json"
-			} else if len(jsonFields) > 0 {
-				// Build JSON body from fields
-				jsonBody := make(map[string]interface{})
-				for _, field := range jsonFields {
-					parts := strings.SplitN(field, "=", 2)
-					if len(parts) != 2 {
-						return fmt.Errorf("invalid json field format: %s (expected key=value)", field)
-					}
-					jsonBody[parts[0]] = parts[1]
-				}
-				data, err := json.Marshal(jsonBody)
-				if err != nil {
-					return fmt.Errorf("could not encode JSON: %w", err)
-				}
-				body = bytes.NewReader(data)
-				contentType = "application/json"
-			} else if len(rawFields) > 0 {
-				// Build form body from fields
-				var formParts []string
-				for _, field := range rawFields {
-					formParts = append(formParts, field)
-				}
-				body = strings.NewReader(strings.Join(formParts, "&"))
-				contentType = "application/x-www-form-urlencoded"
-			}
+			// Ctrl-C cancels ctx; --paginate uses that to stop after the
+			// current page. Signal handling is released on the first
+			// interrupt so a second Ctrl-C exits immediately.
+			ctx, stop := signal.NotifyContext(cmd.Context(), os.Interrupt)
+			defer stop()
+			go func() {
+				<-ctx.Done()
+				stop()
+			}()
+
+			return runAPI(ctx, client, opts)
+		},
+	}
 
-			// Create request
-			req, err := http.NewRequest(strings.ToUpper(method), url, body)
-			if err != nil {
-				return fmt.Errorf("could not create request: %w", err)
-			}
+	cmd.Flags().StringVarP(&opts.method, "method", "X", "GET", "HTTP method to use")
+	_ = cmd.RegisterFlagCompletionFunc("method", cobra.FixedCompletions(commonMethods, cobra.ShellCompDirectiveNoFileComp))
+	cmd.Flags().StringArrayVarP(&opts.headers, "header", "H", nil, "Add a custom header (can be specified multiple times)")
+	cmd.Flags().StringVar(&opts.inputFile, "input", "", "Read request body from file (use - for stdin)")
+	cmd.Flags().StringArrayVarP(&opts.rawFields, "field", "f", nil, "Add a URL-encoded field (can be specified multiple times)")
+	cmd.Flags().StringArrayVarP(&opts.jsonFields, "json", "j", nil, "Add a JSON field as key=string, key:=json or key@file (can be specified multiple times)")
+	cmd.Flags().BoolVarP(&opts.silent, "silent", "s", false, "Do not print response body")
+	cmd.Flags().BoolVarP(&opts.includeResp, "include", "i", false, "Include response headers in output")
+	cmd.Flags().BoolVar(&opts.paginate, "paginate", false, "Automatically fetch all pages of results")
+	cmd.Flags().IntVar(&opts.maxPages, "max-pages", 0, "Stop --paginate after this many pages (0 for no limit)")
+	cmd.Flags().StringVar(&opts.inputJSONL, "input-jsonl", "", "Send one request per line of a JSON Lines file (use - for stdin)")
+	cmd.Flags().BoolVar(&opts.failFast, "fail-fast", false, "Stop sending --input-jsonl requests after the first failure")
+	cmd.Flags().IntVar(&opts.concurrency, "concurrency", 4, "Maximum number of --input-jsonl requests in flight")
+
+	cmd.MarkFlagsMutuallyExclusive("input-jsonl", "input")
+	cmd.MarkFlagsMutuallyExclusive("input-jsonl", "json")
+	cmd.MarkFlagsMutuallyExclusive("input-jsonl", "field")
+	cmd.MarkFlagsMutuallyExclusive("input-jsonl", "paginate")
+	cmd.MarkFlagsMutuallyExclusive("input-jsonl", "include")
 
-			// Set headers
-			req.Header.Set("Authorization", "Bearer "+token)
-			req.Header.Set("Accept", "application/json")
-			if contentType != "" {
-				req.Header.Set("Content-Type", contentType)
-			}
+	return cmd
+}
 
-			// Add custom headers
-			for _, h := range headers {
-				parts := strings.SplitN(h, ":", 2)
-				if len(parts) != 2 {
-					return fmt.Errorf("invalid header format: %s (expected Header:Value)", h)
-				}
-				req.Header.Set(strings.TrimSpace(parts[0]), strings.TrimSpace(parts[1]))
-			}
+// commonMethods are the HTTP methods offered by --method completion; any
+// other valid method is accepted too
+var commonMethods = []string{
+	http.MethodGet,
+	http.MethodPost,
+	http.MethodPut,
+	http.MethodPatch,
+	http.MethodDelete,
+	http.MethodHead,
+	http.MethodOptions,
+}
 
-			// Execute request
-			client := &http.Client{
-				Timeout: 30 * time.Second,
-			}
+// validMethod reports whether method is an RFC 7230 token, the syntax of an
+// HTTP method
+func validMethod(method string) bool {
+	if method == "" {
+		return false
+	}
+	for _, r := range method {
+		alnum := r >= 'A' && r <= 'Z' || r >= 'a' && r <= 'z' || r >= '0' && r <= '9'
+		if !alnum && !strings.ContainsRune("!#$%&'*+-.^_`|~", r) {
+			return false
+		}
+	}
+	return true
+}
 
-			resp, err := client.Do(req)
+// buildRequestBody returns the request body and its content type from
+// --input, --json or --field, in that order of precedence. The content
+// type matches what api.Client sends, whatever the method.
+func buildRequestBody(stdin io.Reader, inputFile string, jsonFields, rawFields []string) (io.Reader, string, error) {
+	switch {
+	case inputFile == "-":
+		return stdin, "application/json", nil
+	case inputFile != "":
+		data, err := os.ReadFile(inputFile)
+		if err != nil {
+			return nil, "", fmt.Errorf("could not read input file: %w", err)
+		}
+		return bytes.NewReader(data), "application/json", nil
+	case len(jsonFields) > 0:
+		jsonBody := make(map[string]interface{})
+		for _, field := range jsonFields {
+			key, value, err := parseJSONField(field, os.ReadFile)
 			if err != nil {
-				return fmt.Errorf("request failed: %w", err)
+				return nil, "", err
 			}
-			defer resp.Body.Close()
+			jsonBody[key] = value
+		}
+		data, err := json.Marshal(jsonBody)
+		if err != nil {
+			return nil, "", fmt.Errorf("could not encode JSON: %w", err)
+		}
+		return bytes.NewReader(data), "application/json", nil
+	case len(rawFields) > 0:
+		return strings.NewReader(strings.Join(rawFields, "&")), "application/x-www-form-urlencoded", nil
+	}
+	return nil, "", nil
+}
 
-			// Handle pagination if requested
-			if paginate && resp.StatusCode == http.StatusOK {
-				return handlePagination(streams, client, req, resp, token, includeResp, silent)
-			}
+// parseJSONField parses a --json field. key=value sets a string, key:=value
+// sets a raw JSON value such as a number, bool, array or object, and
+// key@path sets the JSON read from a file.
+func parseJSONField(field string, readFile func(string) ([]byte, error)) (string, interface{}, error) {
+	i := strings.IndexAny(field, "=:@")
+	if i <= 0 {
+		return "", nil, fmt.Errorf("invalid json field format: %s (expected key=value, key:=json or key@file)", field)
+	}
+	key := field[:i]
 
-			// Print response headers if requested
-			if includeResp {
-				fmt.Fprintf(streams.Out, "%s %s\n", resp.Proto, resp.Status)
-				for key, values := range resp.Header {
-					for _, value := range values {
-						fmt.Fprintf(streams.Out, "%s: %s\n", key, value)
-					}
-				}
-				fmt.Fprintln(streams.Out)
-			}
+	switch {
+	case field[i] == '=':
+		return key, field[i+1:], nil
 
-			// Read and print response body
-			if !silent {
-				respBody, err := io.ReadAll(resp.Body)
-				if err != nil {
-					return fmt.Errorf("could not read response: %w", err)
-				}
-
-				// Pretty-print JSON if possible
-				if strings.Contains(resp.Header.Get("Content-Type"), "application/json") {
-					var prettyJSON bytes.Buffer
-					if err := json.Indent(&prettyJSON, respBody, "", "  "); err == nil {
-						fmt.Fprintln(streams.Out, prettyJSON.String())
-					} else {
-						fmt.Fprintln(streams.Out, string(respBody))
-					}
-				} else {
-					fmt.Fprintln(streams.Out, string(respBody))
-				}
-			}
+	case strings.HasPrefix(field[i:], ":="):
+		raw := field[i+2:]
+		if !json.Valid([]byte(raw)) {
+			return "", nil, fmt.Errorf("invalid JSON value for %s: %s", key, raw)
+		}
+		return key, json.RawMessage(raw), nil
 
-			// Return error for non-2xx status codes
-			if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-				return fmt.Errorf("API request failed with status %d", resp.StatusCode)
-			}
+	case field[i] == '@':
+		path := field[i+1:]
+		data, err := readFile(path)
+		if err != nil {
+			return "", nil, fmt.Errorf("could not read value for %s: %w", key, err)
+		}
+		data = bytes.TrimSpace(data)
+		if !json.Valid(data) {
+			return "", nil, fmt.Errorf("%s does not contain valid JSON", path)
+		}
+		return key, json.RawMessage(data), nil
+	}
 
-			return nil
-		},
+	return "", nil, fmt.Errorf("invalid json field format: %s (expected key=value, key:=json or key@file)", field)
+}
+
+// runAPI sends the request described by opts through client, so it shares
+// the client's base URL and authentication with every other command
+func runAPI(ctx context.Context, client *api.Client, opts *apiOptions) error {
+	method := strings.ToUpper(opts.method)
+	if !validMethod(method) {
+		return fmt.Errorf("invalid method %q: must be an HTTP method such as %s", method, strings.Join(commonMethods, ", "))
 	}
 
-	cmd.Flags().StringVarP(&method, "method", "X", "GET", "HTTP method to use")
-	cmd.Flags().StringArrayVarP(&headers, "header", "H", nil, "Add a custom header (can be specified multiple times)")
-	cmd.Flags().StringVar(&inputFile, "input", "", "Read request body from file (use - for stdin)")
-	cmd.Flags().StringArrayVarP(&rawFields, "field", "f", nil, "Add a URL-encoded field (can be specified multiple times)")
-	cmd.Flags().StringArrayVarP(&jsonFields, "json", "j", nil, "Add a JSON field (can be specified multiple times)")
-	cmd.Flags().BoolVarP(&silent, "silent", "s", false, "Do not print response body")
-	cmd.Flags().BoolVarP(&includeResp, "include", "i", false, "Include response headers in output")
-	cmd.Flags().BoolVar(&paginate, "paginate", false, "Automatically fetch all pages of results")
+	path, query, err := endpointPath(client, opts.endpoint)
+	if err != nil {
+		return err
+	}
+	headers, err := parseHeaders(opts.headers)
+	if err != nil {
+		return err
+	}
 
-	return cmd
-}
+	if opts.inputJSONL != "" {
+		return runBatch(ctx, client, opts, method, path, query, headers)
+	}
+
+	req := &api.Request{
+		Method:  method,
+		Path:    path,
+		Query:   query,
+		Headers: headers,
+	}
 
-// getAuthToken retrieves the authentication token
-func getAuthToken() (string, error) {
-	// Check environment variables first (BB_TOKEN takes precedence)
-	if token := os.Getenv("BB_TOKEN"); token != "" {
-		return token, nil
+	// Prepare request body
+	body, contentType, err := buildRequestBody(opts.streams.In, opts.inputFile, opts.jsonFields, opts.rawFields)
+	if err != nil {
+		return err
 	}
-	if token := os.Getenv("BITBUCKET_TOKEN"); token != "" {
-		return token, nil
+	req.RawBody = body
+	if contentType != "" && req.Headers["Content-Type"] == "" {
+		req.Headers["Content-Type"] = contentType
 	}
 
-	// Load hosts config to get active user
-	hosts, err := config.LoadHostsConfig()
+	resp, err := client.DoRaw(ctx, req)
 	if err != nil {
-		return "", err
+		return err
 	}
 
-	user := hosts.GetActiveUser(config.DefaultHost)
-	if user == "" {
-		return "", fmt.Errorf("no authenticated user found")
+	if opts.includeResp {
+		printHeaders(opts.streams.Out, resp)
 	}
 
-	// Get token from keyring
-	token, err := config.GetToken(config.DefaultHost, user)
-	if err != nil {
-		return "", err
+	// Handle pagination if requested
+	if opts.paginate && resp.StatusCode == http.StatusOK {
+		return handlePagination(ctx, opts, client, resp)
+	}
+
+	if !opts.silent {
+		printBody(opts.streams.Out, resp)
 	}
 
-	return token, nil
+	return checkStatus(resp)
 }
 
-// handlePagination handles paginated responses
-func handlePagination(streams *iostreams.IOStreams, client *http.Client, originalReq *http.Request, firstResp *http.Response, token string, includeResp, silent bool) error {
-	type paginatedResponse struct {
-		Values []json.RawMessage `json:"values"`
-		Next   string            `json:"next"`
+// endpointPath returns the path and query to request for an endpoint. Full
+// URLs are accepted as long as they point at the API, which makes it easy
+// to follow links from earlier responses.
+func endpointPath(client *api.Client, endpoint string) (string, url.Values, error) {
+	if strings.HasPrefix(endpoint, "https://") || strings.HasPrefix(endpoint, "http://") {
+		return client.RelativePath(endpoint)
 	}
+	return endpoint, nil, nil
+}
 
-	allValues := []json.RawMessage{}
+// parseHeaders parses --header values of the form Header:Value
+func parseHeaders(values []string) (map[string]string, error) {
+	headers := make(map[string]string, len(values))
+	for _, h := range values {
+		parts := strings.SplitN(h, ":", 2)
+		if len(parts) != 2 {
+			return nil, fmt.Errorf("invalid header format: %s (expected Header:Value)", h)
+		}
+		headers[strings.TrimSpace(parts[0])] = strings.TrimSpace(parts[1])
+	}
+	return headers, nil
+}
 
-	// Process first response
-	if includeResp {
-		fmt.Fprintf(streams.Out, "%s %s\n", firstResp.Proto, firstResp.Status)
-		for key, values := range firstResp.Header {
-			for _, value := range values {
-				fmt.Fprintf(streams.Out, "%s: %s\n", key, value)
-			}
+// printHeaders writes the status line and headers of resp
+func printHeaders(w io.Writer, resp *api.Response) {
+	fmt.Fprintf(w, "%s %s\n", resp.Proto, resp.Status)
+	for key, values := range resp.Headers {
+		for _, value := range values {
+			fmt.Fprintf(w, "%s: %s\n", key, value)
 		}
-		fmt.Fprintln(streams.Out)
 	}
+	fmt.Fprintln(w)
+}
 
-	body, err := io.ReadAll(firstResp.Body)
-	if err != nil {
-		return fmt.Errorf("could not read response: %w", err)
+// printBody writes the body of resp, pretty-printing JSON when possible
+func printBody(w io.Writer, resp *api.Response) {
+	if strings.Contains(resp.Headers.Get("Content-Type"), "application/json") {
+		var prettyJSON bytes.Buffer
+		if err := json.Indent(&prettyJSON, resp.Body, "", "  "); err == nil {
+			fmt.Fprintln(w, prettyJSON.String())
+			return
+		}
 	}
+	fmt.Fprintln(w, string(resp.Body))
+}
+
+// checkStatus returns an error for non-2xx responses
+func checkStatus(resp *api.Response) error {
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		if id := api.RequestID(resp.Headers); id != "" {
+			return fmt.Errorf("API request failed with status %d (request ID %s)", resp.StatusCode, id)
+		}
+		return fmt.Errorf("API request failed with status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// handlePagination follows the next links from the first page and prints
+// the values of every page as a single JSON array. When ctx is cancelled,
+// for example by Ctrl-C, it finishes the page in flight and prints what it
+// has so far before returning an error.
+func handlePagination(ctx context.Context, opts *apiOptions, client *api.Client, firstResp *api.Response) error {
+	type paginatedResponse struct {
+		Values []json.RawMessage `json:"values"`
+		Next   string            `json:"next"`
+	}
+
+	streams := opts.streams
 
 	var page paginatedResponse
-	if err := json.Unmarshal(body, &page); err != nil {
+	if err := json.Unmarshal(firstResp.Body, &page); err != nil {
 		// Not a paginated response, just print it
-		if !silent {
-			fmt.Fprintln(streams.Out, string(body))
+		if !opts.silent {
+			printBody(streams.Out, firstResp)
 		}
 		return nil
 	}
 
-	allValues = append(allValues, page.Values...)
-	nextURL := page.Next
+	allValues := append([]json.RawMessage{}, page.Values...)
+	pages := 1
 
-	// Fetch remaining pages
-	for nextURL != "" {
-		req, err := http.NewRequest("GET", nextURL, nil)
-		if err != nil {
-			return fmt.Errorf("could not create request: %w", err)
+	// Progress goes to stderr, and only when a person is watching stdout
+	showProgress := streams.IsStdoutTTY()
+	progress := func() {
+		if showProgress {
+			fmt.Fprintf(streams.ErrOut, "\rfetched %d items across %d pages", len(allValues), pages)
 		}
+	}
+	progress()
 
-		req.Header.Set("Authorization", "Bearer "+token)
-		req.Header.Set("Accept", "application/json")
+	// Requests are not tied to ctx so an interrupt lets the current page
+	// finish instead of discarding it
+	reqCtx := context.WithoutCancel(ctx)
 
-		resp, err := client.Do(req)
-		if err != nil {
-			return fmt.Errorf("request failed: %w", err)
+	var stopErr error
+	for page.Next != "" {
+		if ctx.Err() != nil {
+			stopErr = fmt.Errorf("interrupted after %d pages: %w", pages, ctx.Err())
+			break
+		}
+		if opts.maxPages > 0 && pages >= opts.maxPages {
+			break
 		}
 
-		body, err := io.ReadAll(resp.Body)
-		resp.Body.Close()
+		path, query, err := client.RelativePath(page.Next)
 		if err != nil {
-			return fmt.Errorf("could not read response: %w", err)
+			return fmt.Errorf("invalid next page: %w", err)
 		}
 
-		if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-			return fmt.Errorf("API request failed with status %d", resp.StatusCode)
+		resp, err := client.GetRaw(reqCtx, path, query)
+		if err != nil {
+			return err
+		}
+		if err := checkStatus(resp); err != nil {
+			return err
 		}
 
-		if err := json.Unmarshal(body, &page); err != nil {
+		page = paginatedResponse{}
+		if err := json.Unmarshal(resp.Body, &page); err != nil {
 			return fmt.Errorf("could not parse response: %w", err)
 		}
-
 		allValues = append(allValues, page.Values...)
-		nextURL = page.Next
+		pages++
+		progress()
+	}
+
+	if showProgress {
+		fmt.Fprintln(streams.ErrOut)
+	}
+	if stopErr == nil && page.Next != "" {
+		streams.Warning("Stopped after %d pages; more results are available", pages)
 	}
 
 	// Print all values
-	if !silent {
+	if !opts.silent {
 		result, err := json.MarshalIndent(allValues, "", "  ")
 		if err != nil {
 			return fmt.Errorf("could not encode results: %w", err)
@@ -320,5 +443,5 @@ func handlePagination(streams *iostreams.IOStreams, client *http.Client, origina
 		fmt.Fprintln(streams.Out, string(result))
 	}
 
-	return nil
+	return stopErr
 }
@@ -0,0 +1,249 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/rbansal42/bitbucket-cli/internal/api"
+	"github.com/rbansal42/bitbucket-cli/internal/iostreams"
+)
+
+func newTestAPI(t *testing.T, handler http.HandlerFunc) (*api.Client, string) {
+	t.Helper()
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+	baseURL := server.URL + "/2.0"
+	return api.NewClient(api.WithBaseURL(baseURL), api.WithToken("test-token")), baseURL
+}
+
+func newTestOptions(endpoint string) (*apiOptions, *bytes.Buffer) {
+	out := &bytes.Buffer{}
+	return &apiOptions{
+		streams:  &iostreams.IOStreams{In: strings.NewReader(""), Out: out, ErrOut: &bytes.Buffer{}},
+		endpoint: endpoint,
+		method:   "GET",
+	}, out
+}
+
+func TestRunAPI_UsesClientAuthAndBody(t *testing.T) {
+	client, _ := newTestAPI(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/2.0/repositories/ws/repo/issues" {
+			t.Errorf("request = %s %s", r.Method, r.URL.Path)
+		}
+		if got := r.Header.Get("Authorization"); got != "Bearer test-token" {
+			t.Errorf("Authorization = %q", got)
+		}
+		if got := r.Header.Get("Content-Type"); got != "application/x-www-form-urlencoded" {
+			t.Errorf("Content-Type = %q", got)
+		}
+		body, _ := io.ReadAll(r.Body)
+		if string(body) != "title=Bug" {
+			t.Errorf("body = %q", body)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"id":1}`)
+	})
+
+	opts, out := newTestOptions("repositories/ws/repo/issues")
+	opts.method = "post"
+	opts.rawFields = []string{"title=Bug"}
+	if err := runAPI(context.Background(), client, opts); err != nil {
+		t.Fatalf("runAPI() error: %v", err)
+	}
+	if got := out.String(); got != "{\n  \"id\": 1\n}\n" {
+		t.Errorf("output = %q", got)
+	}
+}
+
+func TestRunAPI_Paginate(t *testing.T) {
+	// The handler needs the server's URL to build next links
+	var baseURL string
+	client, baseURL := newTestAPI(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("page") == "2" {
+			fmt.Fprint(w, `{"values": [3]}`)
+			return
+		}
+		fmt.Fprintf(w, `{"values": [1, 2], "next": "%s/repositories/ws?page=2"}`, baseURL)
+	})
+
+	opts, out := newTestOptions(baseURL + "/repositories/ws")
+	opts.paginate = true
+	if err := runAPI(context.Background(), client, opts); err != nil {
+		t.Fatalf("runAPI() error: %v", err)
+	}
+	if got := strings.Join(strings.Fields(out.String()), ""); got != "[1,2,3]" {
+		t.Errorf("output = %q, want all values", got)
+	}
+}
+
+func TestRunAPI_ErrorStatusPrintsBody(t *testing.T) {
+	client, _ := newTestAPI(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		fmt.Fprint(w, `not found`)
+	})
+
+	opts, out := newTestOptions("/user")
+	err := runAPI(context.Background(), client, opts)
+	if err == nil || !strings.Contains(err.Error(), "404") {
+		t.Errorf("runAPI() error = %v, want the 404 status", err)
+	}
+	if !strings.Contains(out.String(), "not found") {
+		t.Errorf("output = %q, want the error body", out.String())
+	}
+}
+
+func TestRunAPI_RejectsOtherHosts(t *testing.T) {
+	client, _ := newTestAPI(t, func(w http.ResponseWriter, r *http.Request) {
+		t.Error("no request should be sent")
+	})
+
+	opts, _ := newTestOptions("https://example.com/2.0/user")
+	if err := runAPI(context.Background(), client, opts); err == nil {
+		t.Error("runAPI() expected an error for a URL on another host")
+	}
+}
+
+func newPagedAPI(t *testing.T, onPage func(page int)) (*api.Client, string) {
+	t.Helper()
+	var baseURL string
+	client, baseURL := newTestAPI(t, func(w http.ResponseWriter, r *http.Request) {
+		page := 1
+		fmt.Sscan(r.URL.Query().Get("page"), &page)
+		if onPage != nil {
+			onPage(page)
+		}
+		fmt.Fprintf(w, `{"values": [%d], "next": "%s/items?page=%d"}`, page, baseURL, page+1)
+	})
+	return client, baseURL
+}
+
+func TestRunAPI_MaxPages(t *testing.T) {
+	client, _ := newPagedAPI(t, nil)
+
+	opts, out := newTestOptions("/items")
+	opts.paginate = true
+	opts.maxPages = 3
+	if err := runAPI(context.Background(), client, opts); err != nil {
+		t.Fatalf("runAPI() error: %v", err)
+	}
+	if got := strings.Join(strings.Fields(out.String()), ""); got != "[1,2,3]" {
+		t.Errorf("output = %q, want the first three pages", got)
+	}
+	if errOut := opts.streams.ErrOut.(*bytes.Buffer).String(); !strings.Contains(errOut, "more results") {
+		t.Errorf("stderr = %q, want a note that more results exist", errOut)
+	}
+}
+
+func TestRunAPI_InterruptKeepsFetchedPages(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// Interrupt while the second page is in flight
+	client, _ := newPagedAPI(t, func(page int) {
+		if page == 2 {
+			cancel()
+		}
+	})
+
+	opts, out := newTestOptions("/items")
+	opts.paginate = true
+	opts.streams.SetStdoutTTY(true)
+	err := runAPI(ctx, client, opts)
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("runAPI() error = %v, want context.Canceled", err)
+	}
+	if got := strings.Join(strings.Fields(out.String()), ""); got != "[1,2]" {
+		t.Errorf("output = %q, want the pages fetched before the interrupt", got)
+	}
+	if errOut := opts.streams.ErrOut.(*bytes.Buffer).String(); !strings.Contains(errOut, "fetched 2 items across 2 pages") {
+		t.Errorf("stderr = %q, want progress", errOut)
+	}
+}
+
+func TestBuildRequestBody_TypedJSONFields(t *testing.T) {
+	dir := t.TempDir()
+	reviewers := filepath.Join(dir, "reviewers.json")
+	if err := os.WriteFile(reviewers, []byte("[{\"uuid\": \"{a}\"}]\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name   string
+		fields []string
+		want   string
+	}{
+		{name: "string", fields: []string{"title=Bug"}, want: `{"title":"Bug"}`},
+		{name: "string that looks like JSON", fields: []string{"priority=42"}, want: `{"priority":"42"}`},
+		{name: "number", fields: []string{"priority:=42"}, want: `{"priority":42}`},
+		{name: "bool", fields: []string{"is_private:=true"}, want: `{"is_private":true}`},
+		{name: "null", fields: []string{"assignee:=null"}, want: `{"assignee":null}`},
+		{name: "array", fields: []string{"ids:=[1,2]"}, want: `{"ids":[1,2]}`},
+		{name: "object", fields: []string{`content:={"raw":"hi"}`}, want: `{"content":{"raw":"hi"}}`},
+		{name: "file", fields: []string{"reviewers@" + reviewers}, want: `{"reviewers":[{"uuid":"{a}"}]}`},
+		{name: "mixed", fields: []string{"title=a=b", "draft:=false"}, want: `{"draft":false,"title":"a=b"}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			body, contentType, err := buildRequestBody(nil, "", tt.fields, nil)
+			if err != nil {
+				t.Fatalf("buildRequestBody() error: %v", err)
+			}
+			if contentType != "application/json" {
+				t.Errorf("content type = %q", contentType)
+			}
+			data, _ := io.ReadAll(body)
+			var compact bytes.Buffer
+			if err := json.Compact(&compact, data); err != nil {
+				t.Fatalf("body is not JSON: %s", data)
+			}
+			if compact.String() != tt.want {
+				t.Errorf("body = %s, want %s", compact.String(), tt.want)
+			}
+		})
+	}
+}
+
+func TestBuildRequestBody_InvalidJSONFields(t *testing.T) {
+	for _, field := range []string{"novalue", "=x", "n:=nope", "n:=[1,", "f@/does/not/exist.json"} {
+		if _, _, err := buildRequestBody(nil, "", []string{field}, nil); err == nil {
+			t.Errorf("buildRequestBody(%q) expected an error", field)
+		}
+	}
+}
+
+func TestRunAPI_Method(t *testing.T) {
+	var got string
+	client, _ := newTestAPI(t, func(w http.ResponseWriter, r *http.Request) {
+		got = r.Method
+	})
+
+	for _, method := range []string{"options", "HEAD", "PROPFIND"} {
+		opts, _ := newTestOptions("repositories/ws/repo")
+		opts.method = method
+		if err := runAPI(context.Background(), client, opts); err != nil {
+			t.Fatalf("runAPI(%s) error: %v", method, err)
+		}
+		if want := strings.ToUpper(method); got != want {
+			t.Errorf("request method = %q, want %q", got, want)
+		}
+	}
+
+	for _, method := range []string{"", "GET /x", "GÉT"} {
+		opts, _ := newTestOptions("repositories/ws/repo")
+		opts.method = method
+		if err := runAPI(context.Background(), client, opts); err == nil || !strings.Contains(err.Error(), "invalid method") {
+			t.Errorf("runAPI(%q) error = %v, want an invalid method error", method, err)
+		}
+	}
+}
@@ -0,0 +1,206 @@
+package api
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"sync"
+
+	"golang.org/x/sync/errgroup"
+
+	"github.com/rbansal42/bitbucket-cli/internal/api"
+)
+
+// maxJSONLLine bounds the size of a single --input-jsonl request body
+const maxJSONLLine = 10 << 20
+
+// batchLine is one request body read from an --input-jsonl file
+type batchLine struct {
+	number int
+	body   []byte
+	err    error // set when the line is not valid JSON
+}
+
+// batchResult is the outcome of one --input-jsonl request, printed as a
+// line of JSON
+type batchResult struct {
+	Line      int             `json:"line"`
+	Status    int             `json:"status,omitempty"`
+	Body      json.RawMessage `json:"body,omitempty"`
+	Error     string          `json:"error,omitempty"`
+	RequestID string          `json:"request_id,omitempty"` // Set for failed requests
+}
+
+// failed reports whether the request failed or could not be sent
+func (r *batchResult) failed() bool {
+	return r.Error != ""
+}
+
+// readJSONLines reads the request bodies from a JSON Lines stream, skipping
+// blank lines but keeping the line numbers of the file
+func readJSONLines(r io.Reader) ([]batchLine, error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxJSONLLine)
+
+	var lines []batchLine
+	for n := 1; scanner.Scan(); n++ {
+		body := bytes.TrimSpace(scanner.Bytes())
+		if len(body) == 0 {
+			continue
+		}
+		line := batchLine{number: n, body: append([]byte(nil), body...)}
+		if !json.Valid(body) {
+			line.err = fmt.Errorf("invalid JSON")
+		}
+		lines = append(lines, line)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("could not read input: %w", err)
+	}
+	return lines, nil
+}
+
+// runBatch sends one request per line of --input-jsonl and prints each
+// result, in input order, as a line of JSON
+func runBatch(ctx context.Context, client *api.Client, opts *apiOptions, method, path string, query url.Values, headers map[string]string) error {
+	switch method {
+	case http.MethodPost, http.MethodPut, http.MethodPatch:
+	default:
+		return fmt.Errorf("--input-jsonl sends request bodies; use --method POST, PUT or PATCH")
+	}
+	if opts.concurrency < 1 {
+		return fmt.Errorf("--concurrency must be at least 1")
+	}
+
+	var in io.Reader = opts.streams.In
+	if opts.inputJSONL != "-" {
+		f, err := os.Open(opts.inputJSONL)
+		if err != nil {
+			return fmt.Errorf("could not read input file: %w", err)
+		}
+		defer f.Close()
+		in = f
+	}
+	lines, err := readJSONLines(in)
+	if err != nil {
+		return err
+	}
+	if len(lines) == 0 {
+		return fmt.Errorf("no requests in %s", opts.inputJSONL)
+	}
+
+	// With --fail-fast the first failure stops requests from being sent;
+	// those already in flight finish
+	stopCtx, stop := context.WithCancel(ctx)
+	defer stop()
+
+	results := make([]*batchResult, len(lines))
+	var (
+		mu     sync.Mutex
+		next   int // index of the next result to print
+		failed int
+	)
+	// record stores a result and prints every result that is now ready, so
+	// the output follows the input order however the requests finish
+	record := func(i int, res *batchResult) {
+		mu.Lock()
+		defer mu.Unlock()
+		results[i] = res
+		if res.failed() {
+			failed++
+			if opts.failFast {
+				stop()
+			}
+		}
+		for ; next < len(results) && results[next] != nil; next++ {
+			if data, err := json.Marshal(results[next]); err == nil {
+				fmt.Fprintln(opts.streams.Out, string(data))
+			}
+		}
+	}
+
+	var g errgroup.Group
+	g.SetLimit(opts.concurrency)
+	for i, line := range lines {
+		if stopCtx.Err() != nil {
+			break
+		}
+		g.Go(func() error {
+			if stopCtx.Err() != nil {
+				return nil
+			}
+			record(i, sendBatchLine(ctx, client, opts, method, path, query, headers, line))
+			return nil
+		})
+	}
+	_ = g.Wait()
+
+	// Skipped lines leave gaps, so print whatever finished after them
+	skipped := 0
+	for _, res := range results[next:] {
+		if res == nil {
+			skipped++
+			continue
+		}
+		if data, err := json.Marshal(res); err == nil {
+			fmt.Fprintln(opts.streams.Out, string(data))
+		}
+	}
+
+	switch {
+	case skipped > 0 && ctx.Err() != nil:
+		opts.streams.Warning("Interrupted; %d requests were not sent", skipped)
+	case skipped > 0:
+		opts.streams.Warning("Stopped after the first failure; %d requests were not sent", skipped)
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d of %d requests failed", failed, len(lines)-skipped)
+	}
+	return nil
+}
+
+// sendBatchLine sends the request for one --input-jsonl line
+func sendBatchLine(ctx context.Context, client *api.Client, opts *apiOptions, method, path string, query url.Values, headers map[string]string, line batchLine) *batchResult {
+	res := &batchResult{Line: line.number}
+	if line.err != nil {
+		res.Error = line.err.Error()
+		return res
+	}
+
+	reqHeaders := map[string]string{"Content-Type": "application/json"}
+	for k, v := range headers {
+		reqHeaders[k] = v
+	}
+
+	resp, err := client.DoRaw(ctx, &api.Request{
+		Method:  method,
+		Path:    path,
+		Query:   query,
+		Headers: reqHeaders,
+		RawBody: bytes.NewReader(line.body),
+	})
+	if err != nil {
+		res.Error = err.Error()
+		return res
+	}
+
+	res.Status = resp.StatusCode
+	if !opts.silent && len(bytes.TrimSpace(resp.Body)) > 0 {
+		if json.Valid(resp.Body) {
+			res.Body = resp.Body
+		} else {
+			res.Body, _ = json.Marshal(string(resp.Body))
+		}
+	}
+	if err := checkStatus(resp); err != nil {
+		res.Error = err.Error()
+		res.RequestID = api.RequestID(resp.Headers)
+	}
+	return res
+}
@@ -0,0 +1,150 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestReadJSONLines(t *testing.T) {
+	lines, err := readJSONLines(strings.NewReader("{\"a\":1}\n\n  {\"b\":2}  \nnot json\n"))
+	if err != nil {
+		t.Fatalf("readJSONLines() error: %v", err)
+	}
+	if len(lines) != 3 {
+		t.Fatalf("readJSONLines() returned %d lines, want 3", len(lines))
+	}
+	if lines[0].number != 1 || lines[1].number != 3 || lines[2].number != 4 {
+		t.Errorf("line numbers = %d, %d, %d; want 1, 3, 4", lines[0].number, lines[1].number, lines[2].number)
+	}
+	if string(lines[1].body) != `{"b":2}` {
+		t.Errorf("body = %q", lines[1].body)
+	}
+	if lines[0].err != nil || lines[2].err == nil {
+		t.Errorf("errors = %v, %v; want only the last line rejected", lines[0].err, lines[2].err)
+	}
+}
+
+func decodeResults(t *testing.T, out string) []batchResult {
+	t.Helper()
+	var results []batchResult
+	for _, line := range strings.Split(strings.TrimSpace(out), "\n") {
+		var res batchResult
+		if err := json.Unmarshal([]byte(line), &res); err != nil {
+			t.Fatalf("output line %q is not JSON: %v", line, err)
+		}
+		results = append(results, res)
+	}
+	return results
+}
+
+func TestRunAPI_InputJSONL(t *testing.T) {
+	client, _ := newTestAPI(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/2.0/repositories/ws/repo/issues" {
+			t.Errorf("request = %s %s", r.Method, r.URL.Path)
+		}
+		if got := r.Header.Get("Content-Type"); got != "application/json" {
+			t.Errorf("Content-Type = %q", got)
+		}
+		var body struct {
+			Title string `json:"title"`
+		}
+		data, _ := io.ReadAll(r.Body)
+		_ = json.Unmarshal(data, &body)
+		w.Header().Set("Content-Type", "application/json")
+		if body.Title == "bad" {
+			w.WriteHeader(http.StatusBadRequest)
+			fmt.Fprint(w, `{"type": "error"}`)
+			return
+		}
+		// Finish out of order to check the output keeps the input order
+		if body.Title == "first" {
+			time.Sleep(20 * time.Millisecond)
+		}
+		w.WriteHeader(http.StatusCreated)
+		fmt.Fprintf(w, `{"title": %q}`, body.Title)
+	})
+
+	input := filepath.Join(t.TempDir(), "issues.jsonl")
+	content := "{\"title\": \"first\"}\n{\"title\": \"bad\"}\n\n{oops\n{\"title\": \"last\"}\n"
+	if err := os.WriteFile(input, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	opts, out := newTestOptions("repositories/ws/repo/issues")
+	opts.method = "POST"
+	opts.inputJSONL = input
+	opts.concurrency = 3
+	err := runAPI(context.Background(), client, opts)
+	if err == nil || !strings.Contains(err.Error(), "2 of 4 requests failed") {
+		t.Errorf("runAPI() error = %v, want 2 of 4 failed", err)
+	}
+
+	results := decodeResults(t, out.String())
+	if len(results) != 4 {
+		t.Fatalf("got %d results, want 4:\n%s", len(results), out)
+	}
+	want := []struct {
+		line   int
+		status int
+		failed bool
+	}{{1, 201, false}, {2, 400, true}, {4, 0, true}, {5, 201, false}}
+	for i, w := range want {
+		r := results[i]
+		if r.Line != w.line || r.Status != w.status || r.failed() != w.failed {
+			t.Errorf("result %d = %+v, want line %d status %d failed %v", i, r, w.line, w.status, w.failed)
+		}
+	}
+	if string(results[0].Body) != `{"title":"first"}` {
+		t.Errorf("first body = %s", results[0].Body)
+	}
+}
+
+func TestRunAPI_InputJSONLFailFast(t *testing.T) {
+	var requests atomic.Int32
+	client, _ := newTestAPI(t, func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		w.WriteHeader(http.StatusInternalServerError)
+	})
+
+	opts, out := newTestOptions("repositories/ws/repo/issues")
+	opts.method = "POST"
+	opts.inputJSONL = "-"
+	opts.streams.In = strings.NewReader("{}\n{}\n{}\n{}\n")
+	opts.concurrency = 1
+	opts.failFast = true
+	if err := runAPI(context.Background(), client, opts); err == nil {
+		t.Fatal("runAPI() expected an error")
+	}
+	if n := requests.Load(); n != 1 {
+		t.Errorf("sent %d requests, want 1", n)
+	}
+	if results := decodeResults(t, out.String()); len(results) != 1 {
+		t.Errorf("got %d results, want 1", len(results))
+	}
+	if errOut := opts.streams.ErrOut.(*bytes.Buffer).String(); !strings.Contains(errOut, "3 requests were not sent") {
+		t.Errorf("stderr = %q", errOut)
+	}
+}
+
+func TestRunAPI_InputJSONLRequiresBodyMethod(t *testing.T) {
+	client, _ := newTestAPI(t, func(w http.ResponseWriter, r *http.Request) {
+		t.Error("no request expected")
+	})
+
+	opts, _ := newTestOptions("user")
+	opts.inputJSONL = "-"
+	opts.concurrency = 1
+	if err := runAPI(context.Background(), client, opts); err == nil || !strings.Contains(err.Error(), "--method") {
+		t.Errorf("runAPI() error = %v, want a --method error", err)
+	}
+}
@@ -25,6 +25,7 @@ setting the BB_TOKEN environment variable or using --with-token.`,
 	cmd.AddCommand(NewCmdLogout(streams))
 	cmd.AddCommand(NewCmdStatus(streams))
 	cmd.AddCommand(NewCmdToken(streams))
+	cmd.AddCommand(NewCmdScopes(streams))
 
 	return cmd
 }
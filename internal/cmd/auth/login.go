@@ -18,6 +18,7 @@ import (
 
 	"github.com/rbansal42/bitbucket-cli/internal/api"
 	"github.com/rbansal42/bitbucket-cli/internal/browser"
+	"github.com/rbansal42/bitbucket-cli/internal/cmdutil"
 	"github.com/rbansal42/bitbucket-cli/internal/config"
 	"github.com/rbansal42/bitbucket-cli/internal/iostreams"
 )
@@ -32,6 +33,9 @@ const (
 
 	// Callback path for OAuth redirect
 	callbackPath = "/callback"
+
+	// loginHint is shown when login needs input but cannot prompt for it
+	loginHint = "use --with-token to read a token from stdin"
 )
 
 type loginOptions struct {
@@ -73,23 +77,30 @@ Alternatively, use --with-token to read a token directly from stdin.`,
 
 	cmd.Flags().BoolVar(&opts.withToken, "with-token", false, "Read token from stdin")
 	cmd.Flags().StringVar(&opts.hostname, "hostname", config.DefaultHost, "Bitbucket hostname")
-	cmd.Flags().StringVar(&opts.scopes, "scopes", defaultScopes, "OAuth scopes to request")
+	cmd.Flags().StringVar(&opts.scopes, "scopes", defaultScopes, "OAuth scopes your consumer should grant, separated by spaces or commas; checked, not requested (see 'bb auth scopes')")
 
 	return cmd
 }
 
 func runLogin(opts *loginOptions) error {
-	// If --with-token flag is set, read token from stdin
+	// If --with-token flag is set, read token from stdin. A token's scopes
+	// were fixed when it was created, so --scopes doesn't apply.
 	if opts.withToken {
 		return loginWithTokenFromStdin(opts)
 	}
 
+	if err := validateScopes(opts.scopes); err != nil {
+		return cmdutil.NewFlagError(err)
+	}
+
 	// Interactive flow
 	return interactiveLogin(opts)
 }
 
 func interactiveLogin(opts *loginOptions) error {
-	reader := bufio.NewReader(os.Stdin)
+	if !opts.streams.CanPrompt() {
+		return &cmdutil.NoPromptError{Hint: loginHint}
+	}
 
 	fmt.Fprintln(opts.streams.Out, "")
 	fmt.Fprintln(opts.streams.Out, "Welcome to bb CLI! Let's get you authenticated with Bitbucket.")
@@ -99,20 +110,20 @@ func interactiveLogin(opts *loginOptions) error {
 	fmt.Fprintln(opts.streams.Out, "  [1] API Token (simple, good for CI/CD)")
 	fmt.Fprintln(opts.streams.Out, "  [2] OAuth (more secure, supports token refresh)")
 	fmt.Fprintln(opts.streams.Out, "")
-	fmt.Fprint(opts.streams.Out, "Enter choice [1/2]: ")
-
-	choice, err := reader.ReadString('\n')
+	choice, err := cmdutil.Prompt(opts.streams, "Enter choice [1/2]: ", loginHint)
 	if err != nil {
 		return fmt.Errorf("failed to read input: %w", err)
 	}
-	choice = strings.TrimSpace(choice)
 
 	var loginErr error
 	switch choice {
 	case "1":
-		loginErr = interactiveAPITokenLogin(opts, reader)
+		loginErr = interactiveAPITokenLogin(opts)
 	case "2":
-		loginErr = interactiveOAuthLogin(opts, reader)
+		if opts.hostname != config.DefaultHost {
+			return fmt.Errorf("OAuth login is only available for %s; use an API token", config.DefaultHost)
+		}
+		loginErr = interactiveOAuthLogin(opts)
 	default:
 		return fmt.Errorf("invalid choice: %s (enter 1 or 2)", choice)
 	}
@@ -121,11 +132,18 @@ func interactiveLogin(opts *loginOptions) error {
 		return loginErr
 	}
 
-	// After successful login, ask about default workspace
-	return promptForDefaultWorkspace(opts, reader)
+	// After successful login, help the user get started. None of this can
+	// fail the login.
+	workspace := promptForDefaultWorkspace(opts)
+	var repo string
+	if workspace != "" {
+		repo = promptForDefaultRepo(opts, workspace)
+	}
+	printNextSteps(opts, workspace, repo)
+	return nil
 }
 
-func interactiveAPITokenLogin(opts *loginOptions, reader *bufio.Reader) error {
+func interactiveAPITokenLogin(opts *loginOptions) error {
 	const apiTokenURL = "https://id.atlassian.com/manage-profile/security/api-tokens"
 
 	fmt.Fprintln(opts.streams.Out, "")
@@ -138,10 +156,8 @@ func interactiveAPITokenLogin(opts *loginOptions, reader *bufio.Reader) error {
 	fmt.Fprintln(opts.streams.Out, "  3. Enter a label (e.g., 'bb-cli')")
 	fmt.Fprintln(opts.streams.Out, "  4. Click 'Create' and copy the token")
 	fmt.Fprintln(opts.streams.Out, "")
-	fmt.Fprint(opts.streams.Out, "Press Enter to open browser (or 'n' to skip): ")
-
-	skipBrowser, _ := reader.ReadString('\n')
-	skipBrowser = strings.TrimSpace(strings.ToLower(skipBrowser))
+	skipBrowser, _ := cmdutil.Prompt(opts.streams, "Press Enter to open browser (or 'n' to skip): ", loginHint)
+	skipBrowser = strings.ToLower(skipBrowser)
 
 	if skipBrowser != "n" && skipBrowser != "no" {
 		if err := browser.Open(apiTokenURL); err != nil {
@@ -152,13 +168,10 @@ func interactiveAPITokenLogin(opts *loginOptions, reader *bufio.Reader) error {
 
 	// Get email for Basic Auth
 	fmt.Fprintln(opts.streams.Out, "")
-	fmt.Fprint(opts.streams.Out, "Enter your Atlassian account email: ")
-
-	email, err := reader.ReadString('\n')
+	email, err := cmdutil.Prompt(opts.streams, "Enter your Atlassian account email: ", loginHint)
 	if err != nil {
 		return fmt.Errorf("failed to read email: %w", err)
 	}
-	email = strings.TrimSpace(email)
 
 	if email == "" {
 		return fmt.Errorf("email cannot be empty")
@@ -167,13 +180,10 @@ func interactiveAPITokenLogin(opts *loginOptions, reader *bufio.Reader) error {
 	// Token entry loop with retry on invalid token
 	for {
 		fmt.Fprintln(opts.streams.Out, "")
-		fmt.Fprint(opts.streams.Out, "Paste your API token: ")
-
-		token, err := reader.ReadString('\n')
+		token, err := cmdutil.Prompt(opts.streams, "Paste your API token: ", loginHint)
 		if err != nil {
 			return fmt.Errorf("failed to read token: %w", err)
 		}
-		token = strings.TrimSpace(token)
 
 		if token == "" {
 			return fmt.Errorf("token cannot be empty")
@@ -194,10 +204,8 @@ func interactiveAPITokenLogin(opts *loginOptions, reader *bufio.Reader) error {
 		fmt.Fprintln(opts.streams.Out, "  - The API token was copied correctly (no extra spaces)")
 		fmt.Fprintln(opts.streams.Out, "  - The API token has not been revoked")
 		fmt.Fprintln(opts.streams.Out, "")
-		fmt.Fprint(opts.streams.Out, "Try again? [Y/n]: ")
-
-		retry, _ := reader.ReadString('\n')
-		retry = strings.TrimSpace(strings.ToLower(retry))
+		retry, _ := cmdutil.Prompt(opts.streams, "Try again? [Y/n]: ", loginHint)
+		retry = strings.ToLower(retry)
 
 		if retry == "n" || retry == "no" {
 			return fmt.Errorf("authentication cancelled")
@@ -206,7 +214,7 @@ func interactiveAPITokenLogin(opts *loginOptions, reader *bufio.Reader) error {
 	}
 }
 
-func interactiveOAuthLogin(opts *loginOptions, reader *bufio.Reader) error {
+func interactiveOAuthLogin(opts *loginOptions) error {
 	// Check if OAuth credentials are already configured
 	clientID := os.Getenv("BB_OAUTH_CLIENT_ID")
 	clientSecret := os.Getenv("BB_OAUTH_CLIENT_SECRET")
@@ -224,13 +232,10 @@ func interactiveOAuthLogin(opts *loginOptions, reader *bufio.Reader) error {
 	fmt.Fprintln(opts.streams.Out, "")
 	fmt.Fprintln(opts.streams.Out, "OAuth requires a one-time setup of an OAuth consumer in Bitbucket.")
 	fmt.Fprintln(opts.streams.Out, "")
-	fmt.Fprint(opts.streams.Out, "Enter your workspace name (e.g., 'myteam'): ")
-
-	workspace, err := reader.ReadString('\n')
+	workspace, err := cmdutil.Prompt(opts.streams, "Enter your workspace name (e.g., 'myteam'): ", loginHint)
 	if err != nil {
 		return fmt.Errorf("failed to read input: %w", err)
 	}
-	workspace = strings.TrimSpace(workspace)
 
 	if workspace == "" {
 		return fmt.Errorf("workspace name is required")
@@ -252,10 +257,8 @@ func interactiveOAuthLogin(opts *loginOptions, reader *bufio.Reader) error {
 	fmt.Fprintln(opts.streams.Out, "  5. Click 'Save'")
 	fmt.Fprintln(opts.streams.Out, "  6. Copy the 'Key' and 'Secret'")
 	fmt.Fprintln(opts.streams.Out, "")
-	fmt.Fprint(opts.streams.Out, "Press Enter to open browser (or 'n' to skip): ")
-
-	skipBrowser, _ := reader.ReadString('\n')
-	skipBrowser = strings.TrimSpace(strings.ToLower(skipBrowser))
+	skipBrowser, _ := cmdutil.Prompt(opts.streams, "Press Enter to open browser (or 'n' to skip): ", loginHint)
+	skipBrowser = strings.ToLower(skipBrowser)
 
 	if skipBrowser != "n" && skipBrowser != "no" {
 		if err := browser.Open(oauthURL); err != nil {
@@ -265,23 +268,19 @@ func interactiveOAuthLogin(opts *loginOptions, reader *bufio.Reader) error {
 	}
 
 	fmt.Fprintln(opts.streams.Out, "")
-	fmt.Fprint(opts.streams.Out, "Paste your OAuth Key (Client ID): ")
-	clientID, err = reader.ReadString('\n')
+	clientID, err = cmdutil.Prompt(opts.streams, "Paste your OAuth Key (Client ID): ", loginHint)
 	if err != nil {
 		return fmt.Errorf("failed to read client ID: %w", err)
 	}
-	clientID = strings.TrimSpace(clientID)
 
 	if clientID == "" {
 		return fmt.Errorf("client ID cannot be empty")
 	}
 
-	fmt.Fprint(opts.streams.Out, "Paste your OAuth Secret (Client Secret): ")
-	clientSecret, err = reader.ReadString('\n')
+	clientSecret, err = cmdutil.Prompt(opts.streams, "Paste your OAuth Secret (Client Secret): ", loginHint)
 	if err != nil {
 		return fmt.Errorf("failed to read client secret: %w", err)
 	}
-	clientSecret = strings.TrimSpace(clientSecret)
 
 	if clientSecret == "" {
 		return fmt.Errorf("client secret cannot be empty")
@@ -317,8 +316,13 @@ func loginWithTokenFromStdin(opts *loginOptions) error {
 func validateAndSaveToken(opts *loginOptions, token string) error {
 	opts.streams.Info("Validating token...")
 
+	hosts, err := loginHosts(opts.hostname)
+	if err != nil {
+		return err
+	}
+
 	// Validate token by making an API request (Bearer token)
-	client := api.NewClient(api.WithToken(token))
+	client := loginClient(hosts, opts.hostname, api.WithToken(token))
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
@@ -333,11 +337,6 @@ func validateAndSaveToken(opts *loginOptions, token string) error {
 	}
 
 	// Update hosts config
-	hosts, err := config.LoadHostsConfig()
-	if err != nil {
-		return fmt.Errorf("failed to load hosts config: %w", err)
-	}
-
 	hosts.SetActiveUser(opts.hostname, user.Username)
 
 	if err := config.SaveHostsConfig(hosts); err != nil {
@@ -351,8 +350,13 @@ func validateAndSaveToken(opts *loginOptions, token string) error {
 func validateAndSaveAPIToken(opts *loginOptions, email, apiToken string) error {
 	opts.streams.Info("Validating credentials...")
 
+	hosts, err := loginHosts(opts.hostname)
+	if err != nil {
+		return err
+	}
+
 	// Validate using Basic Auth (email:api_token)
-	client := api.NewClient(api.WithBasicAuth(email, apiToken))
+	client := loginClient(hosts, opts.hostname, api.WithBasicAuth(email, apiToken))
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
@@ -368,11 +372,6 @@ func validateAndSaveAPIToken(opts *loginOptions, email, apiToken string) error {
 	}
 
 	// Update hosts config
-	hosts, err := config.LoadHostsConfig()
-	if err != nil {
-		return fmt.Errorf("failed to load hosts config: %w", err)
-	}
-
 	hosts.SetActiveUser(opts.hostname, user.Username)
 
 	if err := config.SaveHostsConfig(hosts); err != nil {
@@ -383,27 +382,27 @@ func validateAndSaveAPIToken(opts *loginOptions, email, apiToken string) error {
 	return nil
 }
 
-func promptForDefaultWorkspace(opts *loginOptions, reader *bufio.Reader) error {
+// promptForDefaultWorkspace offers to set a default workspace and returns
+// the default workspace afterwards, or "" if there is none
+func promptForDefaultWorkspace(opts *loginOptions) string {
 	// Check current default workspace
 	currentDefault, _ := config.GetDefaultWorkspace()
 	if currentDefault != "" {
 		fmt.Fprintln(opts.streams.Out, "")
 		opts.streams.Info("Current default workspace: %s", currentDefault)
-		return nil
+		return currentDefault
 	}
 
 	fmt.Fprintln(opts.streams.Out, "")
-	fmt.Fprint(opts.streams.Out, "Would you like to set a default workspace? [y/N]: ")
-
-	answer, err := reader.ReadString('\n')
+	answer, err := cmdutil.Prompt(opts.streams, "Would you like to set a default workspace? [y/N]: ", loginHint)
 	if err != nil {
-		return nil // Don't fail login if this fails
+		return "" // Don't fail login if this fails
 	}
-	answer = strings.TrimSpace(strings.ToLower(answer))
+	answer = strings.ToLower(answer)
 
 	if answer != "y" && answer != "yes" {
 		fmt.Fprintln(opts.streams.Out, "You can set a default workspace later with: bb workspace set-default <workspace>")
-		return nil
+		return ""
 	}
 
 	// List available workspaces
@@ -414,7 +413,7 @@ func promptForDefaultWorkspace(opts *loginOptions, reader *bufio.Reader) error {
 	if err != nil {
 		opts.streams.Warning("Could not fetch workspaces: %v", err)
 		fmt.Fprintln(opts.streams.Out, "You can set a default workspace later with: bb workspace set-default <workspace>")
-		return nil
+		return ""
 	}
 
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
@@ -424,13 +423,13 @@ func promptForDefaultWorkspace(opts *loginOptions, reader *bufio.Reader) error {
 	if err != nil {
 		opts.streams.Warning("Could not fetch workspaces: %v", err)
 		fmt.Fprintln(opts.streams.Out, "You can set a default workspace later with: bb workspace set-default <workspace>")
-		return nil
+		return ""
 	}
 
 	workspaces := result.Values
 	if len(workspaces) == 0 {
 		opts.streams.Info("No workspaces found")
-		return nil
+		return ""
 	}
 
 	fmt.Fprintln(opts.streams.Out, "")
@@ -439,33 +438,30 @@ func promptForDefaultWorkspace(opts *loginOptions, reader *bufio.Reader) error {
 		fmt.Fprintf(opts.streams.Out, "  [%d] %s (%s)\n", i+1, membership.Workspace.Name, membership.Workspace.Slug)
 	}
 	fmt.Fprintln(opts.streams.Out, "")
-	fmt.Fprint(opts.streams.Out, "Enter number to select (or press Enter to skip): ")
-
-	selection, err := reader.ReadString('\n')
+	selection, err := cmdutil.Prompt(opts.streams, "Enter number to select (or press Enter to skip): ", loginHint)
 	if err != nil {
-		return nil
+		return ""
 	}
-	selection = strings.TrimSpace(selection)
 
 	if selection == "" {
 		fmt.Fprintln(opts.streams.Out, "You can set a default workspace later with: bb workspace set-default <workspace>")
-		return nil
+		return ""
 	}
 
 	var idx int
 	if _, err := fmt.Sscanf(selection, "%d", &idx); err != nil || idx < 1 || idx > len(workspaces) {
 		opts.streams.Warning("Invalid selection")
-		return nil
+		return ""
 	}
 
 	selectedWorkspace := workspaces[idx-1].Workspace.Slug
 	if err := config.SetDefaultWorkspace(selectedWorkspace); err != nil {
 		opts.streams.Warning("Failed to set default workspace: %v", err)
-		return nil
+		return ""
 	}
 
 	opts.streams.Success("Default workspace set to: %s", selectedWorkspace)
-	return nil
+	return selectedWorkspace
 }
 
 func getAuthenticatedClient(hostname string) (*api.Client, error) {
@@ -491,16 +487,35 @@ func getAuthenticatedClient(hostname string) (*api.Client, error) {
 		if len(parts) != 2 {
 			return nil, fmt.Errorf("invalid credentials format")
 		}
-		return api.NewClient(api.WithBasicAuth(parts[0], parts[1])), nil
+		return loginClient(hosts, hostname, api.WithBasicAuth(parts[0], parts[1])), nil
 	}
 
 	// Try to parse as JSON (OAuth token)
 	var tokenResp oauthTokenResponse
 	if err := json.Unmarshal([]byte(tokenData), &tokenResp); err == nil && tokenResp.AccessToken != "" {
-		return api.NewClient(api.WithToken(tokenResp.AccessToken)), nil
+		return loginClient(hosts, hostname, api.WithToken(tokenResp.AccessToken)), nil
 	}
 
-	return api.NewClient(api.WithToken(tokenData)), nil
+	return loginClient(hosts, hostname, api.WithToken(tokenData)), nil
+}
+
+// loginHosts loads hosts.yml for logging in to hostname. A host not yet in
+// it is taken to be a Bitbucket Server, since only bitbucket.org runs Cloud.
+func loginHosts(hostname string) (config.HostsConfig, error) {
+	hosts, err := config.LoadHostsConfig()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load hosts config: %w", err)
+	}
+	if hostname != config.DefaultHost && hosts[hostname] == nil {
+		hosts[hostname] = &config.HostConfig{APIVersion: api.APIVersionServer}
+	}
+	return hosts, nil
+}
+
+// loginClient creates a client for hostname with the host's API and TLS
+// settings, so credentials only go to the host they are for
+func loginClient(hosts config.HostsConfig, hostname string, auth api.ClientOption) *api.Client {
+	return api.NewClient(append(cmdutil.HostOptions(hosts, hostname), auth)...)
 }
 
 func performOAuthFlow(opts *loginOptions, clientID, clientSecret string) error {
@@ -625,8 +640,13 @@ func performOAuthFlow(opts *loginOptions, clientID, clientSecret string) error {
 		return fmt.Errorf("failed to exchange code for token: %w", err)
 	}
 
+	hosts, err := loginHosts(opts.hostname)
+	if err != nil {
+		return err
+	}
+
 	// Validate token and get user info
-	client := api.NewClient(api.WithToken(tokenResp.AccessToken))
+	client := loginClient(hosts, opts.hostname, api.WithToken(tokenResp.AccessToken))
 	ctx, cancel = context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
@@ -635,7 +655,9 @@ func performOAuthFlow(opts *loginOptions, clientID, clientSecret string) error {
 		return fmt.Errorf("failed to get user info: %w", err)
 	}
 
-	// Store tokens in keyring (as JSON with refresh token)
+	// Store tokens in keyring (as JSON with refresh token), along with the
+	// consumer that can refresh them
+	tokenResp.ClientID, tokenResp.ClientSecret = clientID, clientSecret
 	tokenData, err := json.Marshal(tokenResp)
 	if err != nil {
 		return fmt.Errorf("failed to marshal token: %w", err)
@@ -646,11 +668,6 @@ func performOAuthFlow(opts *loginOptions, clientID, clientSecret string) error {
 	}
 
 	// Update hosts config
-	hosts, err := config.LoadHostsConfig()
-	if err != nil {
-		return fmt.Errorf("failed to load hosts config: %w", err)
-	}
-
 	hosts.SetActiveUser(opts.hostname, user.Username)
 
 	if err := config.SaveHostsConfig(hosts); err != nil {
@@ -667,6 +684,10 @@ type oauthTokenResponse struct {
 	ExpiresIn    int    `json:"expires_in"`
 	RefreshToken string `json:"refresh_token"`
 	Scopes       string `json:"scopes"`
+
+	// Not part of Bitbucket's response; stored so the token can be refreshed
+	ClientID     string `json:"client_id,omitempty"`
+	ClientSecret string `json:"client_secret,omitempty"`
 }
 
 func exchangeCodeForToken(clientID, clientSecret, code, redirectURI string) (*oauthTokenResponse, error) {
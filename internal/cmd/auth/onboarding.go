@@ -0,0 +1,97 @@
+package auth
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/rbansal42/bitbucket-cli/internal/api"
+	"github.com/rbansal42/bitbucket-cli/internal/cmdutil"
+	"github.com/rbansal42/bitbucket-cli/internal/git"
+)
+
+// promptForDefaultRepo offers to make the only repository in workspace the
+// default for the current directory, stored in .bb.yml as 'bb repo
+// set-default' does outside a git repository. It returns the default
+// repository afterwards, or "" if there is none. It offers nothing inside a
+// git repository, where the repository comes from the remote, or when there
+// is no single obvious candidate.
+func promptForDefaultRepo(opts *loginOptions, workspace string) string {
+	if git.IsGitRepository() {
+		return ""
+	}
+	if repo, _, _ := cmdutil.LocalConfigValue(func(c *cmdutil.LocalConfig) string { return c.DefaultRepo }); repo != "" {
+		return repo
+	}
+
+	client, err := getAuthenticatedClient(opts.hostname)
+	if err != nil {
+		return ""
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	result, err := client.ListRepositories(ctx, workspace, &api.RepositoryListOptions{Limit: 2})
+	if err != nil || len(result.Values) != 1 || result.Next != "" {
+		return ""
+	}
+	repo := result.Values[0].FullName
+
+	fmt.Fprintln(opts.streams.Out, "")
+	answer, err := cmdutil.Prompt(opts.streams, fmt.Sprintf("%s is your only repository. Use it by default in this directory? [y/N]: ", repo), loginHint)
+	if err != nil {
+		return ""
+	}
+	if answer = strings.ToLower(answer); answer != "y" && answer != "yes" {
+		return ""
+	}
+
+	if err := cmdutil.UpdateLocalConfig(func(c *cmdutil.LocalConfig) { c.DefaultRepo = repo }); err != nil {
+		opts.streams.Warning("Failed to set default repository: %v", err)
+		return ""
+	}
+	opts.streams.Success("Default repository set to: %s (in %s)", repo, cmdutil.LocalConfigFile)
+	return repo
+}
+
+// nextStep is a command suggested after login
+type nextStep struct {
+	command     string
+	description string
+}
+
+// printNextSteps suggests a few commands to try, using the default
+// workspace and repository when they are set
+func printNextSteps(opts *loginOptions, workspace, repo string) {
+	var steps []nextStep
+	if workspace == "" {
+		steps = append(steps,
+			nextStep{"bb workspace list", "List the workspaces you belong to"},
+			nextStep{"bb repo list -w WORKSPACE", "List the repositories in a workspace"},
+		)
+	} else {
+		steps = append(steps, nextStep{"bb repo list", "List the repositories in " + workspace})
+	}
+
+	if repo != "" {
+		steps = append(steps,
+			nextStep{"bb pr list --repo " + repo, "List open pull requests in " + repo},
+			nextStep{"bb issue list --repo " + repo, "List issues in " + repo},
+		)
+	} else {
+		steps = append(steps, nextStep{"bb pr list", "List open pull requests, from inside a clone"})
+	}
+	steps = append(steps, nextStep{"bb --help", "See every command"})
+
+	fmt.Fprintln(opts.streams.Out, "")
+	width := 0
+	for _, step := range steps {
+		width = max(width, len(step.command))
+	}
+	fmt.Fprintln(opts.streams.Out, "Try these commands to get started:")
+	for _, step := range steps {
+		fmt.Fprintf(opts.streams.Out, "  %-*s  %s\n", width, step.command, step.description)
+	}
+}
@@ -0,0 +1,30 @@
+package auth
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/rbansal42/bitbucket-cli/internal/iostreams"
+)
+
+func TestPrintNextSteps(t *testing.T) {
+	out := &bytes.Buffer{}
+	opts := &loginOptions{streams: &iostreams.IOStreams{Out: out, ErrOut: &bytes.Buffer{}}}
+
+	printNextSteps(opts, "", "")
+	if !strings.Contains(out.String(), "bb workspace list") || !strings.Contains(out.String(), "bb repo list -w WORKSPACE") {
+		t.Errorf("without a workspace, expected workspace suggestions, got:\n%s", out.String())
+	}
+
+	out.Reset()
+	printNextSteps(opts, "acme", "acme/api")
+	for _, want := range []string{"List the repositories in acme", "bb pr list --repo acme/api", "List open pull requests in acme/api", "List issues in acme/api"} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("expected %q in:\n%s", want, out.String())
+		}
+	}
+	if strings.Contains(out.String(), "WORKSPACE") {
+		t.Errorf("expected no placeholder workspace, got:\n%s", out.String())
+	}
+}
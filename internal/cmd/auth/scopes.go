@@ -0,0 +1,123 @@
+package auth
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"unicode"
+
+	"github.com/spf13/cobra"
+
+	"github.com/rbansal42/bitbucket-cli/internal/cmdutil"
+	"github.com/rbansal42/bitbucket-cli/internal/iostreams"
+)
+
+// oauthScope is a Bitbucket Cloud OAuth scope and what it grants
+type oauthScope struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Default     bool   `json:"default"`
+}
+
+// knownScopes lists the OAuth scopes Bitbucket Cloud accepts. Write scopes
+// imply their read scope, and admin scopes imply write.
+var knownScopes = []oauthScope{
+	{Name: "account", Description: "Read your account information, email addresses and workspace memberships"},
+	{Name: "account:write", Description: "Change your account settings"},
+	{Name: "email", Description: "Read your primary email address"},
+	{Name: "repository", Description: "Read repositories, their source and their commits"},
+	{Name: "repository:write", Description: "Push to repositories and manage branches and tags"},
+	{Name: "repository:admin", Description: "Manage repository settings, permissions and branch restrictions"},
+	{Name: "repository:delete", Description: "Delete repositories"},
+	{Name: "pullrequest", Description: "Read pull requests and their comments"},
+	{Name: "pullrequest:write", Description: "Create, comment on, approve and merge pull requests"},
+	{Name: "issue", Description: "Read issues and their comments"},
+	{Name: "issue:write", Description: "Create, edit and comment on issues"},
+	{Name: "wiki", Description: "Read and write repository wikis"},
+	{Name: "snippet", Description: "Read snippets"},
+	{Name: "snippet:write", Description: "Create, edit and delete snippets"},
+	{Name: "webhook", Description: "Read and manage webhooks"},
+	{Name: "pipeline", Description: "Read pipelines, steps and logs"},
+	{Name: "pipeline:write", Description: "Run and stop pipelines"},
+	{Name: "pipeline:variable", Description: "Manage pipeline variables"},
+	{Name: "runner", Description: "Read pipeline runners"},
+	{Name: "runner:write", Description: "Register and manage pipeline runners"},
+	{Name: "project", Description: "Read projects"},
+	{Name: "project:admin", Description: "Create, edit and delete projects and manage their permissions"},
+}
+
+func init() {
+	defaults := strings.Fields(defaultScopes)
+	for i := range knownScopes {
+		knownScopes[i].Default = slices.Contains(defaults, knownScopes[i].Name)
+	}
+}
+
+// splitScopes splits a --scopes value on spaces and commas
+func splitScopes(scopes string) []string {
+	return strings.FieldsFunc(scopes, func(r rune) bool {
+		return r == ',' || unicode.IsSpace(r)
+	})
+}
+
+// validateScopes checks that every scope is one Bitbucket knows, so a typo
+// in --scopes is reported before the browser is opened
+func validateScopes(scopes string) error {
+	fields := splitScopes(scopes)
+	if len(fields) == 0 {
+		return fmt.Errorf("at least one scope is required")
+	}
+
+	var unknown []string
+	for _, s := range fields {
+		if !slices.ContainsFunc(knownScopes, func(k oauthScope) bool { return k.Name == s }) {
+			unknown = append(unknown, s)
+		}
+	}
+	if len(unknown) > 0 {
+		return fmt.Errorf("unknown scope %s; run 'bb auth scopes' to list the available scopes", strings.Join(unknown, ", "))
+	}
+	return nil
+}
+
+// NewCmdScopes creates the scopes command
+func NewCmdScopes(streams *iostreams.IOStreams) *cobra.Command {
+	var jsonOutput bool
+
+	cmd := &cobra.Command{
+		Use:   "scopes",
+		Short: "List the OAuth scopes that can be requested",
+		Long: `List the OAuth scopes accepted by 'bb auth login --scopes' and what each
+one grants. Scopes marked as default are the ones bb uses.
+
+Bitbucket Cloud doesn't let an OAuth login request scopes: a token gets the
+permissions set on the OAuth consumer. Grant your consumer these scopes in
+its settings under Workspace settings > OAuth consumers.`,
+		Example: `  # List the available scopes
+  bb auth scopes
+
+  # List them as JSON
+  bb auth scopes --json`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if jsonOutput {
+				return cmdutil.PrintJSON(streams, knownScopes)
+			}
+
+			t := cmdutil.NewTableWriter(streams, "SCOPE", "DEFAULT", "GRANTS")
+			t.SetFlexColumn(2)
+			for _, s := range knownScopes {
+				def := ""
+				if s.Default {
+					def = "yes"
+				}
+				t.AddRow(s.Name, def, s.Description)
+			}
+			return t.Render()
+		},
+	}
+
+	cmd.Flags().BoolVar(&jsonOutput, "json", false, "Output in JSON format")
+
+	return cmd
+}
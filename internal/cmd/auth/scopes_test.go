@@ -0,0 +1,39 @@
+package auth
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestValidateScopes(t *testing.T) {
+	if err := validateScopes(defaultScopes); err != nil {
+		t.Errorf("validateScopes(defaultScopes) error: %v", err)
+	}
+	if err := validateScopes("  repository,pullrequest:write  issue "); err != nil {
+		t.Errorf("validateScopes() with extra spaces error: %v", err)
+	}
+
+	err := validateScopes("repository pullrequests issue:wrte")
+	if err == nil {
+		t.Fatal("validateScopes() expected an error for unknown scopes")
+	}
+	if !strings.Contains(err.Error(), "pullrequests, issue:wrte") {
+		t.Errorf("error = %q, want both unknown scopes named", err)
+	}
+
+	if err := validateScopes(" "); err == nil {
+		t.Error("validateScopes() expected an error for no scopes")
+	}
+}
+
+func TestKnownScopesMarkDefaults(t *testing.T) {
+	defaults := 0
+	for _, s := range knownScopes {
+		if s.Default {
+			defaults++
+		}
+	}
+	if want := len(strings.Fields(defaultScopes)); defaults != want {
+		t.Errorf("%d scopes marked as default, want %d", defaults, want)
+	}
+}
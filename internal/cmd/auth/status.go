@@ -10,13 +10,15 @@ import (
 	"github.com/spf13/cobra"
 
 	"github.com/rbansal42/bitbucket-cli/internal/api"
+	"github.com/rbansal42/bitbucket-cli/internal/cmdutil"
 	"github.com/rbansal42/bitbucket-cli/internal/config"
 	"github.com/rbansal42/bitbucket-cli/internal/iostreams"
 )
 
 type statusOptions struct {
-	streams  *iostreams.IOStreams
-	hostname string
+	streams   *iostreams.IOStreams
+	hostname  string
+	rateLimit bool
 }
 
 // NewCmdStatus creates the status command
@@ -31,15 +33,24 @@ func NewCmdStatus(streams *iostreams.IOStreams) *cobra.Command {
 		Long: `View authentication status for Bitbucket.
 
 This command displays information about your current authentication state,
-including the logged-in user and token status.`,
+including the logged-in user and token status. Without --hostname, the host
+other commands talk to is checked, as chosen by --host or BB_HOST.
+
+Use --rate-limit to also show how many API requests remain before Bitbucket
+starts throttling, and when the quota resets. Check it before a large batch
+of commands.`,
 		Example: `  # Check authentication status
-  $ bb auth status`,
+  $ bb auth status
+
+  # Check the remaining API quota
+  $ bb auth status --rate-limit`,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			return runStatus(opts)
 		},
 	}
 
-	cmd.Flags().StringVar(&opts.hostname, "hostname", config.DefaultHost, "Bitbucket hostname")
+	cmd.Flags().StringVar(&opts.hostname, "hostname", "", "Bitbucket hostname (default: the host other commands use)")
+	cmd.Flags().BoolVar(&opts.rateLimit, "rate-limit", false, "Show the remaining API request quota")
 
 	return cmd
 }
@@ -50,6 +61,10 @@ func runStatus(opts *statusOptions) error {
 		return fmt.Errorf("failed to load hosts config: %w", err)
 	}
 
+	if opts.hostname == "" {
+		opts.hostname = cmdutil.Host(hosts)
+	}
+
 	user := hosts.GetActiveUser(opts.hostname)
 	if user == "" {
 		opts.streams.Info("%s", opts.hostname)
@@ -79,7 +94,7 @@ func runStatus(opts *statusOptions) error {
 			opts.streams.Error("Invalid stored credentials format for %s", user)
 			return nil
 		}
-		client = api.NewClient(api.WithBasicAuth(parts[0], parts[1]))
+		client = api.NewClient(append(cmdutil.HostOptions(hosts, opts.hostname), api.WithBasicAuth(parts[0], parts[1]))...)
 		displayToken = parts[1] // Show API token portion
 	} else {
 		// Try to parse as JSON (OAuth token) or use as plain token
@@ -89,7 +104,7 @@ func runStatus(opts *statusOptions) error {
 		} else {
 			displayToken = tokenData
 		}
-		client = api.NewClient(api.WithToken(displayToken))
+		client = api.NewClient(append(cmdutil.HostOptions(hosts, opts.hostname), api.WithToken(displayToken))...)
 	}
 
 	// Validate token by making an API request
@@ -108,15 +123,56 @@ func runStatus(opts *statusOptions) error {
 	opts.streams.Info("%s", opts.hostname)
 	opts.streams.Success("Logged in to %s account %s (%s)", opts.hostname, apiUser.Username, source)
 	opts.streams.Info("  - Active account: true")
-	opts.streams.Info("  - Git operations protocol: %s", hosts.GetGitProtocol(opts.hostname))
+	opts.streams.Info("  - Git operations protocol: %s", config.PreferredGitProtocol(opts.hostname))
 
 	// Mask token for display
 	maskedToken := maskToken(displayToken)
 	opts.streams.Info("  - Token: %s", maskedToken)
 
+	if opts.rateLimit {
+		rl, err := client.GetRateLimitStatus(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to get rate limit: %w", err)
+		}
+		opts.streams.Info("  - Rate limit: %s", formatRateLimit(rl, time.Now()))
+	}
+
 	return nil
 }
 
+// formatRateLimit describes a rate limit for the status output
+func formatRateLimit(rl *api.RateLimit, now time.Time) string {
+	if rl == nil {
+		return "not reported by Bitbucket"
+	}
+
+	var desc string
+	switch {
+	case rl.Remaining >= 0 && rl.Limit > 0:
+		desc = fmt.Sprintf("%d of %d requests remaining", rl.Remaining, rl.Limit)
+	case rl.Remaining >= 0:
+		desc = fmt.Sprintf("%d requests remaining", rl.Remaining)
+	case rl.Limit > 0:
+		desc = fmt.Sprintf("%d requests per window", rl.Limit)
+	default:
+		desc = "reported"
+	}
+	if rl.Resource != "" {
+		desc += fmt.Sprintf(" (%s)", rl.Resource)
+	}
+	if rl.NearLimit {
+		desc += ", nearly exhausted"
+	}
+	if !rl.Reset.IsZero() {
+		wait := rl.Reset.Sub(now).Round(time.Second)
+		if wait < 0 {
+			wait = 0
+		}
+		desc += fmt.Sprintf(", resets in %s at %s", wait, rl.Reset.Local().Format("15:04"))
+	}
+	return desc
+}
+
 func maskToken(token string) string {
 	if len(token) <= 8 {
 		return strings.Repeat("*", len(token))
@@ -0,0 +1,32 @@
+package auth
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/rbansal42/bitbucket-cli/internal/api"
+)
+
+func TestFormatRateLimit(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	tests := []struct {
+		name string
+		rl   *api.RateLimit
+		want string
+	}{
+		{name: "not reported", rl: nil, want: "not reported by Bitbucket"},
+		{name: "full", rl: &api.RateLimit{Limit: 1000, Remaining: 42, Reset: now.Add(5 * time.Minute)}, want: "42 of 1000 requests remaining, resets in 5m0s at "},
+		{name: "limit only", rl: &api.RateLimit{Limit: 1000, Remaining: -1, Resource: "api"}, want: "1000 requests per window (api)"},
+		{name: "near limit", rl: &api.RateLimit{Remaining: 3, NearLimit: true}, want: "3 requests remaining, nearly exhausted"},
+		{name: "past reset", rl: &api.RateLimit{Remaining: 0, Reset: now.Add(-time.Minute)}, want: "0 requests remaining, resets in 0s"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := formatRateLimit(tt.rl, now); !strings.HasPrefix(got, tt.want) {
+				t.Errorf("formatRateLimit() = %q, want prefix %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -6,6 +6,7 @@ import (
 
 	"github.com/spf13/cobra"
 
+	"github.com/rbansal42/bitbucket-cli/internal/cmdutil"
 	"github.com/rbansal42/bitbucket-cli/internal/config"
 	"github.com/rbansal42/bitbucket-cli/internal/iostreams"
 )
@@ -51,7 +52,7 @@ func runToken(opts *tokenOptions) error {
 
 	user := hosts.GetActiveUser(opts.hostname)
 	if user == "" {
-		return fmt.Errorf("not logged in to %s. Run 'bb auth login' to authenticate", opts.hostname)
+		return cmdutil.NewAuthError("not logged in to %s. Run 'bb auth login' to authenticate", opts.hostname)
 	}
 
 	// Get token
@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/spf13/cobra"
@@ -18,6 +19,7 @@ type CreateOptions struct {
 	BranchName string
 	Repo       string
 	Target     string
+	Type       string
 	JSON       bool
 	Streams    *iostreams.IOStreams
 }
@@ -34,10 +36,17 @@ func NewCmdCreate(streams *iostreams.IOStreams) *cobra.Command {
 		Long: `Create a new branch in a Bitbucket repository.
 
 You must specify the target branch, tag, or commit to branch from using --target.
-By default, this command detects the repository from your git remote.`,
+By default, this command detects the repository from your git remote.
+
+Use --type to prefix the name with the prefix the repository's branching model
+uses for that kind of branch (for example feature/). A name without any of
+the model's prefixes is created as given, with a hint about the prefixes.`,
 		Example: `  # Create a branch from main
   bb branch create feature-branch --target main
 
+  # Create feature/login-page using the branching model's prefix
+  bb branch create login-page --type feature --target develop
+
   # Create a branch from a specific commit
   bb branch create hotfix-branch --target abc1234
 
@@ -55,10 +64,12 @@ By default, this command detects the repository from your git remote.`,
 
 	cmd.Flags().StringVarP(&opts.Repo, "repo", "R", "", "Repository in WORKSPACE/REPO format (detects from git remote if not specified)")
 	cmd.Flags().StringVarP(&opts.Target, "target", "t", "", "Branch, tag, or commit to branch from (required)")
+	cmd.Flags().StringVar(&opts.Type, "type", "", "Branch type whose prefix to add: feature, bugfix, release or hotfix")
 	cmd.Flags().BoolVar(&opts.JSON, "json", false, "Output in JSON format")
 
 	cmd.MarkFlagRequired("target")
 
+	_ = cmd.RegisterFlagCompletionFunc("type", cmdutil.StaticFlagCompletion([]string{"feature", "bugfix", "release", "hotfix"}))
 	_ = cmd.RegisterFlagCompletionFunc("target", cmdutil.CompleteBranchNames)
 	_ = cmd.RegisterFlagCompletionFunc("repo", cmdutil.CompleteRepoNames)
 
@@ -82,6 +93,10 @@ func runCreate(ctx context.Context, opts *CreateOptions) error {
 	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
 	defer cancel()
 
+	if err := applyBranchType(ctx, client, opts, workspace, repoSlug); err != nil {
+		return err
+	}
+
 	// Try to resolve target as a branch first to get the commit hash
 	commitHash := opts.Target
 	branch, err := client.GetBranch(ctx, workspace, repoSlug, opts.Target)
@@ -113,6 +128,40 @@ func runCreate(ctx context.Context, opts *CreateOptions) error {
 	return nil
 }
 
+// applyBranchType prefixes the branch name for --type, or hints at the
+// branching model's prefixes when the name has none of them
+func applyBranchType(ctx context.Context, client *api.Client, opts *CreateOptions, workspace, repoSlug string) error {
+	model, err := client.GetBranchingModel(ctx, workspace, repoSlug)
+	if opts.Type != "" {
+		if err != nil {
+			return fmt.Errorf("failed to get branching model: %w", err)
+		}
+		prefix := model.Prefix(opts.Type)
+		if prefix == "" {
+			return fmt.Errorf("the branching model of %s/%s has no %s branches", workspace, repoSlug, opts.Type)
+		}
+		if !strings.HasPrefix(opts.BranchName, prefix) {
+			opts.BranchName = prefix + opts.BranchName
+		}
+		return nil
+	}
+
+	// The hint is best effort; a missing model never blocks the create
+	if err != nil || opts.JSON || model.TypeOf(opts.BranchName) != "" {
+		return nil
+	}
+	var prefixes []string
+	for _, t := range model.BranchTypes {
+		if t.Prefix != "" {
+			prefixes = append(prefixes, t.Prefix)
+		}
+	}
+	if len(prefixes) > 0 {
+		opts.Streams.Info("Tip: branches in %s/%s are usually prefixed with %s; use --type to add one", workspace, repoSlug, strings.Join(prefixes, ", "))
+	}
+	return nil
+}
+
 func outputCreateJSON(streams *iostreams.IOStreams, branch *api.BranchFull) error {
 	output := map[string]interface{}{
 		"name": branch.Name,
@@ -1,15 +1,17 @@
 package branch
 
 import (
-	"bufio"
 	"context"
+	"errors"
 	"fmt"
-	"strings"
+	"path"
 	"time"
 
 	"github.com/spf13/cobra"
 
+	"github.com/rbansal42/bitbucket-cli/internal/api"
 	"github.com/rbansal42/bitbucket-cli/internal/cmdutil"
+	"github.com/rbansal42/bitbucket-cli/internal/git"
 	"github.com/rbansal42/bitbucket-cli/internal/iostreams"
 )
 
@@ -18,6 +20,10 @@ type DeleteOptions struct {
 	BranchName string
 	Repo       string
 	Force      bool
+	Merged     bool
+	Into       string
+	Pattern    string
+	Select     bool
 	Streams    *iostreams.IOStreams
 }
 
@@ -28,12 +34,24 @@ func NewCmdDelete(streams *iostreams.IOStreams) *cobra.Command {
 	}
 
 	cmd := &cobra.Command{
-		Use:   "delete <branch-name>",
-		Short: "Delete a branch",
+		Use:   "delete [<branch-name>]",
+		Short: "Delete one or more branches",
 		Long: `Delete a branch from a Bitbucket repository.
 
-By default, you will be prompted to confirm the deletion.
-Use --force to skip the confirmation prompt.
+By default, you will be asked to type the branch name to confirm the
+deletion. Use --force to skip the confirmation prompt.
+
+To delete several branches at once, use --merged to select branches that
+are fully merged into a base branch (the repository's main branch unless
+--into is given), and/or --pattern to select branches whose names match a
+glob. The main branch, the base branch and your current git branch are
+never deleted. Every selected branch is attempted and a per-branch result
+is reported at the end.
+
+Use --select to pick the branches to delete from a checkbox list instead
+(space to toggle, enter to confirm). Combined with --merged or --pattern,
+only the matching branches are offered. --select needs an interactive
+terminal; in scripts pass a branch name or use --merged / --pattern.
 
 By default, this command detects the repository from your git remote.`,
 		Example: `  # Delete a branch (will prompt for confirmation)
@@ -43,9 +61,43 @@ By default, this command detects the repository from your git remote.`,
   bb branch delete feature-branch --force
 
   # Delete a branch in a specific repository
-  bb branch delete feature-branch --repo myworkspace/myrepo`,
-		Args: cobra.ExactArgs(1),
+  bb branch delete feature-branch --repo myworkspace/myrepo
+
+  # Delete all branches already merged into main
+  bb branch delete --merged --into main
+
+  # Delete all branches matching a glob
+  bb branch delete --pattern 'feature/*'
+
+  # Choose branches to delete interactively
+  bb branch delete --select
+
+  # Delete merged feature branches without confirmation
+  bb branch delete --merged --pattern 'feature/*' --force`,
+		Args: cobra.MaximumNArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
+			bulk := opts.Merged || opts.Pattern != "" || opts.Select
+			if opts.Into != "" && !opts.Merged {
+				return fmt.Errorf("--into can only be used with --merged")
+			}
+			if len(args) == 1 && bulk {
+				return fmt.Errorf("cannot combine a branch name with --merged, --pattern or --select")
+			}
+			if len(args) == 0 && !bulk {
+				return fmt.Errorf("branch name required (or use --merged / --pattern / --select to delete several branches)")
+			}
+			if opts.Select && !opts.Streams.CanPrompt() {
+				return fmt.Errorf("--select requires an interactive terminal; pass a branch name or use --merged / --pattern instead")
+			}
+			if opts.Pattern != "" {
+				if _, err := path.Match(opts.Pattern, ""); err != nil {
+					return fmt.Errorf("invalid --pattern %q: %w", opts.Pattern, err)
+				}
+			}
+
+			if bulk {
+				return runBulkDelete(cmd.Context(), opts)
+			}
 			opts.BranchName = args[0]
 			return runDelete(cmd.Context(), opts)
 		},
@@ -53,9 +105,14 @@ By default, this command detects the repository from your git remote.`,
 
 	cmd.Flags().StringVarP(&opts.Repo, "repo", "R", "", "Repository in WORKSPACE/REPO format (detects from git remote if not specified)")
 	cmd.Flags().BoolVarP(&opts.Force, "force", "f", false, "Skip confirmation prompt")
+	cmd.Flags().BoolVar(&opts.Merged, "merged", false, "Delete branches fully merged into the base branch")
+	cmd.Flags().StringVar(&opts.Into, "into", "", "Base branch for --merged (default: repository main branch)")
+	cmd.Flags().StringVar(&opts.Pattern, "pattern", "", "Delete branches whose names match a glob (e.g. 'feature/*')")
+	cmd.Flags().BoolVar(&opts.Select, "select", false, "Choose the branches to delete from an interactive list")
 
 	cmd.ValidArgsFunction = cmdutil.CompleteBranchNames
 	_ = cmd.RegisterFlagCompletionFunc("repo", cmdutil.CompleteRepoNames)
+	_ = cmd.RegisterFlagCompletionFunc("into", cmdutil.CompleteBranchNames)
 
 	return cmd
 }
@@ -67,24 +124,18 @@ func runDelete(ctx context.Context, opts *DeleteOptions) error {
 		return err
 	}
 
-	// If not forced, prompt for confirmation
+	// If not forced, have the user type the branch name to confirm
 	if !opts.Force {
-		// Require TTY for interactive confirmation
-		if !opts.Streams.IsStdinTTY() {
-			return fmt.Errorf("cannot confirm deletion in non-interactive mode\nUse --force flag to skip confirmation")
+		if !opts.Streams.CanPrompt() {
+			return &cmdutil.NoPromptError{Hint: "pass --force to skip confirmation"}
 		}
-
-		fmt.Fprintf(opts.Streams.Out, "Delete branch %s from %s/%s? [y/N]: ", opts.BranchName, workspace, repoSlug)
-
-		reader := bufio.NewReader(opts.Streams.In)
-		response, err := reader.ReadString('\n')
+		fmt.Fprintf(opts.Streams.ErrOut, "Deleting branch %s from %s/%s.\n", opts.BranchName, workspace, repoSlug)
+		confirmed, err := cmdutil.ConfirmByTyping(opts.Streams, opts.BranchName)
 		if err != nil {
-			return fmt.Errorf("failed to read response: %w", err)
+			return err
 		}
-
-		response = strings.TrimSpace(strings.ToLower(response))
-		if response != "y" && response != "yes" {
-			return fmt.Errorf("deletion cancelled")
+		if !confirmed {
+			return fmt.Errorf("deletion cancelled: branch name did not match")
 		}
 	}
 
@@ -106,3 +157,162 @@ func runDelete(ctx context.Context, opts *DeleteOptions) error {
 	opts.Streams.Success("Deleted branch %s from %s/%s", opts.BranchName, workspace, repoSlug)
 	return nil
 }
+
+func runBulkDelete(ctx context.Context, opts *DeleteOptions) error {
+	// Parse repository
+	workspace, repoSlug, err := cmdutil.ParseRepository(opts.Repo)
+	if err != nil {
+		return err
+	}
+
+	// Get API client
+	client, err := cmdutil.GetAPIClient()
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Minute)
+	defer cancel()
+
+	repo, err := client.GetRepository(ctx, workspace, repoSlug)
+	if err != nil {
+		return fmt.Errorf("failed to get repository: %w", err)
+	}
+
+	protected := make(map[string]bool)
+	if repo.MainBranch != nil && repo.MainBranch.Name != "" {
+		protected[repo.MainBranch.Name] = true
+	}
+	if current, err := git.GetCurrentBranch(); err == nil && current != "HEAD" {
+		protected[current] = true
+	}
+
+	base := opts.Into
+	if opts.Merged {
+		if base == "" {
+			if repo.MainBranch == nil || repo.MainBranch.Name == "" {
+				return fmt.Errorf("could not determine the main branch of %s/%s; use --into", workspace, repoSlug)
+			}
+			base = repo.MainBranch.Name
+		}
+		protected[base] = true
+	}
+
+	branches, err := listAllBranches(ctx, client, workspace, repoSlug)
+	if err != nil {
+		return fmt.Errorf("failed to list branches: %w", err)
+	}
+
+	var candidates []string
+	for _, b := range branches {
+		if protected[b.Name] {
+			continue
+		}
+		if opts.Pattern != "" {
+			if ok, _ := path.Match(opts.Pattern, b.Name); !ok {
+				continue
+			}
+		}
+		if opts.Merged {
+			merged, err := client.IsBranchMerged(ctx, workspace, repoSlug, b.Name, base)
+			if err != nil {
+				opts.Streams.Warning("Skipping %s: could not compare with %s: %v", b.Name, base, err)
+				continue
+			}
+			if !merged {
+				continue
+			}
+		}
+		candidates = append(candidates, b.Name)
+	}
+
+	if len(candidates) == 0 {
+		opts.Streams.Info("No branches to delete in %s/%s", workspace, repoSlug)
+		return nil
+	}
+
+	if opts.Select {
+		// The selection itself is the confirmation
+		candidates, err = selectBranches(candidates)
+		if err != nil {
+			return err
+		}
+		if len(candidates) == 0 {
+			opts.Streams.Info("No branches selected")
+			return nil
+		}
+	} else {
+		fmt.Fprintf(opts.Streams.Out, "The following %d branch(es) will be deleted from %s/%s:\n", len(candidates), workspace, repoSlug)
+		for _, name := range candidates {
+			fmt.Fprintf(opts.Streams.Out, "  %s\n", name)
+		}
+	}
+
+	if !opts.Force && !opts.Select {
+		question := fmt.Sprintf("Delete %d branch(es)?", len(candidates))
+		confirmed, err := cmdutil.Confirm(opts.Streams, question, false, "pass --force to skip confirmation")
+		if err != nil {
+			return err
+		}
+		if !confirmed {
+			return fmt.Errorf("deletion cancelled")
+		}
+	}
+
+	// Attempt every branch and report the outcome of each at the end
+	failures := make(map[string]error)
+	for _, name := range candidates {
+		if err := client.DeleteBranch(ctx, workspace, repoSlug, name); err != nil {
+			failures[name] = err
+		}
+	}
+
+	fmt.Fprintln(opts.Streams.Out)
+	for _, name := range candidates {
+		if err, failed := failures[name]; failed {
+			opts.Streams.Error("Failed to delete %s: %v", name, err)
+		} else {
+			opts.Streams.Success("Deleted branch %s", name)
+		}
+	}
+
+	if len(failures) > 0 {
+		return fmt.Errorf("failed to delete %d of %d branches", len(failures), len(candidates))
+	}
+	return nil
+}
+
+// selectBranches lets the user pick branches from names
+func selectBranches(names []string) ([]string, error) {
+	indices, err := cmdutil.MultiSelect("Select branches to delete", names)
+	if errors.Is(err, cmdutil.ErrNotInteractive) {
+		return nil, fmt.Errorf("--select requires an interactive terminal; pass a branch name or use --merged / --pattern instead")
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	chosen := make([]string, len(indices))
+	for i, idx := range indices {
+		chosen[i] = names[idx]
+	}
+	return chosen, nil
+}
+
+// listAllBranches fetches every branch in the repository, following pagination
+func listAllBranches(ctx context.Context, client *api.Client, workspace, repoSlug string) ([]api.BranchFull, error) {
+	var all []api.BranchFull
+	for page := 1; ; page++ {
+		result, err := client.ListBranches(ctx, workspace, repoSlug, &api.BranchListOptions{
+			Page:  page,
+			Limit: 100,
+		})
+		if err != nil {
+			return nil, err
+		}
+		all = append(all, result.Values...)
+		if result.Next == "" {
+			return all, nil
+		}
+	}
+}
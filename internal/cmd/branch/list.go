@@ -3,7 +3,7 @@ package branch
 import (
 	"context"
 	"fmt"
-	"text/tabwriter"
+	"strings"
 	"time"
 
 	"github.com/spf13/cobra"
@@ -15,10 +15,12 @@ import (
 
 // ListOptions holds the options for the list command
 type ListOptions struct {
-	Repo    string
-	Limit   int
-	JSON    bool
-	Streams *iostreams.IOStreams
+	Repo      string
+	Limit     int
+	JSON      bool
+	ShowCount bool
+	ExitCode  bool
+	Streams   *iostreams.IOStreams
 }
 
 // NewCmdList creates the branch list command
@@ -54,6 +56,9 @@ Use the --repo flag to specify a different repository.`,
 	cmd.Flags().StringVarP(&opts.Repo, "repo", "R", "", "Repository in WORKSPACE/REPO format (detects from git remote if not specified)")
 	cmd.Flags().IntVarP(&opts.Limit, "limit", "l", 30, "Maximum number of branches to list")
 	cmd.Flags().BoolVar(&opts.JSON, "json", false, "Output in JSON format")
+	cmdutil.AddShowCountFlag(cmd, &opts.ShowCount)
+	cmdutil.AddAllFlag(cmd, &opts.Limit)
+	cmdutil.AddExitCodeFlag(cmd, &opts.ExitCode)
 
 	_ = cmd.RegisterFlagCompletionFunc("repo", cmdutil.CompleteRepoNames)
 
@@ -79,29 +84,41 @@ func runList(ctx context.Context, opts *ListOptions) error {
 
 	// Build list options
 	listOpts := &api.BranchListOptions{
-		Limit: opts.Limit,
+		Limit: cmdutil.PageLen(opts.Limit, cmdutil.MaxPageLen),
 	}
 
 	// Fetch branches
 	result, err := client.ListBranches(ctx, workspace, repoSlug, listOpts)
+	if err == nil {
+		result, err = cmdutil.CollectPages(ctx, client, result, opts.Limit)
+	}
 	if err != nil {
 		return fmt.Errorf("failed to list branches: %w", err)
 	}
 
 	if len(result.Values) == 0 {
 		opts.Streams.Info("No branches found in %s/%s", workspace, repoSlug)
-		return nil
+		return cmdutil.NoResults(opts.ExitCode)
+	}
+
+	var count *cmdutil.PageCount
+	if opts.ShowCount {
+		count = cmdutil.NewPageCount(result, len(result.Values))
 	}
 
 	// Output results
 	if opts.JSON {
-		return outputListJSON(opts.Streams, result.Values)
+		return outputListJSON(opts.Streams, result.Values, count)
 	}
 
-	return outputTable(opts.Streams, result.Values)
+	if err := outputTable(opts.Streams, result.Values); err != nil {
+		return err
+	}
+	cmdutil.PrintPageCount(opts.Streams, count)
+	return nil
 }
 
-func outputListJSON(streams *iostreams.IOStreams, branches []api.BranchFull) error {
+func outputListJSON(streams *iostreams.IOStreams, branches []api.BranchFull, count *cmdutil.PageCount) error {
 	// Create simplified JSON output
 	output := make([]map[string]interface{}, len(branches))
 	for i, branch := range branches {
@@ -115,17 +132,13 @@ func outputListJSON(streams *iostreams.IOStreams, branches []api.BranchFull) err
 		output[i] = item
 	}
 
-	return cmdutil.PrintJSON(streams, output)
+	return cmdutil.PrintListJSON(streams, output, count)
 }
 
 func outputTable(streams *iostreams.IOStreams, branches []api.BranchFull) error {
-	w := tabwriter.NewWriter(streams.Out, 0, 0, 2, ' ', 0)
-
-	// Print header
-	header := "NAME\tCOMMIT\tMESSAGE"
-	cmdutil.PrintTableHeader(streams, w, header)
+	t := cmdutil.NewTableWriter(streams, "NAME", "COMMIT", "MESSAGE")
+	t.SetFlexColumn(2)
 
-	// Print rows
 	for _, branch := range branches {
 		name := branch.Name
 		commit := ""
@@ -138,12 +151,12 @@ func outputTable(streams *iostreams.IOStreams, branches []api.BranchFull) error
 			} else {
 				commit = branch.Target.Hash
 			}
-			// Truncate message to 50 chars and replace newlines
-			message = cmdutil.TruncateString(branch.Target.Message, 50)
+			// Only the subject line of the commit message
+			message, _, _ = strings.Cut(branch.Target.Message, "\n")
 		}
 
-		fmt.Fprintf(w, "%s\t%s\t%s\n", name, commit, message)
+		t.AddRow(name, commit, message)
 	}
 
-	return w.Flush()
+	return t.Render()
 }
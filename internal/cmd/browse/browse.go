@@ -5,7 +5,6 @@ import (
 	"os"
 	"os/exec"
 	"runtime"
-	"strings"
 
 	"github.com/spf13/cobra"
 
@@ -72,14 +71,13 @@ Use flags to open specific sections like issues, pull requests, or settings.`,
 			}
 
 			// Parse workspace and repo name
-			parts := strings.SplitN(repoPath, "/", 2)
-			if len(parts) != 2 {
-				return fmt.Errorf("invalid repository format: %s (expected workspace/repo)", repoPath)
+			workspace, repoName, err := cmdutil.ParseRepoArg(repoPath)
+			if err != nil {
+				return err
 			}
-			workspace, repoName := parts[0], parts[1]
 
 			// Build the URL
-			baseURL := fmt.Sprintf("https://bitbucket.org/%s/%s", workspace, repoName)
+			baseURL := cmdutil.WebURL(nil, workspace, repoName)
 			var url string
 
 			switch {
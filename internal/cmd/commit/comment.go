@@ -0,0 +1,115 @@
+package commit
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/rbansal42/bitbucket-cli/internal/api"
+	"github.com/rbansal42/bitbucket-cli/internal/cmdutil"
+	"github.com/rbansal42/bitbucket-cli/internal/iostreams"
+)
+
+type commentOptions struct {
+	streams *iostreams.IOStreams
+	repo    string
+	hash    string
+	body    string
+	file    string
+	line    int
+}
+
+// NewCmdComment creates the commit comment command
+func NewCmdComment(streams *iostreams.IOStreams) *cobra.Command {
+	opts := &commentOptions{
+		streams: streams,
+	}
+
+	cmd := &cobra.Command{
+		Use:   "comment <commit>",
+		Short: "Add a comment to a commit",
+		Long: `Add a comment to a commit, outside of any pull request.
+
+If the comment body is not provided via --body, an editor will be opened
+for you to enter the comment text.
+
+Use --file and --line together to comment on a line of a file changed by
+the commit; the line number is in the new version of the file.`,
+		Example: `  # Comment on a commit (opens editor)
+  bb commit comment 1a2b3c4
+
+  # Comment with a body
+  bb commit comment 1a2b3c4 --body "Should this be behind a feature flag?"
+
+  # Comment on line 42 of a file
+  bb commit comment 1a2b3c4 --file src/main.go --line 42 --body "Off by one"`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts.hash = args[0]
+			return runComment(cmd.Context(), opts)
+		},
+	}
+
+	cmd.Flags().StringVarP(&opts.body, "body", "b", "", "Comment body text")
+	cmd.Flags().StringVarP(&opts.file, "file", "f", "", "Path of the file to comment on")
+	cmd.Flags().IntVarP(&opts.line, "line", "l", 0, "Line of --file to comment on")
+	cmd.Flags().StringVarP(&opts.repo, "repo", "R", "", "Repository in WORKSPACE/REPO format")
+
+	cmd.MarkFlagsRequiredTogether("file", "line")
+
+	_ = cmd.RegisterFlagCompletionFunc("repo", cmdutil.CompleteRepoNames)
+
+	return cmd
+}
+
+func runComment(ctx context.Context, opts *commentOptions) error {
+	if opts.file != "" && opts.line < 1 {
+		return fmt.Errorf("--line must be at least 1")
+	}
+
+	workspace, repoSlug, err := cmdutil.ParseRepository(opts.repo)
+	if err != nil {
+		return err
+	}
+
+	// If no body provided, open editor
+	if opts.body == "" {
+		if !opts.streams.CanPrompt() {
+			return &cmdutil.NoPromptError{Hint: "pass --body"}
+		}
+		body, err := cmdutil.OpenEditor("")
+		if err != nil {
+			return fmt.Errorf("failed to get comment: %w", err)
+		}
+		if body == "" {
+			return fmt.Errorf("comment body is required")
+		}
+		opts.body = body
+	}
+
+	client, err := cmdutil.GetAPIClient()
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	comment, err := client.AddCommitComment(ctx, workspace, repoSlug, opts.hash, &api.AddPRCommentOptions{
+		Content: opts.body,
+		Path:    opts.file,
+		Line:    opts.line,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to add comment: %w", err)
+	}
+
+	if comment.Links.HTML.Href != "" {
+		fmt.Fprintln(opts.streams.Out, comment.Links.HTML.Href)
+	} else {
+		opts.streams.Success("Added comment to commit %s", cmdutil.ShortHash(opts.hash))
+	}
+	return nil
+}
@@ -0,0 +1,32 @@
+package commit
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/rbansal42/bitbucket-cli/internal/iostreams"
+)
+
+// NewCmdCommit creates the commit command and its subcommands
+func NewCmdCommit(streams *iostreams.IOStreams) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "commit <command>",
+		Short: "Work with commits",
+		Long: `View and comment on individual commits in a repository.
+
+To list the history of a branch, use "bb repo commits"; to list the commits
+in a pull request, use "bb pr commits".`,
+		Example: `  # View a commit in the current repository
+  bb commit view 1a2b3c4
+
+  # View a commit in another repository
+  bb commit view 1a2b3c4 --repo myworkspace/myrepo
+
+  # Comment on a commit
+  bb commit comment 1a2b3c4 --body "Nice cleanup"`,
+	}
+
+	cmd.AddCommand(NewCmdView(streams))
+	cmd.AddCommand(NewCmdComment(streams))
+
+	return cmd
+}
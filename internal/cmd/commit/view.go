@@ -0,0 +1,153 @@
+package commit
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/rbansal42/bitbucket-cli/internal/api"
+	"github.com/rbansal42/bitbucket-cli/internal/browser"
+	"github.com/rbansal42/bitbucket-cli/internal/cmdutil"
+	"github.com/rbansal42/bitbucket-cli/internal/iostreams"
+)
+
+type viewOptions struct {
+	streams *iostreams.IOStreams
+	repo    string
+	hash    string
+	web     bool
+	jsonOut bool
+}
+
+// NewCmdView creates the commit view command
+func NewCmdView(streams *iostreams.IOStreams) *cobra.Command {
+	opts := &viewOptions{
+		streams: streams,
+	}
+
+	cmd := &cobra.Command{
+		Use:   "view <commit>",
+		Short: "View a commit",
+		Long: `Display a commit's author, date and full message.
+
+The commit can be a full or abbreviated hash, or a branch or tag name.
+For signed commits, the signature line shows whether Bitbucket verified
+the GPG or SSH signature. Unsigned commits have no signature line.`,
+		Example: `  # View a commit
+  bb commit view 1a2b3c4
+
+  # View the commit at the tip of a branch
+  bb commit view main
+
+  # Open the commit in the browser
+  bb commit view 1a2b3c4 --web`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts.hash = args[0]
+			return runView(cmd.Context(), opts)
+		},
+	}
+
+	cmd.Flags().BoolVarP(&opts.web, "web", "w", false, "Open the commit in a web browser")
+	cmd.Flags().BoolVar(&opts.jsonOut, "json", false, "Output in JSON format")
+	cmd.Flags().StringVarP(&opts.repo, "repo", "R", "", "Repository in WORKSPACE/REPO format")
+
+	_ = cmd.RegisterFlagCompletionFunc("repo", cmdutil.CompleteRepoNames)
+
+	return cmd
+}
+
+func runView(ctx context.Context, opts *viewOptions) error {
+	workspace, repoSlug, err := cmdutil.ParseRepository(opts.repo)
+	if err != nil {
+		return err
+	}
+
+	client, err := cmdutil.GetAPIClient()
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	opts.streams.Verbose("Fetching commit %s...", opts.hash)
+	commit, err := client.GetCommit(ctx, workspace, repoSlug, opts.hash)
+	if err != nil {
+		return fmt.Errorf("failed to get commit: %w", err)
+	}
+
+	if opts.web {
+		if err := browser.Open(commit.Links.HTML.Href); err != nil {
+			return fmt.Errorf("could not open browser: %w", err)
+		}
+		opts.streams.Success("Opened %s in your browser", commit.Links.HTML.Href)
+		return nil
+	}
+
+	if opts.jsonOut {
+		return cmdutil.PrintJSON(opts.streams, commit)
+	}
+
+	writeCommit(opts.streams, opts.streams.Out, commit)
+	return nil
+}
+
+// writeCommit prints a commit in a layout similar to git show
+func writeCommit(streams *iostreams.IOStreams, w io.Writer, c *api.Commit) {
+	fmt.Fprintf(w, "%s %s\n\n", streams.ColorFunc(iostreams.Yellow)(c.Hash), streams.ColorFunc(iostreams.Bold)(cmdutil.CommitSubject(c.Message)))
+
+	// The raw git author keeps the email address, as git show does
+	author := cmdutil.CommitAuthorName(c.Author)
+	if c.Author != nil && c.Author.Raw != "" {
+		author = c.Author.Raw
+	}
+	fmt.Fprintf(w, "Author:    %s\n", author)
+	if !c.Date.IsZero() {
+		fmt.Fprintf(w, "Date:      %s (%s)\n", c.Date.Local().Format("2006-01-02 15:04"), cmdutil.TimeAgo(c.Date))
+	}
+	if c.Signature != nil {
+		fmt.Fprintf(w, "Signature: %s\n", describeSignature(streams, c.Signature))
+	}
+
+	if _, body, ok := strings.Cut(strings.TrimSpace(c.Message), "\n"); ok {
+		if body = strings.TrimSpace(body); body != "" {
+			fmt.Fprintln(w)
+			for _, line := range strings.Split(body, "\n") {
+				fmt.Fprintf(w, "    %s\n", line)
+			}
+		}
+	}
+
+	if c.Links.HTML.Href != "" {
+		fmt.Fprintf(w, "\nView in browser: %s\n", c.Links.HTML.Href)
+	}
+}
+
+// describeSignature renders the verification badge followed by the
+// signature type, signer and, for unverified signatures, the reason
+func describeSignature(streams *iostreams.IOStreams, sig *api.CommitSignature) string {
+	desc := cmdutil.SignatureBadge(streams, sig)
+
+	var details []string
+	if sig.Type != "" {
+		details = append(details, strings.ToUpper(sig.Type))
+	}
+	if sig.Signer != nil {
+		details = append(details, "signed by "+cmdutil.GetUserDisplayName(sig.Signer))
+	}
+	if sig.KeyID != "" {
+		details = append(details, "key "+sig.KeyID)
+	}
+	if len(details) > 0 {
+		desc += " (" + strings.Join(details, ", ") + ")"
+	}
+	if !sig.Verified && sig.Reason != "" {
+		desc += ": " + sig.Reason
+	}
+	return desc
+}
@@ -0,0 +1,61 @@
+package commit
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/rbansal42/bitbucket-cli/internal/api"
+	"github.com/rbansal42/bitbucket-cli/internal/iostreams"
+)
+
+func TestWriteCommit(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		want    []string
+		notWant []string
+	}{
+		{
+			name: "verified",
+			data: `{"hash": "abc123", "message": "Fix bug\n\nLonger description", "author": {"raw": "Dev <dev@example.com>"},
+				"signature": {"type": "ssh", "verified": true, "key_id": "SHA256:xyz"}}`,
+			want: []string{"abc123 Fix bug\n", "Author:    Dev <dev@example.com>\n", "Signature: verified (SSH, key SHA256:xyz)\n", "\n    Longer description\n"},
+		},
+		{
+			name: "unverified",
+			data: `{"hash": "abc123", "message": "Fix bug", "signature": {"type": "gpg", "verified": false, "reason": "unknown key"}}`,
+			want: []string{"Signature: unverified (GPG): unknown key\n"},
+		},
+		{
+			name:    "unsigned",
+			data:    `{"hash": "abc123", "message": "Fix bug"}`,
+			notWant: []string{"Signature", "verified"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var c api.Commit
+			if err := json.Unmarshal([]byte(tt.data), &c); err != nil {
+				t.Fatalf("decoding commit: %v", err)
+			}
+
+			var buf bytes.Buffer
+			writeCommit(&iostreams.IOStreams{}, &buf, &c)
+			out := buf.String()
+
+			for _, want := range tt.want {
+				if !strings.Contains(out, want) {
+					t.Errorf("output missing %q:\n%s", want, out)
+				}
+			}
+			for _, notWant := range tt.notWant {
+				if strings.Contains(out, notWant) {
+					t.Errorf("output contains %q:\n%s", notWant, out)
+				}
+			}
+		})
+	}
+}
@@ -25,11 +25,15 @@ Linux:
 macOS:
     bb completion bash > $(brew --prefix)/etc/bash_completion.d/bb
 
+Or let bb install it for your user:
+
+    bb completion bash --install
+
 You will need to start a new shell for this setup to take effect.`,
 		Args:                  cobra.NoArgs,
 		DisableFlagsInUseLine: true,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return cmd.Root().GenBashCompletionV2(streams.Out, true)
+			return runCompletion(cmd, streams, "bash", true)
 		},
 	}
 }
@@ -1,20 +1,28 @@
 package completion
 
 import (
+	"fmt"
+	"os"
+	"strings"
+
 	"github.com/spf13/cobra"
 
+	"github.com/rbansal42/bitbucket-cli/internal/cmdutil"
 	"github.com/rbansal42/bitbucket-cli/internal/iostreams"
 )
 
 // NewCmdCompletion creates the completion command and its subcommands
 func NewCmdCompletion(streams *iostreams.IOStreams) *cobra.Command {
 	cmd := &cobra.Command{
-		Use:   "completion <shell>",
+		Use:   "completion [<shell>]",
 		Short: "Generate shell completion scripts",
 		Long: `Generate shell completion scripts for bb.
 
 The completion script must be evaluated to provide interactive completion.
-This can be done by sourcing it in your shell configuration.
+This can be done by sourcing it in your shell configuration, or by passing
+--install to write it where your shell looks for completions.
+
+When no shell is given on a terminal, it is detected from $SHELL.
 
 For examples of loading completions, run:
   bb completion bash --help
@@ -31,9 +39,27 @@ For examples of loading completions, run:
   bb completion fish
 
   # Generate PowerShell completion
-  bb completion powershell`,
+  bb completion powershell
+
+  # Install completions for the current shell
+  bb completion --install`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			install, _ := cmd.Flags().GetBool("install")
+			if !install && !streams.IsStdoutTTY() {
+				return cmdutil.NewFlagError(fmt.Errorf("specify a shell: %s", strings.Join(shells, ", ")))
+			}
+
+			shell, err := detectShell(os.Getenv("SHELL"))
+			if err != nil {
+				return err
+			}
+			return runCompletion(cmd, streams, shell, true)
+		},
 	}
 
+	cmd.PersistentFlags().Bool("install", false, "Write the script to the conventional location for the shell")
+
 	cmd.AddCommand(NewCmdBash(streams))
 	cmd.AddCommand(NewCmdZsh(streams))
 	cmd.AddCommand(NewCmdFish(streams))
@@ -23,11 +23,15 @@ To load completions for every new session, execute once:
 
     bb completion fish > ~/.config/fish/completions/bb.fish
 
+or:
+
+    bb completion fish --install
+
 You will need to start a new shell for this setup to take effect.`,
 		Args:                  cobra.NoArgs,
 		DisableFlagsInUseLine: true,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return cmd.Root().GenFishCompletion(streams.Out, !noDescriptions)
+			return runCompletion(cmd, streams, "fish", !noDescriptions)
 		},
 	}
 
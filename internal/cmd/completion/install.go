@@ -0,0 +1,126 @@
+package completion
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/rbansal42/bitbucket-cli/internal/cmdutil"
+	"github.com/rbansal42/bitbucket-cli/internal/iostreams"
+)
+
+// shells lists the shells bb can generate completions for
+var shells = []string{"bash", "zsh", "fish", "powershell"}
+
+// runCompletion prints the completion script for shell, or writes it to the
+// conventional location when --install was passed
+func runCompletion(cmd *cobra.Command, streams *iostreams.IOStreams, shell string, descriptions bool) error {
+	install, _ := cmd.Flags().GetBool("install")
+	if !install {
+		return generateScript(cmd.Root(), shell, streams.Out, descriptions)
+	}
+
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return fmt.Errorf("could not find home directory: %w", err)
+	}
+	path, hint := installTarget(shell, home, os.Getenv)
+
+	var script bytes.Buffer
+	if err := generateScript(cmd.Root(), shell, &script, descriptions); err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("failed to create completion directory: %w", err)
+	}
+	if err := os.WriteFile(path, script.Bytes(), 0o644); err != nil {
+		return fmt.Errorf("failed to write completion script: %w", err)
+	}
+
+	streams.Success("Installed %s completions to %s", shell, path)
+	if hint != "" {
+		fmt.Fprintf(streams.Out, "\n%s\n", hint)
+	}
+	fmt.Fprintln(streams.Out, "Start a new shell for completions to take effect.")
+	return nil
+}
+
+// generateScript writes the completion script for shell to w. Dynamic
+// completions are resolved at completion time by calling back into bb, so
+// the scripts cover every registered ValidArgsFunction and flag completion.
+func generateScript(root *cobra.Command, shell string, w io.Writer, descriptions bool) error {
+	switch shell {
+	case "bash":
+		return root.GenBashCompletionV2(w, descriptions)
+	case "zsh":
+		if !descriptions {
+			return root.GenZshCompletionNoDesc(w)
+		}
+		return root.GenZshCompletion(w)
+	case "fish":
+		return root.GenFishCompletion(w, descriptions)
+	case "powershell":
+		if !descriptions {
+			return root.GenPowerShellCompletion(w)
+		}
+		return root.GenPowerShellCompletionWithDesc(w)
+	}
+	return fmt.Errorf("unsupported shell %q: must be one of %s", shell, strings.Join(shells, ", "))
+}
+
+// installTarget returns where --install writes the script for shell, and
+// any line the user needs to add to their shell configuration for it to be
+// loaded. getenv is os.Getenv outside tests.
+func installTarget(shell, home string, getenv func(string) string) (path, hint string) {
+	dataHome := getenv("XDG_DATA_HOME")
+	if dataHome == "" {
+		dataHome = filepath.Join(home, ".local", "share")
+	}
+	configHome := getenv("XDG_CONFIG_HOME")
+	if configHome == "" {
+		configHome = filepath.Join(home, ".config")
+	}
+
+	switch shell {
+	case "bash":
+		// bash-completion loads scripts from here on demand
+		path = filepath.Join(dataHome, "bash-completion", "completions", "bb")
+		hint = fmt.Sprintf("If completions don't load, add this line to ~/.bashrc:\n    source %s", path)
+	case "zsh":
+		zdot := getenv("ZDOTDIR")
+		if zdot == "" {
+			zdot = home
+		}
+		dir := filepath.Join(zdot, ".zfunc")
+		path = filepath.Join(dir, "_bb")
+		hint = fmt.Sprintf("Add these lines to %s if they aren't there already:\n    fpath=(%s $fpath)\n    autoload -U compinit; compinit",
+			filepath.Join(zdot, ".zshrc"), dir)
+	case "fish":
+		// fish loads everything in this directory automatically
+		path = filepath.Join(configHome, "fish", "completions", "bb.fish")
+	case "powershell":
+		path = filepath.Join(configHome, "powershell", "bb-completion.ps1")
+		hint = fmt.Sprintf("Add this line to your PowerShell profile ($PROFILE):\n    . %s", path)
+	}
+	return path, hint
+}
+
+// detectShell maps the value of $SHELL to a supported shell name
+func detectShell(shellEnv string) (string, error) {
+	name := strings.TrimSuffix(filepath.Base(shellEnv), ".exe")
+	switch name {
+	case "bash", "zsh", "fish":
+		return name, nil
+	case "pwsh", "powershell":
+		return "powershell", nil
+	}
+	if shellEnv == "" {
+		return "", cmdutil.NewFlagError(fmt.Errorf("could not detect your shell: $SHELL is not set; specify one of %s", strings.Join(shells, ", ")))
+	}
+	return "", cmdutil.NewFlagError(fmt.Errorf("unsupported shell %q; specify one of %s", name, strings.Join(shells, ", ")))
+}
@@ -0,0 +1,65 @@
+package completion
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestDetectShell(t *testing.T) {
+	tests := []struct {
+		env     string
+		want    string
+		wantErr bool
+	}{
+		{env: "/bin/bash", want: "bash"},
+		{env: "/usr/local/bin/zsh", want: "zsh"},
+		{env: "/opt/homebrew/bin/fish", want: "fish"},
+		{env: "/usr/bin/pwsh", want: "powershell"},
+		{env: "/bin/tcsh", wantErr: true},
+		{env: "", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		got, err := detectShell(tt.env)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("detectShell(%q) error = %v, wantErr %v", tt.env, err, tt.wantErr)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("detectShell(%q) = %q, want %q", tt.env, got, tt.want)
+		}
+	}
+}
+
+func TestInstallTarget(t *testing.T) {
+	home := filepath.Join("/home", "user")
+	noEnv := func(string) string { return "" }
+
+	tests := []struct {
+		shell    string
+		getenv   func(string) string
+		want     string
+		wantHint bool
+	}{
+		{"bash", noEnv, filepath.Join(home, ".local", "share", "bash-completion", "completions", "bb"), true},
+		{"zsh", noEnv, filepath.Join(home, ".zfunc", "_bb"), true},
+		{"fish", noEnv, filepath.Join(home, ".config", "fish", "completions", "bb.fish"), false},
+		{"powershell", noEnv, filepath.Join(home, ".config", "powershell", "bb-completion.ps1"), true},
+		{"fish", func(k string) string {
+			if k == "XDG_CONFIG_HOME" {
+				return "/xdg"
+			}
+			return ""
+		}, filepath.Join("/xdg", "fish", "completions", "bb.fish"), false},
+	}
+
+	for _, tt := range tests {
+		path, hint := installTarget(tt.shell, home, tt.getenv)
+		if path != tt.want {
+			t.Errorf("installTarget(%q) path = %q, want %q", tt.shell, path, tt.want)
+		}
+		if (hint != "") != tt.wantHint {
+			t.Errorf("installTarget(%q) hint = %q", tt.shell, hint)
+		}
+	}
+}
@@ -20,14 +20,15 @@ To load completions in your current shell session:
     bb completion powershell | Out-String | Invoke-Expression
 
 To load completions for every new session, add the output of the above command
-to your PowerShell profile.`,
+to your PowerShell profile, or run:
+
+    bb completion powershell --install
+
+and dot-source the file it reports from your profile.`,
 		Args:                  cobra.NoArgs,
 		DisableFlagsInUseLine: true,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			if noDescriptions {
-				return cmd.Root().GenPowerShellCompletion(streams.Out)
-			}
-			return cmd.Root().GenPowerShellCompletionWithDesc(streams.Out)
+			return runCompletion(cmd, streams, "powershell", !noDescriptions)
 		},
 	}
 
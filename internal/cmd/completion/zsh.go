@@ -27,6 +27,10 @@ Linux:
 macOS:
     bb completion zsh > $(brew --prefix)/share/zsh/site-functions/_bb
 
+Or let bb install it for your user:
+
+    bb completion zsh --install
+
 You will need to start a new shell for this setup to take effect.
 
 If shell completion is not already enabled in your environment, you will need
@@ -36,10 +40,7 @@ to enable it. You can execute the following once:
 		Args:                  cobra.NoArgs,
 		DisableFlagsInUseLine: true,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			if noDescriptions {
-				return cmd.Root().GenZshCompletionNoDesc(streams.Out)
-			}
-			return cmd.Root().GenZshCompletion(streams.Out)
+			return runCompletion(cmd, streams, "zsh", !noDescriptions)
 		},
 	}
 
@@ -17,12 +17,17 @@ Configuration is stored in ~/.config/bb/config.yml or the directory
 specified by the BB_CONFIG_DIR environment variable.
 
 Available settings:
-  git_protocol   The protocol to use for git operations (ssh, https)
-  editor         The editor to use for composing text
-  prompt         Whether to enable interactive prompts (enabled, disabled)
-  pager          The pager to use for output
-  browser        The browser to use for opening URLs
-  http_timeout   HTTP request timeout in seconds`,
+  git_protocol       The protocol to use for git operations (ssh, https)
+  editor             The editor to use for composing text
+  prompt             Whether to enable interactive prompts (enabled, disabled)
+  pager              The pager to use for output
+  browser            The browser to use for opening URLs
+  http_timeout       HTTP request timeout in seconds
+  update_url         Release URL queried by 'bb version --check'
+  merge_strategy     Default strategy for 'bb pr merge' (merge_commit, squash, fast_forward)
+  per_page           Results requested per page by list commands (1-100)
+  default_project    Project key used by 'bb repo create' without --project
+  credential_helper  Command that prints the token for a host read from stdin`,
 	}
 
 	cmd.AddCommand(NewCmdConfigGet(streams))
@@ -21,17 +21,25 @@ func NewCmdConfigGet(streams *iostreams.IOStreams) *cobra.Command {
 		Long: `Print the value of a configuration key.
 
 Available keys:
-  git_protocol   The protocol to use for git operations
-  editor         The editor to use for composing text
-  prompt         Whether to enable interactive prompts
-  pager          The pager to use for output
-  browser        The browser to use for opening URLs
-  http_timeout   HTTP request timeout in seconds`,
+  git_protocol       The protocol to use for git operations
+  editor             The editor to use for composing text
+  prompt             Whether to enable interactive prompts
+  pager              The pager to use for output
+  browser            The browser to use for opening URLs
+  http_timeout       HTTP request timeout in seconds
+  update_url         Release URL queried by 'bb version --check'
+  merge_strategy     Default strategy for 'bb pr merge' (merge_commit, squash, fast_forward)
+  per_page           Results requested per page by list commands
+  default_project    Project key used by 'bb repo create' without --project
+  credential_helper  Command that prints the token for a host read from stdin`,
 		Example: `  # Get the git protocol setting
   bb config get git_protocol
 
   # Get the editor setting
-  bb config get editor`,
+  bb config get editor
+
+  # Get the git protocol used for a specific host
+  bb config get git_protocol --host bitbucket.example.com`,
 		Args: cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			key := strings.ToLower(args[0])
@@ -48,6 +56,15 @@ Available keys:
 				return err
 			}
 
+			// A per-host git_protocol overrides the global one, and with
+			// neither set the protocol depends on the SSH keys available
+			if key == "git_protocol" && (host != "" || value == "") {
+				if host == "" {
+					host = coreconfig.DefaultHost
+				}
+				value = coreconfig.PreferredGitProtocol(host)
+			}
+
 			fmt.Fprintln(streams.Out, value)
 			return nil
 		},
@@ -62,12 +79,17 @@ Available keys:
 func getConfigValue(cfg *coreconfig.Config, key string) (string, error) {
 	// Map config keys to struct fields
 	keyMap := map[string]string{
-		"git_protocol": "GitProtocol",
-		"editor":       "Editor",
-		"prompt":       "Prompt",
-		"pager":        "Pager",
-		"browser":      "Browser",
-		"http_timeout": "HTTPTimeout",
+		"git_protocol":      "GitProtocol",
+		"editor":            "Editor",
+		"prompt":            "Prompt",
+		"pager":             "Pager",
+		"browser":           "Browser",
+		"http_timeout":      "HTTPTimeout",
+		"update_url":        "UpdateURL",
+		"merge_strategy":    "MergeStrategy",
+		"per_page":          "PerPage",
+		"default_project":   "DefaultProject",
+		"credential_helper": "CredentialHelper",
 	}
 
 	fieldName, ok := keyMap[key]
@@ -40,17 +40,28 @@ Shows the current configuration settings from the config file.`,
 
 // printConfig prints all configuration values
 func printConfig(streams *iostreams.IOStreams, cfg *coreconfig.Config) {
+	// An unset git_protocol shows the one clone URLs use
+	gitProtocol := cfg.GitProtocol
+	if gitProtocol == "" {
+		gitProtocol = coreconfig.PreferredGitProtocol(coreconfig.DefaultHost)
+	}
+
 	// Define the order and format of output
 	settings := []struct {
 		key   string
 		value interface{}
 	}{
-		{"git_protocol", cfg.GitProtocol},
+		{"git_protocol", gitProtocol},
 		{"editor", cfg.Editor},
 		{"prompt", cfg.Prompt},
 		{"pager", cfg.Pager},
 		{"browser", cfg.Browser},
 		{"http_timeout", cfg.HTTPTimeout},
+		{"update_url", cfg.UpdateURL},
+		{"merge_strategy", cfg.MergeStrategy},
+		{"per_page", cfg.PerPage},
+		{"default_project", cfg.DefaultProject},
+		{"credential_helper", cfg.CredentialHelper},
 	}
 
 	for _, s := range settings {
@@ -2,11 +2,14 @@ package config
 
 import (
 	"fmt"
+	"slices"
 	"strconv"
 	"strings"
 
 	"github.com/spf13/cobra"
 
+	"github.com/rbansal42/bitbucket-cli/internal/api"
+	"github.com/rbansal42/bitbucket-cli/internal/cmdutil"
 	coreconfig "github.com/rbansal42/bitbucket-cli/internal/config"
 	"github.com/rbansal42/bitbucket-cli/internal/iostreams"
 )
@@ -21,15 +24,23 @@ func NewCmdConfigSet(streams *iostreams.IOStreams) *cobra.Command {
 		Long: `Update configuration with a value for the given key.
 
 Available keys:
-  git_protocol   The protocol to use for git operations (ssh, https)
-  editor         The editor to use for composing text
-  prompt         Whether to enable interactive prompts (enabled, disabled)
-  pager          The pager to use for output
-  browser        The browser to use for opening URLs
-  http_timeout   HTTP request timeout in seconds`,
+  git_protocol       The protocol to use for git operations (ssh, https)
+  editor             The editor to use for composing text
+  prompt             Whether to enable interactive prompts (enabled, disabled)
+  pager              The pager to use for output
+  browser            The browser to use for opening URLs
+  http_timeout       HTTP request timeout in seconds
+  update_url         Release URL queried by 'bb version --check'
+  merge_strategy     Default strategy for 'bb pr merge' (merge_commit, squash, fast_forward)
+  per_page           Results requested per page by list commands (1-100)
+  default_project    Project key used by 'bb repo create' without --project
+  credential_helper  Command that prints the token for a host read from stdin`,
 		Example: `  # Set the git protocol to HTTPS
   bb config set git_protocol https
 
+  # Use SSH for a self-hosted Bitbucket only
+  bb config set git_protocol ssh --host bitbucket.example.com
+
   # Set the editor to vim
   bb config set editor vim
 
@@ -37,12 +48,32 @@ Available keys:
   bb config set prompt disabled
 
   # Set HTTP timeout to 60 seconds
-  bb config set http_timeout 60`,
+  bb config set http_timeout 60
+
+  # Squash merge pull requests by default
+  bb config set merge_strategy squash
+
+  # Fetch smaller pages on a slow connection
+  bb config set per_page 20
+
+  # Create repositories in the CORE project by default
+  bb config set default_project CORE
+
+  # Read tokens from a secrets manager
+  bb config set credential_helper "vault kv get -field=token secret/bitbucket"`,
 		Args: cobra.ExactArgs(2),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			key := strings.ToLower(args[0])
 			value := args[1]
 
+			if host != "" {
+				if err := setHostValue(host, key, value); err != nil {
+					return err
+				}
+				streams.Success("Set %s to %s for %s", key, value, host)
+				return nil
+			}
+
 			// Load config
 			cfg, err := coreconfig.LoadConfig()
 			if err != nil {
@@ -64,11 +95,31 @@ Available keys:
 		},
 	}
 
-	cmd.Flags().StringVarP(&host, "host", "h", "", "Set per-host configuration")
+	cmd.Flags().StringVarP(&host, "host", "h", "", "Set per-host configuration (git_protocol only)")
 
 	return cmd
 }
 
+// setHostValue sets a per-host value in the hosts file
+func setHostValue(host, key, value string) error {
+	if key != "git_protocol" {
+		return fmt.Errorf("%s cannot be set per host; only git_protocol can", key)
+	}
+	if !slices.Contains(coreconfig.GitProtocols, value) {
+		return fmt.Errorf("invalid git_protocol: %s (must be 'ssh' or 'https')", value)
+	}
+
+	hosts, err := coreconfig.LoadHostsConfig()
+	if err != nil {
+		return fmt.Errorf("could not load hosts config: %w", err)
+	}
+	hosts.SetGitProtocol(host, value)
+	if err := coreconfig.SaveHostsConfig(hosts); err != nil {
+		return fmt.Errorf("could not save hosts config: %w", err)
+	}
+	return nil
+}
+
 // setConfigValue sets a config value with validation
 func setConfigValue(cfg *coreconfig.Config, key, value string) error {
 	switch key {
@@ -103,6 +154,36 @@ func setConfigValue(cfg *coreconfig.Config, key, value string) error {
 		}
 		cfg.HTTPTimeout = timeout
 
+	case "update_url":
+		cfg.UpdateURL = value
+
+	case "merge_strategy":
+		if !slices.Contains(api.MergeStrategies, api.MergeStrategy(value)) {
+			return fmt.Errorf("invalid merge_strategy: %s (must be 'merge_commit', 'squash' or 'fast_forward')", value)
+		}
+		cfg.MergeStrategy = value
+
+	case "per_page":
+		perPage, err := strconv.Atoi(value)
+		if err != nil {
+			return fmt.Errorf("invalid per_page: %s (must be a number)", value)
+		}
+		if err := cmdutil.ValidatePerPage(perPage); err != nil {
+			return fmt.Errorf("invalid per_page: %w", err)
+		}
+		cfg.PerPage = perPage
+
+	case "default_project":
+		// An empty value clears the setting; whether the project exists is
+		// checked when 'bb repo create' uses it, in the workspace it uses
+		if value != "" && !cmdutil.IsProjectKey(value) {
+			return fmt.Errorf("invalid default_project: %q is not a project key (letters, digits and underscores, starting with a letter)", value)
+		}
+		cfg.DefaultProject = strings.ToUpper(value)
+
+	case "credential_helper":
+		cfg.CredentialHelper = value
+
 	default:
 		return fmt.Errorf("unknown configuration key: %s", key)
 	}
@@ -0,0 +1,100 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"net/url"
+	"os/exec"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/rbansal42/bitbucket-cli/internal/api"
+	"github.com/rbansal42/bitbucket-cli/internal/cmdutil"
+)
+
+// Exit codes returned by bb. These are part of the scripting interface
+// documented in docs/guide/scripting.md and must not change meaning.
+const (
+	ExitOK       = 0
+	ExitError    = 1
+	ExitUsage    = 2
+	ExitNotFound = 3
+	ExitAuth     = 4
+	ExitNetwork  = 8
+)
+
+// ExitCode maps an error returned by Execute to the process exit code.
+func ExitCode(err error) int {
+	if err == nil {
+		return ExitOK
+	}
+
+	// An empty result under --exit-code is reported like grep's "no match"
+	if errors.Is(err, cmdutil.ErrNoResults) {
+		return ExitError
+	}
+
+	// Shell aliases exit with the status of the command they ran
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) {
+		return exitErr.ExitCode()
+	}
+
+	var flagErr *cmdutil.FlagError
+	if errors.As(err, &flagErr) || strings.HasPrefix(err.Error(), "unknown command ") {
+		return ExitUsage
+	}
+
+	var noPromptErr *cmdutil.NoPromptError
+	if errors.As(err, &noPromptErr) {
+		return ExitUsage
+	}
+
+	var authErr *cmdutil.AuthError
+	if errors.As(err, &authErr) {
+		return ExitAuth
+	}
+
+	var apiErr *api.APIError
+	if errors.As(err, &apiErr) {
+		switch apiErr.StatusCode {
+		case http.StatusUnauthorized, http.StatusForbidden:
+			return ExitAuth
+		case http.StatusNotFound:
+			return ExitNotFound
+		}
+		return ExitError
+	}
+
+	var urlErr *url.Error
+	var netErr net.Error
+	if errors.Is(err, context.DeadlineExceeded) || errors.As(err, &urlErr) || errors.As(err, &netErr) {
+		return ExitNetwork
+	}
+
+	return ExitError
+}
+
+// markUsageErrors makes flag parsing and argument validation failures on
+// cmd and its subcommands return a FlagError.
+func markUsageErrors(cmd *cobra.Command) {
+	cmd.SetFlagErrorFunc(func(_ *cobra.Command, err error) error {
+		return cmdutil.NewFlagError(err)
+	})
+
+	if args := cmd.Args; args != nil {
+		cmd.Args = func(c *cobra.Command, a []string) error {
+			if err := args(c, a); err != nil {
+				return cmdutil.NewFlagError(err)
+			}
+			return nil
+		}
+	}
+
+	for _, sub := range cmd.Commands() {
+		markUsageErrors(sub)
+	}
+}
@@ -0,0 +1,42 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"testing"
+
+	"github.com/rbansal42/bitbucket-cli/internal/api"
+	"github.com/rbansal42/bitbucket-cli/internal/cmdutil"
+)
+
+func TestExitCode(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want int
+	}{
+		{name: "nil", err: nil, want: ExitOK},
+		{name: "generic", err: errors.New("boom"), want: ExitError},
+		{name: "no results", err: cmdutil.ErrNoResults, want: ExitError},
+		{name: "flag error", err: cmdutil.NewFlagError(errors.New("unknown flag: --x")), want: ExitUsage},
+		{name: "unknown command", err: errors.New(`unknown command "nope" for "bb"`), want: ExitUsage},
+		{name: "no prompt", err: fmt.Errorf("failed: %w", &cmdutil.NoPromptError{Hint: "pass --title"}), want: ExitUsage},
+		{name: "auth error", err: cmdutil.NewAuthError("not logged in"), want: ExitAuth},
+		{name: "api 401", err: fmt.Errorf("failed: %w", &api.APIError{StatusCode: 401}), want: ExitAuth},
+		{name: "api 403", err: &api.APIError{StatusCode: 403}, want: ExitAuth},
+		{name: "api 404", err: fmt.Errorf("failed to get pull request: %w", &api.APIError{StatusCode: 404}), want: ExitNotFound},
+		{name: "api 500", err: &api.APIError{StatusCode: 500}, want: ExitError},
+		{name: "url error", err: fmt.Errorf("request failed: %w", &url.Error{Op: "Get", URL: "https://x", Err: errors.New("dial tcp")}), want: ExitNetwork},
+		{name: "timeout", err: fmt.Errorf("request failed: %w", context.DeadlineExceeded), want: ExitNetwork},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ExitCode(tt.err); got != tt.want {
+				t.Errorf("ExitCode(%v) = %d, want %d", tt.err, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,267 @@
+package issue
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/rbansal42/bitbucket-cli/internal/api"
+	"github.com/rbansal42/bitbucket-cli/internal/cmdutil"
+	"github.com/rbansal42/bitbucket-cli/internal/iostreams"
+)
+
+// maxAttachmentPages bounds how many pages of attachments are fetched
+const maxAttachmentPages = 10
+
+// NewCmdAttachment creates the issue attachment command and its subcommands
+func NewCmdAttachment(streams *iostreams.IOStreams) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "attachment <command>",
+		Short:   "List and download issue attachments",
+		Long:    `List the files attached to an issue and download them.`,
+		Aliases: []string{"attachments"},
+	}
+
+	cmd.AddCommand(NewCmdAttachmentList(streams))
+	cmd.AddCommand(NewCmdAttachmentDownload(streams))
+
+	return cmd
+}
+
+type attachmentListOptions struct {
+	streams *iostreams.IOStreams
+	repo    string
+	jsonOut bool
+}
+
+// NewCmdAttachmentList creates the issue attachment list command
+func NewCmdAttachmentList(streams *iostreams.IOStreams) *cobra.Command {
+	opts := &attachmentListOptions{
+		streams: streams,
+	}
+
+	cmd := &cobra.Command{
+		Use:   "list <issue-id>",
+		Short: "List the files attached to an issue",
+		Example: `  # List attachments of issue #123
+  bb issue attachment list 123
+
+  # Output as JSON
+  bb issue attachment list 123 --json`,
+		Aliases: []string{"ls"},
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runAttachmentList(cmd.Context(), opts, args)
+		},
+	}
+
+	cmd.Flags().BoolVar(&opts.jsonOut, "json", false, "Output in JSON format")
+	cmd.Flags().StringVar(&opts.repo, "repo", "", "Repository in WORKSPACE/REPO format")
+
+	cmd.ValidArgsFunction = cmdutil.CompleteIssueIDs
+	_ = cmd.RegisterFlagCompletionFunc("repo", cmdutil.CompleteRepoNames)
+
+	return cmd
+}
+
+func runAttachmentList(ctx context.Context, opts *attachmentListOptions, args []string) error {
+	issueID, err := parseIssueID(args)
+	if err != nil {
+		return err
+	}
+
+	workspace, repoSlug, err := cmdutil.ParseRepository(opts.repo)
+	if err != nil {
+		return err
+	}
+
+	client, err := cmdutil.GetAPIClient()
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	return printAttachments(ctx, client, opts, workspace, repoSlug, issueID)
+}
+
+// printAttachments lists the names of an issue's attachments
+func printAttachments(ctx context.Context, client *api.Client, opts *attachmentListOptions, workspace, repoSlug string, issueID int) error {
+	attachments, err := listAttachments(ctx, client, workspace, repoSlug, issueID)
+	if err != nil {
+		return attachmentError(err, issueID)
+	}
+
+	if opts.jsonOut {
+		names := make([]map[string]string, len(attachments))
+		for i, a := range attachments {
+			names[i] = map[string]string{"name": a.Name}
+		}
+		return cmdutil.PrintJSON(opts.streams, names)
+	}
+
+	if len(attachments) == 0 {
+		opts.streams.Info("Issue #%d has no attachments", issueID)
+		return nil
+	}
+
+	for _, a := range attachments {
+		fmt.Fprintln(opts.streams.Out, a.Name)
+	}
+	return nil
+}
+
+type attachmentDownloadOptions struct {
+	streams *iostreams.IOStreams
+	repo    string
+	file    string
+	out     string
+}
+
+// NewCmdAttachmentDownload creates the issue attachment download command
+func NewCmdAttachmentDownload(streams *iostreams.IOStreams) *cobra.Command {
+	opts := &attachmentDownloadOptions{
+		streams: streams,
+	}
+
+	cmd := &cobra.Command{
+		Use:   "download <issue-id> --file <name>",
+		Short: "Download a file attached to an issue",
+		Long: `Download a file attached to an issue.
+
+The file is saved under its attachment name in the current directory unless
+--out is given. --out may name a file, an existing directory to save into,
+or "-" for standard output.`,
+		Example: `  # Download an attachment into the current directory
+  bb issue attachment download 123 --file "crash log.txt"
+
+  # Save it somewhere else
+  bb issue attachment download 123 --file screenshot.png --out ~/Downloads
+
+  # Print it to standard output
+  bb issue attachment download 123 --file crash.log --out -`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runAttachmentDownload(cmd.Context(), opts, args)
+		},
+	}
+
+	cmd.Flags().StringVarP(&opts.file, "file", "f", "", "Name of the attachment to download (required)")
+	cmd.Flags().StringVarP(&opts.out, "out", "o", "", "Where to save the file, or - for standard output")
+	cmd.Flags().StringVar(&opts.repo, "repo", "", "Repository in WORKSPACE/REPO format")
+	_ = cmd.MarkFlagRequired("file")
+
+	cmd.ValidArgsFunction = cmdutil.CompleteIssueIDs
+	_ = cmd.RegisterFlagCompletionFunc("repo", cmdutil.CompleteRepoNames)
+
+	return cmd
+}
+
+func runAttachmentDownload(ctx context.Context, opts *attachmentDownloadOptions, args []string) error {
+	issueID, err := parseIssueID(args)
+	if err != nil {
+		return err
+	}
+
+	workspace, repoSlug, err := cmdutil.ParseRepository(opts.repo)
+	if err != nil {
+		return err
+	}
+
+	client, err := cmdutil.GetAPIClient()
+	if err != nil {
+		return err
+	}
+
+	return downloadAttachment(ctx, client, opts, workspace, repoSlug, issueID)
+}
+
+// downloadAttachment saves an issue attachment where opts.out says. A
+// download that fails partway leaves no file behind.
+func downloadAttachment(ctx context.Context, client *api.Client, opts *attachmentDownloadOptions, workspace, repoSlug string, issueID int) error {
+	opts.streams.Verbose("Downloading %s from issue #%d...", opts.file, issueID)
+	content, err := client.GetIssueAttachment(ctx, workspace, repoSlug, issueID, opts.file)
+	if err != nil {
+		return missingAttachmentError(ctx, client, err, workspace, repoSlug, issueID, opts.file)
+	}
+	defer content.Close()
+
+	out, err := cmdutil.OutputWriter(opts.streams, attachmentOutputPath(opts.out, opts.file))
+	if err != nil {
+		return err
+	}
+	defer out.Abort()
+
+	if _, err := io.Copy(out, content); err != nil {
+		return fmt.Errorf("failed to download %s: %w", opts.file, err)
+	}
+	return out.Close()
+}
+
+// attachmentOutputPath returns where to save an attachment: its own name
+// in the current directory, inside out if it is a directory, or out itself
+func attachmentOutputPath(out, name string) string {
+	base := filepath.Base(filepath.FromSlash(name))
+	if out == "" {
+		return base
+	}
+	if info, err := os.Stat(out); err == nil && info.IsDir() {
+		return filepath.Join(out, base)
+	}
+	return out
+}
+
+// listAttachments fetches every attachment of an issue
+func listAttachments(ctx context.Context, client *api.Client, workspace, repoSlug string, issueID int) ([]api.IssueAttachment, error) {
+	var attachments []api.IssueAttachment
+	page, err := client.ListIssueAttachments(ctx, workspace, repoSlug, issueID)
+	for pages := 1; page != nil && err == nil; pages++ {
+		attachments = append(attachments, page.Values...)
+		if pages >= maxAttachmentPages {
+			break
+		}
+		page, err = api.NextPage(ctx, client, page)
+	}
+	return attachments, err
+}
+
+// missingAttachmentError explains a failed download. A 404 may mean the
+// issue or the attachment is missing, so the attachments are listed to tell
+// which and to suggest the names that do exist.
+func missingAttachmentError(ctx context.Context, client *api.Client, err error, workspace, repoSlug string, issueID int, name string) error {
+	var apiErr *api.APIError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusNotFound {
+		return fmt.Errorf("failed to download %s: %w", name, err)
+	}
+
+	attachments, listErr := listAttachments(ctx, client, workspace, repoSlug, issueID)
+	if listErr != nil {
+		return attachmentError(listErr, issueID)
+	}
+	if len(attachments) == 0 {
+		return fmt.Errorf("issue #%d has no attachments: %w", issueID, err)
+	}
+	names := make([]string, len(attachments))
+	for i, a := range attachments {
+		names[i] = fmt.Sprintf("%q", a.Name)
+	}
+	return fmt.Errorf("issue #%d has no attachment named %q (available: %s): %w", issueID, name, strings.Join(names, ", "), err)
+}
+
+// attachmentError explains a failure to list an issue's attachments
+func attachmentError(err error, issueID int) error {
+	var apiErr *api.APIError
+	if errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusNotFound {
+		return fmt.Errorf("issue #%d not found: %w", issueID, err)
+	}
+	return fmt.Errorf("failed to list attachments: %w", err)
+}
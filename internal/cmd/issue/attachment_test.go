@@ -0,0 +1,118 @@
+package issue
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/rbansal42/bitbucket-cli/internal/api"
+	"github.com/rbansal42/bitbucket-cli/internal/iostreams"
+)
+
+const attachmentsPath = "/repositories/ws/repo/issues/7/attachments"
+
+// newAttachmentTestClient serves the attachments of issue 7: a list of
+// "notes.txt" and "crash log.txt", the content of notes.txt, and a download
+// of "broken.bin" that ends early
+func newAttachmentTestClient(t *testing.T) *api.Client {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case attachmentsPath:
+			fmt.Fprint(w, `{"values": [{"name": "notes.txt"}, {"name": "crash log.txt"}]}`)
+		case attachmentsPath + "/notes.txt":
+			fmt.Fprint(w, "remember the milk\n")
+		case attachmentsPath + "/broken.bin":
+			w.Header().Set("Content-Length", "100")
+			fmt.Fprint(w, "short")
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(server.Close)
+	return api.NewClient(api.WithBaseURL(server.URL), api.WithToken("test-token"))
+}
+
+func TestPrintAttachments(t *testing.T) {
+	client := newAttachmentTestClient(t)
+
+	out := &bytes.Buffer{}
+	opts := &attachmentListOptions{streams: &iostreams.IOStreams{Out: out, ErrOut: &bytes.Buffer{}}}
+	if err := printAttachments(context.Background(), client, opts, "ws", "repo", 7); err != nil {
+		t.Fatalf("printAttachments() error: %v", err)
+	}
+	if out.String() != "notes.txt\ncrash log.txt\n" {
+		t.Errorf("output = %q, want one name per line", out.String())
+	}
+
+	out.Reset()
+	opts.jsonOut = true
+	if err := printAttachments(context.Background(), client, opts, "ws", "repo", 7); err != nil {
+		t.Fatalf("printAttachments() error: %v", err)
+	}
+	if !strings.Contains(out.String(), `"name": "crash log.txt"`) {
+		t.Errorf("JSON output = %s, want the attachment names", out.String())
+	}
+
+	err := printAttachments(context.Background(), client, opts, "ws", "repo", 8)
+	if err == nil || !strings.Contains(err.Error(), "issue #8 not found") {
+		t.Errorf("printAttachments() for a missing issue error = %v, want issue #8 not found", err)
+	}
+}
+
+func TestDownloadAttachment(t *testing.T) {
+	client := newAttachmentTestClient(t)
+	dir := t.TempDir()
+
+	out := &bytes.Buffer{}
+	opts := &attachmentDownloadOptions{
+		streams: &iostreams.IOStreams{Out: out, ErrOut: out},
+		file:    "notes.txt",
+		out:     dir,
+	}
+	if err := downloadAttachment(context.Background(), client, opts, "ws", "repo", 7); err != nil {
+		t.Fatalf("downloadAttachment() error: %v", err)
+	}
+	data, err := os.ReadFile(filepath.Join(dir, "notes.txt"))
+	if err != nil || string(data) != "remember the milk\n" {
+		t.Errorf("saved file = %q, %v, want the attachment content", data, err)
+	}
+	if !strings.Contains(out.String(), "Wrote 18 bytes") {
+		t.Errorf("output = %q, want the bytes written", out.String())
+	}
+
+	// A missing attachment lists the ones that exist
+	opts.file = "missing.txt"
+	err = downloadAttachment(context.Background(), client, opts, "ws", "repo", 7)
+	if err == nil || !strings.Contains(err.Error(), `available: "notes.txt", "crash log.txt"`) {
+		t.Errorf("downloadAttachment() error = %v, want the available attachments", err)
+	}
+}
+
+func TestDownloadAttachment_Interrupted(t *testing.T) {
+	client := newAttachmentTestClient(t)
+	dir := t.TempDir()
+
+	out := &bytes.Buffer{}
+	opts := &attachmentDownloadOptions{
+		streams: &iostreams.IOStreams{Out: out, ErrOut: out},
+		file:    "broken.bin",
+		out:     dir,
+	}
+	err := downloadAttachment(context.Background(), client, opts, "ws", "repo", 7)
+	if err == nil || !strings.Contains(err.Error(), "failed to download broken.bin") {
+		t.Errorf("downloadAttachment() error = %v, want a download failure", err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "broken.bin")); !os.IsNotExist(err) {
+		t.Errorf("partly downloaded file was left behind: %v", err)
+	}
+	if strings.Contains(out.String(), "Wrote") {
+		t.Errorf("output = %q, want no report of a written file", out.String())
+	}
+}
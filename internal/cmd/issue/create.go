@@ -36,7 +36,10 @@ func NewCmdCreate(streams *iostreams.IOStreams) *cobra.Command {
 		Long: `Create a new issue in a Bitbucket repository.
 
 If --title is not provided and stdin is a TTY, you will be prompted
-to enter a title interactively.`,
+to enter a title interactively. Without --body, your editor is opened to
+write the description; save an empty file to leave it blank.
+
+The issue tracker must be enabled on the repository.`,
 		Example: `  # Create an issue interactively
   bb issue create
 
@@ -63,12 +66,8 @@ to enter a title interactively.`,
 	cmd.Flags().StringVarP(&opts.assignee, "assignee", "a", "", "Assignee username")
 	cmd.Flags().StringVar(&opts.repo, "repo", "", "Repository in WORKSPACE/REPO format")
 
-	_ = cmd.RegisterFlagCompletionFunc("kind", cmdutil.StaticFlagCompletion([]string{
-		"bug", "enhancement", "proposal", "task",
-	}))
-	_ = cmd.RegisterFlagCompletionFunc("priority", cmdutil.StaticFlagCompletion([]string{
-		"trivial", "minor", "major", "critical", "blocker",
-	}))
+	_ = cmd.RegisterFlagCompletionFunc("kind", cmdutil.StaticFlagCompletion(issueKinds))
+	_ = cmd.RegisterFlagCompletionFunc("priority", cmdutil.StaticFlagCompletion(issuePriorities))
 	_ = cmd.RegisterFlagCompletionFunc("assignee", cmdutil.CompleteWorkspaceMembers)
 	_ = cmd.RegisterFlagCompletionFunc("repo", cmdutil.CompleteRepoNames)
 
@@ -76,6 +75,14 @@ to enter a title interactively.`,
 }
 
 func runCreate(opts *createOptions) error {
+	// Validate before prompting so mistakes don't cost the user their input
+	if err := validateIssueKind(opts.kind); err != nil {
+		return err
+	}
+	if err := validateIssuePriority(opts.priority); err != nil {
+		return err
+	}
+
 	// Resolve repository
 	workspace, repoSlug, err := cmdutil.ParseRepository(opts.repo)
 	if err != nil {
@@ -88,16 +95,22 @@ func runCreate(opts *createOptions) error {
 		return err
 	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
-	defer cancel()
+	// Resolve the assignee before prompting too, so an unknown user is
+	// reported before the title and body are typed
+	var assignee *api.User
+	if opts.assignee != "" {
+		resolveCtx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		uuid, err := cmdutil.UserResolverFor(client, workspace).Resolve(resolveCtx, opts.assignee)
+		cancel()
+		if err != nil {
+			return fmt.Errorf("could not resolve assignee %q: %w", opts.assignee, err)
+		}
+		assignee = &api.User{UUID: uuid}
+	}
 
 	// Interactive mode: prompt for title if not provided
 	if opts.title == "" {
-		if !opts.streams.IsStdinTTY() {
-			return fmt.Errorf("--title flag is required when not running interactively")
-		}
-
-		title, err := promptForTitle(opts.streams)
+		title, err := cmdutil.Prompt(opts.streams, "Title: ", "pass --title")
 		if err != nil {
 			return err
 		}
@@ -107,16 +120,14 @@ func runCreate(opts *createOptions) error {
 		opts.title = title
 	}
 
-	// Validate kind
-	validKinds := map[string]bool{"bug": true, "enhancement": true, "proposal": true, "task": true}
-	if !validKinds[opts.kind] {
-		return fmt.Errorf("invalid kind %q: must be one of bug, enhancement, proposal, task", opts.kind)
-	}
-
-	// Validate priority
-	validPriorities := map[string]bool{"trivial": true, "minor": true, "major": true, "critical": true, "blocker": true}
-	if !validPriorities[opts.priority] {
-		return fmt.Errorf("invalid priority %q: must be one of trivial, minor, major, critical, blocker", opts.priority)
+	// Interactive mode: open editor for body if not provided
+	if opts.body == "" && opts.streams.CanPrompt() {
+		body, err := cmdutil.OpenEditor("")
+		if err != nil {
+			opts.streams.Warning("Could not open editor: %v", err)
+		} else {
+			opts.body = body
+		}
 	}
 
 	// Build create options
@@ -124,20 +135,17 @@ func runCreate(opts *createOptions) error {
 		Title:    opts.title,
 		Kind:     opts.kind,
 		Priority: opts.priority,
+		Assignee: assignee,
 	}
 
 	if opts.body != "" {
 		createOpts.Content = &api.Content{Raw: opts.body}
 	}
 
-	// Resolve assignee if provided
-	if opts.assignee != "" {
-		uuid, err := resolveUserUUID(ctx, client, workspace, opts.assignee)
-		if err != nil {
-			return fmt.Errorf("could not resolve assignee %q: %w", opts.assignee, err)
-		}
-		createOpts.Assignee = &api.User{UUID: uuid}
-	}
+	// The prompts are outside the request timeout, as the editor can stay
+	// open for a while
+	ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
+	defer cancel()
 
 	opts.streams.Info("Creating issue in %s/%s...", workspace, repoSlug)
 
@@ -68,14 +68,12 @@ func runDelete(opts *deleteOptions, args []string) error {
 
 	// If not auto-confirmed, show warning and prompt
 	if !opts.yes {
-		// Require TTY for interactive confirmation
-		if !opts.streams.IsStdinTTY() {
-			return fmt.Errorf("cannot confirm deletion: stdin is not a terminal\nUse --yes flag to skip confirmation in non-interactive mode")
+		question := fmt.Sprintf("Are you sure you want to delete issue #%d?", issueID)
+		confirmed, err := cmdutil.Confirm(opts.streams, question, false, "pass --yes to skip confirmation")
+		if err != nil {
+			return err
 		}
-
-		fmt.Fprintf(opts.streams.Out, "Are you sure you want to delete issue #%d? [y/N] ", issueID)
-
-		if !confirmPrompt(opts.streams.In) {
+		if !confirmed {
 			return fmt.Errorf("deletion cancelled")
 		}
 	}
@@ -88,12 +88,8 @@ Use an empty string for --assignee to clear the assignee.`,
 	cmd.Flags().StringVar(&opts.repo, "repo", "", "Repository in WORKSPACE/REPO format")
 
 	cmd.ValidArgsFunction = cmdutil.CompleteIssueIDs
-	_ = cmd.RegisterFlagCompletionFunc("kind", cmdutil.StaticFlagCompletion([]string{
-		"bug", "enhancement", "proposal", "task",
-	}))
-	_ = cmd.RegisterFlagCompletionFunc("priority", cmdutil.StaticFlagCompletion([]string{
-		"trivial", "minor", "major", "critical", "blocker",
-	}))
+	_ = cmd.RegisterFlagCompletionFunc("kind", cmdutil.StaticFlagCompletion(issueKinds))
+	_ = cmd.RegisterFlagCompletionFunc("priority", cmdutil.StaticFlagCompletion(issuePriorities))
 	_ = cmd.RegisterFlagCompletionFunc("assignee", cmdutil.CompleteWorkspaceMembers)
 	_ = cmd.RegisterFlagCompletionFunc("repo", cmdutil.CompleteRepoNames)
 
@@ -133,19 +129,15 @@ func runEdit(opts *editOptions) error {
 	}
 
 	if opts.kindSet {
-		// Validate kind
-		validKinds := map[string]bool{"bug": true, "enhancement": true, "proposal": true, "task": true}
-		if !validKinds[opts.kind] {
-			return fmt.Errorf("invalid kind %q: must be one of bug, enhancement, proposal, task", opts.kind)
+		if err := validateIssueKind(opts.kind); err != nil {
+			return err
 		}
 		updateOpts.Kind = &opts.kind
 	}
 
 	if opts.prioritySet {
-		// Validate priority
-		validPriorities := map[string]bool{"trivial": true, "minor": true, "major": true, "critical": true, "blocker": true}
-		if !validPriorities[opts.priority] {
-			return fmt.Errorf("invalid priority %q: must be one of trivial, minor, major, critical, blocker", opts.priority)
+		if err := validateIssuePriority(opts.priority); err != nil {
+			return err
 		}
 		updateOpts.Priority = &opts.priority
 	}
@@ -156,7 +148,7 @@ func runEdit(opts *editOptions) error {
 			updateOpts.Assignee = &api.User{}
 		} else {
 			// Resolve assignee username to UUID
-			uuid, err := resolveUserUUID(ctx, client, workspace, opts.assignee)
+			uuid, err := cmdutil.UserResolverFor(client, workspace).Resolve(ctx, opts.assignee)
 			if err != nil {
 				return fmt.Errorf("could not resolve assignee %q: %w", opts.assignee, err)
 			}
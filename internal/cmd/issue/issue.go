@@ -39,8 +39,10 @@ their lifecycle.`,
 	cmd.AddCommand(NewCmdEdit(streams))
 	cmd.AddCommand(NewCmdComment(streams))
 	cmd.AddCommand(NewCmdClose(streams))
+	cmd.AddCommand(NewCmdResolve(streams))
 	cmd.AddCommand(NewCmdReopen(streams))
 	cmd.AddCommand(NewCmdDelete(streams))
+	cmd.AddCommand(NewCmdAttachment(streams))
 
 	return cmd
 }
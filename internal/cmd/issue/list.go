@@ -3,7 +3,7 @@ package issue
 import (
 	"context"
 	"fmt"
-	"text/tabwriter"
+	"net/url"
 
 	"github.com/spf13/cobra"
 
@@ -14,14 +14,19 @@ import (
 
 // ListOptions holds the options for the list command
 type ListOptions struct {
-	State    string
-	Kind     string
-	Priority string
-	Assignee string
-	Limit    int
-	JSON     bool
-	Repo     string
-	Streams  *iostreams.IOStreams
+	State     string
+	Kind      string
+	Priority  string
+	Assignee  string
+	Limit     int
+	JSON      bool
+	Format    cmdutil.ListFormat
+	ShowCount bool
+	ExitCode  bool
+	Repo      string
+	Web       bool
+	NoBrowser bool
+	Streams   *iostreams.IOStreams
 }
 
 // NewCmdList creates the issue list command
@@ -36,7 +41,13 @@ func NewCmdList(streams *iostreams.IOStreams) *cobra.Command {
 		Long: `List issues in a Bitbucket repository.
 
 By default, this shows all issues. Use flags to filter by state, kind,
-priority, or assignee.`,
+priority, or assignee. --assignee accepts @me for the logged-in user.
+
+--web opens the issue page of the repository in your browser instead,
+without calling the API. --state, --kind and --priority are applied to the
+page, and so is --assignee when it is an account UUID; the web UI can't
+filter by username. The URL is printed instead when --no-browser is given
+or the output is not a terminal.`,
 		Example: `  # List all issues
   bb issue list
 
@@ -52,14 +63,26 @@ priority, or assignee.`,
   # List issues assigned to a user
   bb issue list --assignee johndoe
 
+  # List issues assigned to you
+  bb issue list --assignee @me
+
   # Limit results
   bb issue list --limit 10
 
   # Output as JSON
   bb issue list --json
 
+  # Export open bugs as CSV
+  bb issue list --state open --kind bug --limit 200 --format csv
+
+  # Print one line per issue with a template
+  bb issue list --template '{{.id}} {{.title}} ({{.assignee}})'
+
   # List issues in a specific repository
-  bb issue list --repo workspace/repo`,
+  bb issue list --repo workspace/repo
+
+  # Open the open bugs in the browser
+  bb issue list --state open --kind bug --web`,
 		Aliases: []string{"ls"},
 		RunE: func(cmd *cobra.Command, args []string) error {
 			return runList(cmd.Context(), opts)
@@ -69,10 +92,17 @@ priority, or assignee.`,
 	cmd.Flags().StringVarP(&opts.State, "state", "s", "", "Filter by state (new, open, resolved, on hold, invalid, duplicate, wontfix, closed)")
 	cmd.Flags().StringVarP(&opts.Kind, "kind", "k", "", "Filter by kind (bug, enhancement, proposal, task)")
 	cmd.Flags().StringVarP(&opts.Priority, "priority", "p", "", "Filter by priority (trivial, minor, major, critical, blocker)")
-	cmd.Flags().StringVarP(&opts.Assignee, "assignee", "a", "", "Filter by assignee username")
+	cmd.Flags().StringVarP(&opts.Assignee, "assignee", "a", "", "Filter by assignee username, or @me")
 	cmd.Flags().IntVarP(&opts.Limit, "limit", "l", 30, "Maximum number of issues to list")
 	cmd.Flags().BoolVar(&opts.JSON, "json", false, "Output in JSON format")
+	cmdutil.AddListFormatFlags(cmd, &opts.Format)
+	cmdutil.AddShowCountFlag(cmd, &opts.ShowCount)
+	cmdutil.AddAllFlag(cmd, &opts.Limit)
+	cmdutil.AddExitCodeFlag(cmd, &opts.ExitCode)
 	cmd.Flags().StringVar(&opts.Repo, "repo", "", "Repository in WORKSPACE/REPO format")
+	cmd.Flags().BoolVarP(&opts.Web, "web", "w", false, "Open the issue list in the browser")
+	cmd.Flags().BoolVar(&opts.NoBrowser, "no-browser", false, "With --web, print the URL instead of opening the browser")
+	cmd.MarkFlagsMutuallyExclusive("web", "json")
 
 	// NOTE: "on hold" contains a space, which is the canonical Bitbucket API value
 	// (confirmed in the OpenAPI spec enum). Cobra handles quoting automatically for
@@ -81,19 +111,25 @@ priority, or assignee.`,
 	_ = cmd.RegisterFlagCompletionFunc("state", cmdutil.StaticFlagCompletion([]string{
 		"new", "open", "resolved", "on hold", "invalid", "duplicate", "wontfix", "closed",
 	}))
-	_ = cmd.RegisterFlagCompletionFunc("kind", cmdutil.StaticFlagCompletion([]string{
-		"bug", "enhancement", "proposal", "task",
-	}))
-	_ = cmd.RegisterFlagCompletionFunc("priority", cmdutil.StaticFlagCompletion([]string{
-		"trivial", "minor", "major", "critical", "blocker",
-	}))
+	_ = cmd.RegisterFlagCompletionFunc("kind", cmdutil.StaticFlagCompletion(issueKinds))
+	_ = cmd.RegisterFlagCompletionFunc("priority", cmdutil.StaticFlagCompletion(issuePriorities))
 	_ = cmd.RegisterFlagCompletionFunc("assignee", cmdutil.CompleteWorkspaceMembers)
 	_ = cmd.RegisterFlagCompletionFunc("repo", cmdutil.CompleteRepoNames)
 
 	return cmd
 }
 
+// issueListFields are the columns of --format csv and tsv, in order
+var issueListFields = []string{"id", "title", "state", "kind", "priority", "assignee", "reporter", "votes", "created_on", "updated_on", "url"}
+
 func runList(ctx context.Context, opts *ListOptions) error {
+	if err := opts.Format.Validate(opts.JSON); err != nil {
+		return err
+	}
+	if opts.Web {
+		return openListWeb(opts)
+	}
+
 	// Get API client
 	client, err := cmdutil.GetAPIClient()
 	if err != nil {
@@ -106,36 +142,62 @@ func runList(ctx context.Context, opts *ListOptions) error {
 		return err
 	}
 
+	assignee, err := cmdutil.ResolvePrincipal(ctx, client, opts.Assignee)
+	if err != nil {
+		return err
+	}
+
 	// Build list options
 	listOpts := &api.IssueListOptions{
 		State:    opts.State,
 		Kind:     opts.Kind,
 		Priority: opts.Priority,
-		Assignee: opts.Assignee,
-		Limit:    opts.Limit,
+		Assignee: assignee,
+		Limit:    cmdutil.PageLen(opts.Limit, cmdutil.MaxPageLen),
 	}
 
 	// Fetch issues
+	opts.Streams.Verbose("Fetching issues for %s/%s...", workspace, repoSlug)
 	result, err := client.ListIssues(ctx, workspace, repoSlug, listOpts)
+	if err == nil {
+		result, err = cmdutil.CollectPages(ctx, client, result, opts.Limit)
+	}
 	if err != nil {
 		return fmt.Errorf("failed to list issues: %w", err)
 	}
 
 	if len(result.Values) == 0 {
 		opts.Streams.Info("No issues found in %s/%s", workspace, repoSlug)
-		return nil
+		return cmdutil.NoResults(opts.ExitCode)
+	}
+
+	var count *cmdutil.PageCount
+	if opts.ShowCount {
+		count = cmdutil.NewPageCount(result, len(result.Values))
 	}
 
 	// Output results
 	if opts.JSON {
-		return outputListJSON(opts.Streams, result.Values)
+		return outputListJSON(opts.Streams, result.Values, count)
+	}
+	if opts.Format.Enabled() {
+		return opts.Format.Print(opts.Streams, issueListFields, issueListJSON(result.Values))
 	}
 
-	return outputIssueTable(opts.Streams, result.Values)
+	if err := outputIssueTable(opts.Streams, result.Values); err != nil {
+		return err
+	}
+	cmdutil.PrintPageCount(opts.Streams, count)
+	return nil
 }
 
-func outputListJSON(streams *iostreams.IOStreams, issues []api.Issue) error {
-	// Create simplified JSON output
+func outputListJSON(streams *iostreams.IOStreams, issues []api.Issue, count *cmdutil.PageCount) error {
+	return cmdutil.PrintListJSON(streams, issueListJSON(issues), count)
+}
+
+// issueListJSON returns the simplified form of issues shared by --json,
+// --format and --template
+func issueListJSON(issues []api.Issue) []map[string]interface{} {
 	output := make([]map[string]interface{}, len(issues))
 	for i, issue := range issues {
 		output[i] = map[string]interface{}{
@@ -154,30 +216,53 @@ func outputListJSON(streams *iostreams.IOStreams, issues []api.Issue) error {
 			output[i]["url"] = issue.Links.HTML.Href
 		}
 	}
-
-	return cmdutil.PrintJSON(streams, output)
+	return output
 }
 
 func outputIssueTable(streams *iostreams.IOStreams, issues []api.Issue) error {
-	w := tabwriter.NewWriter(streams.Out, 0, 0, 2, ' ', 0)
+	t := cmdutil.NewTableWriter(streams, "#", "TITLE", "STATE", "KIND", "PRIORITY", "ASSIGNEE", "UPDATED")
+	t.SetFlexColumn(1)
+	t.SetMaxWidth(5, 15)
 
-	// Print header
-	header := "#\tTITLE\tSTATE\tKIND\tPRIORITY\tASSIGNEE\tUPDATED"
-	cmdutil.PrintTableHeader(streams, w, header)
-
-	// Print rows
 	for _, issue := range issues {
 		id := fmt.Sprintf("%d", issue.ID)
-		title := cmdutil.TruncateString(issue.Title, 40)
-		state := formatIssueState(streams, issue.State)
+		state := cmdutil.ColorState(streams, issue.State)
 		kind := formatIssueKind(streams, issue.Kind)
 		priority := formatIssuePriority(streams, issue.Priority)
-		assignee := cmdutil.TruncateString(cmdutil.GetUserDisplayName(issue.Assignee), 15)
+		assignee := cmdutil.GetUserDisplayName(issue.Assignee)
 		updated := cmdutil.TimeAgo(issue.UpdatedOn)
 
-		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\t%s\n",
-			id, title, state, kind, priority, assignee, updated)
+		t.AddRow(id, issue.Title, state, kind, priority, assignee, updated)
+	}
+
+	return t.Render()
+}
+
+// openListWeb opens the issue page of the repository with the filters the
+// web UI understands
+func openListWeb(opts *ListOptions) error {
+	workspace, repoSlug, err := cmdutil.ParseRepository(opts.Repo)
+	if err != nil {
+		return err
+	}
+
+	query := url.Values{}
+	if opts.State != "" {
+		query.Set("status", opts.State)
+	}
+	if opts.Kind != "" {
+		query.Set("kind", opts.Kind)
+	}
+	if opts.Priority != "" {
+		query.Set("priority", opts.Priority)
+	}
+	if opts.Assignee != "" {
+		if cmdutil.IsUUID(opts.Assignee) {
+			query.Set("responsible", opts.Assignee)
+		} else {
+			opts.Streams.Warning("The web UI can only filter by an account UUID; ignoring --assignee %s", opts.Assignee)
+		}
 	}
 
-	return w.Flush()
+	return cmdutil.OpenWeb(opts.Streams, cmdutil.WebURL(query, workspace, repoSlug, "issues"), opts.NoBrowser)
 }
@@ -0,0 +1,89 @@
+package issue
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/rbansal42/bitbucket-cli/internal/api"
+	"github.com/rbansal42/bitbucket-cli/internal/iostreams"
+)
+
+func TestOutputListJSON(t *testing.T) {
+	issues := []api.Issue{
+		{
+			ID:        7,
+			Title:     "Crash on login",
+			State:     "open",
+			Kind:      "bug",
+			Priority:  "critical",
+			Assignee:  &api.User{DisplayName: "Alice"},
+			Reporter:  &api.User{DisplayName: "Bob"},
+			CreatedOn: time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC),
+			Links:     &api.IssueLinks{HTML: &api.Link{Href: "https://bitbucket.org/ws/repo/issues/7"}},
+		},
+	}
+
+	out := &bytes.Buffer{}
+	streams := &iostreams.IOStreams{Out: out, ErrOut: &bytes.Buffer{}}
+	if err := outputListJSON(streams, issues, nil); err != nil {
+		t.Fatalf("outputListJSON() error: %v", err)
+	}
+
+	var got []map[string]any
+	if err := json.Unmarshal(out.Bytes(), &got); err != nil {
+		t.Fatalf("output is not a JSON array: %v\n%s", err, out.String())
+	}
+	if len(got) != 1 {
+		t.Fatalf("got %d issues, want 1", len(got))
+	}
+
+	want := map[string]any{
+		"id":       float64(7),
+		"title":    "Crash on login",
+		"state":    "open",
+		"kind":     "bug",
+		"priority": "critical",
+		"assignee": "Alice",
+		"reporter": "Bob",
+		"url":      "https://bitbucket.org/ws/repo/issues/7",
+	}
+	for field, value := range want {
+		if got[0][field] != value {
+			t.Errorf("%s = %v, want %v", field, got[0][field], value)
+		}
+	}
+
+	// Every CSV and TSV column is a --json field
+	for _, field := range issueListFields {
+		if _, ok := got[0][field]; !ok {
+			t.Errorf("--format column %q is missing from --json output", field)
+		}
+	}
+}
+
+func TestOpenListWeb(t *testing.T) {
+	out := &bytes.Buffer{}
+	errOut := &bytes.Buffer{}
+	opts := &ListOptions{
+		Repo:     "ws/repo",
+		State:    "on hold",
+		Kind:     "bug",
+		Assignee: "alice",
+		Streams:  &iostreams.IOStreams{Out: out, ErrOut: errOut},
+	}
+	if err := openListWeb(opts); err != nil {
+		t.Fatalf("openListWeb() error: %v", err)
+	}
+
+	// Output that is not a terminal gets the URL printed
+	want := "https://bitbucket.org/ws/repo/issues?kind=bug&status=on+hold\n"
+	if out.String() != want {
+		t.Errorf("output = %q, want %q", out.String(), want)
+	}
+	if !strings.Contains(errOut.String(), "--assignee alice") {
+		t.Errorf("expected a warning about the ignored assignee, got %q", errOut.String())
+	}
+}
@@ -1,25 +1,15 @@
 package issue
 
 import (
-	"context"
-	"fmt"
-	"time"
-
 	"github.com/spf13/cobra"
 
-	"github.com/rbansal42/bitbucket-cli/internal/api"
 	"github.com/rbansal42/bitbucket-cli/internal/cmdutil"
 	"github.com/rbansal42/bitbucket-cli/internal/iostreams"
 )
 
-type reopenOptions struct {
-	streams *iostreams.IOStreams
-	repo    string
-}
-
 // NewCmdReopen creates the reopen command
 func NewCmdReopen(streams *iostreams.IOStreams) *cobra.Command {
-	opts := &reopenOptions{
+	opts := &transitionOptions{
 		streams: streams,
 	}
 
@@ -33,14 +23,18 @@ additional work is needed.`,
 		Example: `  # Reopen issue #42
   bb issue reopen 42
 
+  # Reopen with a comment
+  bb issue reopen 42 --comment "Still happens on Windows"
+
   # Reopen an issue in a specific repository
   bb issue reopen 42 --repo workspace/repo`,
 		Args: cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return runReopen(opts, args)
+			return runTransition(opts, args, "open", "reopen")
 		},
 	}
 
+	cmd.Flags().StringVarP(&opts.comment, "comment", "c", "", "Add a comment explaining why the issue is reopened")
 	cmd.Flags().StringVar(&opts.repo, "repo", "", "Repository in WORKSPACE/REPO format")
 
 	cmd.ValidArgsFunction = cmdutil.CompleteIssueIDs
@@ -48,37 +42,3 @@ additional work is needed.`,
 
 	return cmd
 }
-
-func runReopen(opts *reopenOptions, args []string) error {
-	issueID, err := parseIssueID(args)
-	if err != nil {
-		return err
-	}
-
-	workspace, repoSlug, err := cmdutil.ParseRepository(opts.repo)
-	if err != nil {
-		return err
-	}
-
-	client, err := cmdutil.GetAPIClient()
-	if err != nil {
-		return err
-	}
-
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
-	defer cancel()
-
-	// Update issue state to open
-	state := "open"
-	updateOpts := &api.IssueUpdateOptions{
-		State: &state,
-	}
-
-	_, err = client.UpdateIssue(ctx, workspace, repoSlug, issueID, updateOpts)
-	if err != nil {
-		return fmt.Errorf("failed to reopen issue: %w", err)
-	}
-
-	opts.streams.Success("Reopened issue #%d", issueID)
-	return nil
-}
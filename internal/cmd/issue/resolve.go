@@ -0,0 +1,75 @@
+package issue
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/rbansal42/bitbucket-cli/internal/cmdutil"
+	"github.com/rbansal42/bitbucket-cli/internal/iostreams"
+)
+
+// resolutionStates are the states 'bb issue resolve --as' accepts besides the
+// default of resolved
+var resolutionStates = []string{"duplicate", "wontfix"}
+
+type resolveOptions struct {
+	transitionOptions
+	as string
+}
+
+// NewCmdResolve creates the resolve command
+func NewCmdResolve(streams *iostreams.IOStreams) *cobra.Command {
+	opts := &resolveOptions{
+		transitionOptions: transitionOptions{streams: streams},
+	}
+
+	cmd := &cobra.Command{
+		Use:   "resolve <issue-id>",
+		Short: "Resolve an issue",
+		Long: `Resolve an issue by setting its state to resolved.
+
+Use --as to record that the issue is a duplicate or won't be fixed instead.
+Optionally, you can add a comment, for example pointing at the fix or the
+original issue.`,
+		Example: `  # Resolve issue #42
+  bb issue resolve 42 --comment "Fixed in commit abc123"
+
+  # Mark an issue as a duplicate
+  bb issue resolve 42 --as duplicate --comment "Duplicate of #8"
+
+  # Mark an issue as won't fix
+  bb issue resolve 42 --as wontfix`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			state, err := resolutionState(opts.as)
+			if err != nil {
+				return err
+			}
+			return runTransition(&opts.transitionOptions, args, state, "resolve")
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.as, "as", "", "Resolve as a different state: duplicate or wontfix")
+	cmd.Flags().StringVarP(&opts.comment, "comment", "c", "", "Add a comment when resolving")
+	cmd.Flags().StringVar(&opts.repo, "repo", "", "Repository in WORKSPACE/REPO format")
+
+	cmd.ValidArgsFunction = cmdutil.CompleteIssueIDs
+	_ = cmd.RegisterFlagCompletionFunc("as", cmdutil.StaticFlagCompletion(resolutionStates))
+	_ = cmd.RegisterFlagCompletionFunc("repo", cmdutil.CompleteRepoNames)
+
+	return cmd
+}
+
+// resolutionState returns the issue state for the --as flag value
+func resolutionState(as string) (string, error) {
+	if as == "" {
+		return "resolved", nil
+	}
+	if !slices.Contains(resolutionStates, as) {
+		return "", fmt.Errorf("invalid --as value %q: must be one of %s", as, strings.Join(resolutionStates, ", "))
+	}
+	return as, nil
+}
@@ -0,0 +1,29 @@
+package issue
+
+import "testing"
+
+func TestResolutionState(t *testing.T) {
+	tests := []struct {
+		as      string
+		want    string
+		wantErr bool
+	}{
+		{as: "", want: "resolved"},
+		{as: "duplicate", want: "duplicate"},
+		{as: "wontfix", want: "wontfix"},
+		{as: "resolved", wantErr: true},
+		{as: "invalid", wantErr: true},
+		{as: "Duplicate", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.as, func(t *testing.T) {
+			got, err := resolutionState(tt.as)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("resolutionState(%q) error = %v, wantErr %v", tt.as, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("resolutionState(%q) = %q, want %q", tt.as, got, tt.want)
+			}
+		})
+	}
+}
@@ -1,19 +1,37 @@
 package issue
 
 import (
-	"bufio"
-	"context"
-	"encoding/json"
 	"fmt"
-	"io"
-	"os"
+	"slices"
 	"strconv"
 	"strings"
 
-	"github.com/rbansal42/bitbucket-cli/internal/api"
 	"github.com/rbansal42/bitbucket-cli/internal/iostreams"
 )
 
+// issueKinds and issuePriorities are the values Bitbucket accepts for an
+// issue's kind and priority, in the order the web UI lists them
+var (
+	issueKinds      = []string{"bug", "enhancement", "proposal", "task"}
+	issuePriorities = []string{"trivial", "minor", "major", "critical", "blocker"}
+)
+
+// validateIssueKind checks kind against issueKinds
+func validateIssueKind(kind string) error {
+	if !slices.Contains(issueKinds, kind) {
+		return fmt.Errorf("invalid kind %q: must be one of %s", kind, strings.Join(issueKinds, ", "))
+	}
+	return nil
+}
+
+// validateIssuePriority checks priority against issuePriorities
+func validateIssuePriority(priority string) error {
+	if !slices.Contains(issuePriorities, priority) {
+		return fmt.Errorf("invalid priority %q: must be one of %s", priority, strings.Join(issuePriorities, ", "))
+	}
+	return nil
+}
+
 // parseIssueID parses an issue ID from args or returns an error
 func parseIssueID(args []string) (int, error) {
 	if len(args) == 0 {
@@ -33,28 +51,6 @@ func parseIssueID(args []string) (int, error) {
 	return issueID, nil
 }
 
-// formatIssueState formats issue state with color
-func formatIssueState(streams *iostreams.IOStreams, state string) string {
-	if !streams.ColorEnabled() {
-		return state
-	}
-
-	switch strings.ToLower(state) {
-	case "new":
-		return iostreams.Cyan + state + iostreams.Reset
-	case "open":
-		return iostreams.Green + state + iostreams.Reset
-	case "resolved", "closed":
-		return iostreams.Magenta + state + iostreams.Reset
-	case "on hold":
-		return iostreams.Yellow + state + iostreams.Reset
-	case "invalid", "duplicate", "wontfix":
-		return iostreams.Red + state + iostreams.Reset
-	default:
-		return state
-	}
-}
-
 // formatIssuePriority formats issue priority with color
 func formatIssuePriority(streams *iostreams.IOStreams, priority string) string {
 	if !streams.ColorEnabled() {
@@ -94,69 +90,3 @@ func formatIssueKind(streams *iostreams.IOStreams, kind string) string {
 		return kind
 	}
 }
-
-// confirmPrompt prompts the user with a yes/no question and returns true if they confirm
-func confirmPrompt(reader io.Reader) bool {
-	scanner := bufio.NewScanner(reader)
-	if scanner.Scan() {
-		input := strings.TrimSpace(strings.ToLower(scanner.Text()))
-		return input == "y" || input == "yes"
-	}
-	return false
-}
-
-// promptForTitle prompts the user to enter a title
-func promptForTitle(streams *iostreams.IOStreams) (string, error) {
-	fmt.Fprint(streams.Out, "Title: ")
-
-	reader := bufio.NewReader(os.Stdin)
-	title, err := reader.ReadString('\n')
-	if err != nil {
-		return "", err
-	}
-
-	return strings.TrimSpace(title), nil
-}
-
-// resolveUserUUID resolves a username to a UUID
-func resolveUserUUID(ctx context.Context, client *api.Client, workspace, username string) (string, error) {
-	// First try to get user directly by username
-	path := fmt.Sprintf("/users/%s", username)
-	resp, err := client.Get(ctx, path, nil)
-	if err == nil {
-		var user struct {
-			UUID string `json:"uuid"`
-		}
-		if parseErr := json.Unmarshal(resp.Body, &user); parseErr == nil && user.UUID != "" {
-			return user.UUID, nil
-		}
-	}
-
-	// Fallback: try workspace members
-	membersPath := fmt.Sprintf("/workspaces/%s/members", workspace)
-	resp, err = client.Get(ctx, membersPath, nil)
-	if err != nil {
-		return "", fmt.Errorf("could not resolve user %q", username)
-	}
-
-	var members struct {
-		Values []struct {
-			User struct {
-				UUID        string `json:"uuid"`
-				Username    string `json:"username"`
-				DisplayName string `json:"display_name"`
-			} `json:"user"`
-		} `json:"values"`
-	}
-	if err := json.Unmarshal(resp.Body, &members); err != nil {
-		return "", fmt.Errorf("could not parse workspace members: %w", err)
-	}
-
-	for _, m := range members.Values {
-		if m.User.Username == username || m.User.DisplayName == username {
-			return m.User.UUID, nil
-		}
-	}
-
-	return "", fmt.Errorf("user %q not found in workspace %q", username, workspace)
-}
@@ -0,0 +1,62 @@
+package issue
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/rbansal42/bitbucket-cli/internal/api"
+	"github.com/rbansal42/bitbucket-cli/internal/cmdutil"
+	"github.com/rbansal42/bitbucket-cli/internal/iostreams"
+)
+
+// transitionOptions are the options shared by the commands that move an
+// issue between states
+type transitionOptions struct {
+	streams *iostreams.IOStreams
+	repo    string
+	comment string
+}
+
+// runTransition moves the issue in args to state, then posts the optional
+// comment. verb is used in messages, e.g. "close" or "reopen".
+func runTransition(opts *transitionOptions, args []string, state, verb string) error {
+	issueID, err := parseIssueID(args)
+	if err != nil {
+		return err
+	}
+
+	workspace, repoSlug, err := cmdutil.ParseRepository(opts.repo)
+	if err != nil {
+		return err
+	}
+
+	client, err := cmdutil.GetAPIClient()
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	return transitionIssue(ctx, client, opts, workspace, repoSlug, issueID, state, verb)
+}
+
+// transitionIssue moves an issue to state and posts the optional comment
+func transitionIssue(ctx context.Context, client *api.Client, opts *transitionOptions, workspace, repoSlug string, issueID int, state, verb string) error {
+	issue, err := client.UpdateIssueState(ctx, workspace, repoSlug, issueID, state)
+	if err != nil {
+		return fmt.Errorf("failed to %s issue: %w", verb, err)
+	}
+
+	// Comment after the transition so a rejected change doesn't leave a
+	// comment behind explaining something that never happened
+	if opts.comment != "" {
+		if _, err := client.CreateIssueComment(ctx, workspace, repoSlug, issueID, opts.comment); err != nil {
+			return fmt.Errorf("issue #%d is now %s, but failed to add comment: %w", issueID, issue.State, err)
+		}
+	}
+
+	opts.streams.Success("Issue #%d is now %s", issueID, issue.State)
+	return nil
+}
@@ -0,0 +1,78 @@
+package issue
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/rbansal42/bitbucket-cli/internal/api"
+	"github.com/rbansal42/bitbucket-cli/internal/iostreams"
+)
+
+func TestRunTransitionInvalidIssueID(t *testing.T) {
+	opts := &transitionOptions{streams: &iostreams.IOStreams{Out: &bytes.Buffer{}, ErrOut: &bytes.Buffer{}}}
+	for _, args := range [][]string{{"abc"}, {"0"}, {"-3"}} {
+		if err := runTransition(opts, args, "resolved", "resolve"); err == nil || !strings.Contains(err.Error(), "invalid issue ID") {
+			t.Errorf("runTransition(%q) error = %v, want an invalid issue ID error", args, err)
+		}
+	}
+}
+
+func TestTransitionIssue(t *testing.T) {
+	tests := []struct {
+		name        string
+		comment     string
+		stateStatus int
+		commentCode int
+		wantErr     string
+		wantComment bool
+	}{
+		{name: "success", comment: "Fixed", stateStatus: http.StatusOK, commentCode: http.StatusCreated, wantComment: true},
+		{name: "no comment", stateStatus: http.StatusOK},
+		{name: "state rejected", comment: "Fixed", stateStatus: http.StatusForbidden, wantErr: "failed to resolve issue"},
+		{name: "comment rejected", comment: "Fixed", stateStatus: http.StatusOK, commentCode: http.StatusBadRequest, wantErr: "issue #7 is now resolved, but failed to add comment", wantComment: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			commented := false
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				switch {
+				case r.Method == http.MethodPut && r.URL.Path == "/repositories/ws/repo/issues/7":
+					w.WriteHeader(tt.stateStatus)
+					fmt.Fprint(w, `{"id": 7, "state": "resolved"}`)
+				case r.Method == http.MethodPost && r.URL.Path == "/repositories/ws/repo/issues/7/comments":
+					commented = true
+					w.WriteHeader(tt.commentCode)
+					fmt.Fprint(w, `{"id": 1}`)
+				default:
+					t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+				}
+			}))
+			defer server.Close()
+
+			client := api.NewClient(api.WithBaseURL(server.URL), api.WithToken("test-token"))
+			out := &bytes.Buffer{}
+			opts := &transitionOptions{streams: &iostreams.IOStreams{Out: out, ErrOut: &bytes.Buffer{}}, comment: tt.comment}
+
+			err := transitionIssue(context.Background(), client, opts, "ws", "repo", 7, "resolved", "resolve")
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("transitionIssue() error: %v", err)
+				}
+				if !strings.Contains(out.String(), "Issue #7 is now resolved") {
+					t.Errorf("output = %q, want a success message", out.String())
+				}
+			} else if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("transitionIssue() error = %v, want %q", err, tt.wantErr)
+			}
+			if commented != tt.wantComment {
+				t.Errorf("comment posted = %v, want %v", commented, tt.wantComment)
+			}
+		})
+	}
+}
@@ -88,6 +88,7 @@ func runView(opts *viewOptions, args []string) error {
 	defer cancel()
 
 	// Fetch issue details
+	opts.streams.Verbose("Fetching issue #%d...", issueID)
 	issue, err := client.GetIssue(ctx, workspace, repoSlug, issueID)
 	if err != nil {
 		return fmt.Errorf("failed to get issue: %w", err)
@@ -170,7 +171,7 @@ func displayIssue(streams *iostreams.IOStreams, issue *api.Issue, comments []api
 	fmt.Fprintln(streams.Out)
 
 	// State, Kind, Priority
-	fmt.Fprintf(streams.Out, "State:    %s\n", formatIssueState(streams, issue.State))
+	fmt.Fprintf(streams.Out, "State:    %s\n", cmdutil.ColorState(streams, issue.State))
 	fmt.Fprintf(streams.Out, "Kind:     %s\n", formatIssueKind(streams, issue.Kind))
 	fmt.Fprintf(streams.Out, "Priority: %s\n", formatIssuePriority(streams, issue.Priority))
 	fmt.Fprintln(streams.Out)
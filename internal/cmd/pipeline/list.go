@@ -15,12 +15,14 @@ import (
 
 // ListOptions holds the options for the list command
 type ListOptions struct {
-	Status  string
-	Branch  string
-	Limit   int
-	JSON    bool
-	Repo    string
-	Streams *iostreams.IOStreams
+	Status    string
+	Branch    string
+	Limit     int
+	JSON      bool
+	ShowCount bool
+	ExitCode  bool
+	Repo      string
+	Streams   *iostreams.IOStreams
 }
 
 // NewCmdList creates the pipeline list command
@@ -63,6 +65,9 @@ by pipeline status (PENDING, IN_PROGRESS, COMPLETED, SUCCESSFUL, FAILED, STOPPED
 	cmd.Flags().StringVarP(&opts.Branch, "branch", "b", "", "Filter by branch name")
 	cmd.Flags().IntVarP(&opts.Limit, "limit", "l", 30, "Maximum number of pipelines to list")
 	cmd.Flags().BoolVar(&opts.JSON, "json", false, "Output in JSON format")
+	cmdutil.AddShowCountFlag(cmd, &opts.ShowCount)
+	cmdutil.AddAllFlag(cmd, &opts.Limit)
+	cmdutil.AddExitCodeFlag(cmd, &opts.ExitCode)
 	cmd.Flags().StringVarP(&opts.Repo, "repo", "R", "", "Repository in WORKSPACE/REPO format")
 
 	_ = cmd.RegisterFlagCompletionFunc("status", cmdutil.StaticFlagCompletion([]string{
@@ -89,7 +94,8 @@ func runList(ctx context.Context, opts *ListOptions) error {
 
 	// Build list options
 	listOpts := &api.PipelineListOptions{
-		Sort: "-created_on", // Sort by newest first
+		Sort:  "-created_on", // Sort by newest first
+		Limit: cmdutil.PageLen(opts.Limit, cmdutil.MaxPageLen),
 	}
 
 	if opts.Status != "" {
@@ -102,18 +108,16 @@ func runList(ctx context.Context, opts *ListOptions) error {
 		return fmt.Errorf("failed to list pipelines: %w", err)
 	}
 
-	// Filter by branch if specified (client-side filter since API may not support it directly)
 	var pipelines []api.Pipeline
-	for _, p := range result.Values {
-		if opts.Branch != "" {
-			if p.Target == nil || p.Target.RefName != opts.Branch {
-				continue
-			}
-		}
-		pipelines = append(pipelines, p)
-		if len(pipelines) >= opts.Limit {
-			break
-		}
+	var pageCount *cmdutil.PageCount
+	if opts.Branch != "" {
+		pipelines, pageCount, err = listBranchPipelines(ctx, client, opts.Streams, result, opts.Branch, opts.Limit)
+	} else if result, err = cmdutil.CollectPages(ctx, client, result, opts.Limit); err == nil {
+		pipelines = result.Values
+		pageCount = cmdutil.NewPageCount(result, len(pipelines))
+	}
+	if err != nil {
+		return fmt.Errorf("failed to list pipelines: %w", err)
 	}
 
 	if len(pipelines) == 0 {
@@ -122,18 +126,61 @@ func runList(ctx context.Context, opts *ListOptions) error {
 		} else {
 			opts.Streams.Info("No pipelines found in %s/%s", workspace, repoSlug)
 		}
-		return nil
+		return cmdutil.NoResults(opts.ExitCode)
+	}
+
+	var count *cmdutil.PageCount
+	if opts.ShowCount {
+		count = pageCount
 	}
 
 	// Output results
 	if opts.JSON {
-		return outputListJSON(opts.Streams, pipelines)
+		return outputListJSON(opts.Streams, pipelines, count)
+	}
+
+	if err := outputListTable(opts.Streams, pipelines); err != nil {
+		return err
 	}
+	cmdutil.PrintPageCount(opts.Streams, count)
+	return nil
+}
+
+// maxBranchPages bounds how many pages of pipelines --branch searches
+const maxBranchPages = 10
 
-	return outputListTable(opts.Streams, pipelines)
+// listBranchPipelines keeps the pipelines that ran on branch, following
+// further pages until limit are found. The pipelines endpoint can't filter
+// by branch, so only maxBranchPages pages are searched, with a warning if
+// there are more.
+func listBranchPipelines(ctx context.Context, client *api.Client, streams *iostreams.IOStreams, page *api.Paginated[api.Pipeline], branch string, limit int) ([]api.Pipeline, *cmdutil.PageCount, error) {
+	var pipelines []api.Pipeline
+	for pages := 1; ; pages++ {
+		for _, p := range page.Values {
+			if p.Target != nil && p.Target.RefName == branch {
+				pipelines = append(pipelines, p)
+			}
+		}
+		if limit > 0 && len(pipelines) >= limit {
+			return pipelines[:limit], &cmdutil.PageCount{Shown: limit, HasMore: len(pipelines) > limit || page.Next != ""}, nil
+		}
+		if page.Next == "" {
+			break
+		}
+		if pages >= maxBranchPages {
+			streams.Warning("Only the %d most recent pages of pipelines were searched for branch %s; older ones may be missing", maxBranchPages, branch)
+			break
+		}
+		next, err := api.NextPage(ctx, client, page)
+		if err != nil {
+			return nil, nil, err
+		}
+		page = next
+	}
+	return pipelines, &cmdutil.PageCount{Shown: len(pipelines), HasMore: page.Next != ""}, nil
 }
 
-func outputListJSON(streams *iostreams.IOStreams, pipelines []api.Pipeline) error {
+func outputListJSON(streams *iostreams.IOStreams, pipelines []api.Pipeline, count *cmdutil.PageCount) error {
 	// Create simplified JSON output
 	output := make([]map[string]interface{}, len(pipelines))
 	for i, p := range pipelines {
@@ -174,7 +221,7 @@ func outputListJSON(streams *iostreams.IOStreams, pipelines []api.Pipeline) erro
 		}
 	}
 
-	return cmdutil.PrintJSON(streams, output)
+	return cmdutil.PrintListJSON(streams, output, count)
 }
 
 func outputListTable(streams *iostreams.IOStreams, pipelines []api.Pipeline) error {
@@ -54,7 +54,7 @@ pipeline defined in bitbucket-pipelines.yml with --custom.`,
 		},
 	}
 
-	cmd.Flags().StringVarP(&opts.branch, "branch", "b", "", "Branch to run pipeline on (default: current branch or main)")
+	cmd.Flags().StringVarP(&opts.branch, "branch", "b", "", "Branch to run pipeline on (default: current branch, or the repository's default branch)")
 	cmd.Flags().StringVar(&opts.commit, "commit", "", "Specific commit hash to run pipeline on")
 	cmd.Flags().StringVar(&opts.custom, "custom", "", "Custom pipeline name (for custom pipelines in bitbucket-pipelines.yml)")
 	cmd.Flags().StringVarP(&opts.repo, "repo", "R", "", "Repository in WORKSPACE/REPO format")
@@ -72,31 +72,31 @@ func runPipelineRun(opts *runOptions) error {
 		return err
 	}
 
+	// Get authenticated client
+	client, err := cmdutil.GetAPIClient()
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
 	// Determine the branch to use
 	branch := opts.branch
 	if branch == "" {
-		// Try to get current branch from git
+		// Try to get current branch from git, falling back to the
+		// repository's default branch outside a checkout
 		currentBranch, err := git.GetCurrentBranch()
-		if err != nil {
-			// Fall back to main if we can't detect the current branch
-			branch = "main"
-		} else {
+		if err == nil {
 			branch = currentBranch
+		} else if branch, err = cmdutil.DefaultBranch(ctx, client, workspace, repoSlug); err != nil {
+			return fmt.Errorf("could not determine the branch to run; use --branch: %w", err)
 		}
 	}
 
 	// Build pipeline run options
 	pipelineOpts := buildPipelineRunOptions(branch, opts.commit, opts.custom)
 
-	// Get authenticated client
-	client, err := cmdutil.GetAPIClient()
-	if err != nil {
-		return err
-	}
-
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
-	defer cancel()
-
 	// Display what we're about to do
 	if opts.custom != "" {
 		opts.streams.Info("Triggering custom pipeline '%s' on branch %s in %s/%s...", opts.custom, branch, workspace, repoSlug)
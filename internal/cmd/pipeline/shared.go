@@ -8,6 +8,7 @@ import (
 	"time"
 
 	"github.com/rbansal42/bitbucket-cli/internal/api"
+	"github.com/rbansal42/bitbucket-cli/internal/cmdutil"
 	"github.com/rbansal42/bitbucket-cli/internal/iostreams"
 )
 
@@ -56,25 +57,7 @@ func formatPipelineState(streams *iostreams.IOStreams, state *api.PipelineState)
 		displayText = resultName
 	}
 
-	if !streams.ColorEnabled() {
-		return displayText
-	}
-
-	// Apply color based on state
-	switch {
-	case resultName == "SUCCESSFUL":
-		return iostreams.Green + displayText + iostreams.Reset
-	case resultName == "FAILED" || resultName == "ERROR":
-		return iostreams.Red + displayText + iostreams.Reset
-	case resultName == "STOPPED":
-		return iostreams.Yellow + displayText + iostreams.Reset
-	case stateName == "IN_PROGRESS":
-		return iostreams.Yellow + displayText + iostreams.Reset
-	case stateName == "PENDING":
-		return iostreams.Cyan + displayText + iostreams.Reset
-	default:
-		return displayText
-	}
+	return cmdutil.ColorState(streams, displayText)
 }
 
 // formatDuration formats a duration in a human-readable format
@@ -176,24 +176,7 @@ func formatStepStatus(streams *iostreams.IOStreams, state *api.PipelineStepState
 		status = state.Result.Name
 	}
 
-	if !streams.ColorEnabled() {
-		return status
-	}
-
-	switch status {
-	case "SUCCESSFUL":
-		return iostreams.Green + status + iostreams.Reset
-	case "FAILED":
-		return iostreams.Red + status + iostreams.Reset
-	case "IN_PROGRESS", "RUNNING":
-		return iostreams.Cyan + status + iostreams.Reset
-	case "PENDING":
-		return iostreams.Yellow + status + iostreams.Reset
-	case "STOPPED":
-		return iostreams.Magenta + status + iostreams.Reset
-	default:
-		return status
-	}
+	return cmdutil.ColorState(streams, status)
 }
 
 // formatStepDuration formats a duration between two times
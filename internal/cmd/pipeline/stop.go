@@ -1,10 +1,8 @@
 package pipeline
 
 import (
-	"bufio"
 	"context"
 	"fmt"
-	"io"
 	"strconv"
 	"strings"
 	"time"
@@ -92,19 +90,17 @@ func runPipelineStop(opts *stopOptions) error {
 
 	// Confirmation prompt
 	if !opts.yes {
-		// Require TTY for interactive confirmation
-		if !opts.streams.IsStdinTTY() {
-			return fmt.Errorf("cannot confirm stop: stdin is not a terminal\nUse --yes flag to skip confirmation in non-interactive mode")
-		}
-
 		displayID := opts.pipelineArg
 		if buildNumber > 0 {
 			displayID = fmt.Sprintf("#%d", buildNumber)
 		}
 
-		fmt.Fprintf(opts.streams.Out, "Are you sure you want to stop pipeline %s? [y/N] ", displayID)
-
-		if !confirmStop(opts.streams.In) {
+		question := fmt.Sprintf("Are you sure you want to stop pipeline %s?", displayID)
+		confirmed, err := cmdutil.Confirm(opts.streams, question, false, "pass --yes to skip confirmation")
+		if err != nil {
+			return err
+		}
+		if !confirmed {
 			return fmt.Errorf("stop cancelled")
 		}
 	}
@@ -150,26 +146,3 @@ func parsePipelineStopArg(arg string) (uuid string, buildNumber int, err error)
 
 	return "", num, nil
 }
-
-// confirmStop prompts the user to confirm stop operation
-func confirmStop(in interface{}) bool {
-	var reader *bufio.Reader
-
-	// Handle different input types
-	switch r := in.(type) {
-	case *bufio.Reader:
-		reader = r
-	case io.Reader:
-		reader = bufio.NewReader(r)
-	default:
-		return false
-	}
-
-	input, err := reader.ReadString('\n')
-	if err != nil {
-		return false
-	}
-
-	input = strings.TrimSpace(strings.ToLower(input))
-	return input == "y" || input == "yes"
-}
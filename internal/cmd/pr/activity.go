@@ -0,0 +1,110 @@
+package pr
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/rbansal42/bitbucket-cli/internal/api"
+	"github.com/rbansal42/bitbucket-cli/internal/cmdutil"
+	"github.com/rbansal42/bitbucket-cli/internal/iostreams"
+)
+
+// maxActivityPages bounds how much history --activity fetches for very
+// busy pull requests
+const maxActivityPages = 20
+
+// fetchActivity returns a pull request's activity in chronological order.
+// truncated is set when older history was left out.
+func fetchActivity(ctx context.Context, client *api.Client, workspace, repoSlug string, prID int64) (entries []api.Activity, truncated bool, err error) {
+	page, err := client.GetPullRequestActivity(ctx, workspace, repoSlug, prID)
+	if err != nil {
+		return nil, false, err
+	}
+
+	for i := 0; page != nil; i++ {
+		if i == maxActivityPages {
+			truncated = true
+			break
+		}
+		entries = append(entries, page.Values...)
+		if page, err = api.NextPage(ctx, client, page); err != nil {
+			return nil, false, err
+		}
+	}
+
+	// The API returns newest first; a timeline reads oldest first
+	sort.SliceStable(entries, func(i, j int) bool {
+		return entries[i].Date().Before(entries[j].Date())
+	})
+	return entries, truncated, nil
+}
+
+// formatActivity renders activity entries as a timeline, one event per
+// line. complete says whether entries start at the pull request's creation.
+func formatActivity(streams *iostreams.IOStreams, entries []api.Activity, complete bool) string {
+	var b strings.Builder
+	opened := !complete
+
+	for _, a := range entries {
+		actor := a.Actor()
+		if actor == nil {
+			continue
+		}
+
+		var event string
+		switch a.Kind() {
+		case api.ActivityUpdate:
+			event = describeUpdate(a.Update, opened)
+			opened = true
+		case api.ActivityApproval:
+			event = streams.ColorFunc(iostreams.Green)("approved")
+		case api.ActivityChangesRequested:
+			event = streams.ColorFunc(iostreams.Yellow)("requested changes")
+		case api.ActivityComment:
+			event = describeComment(a.Comment)
+		}
+
+		fmt.Fprintf(&b, "  %s  %s %s\n", a.Date().Local().Format("2006-01-02 15:04"), cmdutil.GetUserDisplayName(actor), event)
+	}
+
+	return b.String()
+}
+
+// describeUpdate says what an update entry did. The first update is the
+// pull request being opened; later ones are pushes, edits or state changes.
+func describeUpdate(u *api.PRUpdate, opened bool) string {
+	switch {
+	case u.State == api.PRStateMerged:
+		return "merged the pull request"
+	case u.State == api.PRStateDeclined:
+		if u.Reason != "" {
+			return fmt.Sprintf("declined the pull request: %s", cmdutil.TruncateString(u.Reason, 60))
+		}
+		return "declined the pull request"
+	case !opened:
+		return "opened the pull request"
+	default:
+		return "updated the pull request"
+	}
+}
+
+func describeComment(c *api.PRComment) string {
+	text := fmt.Sprintf("%q", cmdutil.TruncateString(c.Content.Raw, 60))
+	switch {
+	case c.Inline != nil && c.Inline.Path != "":
+		line := c.Inline.To
+		if line == 0 {
+			line = c.Inline.From
+		}
+		if line > 0 {
+			return fmt.Sprintf("commented on %s:%d: %s", c.Inline.Path, line, text)
+		}
+		return fmt.Sprintf("commented on %s: %s", c.Inline.Path, text)
+	case c.Parent != nil:
+		return "replied: " + text
+	default:
+		return "commented: " + text
+	}
+}
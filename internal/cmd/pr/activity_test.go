@@ -0,0 +1,54 @@
+package pr
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/rbansal42/bitbucket-cli/internal/api"
+	"github.com/rbansal42/bitbucket-cli/internal/iostreams"
+)
+
+func TestFormatActivity(t *testing.T) {
+	streams := &iostreams.IOStreams{Out: &bytes.Buffer{}, ErrOut: &bytes.Buffer{}}
+	at := func(h int) time.Time { return time.Date(2024, 1, 1, h, 0, 0, 0, time.UTC) }
+
+	comment := &api.PRComment{User: api.User{DisplayName: "Carol"}, CreatedOn: at(3)}
+	comment.Content.Raw = "Please add\na test"
+
+	entries := []api.Activity{
+		{Update: &api.PRUpdate{State: api.PRStateOpen, Author: api.User{DisplayName: "Alice"}, Date: at(1)}},
+		{Update: &api.PRUpdate{State: api.PRStateOpen, Author: api.User{DisplayName: "Alice"}, Date: at(2)}},
+		{Comment: comment},
+		{ChangesRequested: &api.PRReview{User: api.User{DisplayName: "Dave"}, Date: at(4)}},
+		{Approval: &api.PRReview{User: api.User{DisplayName: "Bob"}, Date: at(5)}},
+		{Update: &api.PRUpdate{State: api.PRStateMerged, Author: api.User{DisplayName: "Bob"}, Date: at(6)}},
+		{},
+	}
+
+	got := formatActivity(streams, entries, true)
+	want := []string{
+		"Alice opened the pull request",
+		"Alice updated the pull request",
+		`Carol commented: "Please add a test"`,
+		"Dave requested changes",
+		"Bob approved",
+		"Bob merged the pull request",
+	}
+
+	lines := strings.Split(strings.TrimSuffix(got, "\n"), "\n")
+	if len(lines) != len(want) {
+		t.Fatalf("expected %d lines, got %d:\n%s", len(want), len(lines), got)
+	}
+	for i, line := range lines {
+		if !strings.HasSuffix(line, want[i]) {
+			t.Errorf("line %d = %q, want suffix %q", i, line, want[i])
+		}
+	}
+
+	// Without the start of the history the first update is not an open
+	if got := formatActivity(streams, entries[:1], false); !strings.HasSuffix(strings.TrimSpace(got), "Alice updated the pull request") {
+		t.Errorf("truncated history rendered as %q", got)
+	}
+}
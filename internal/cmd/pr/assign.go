@@ -0,0 +1,91 @@
+package pr
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/rbansal42/bitbucket-cli/internal/cmdutil"
+	"github.com/rbansal42/bitbucket-cli/internal/iostreams"
+)
+
+type assignOptions struct {
+	streams *iostreams.IOStreams
+	repo    string
+}
+
+// NewCmdAssign creates the assign command
+func NewCmdAssign(streams *iostreams.IOStreams) *cobra.Command {
+	opts := &assignOptions{
+		streams: streams,
+	}
+
+	cmd := &cobra.Command{
+		Use:   "assign <number> <user>...",
+		Short: "Assign a pull request by adding reviewers",
+		Long: `Assign a pull request to one or more users.
+
+Bitbucket pull requests have no assignees, so teams use reviewers instead:
+this command adds each user as a reviewer, keeping the reviewers the pull
+request already has. It is the equivalent of assigning a pull request on
+GitHub, and 'bb pr list --assignee' finds the pull requests assigned this
+way.
+
+Users are given by username, account UUID or @me. The author of a pull
+request cannot be one of its reviewers.`,
+		Example: `  # Assign pull request #123 to a teammate
+  bb pr assign 123 johndoe
+
+  # Assign it to yourself and another user
+  bb pr assign 123 @me janedoe`,
+		Args: cobra.MinimumNArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runAssign(cmd.Context(), opts, args)
+		},
+	}
+
+	cmd.Flags().StringVarP(&opts.repo, "repo", "R", "", "Repository in WORKSPACE/REPO format")
+
+	cmd.ValidArgsFunction = func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		if len(args) == 0 {
+			return cmdutil.CompletePRNumbers(cmd, args, toComplete)
+		}
+		return cmdutil.CompleteWorkspaceMembers(cmd, args, toComplete)
+	}
+	_ = cmd.RegisterFlagCompletionFunc("repo", cmdutil.CompleteRepoNames)
+
+	return cmd
+}
+
+func runAssign(ctx context.Context, opts *assignOptions, args []string) error {
+	prNum, err := parsePRNumber(args)
+	if err != nil {
+		return err
+	}
+	users := args[1:]
+
+	workspace, repoSlug, err := cmdutil.ParseRepository(opts.repo)
+	if err != nil {
+		return err
+	}
+
+	client, err := cmdutil.GetAPIClient()
+	if err != nil {
+		return err
+	}
+
+	// Assigning someone who doesn't exist is always a mistake
+	uuids, err := resolveReviewers(ctx, client, opts.streams, workspace, users, true)
+	if err != nil {
+		return err
+	}
+
+	if _, err := client.AddPullRequestReviewers(ctx, workspace, repoSlug, int64(prNum), uuids); err != nil {
+		return fmt.Errorf("failed to assign pull request: %w", err)
+	}
+
+	opts.streams.Success("Assigned pull request #%d to %s (as reviewers)", prNum, strings.Join(users, ", "))
+	return nil
+}
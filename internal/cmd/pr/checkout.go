@@ -89,6 +89,7 @@ func runCheckout(opts *checkoutOptions) error {
 	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
 	defer cancel()
 
+	opts.streams.Verbose("Fetching pull request #%d...", opts.prNumber)
 	pr, err := client.GetPullRequest(ctx, workspace, repoSlug, int64(opts.prNumber))
 	if err != nil {
 		return fmt.Errorf("failed to get pull request: %w", err)
@@ -125,6 +126,7 @@ func runCheckout(opts *checkoutOptions) error {
 	}
 
 	// Fetch and create tracking branch
+	opts.streams.Verbose("Fetching branch %s from %s...", sourceBranch, remote.Name)
 	refspec := fmt.Sprintf("%s:%s", sourceBranch, sourceBranch)
 	if err := git.Fetch(remote.Name, refspec); err != nil {
 		return fmt.Errorf("failed to fetch branch: %w", err)
@@ -17,10 +17,12 @@ import (
 
 // ChecksOptions holds the options for the checks command
 type ChecksOptions struct {
-	Repo    string
-	PRID    int64
-	JSON    bool
-	Streams *iostreams.IOStreams
+	Repo     string
+	PRID     int64
+	JSON     bool
+	Watch    bool
+	Interval time.Duration
+	Streams  *iostreams.IOStreams
 }
 
 // NewCmdChecks creates the pr checks command
@@ -33,7 +35,12 @@ func NewCmdChecks(streams *iostreams.IOStreams) *cobra.Command {
 		Long: `View the status of CI/CD checks for a pull request.
 
 Shows build statuses, pipeline results, and other commit statuses
-associated with the pull request.`,
+associated with the pull request.
+
+The command exits with status 0 only when every check has succeeded (or
+when the pull request has no checks at all), and non-zero if any check
+failed, was stopped, or is still running. Use --watch to keep polling
+until every check reaches a final state.`,
 		Example: `  # View checks for PR #123
   bb pr checks 123
 
@@ -41,7 +48,10 @@ associated with the pull request.`,
   bb pr checks 123 --json
 
   # View checks for a specific repository
-  bb pr checks 123 --repo workspace/repo`,
+  bb pr checks 123 --repo workspace/repo
+
+  # Wait for all checks to finish, then merge if they passed
+  bb pr checks 123 --watch && bb pr merge 123`,
 		Args: cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			id, err := strconv.ParseInt(args[0], 10, 64)
@@ -58,6 +68,8 @@ associated with the pull request.`,
 
 	cmd.Flags().StringVarP(&opts.Repo, "repo", "R", "", "Repository in WORKSPACE/REPO format")
 	cmd.Flags().BoolVar(&opts.JSON, "json", false, "Output in JSON format")
+	cmd.Flags().BoolVarP(&opts.Watch, "watch", "w", false, "Poll until all checks reach a final state")
+	cmd.Flags().DurationVarP(&opts.Interval, "interval", "i", 5*time.Second, "Polling interval for --watch")
 
 	cmd.ValidArgsFunction = cmdutil.CompletePRNumbers
 	_ = cmd.RegisterFlagCompletionFunc("repo", cmdutil.CompleteRepoNames)
@@ -78,27 +90,133 @@ func runChecks(ctx context.Context, opts *ChecksOptions) error {
 		return err
 	}
 
-	// Create context with timeout
+	if opts.Interval <= 0 {
+		return fmt.Errorf("--interval must be positive")
+	}
+
+	// Lines written by the previous table, so a TTY can redraw in place
+	printed := 0
+	for {
+		statuses, err := fetchChecks(ctx, client, workspace, repoSlug, opts.PRID)
+		if err != nil {
+			return err
+		}
+
+		if len(statuses) == 0 {
+			opts.Streams.Info("No checks reported for PR #%d", opts.PRID)
+			return nil
+		}
+
+		done := allChecksFinished(statuses)
+		if !opts.Watch || done {
+			if printed > 0 {
+				clearLines(opts.Streams, printed)
+			}
+			if opts.JSON {
+				if err := outputChecksJSON(opts.Streams, statuses); err != nil {
+					return err
+				}
+			} else if err := outputChecksTable(opts.Streams, statuses); err != nil {
+				return err
+			}
+			return checksResult(statuses)
+		}
+
+		if !opts.JSON {
+			if opts.Streams.IsStdoutTTY() {
+				if printed > 0 {
+					clearLines(opts.Streams, printed)
+				}
+				if err := outputChecksTable(opts.Streams, statuses); err != nil {
+					return err
+				}
+				printed = len(statuses) + 1
+			} else {
+				pending := 0
+				for _, s := range statuses {
+					if !isTerminalCheckState(s.State) {
+						pending++
+					}
+				}
+				fmt.Fprintf(opts.Streams.Out, "Waiting for %d of %d checks...\n", pending, len(statuses))
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(opts.Interval):
+		}
+	}
+}
+
+// fetchChecks retrieves the statuses for a pull request, following every
+// page, with a timeout for the whole fetch
+func fetchChecks(ctx context.Context, client *api.Client, workspace, repoSlug string, prID int64) ([]api.CommitStatus, error) {
 	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
 	defer cancel()
 
-	// Get statuses
-	result, err := client.GetPullRequestStatuses(ctx, workspace, repoSlug, opts.PRID)
-	if err != nil {
-		return fmt.Errorf("failed to get status checks: %w", err)
+	var statuses []api.CommitStatus
+	for status, err := range api.Iterate(ctx, client, func(ctx context.Context) (*api.Paginated[api.CommitStatus], error) {
+		return client.GetPullRequestStatuses(ctx, workspace, repoSlug, prID)
+	}) {
+		if err != nil {
+			return nil, fmt.Errorf("failed to get status checks: %w", err)
+		}
+		statuses = append(statuses, status)
+	}
+	return statuses, nil
+}
+
+// isTerminalCheckState reports whether a check will not change state anymore
+func isTerminalCheckState(state string) bool {
+	switch state {
+	case "SUCCESSFUL", "FAILED", "STOPPED":
+		return true
+	default:
+		return false
+	}
+}
+
+// allChecksFinished reports whether every check has reached a terminal state
+func allChecksFinished(statuses []api.CommitStatus) bool {
+	for _, s := range statuses {
+		if !isTerminalCheckState(s.State) {
+			return false
+		}
 	}
+	return true
+}
 
-	if len(result.Values) == 0 {
-		opts.Streams.Info("No status checks found for PR #%d", opts.PRID)
-		return nil
+// checksResult returns an error unless every check succeeded
+func checksResult(statuses []api.CommitStatus) error {
+	var failed, pending, stopped int
+	for _, s := range statuses {
+		switch s.State {
+		case "SUCCESSFUL":
+		case "FAILED":
+			failed++
+		case "STOPPED":
+			stopped++
+		default:
+			pending++
+		}
 	}
 
-	// Output
-	if opts.JSON {
-		return outputChecksJSON(opts.Streams, result.Values)
+	switch {
+	case failed > 0:
+		return fmt.Errorf("%d of %d checks failed", failed, len(statuses))
+	case stopped > 0:
+		return fmt.Errorf("%d of %d checks were stopped", stopped, len(statuses))
+	case pending > 0:
+		return fmt.Errorf("%d of %d checks are still pending", pending, len(statuses))
 	}
+	return nil
+}
 
-	return outputChecksTable(opts.Streams, result.Values)
+// clearLines moves the cursor up n lines and clears to the end of the screen
+func clearLines(streams *iostreams.IOStreams, n int) {
+	fmt.Fprintf(streams.Out, "\033[%dA\033[J", n)
 }
 
 func outputChecksJSON(streams *iostreams.IOStreams, statuses []api.CommitStatus) error {
@@ -128,7 +246,7 @@ func outputChecksTable(streams *iostreams.IOStreams, statuses []api.CommitStatus
 	w := tabwriter.NewWriter(streams.Out, 0, 0, 2, ' ', 0)
 
 	// Header
-	header := "STATUS\tNAME\tDESCRIPTION"
+	header := "STATUS\tNAME\tDESCRIPTION\tURL"
 	if streams.ColorEnabled() {
 		fmt.Fprintln(w, iostreams.Bold+header+iostreams.Reset)
 	} else {
@@ -144,7 +262,7 @@ func outputChecksTable(streams *iostreams.IOStreams, statuses []api.CommitStatus
 		}
 		desc := cmdutil.TruncateString(s.Description, 50)
 
-		fmt.Fprintf(w, "%s\t%s\t%s\n", status, name, desc)
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", status, name, desc, s.URL)
 	}
 
 	return w.Flush()
@@ -0,0 +1,80 @@
+package pr
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/rbansal42/bitbucket-cli/internal/api"
+)
+
+func TestChecksResult(t *testing.T) {
+	tests := []struct {
+		name     string
+		states   []string
+		wantErr  bool
+		finished bool
+	}{
+		{
+			name:     "all successful",
+			states:   []string{"SUCCESSFUL", "SUCCESSFUL"},
+			wantErr:  false,
+			finished: true,
+		},
+		{
+			name:     "one failed",
+			states:   []string{"SUCCESSFUL", "FAILED"},
+			wantErr:  true,
+			finished: true,
+		},
+		{
+			name:     "one stopped",
+			states:   []string{"STOPPED", "SUCCESSFUL"},
+			wantErr:  true,
+			finished: true,
+		},
+		{
+			name:     "still running",
+			states:   []string{"SUCCESSFUL", "INPROGRESS"},
+			wantErr:  true,
+			finished: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			statuses := make([]api.CommitStatus, len(tt.states))
+			for i, s := range tt.states {
+				statuses[i] = api.CommitStatus{State: s}
+			}
+
+			err := checksResult(statuses)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("checksResult() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if got := allChecksFinished(statuses); got != tt.finished {
+				t.Errorf("allChecksFinished() = %v, want %v", got, tt.finished)
+			}
+		})
+	}
+}
+
+func TestFetchChecks_Pages(t *testing.T) {
+	client := newViewTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("page") == "2" {
+			fmt.Fprint(w, `{"values": [{"key": "lint", "state": "INPROGRESS"}]}`)
+			return
+		}
+		fmt.Fprintf(w, `{"next": "http://%s/repositories/ws/repo/pullrequests/7/statuses?page=2", "values": [{"key": "build", "state": "SUCCESSFUL"}]}`, r.Host)
+	})
+
+	statuses, err := fetchChecks(context.Background(), client, "ws", "repo", 7)
+	if err != nil {
+		t.Fatalf("fetchChecks() error: %v", err)
+	}
+	if len(statuses) != 2 || statuses[1].Key != "lint" {
+		t.Errorf("fetchChecks() = %+v, want the statuses from both pages", statuses)
+	}
+}
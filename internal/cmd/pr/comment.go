@@ -2,7 +2,9 @@ package pr
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"net/http"
 
 	"github.com/spf13/cobra"
 
@@ -12,9 +14,14 @@ import (
 )
 
 type commentOptions struct {
-	streams *iostreams.IOStreams
-	repo    string
-	body    string
+	streams  *iostreams.IOStreams
+	repo     string
+	body     string
+	editID   int64
+	deleteID int64
+	editing  bool // --edit was given, whatever its value
+	deleting bool // --delete was given, whatever its value
+	yes      bool
 }
 
 // NewCmdComment creates the comment command
@@ -25,11 +32,14 @@ func NewCmdComment(streams *iostreams.IOStreams) *cobra.Command {
 
 	cmd := &cobra.Command{
 		Use:   "comment [<number>]",
-		Short: "Add a comment to a pull request",
+		Short: "Add, edit or delete a comment on a pull request",
 		Long: `Add a comment to a pull request.
 
 If the comment body is not provided via --body, an editor will be opened
-for you to enter the comment text.`,
+for you to enter the comment text.
+
+Use --edit with a comment ID to change one of your comments, or --delete to
+remove it. Comment IDs are shown by "bb pr comments --json".`,
 		Example: `  # Add a comment to pull request #123 (opens editor)
   bb pr comment 123
 
@@ -37,16 +47,30 @@ for you to enter the comment text.`,
   bb pr comment 123 --body "This looks great!"
 
   # Add a comment to a PR in a specific repository
-  bb pr comment 123 --repo workspace/repo --body "LGTM"`,
+  bb pr comment 123 --repo workspace/repo --body "LGTM"
+
+  # Edit comment 456 on pull request #123
+  bb pr comment 123 --edit 456 --body "Updated text"
+
+  # Delete comment 456 without confirmation
+  bb pr comment 123 --delete 456 --yes`,
 		Args: cobra.MaximumNArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
+			opts.editing = cmd.Flags().Changed("edit")
+			opts.deleting = cmd.Flags().Changed("delete")
 			return runComment(opts, args)
 		},
 	}
 
 	cmd.Flags().StringVarP(&opts.body, "body", "b", "", "Comment body text")
+	cmd.Flags().Int64Var(&opts.editID, "edit", 0, "Edit the comment with this ID")
+	cmd.Flags().Int64Var(&opts.deleteID, "delete", 0, "Delete the comment with this ID")
+	cmd.Flags().BoolVarP(&opts.yes, "yes", "y", false, "Skip the confirmation prompt when deleting")
 	cmd.Flags().StringVarP(&opts.repo, "repo", "R", "", "Repository in WORKSPACE/REPO format")
 
+	cmd.MarkFlagsMutuallyExclusive("edit", "delete")
+	cmd.MarkFlagsMutuallyExclusive("body", "delete")
+
 	cmd.ValidArgsFunction = cmdutil.CompletePRNumbers
 	_ = cmd.RegisterFlagCompletionFunc("repo", cmdutil.CompleteRepoNames)
 
@@ -54,6 +78,13 @@ for you to enter the comment text.`,
 }
 
 func runComment(opts *commentOptions, args []string) error {
+	if opts.editing && opts.editID < 1 {
+		return cmdutil.NewFlagError(fmt.Errorf("invalid comment ID for --edit: %d", opts.editID))
+	}
+	if opts.deleting && opts.deleteID < 1 {
+		return cmdutil.NewFlagError(fmt.Errorf("invalid comment ID for --delete: %d", opts.deleteID))
+	}
+
 	prNum, err := parsePRNumber(args)
 	if err != nil {
 		return err
@@ -64,9 +95,19 @@ func runComment(opts *commentOptions, args []string) error {
 		return err
 	}
 
+	if opts.deleting {
+		return runDeleteComment(opts, workspace, repoSlug, prNum)
+	}
+	if opts.editing {
+		return runEditComment(opts, workspace, repoSlug, prNum)
+	}
+
 	// If no body provided, open editor
 	if opts.body == "" {
-		body, err := openEditor("")
+		if !opts.streams.CanPrompt() {
+			return &cmdutil.NoPromptError{Hint: "pass --body"}
+		}
+		body, err := cmdutil.OpenEditor("")
 		if err != nil {
 			return fmt.Errorf("failed to get comment: %w", err)
 		}
@@ -115,3 +156,79 @@ func runComment(opts *commentOptions, args []string) error {
 
 	return nil
 }
+
+func runEditComment(opts *commentOptions, workspace, repoSlug string, prNum int) error {
+	client, err := cmdutil.GetAPIClient()
+	if err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+
+	// Without --body, edit the current text in the editor
+	if opts.body == "" {
+		if !opts.streams.CanPrompt() {
+			return &cmdutil.NoPromptError{Hint: "pass --body"}
+		}
+		existing, err := client.GetPRComment(ctx, workspace, repoSlug, int64(prNum), opts.editID)
+		if err != nil {
+			return commentError(err, "get", opts.editID)
+		}
+		body, err := cmdutil.OpenEditor(existing.Content.Raw)
+		if err != nil {
+			return fmt.Errorf("failed to open editor: %w", err)
+		}
+		if body == "" {
+			return fmt.Errorf("comment body is required")
+		}
+		opts.body = body
+	}
+
+	comment, err := client.UpdatePRComment(ctx, workspace, repoSlug, int64(prNum), opts.editID, opts.body)
+	if err != nil {
+		return commentError(err, "edit", opts.editID)
+	}
+
+	opts.streams.Success("Edited comment %d on pull request #%d", comment.ID, prNum)
+	return nil
+}
+
+func runDeleteComment(opts *commentOptions, workspace, repoSlug string, prNum int) error {
+	if !opts.yes {
+		question := fmt.Sprintf("Delete comment %d on pull request #%d?", opts.deleteID, prNum)
+		confirmed, err := cmdutil.Confirm(opts.streams, question, false, "pass --yes to skip confirmation")
+		if err != nil {
+			return err
+		}
+		if !confirmed {
+			return fmt.Errorf("delete cancelled")
+		}
+	}
+
+	client, err := cmdutil.GetAPIClient()
+	if err != nil {
+		return err
+	}
+
+	if err := client.DeletePRComment(context.Background(), workspace, repoSlug, int64(prNum), opts.deleteID); err != nil {
+		return commentError(err, "delete", opts.deleteID)
+	}
+
+	opts.streams.Success("Deleted comment %d on pull request #%d", opts.deleteID, prNum)
+	return nil
+}
+
+// commentError explains the errors Bitbucket returns for a comment that is
+// missing or belongs to someone else. action is "get", "edit" or "delete".
+func commentError(err error, action string, commentID int64) error {
+	var apiErr *api.APIError
+	if errors.As(err, &apiErr) {
+		if apiErr.StatusCode == http.StatusNotFound {
+			return fmt.Errorf("comment %d not found on this pull request: %w", commentID, err)
+		}
+		if apiErr.StatusCode == http.StatusForbidden && action != "get" {
+			return fmt.Errorf("you can only %s your own comments: %w", action, err)
+		}
+	}
+	return fmt.Errorf("failed to %s comment: %w", action, err)
+}
@@ -0,0 +1,56 @@
+package pr
+
+import (
+	"bytes"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/rbansal42/bitbucket-cli/internal/api"
+	"github.com/rbansal42/bitbucket-cli/internal/cmdutil"
+	"github.com/rbansal42/bitbucket-cli/internal/iostreams"
+)
+
+func TestCommentError(t *testing.T) {
+	tests := []struct {
+		name   string
+		err    error
+		action string
+		want   string
+	}{
+		{"forbidden delete", &api.APIError{StatusCode: http.StatusForbidden}, "delete", "you can only delete your own comments"},
+		{"forbidden edit", &api.APIError{StatusCode: http.StatusForbidden}, "edit", "you can only edit your own comments"},
+		{"forbidden get", &api.APIError{StatusCode: http.StatusForbidden}, "get", "failed to get comment"},
+		{"not found", &api.APIError{StatusCode: http.StatusNotFound}, "edit", "comment 5 not found"},
+		{"other", errors.New("boom"), "delete", "failed to delete comment: boom"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := commentError(tt.err, tt.action, 5)
+			if !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("commentError() = %q, want %q", err, tt.want)
+			}
+			if !errors.Is(err, tt.err) {
+				t.Error("commentError() did not wrap the original error")
+			}
+		})
+	}
+}
+
+func TestCommentRejectsZeroCommentID(t *testing.T) {
+	for _, flag := range []string{"--edit", "--delete"} {
+		t.Run(flag, func(t *testing.T) {
+			cmd := NewCmdComment(&iostreams.IOStreams{Out: &bytes.Buffer{}, ErrOut: &bytes.Buffer{}})
+			cmd.SetArgs([]string{"1", flag, "0", "--repo", "ws/repo"})
+			cmd.SetOut(&bytes.Buffer{})
+			cmd.SetErr(&bytes.Buffer{})
+
+			err := cmd.Execute()
+			var flagErr *cmdutil.FlagError
+			if !errors.As(err, &flagErr) || !strings.Contains(err.Error(), "invalid comment ID for "+flag) {
+				t.Errorf("Execute() error = %v, want an invalid comment ID error", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,279 @@
+package pr
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/rbansal42/bitbucket-cli/internal/api"
+	"github.com/rbansal42/bitbucket-cli/internal/cmdutil"
+	"github.com/rbansal42/bitbucket-cli/internal/iostreams"
+)
+
+// maxCommentPages bounds how many pages of comments are fetched
+const maxCommentPages = 20
+
+type commentsOptions struct {
+	streams  *iostreams.IOStreams
+	repo     string
+	resolved bool
+	jsonOut  bool
+}
+
+// commentThread is a comment and the replies to it
+type commentThread struct {
+	Comment api.PRComment    `json:"comment"`
+	Replies []*commentThread `json:"replies"`
+}
+
+// NewCmdComments creates the comments command
+func NewCmdComments(streams *iostreams.IOStreams) *cobra.Command {
+	opts := &commentsOptions{
+		streams: streams,
+	}
+
+	cmd := &cobra.Command{
+		Use:   "comments [<number>]",
+		Short: "List the comments on a pull request",
+		Long: `List the comments on a pull request as threads, oldest first.
+
+Replies are indented under the comment they answer, and inline comments
+show the file and line they were made on. Reactions are summarized under
+a comment when Bitbucket includes them. Use --resolved=false to hide
+threads that have been resolved. Output is shown in a pager when stdout is
+a terminal.`,
+		Example: `  # Read the discussion on pull request #123
+  bb pr comments 123
+
+  # Only show threads that still need attention
+  bb pr comments 123 --resolved=false
+
+  # Output the threads as JSON
+  bb pr comments 123 --json`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runComments(cmd.Context(), opts, args)
+		},
+	}
+
+	cmd.Flags().BoolVar(&opts.resolved, "resolved", true, "Include resolved threads")
+	cmd.Flags().BoolVar(&opts.jsonOut, "json", false, "Output in JSON format")
+	cmd.Flags().StringVarP(&opts.repo, "repo", "R", "", "Repository in WORKSPACE/REPO format")
+
+	cmd.ValidArgsFunction = cmdutil.CompletePRNumbers
+	_ = cmd.RegisterFlagCompletionFunc("repo", cmdutil.CompleteRepoNames)
+
+	return cmd
+}
+
+func runComments(ctx context.Context, opts *commentsOptions, args []string) error {
+	prNum, err := parsePRNumber(args)
+	if err != nil {
+		return err
+	}
+
+	workspace, repoSlug, err := cmdutil.ParseRepository(opts.repo)
+	if err != nil {
+		return err
+	}
+
+	client, err := cmdutil.GetAPIClient()
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 60*time.Second)
+	defer cancel()
+
+	comments, truncated, err := fetchComments(ctx, client, workspace, repoSlug, int64(prNum))
+	if err != nil {
+		return fmt.Errorf("failed to list comments: %w", err)
+	}
+
+	threads := buildCommentThreads(comments)
+	if !opts.resolved {
+		threads = unresolvedThreads(threads)
+	}
+
+	if opts.jsonOut {
+		if threads == nil {
+			threads = []*commentThread{}
+		}
+		return cmdutil.PrintJSON(opts.streams, threads)
+	}
+
+	if len(threads) == 0 {
+		opts.streams.Info("No comments on pull request #%d", prNum)
+		return nil
+	}
+
+	cmdutil.StartPager(opts.streams)
+	defer opts.streams.StopPager()
+
+	if truncated {
+		opts.streams.Warning("Only the first %d pages of comments are shown", maxCommentPages)
+	}
+	for i, t := range threads {
+		if i > 0 {
+			fmt.Fprintln(opts.streams.Out)
+		}
+		writeCommentThread(opts.streams, opts.streams.Out, t, 0)
+	}
+	return nil
+}
+
+// fetchComments returns every comment on a pull request. truncated is set
+// when there were more than maxCommentPages pages.
+func fetchComments(ctx context.Context, client *api.Client, workspace, repoSlug string, prID int64) (comments []api.PRComment, truncated bool, err error) {
+	page, err := client.ListPRComments(ctx, workspace, repoSlug, prID)
+	for i := 0; page != nil && err == nil; i++ {
+		if i == maxCommentPages {
+			return comments, true, nil
+		}
+		comments = append(comments, page.Values...)
+		page, err = api.NextPage(ctx, client, page)
+	}
+	return comments, false, err
+}
+
+// buildCommentThreads arranges comments into threads by their parent IDs,
+// oldest first at every level. Replies whose parent is missing are shown as
+// threads of their own.
+func buildCommentThreads(comments []api.PRComment) []*commentThread {
+	sorted := make([]api.PRComment, len(comments))
+	copy(sorted, comments)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if !sorted[i].CreatedOn.Equal(sorted[j].CreatedOn) {
+			return sorted[i].CreatedOn.Before(sorted[j].CreatedOn)
+		}
+		return sorted[i].ID < sorted[j].ID
+	})
+
+	byID := make(map[int64]*commentThread, len(sorted))
+	for _, c := range sorted {
+		byID[c.ID] = &commentThread{Comment: c}
+	}
+
+	var roots []*commentThread
+	for _, c := range sorted {
+		t := byID[c.ID]
+		if c.Parent != nil {
+			if parent, ok := byID[c.Parent.ID]; ok && parent != t {
+				parent.Replies = append(parent.Replies, t)
+				continue
+			}
+		}
+		roots = append(roots, t)
+	}
+	return roots
+}
+
+// unresolvedThreads drops threads whose top comment has been resolved
+func unresolvedThreads(threads []*commentThread) []*commentThread {
+	var out []*commentThread
+	for _, t := range threads {
+		if t.Comment.Resolution == nil {
+			out = append(out, t)
+		}
+	}
+	return out
+}
+
+// writeCommentThread prints a comment and its replies, each level indented
+// further than the last
+func writeCommentThread(streams *iostreams.IOStreams, w io.Writer, t *commentThread, depth int) {
+	indent := strings.Repeat("    ", depth)
+	c := t.Comment
+
+	header := streams.ColorFunc(iostreams.Bold)(cmdutil.GetUserDisplayName(&c.User)) + " " + cmdutil.TimeAgo(c.CreatedOn)
+	if loc := commentLocation(c); loc != "" {
+		header += " on " + streams.ColorFunc(iostreams.Cyan)(loc)
+	}
+	if c.Resolution != nil {
+		header += " " + streams.ColorFunc(iostreams.Green)("(resolved)")
+	}
+	fmt.Fprintf(w, "%s%s\n", indent, header)
+
+	body := strings.TrimRight(c.Content.Raw, "\n")
+	if c.Deleted {
+		body = "(deleted)"
+	}
+	for _, line := range strings.Split(body, "\n") {
+		fmt.Fprintf(w, "%s  %s\n", indent, line)
+	}
+	if summary := formatReactions(c.Reactions); summary != "" && !c.Deleted {
+		fmt.Fprintf(w, "%s  %s\n", indent, summary)
+	}
+
+	for _, r := range t.Replies {
+		writeCommentThread(streams, w, r, depth+1)
+	}
+}
+
+// reactionEmoji maps the shortcodes reactions may be reported with to
+// their emoji
+var reactionEmoji = map[string]string{
+	"thumbsup":   "👍",
+	"+1":         "👍",
+	"thumbsdown": "👎",
+	"-1":         "👎",
+	"tada":       "🎉",
+	"heart":      "❤️",
+	"smile":      "😄",
+	"laughing":   "😆",
+	"confused":   "😕",
+	"eyes":       "👀",
+	"rocket":     "🚀",
+}
+
+// formatReactions summarizes a comment's reactions, e.g. "👍 3  🎉 1".
+// Unknown shortcodes are shown as :shortcode:.
+func formatReactions(reactions []api.CommentReaction) string {
+	var parts []string
+	for _, r := range reactions {
+		if r.Count <= 0 || r.Emoji == "" {
+			continue
+		}
+		name := strings.Trim(r.Emoji, ":")
+		emoji, ok := reactionEmoji[name]
+		if !ok {
+			emoji = r.Emoji
+			if isShortcode(name) {
+				emoji = ":" + name + ":"
+			}
+		}
+		parts = append(parts, fmt.Sprintf("%s %d", emoji, r.Count))
+	}
+	return strings.Join(parts, "  ")
+}
+
+// isShortcode reports whether s looks like an emoji shortcode rather than
+// an emoji
+func isShortcode(s string) bool {
+	for _, r := range s {
+		if !(r >= 'a' && r <= 'z' || r >= '0' && r <= '9' || r == '_' || r == '+' || r == '-') {
+			return false
+		}
+	}
+	return s != ""
+}
+
+// commentLocation returns the file and line an inline comment was made on
+func commentLocation(c api.PRComment) string {
+	if c.Inline == nil || c.Inline.Path == "" {
+		return ""
+	}
+	line := c.Inline.To
+	if line == 0 {
+		line = c.Inline.From
+	}
+	if line > 0 {
+		return fmt.Sprintf("%s:%d", c.Inline.Path, line)
+	}
+	return c.Inline.Path
+}
@@ -0,0 +1,138 @@
+package pr
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/rbansal42/bitbucket-cli/internal/api"
+	"github.com/rbansal42/bitbucket-cli/internal/iostreams"
+)
+
+func decodeComments(t *testing.T, data string) []api.PRComment {
+	t.Helper()
+	var comments []api.PRComment
+	if err := json.Unmarshal([]byte(data), &comments); err != nil {
+		t.Fatalf("decoding comments: %v", err)
+	}
+	return comments
+}
+
+func TestBuildCommentThreads(t *testing.T) {
+	comments := decodeComments(t, `[
+		{"id": 4, "created_on": "2024-01-01T10:03:00Z", "parent": {"id": 1}},
+		{"id": 1, "created_on": "2024-01-01T10:00:00Z"},
+		{"id": 3, "created_on": "2024-01-01T10:02:00Z", "parent": {"id": 2}},
+		{"id": 2, "created_on": "2024-01-01T10:01:00Z", "parent": {"id": 1}},
+		{"id": 5, "created_on": "2024-01-01T10:04:00Z", "parent": {"id": 99}}
+	]`)
+
+	threads := buildCommentThreads(comments)
+	if len(threads) != 2 || threads[0].Comment.ID != 1 || threads[1].Comment.ID != 5 {
+		t.Fatalf("roots = %v, want comments 1 and 5", threadIDs(threads))
+	}
+	replies := threads[0].Replies
+	if len(replies) != 2 || replies[0].Comment.ID != 2 || replies[1].Comment.ID != 4 {
+		t.Fatalf("replies to 1 = %v, want [2 4]", threadIDs(replies))
+	}
+	if got := threadIDs(replies[0].Replies); len(got) != 1 || got[0] != 3 {
+		t.Errorf("replies to 2 = %v, want [3]", got)
+	}
+}
+
+func threadIDs(threads []*commentThread) []int64 {
+	ids := make([]int64, len(threads))
+	for i, t := range threads {
+		ids[i] = t.Comment.ID
+	}
+	return ids
+}
+
+func TestUnresolvedThreads(t *testing.T) {
+	threads := buildCommentThreads(decodeComments(t, `[
+		{"id": 1, "created_on": "2024-01-01T10:00:00Z", "resolution": {"created_on": "2024-01-02T10:00:00Z"}},
+		{"id": 2, "created_on": "2024-01-01T10:01:00Z"}
+	]`))
+
+	got := threadIDs(unresolvedThreads(threads))
+	if len(got) != 1 || got[0] != 2 {
+		t.Errorf("unresolvedThreads() = %v, want [2]", got)
+	}
+}
+
+func TestWriteCommentThread(t *testing.T) {
+	threads := buildCommentThreads(decodeComments(t, `[
+		{"id": 1, "created_on": "2024-01-01T10:00:00Z", "user": {"display_name": "Jane"},
+		 "content": {"raw": "Why?\nReally?"}, "inline": {"path": "main.go", "to": 12},
+		 "resolution": {"created_on": "2024-01-02T10:00:00Z"}},
+		{"id": 2, "created_on": "2024-01-01T10:01:00Z", "user": {"display_name": "John"},
+		 "content": {"raw": "oops"}, "deleted": true, "parent": {"id": 1}}
+	]`))
+
+	streams := &iostreams.IOStreams{}
+	var buf bytes.Buffer
+	writeCommentThread(streams, &buf, threads[0], 0)
+	out := buf.String()
+
+	for _, want := range []string{
+		"Jane ",
+		" on main.go:12 (resolved)\n",
+		"\n  Why?\n  Really?\n",
+		"\n    John ",
+		"\n      (deleted)\n",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q:\n%s", want, out)
+		}
+	}
+	if strings.Contains(out, "oops") {
+		t.Errorf("deleted comment content was shown:\n%s", out)
+	}
+}
+
+func TestWriteCommentThread_Reactions(t *testing.T) {
+	threads := buildCommentThreads(decodeComments(t, `[
+		{"id": 1, "created_on": "2024-01-01T10:00:00Z", "user": {"display_name": "Jane"},
+		 "content": {"raw": "Ship it"},
+		 "reactions": [{"emoji": "thumbsup", "count": 3}, {"emoji": ":tada:", "count": 1}, {"emoji": "party_parrot", "count": 2}, {"emoji": "🔥", "count": 1}, {"emoji": "eyes", "count": 0}]},
+		{"id": 2, "created_on": "2024-01-01T10:01:00Z", "user": {"display_name": "John"},
+		 "content": {"raw": "Agreed"}, "parent": {"id": 1}}
+	]`))
+
+	var buf bytes.Buffer
+	writeCommentThread(&iostreams.IOStreams{}, &buf, threads[0], 0)
+	want := "  Ship it\n  👍 3  🎉 1  :party_parrot: 2  🔥 1\n    John "
+	if !strings.Contains(buf.String(), want) {
+		t.Errorf("output missing %q:\n%s", want, buf.String())
+	}
+	if strings.Count(buf.String(), "\n") != 5 {
+		t.Errorf("a comment without reactions got a reaction line:\n%s", buf.String())
+	}
+}
+
+func TestFetchComments_Paginates(t *testing.T) {
+	path := "/repositories/ws/repo/pullrequests/7/comments"
+	client := newViewTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != path {
+			http.NotFound(w, r)
+			return
+		}
+		if r.URL.Query().Get("page") == "2" {
+			fmt.Fprint(w, `{"values": [{"id": 2}]}`)
+			return
+		}
+		fmt.Fprintf(w, `{"values": [{"id": 1}], "next": "http://%s%s?page=2"}`, r.Host, path)
+	})
+
+	comments, truncated, err := fetchComments(context.Background(), client, "ws", "repo", 7)
+	if err != nil {
+		t.Fatalf("fetchComments() error: %v", err)
+	}
+	if len(comments) != 2 || truncated {
+		t.Errorf("fetchComments() = %d comments, truncated %v; want 2, false", len(comments), truncated)
+	}
+}
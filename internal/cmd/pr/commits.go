@@ -0,0 +1,120 @@
+package pr
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/rbansal42/bitbucket-cli/internal/api"
+	"github.com/rbansal42/bitbucket-cli/internal/cmdutil"
+	"github.com/rbansal42/bitbucket-cli/internal/iostreams"
+)
+
+type commitsOptions struct {
+	streams *iostreams.IOStreams
+	repo    string
+	limit   int
+	jsonOut bool
+}
+
+// NewCmdCommits creates the commits command
+func NewCmdCommits(streams *iostreams.IOStreams) *cobra.Command {
+	opts := &commitsOptions{
+		streams: streams,
+	}
+
+	cmd := &cobra.Command{
+		Use:   "commits [<number>]",
+		Short: "List the commits in a pull request",
+		Long: `List the commits a pull request would merge, newest first.
+
+When Bitbucket reports signature verification for a commit, a SIGNATURE
+column shows whether it is verified. Use "bb commit view" for the full
+details of a single commit.`,
+		Example: `  # List the commits in pull request #123
+  bb pr commits 123
+
+  # Output as JSON
+  bb pr commits 123 --json`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runCommits(cmd.Context(), opts, args)
+		},
+	}
+
+	cmd.Flags().IntVarP(&opts.limit, "limit", "l", 30, "Maximum number of commits to list")
+	cmd.Flags().BoolVar(&opts.jsonOut, "json", false, "Output in JSON format")
+	cmd.Flags().StringVarP(&opts.repo, "repo", "R", "", "Repository in WORKSPACE/REPO format")
+
+	cmd.ValidArgsFunction = cmdutil.CompletePRNumbers
+	_ = cmd.RegisterFlagCompletionFunc("repo", cmdutil.CompleteRepoNames)
+
+	return cmd
+}
+
+func runCommits(ctx context.Context, opts *commitsOptions, args []string) error {
+	prNum, err := parsePRNumber(args)
+	if err != nil {
+		return err
+	}
+
+	workspace, repoSlug, err := cmdutil.ParseRepository(opts.repo)
+	if err != nil {
+		return err
+	}
+
+	client, err := cmdutil.GetAPIClient()
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	opts.streams.Verbose("Fetching commits for pull request #%d...", prNum)
+	result, err := client.ListPullRequestCommits(ctx, workspace, repoSlug, int64(prNum), opts.limit)
+	if err != nil {
+		return fmt.Errorf("failed to list commits: %w", err)
+	}
+
+	if opts.jsonOut {
+		return cmdutil.PrintJSON(opts.streams, result.Values)
+	}
+
+	if len(result.Values) == 0 {
+		opts.streams.Info("No commits in pull request #%d", prNum)
+		return nil
+	}
+
+	return writeCommitTable(opts.streams, result.Values)
+}
+
+// writeCommitTable lists commits, adding a SIGNATURE column only when at
+// least one of them reports signature verification
+func writeCommitTable(streams *iostreams.IOStreams, commits []api.Commit) error {
+	signed := false
+	for _, c := range commits {
+		if c.Signature != nil {
+			signed = true
+			break
+		}
+	}
+
+	headers := []string{"COMMIT", "MESSAGE", "AUTHOR", "DATE"}
+	if signed {
+		headers = append(headers, "SIGNATURE")
+	}
+	t := cmdutil.NewTableWriter(streams, headers...)
+	t.SetFlexColumn(1)
+	t.SetMaxWidth(2, 25)
+	for _, c := range commits {
+		row := []string{cmdutil.ShortHash(c.Hash), cmdutil.CommitSubject(c.Message), cmdutil.CommitAuthorName(c.Author), cmdutil.TimeAgo(c.Date)}
+		if signed {
+			row = append(row, cmdutil.SignatureBadge(streams, c.Signature))
+		}
+		t.AddRow(row...)
+	}
+	return t.Render()
+}
@@ -1,13 +1,16 @@
 package pr
 
 import (
-	"bufio"
 	"bytes"
 	"context"
-	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
+	"net"
+	"net/http"
 	"os"
 	"os/exec"
+	"regexp"
 	"strings"
 	"time"
 
@@ -21,17 +24,27 @@ import (
 )
 
 type createOptions struct {
-	streams          *iostreams.IOStreams
-	title            string
-	body             string
-	baseBranch       string
-	headBranch       string
-	reviewers        []string
-	fill             bool
-	draft            bool
-	web              bool
-	noMaintainerEdit bool
-	repo             string
+	streams            *iostreams.IOStreams
+	title              string
+	body               string
+	titleFile          string
+	bodyFile           string
+	templateFile       string
+	baseBranch         string
+	headBranch         string
+	headRepo           string
+	baseRepo           string // destination WORKSPACE/REPO, set once resolved
+	reviewers          []string
+	noDefaultReviewers bool
+	requireReviewers   bool
+	fill               bool
+	fillFirst          bool
+	draft              bool
+	web                bool
+	noMaintainerEdit   bool
+	push               bool // push the head branch if it isn't pushed
+	noPush             bool // skip the check that the head branch is pushed
+	repo               string
 }
 
 // NewCmdCreate creates the create command
@@ -46,22 +59,63 @@ func NewCmdCreate(streams *iostreams.IOStreams) *cobra.Command {
 		Long: `Create a pull request from the current branch.
 
 The current branch will be used as the source branch. By default, the destination
-branch is the repository's default branch (usually main or master).
+branch is the development branch of the repository's branching model, or the
+repository's default branch (usually main or master) if the model has none.
 
 If --title is not provided, you will be prompted to enter a title interactively.
-If --body is not provided, an editor will open for you to write the description.`,
+If --body is not provided, an editor will open for you to write the description.
+The title and body can also be read from files with --title-file and --body-file,
+or together with --template-file, whose first line is the title and the rest
+the body. Use "-" to read from standard input.
+
+A description written in the editor is kept as a draft under ~/.cache/bb/drafts
+until the pull request is created. If the editor crashes or creation fails, the
+next pr create on the same branch offers to recover it.
+
+The repository's default reviewers are added automatically, together with any
+--reviewer values. Use --no-default-reviewers to skip them. Reviewers that
+cannot be found are skipped with a warning, or stop the command with
+--require-reviewers.
+
+Before calling the API, bb checks that the head branch has been pushed: the
+git remote for the repository (the --repo repository, or the one detected
+from the remotes) must have a branch of the same name with all of its
+commits. If not, the command stops and asks you to push, unless --push is
+given, in which case bb runs 'git push --set-upstream' to that remote first.
+Without a remote for the repository the check fails. Use --no-push to skip
+it, for example when the branch was pushed from elsewhere. Branches given
+as WORKSPACE/REPO:BRANCH are not checked.
+
+If the create request times out or the connection drops, bb checks whether the
+pull request was created anyway and reports it as created, so the command is
+safe to retry.`,
 		Example: `  # Create a pull request interactively
   bb pr create
 
   # Create a pull request with title and body
   bb pr create --title "Add new feature" --body "Description of changes"
 
+  # Read the description from a generated file
+  bb pr create --title "Release 1.2" --body-file release-notes.md
+
+  # Read title and body from a template (first line is the title)
+  generate-pr-text | bb pr create --template-file -
+
   # Create a pull request with auto-filled title from commits
   bb pr create --fill
 
+  # Push the current branch first if needed
+  bb pr create --fill --push
+
+  # Use only the first commit's subject and body
+  bb pr create --fill-first
+
   # Create a pull request to a specific base branch
   bb pr create --base develop
 
+  # Open a pull request from a fork's branch into the upstream repository
+  bb pr create --head contributor/myrepo:fix-typo --title "Fix typo"
+
   # Create a pull request with reviewers
   bb pr create --title "My PR" --reviewer user1 --reviewer user2
 
@@ -74,15 +128,28 @@ If --body is not provided, an editor will open for you to write the description.
 
 	cmd.Flags().StringVarP(&opts.title, "title", "t", "", "Title of the pull request")
 	cmd.Flags().StringVarP(&opts.body, "body", "b", "", "Body/description of the pull request")
-	cmd.Flags().StringVar(&opts.baseBranch, "base", "", "Base branch (destination). Defaults to repository's default branch")
-	cmd.Flags().StringVar(&opts.headBranch, "head", "", "Head branch (source). Defaults to current branch")
+	cmd.Flags().StringVarP(&opts.bodyFile, "body-file", "F", "", "Read body text from file (use \"-\" to read from standard input)")
+	cmd.Flags().StringVar(&opts.titleFile, "title-file", "", "Read title from file (use \"-\" to read from standard input)")
+	cmd.Flags().StringVar(&opts.templateFile, "template-file", "", "Read title (first line) and body (remaining lines) from file")
+	cmd.Flags().StringVar(&opts.baseBranch, "base", "", "Base branch (destination). Defaults to the development branch of the repository's branching model")
+	cmd.Flags().StringVar(&opts.headBranch, "head", "", "Head branch (source), or WORKSPACE/REPO:BRANCH for a fork. Defaults to current branch")
 	cmd.Flags().StringArrayVarP(&opts.reviewers, "reviewer", "r", nil, "Add reviewer by username (can be repeated)")
+	cmd.Flags().BoolVar(&opts.noDefaultReviewers, "no-default-reviewers", false, "Do not add the repository's default reviewers")
+	cmd.Flags().BoolVar(&opts.requireReviewers, "require-reviewers", false, "Fail if any --reviewer cannot be found")
 	cmd.Flags().BoolVar(&opts.fill, "fill", false, "Auto-fill title and body from commits")
+	cmd.Flags().BoolVar(&opts.fillFirst, "fill-first", false, "Auto-fill title and body from the first commit only")
 	cmd.Flags().BoolVarP(&opts.draft, "draft", "d", false, "Create as draft (adds [DRAFT] prefix to title)")
 	cmd.Flags().BoolVarP(&opts.web, "web", "w", false, "Open the created pull request in the browser")
 	cmd.Flags().BoolVar(&opts.noMaintainerEdit, "no-maintainer-edit", false, "Disable maintainer edits (not supported by Bitbucket)")
+	cmd.Flags().BoolVar(&opts.push, "push", false, "Push the head branch with 'git push --set-upstream' if it isn't pushed")
+	cmd.Flags().BoolVar(&opts.noPush, "no-push", false, "Don't check that the head branch is pushed")
 	cmd.Flags().StringVarP(&opts.repo, "repo", "R", "", "Repository in WORKSPACE/REPO format")
 
+	cmd.MarkFlagsMutuallyExclusive("push", "no-push")
+	cmd.MarkFlagsMutuallyExclusive("fill", "fill-first")
+	cmd.MarkFlagsMutuallyExclusive("body", "body-file", "template-file")
+	cmd.MarkFlagsMutuallyExclusive("title", "title-file", "template-file")
+
 	_ = cmd.RegisterFlagCompletionFunc("base", cmdutil.CompleteBranchNames)
 	_ = cmd.RegisterFlagCompletionFunc("head", cmdutil.CompleteBranchNames)
 	_ = cmd.RegisterFlagCompletionFunc("reviewer", cmdutil.CompleteWorkspaceMembers)
@@ -92,12 +159,22 @@ If --body is not provided, an editor will open for you to write the description.
 }
 
 func runCreate(opts *createOptions) error {
+	if err := readTitleAndBodyFiles(opts); err != nil {
+		return err
+	}
+
 	// Resolve repository
 	workspace, repoSlug, err := cmdutil.ParseRepository(opts.repo)
 	if err != nil {
 		return err
 	}
 
+	// Split a WORKSPACE/REPO:BRANCH head into the fork and its branch
+	opts.headRepo, opts.headBranch, err = parseHeadRef(opts.headBranch)
+	if err != nil {
+		return err
+	}
+
 	// Get current branch as head if not specified
 	if opts.headBranch == "" {
 		opts.headBranch, err = git.GetCurrentBranch()
@@ -107,10 +184,18 @@ func runCreate(opts *createOptions) error {
 	}
 
 	// Prevent creating PR from main/master
-	if opts.headBranch == "main" || opts.headBranch == "master" {
+	if opts.headRepo == "" && (opts.headBranch == "main" || opts.headBranch == "master") {
 		return fmt.Errorf("cannot create a pull request from branch %q - please switch to a feature branch", opts.headBranch)
 	}
 
+	// Bitbucket's error for a branch it doesn't have is unclear, so check
+	// with git before any API call
+	if !opts.noPush {
+		if err := ensureHeadPushed(opts, workspace, repoSlug); err != nil {
+			return err
+		}
+	}
+
 	// Get authenticated client
 	client, err := cmdutil.GetAPIClient()
 	if err != nil {
@@ -120,31 +205,35 @@ func runCreate(opts *createOptions) error {
 	ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
 	defer cancel()
 
-	// Get default branch if base not specified
-	if opts.baseBranch == "" {
-		defaultBranch, err := getDefaultBranch(ctx, client, workspace, repoSlug)
-		if err != nil {
-			opts.streams.Warning("Could not determine default branch, using 'main': %v", err)
-			opts.baseBranch = "main"
-		} else {
-			opts.baseBranch = defaultBranch
-		}
+	workspace, repoSlug, err = resolveDestination(ctx, client, opts, workspace, repoSlug)
+	if err != nil {
+		return err
 	}
 
 	// Check if PR already exists for this branch
-	existingPR, _ := findExistingPR(ctx, client, workspace, repoSlug, opts.headBranch)
+	existingPR, _ := findExistingPR(ctx, client, workspace, repoSlug, opts.headRepo, opts.headBranch)
 	if existingPR != nil {
-		return fmt.Errorf("a pull request already exists for branch %q: %s", opts.headBranch, existingPR.Links.HTML.Href)
+		return fmt.Errorf("a pull request already exists for branch %q: %s", headLabel(opts), existingPR.Links.HTML.Href)
+	}
+
+	// Resolve reviewer UUIDs before asking for a title and body, so a
+	// missing reviewer with --require-reviewers fails early
+	var reviewerUUIDs []string
+	if len(opts.reviewers) > 0 {
+		reviewerUUIDs, err = resolveReviewers(ctx, client, opts.streams, workspace, opts.reviewers, opts.requireReviewers)
+		if err != nil {
+			return err
+		}
 	}
 
-	// Handle --fill flag
-	if opts.fill {
+	// Handle --fill and --fill-first flags
+	if opts.fill || opts.fillFirst {
 		fillFromCommits(opts)
 	}
 
 	// Interactive mode: prompt for title if not provided
 	if opts.title == "" {
-		title, err := promptForTitle(opts.streams)
+		title, err := cmdutil.Prompt(opts.streams, "Title: ", "pass --title")
 		if err != nil {
 			return err
 		}
@@ -161,26 +250,22 @@ func runCreate(opts *createOptions) error {
 		}
 	}
 
-	// Interactive mode: open editor for body if not provided and stdin is TTY
-	if opts.body == "" && opts.streams.IsStdinTTY() && !opts.fill {
-		body, err := openEditor(getBodyTemplate(opts))
+	// Interactive mode: open editor for body if not provided and we may
+	// prompt. The body is kept as a draft until the pull request is created.
+	var draft string
+	if opts.body == "" && opts.streams.CanPrompt() && !opts.fill && !opts.fillFirst {
+		draft, err = editBodyDraft(opts, workspace, repoSlug)
 		if err != nil {
-			opts.streams.Warning("Could not open editor: %v", err)
-		} else {
-			opts.body = cleanupBody(body)
+			return err
 		}
 	}
 
 	// Display what we're about to do
-	opts.streams.Info("Creating pull request for %s into %s\n", opts.headBranch, opts.baseBranch)
+	opts.streams.Info("Creating pull request for %s into %s\n", headLabel(opts), opts.baseBranch)
 
-	// Resolve reviewer UUIDs
-	var reviewerUUIDs []string
-	if len(opts.reviewers) > 0 {
-		reviewerUUIDs, err = resolveReviewers(ctx, client, workspace, opts.reviewers)
-		if err != nil {
-			opts.streams.Warning("Could not resolve some reviewers: %v", err)
-		}
+	// Add the repository's default reviewers, as the web UI does
+	if !opts.noDefaultReviewers {
+		reviewerUUIDs = addDefaultReviewers(ctx, client, opts.streams, workspace, repoSlug, reviewerUUIDs)
 	}
 
 	// Create the PR
@@ -188,15 +273,31 @@ func runCreate(opts *createOptions) error {
 		Title:             opts.title,
 		Description:       opts.body,
 		SourceBranch:      opts.headBranch,
+		SourceRepo:        opts.headRepo,
 		DestinationBranch: opts.baseBranch,
 		CloseSourceBranch: false,
 		Reviewers:         reviewerUUIDs,
 	}
 
+	opts.streams.Verbose("Creating pull request from %s into %s...", opts.headBranch, opts.baseBranch)
 	pr, err := client.CreatePullRequest(ctx, workspace, repoSlug, createOpts)
+	if err != nil && isAmbiguousCreateError(err) {
+		// The request may have reached the server before the connection
+		// failed; if the pull request now exists, the create succeeded
+		opts.streams.Warning("Request failed (%v); checking whether the pull request was created", err)
+		pr = recoverCreatedPR(client, workspace, repoSlug, opts.headRepo, opts.headBranch)
+		if pr != nil {
+			opts.streams.Success("Pull request #%d was created", pr.ID)
+			err = nil
+		}
+	}
 	if err != nil {
+		if draft != "" {
+			opts.streams.Warning("Your description was saved and will be offered the next time you run bb pr create on this branch")
+		}
 		return fmt.Errorf("failed to create pull request: %w", err)
 	}
+	clearDraft(draft)
 
 	// Print success message
 	fmt.Fprintln(opts.streams.Out)
@@ -212,36 +313,196 @@ func runCreate(opts *createOptions) error {
 	return nil
 }
 
-// getDefaultBranch fetches the repository's default branch
-func getDefaultBranch(ctx context.Context, client *api.Client, workspace, repoSlug string) (string, error) {
-	path := fmt.Sprintf("/repositories/%s/%s", workspace, repoSlug)
-	resp, err := client.Get(ctx, path, nil)
+// ensureHeadPushed checks that the git remote for workspace/repoSlug, the
+// repository the pull request's branch is read from, has all of the head
+// branch's commits under the same name. With --push a branch that isn't
+// pushed is pushed there; otherwise it is an error. Fork heads and branches
+// that don't exist locally are left to Bitbucket.
+func ensureHeadPushed(opts *createOptions, workspace, repoSlug string) error {
+	branch := opts.headBranch
+	if opts.headRepo != "" || !git.RefExists("refs/heads/"+branch) {
+		return nil
+	}
+
+	remotes, err := git.GetRemotes()
+	if err != nil {
+		return err
+	}
+	remote := remoteFor(remotes, workspace+"/"+repoSlug)
+	if remote == "" {
+		return fmt.Errorf("no git remote points to %s/%s, so branch %q can't be checked or pushed; add one with 'git remote add', or use --no-push", workspace, repoSlug, branch)
+	}
+	remoteRef := "refs/remotes/" + remote + "/" + branch
+	var problem string
+	if !git.RefExists(remoteRef) {
+		problem = fmt.Sprintf("branch %q is not pushed to %s", branch, remote)
+	} else {
+		n, err := git.CountCommits(remoteRef, "refs/heads/"+branch)
+		if err != nil {
+			return fmt.Errorf("failed to check whether branch %q is pushed to %s: %w; use --no-push to skip the check", branch, remote, err)
+		}
+		if n > 0 {
+			commits := "commits"
+			if n == 1 {
+				commits = "commit"
+			}
+			problem = fmt.Sprintf("branch %q has %d %s not pushed to %s", branch, n, commits, remote)
+		}
+	}
+	if problem == "" {
+		return nil
+	}
+	if !opts.push {
+		// The check uses the remote-tracking branch, which is only as
+		// current as the last fetch
+		return fmt.Errorf("%s; run 'git push --set-upstream %s %s' or use --push. If it was pushed from elsewhere, run 'git fetch %s' first, or use --no-push to skip the check", problem, remote, branch, remote)
+	}
+
+	opts.streams.Info("Pushing %s to %s...", branch, remote)
+	push := exec.Command("git", "push", "--set-upstream", remote, branch)
+	push.Stdout = opts.streams.ErrOut
+	push.Stderr = opts.streams.ErrOut
+	if err := push.Run(); err != nil {
+		return fmt.Errorf("failed to push %s: %w", branch, err)
+	}
+	opts.streams.Success("Pushed %s to %s", branch, remote)
+	return nil
+}
+
+// createRecheckAttempts and createRecheckDelay control how long pr create
+// waits for a pull request to appear after an ambiguous failure
+const (
+	createRecheckAttempts = 3
+	createRecheckDelay    = 2 * time.Second
+)
+
+// isAmbiguousCreateError reports whether a create request failed in a way
+// that leaves it unknown whether the server created the pull request: a
+// timeout or dropped connection, or a gateway error from a proxy that gave
+// up waiting for Bitbucket, rather than an error response from Bitbucket
+func isAmbiguousCreateError(err error) bool {
+	var apiErr *api.APIError
+	if errors.As(err, &apiErr) {
+		return apiErr.StatusCode == http.StatusBadGateway || apiErr.StatusCode == http.StatusGatewayTimeout
+	}
+
+	var netErr net.Error
+	return errors.Is(err, context.DeadlineExceeded) || errors.As(err, &netErr)
+}
+
+// recoverCreatedPR looks for an open pull request for the head branch after
+// an ambiguous create failure, retrying briefly in case it is not yet listed
+func recoverCreatedPR(client *api.Client, workspace, repoSlug, headRepo, headBranch string) *api.PullRequest {
+	for attempt := 0; attempt < createRecheckAttempts; attempt++ {
+		if attempt > 0 {
+			time.Sleep(createRecheckDelay)
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		pr, err := findExistingPR(ctx, client, workspace, repoSlug, headRepo, headBranch)
+		cancel()
+		if err == nil && pr != nil {
+			return pr
+		}
+	}
+	return nil
+}
+
+// parseHeadRef splits a --head value of the form WORKSPACE/REPO:BRANCH into
+// the source repository and branch. A plain branch name has no repository.
+func parseHeadRef(head string) (repo, branch string, err error) {
+	repoPart, branch, found := strings.Cut(head, ":")
+	if !found {
+		return "", head, nil
+	}
+
+	// An empty repository must not fall back to the git remote
+	if repoPart == "" {
+		return "", "", fmt.Errorf("invalid --head %q: expected BRANCH or WORKSPACE/REPO:BRANCH", head)
+	}
+	ws, slug, err := cmdutil.ParseRepoArg(repoPart)
+	if err != nil {
+		return "", "", fmt.Errorf("invalid --head %q: expected BRANCH or WORKSPACE/REPO:BRANCH", head)
+	}
+	if branch == "" {
+		return "", "", fmt.Errorf("invalid --head %q: branch name is missing", head)
+	}
+
+	return ws + "/" + slug, branch, nil
+}
+
+// resolveUpstream returns the parent of a forked repository
+func resolveUpstream(ctx context.Context, client *api.Client, workspace, repoSlug string) (string, string, error) {
+	repo, err := client.GetRepository(ctx, workspace, repoSlug)
 	if err != nil {
-		return "", err
+		return "", "", fmt.Errorf("failed to look up upstream of %s/%s: %w", workspace, repoSlug, err)
+	}
+	if repo.Parent == nil || repo.Parent.FullName == "" {
+		return "", "", fmt.Errorf("%s/%s is not a fork; use --repo to choose the destination repository", workspace, repoSlug)
 	}
 
-	var repo struct {
-		MainBranch struct {
-			Name string `json:"name"`
-		} `json:"mainbranch"`
+	return cmdutil.ParseRepository(repo.Parent.FullName)
+}
+
+// resolveDestination returns the repository the pull request is opened
+// against and fills in the default base branch. A fork's branch, given as
+// --head WORKSPACE/REPO:BRANCH, targets the upstream repository: when run
+// from a clone of the fork without --repo, that is the fork's parent. The
+// base branch and branching model always come from the destination, never
+// the fork.
+func resolveDestination(ctx context.Context, client *api.Client, opts *createOptions, workspace, repoSlug string) (string, string, error) {
+	var err error
+	if opts.headRepo != "" && opts.repo == "" && strings.EqualFold(opts.headRepo, workspace+"/"+repoSlug) {
+		workspace, repoSlug, err = resolveUpstream(ctx, client, workspace, repoSlug)
+		if err != nil {
+			return "", "", err
+		}
 	}
-	if err := parseResponseBody(resp.Body, &repo); err != nil {
-		return "", err
+	if strings.EqualFold(opts.headRepo, workspace+"/"+repoSlug) {
+		opts.headRepo = ""
 	}
+	opts.baseRepo = workspace + "/" + repoSlug
 
-	if repo.MainBranch.Name == "" {
-		return "main", nil
+	// Default the base to the branching model's development branch. A
+	// fork's branch is never the destination's development branch, even if
+	// it shares its name.
+	if opts.baseBranch == "" {
+		head := opts.headBranch
+		if opts.headRepo != "" {
+			head = ""
+		}
+		opts.baseBranch, err = defaultBaseBranch(ctx, client, workspace, repoSlug, head)
+		if err != nil {
+			return "", "", fmt.Errorf("could not determine the default branch; use --base: %w", err)
+		}
 	}
-	return repo.MainBranch.Name, nil
+
+	return workspace, repoSlug, nil
 }
 
-// parseResponseBody parses JSON response body
-func parseResponseBody(body []byte, v interface{}) error {
-	return json.Unmarshal(body, v)
+// defaultBaseBranch returns the development branch of the repository's
+// branching model, or the repository's default branch when the model has no
+// usable development branch or head is the development branch itself
+func defaultBaseBranch(ctx context.Context, client *api.Client, workspace, repoSlug, head string) (string, error) {
+	model, err := client.GetBranchingModel(ctx, workspace, repoSlug)
+	if err == nil && model.Development != nil && !model.Development.UseMainbranch &&
+		model.Development.Branch != nil && model.Development.Name != head {
+		return model.Development.Name, nil
+	}
+	return cmdutil.DefaultBranch(ctx, client, workspace, repoSlug)
 }
 
-// findExistingPR checks if there's already an open PR for the given branch
-func findExistingPR(ctx context.Context, client *api.Client, workspace, repoSlug, branch string) (*api.PullRequest, error) {
+// headLabel describes the source branch, including the fork when there is one
+func headLabel(opts *createOptions) string {
+	if opts.headRepo != "" {
+		return opts.headRepo + ":" + opts.headBranch
+	}
+	return opts.headBranch
+}
+
+// findExistingPR checks if there's already an open PR for the given branch.
+// sourceRepo limits the match to a fork's branch; empty means the same repository.
+func findExistingPR(ctx context.Context, client *api.Client, workspace, repoSlug, sourceRepo, branch string) (*api.PullRequest, error) {
 	opts := &api.PRListOptions{
 		State: api.PRStateOpen,
 		Limit: 100,
@@ -253,7 +514,14 @@ func findExistingPR(ctx context.Context, client *api.Client, workspace, repoSlug
 	}
 
 	for _, pr := range result.Values {
-		if pr.Source.Branch.Name == branch {
+		if pr.Source.Branch.Name != branch {
+			continue
+		}
+		prRepo := ""
+		if pr.Source.Repository != nil && !strings.EqualFold(pr.Source.Repository.FullName, workspace+"/"+repoSlug) {
+			prRepo = pr.Source.Repository.FullName
+		}
+		if strings.EqualFold(prRepo, sourceRepo) {
 			return &pr, nil
 		}
 	}
@@ -261,78 +529,252 @@ func findExistingPR(ctx context.Context, client *api.Client, workspace, repoSlug
 	return nil, nil
 }
 
-// fillFromCommits fills title and body from git commits
+// commitMessage is the subject and body of a single commit
+type commitMessage struct {
+	subject string
+	body    string
+}
+
+// draftPrefixPattern matches [DRAFT]/[WIP]-style markers at the start of a title
+var draftPrefixPattern = regexp.MustCompile(`(?i)^\s*(\[(draft|wip)\]|\((draft|wip)\)|(draft|wip):)\s*`)
+
+// stripDraftPrefix removes draft markers from a title detected from commits;
+// --draft adds its own marker back
+func stripDraftPrefix(title string) string {
+	for draftPrefixPattern.MatchString(title) {
+		title = draftPrefixPattern.ReplaceAllString(title, "")
+	}
+	return strings.TrimSpace(title)
+}
+
+// fillFromCommits fills title and body from git commits. With --fill the
+// newest commit becomes the title and the others are listed in the body;
+// with --fill-first only the branch's first commit is used.
 func fillFromCommits(opts *createOptions) {
-	// Get commits between base and head
-	commits, err := getCommitMessages(opts.baseBranch, opts.headBranch)
+	baseRemote, headRef := commitRefs(opts)
+	commits, err := getCommitMessages(baseRemote, opts.baseBranch, headRef)
 	if err != nil {
+		opts.streams.Warning("Could not read commits for --fill: %v", err)
 		return
 	}
 
 	if len(commits) == 0 {
+		opts.streams.Warning("No commits found between %s and %s; nothing to fill from", opts.baseBranch, opts.headBranch)
+		return
+	}
+
+	if opts.fillFirst {
+		first := commits[len(commits)-1]
+		if opts.title == "" {
+			opts.title = stripDraftPrefix(first.subject)
+		}
+		if opts.body == "" {
+			opts.body = first.body
+		}
 		return
 	}
 
 	// Use first commit as title
-	if opts.title == "" && len(commits) > 0 {
-		opts.title = commits[0]
+	if opts.title == "" {
+		opts.title = stripDraftPrefix(commits[0].subject)
 	}
 
 	// Use remaining commits as body
 	if opts.body == "" && len(commits) > 1 {
 		var bodyLines []string
 		for _, commit := range commits[1:] {
-			bodyLines = append(bodyLines, "- "+commit)
+			bodyLines = append(bodyLines, "- "+commit.subject)
 		}
 		opts.body = strings.Join(bodyLines, "\n")
 	}
 }
 
-// getCommitMessages returns commit messages between base and head
-func getCommitMessages(base, head string) ([]string, error) {
-	// Try to get commits
-	cmd := exec.Command("git", "log", "--format=%s", fmt.Sprintf("origin/%s..%s", base, head))
+// commitRefs returns the git remote of the destination repository and the
+// ref of the head branch, so that a fork's commits are compared against the
+// upstream base rather than the fork's copy of it. The destination's remote
+// defaults to origin; a fork's branch that isn't checked out locally is read
+// from the fork's remote.
+func commitRefs(opts *createOptions) (baseRemote, headRef string) {
+	remotes, _ := git.GetBitbucketRemotes()
+
+	baseRemote = remoteFor(remotes, opts.baseRepo)
+	if baseRemote == "" {
+		baseRemote = "origin"
+	}
+
+	headRef = opts.headBranch
+	if opts.headRepo != "" && !git.RefExists("refs/heads/"+opts.headBranch) {
+		if remote := remoteFor(remotes, opts.headRepo); remote != "" {
+			headRef = remote + "/" + opts.headBranch
+		}
+	}
+	return baseRemote, headRef
+}
+
+// remoteFor returns the name of the remote pointing at the repository
+// WORKSPACE/REPO, or "" if there is none
+func remoteFor(remotes []git.Remote, fullName string) string {
+	if fullName == "" {
+		return ""
+	}
+	for _, r := range remotes {
+		if strings.EqualFold(r.Workspace+"/"+r.RepoSlug, fullName) {
+			return r.Name
+		}
+	}
+	return ""
+}
+
+// resolveBaseRef returns the ref to compare the head branch against,
+// preferring the remote-tracking branch and fetching it if it is missing
+func resolveBaseRef(remote, base string) (string, error) {
+	remoteRef := remote + "/" + base
+	if git.RefExists(remoteRef) {
+		return remoteRef, nil
+	}
+	if git.RefExists(base) {
+		return base, nil
+	}
+
+	if err := git.Fetch(remote, base); err != nil {
+		return "", fmt.Errorf("base branch %s is not available locally: %w", base, err)
+	}
+	if git.RefExists(remoteRef) {
+		return remoteRef, nil
+	}
+	return "FETCH_HEAD", nil
+}
+
+// getCommitMessages returns the commits on head that are not on base, newest
+// first, reading base from remote
+func getCommitMessages(remote, base, head string) ([]commitMessage, error) {
+	baseRef, err := resolveBaseRef(remote, base)
+	if err != nil {
+		return nil, err
+	}
+
+	mergeBase, err := git.MergeBase(baseRef, head)
+	if err != nil {
+		return nil, err
+	}
+
+	// Separate fields with unit separators and records with record separators
+	// so multi-line bodies survive intact
+	cmd := exec.Command("git", "log", "--format=%s%x1f%b%x1e", fmt.Sprintf("%s..%s", mergeBase, head))
 	var stdout, stderr bytes.Buffer
 	cmd.Stdout = &stdout
 	cmd.Stderr = &stderr
 
 	if err := cmd.Run(); err != nil {
-		// Fallback: try without origin/ prefix
-		cmd = exec.Command("git", "log", "--format=%s", fmt.Sprintf("%s..%s", base, head))
-		cmd.Stdout = &stdout
-		cmd.Stderr = &stderr
-		if err := cmd.Run(); err != nil {
-			return nil, err
+		return nil, fmt.Errorf("git log failed: %s", strings.TrimSpace(stderr.String()))
+	}
+
+	return parseCommitLog(stdout.String()), nil
+}
+
+// parseCommitLog parses the output of git log --format=%s%x1f%b%x1e
+func parseCommitLog(output string) []commitMessage {
+	var commits []commitMessage
+	for _, record := range strings.Split(output, "\x1e") {
+		record = strings.TrimSpace(record)
+		if record == "" {
+			continue
 		}
+		subject, body, _ := strings.Cut(record, "\x1f")
+		subject = strings.TrimSpace(subject)
+		if subject == "" {
+			continue
+		}
+		commits = append(commits, commitMessage{
+			subject: subject,
+			body:    strings.TrimSpace(body),
+		})
 	}
+	return commits
+}
 
-	lines := strings.Split(strings.TrimSpace(stdout.String()), "\n")
-	var commits []string
-	for _, line := range lines {
-		line = strings.TrimSpace(line)
-		if line != "" {
-			commits = append(commits, line)
+// readTitleAndBodyFiles fills the title and body from --title-file,
+// --body-file and --template-file
+func readTitleAndBodyFiles(opts *createOptions) error {
+	stdinUsers := 0
+	for _, path := range []string{opts.titleFile, opts.bodyFile, opts.templateFile} {
+		if path == "-" {
+			stdinUsers++
 		}
 	}
+	if stdinUsers > 1 {
+		return fmt.Errorf("only one of --title-file, --body-file and --template-file can read from standard input")
+	}
 
-	return commits, nil
-}
+	if opts.templateFile != "" {
+		content, err := readTextInput(opts.templateFile, opts.streams.In)
+		if err != nil {
+			return err
+		}
+		opts.title, opts.body = splitTemplate(content)
+		if opts.title == "" {
+			return fmt.Errorf("template %s has an empty first line; expected the pull request title", describeInput(opts.templateFile))
+		}
+	}
 
-// promptForTitle prompts the user to enter a title
-func promptForTitle(streams *iostreams.IOStreams) (string, error) {
-	if !streams.IsStdinTTY() {
-		return "", fmt.Errorf("--title flag is required when not running interactively")
+	if opts.titleFile != "" {
+		title, err := readTextInput(opts.titleFile, opts.streams.In)
+		if err != nil {
+			return err
+		}
+		opts.title = strings.TrimSpace(title)
 	}
 
-	fmt.Fprint(streams.Out, "Title: ")
+	if opts.bodyFile != "" {
+		body, err := readTextInput(opts.bodyFile, opts.streams.In)
+		if err != nil {
+			return err
+		}
+		opts.body = body
+	}
 
-	reader := bufio.NewReader(os.Stdin)
-	title, err := reader.ReadString('\n')
+	return nil
+}
+
+// readTextInput reads the file at path, or stdin when path is "-", with a
+// single trailing newline removed. Empty input is an error.
+func readTextInput(path string, stdin io.Reader) (string, error) {
+	var (
+		data []byte
+		err  error
+	)
+	if path == "-" {
+		data, err = io.ReadAll(stdin)
+	} else {
+		data, err = os.ReadFile(path)
+	}
 	if err != nil {
-		return "", err
+		return "", fmt.Errorf("failed to read %s: %w", describeInput(path), err)
 	}
 
-	return strings.TrimSpace(title), nil
+	text := strings.TrimSuffix(string(data), "\n")
+	text = strings.TrimSuffix(text, "\r")
+	if strings.TrimSpace(text) == "" {
+		return "", fmt.Errorf("%s is empty", describeInput(path))
+	}
+	return text, nil
+}
+
+// describeInput names a file argument for error messages
+func describeInput(path string) string {
+	if path == "-" {
+		return "standard input"
+	}
+	return path
+}
+
+// splitTemplate splits template content into a title (the first line) and a
+// body (the remaining lines, without leading blank lines)
+func splitTemplate(content string) (title, body string) {
+	title, body, _ = strings.Cut(content, "\n")
+	title = strings.TrimSpace(title)
+	body = strings.TrimLeft(body, "\r\n")
+	return title, body
 }
 
 // getBodyTemplate returns a template for the PR body
@@ -365,64 +807,70 @@ func cleanupBody(body string) string {
 	return strings.TrimSpace(strings.Join(result, "\n"))
 }
 
-// resolveReviewers resolves usernames to UUIDs
-func resolveReviewers(ctx context.Context, client *api.Client, workspace string, usernames []string) ([]string, error) {
-	var uuids []string
-
-	for _, username := range usernames {
-		// Try to get user by username
-		uuid, err := getUserUUID(ctx, client, workspace, username)
-		if err != nil {
-			continue // Skip failed lookups
-		}
-		uuids = append(uuids, uuid)
+// resolveReviewers resolves --reviewer usernames to UUIDs. Each user that
+// cannot be found is reported by name; with require set that is an error
+// instead of a warning.
+func resolveReviewers(ctx context.Context, client *api.Client, streams *iostreams.IOStreams, workspace string, names []string, require bool) ([]string, error) {
+	uuids, err := cmdutil.UserResolverFor(client, workspace).ResolveAll(ctx, names)
+	var unresolved *cmdutil.UnresolvedUsersError
+	if !errors.As(err, &unresolved) {
+		return uuids, err
 	}
 
+	if require {
+		return nil, fmt.Errorf("could not find reviewers %s: %w", strings.Join(unresolved.Names, ", "), err)
+	}
+	for _, name := range unresolved.Names {
+		streams.Warning("Could not find user: %s", name)
+	}
 	return uuids, nil
 }
 
-// getUserUUID looks up a user's UUID by username
-func getUserUUID(ctx context.Context, client *api.Client, workspace, username string) (string, error) {
-	// First try as workspace member
-	path := fmt.Sprintf("/workspaces/%s/members", workspace)
-	resp, err := client.Get(ctx, path, nil)
-	if err == nil {
-		var members struct {
-			Values []struct {
-				User struct {
-					UUID     string `json:"uuid"`
-					Username string `json:"username"`
-					Nickname string `json:"nickname"`
-				} `json:"user"`
-			} `json:"values"`
-		}
-		if parseErr := parseJSONResponse(resp.Body, &members); parseErr == nil {
-			for _, m := range members.Values {
-				if m.User.Username == username || m.User.Nickname == username {
-					return m.User.UUID, nil
-				}
-			}
-		}
-	}
-
-	// Fallback: try to get user directly
-	userPath := fmt.Sprintf("/users/%s", username)
-	resp, err = client.Get(ctx, userPath, nil)
+// addDefaultReviewers appends the repository's default reviewers to uuids and
+// reports which reviewers were added
+func addDefaultReviewers(ctx context.Context, client *api.Client, streams *iostreams.IOStreams, workspace, repoSlug string, uuids []string) []string {
+	result, err := client.GetDefaultReviewers(ctx, workspace, repoSlug)
 	if err != nil {
-		return "", fmt.Errorf("user not found: %s", username)
+		streams.Warning("Could not fetch default reviewers: %v", err)
+		return uuids
 	}
 
-	var user struct {
-		UUID string `json:"uuid"`
+	// Bitbucket rejects the author as a reviewer, so leave ourselves out
+	selfUUID := ""
+	if user, err := client.CurrentUser(ctx); err == nil {
+		selfUUID = user.UUID
 	}
-	if err := parseJSONResponse(resp.Body, &user); err != nil {
-		return "", err
+
+	merged, added := mergeReviewers(uuids, result.Values, selfUUID)
+	if len(added) > 0 {
+		names := make([]string, len(added))
+		for i, u := range added {
+			names[i] = cmdutil.GetUserDisplayName(&u)
+		}
+		streams.Info("Adding default reviewers: %s", strings.Join(names, ", "))
 	}
 
-	return user.UUID, nil
+	return merged
 }
 
-// parseJSONResponse parses JSON response body into the given interface
-func parseJSONResponse(body []byte, v interface{}) error {
-	return json.Unmarshal(body, v)
+// mergeReviewers merges default reviewers into the explicit reviewer UUIDs,
+// skipping duplicates and the PR author. It returns the merged list and the
+// default reviewers that were actually added.
+func mergeReviewers(uuids []string, defaults []api.User, selfUUID string) ([]string, []api.User) {
+	seen := make(map[string]bool, len(uuids))
+	for _, uuid := range uuids {
+		seen[uuid] = true
+	}
+
+	var added []api.User
+	for _, u := range defaults {
+		if u.UUID == "" || u.UUID == selfUUID || seen[u.UUID] {
+			continue
+		}
+		seen[u.UUID] = true
+		uuids = append(uuids, u.UUID)
+		added = append(added, u)
+	}
+
+	return uuids, added
 }
@@ -0,0 +1,500 @@
+package pr
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/rbansal42/bitbucket-cli/internal/api"
+	"github.com/rbansal42/bitbucket-cli/internal/git"
+	"github.com/rbansal42/bitbucket-cli/internal/iostreams"
+)
+
+func TestMergeReviewers(t *testing.T) {
+	defaults := []api.User{
+		{UUID: "{alice}", DisplayName: "Alice"},
+		{UUID: "{bob}", DisplayName: "Bob"},
+		{UUID: "{me}", DisplayName: "Me"},
+	}
+
+	tests := []struct {
+		name      string
+		explicit  []string
+		selfUUID  string
+		wantUUIDs []string
+		wantAdded []string
+	}{
+		{
+			name:      "no explicit reviewers",
+			selfUUID:  "{me}",
+			wantUUIDs: []string{"{alice}", "{bob}"},
+			wantAdded: []string{"Alice", "Bob"},
+		},
+		{
+			name:      "explicit reviewer overlaps a default",
+			explicit:  []string{"{bob}", "{carol}"},
+			selfUUID:  "{me}",
+			wantUUIDs: []string{"{bob}", "{carol}", "{alice}"},
+			wantAdded: []string{"Alice"},
+		},
+		{
+			name:      "author unknown",
+			wantUUIDs: []string{"{alice}", "{bob}", "{me}"},
+			wantAdded: []string{"Alice", "Bob", "Me"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotUUIDs, added := mergeReviewers(tt.explicit, defaults, tt.selfUUID)
+
+			if !reflect.DeepEqual(gotUUIDs, tt.wantUUIDs) {
+				t.Errorf("uuids = %v, want %v", gotUUIDs, tt.wantUUIDs)
+			}
+
+			var gotAdded []string
+			for _, u := range added {
+				gotAdded = append(gotAdded, u.DisplayName)
+			}
+			if !reflect.DeepEqual(gotAdded, tt.wantAdded) {
+				t.Errorf("added = %v, want %v", gotAdded, tt.wantAdded)
+			}
+		})
+	}
+}
+
+func TestStripDraftPrefix(t *testing.T) {
+	tests := []struct {
+		title string
+		want  string
+	}{
+		{"Add feature", "Add feature"},
+		{"[DRAFT] Add feature", "Add feature"},
+		{"[wip] Add feature", "Add feature"},
+		{"WIP: Add feature", "Add feature"},
+		{"(Draft) Add feature", "Add feature"},
+		{"[DRAFT] [WIP] Add feature", "Add feature"},
+		{"Fix [DRAFT] handling", "Fix [DRAFT] handling"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.title, func(t *testing.T) {
+			if got := stripDraftPrefix(tt.title); got != tt.want {
+				t.Errorf("stripDraftPrefix(%q) = %q, want %q", tt.title, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseCommitLog(t *testing.T) {
+	output := "Second commit\x1fLonger explanation\n\nwith paragraphs\n\x1e\n" +
+		"First commit\x1f\x1e\n"
+
+	commits := parseCommitLog(output)
+
+	want := []commitMessage{
+		{subject: "Second commit", body: "Longer explanation\n\nwith paragraphs"},
+		{subject: "First commit", body: ""},
+	}
+	if !reflect.DeepEqual(commits, want) {
+		t.Errorf("parseCommitLog() = %#v, want %#v", commits, want)
+	}
+
+	if got := parseCommitLog(""); len(got) != 0 {
+		t.Errorf("expected no commits for empty output, got %d", len(got))
+	}
+}
+
+func TestReadTextInput(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name, content string) string {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+		return path
+	}
+
+	tests := []struct {
+		name    string
+		path    string
+		stdin   string
+		want    string
+		wantErr bool
+	}{
+		{name: "trims one trailing newline", path: write("body.md", "Line 1\n\nLine 2\n\n"), want: "Line 1\n\nLine 2\n"},
+		{name: "trims CRLF", path: write("crlf.md", "Body\r\n"), want: "Body"},
+		{name: "stdin", path: "-", stdin: "From stdin\n", want: "From stdin"},
+		{name: "empty file", path: write("empty.md", "\n"), wantErr: true},
+		{name: "missing file", path: filepath.Join(dir, "missing.md"), wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := readTextInput(tt.path, strings.NewReader(tt.stdin))
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("expected error, got %q", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("readTextInput() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSplitTemplate(t *testing.T) {
+	tests := []struct {
+		content   string
+		wantTitle string
+		wantBody  string
+	}{
+		{"Add feature\n\nDetails here\nMore", "Add feature", "Details here\nMore"},
+		{"Title only", "Title only", ""},
+		{"  Spaced title  \r\nBody", "Spaced title", "Body"},
+	}
+
+	for _, tt := range tests {
+		title, body := splitTemplate(tt.content)
+		if title != tt.wantTitle || body != tt.wantBody {
+			t.Errorf("splitTemplate(%q) = (%q, %q), want (%q, %q)", tt.content, title, body, tt.wantTitle, tt.wantBody)
+		}
+	}
+}
+
+func TestParseHeadRef(t *testing.T) {
+	// Outside a git repository, so nothing can come from a remote
+	t.Chdir(t.TempDir())
+
+	tests := []struct {
+		head       string
+		wantRepo   string
+		wantBranch string
+		wantErr    bool
+	}{
+		{head: "feature/x", wantBranch: "feature/x"},
+		{head: "", wantBranch: ""},
+		{head: "fork-ws/fork-repo:fix/typo", wantRepo: "fork-ws/fork-repo", wantBranch: "fix/typo"},
+		{head: "fork-ws/fork-repo:", wantErr: true},
+		{head: ":branch", wantErr: true},
+		{head: "fork-repo:branch", wantErr: true},
+		{head: "/repo:branch", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.head, func(t *testing.T) {
+			repo, branch, err := parseHeadRef(tt.head)
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("expected error, got (%q, %q)", repo, branch)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if repo != tt.wantRepo || branch != tt.wantBranch {
+				t.Errorf("parseHeadRef(%q) = (%q, %q), want (%q, %q)", tt.head, repo, branch, tt.wantRepo, tt.wantBranch)
+			}
+		})
+	}
+}
+
+func TestReadTitleAndBodyFilesOneStdinReader(t *testing.T) {
+	opts := &createOptions{
+		streams:      &iostreams.IOStreams{In: strings.NewReader("text"), Out: &bytes.Buffer{}, ErrOut: &bytes.Buffer{}},
+		bodyFile:     "-",
+		templateFile: "-",
+	}
+	err := readTitleAndBodyFiles(opts)
+	if err == nil || !strings.Contains(err.Error(), "--template-file") {
+		t.Errorf("readTitleAndBodyFiles() error = %v, want one naming --template-file", err)
+	}
+}
+
+func TestIsAmbiguousCreateError(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{name: "timeout", err: fmt.Errorf("request failed: %w", context.DeadlineExceeded), want: true},
+		{name: "connection error", err: fmt.Errorf("request failed: %w", &url.Error{Op: "Post", URL: "https://x", Err: errors.New("connection reset")}), want: true},
+		{name: "api error", err: &api.APIError{StatusCode: 400, Message: "Bad Request"}, want: false},
+		{name: "bad gateway", err: fmt.Errorf("failed to create pull request: %w", &api.APIError{StatusCode: 502}), want: true},
+		{name: "gateway timeout", err: &api.APIError{StatusCode: 504}, want: true},
+		{name: "server error", err: &api.APIError{StatusCode: 500}, want: false},
+		{name: "other error", err: errors.New("boom"), want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := isAmbiguousCreateError(tt.err); got != tt.want {
+				t.Errorf("isAmbiguousCreateError(%v) = %v, want %v", tt.err, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestResolveReviewers(t *testing.T) {
+	client := newViewTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/workspaces/ws/permissions":
+			fmt.Fprint(w, `{"values": [{"user": {"uuid": "{alice}", "username": "alice"}}]}`)
+		default:
+			http.NotFound(w, r)
+		}
+	})
+
+	errOut := &bytes.Buffer{}
+	streams := &iostreams.IOStreams{Out: &bytes.Buffer{}, ErrOut: errOut}
+
+	uuids, err := resolveReviewers(context.Background(), client, streams, "ws", []string{"alice", "bob"}, false)
+	if err != nil {
+		t.Fatalf("resolveReviewers() error: %v", err)
+	}
+	if !reflect.DeepEqual(uuids, []string{"{alice}"}) {
+		t.Errorf("uuids = %v, want [{alice}]", uuids)
+	}
+	if !strings.Contains(errOut.String(), "Could not find user: bob") {
+		t.Errorf("warnings = %q, want one naming bob", errOut.String())
+	}
+
+	_, err = resolveReviewers(context.Background(), client, streams, "ws", []string{"alice", "bob"}, true)
+	if err == nil || !strings.Contains(err.Error(), "could not find reviewers bob") {
+		t.Errorf("resolveReviewers(require) error = %v, want one naming bob", err)
+	}
+}
+
+func TestDefaultBaseBranch(t *testing.T) {
+	client := newViewTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/repositories/ws/gitflow/branching-model":
+			fmt.Fprint(w, `{"development": {"name": "develop", "branch": {"name": "develop"}}}`)
+		case "/repositories/ws/missing-dev/branching-model":
+			fmt.Fprint(w, `{"development": {"name": "develop"}}`)
+		case "/repositories/ws/forbidden/branching-model":
+			w.WriteHeader(http.StatusForbidden)
+			fmt.Fprint(w, `{"type": "error", "error": {"message": "forbidden"}}`)
+		default:
+			fmt.Fprint(w, `{"mainbranch": {"name": "main"}}`)
+		}
+	})
+	ctx := context.Background()
+
+	tests := []struct {
+		repo string
+		head string
+		want string
+	}{
+		{"gitflow", "feature/login", "develop"},
+		{"gitflow", "develop", "main"},
+		{"missing-dev", "feature/login", "main"},
+		{"forbidden", "feature/login", "main"},
+	}
+	for _, tt := range tests {
+		got, err := defaultBaseBranch(ctx, client, "ws", tt.repo, tt.head)
+		if err != nil {
+			t.Fatalf("defaultBaseBranch(%s, %s) error: %v", tt.repo, tt.head, err)
+		}
+		if got != tt.want {
+			t.Errorf("defaultBaseBranch(%s, %s) = %q, want %q", tt.repo, tt.head, got, tt.want)
+		}
+	}
+}
+
+func TestResolveDestinationFork(t *testing.T) {
+	var mu sync.Mutex
+	var paths []string
+	client := newViewTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		paths = append(paths, r.URL.Path)
+		mu.Unlock()
+
+		switch r.URL.Path {
+		case "/repositories/me/app-fork":
+			fmt.Fprint(w, `{"full_name": "me/app-fork", "parent": {"full_name": "team/app"}}`)
+		case "/repositories/team/app/branching-model":
+			fmt.Fprint(w, `{"development": {"name": "develop", "branch": {"name": "develop"}}}`)
+		case "/repositories/team/app/default-reviewers":
+			fmt.Fprint(w, `{"values": [{"uuid": "{lead}", "display_name": "Lead"}]}`)
+		case "/user":
+			fmt.Fprint(w, `{"uuid": "{me}"}`)
+		default:
+			t.Errorf("unexpected request to %s", r.URL.Path)
+			http.NotFound(w, r)
+		}
+	})
+	ctx := context.Background()
+	streams := &iostreams.IOStreams{Out: &bytes.Buffer{}, ErrOut: &bytes.Buffer{}}
+
+	// Run from a clone of the fork, with a fork branch named like the
+	// upstream's development branch
+	opts := &createOptions{streams: streams, headRepo: "me/app-fork", headBranch: "develop"}
+	ws, slug, err := resolveDestination(ctx, client, opts, "me", "app-fork")
+	if err != nil {
+		t.Fatalf("resolveDestination() error: %v", err)
+	}
+	if ws != "team" || slug != "app" || opts.baseRepo != "team/app" {
+		t.Errorf("destination = %s/%s (baseRepo %q), want team/app", ws, slug, opts.baseRepo)
+	}
+	if opts.headRepo != "me/app-fork" {
+		t.Errorf("headRepo = %q, want the fork kept as the source", opts.headRepo)
+	}
+	if opts.baseBranch != "develop" {
+		t.Errorf("baseBranch = %q, want the upstream development branch", opts.baseBranch)
+	}
+
+	reviewers := addDefaultReviewers(ctx, client, streams, ws, slug, nil)
+	if !reflect.DeepEqual(reviewers, []string{"{lead}"}) {
+		t.Errorf("reviewers = %v, want the upstream default reviewers", reviewers)
+	}
+
+	for _, p := range paths {
+		if strings.HasPrefix(p, "/repositories/me/app-fork/") {
+			t.Errorf("queried the fork for %s", p)
+		}
+	}
+}
+
+func TestResolveDestinationSameRepo(t *testing.T) {
+	client := newViewTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"mainbranch": {"name": "main"}}`)
+	})
+
+	// A head naming the --repo destination itself is an ordinary branch
+	opts := &createOptions{repo: "team/app", headRepo: "TEAM/app", headBranch: "feature"}
+	ws, slug, err := resolveDestination(context.Background(), client, opts, "team", "app")
+	if err != nil {
+		t.Fatalf("resolveDestination() error: %v", err)
+	}
+	if ws != "team" || slug != "app" || opts.headRepo != "" || opts.baseBranch != "main" {
+		t.Errorf("got %s/%s, headRepo %q, base %q", ws, slug, opts.headRepo, opts.baseBranch)
+	}
+}
+
+func TestRemoteFor(t *testing.T) {
+	remotes := []git.Remote{
+		{Name: "origin", Workspace: "me", RepoSlug: "app-fork"},
+		{Name: "upstream", Workspace: "team", RepoSlug: "app"},
+	}
+
+	tests := []struct {
+		fullName string
+		want     string
+	}{
+		{"team/app", "upstream"},
+		{"Me/App-Fork", "origin"},
+		{"other/app", ""},
+		{"", ""},
+	}
+	for _, tt := range tests {
+		if got := remoteFor(remotes, tt.fullName); got != tt.want {
+			t.Errorf("remoteFor(%q) = %q, want %q", tt.fullName, got, tt.want)
+		}
+	}
+}
+
+func TestEnsureHeadPushed(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not available")
+	}
+	dir := t.TempDir()
+	gitRun := func(args ...string) {
+		t.Helper()
+		args = append([]string{"-c", "user.name=t", "-c", "user.email=t@example.com"}, args...)
+		if out, err := exec.Command("git", args...).CombinedOutput(); err != nil {
+			t.Fatalf("git %v: %v\n%s", args, err, out)
+		}
+	}
+	origin := filepath.Join(dir, "origin.git")
+	work := filepath.Join(dir, "work")
+	gitRun("init", "-q", "--bare", origin)
+	gitRun("clone", "-q", origin, work)
+	t.Chdir(work)
+	// The remote names the Bitbucket repository, but pushes go to the
+	// local bare repository
+	gitRun("remote", "set-url", "origin", "https://bitbucket.org/ws/repo.git")
+	gitRun("remote", "set-url", "--push", "origin", origin)
+	gitRun("commit", "-q", "--allow-empty", "-m", "init")
+	gitRun("push", "-q", "origin", "HEAD:refs/heads/main")
+	gitRun("checkout", "-q", "-b", "feature")
+	gitRun("commit", "-q", "--allow-empty", "-m", "change")
+
+	var out bytes.Buffer
+	opts := &createOptions{streams: &iostreams.IOStreams{Out: &out, ErrOut: &out}, headBranch: "feature"}
+
+	err := ensureHeadPushed(opts, "ws", "repo")
+	if err == nil || !strings.Contains(err.Error(), `branch "feature" is not pushed to origin`) || !strings.Contains(err.Error(), "--push") {
+		t.Fatalf("ensureHeadPushed(unpushed) error = %v", err)
+	}
+	if !strings.Contains(err.Error(), "git fetch origin") || !strings.Contains(err.Error(), "--no-push") {
+		t.Errorf("ensureHeadPushed(unpushed) error = %v, want hints to fetch or skip the check", err)
+	}
+
+	opts.push = true
+	if err := ensureHeadPushed(opts, "ws", "repo"); err != nil {
+		t.Fatalf("ensureHeadPushed(--push) error: %v", err)
+	}
+	if !git.RefExists("refs/remotes/origin/feature") {
+		t.Fatal("--push didn't push the branch")
+	}
+
+	opts.push = false
+	if err := ensureHeadPushed(opts, "ws", "repo"); err != nil {
+		t.Errorf("ensureHeadPushed(pushed) error: %v", err)
+	}
+
+	gitRun("commit", "-q", "--allow-empty", "-m", "more")
+	err = ensureHeadPushed(opts, "ws", "repo")
+	if err == nil || !strings.Contains(err.Error(), "has 1 commit not pushed") {
+		t.Errorf("ensureHeadPushed(ahead) error = %v", err)
+	}
+
+	// A branch that can't be compared with the remote, here a commit whose
+	// parent is missing, is an error rather than a branch taken to be pushed
+	tree, err := exec.Command("git", "rev-parse", "HEAD^{tree}").Output()
+	if err != nil {
+		t.Fatal(err)
+	}
+	hashObject := exec.Command("git", "hash-object", "-t", "commit", "-w", "--literally", "--stdin")
+	hashObject.Stdin = strings.NewReader(fmt.Sprintf("tree %s\nparent %s\nauthor t <t@example.com> 0 +0000\ncommitter t <t@example.com> 0 +0000\n\nbroken\n",
+		strings.TrimSpace(string(tree)), strings.Repeat("1", 40)))
+	broken, err := hashObject.Output()
+	if err != nil {
+		t.Fatal(err)
+	}
+	gitRun("update-ref", "refs/heads/feature", strings.TrimSpace(string(broken)))
+	err = ensureHeadPushed(opts, "ws", "repo")
+	if err == nil || !strings.Contains(err.Error(), "failed to check whether branch \"feature\" is pushed") {
+		t.Errorf("ensureHeadPushed(unreadable remote branch) error = %v", err)
+	}
+
+	// Without a remote for the repository nothing is pushed anywhere else
+	opts.push = true
+	err = ensureHeadPushed(opts, "other", "repo")
+	if err == nil || !strings.Contains(err.Error(), "no git remote points to other/repo") {
+		t.Errorf("ensureHeadPushed(other repository) error = %v", err)
+	}
+
+	// Fork heads aren't checked
+	opts.headRepo = "someone/fork"
+	if err := ensureHeadPushed(opts, "ws", "repo"); err != nil {
+		t.Errorf("ensureHeadPushed(fork) error: %v", err)
+	}
+}
@@ -2,16 +2,15 @@ package pr
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"io"
-	"net/http"
+	"os"
+	"path/filepath"
 	"strings"
 
 	"github.com/spf13/cobra"
 
 	"github.com/rbansal42/bitbucket-cli/internal/cmdutil"
-	"github.com/rbansal42/bitbucket-cli/internal/config"
 	"github.com/rbansal42/bitbucket-cli/internal/iostreams"
 )
 
@@ -19,6 +18,11 @@ type diffOptions struct {
 	streams *iostreams.IOStreams
 	repo    string
 	noColor bool
+	stat    bool
+	patch   bool
+	output  string
+	split   bool
+	dir     string // --output-dir for --split
 }
 
 // NewCmdDiff creates the diff command
@@ -33,15 +37,36 @@ func NewCmdDiff(streams *iostreams.IOStreams) *cobra.Command {
 		Long: `Display the diff for a pull request.
 
 Shows the changes introduced by the pull request. Color output is enabled
-by default when stdout is a terminal, and disabled when piped.`,
+by default when stdout is a terminal, and disabled when piped or written to
+a file with --output.
+
+With --patch-format, the pull request's commits are printed as a series of
+patches in git format-patch style, which can be applied with git am.
+
+With --split, each changed file's part of the diff is written to its own
+file under --output-dir, at the file's path with .diff appended: a change
+to src/main.go is written to <dir>/src/main.go.diff. Renamed files are
+written under their new path and deleted files under their old one. Path
+components that could escape the directory, such as "..", and characters
+that aren't allowed in file names are replaced with underscores. Binary
+files have no text diff and are skipped with a note.`,
 		Example: `  # View diff for pull request #123
   bb pr diff 123
 
   # View diff without color
   bb pr diff 123 --no-color
 
-  # Pipe diff to a file
-  bb pr diff 123 > changes.diff`,
+  # Write the diff to a file
+  bb pr diff 123 --output changes.diff
+
+  # Show a per-file summary of changes
+  bb pr diff 123 --stat
+
+  # Apply the pull request's commits to the current branch
+  bb pr diff 123 --patch-format | git am
+
+  # Write each file's diff to its own file under ./diffs
+  bb pr diff 123 --split --output-dir ./diffs`,
 		Args: cobra.MaximumNArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			return runDiff(opts, args)
@@ -49,8 +74,17 @@ by default when stdout is a terminal, and disabled when piped.`,
 	}
 
 	cmd.Flags().BoolVar(&opts.noColor, "no-color", false, "Disable color output")
+	cmd.Flags().BoolVar(&opts.stat, "stat", false, "Show a summary of changed files instead of the full diff")
+	cmd.Flags().BoolVar(&opts.patch, "patch-format", false, "Show the commits as patches in git format-patch style")
+	cmd.Flags().StringVarP(&opts.output, "output", "o", "", "Write the diff to a file instead of standard output")
+	cmd.Flags().BoolVar(&opts.split, "split", false, "Write each file's diff to a separate file under --output-dir")
+	cmd.Flags().StringVar(&opts.dir, "output-dir", "", "Directory for the files written by --split")
 	cmd.Flags().StringVarP(&opts.repo, "repo", "R", "", "Repository in WORKSPACE/REPO format")
 
+	cmd.MarkFlagsMutuallyExclusive("stat", "patch-format", "split")
+	cmd.MarkFlagsMutuallyExclusive("output", "split")
+	cmd.MarkFlagsRequiredTogether("split", "output-dir")
+
 	cmd.ValidArgsFunction = cmdutil.CompletePRNumbers
 	_ = cmd.RegisterFlagCompletionFunc("repo", cmdutil.CompleteRepoNames)
 
@@ -75,115 +109,124 @@ func runDiff(opts *diffOptions, args []string) error {
 
 	ctx := context.Background()
 
-	// Get the PR to get the diff link
-	pr, err := client.GetPullRequest(ctx, workspace, repoSlug, int64(prNum))
-	if err != nil {
-		return fmt.Errorf("failed to get pull request: %w", err)
+	if opts.split {
+		diff, err := client.GetPullRequestDiffReader(ctx, workspace, repoSlug, int64(prNum))
+		if err != nil {
+			return fmt.Errorf("failed to fetch diff: %w", err)
+		}
+		defer diff.Close()
+		return writeSplitDiff(opts.streams, diff, opts.dir)
 	}
 
-	// Fetch the diff using the diff link
-	diffURL := pr.Links.Diff.Href
-	if diffURL == "" {
-		diffURL = fmt.Sprintf("https://api.bitbucket.org/2.0/repositories/%s/%s/pullrequests/%d/diff", workspace, repoSlug, prNum)
-	}
+	// Determine if we should colorize
+	useColor := cmdutil.IsStdoutPath(opts.output) && opts.streams.IsStdoutTTY() && !opts.noColor
 
-	// Make HTTP request for diff (using raw HTTP since it returns text/plain)
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, diffURL, nil)
-	if err != nil {
-		return fmt.Errorf("failed to create request: %w", err)
+	if opts.stat {
+		entries, err := client.GetPullRequestDiffStat(ctx, workspace, repoSlug, int64(prNum))
+		if err != nil {
+			return fmt.Errorf("failed to get diffstat: %w", err)
+		}
+		out, err := cmdutil.OutputWriter(opts.streams, opts.output)
+		if err != nil {
+			return err
+		}
+		if _, err := io.WriteString(out, cmdutil.FormatDiffStat(entries, useColor)); err != nil {
+			out.Abort()
+			return fmt.Errorf("failed to write diffstat: %w", err)
+		}
+		return out.Close()
 	}
 
-	// Get token for auth
-	token, err := getTokenForRequest()
-	if err != nil {
-		return err
+	// Stream the diff straight to the output; diffs of large pull requests
+	// can be far too big to hold in memory
+	var diff io.ReadCloser
+	if opts.patch {
+		diff, err = client.GetPullRequestPatchReader(ctx, workspace, repoSlug, int64(prNum))
+	} else {
+		diff, err = client.GetPullRequestDiffReader(ctx, workspace, repoSlug, int64(prNum))
 	}
-	req.Header.Set("Authorization", "Bearer "+token)
-	req.Header.Set("Accept", "text/plain")
-
-	resp, err := http.DefaultClient.Do(req)
 	if err != nil {
 		return fmt.Errorf("failed to fetch diff: %w", err)
 	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK {
-		return fmt.Errorf("failed to fetch diff: %s", resp.Status)
-	}
+	defer diff.Close()
 
-	// Read diff content
-	diffContent, err := io.ReadAll(resp.Body)
+	out, err := cmdutil.OutputWriter(opts.streams, opts.output)
 	if err != nil {
-		return fmt.Errorf("failed to read diff: %w", err)
+		return err
 	}
-
-	// Determine if we should colorize
-	useColor := opts.streams.IsStdoutTTY() && !opts.noColor
-
-	if useColor {
-		colorizedDiff := colorizeDiff(string(diffContent))
-		fmt.Fprint(opts.streams.Out, colorizedDiff)
-	} else {
-		fmt.Fprint(opts.streams.Out, string(diffContent))
+	if err := cmdutil.CopyDiff(out, diff, useColor); err != nil {
+		out.Abort()
+		return fmt.Errorf("failed to read diff: %w", err)
 	}
 
-	return nil
+	return out.Close()
 }
 
-// colorizeDiff adds ANSI colors to a diff
-func colorizeDiff(diff string) string {
-	var result strings.Builder
-	lines := strings.Split(diff, "\n")
-
-	for _, line := range lines {
-		switch {
-		case strings.HasPrefix(line, "+++ ") || strings.HasPrefix(line, "--- "):
-			// File headers - bold
-			result.WriteString(iostreams.Bold + line + iostreams.Reset + "\n")
-		case strings.HasPrefix(line, "+"):
-			// Additions - green
-			result.WriteString(iostreams.Green + line + iostreams.Reset + "\n")
-		case strings.HasPrefix(line, "-"):
-			// Deletions - red
-			result.WriteString(iostreams.Red + line + iostreams.Reset + "\n")
-		case strings.HasPrefix(line, "@@"):
-			// Hunk headers - cyan
-			result.WriteString(iostreams.Cyan + line + iostreams.Reset + "\n")
-		case strings.HasPrefix(line, "diff "):
-			// Diff headers - bold blue
-			result.WriteString(iostreams.BoldBlue + line + iostreams.Reset + "\n")
-		default:
-			result.WriteString(line + "\n")
+// writeSplitDiff writes each file's part of a diff to dir/<path>.diff,
+// skipping binary files
+func writeSplitDiff(streams *iostreams.IOStreams, diff io.Reader, dir string) error {
+	written := map[string]bool{}
+	files, skipped := 0, 0
+	err := cmdutil.SplitDiff(diff, func(f *cmdutil.FileDiff) error {
+		if f.Binary {
+			streams.Info("Skipped binary file %s", f.Path())
+			skipped++
+			return nil
 		}
-	}
 
-	return result.String()
-}
+		name := splitDiffFileName(f.Path())
+		// Sanitizing can map two paths to one name; keep both
+		base := name
+		for i := 2; written[name]; i++ {
+			name = fmt.Sprintf("%s-%d", base, i)
+		}
+		written[name] = true
 
-// getTokenForRequest gets the access token for making requests
-func getTokenForRequest() (string, error) {
-	hosts, err := config.LoadHostsConfig()
+		path := filepath.Join(dir, name+".diff")
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			return fmt.Errorf("failed to create directory: %w", err)
+		}
+		if err := os.WriteFile(path, []byte(f.Text), 0o644); err != nil {
+			return fmt.Errorf("failed to write %s: %w", path, err)
+		}
+		files++
+		return nil
+	})
 	if err != nil {
-		return "", err
+		return fmt.Errorf("failed to split diff: %w", err)
 	}
 
-	user := hosts.GetActiveUser(config.DefaultHost)
-	if user == "" {
-		return "", fmt.Errorf("not logged in")
-	}
-
-	tokenData, _, err := config.GetTokenFromEnvOrKeyring(config.DefaultHost, user)
-	if err != nil {
-		return "", err
+	switch {
+	case files == 0 && skipped == 0:
+		streams.Info("No changes to write")
+	case skipped > 0:
+		streams.Success("Wrote %d file diffs to %s (%d binary files skipped)", files, dir, skipped)
+	default:
+		streams.Success("Wrote %d file diffs to %s", files, dir)
 	}
+	return nil
+}
 
-	// Parse token if JSON
-	var tokenResp struct {
-		AccessToken string `json:"access_token"`
+// splitDiffFileName turns a path from a diff into a relative file name that
+// stays inside the output directory and is valid on every platform
+func splitDiffFileName(path string) string {
+	var parts []string
+	for _, part := range strings.Split(path, "/") {
+		switch part {
+		case "":
+			continue
+		case ".", "..":
+			part = "_"
+		}
+		parts = append(parts, strings.Map(func(r rune) rune {
+			if r < 0x20 || strings.ContainsRune(`\:*?"<>|`, r) {
+				return '_'
+			}
+			return r
+		}, part))
 	}
-	if err := json.Unmarshal([]byte(tokenData), &tokenResp); err == nil && tokenResp.AccessToken != "" {
-		return tokenResp.AccessToken, nil
+	if len(parts) == 0 {
+		return "_"
 	}
-
-	return tokenData, nil
+	return filepath.Join(parts...)
 }
@@ -0,0 +1,78 @@
+package pr
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/rbansal42/bitbucket-cli/internal/iostreams"
+)
+
+func TestWriteSplitDiff(t *testing.T) {
+	diff := `diff --git a/src/main.go b/src/main.go
+--- a/src/main.go
++++ b/src/main.go
+@@ -1 +1 @@
+-a
++b
+diff --git a/docs/old.md b/docs/new.md
+similarity index 100%
+rename from docs/old.md
+rename to docs/new.md
+diff --git a/../escape.txt b/../escape.txt
+--- a/../escape.txt
++++ b/../escape.txt
+@@ -1 +1 @@
+-x
++y
+diff --git a/img.png b/img.png
+Binary files a/img.png and b/img.png differ
+`
+	dir := t.TempDir()
+	var out bytes.Buffer
+	streams := &iostreams.IOStreams{Out: &out, ErrOut: &out}
+	if err := writeSplitDiff(streams, strings.NewReader(diff), dir); err != nil {
+		t.Fatalf("writeSplitDiff() error: %v", err)
+	}
+
+	for name, want := range map[string]string{
+		"src/main.go.diff":  "+b\n",
+		"docs/new.md.diff":  "rename to docs/new.md\n",
+		"_/escape.txt.diff": "+y\n",
+	} {
+		data, err := os.ReadFile(filepath.Join(dir, name))
+		if err != nil {
+			t.Errorf("%s not written: %v", name, err)
+			continue
+		}
+		if !strings.HasSuffix(string(data), want) {
+			t.Errorf("%s = %q, want it to end with %q", name, data, want)
+		}
+	}
+	if _, err := os.Stat(filepath.Join(dir, "img.png.diff")); err == nil {
+		t.Error("binary file was written")
+	}
+	for _, want := range []string{"Skipped binary file img.png", "Wrote 3 file diffs", "1 binary files skipped"} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("output missing %q:\n%s", want, out.String())
+		}
+	}
+}
+
+func TestSplitDiffFileName(t *testing.T) {
+	tests := map[string]string{
+		"main.go":      "main.go",
+		"a/b/c.go":     filepath.Join("a", "b", "c.go"),
+		"../../etc/x":  filepath.Join("_", "_", "etc", "x"),
+		"/abs//path":   filepath.Join("abs", "path"),
+		`we:ird*na?me`: "we_ird_na_me",
+		"":             "_",
+	}
+	for path, want := range tests {
+		if got := splitDiffFileName(path); got != want {
+			t.Errorf("splitDiffFileName(%q) = %q, want %q", path, got, want)
+		}
+	}
+}
@@ -0,0 +1,89 @@
+package pr
+
+import (
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/rbansal42/bitbucket-cli/internal/cmdutil"
+)
+
+// draftPath returns where pr create keeps the description being written
+// for branch, under the user cache directory:
+// ~/.cache/bb/drafts/{workspace}/{repo}/{branch}. The branch is escaped so
+// that names containing slashes map to a single file.
+func draftPath(workspace, repoSlug, branch string) (string, error) {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "bb", "drafts", workspace, repoSlug, url.PathEscape(branch)), nil
+}
+
+// editBodyDraft opens the editor on the draft file for the pull request
+// description, offering to start from a draft left by an earlier attempt.
+// The draft is kept until the pull request is created, so a crashed
+// editor or failed request doesn't lose it. It returns the path of the
+// draft, or an error if the editor failed after the description was
+// changed.
+func editBodyDraft(opts *createOptions, workspace, repoSlug string) (string, error) {
+	path, err := draftPath(workspace, repoSlug, opts.headBranch)
+	if err != nil {
+		// Without a cache directory there is nowhere to keep a draft
+		body, err := cmdutil.OpenEditor(getBodyTemplate(opts))
+		if err != nil {
+			opts.streams.Warning("Could not open editor: %v", err)
+			return "", nil
+		}
+		opts.body = cleanupBody(body)
+		return "", nil
+	}
+
+	template := getBodyTemplate(opts)
+	initial := template
+	if saved, err := os.ReadFile(path); err == nil && strings.TrimSpace(string(saved)) != "" {
+		useDraft, err := cmdutil.Confirm(opts.streams, "Recover the description saved from your last attempt?", true, "")
+		if err != nil {
+			return "", err
+		}
+		if useDraft {
+			initial = string(saved)
+		}
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return "", fmt.Errorf("failed to save draft: %w", err)
+	}
+	if err := os.WriteFile(path, []byte(initial), 0600); err != nil {
+		return "", fmt.Errorf("failed to save draft: %w", err)
+	}
+
+	editErr := cmdutil.EditFile(path)
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("failed to read draft: %w", err)
+	}
+
+	if editErr != nil {
+		if strings.TrimSpace(string(content)) == strings.TrimSpace(template) {
+			// Nothing was written, so carry on without a description
+			clearDraft(path)
+			opts.streams.Warning("Could not open editor: %v", editErr)
+			return "", nil
+		}
+		return "", fmt.Errorf("%w; your description was saved to %s and will be offered the next time you run bb pr create on this branch", editErr, path)
+	}
+
+	opts.body = cleanupBody(string(content))
+	return path, nil
+}
+
+// clearDraft removes a draft once it is no longer needed
+func clearDraft(path string) {
+	if path == "" {
+		return
+	}
+	_ = os.Remove(path)
+}
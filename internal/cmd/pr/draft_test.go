@@ -0,0 +1,90 @@
+package pr
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/rbansal42/bitbucket-cli/internal/iostreams"
+)
+
+// setEditorScript makes the editor a shell script running body with the
+// file being edited as $1
+func setEditorScript(t *testing.T, body string) {
+	t.Helper()
+	script := filepath.Join(t.TempDir(), "editor.sh")
+	if err := os.WriteFile(script, []byte("#!/bin/sh\n"+body+"\n"), 0700); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("BB_EDITOR", script)
+}
+
+func TestEditBodyDraft(t *testing.T) {
+	t.Setenv("XDG_CACHE_HOME", t.TempDir())
+	t.Setenv("HOME", t.TempDir())
+
+	newOpts := func(stdin string) *createOptions {
+		streams := &iostreams.IOStreams{In: strings.NewReader(stdin), Out: &bytes.Buffer{}, ErrOut: &bytes.Buffer{}}
+		streams.SetStdinTTY(true)
+		return &createOptions{streams: streams, headBranch: "feature/login", baseBranch: "main"}
+	}
+
+	// The editor writes a description and then crashes
+	setEditorScript(t, `echo "Half-written description" > "$1"; exit 1`)
+	opts := newOpts("")
+	if _, err := editBodyDraft(opts, "ws", "repo"); err == nil || !strings.Contains(err.Error(), "saved to") {
+		t.Fatalf("editBodyDraft() error = %v, want the draft location", err)
+	}
+
+	path, err := draftPath("ws", "repo", "feature/login")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if filepath.Base(path) != "feature%2Flogin" {
+		t.Errorf("draftPath() = %s, want the branch escaped into one file name", path)
+	}
+	if data, _ := os.ReadFile(path); !strings.Contains(string(data), "Half-written") {
+		t.Fatalf("draft = %q, want the crashed editor's content", data)
+	}
+
+	// The next attempt offers the draft; accepting it starts the editor on it
+	setEditorScript(t, `echo "Finished" >> "$1"`)
+	opts = newOpts("y\n")
+	got, err := editBodyDraft(opts, "ws", "repo")
+	if err != nil {
+		t.Fatalf("editBodyDraft() error: %v", err)
+	}
+	if got != path {
+		t.Errorf("editBodyDraft() = %q, want %q", got, path)
+	}
+	if opts.body != "Half-written description\nFinished" {
+		t.Errorf("body = %q", opts.body)
+	}
+
+	// Declining the draft starts again from the template
+	setEditorScript(t, `true`)
+	opts = newOpts("n\n")
+	if _, err := editBodyDraft(opts, "ws", "repo"); err != nil {
+		t.Fatalf("editBodyDraft() error: %v", err)
+	}
+	if strings.Contains(opts.body, "Half-written") || !strings.Contains(opts.body, "## Summary") {
+		t.Errorf("body = %q, want the template", opts.body)
+	}
+
+	clearDraft(path)
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Errorf("draft still exists after clearDraft: %v", err)
+	}
+
+	// An editor that fails without changes leaves no draft behind
+	setEditorScript(t, `exit 1`)
+	opts = newOpts("")
+	if _, err := editBodyDraft(opts, "ws", "repo"); err != nil {
+		t.Fatalf("editBodyDraft() error: %v", err)
+	}
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Errorf("draft kept for an unchanged template: %v", err)
+	}
+}
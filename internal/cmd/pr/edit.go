@@ -4,24 +4,32 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
-	"strconv"
+	"strings"
 	"time"
 
 	"github.com/spf13/cobra"
 
 	"github.com/rbansal42/bitbucket-cli/internal/api"
 	"github.com/rbansal42/bitbucket-cli/internal/cmdutil"
+	"github.com/rbansal42/bitbucket-cli/internal/git"
 	"github.com/rbansal42/bitbucket-cli/internal/iostreams"
 )
 
 type editOptions struct {
-	streams *iostreams.IOStreams
-	repo    string
-	prID    int64
-	title   string
-	body    string
-	base    string // destination branch
-	jsonOut bool
+	streams  *iostreams.IOStreams
+	repo     string
+	prNumber int
+	title    string
+	body     string
+	bodyFile string
+	base     string // destination branch
+	jsonOut  bool
+
+	// Which of --title, --body and --base were given, so an empty --body
+	// can clear the description
+	titleSet bool
+	bodySet  bool
+	baseSet  bool
 }
 
 // NewCmdEdit creates the edit command
@@ -31,17 +39,26 @@ func NewCmdEdit(streams *iostreams.IOStreams) *cobra.Command {
 	}
 
 	cmd := &cobra.Command{
-		Use:   "edit <number>",
+		Use:   "edit [<number>]",
 		Short: "Edit a pull request",
 		Long: `Edit the title, description, or destination branch of a pull request.
 
-At least one of --title, --body, or --base must be specified.`,
+Without a number, the open pull request for the current branch is edited.
+Only the fields given are changed; --body "" clears the description. With
+none of --title, --body, --body-file or --base, an editor opens on the
+current description when running in a terminal.`,
 		Example: `  # Edit PR title
   bb pr edit 123 --title "New title"
 
   # Edit PR description
   bb pr edit 123 --body "New description"
 
+  # Edit the description of the current branch's PR in your editor
+  bb pr edit
+
+  # Read the description from a file, or "-" for standard input
+  bb pr edit 123 --body-file notes.md
+
   # Edit destination branch
   bb pr edit 123 --base develop
 
@@ -50,13 +67,18 @@ At least one of --title, --body, or --base must be specified.`,
 
   # Output as JSON
   bb pr edit 123 --title "New title" --json`,
-		Args: cobra.ExactArgs(1),
+		Args: cobra.MaximumNArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			id, err := strconv.ParseInt(args[0], 10, 64)
-			if err != nil {
-				return fmt.Errorf("invalid pull request number: %s", args[0])
+			if len(args) > 0 {
+				var err error
+				opts.prNumber, err = parsePRNumber(args)
+				if err != nil {
+					return err
+				}
 			}
-			opts.prID = id
+			opts.titleSet = cmd.Flags().Changed("title")
+			opts.bodySet = cmd.Flags().Changed("body")
+			opts.baseSet = cmd.Flags().Changed("base")
 			return runEdit(cmd.Context(), opts)
 		},
 	}
@@ -64,8 +86,10 @@ At least one of --title, --body, or --base must be specified.`,
 	cmd.Flags().StringVarP(&opts.repo, "repo", "R", "", "Repository in WORKSPACE/REPO format")
 	cmd.Flags().StringVarP(&opts.title, "title", "t", "", "New title for the pull request")
 	cmd.Flags().StringVarP(&opts.body, "body", "b", "", "New description for the pull request")
+	cmd.Flags().StringVarP(&opts.bodyFile, "body-file", "F", "", "Read the new description from file (use \"-\" to read from standard input)")
 	cmd.Flags().StringVar(&opts.base, "base", "", "New destination branch")
 	cmd.Flags().BoolVar(&opts.jsonOut, "json", false, "Output in JSON format")
+	cmd.MarkFlagsMutuallyExclusive("body", "body-file")
 
 	cmd.ValidArgsFunction = cmdutil.CompletePRNumbers
 	_ = cmd.RegisterFlagCompletionFunc("base", cmdutil.CompleteBranchNames)
@@ -75,9 +99,12 @@ At least one of --title, --body, or --base must be specified.`,
 }
 
 func runEdit(ctx context.Context, opts *editOptions) error {
-	// Validate - at least one field must be specified
-	if opts.title == "" && opts.body == "" && opts.base == "" {
-		return fmt.Errorf("nothing to edit: specify --title, --body, or --base")
+	update, err := editUpdateOptions(opts)
+	if err != nil {
+		return err
+	}
+	if update == nil && !opts.streams.CanPrompt() {
+		return fmt.Errorf("nothing to edit: specify --title, --body, --body-file, or --base")
 	}
 
 	// Parse repository
@@ -92,19 +119,48 @@ func runEdit(ctx context.Context, opts *editOptions) error {
 		return err
 	}
 
+	// If no PR number, try to find PR for current branch
+	if opts.prNumber == 0 {
+		currentBranch, err := git.GetCurrentBranch()
+		if err != nil {
+			return fmt.Errorf("could not determine current branch: %w. Please specify a pull request number", err)
+		}
+		findCtx, cancel := context.WithTimeout(ctx, 30*time.Second)
+		opts.prNumber, err = findPRForBranch(findCtx, workspace, repoSlug, currentBranch)
+		cancel()
+		if err != nil {
+			return err
+		}
+	}
+
+	// Without any flags, edit the current description. The editor is
+	// outside the request timeouts, as it can stay open for a while.
+	if update == nil {
+		fetchCtx, cancel := context.WithTimeout(ctx, 30*time.Second)
+		pr, err := client.GetPullRequest(fetchCtx, workspace, repoSlug, int64(opts.prNumber))
+		cancel()
+		if err != nil {
+			return fmt.Errorf("failed to get pull request: %w", err)
+		}
+
+		body, err := cmdutil.OpenEditor(pr.Description)
+		if err != nil {
+			return fmt.Errorf("failed to open editor: %w", err)
+		}
+		if body == strings.TrimSpace(pr.Description) {
+			opts.streams.Info("No changes to pull request #%d", opts.prNumber)
+			return nil
+		}
+		// The title is passed along so it isn't fetched again
+		update = &api.PRUpdateOptions{Title: &pr.Title, Description: &body}
+	}
+
 	// Create context with timeout
 	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
 	defer cancel()
 
-	// Build update options
-	updateOpts := &api.PRCreateOptions{
-		Title:             opts.title,
-		Description:       opts.body,
-		DestinationBranch: opts.base,
-	}
-
 	// Update PR
-	pr, err := client.UpdatePullRequest(ctx, workspace, repoSlug, opts.prID, updateOpts)
+	pr, err := client.UpdatePullRequest(ctx, workspace, repoSlug, int64(opts.prNumber), update)
 	if err != nil {
 		return fmt.Errorf("failed to update pull request: %w", err)
 	}
@@ -115,12 +171,49 @@ func runEdit(ctx context.Context, opts *editOptions) error {
 	}
 
 	// Output success message
-	opts.streams.Success("Edited pull request #%d", opts.prID)
+	opts.streams.Success("Edited pull request #%d", opts.prNumber)
 	fmt.Fprintln(opts.streams.Out, pr.Links.HTML.Href)
 
 	return nil
 }
 
+// editUpdateOptions builds the update from the fields given on the command
+// line, reading --body-file. It returns nil when no field was given.
+func editUpdateOptions(opts *editOptions) (*api.PRUpdateOptions, error) {
+	if opts.bodyFile != "" {
+		body, err := readTextInput(opts.bodyFile, opts.streams.In)
+		if err != nil {
+			return nil, err
+		}
+		opts.body = body
+		opts.bodySet = true
+	}
+
+	if !opts.titleSet && !opts.bodySet && !opts.baseSet {
+		return nil, nil
+	}
+
+	update := &api.PRUpdateOptions{}
+	if opts.titleSet {
+		title := strings.TrimSpace(opts.title)
+		if title == "" {
+			return nil, fmt.Errorf("--title cannot be empty")
+		}
+		update.Title = &title
+	}
+	if opts.bodySet {
+		update.Description = &opts.body
+	}
+	if opts.baseSet {
+		base := strings.TrimSpace(opts.base)
+		if base == "" {
+			return nil, fmt.Errorf("--base cannot be empty")
+		}
+		update.DestinationBranch = &base
+	}
+	return update, nil
+}
+
 func outputEditJSON(streams *iostreams.IOStreams, pr *api.PullRequest) error {
 	data, err := json.MarshalIndent(api.PullRequestJSON{PullRequest: pr}, "", "  ")
 	if err != nil {
@@ -0,0 +1,55 @@
+package pr
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/rbansal42/bitbucket-cli/internal/iostreams"
+)
+
+func TestEditUpdateOptions(t *testing.T) {
+	streams := &iostreams.IOStreams{In: strings.NewReader("From stdin\n"), Out: &bytes.Buffer{}, ErrOut: &bytes.Buffer{}}
+
+	// No fields means the description is edited interactively
+	update, err := editUpdateOptions(&editOptions{streams: streams})
+	if err != nil || update != nil {
+		t.Fatalf("editUpdateOptions() with no fields = %+v, %v, want nil", update, err)
+	}
+
+	// Only the fields given are set, and an empty --body clears the
+	// description
+	update, err = editUpdateOptions(&editOptions{streams: streams, title: " New title ", titleSet: true, bodySet: true})
+	if err != nil {
+		t.Fatalf("editUpdateOptions() error: %v", err)
+	}
+	if update.Title == nil || *update.Title != "New title" {
+		t.Errorf("Title = %v, want New title", update.Title)
+	}
+	if update.Description == nil || *update.Description != "" {
+		t.Errorf("Description = %v, want an empty description", update.Description)
+	}
+	if update.DestinationBranch != nil {
+		t.Errorf("DestinationBranch = %q, want unset", *update.DestinationBranch)
+	}
+
+	update, err = editUpdateOptions(&editOptions{streams: streams, bodyFile: "-"})
+	if err != nil {
+		t.Fatalf("editUpdateOptions() error: %v", err)
+	}
+	if update.Description == nil || *update.Description != "From stdin" {
+		t.Errorf("Description = %v, want the body read from standard input", update.Description)
+	}
+	if update.Title != nil {
+		t.Errorf("Title = %q, want unset", *update.Title)
+	}
+
+	for _, opts := range []*editOptions{
+		{streams: streams, title: " ", titleSet: true},
+		{streams: streams, baseSet: true},
+	} {
+		if _, err := editUpdateOptions(opts); err == nil {
+			t.Errorf("editUpdateOptions(%+v) succeeded, want an error", opts)
+		}
+	}
+}
@@ -3,8 +3,8 @@ package pr
 import (
 	"context"
 	"fmt"
+	"net/url"
 	"strings"
-	"text/tabwriter"
 
 	"github.com/spf13/cobra"
 
@@ -15,12 +15,21 @@ import (
 
 // ListOptions holds the options for the list command
 type ListOptions struct {
-	State   string
-	Author  string
-	Limit   int
-	JSON    bool
-	Repo    string
-	Streams *iostreams.IOStreams
+	State       string
+	Author      string
+	CreatedByMe bool
+	Reviewer    string
+	Since       string
+	Until       string
+	Limit       int
+	JSON        bool
+	Format      cmdutil.ListFormat
+	ShowCount   bool
+	ExitCode    bool
+	Repo        string
+	Web         bool
+	NoBrowser   bool
+	Streams     *iostreams.IOStreams
 }
 
 // NewCmdList creates the pr list command
@@ -35,7 +44,25 @@ func NewCmdList(streams *iostreams.IOStreams) *cobra.Command {
 		Long: `List pull requests in a Bitbucket repository.
 
 By default, this shows open pull requests. Use the --state flag to filter
-by state (OPEN, MERGED, DECLINED).`,
+by state (OPEN, MERGED, DECLINED).
+
+Use --since and --until to list pull requests created in a date window.
+Dates are YYYY-MM-DD, YYYY-MM-DDTHH:MM:SS or RFC3339; values without a
+time zone are UTC, which is how Bitbucket compares them. --since is
+inclusive and --until is exclusive.
+
+--author and --reviewer accept @me for the logged-in user, and
+--created-by-me is short for --author @me.
+
+Bitbucket pull requests have no assignees; teams use reviewers instead, and
+'bb pr assign' adds them. --assignee is another name for --reviewer, so
+--assignee @me lists the pull requests assigned to you.
+
+--web opens the pull request page of the repository in your browser
+instead, without calling the API. --state is applied to the page, and so is
+--author when it is an account UUID; the web UI can't filter by username.
+The URL is printed instead when --no-browser is given or the output is not
+a terminal.`,
 		Example: `  # List open pull requests
   bb pr list
 
@@ -45,14 +72,38 @@ by state (OPEN, MERGED, DECLINED).`,
   # List pull requests by a specific author
   bb pr list --author johndoe
 
+  # List your own open pull requests
+  bb pr list --created-by-me
+
+  # List pull requests waiting for your review
+  bb pr list --reviewer @me
+
+  # List pull requests assigned to you, the same as --reviewer @me
+  bb pr list --assignee @me
+
+  # List merged pull requests that were created in January 2024
+  bb pr list --state MERGED --since 2024-01-01 --until 2024-02-01
+
   # List pull requests with limit
   bb pr list --limit 10
 
   # Output as JSON
   bb pr list --json
 
+  # Export merged pull requests as TSV
+  bb pr list --state MERGED --limit 200 --format tsv
+
+  # Print one line per pull request with a template
+  bb pr list --template '{{.id}} {{.source_branch}} {{.author}}'
+
   # List PRs for a specific repository
-  bb pr list --repo workspace/repo`,
+  bb pr list --repo workspace/repo
+
+  # Fail in a script when there are no open pull requests
+  bb pr list --state OPEN --exit-code
+
+  # Open the merged pull requests in the browser
+  bb pr list --state MERGED --web`,
 		Aliases: []string{"ls"},
 		RunE: func(cmd *cobra.Command, args []string) error {
 			return runList(cmd.Context(), opts)
@@ -60,19 +111,56 @@ by state (OPEN, MERGED, DECLINED).`,
 	}
 
 	cmd.Flags().StringVarP(&opts.State, "state", "s", "OPEN", "Filter by state: OPEN, MERGED, DECLINED")
-	cmd.Flags().StringVarP(&opts.Author, "author", "a", "", "Filter by author username")
+	cmd.Flags().StringVarP(&opts.Author, "author", "a", "", "Filter by author username, or @me")
+	cmd.Flags().BoolVar(&opts.CreatedByMe, "created-by-me", false, "Only pull requests you created (same as --author @me)")
+	cmd.MarkFlagsMutuallyExclusive("author", "created-by-me")
+	cmd.Flags().StringVar(&opts.Reviewer, "reviewer", "", "Filter by reviewer username, or @me")
+	cmd.Flags().StringVar(&opts.Reviewer, "assignee", "", "Filter by assignee, which Bitbucket calls a reviewer (same as --reviewer)")
+	cmd.MarkFlagsMutuallyExclusive("reviewer", "assignee")
+	cmd.Flags().StringVar(&opts.Since, "since", "", "Only pull requests created on or after this date (UTC)")
+	cmd.Flags().StringVar(&opts.Until, "until", "", "Only pull requests created before this date (UTC)")
 	cmd.Flags().IntVarP(&opts.Limit, "limit", "l", 30, "Maximum number of pull requests to list")
 	cmd.Flags().BoolVar(&opts.JSON, "json", false, "Output in JSON format")
+	cmdutil.AddListFormatFlags(cmd, &opts.Format)
+	cmdutil.AddShowCountFlag(cmd, &opts.ShowCount)
+	cmdutil.AddAllFlag(cmd, &opts.Limit)
+	cmdutil.AddExitCodeFlag(cmd, &opts.ExitCode)
 	cmd.Flags().StringVarP(&opts.Repo, "repo", "R", "", "Repository in WORKSPACE/REPO format")
+	cmd.Flags().BoolVarP(&opts.Web, "web", "w", false, "Open the pull request list in the browser")
+	cmd.Flags().BoolVar(&opts.NoBrowser, "no-browser", false, "With --web, print the URL instead of opening the browser")
+	cmd.MarkFlagsMutuallyExclusive("web", "json")
 
 	_ = cmd.RegisterFlagCompletionFunc("state", cmdutil.StaticFlagCompletion([]string{"OPEN", "MERGED", "DECLINED"}))
 	_ = cmd.RegisterFlagCompletionFunc("author", cmdutil.CompleteWorkspaceMembers)
+	_ = cmd.RegisterFlagCompletionFunc("reviewer", cmdutil.CompleteWorkspaceMembers)
+	_ = cmd.RegisterFlagCompletionFunc("assignee", cmdutil.CompleteWorkspaceMembers)
 	_ = cmd.RegisterFlagCompletionFunc("repo", cmdutil.CompleteRepoNames)
 
 	return cmd
 }
 
+// prListFields are the columns of --format csv and tsv, in order
+var prListFields = []string{"id", "title", "state", "author", "source_branch", "destination_branch", "comment_count", "task_count", "created_on", "updated_on", "url"}
+
 func runList(ctx context.Context, opts *ListOptions) error {
+	if err := opts.Format.Validate(opts.JSON); err != nil {
+		return err
+	}
+
+	if opts.CreatedByMe {
+		opts.Author = "@me"
+	}
+
+	// Validate state
+	state := strings.ToUpper(opts.State)
+	if state != "OPEN" && state != "MERGED" && state != "DECLINED" {
+		return fmt.Errorf("invalid state: %s (must be OPEN, MERGED, or DECLINED)", opts.State)
+	}
+
+	if opts.Web {
+		return openListWeb(opts, state)
+	}
+
 	// Get API client
 	client, err := cmdutil.GetAPIClient()
 	if err != nil {
@@ -85,86 +173,116 @@ func runList(ctx context.Context, opts *ListOptions) error {
 		return err
 	}
 
-	// Validate state
-	state := strings.ToUpper(opts.State)
-	if state != "OPEN" && state != "MERGED" && state != "DECLINED" {
-		return fmt.Errorf("invalid state: %s (must be OPEN, MERGED, or DECLINED)", opts.State)
+	since, until, err := cmdutil.ParseDateRange(opts.Since, opts.Until)
+	if err != nil {
+		return err
+	}
+
+	author, err := cmdutil.ResolvePrincipal(ctx, client, opts.Author)
+	if err != nil {
+		return err
+	}
+	reviewer, err := cmdutil.ResolvePrincipal(ctx, client, opts.Reviewer)
+	if err != nil {
+		return err
 	}
 
 	// Build list options
 	listOpts := &api.PRListOptions{
-		State:  api.PRState(state),
-		Author: opts.Author,
-		Limit:  opts.Limit,
+		State:    api.PRState(state),
+		Author:   author,
+		Reviewer: reviewer,
+		Since:    since,
+		Until:    until,
+		Limit:    cmdutil.PageLen(opts.Limit, cmdutil.MaxPageLen),
 	}
 
+	opts.Streams.Verbose("Fetching %s pull requests for %s/%s...", strings.ToLower(state), workspace, repoSlug)
 	// Fetch pull requests
 	result, err := client.ListPullRequests(ctx, workspace, repoSlug, listOpts)
+	if err == nil {
+		result, err = cmdutil.CollectPages(ctx, client, result, opts.Limit)
+	}
 	if err != nil {
 		return fmt.Errorf("failed to list pull requests: %w", err)
 	}
 
 	if len(result.Values) == 0 {
-		if opts.Author != "" {
+		switch {
+		case opts.Author != "":
 			opts.Streams.Info("No %s pull requests found by %s in %s/%s", strings.ToLower(state), opts.Author, workspace, repoSlug)
-		} else {
+		case opts.Reviewer != "":
+			opts.Streams.Info("No %s pull requests found for reviewer %s in %s/%s", strings.ToLower(state), opts.Reviewer, workspace, repoSlug)
+		default:
 			opts.Streams.Info("No %s pull requests found in %s/%s", strings.ToLower(state), workspace, repoSlug)
 		}
-		return nil
+		return cmdutil.NoResults(opts.ExitCode)
+	}
+
+	var count *cmdutil.PageCount
+	if opts.ShowCount {
+		count = cmdutil.NewPageCount(result, len(result.Values))
 	}
 
 	// Output results
 	if opts.JSON {
-		return outputListJSON(opts.Streams, result.Values)
+		return outputListJSON(opts.Streams, result.Values, count)
+	}
+	if opts.Format.Enabled() {
+		return opts.Format.Print(opts.Streams, prListFields, prListJSON(result.Values))
 	}
 
-	return outputTable(opts.Streams, result.Values)
+	if err := outputTable(opts.Streams, result.Values); err != nil {
+		return err
+	}
+	cmdutil.PrintPageCount(opts.Streams, count)
+	return nil
 }
 
-func outputListJSON(streams *iostreams.IOStreams, prs []api.PullRequest) error {
-	// Create simplified JSON output
+func outputListJSON(streams *iostreams.IOStreams, prs []api.PullRequest, count *cmdutil.PageCount) error {
+	return cmdutil.PrintListJSON(streams, prListJSON(prs), count)
+}
+
+// prListJSON returns the simplified form of prs shared by --json, --format
+// and --template
+func prListJSON(prs []api.PullRequest) []api.PullRequestJSON {
 	output := make([]api.PullRequestJSON, len(prs))
 	for i := range prs {
 		output[i] = api.PullRequestJSON{PullRequest: &prs[i]}
 	}
-
-	return cmdutil.PrintJSON(streams, output)
+	return output
 }
 
 func outputTable(streams *iostreams.IOStreams, prs []api.PullRequest) error {
-	w := tabwriter.NewWriter(streams.Out, 0, 0, 2, ' ', 0)
-
-	// Print header
-	header := "ID\tTITLE\tBRANCH\tAUTHOR\tSTATUS"
-	cmdutil.PrintTableHeader(streams, w, header)
+	t := cmdutil.NewTableWriter(streams, "ID", "TITLE", "BRANCH", "AUTHOR", "STATUS")
+	t.SetFlexColumn(1)
+	t.SetMaxWidth(2, 30)
+	t.SetMaxWidth(3, 20)
 
-	// Print rows
 	for _, pr := range prs {
-		title := cmdutil.TruncateString(pr.Title, 50)
-		branch := cmdutil.TruncateString(pr.Source.Branch.Name, 30)
-		author := cmdutil.TruncateString(pr.Author.DisplayName, 20)
-		status := formatStatus(streams, string(pr.State))
-
-		fmt.Fprintf(w, "%d\t%s\t%s\t%s\t%s\n",
-			pr.ID, title, branch, author, status)
+		status := cmdutil.ColorState(streams, string(pr.State))
+		t.AddRow(fmt.Sprintf("%d", pr.ID), pr.Title, pr.Source.Branch.Name, pr.Author.DisplayName, status)
 	}
 
-	return w.Flush()
+	return t.Render()
 }
 
-func formatStatus(streams *iostreams.IOStreams, state string) string {
-	if !streams.ColorEnabled() {
-		return state
+// openListWeb opens the pull request page of the repository with the state
+// and author filters the web UI understands
+func openListWeb(opts *ListOptions, state string) error {
+	workspace, repoSlug, err := cmdutil.ParseRepository(opts.Repo)
+	if err != nil {
+		return err
 	}
 
-	switch state {
-	case "OPEN":
-		return iostreams.Green + state + iostreams.Reset
-	case "MERGED":
-		return iostreams.Magenta + state + iostreams.Reset
-	case "DECLINED":
-		return iostreams.Red + state + iostreams.Reset
-	default:
-		return state
+	query := url.Values{"state": {state}}
+	if opts.Author != "" {
+		if cmdutil.IsUUID(opts.Author) {
+			query.Set("author", opts.Author)
+		} else {
+			opts.Streams.Warning("The web UI can only filter by an account UUID; ignoring --author %s", opts.Author)
+		}
 	}
+
+	return cmdutil.OpenWeb(opts.Streams, cmdutil.WebURL(query, workspace, repoSlug, "pull-requests"), opts.NoBrowser)
 }
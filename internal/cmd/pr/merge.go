@@ -1,10 +1,10 @@
 package pr
 
 import (
-	"bufio"
 	"context"
+	"errors"
 	"fmt"
-	"os"
+	"slices"
 	"strings"
 	"time"
 
@@ -12,26 +12,31 @@ import (
 
 	"github.com/rbansal42/bitbucket-cli/internal/api"
 	"github.com/rbansal42/bitbucket-cli/internal/cmdutil"
+	"github.com/rbansal42/bitbucket-cli/internal/config"
 	"github.com/rbansal42/bitbucket-cli/internal/git"
 	"github.com/rbansal42/bitbucket-cli/internal/iostreams"
 )
 
 type mergeOptions struct {
-	streams      *iostreams.IOStreams
-	prNumber     int
-	repo         string
-	mergeMethod  string // "merge", "squash", or "rebase"
-	deleteBranch bool
-	message      string
-	autoMerge    bool
-	yes          bool // skip confirmation
+	streams       *iostreams.IOStreams
+	prNumber      int
+	repo          string
+	strategy      api.MergeStrategy // from flags; empty to use the configured default
+	deleteBranch  bool
+	message       string
+	autoMerge     bool
+	timeout       time.Duration // how long --auto waits for checks
+	interval      time.Duration // how often --auto polls
+	yes           bool          // skip confirmation
+	selectPRs     bool          // pick several PRs interactively
+	force         bool          // merge even when a blocking merge check fails
+	allowNoChecks bool          // with --auto, merge when no checks are reported
 }
 
 // NewCmdMerge creates the merge command
 func NewCmdMerge(streams *iostreams.IOStreams) *cobra.Command {
 	opts := &mergeOptions{
-		streams:     streams,
-		mergeMethod: "merge", // default
+		streams: streams,
 	}
 
 	cmd := &cobra.Command{
@@ -42,15 +47,44 @@ func NewCmdMerge(streams *iostreams.IOStreams) *cobra.Command {
 If no pull request number is provided, the command will try to find a
 pull request associated with the current branch.
 
-By default, the pull request is merged using a merge commit. Use --squash
-for squash merge or --rebase to attempt a rebase merge (note: Bitbucket
-may not support rebase merge for all repositories).`,
+The merge strategy is chosen by --merge-commit, --squash or --fast-forward.
+Without one of these flags, the merge_strategy key from a .bb.yml file in the
+current directory or repository root is used, then the merge_strategy set
+with 'bb config set', and finally the destination branch's default in
+Bitbucket. If a configured strategy is not allowed for the destination
+branch, bb warns and falls back to the branch's default.
+
+Use --select to merge several pull requests at once: the open pull requests
+are shown in a checkbox list (space to toggle, enter to confirm) and each
+chosen one is merged with the selected strategy. A result is reported for
+every pull request. --select needs an interactive terminal; in scripts pass
+a pull request number instead.
+
+With --auto, bb waits for the pull request's checks before merging. It
+polls every --interval until every check on the latest commit has
+succeeded, then merges. It gives up without merging if a check fails or is
+stopped, if the pull request is closed, or after --timeout. When new commits
+are pushed while waiting, only the checks on the newest commit count. Until
+a check is reported on the newest commit, bb keeps waiting, since CI may not
+have started yet; use --allow-no-checks to merge a pull request that has no
+checks at all.
+
+Before merging, bb evaluates the pull request's merge checks: it must have
+no merge conflicts, and it must meet the destination branch's merge
+restrictions, such as a minimum number of approvals or passing builds. Unmet
+checks that Bitbucket enforces (with the enforce_merge_checks restriction)
+stop the merge; the others are shown as warnings. Use --force to merge
+anyway, though Bitbucket may still refuse. Branch restrictions can only be
+read by repository admins; for other users only conflicts are checked.`,
 		Example: `  # Merge pull request #123
   bb pr merge 123
 
   # Squash merge
   bb pr merge 123 --squash
 
+  # Squash merge by default in this repository
+  echo "merge_strategy: squash" >> .bb.yml
+
   # Merge and delete the source branch
   bb pr merge 123 --delete-branch
 
@@ -60,8 +94,14 @@ may not support rebase merge for all repositories).`,
   # Skip confirmation prompt
   bb pr merge 123 --yes
 
-  # Enable auto-merge when checks pass
-  bb pr merge 123 --auto`,
+  # Pick several pull requests to squash merge
+  bb pr merge --select --squash
+
+  # Squash merge once all checks have passed, waiting up to an hour
+  bb pr merge 123 --auto --squash --timeout 1h
+
+  # Merge even though a merge check fails
+  bb pr merge 123 --force`,
 		Args: cobra.MaximumNArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			// Get repo from flag
@@ -79,13 +119,32 @@ may not support rebase merge for all repositories).`,
 			}
 			// If no PR number given, we'll try to find it later from current branch
 
-			// Determine merge method from flags
-			if squash, _ := cmd.Flags().GetBool("squash"); squash {
-				opts.mergeMethod = "squash"
-			} else if rebase, _ := cmd.Flags().GetBool("rebase"); rebase {
-				opts.mergeMethod = "rebase"
+			// Determine merge strategy from flags
+			for flag, strategy := range strategyFlags {
+				if on, _ := cmd.Flags().GetBool(flag); on {
+					opts.strategy = strategy
+				}
+			}
+
+			if !opts.autoMerge && (cmd.Flags().Changed("timeout") || cmd.Flags().Changed("interval") || opts.allowNoChecks) {
+				return fmt.Errorf("--timeout, --interval and --allow-no-checks can only be used with --auto")
+			}
+			if opts.interval <= 0 {
+				return fmt.Errorf("--interval must be positive")
+			}
+
+			if opts.selectPRs {
+				if len(args) > 0 {
+					return fmt.Errorf("cannot combine a pull request number with --select")
+				}
+				if opts.message != "" || opts.autoMerge {
+					return fmt.Errorf("--message and --auto cannot be used with --select")
+				}
+				if !opts.streams.CanPrompt() {
+					return fmt.Errorf("--select requires an interactive terminal; pass a pull request number instead")
+				}
+				return runMergeSelect(cmd.Context(), opts)
 			}
-			// "merge" is default, no need to check
 
 			return runMerge(opts)
 		},
@@ -93,14 +152,22 @@ may not support rebase merge for all repositories).`,
 
 	cmd.Flags().BoolVarP(&opts.deleteBranch, "delete-branch", "d", false, "Delete the source branch after merge")
 	cmd.Flags().StringVarP(&opts.message, "message", "m", "", "Custom merge commit message")
-	cmd.Flags().BoolVar(&opts.autoMerge, "auto", false, "Enable auto-merge when checks pass")
+	cmd.Flags().BoolVar(&opts.autoMerge, "auto", false, "Wait for all checks to pass, then merge")
+	cmd.Flags().DurationVar(&opts.timeout, "timeout", 30*time.Minute, "Maximum time --auto waits for checks")
+	cmd.Flags().DurationVarP(&opts.interval, "interval", "i", 10*time.Second, "Polling interval for --auto")
 	cmd.Flags().BoolVarP(&opts.yes, "yes", "y", false, "Skip confirmation prompt")
+	cmd.Flags().BoolVar(&opts.selectPRs, "select", false, "Choose several pull requests to merge from an interactive list")
+	cmd.Flags().BoolVar(&opts.force, "force", false, "Merge even when a blocking merge check fails")
+	cmd.Flags().BoolVar(&opts.allowNoChecks, "allow-no-checks", false, "With --auto, merge when no checks are reported instead of waiting for them")
 	cmd.Flags().StringVarP(&opts.repo, "repo", "R", "", "Repository in WORKSPACE/REPO format")
 
 	// Merge strategy flags (mutually exclusive)
-	cmd.Flags().Bool("merge", false, "Use merge commit (default)")
+	cmd.Flags().Bool("merge-commit", false, "Use a merge commit")
 	cmd.Flags().Bool("squash", false, "Use squash merge")
-	cmd.Flags().Bool("rebase", false, "Use rebase merge (if supported)")
+	cmd.Flags().Bool("fast-forward", false, "Use fast-forward merge")
+	cmd.Flags().Bool("merge", false, "Alias for --merge-commit")
+	cmd.Flags().Bool("rebase", false, "Alias for --fast-forward")
+	cmd.MarkFlagsMutuallyExclusive("merge-commit", "squash", "fast-forward", "merge", "rebase")
 
 	cmd.ValidArgsFunction = cmdutil.CompletePRNumbers
 	_ = cmd.RegisterFlagCompletionFunc("repo", cmdutil.CompleteRepoNames)
@@ -138,6 +205,7 @@ func runMerge(opts *mergeOptions) error {
 		opts.prNumber = prNumber
 	}
 
+	opts.streams.Verbose("Fetching pull request #%d...", opts.prNumber)
 	// Get PR details
 	pr, err := client.GetPullRequest(ctx, workspace, repoSlug, int64(opts.prNumber))
 	if err != nil {
@@ -149,31 +217,60 @@ func runMerge(opts *mergeOptions) error {
 		return fmt.Errorf("pull request #%d is not open (state: %s)", opts.prNumber, pr.State)
 	}
 
-	// Determine merge method from flags
-	mergeMethod := determineMergeMethod(opts)
+	configured, source, err := configuredMergeStrategy()
+	if err != nil {
+		return err
+	}
+	mergeMethod, warning, err := chooseMergeStrategy(pr, opts.strategy, configured, source)
+	if err != nil {
+		return err
+	}
+	if warning != "" {
+		opts.streams.Warning("%s", warning)
+	}
 
-	// Warn about rebase
-	if mergeMethod == "rebase" {
-		opts.streams.Warning("Note: Rebase merge may not be supported for all repositories")
+	// With --auto the checks are evaluated once the builds have finished
+	if !opts.autoMerge {
+		if err := checkMergeable(ctx, opts.streams, client, workspace, repoSlug, pr, opts.force); err != nil {
+			return err
+		}
 	}
 
 	// Confirmation prompt
 	if !opts.yes {
 		opts.streams.Info("Pull request #%d: %s", pr.ID, pr.Title)
 		opts.streams.Info("  %s -> %s", pr.Source.Branch.Name, pr.Destination.Branch.Name)
-		opts.streams.Info("  Merge method: %s", mergeMethod)
+		opts.streams.Info("  Merge strategy: %s", mergeMethod)
 		if opts.deleteBranch {
 			opts.streams.Info("  Will delete source branch after merge")
 		}
 
-		if !confirm(opts.streams, "Merge this pull request?") {
+		confirmed, err := cmdutil.Confirm(opts.streams, "Merge this pull request?", false, "pass --yes to skip confirmation")
+		if err != nil {
+			return err
+		}
+		if !confirmed {
 			return fmt.Errorf("merge cancelled")
 		}
 	}
 
-	// Handle auto-merge
+	// Wait for the checks; the wait has its own deadline, so the merge
+	// gets a fresh one afterwards
 	if opts.autoMerge {
-		return enableAutoMerge(ctx, client, workspace, repoSlug, opts, mergeMethod)
+		if err := waitForChecks(context.Background(), client, workspace, repoSlug, opts); err != nil {
+			return err
+		}
+		ctx, cancel = context.WithTimeout(context.Background(), 60*time.Second)
+		defer cancel()
+
+		// Approvals and commits may have changed while waiting
+		pr, err = client.GetPullRequest(ctx, workspace, repoSlug, int64(opts.prNumber))
+		if err != nil {
+			return fmt.Errorf("failed to get pull request: %w", err)
+		}
+		if err := checkMergeable(ctx, opts.streams, client, workspace, repoSlug, pr, opts.force); err != nil {
+			return err
+		}
 	}
 
 	// Perform the merge
@@ -194,31 +291,183 @@ func runMerge(opts *mergeOptions) error {
 	return nil
 }
 
-// determineMergeMethod determines the merge method from flags
-func determineMergeMethod(opts *mergeOptions) string {
-	// Check explicit flags (these would need to be parsed from cobra.Command)
-	// For now, use the default unless overridden
-	return opts.mergeMethod
+// runMergeSelect merges the open pull requests the user picks from a list
+func runMergeSelect(ctx context.Context, opts *mergeOptions) error {
+	workspace, repoSlug, err := cmdutil.ParseRepository(opts.repo)
+	if err != nil {
+		return err
+	}
+
+	client, err := cmdutil.GetAPIClient()
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Minute)
+	defer cancel()
+
+	prs, err := api.Collect(client.IterPullRequests(ctx, workspace, repoSlug, &api.PRListOptions{
+		State: api.PRStateOpen,
+		Limit: cmdutil.MaxPageLen,
+	}))
+	if err != nil {
+		return fmt.Errorf("failed to list pull requests: %w", err)
+	}
+	if len(prs) == 0 {
+		opts.streams.Info("No open pull requests in %s/%s", workspace, repoSlug)
+		return nil
+	}
+
+	items := make([]string, len(prs))
+	for i, pr := range prs {
+		items[i] = fmt.Sprintf("#%d %s (%s -> %s)", pr.ID, pr.Title, pr.Source.Branch.Name, pr.Destination.Branch.Name)
+	}
+
+	indices, err := cmdutil.MultiSelect("Select pull requests to merge", items)
+	if errors.Is(err, cmdutil.ErrNotInteractive) {
+		return fmt.Errorf("--select requires an interactive terminal; pass a pull request number instead")
+	}
+	if err != nil {
+		return err
+	}
+	if len(indices) == 0 {
+		opts.streams.Info("No pull requests selected")
+		return nil
+	}
+
+	configured, source, err := configuredMergeStrategy()
+	if err != nil {
+		return err
+	}
+
+	// Attempt every pull request and report the outcome of each
+	failed := 0
+	for _, idx := range indices {
+		pr := prs[idx]
+		mergeMethod, warning, err := chooseMergeStrategy(&pr, opts.strategy, configured, source)
+		if err != nil {
+			opts.streams.Error("Failed to merge pull request #%d: %v", pr.ID, err)
+			failed++
+			continue
+		}
+		if warning != "" {
+			opts.streams.Warning("Pull request #%d: %s", pr.ID, warning)
+		}
+		// Listed pull requests have no participants, which the checks need
+		full, err := client.GetPullRequest(ctx, workspace, repoSlug, pr.ID)
+		if err == nil {
+			err = checkMergeable(ctx, opts.streams, client, workspace, repoSlug, full, opts.force)
+		}
+		if err != nil {
+			opts.streams.Error("Failed to merge pull request #%d: %v", pr.ID, err)
+			failed++
+			continue
+		}
+		if err := mergePullRequest(ctx, client, workspace, repoSlug, int(pr.ID), mergeMethod, "", opts.deleteBranch); err != nil {
+			opts.streams.Error("Failed to merge pull request #%d: %v", pr.ID, err)
+			failed++
+			continue
+		}
+		if opts.deleteBranch {
+			opts.streams.Success("Pull request #%d merged and branch %s deleted", pr.ID, pr.Source.Branch.Name)
+		} else {
+			opts.streams.Success("Pull request #%d merged", pr.ID)
+		}
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("failed to merge %d of %d pull requests", failed, len(indices))
+	}
+	return nil
+}
+
+// strategyFlags maps the merge strategy flags to the strategy they select
+var strategyFlags = map[string]api.MergeStrategy{
+	"merge-commit": api.MergeStrategyMergeCommit,
+	"merge":        api.MergeStrategyMergeCommit,
+	"squash":       api.MergeStrategySquash,
+	"fast-forward": api.MergeStrategyFastForward,
+	"rebase":       api.MergeStrategyFastForward,
+}
+
+// configuredMergeStrategy returns the default merge strategy from .bb.yml in
+// the current directory or repository root, or else from the global config,
+// along with where it was found. It returns an empty strategy if none is set.
+func configuredMergeStrategy() (api.MergeStrategy, string, error) {
+	value, source, err := cmdutil.LocalConfigValue(func(c *cmdutil.LocalConfig) string { return c.MergeStrategy })
+	if err != nil {
+		return "", "", err
+	}
+
+	if value == "" {
+		if cfg, err := config.LoadConfig(); err == nil && cfg.MergeStrategy != "" {
+			value, source = cfg.MergeStrategy, "bb config"
+		}
+	}
+	if value == "" {
+		return "", "", nil
+	}
+
+	strategy := api.MergeStrategy(value)
+	if !slices.Contains(api.MergeStrategies, strategy) {
+		return "", "", fmt.Errorf("invalid merge_strategy %q in %s (must be 'merge_commit', 'squash' or 'fast_forward')", value, source)
+	}
+	return strategy, source, nil
+}
+
+// chooseMergeStrategy picks the strategy to merge pr with. An explicit
+// strategy from flags must be allowed by the destination branch. A
+// configured default that is not allowed falls back to the branch default,
+// with a warning for the user.
+func chooseMergeStrategy(pr *api.PullRequest, explicit, configured api.MergeStrategy, source string) (api.MergeStrategy, string, error) {
+	branch := pr.Destination.Branch
+	allowed := func(s api.MergeStrategy) bool {
+		// Older responses don't list strategies; let Bitbucket decide then
+		return len(branch.MergeStrategies) == 0 || slices.Contains(branch.MergeStrategies, s)
+	}
+
+	fallback := branch.DefaultMergeStrategy
+	if fallback == "" || !allowed(fallback) {
+		fallback = api.MergeStrategyMergeCommit
+		if len(branch.MergeStrategies) > 0 && !allowed(fallback) {
+			fallback = branch.MergeStrategies[0]
+		}
+	}
+
+	switch {
+	case explicit != "":
+		if !allowed(explicit) {
+			return "", "", fmt.Errorf("%s is not allowed when merging into %s (allowed: %s)", explicit, branch.Name, joinStrategies(branch.MergeStrategies))
+		}
+		return explicit, "", nil
+	case configured != "":
+		if !allowed(configured) {
+			warning := fmt.Sprintf("merge_strategy %s from %s is not allowed when merging into %s; using %s", configured, source, branch.Name, fallback)
+			return fallback, warning, nil
+		}
+		return configured, "", nil
+	default:
+		return fallback, "", nil
+	}
+}
+
+func joinStrategies(strategies []api.MergeStrategy) string {
+	names := make([]string, len(strategies))
+	for i, s := range strategies {
+		names[i] = string(s)
+	}
+	return strings.Join(names, ", ")
 }
 
 // mergePullRequest merges a pull request via the API
-func mergePullRequest(ctx context.Context, client *api.Client, workspace, repoSlug string, prNumber int, mergeMethod, message string, deleteBranch bool) error {
+func mergePullRequest(ctx context.Context, client *api.Client, workspace, repoSlug string, prNumber int, strategy api.MergeStrategy, message string, deleteBranch bool) error {
 	path := fmt.Sprintf("/repositories/%s/%s/pullrequests/%d/merge", workspace, repoSlug, prNumber)
 
 	// Build merge request body
 	body := map[string]interface{}{}
 
 	// Set merge strategy
-	switch mergeMethod {
-	case "squash":
-		body["merge_strategy"] = "squash"
-	case "rebase":
-		// Bitbucket uses "fast_forward" which may fail if not possible
-		body["merge_strategy"] = "fast_forward"
-	default:
-		// Default is merge commit
-		body["merge_strategy"] = "merge_commit"
-	}
+	body["merge_strategy"] = string(strategy)
 
 	// Set custom commit message if provided
 	if message != "" {
@@ -234,31 +483,108 @@ func mergePullRequest(ctx context.Context, client *api.Client, workspace, repoSl
 	return err
 }
 
-// enableAutoMerge enables auto-merge for a PR when checks pass
-func enableAutoMerge(ctx context.Context, client *api.Client, workspace, repoSlug string, opts *mergeOptions, mergeMethod string) error {
-	// Note: Bitbucket's auto-merge API may differ from this implementation
-	// This is a simplified version - the actual API endpoint may vary
+// waitForChecks polls a pull request until every check on its latest
+// commit has succeeded. It fails as soon as a check fails or is stopped, the
+// pull request is no longer open, or opts.timeout passes.
+func waitForChecks(ctx context.Context, client *api.Client, workspace, repoSlug string, opts *mergeOptions) error {
+	ctx, cancel := context.WithTimeout(ctx, opts.timeout)
+	defer cancel()
+
+	prID := int64(opts.prNumber)
+	var head, progress string
+	for {
+		pr, err := client.GetPullRequest(ctx, workspace, repoSlug, prID)
+		if err != nil {
+			return waitError(ctx, opts, fmt.Errorf("failed to get pull request: %w", err))
+		}
+		if pr.State != api.PRStateOpen {
+			return fmt.Errorf("pull request #%d was %s while waiting for checks; not merging", prID, strings.ToLower(string(pr.State)))
+		}
+		if hash := pr.Source.Commit.Hash; hash != head {
+			if head != "" {
+				opts.streams.Info("New commits pushed; waiting for checks on %s", cmdutil.ShortHash(hash))
+			}
+			head, progress = hash, ""
+		}
 
-	opts.streams.Warning("Auto-merge is not directly supported via API. Consider enabling it in the Bitbucket web interface.")
-	opts.streams.Info("Alternatively, you can wait for checks to pass and then run 'bb pr merge %d' again.", opts.prNumber)
+		statuses, err := fetchChecks(ctx, client, workspace, repoSlug, prID)
+		if err != nil {
+			return waitError(ctx, opts, err)
+		}
+		statuses = statusesForCommit(statuses, head)
 
-	return nil
+		if failed := failedChecks(statuses); len(failed) > 0 {
+			return fmt.Errorf("not merging pull request #%d: check %q %s", prID, checkName(failed[0]), strings.ToLower(failed[0].State))
+		}
+		if len(statuses) == 0 && opts.allowNoChecks {
+			opts.streams.Info("No checks reported for pull request #%d", prID)
+			return nil
+		}
+		// No checks on the head yet means CI hasn't reported on it, not
+		// that it passed, so keep waiting
+		if len(statuses) > 0 && allChecksFinished(statuses) {
+			opts.streams.Success("All %d checks passed", len(statuses))
+			return nil
+		}
+
+		summary := "no checks reported yet"
+		if len(statuses) > 0 {
+			summary = summarizeChecks(statuses)
+		}
+		if summary != progress {
+			opts.streams.Info("Waiting for checks on %s: %s", cmdutil.ShortHash(head), summary)
+			progress = summary
+		}
+
+		select {
+		case <-ctx.Done():
+			return waitError(ctx, opts, ctx.Err())
+		case <-time.After(opts.interval):
+		}
+	}
 }
 
-// confirm prompts the user for confirmation
-func confirm(streams *iostreams.IOStreams, prompt string) bool {
-	if !streams.IsStdinTTY() {
-		return false
+// waitError reports a timeout in terms of --timeout rather than as a
+// failed request
+func waitError(ctx context.Context, opts *mergeOptions, err error) error {
+	if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		return fmt.Errorf("timed out after %s waiting for checks on pull request #%d; not merging", opts.timeout, opts.prNumber)
 	}
+	return err
+}
 
-	fmt.Fprintf(streams.Out, "%s [y/N]: ", prompt)
+// statusesForCommit keeps the statuses reported for the commit hash, which
+// may be abbreviated. Statuses without a commit link are kept.
+func statusesForCommit(statuses []api.CommitStatus, hash string) []api.CommitStatus {
+	if hash == "" {
+		return statuses
+	}
+	var kept []api.CommitStatus
+	for _, s := range statuses {
+		href := s.Links.Commit.Href
+		_, commit, found := strings.Cut(href, "/commit/")
+		if href == "" || !found || strings.HasPrefix(commit, hash) || strings.HasPrefix(hash, commit) {
+			kept = append(kept, s)
+		}
+	}
+	return kept
+}
 
-	reader := bufio.NewReader(os.Stdin)
-	response, err := reader.ReadString('\n')
-	if err != nil {
-		return false
+// failedChecks returns the checks that failed or were stopped
+func failedChecks(statuses []api.CommitStatus) []api.CommitStatus {
+	var failed []api.CommitStatus
+	for _, s := range statuses {
+		if s.State == "FAILED" || s.State == "STOPPED" {
+			failed = append(failed, s)
+		}
 	}
+	return failed
+}
 
-	response = strings.TrimSpace(strings.ToLower(response))
-	return response == "y" || response == "yes"
+// checkName is the name a check is shown with
+func checkName(s api.CommitStatus) string {
+	if s.Name != "" {
+		return s.Name
+	}
+	return s.Key
 }
@@ -0,0 +1,306 @@
+package pr
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/rbansal42/bitbucket-cli/internal/api"
+	"github.com/rbansal42/bitbucket-cli/internal/iostreams"
+)
+
+func TestChooseMergeStrategy(t *testing.T) {
+	squashOnly := &api.PullRequest{}
+	squashOnly.Destination.Branch = api.Branch{
+		Name:                 "main",
+		MergeStrategies:      []api.MergeStrategy{api.MergeStrategySquash},
+		DefaultMergeStrategy: api.MergeStrategySquash,
+	}
+	unrestricted := &api.PullRequest{}
+	unrestricted.Destination.Branch = api.Branch{Name: "main"}
+
+	tests := []struct {
+		name        string
+		pr          *api.PullRequest
+		explicit    api.MergeStrategy
+		configured  api.MergeStrategy
+		want        api.MergeStrategy
+		wantWarning bool
+		wantErr     bool
+	}{
+		{name: "nothing set uses merge commit", pr: unrestricted, want: api.MergeStrategyMergeCommit},
+		{name: "nothing set uses branch default", pr: squashOnly, want: api.MergeStrategySquash},
+		{name: "configured default", pr: unrestricted, configured: api.MergeStrategySquash, want: api.MergeStrategySquash},
+		{name: "flag beats configured default", pr: unrestricted, explicit: api.MergeStrategyFastForward, configured: api.MergeStrategySquash, want: api.MergeStrategyFastForward},
+		{name: "disallowed configured default falls back", pr: squashOnly, configured: api.MergeStrategyMergeCommit, want: api.MergeStrategySquash, wantWarning: true},
+		{name: "disallowed flag is an error", pr: squashOnly, explicit: api.MergeStrategyFastForward, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, warning, err := chooseMergeStrategy(tt.pr, tt.explicit, tt.configured, ".bb.yml")
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("expected an error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("chooseMergeStrategy() = %s, want %s", got, tt.want)
+			}
+			if (warning != "") != tt.wantWarning {
+				t.Errorf("unexpected warning %q", warning)
+			}
+		})
+	}
+}
+
+func TestConfiguredMergeStrategy(t *testing.T) {
+	dir := t.TempDir()
+	t.Chdir(dir)
+	t.Setenv("BB_CONFIG_DIR", t.TempDir())
+
+	strategy, _, err := configuredMergeStrategy()
+	if err != nil || strategy != "" {
+		t.Fatalf("expected no strategy, got %q, %v", strategy, err)
+	}
+
+	if err := os.WriteFile(".bb.yml", []byte("default_repo: ws/repo\nmerge_strategy: squash\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	strategy, source, err := configuredMergeStrategy()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if strategy != api.MergeStrategySquash || !strings.HasSuffix(source, ".bb.yml") {
+		t.Errorf("got %q from %q, want squash from .bb.yml", strategy, source)
+	}
+
+	if err := os.WriteFile(".bb.yml", []byte("merge_strategy: rebase\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := configuredMergeStrategy(); err == nil {
+		t.Error("expected an error for an invalid strategy")
+	}
+}
+
+func TestStatusesForCommit(t *testing.T) {
+	status := func(key, href string) api.CommitStatus {
+		s := api.CommitStatus{Key: key}
+		s.Links.Commit.Href = href
+		return s
+	}
+	statuses := []api.CommitStatus{
+		status("old", "https://api.bitbucket.org/2.0/repositories/ws/repo/commit/aaaaaaaaaaaa1111"),
+		status("new", "https://api.bitbucket.org/2.0/repositories/ws/repo/commit/bbbbbbbbbbbb2222"),
+		status("unlinked", ""),
+	}
+
+	got := statusesForCommit(statuses, "bbbbbbbbbbbb")
+	if len(got) != 2 || got[0].Key != "new" || got[1].Key != "unlinked" {
+		t.Errorf("statusesForCommit() = %v, want the new and unlinked statuses", got)
+	}
+	if got := statusesForCommit(statuses, ""); len(got) != 3 {
+		t.Errorf("statusesForCommit(no hash) kept %d statuses, want 3", len(got))
+	}
+}
+
+func TestWaitForChecks(t *testing.T) {
+	const prPath = "/repositories/ws/repo/pullrequests/7"
+	statusJSON := func(hash, state string) string {
+		return `{"key": "build-` + hash + `", "state": "` + state + `", "links": {"commit": {"href": "https://x/commit/` + hash + `"}}}`
+	}
+
+	tests := []struct {
+		name          string
+		polls         []string // PR state and head hash per poll
+		checks        []string // statuses JSON per poll
+		allowNoChecks bool
+		wantErr       string
+	}{
+		{
+			name:   "passes after new commits",
+			polls:  []string{"OPEN aaa", "OPEN bbb", "OPEN bbb"},
+			checks: []string{statusJSON("aaa", "INPROGRESS"), statusJSON("aaa", "SUCCESSFUL") + "," + statusJSON("bbb", "INPROGRESS"), statusJSON("aaa", "SUCCESSFUL") + "," + statusJSON("bbb", "SUCCESSFUL")},
+		},
+		{
+			name:   "waits until checks are reported",
+			polls:  []string{"OPEN aaa", "OPEN aaa", "OPEN aaa"},
+			checks: []string{"", statusJSON("aaa", "INPROGRESS"), statusJSON("aaa", "SUCCESSFUL")},
+		},
+		{
+			name:   "waits for checks on new commits",
+			polls:  []string{"OPEN aaa", "OPEN bbb", "OPEN bbb"},
+			checks: []string{statusJSON("aaa", "INPROGRESS"), statusJSON("aaa", "SUCCESSFUL"), statusJSON("aaa", "SUCCESSFUL") + "," + statusJSON("bbb", "SUCCESSFUL")},
+		},
+		{
+			name:          "no checks allowed",
+			polls:         []string{"OPEN aaa"},
+			checks:        []string{""},
+			allowNoChecks: true,
+		},
+		{
+			name:    "failed check aborts",
+			polls:   []string{"OPEN aaa"},
+			checks:  []string{statusJSON("aaa", "FAILED") + "," + `{"key": "lint", "state": "INPROGRESS"}`},
+			wantErr: "failed",
+		},
+		{
+			name:    "declined while waiting",
+			polls:   []string{"OPEN aaa", "DECLINED aaa"},
+			checks:  []string{statusJSON("aaa", "INPROGRESS"), statusJSON("aaa", "INPROGRESS")},
+			wantErr: "was declined",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			poll := -1
+			client := newViewTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+				switch r.URL.Path {
+				case prPath:
+					poll++
+					state, hash, _ := strings.Cut(tt.polls[poll], " ")
+					fmt.Fprintf(w, `{"id": 7, "state": %q, "source": {"commit": {"hash": %q}}}`, state, hash)
+				case prPath + "/statuses":
+					fmt.Fprintf(w, `{"values": [%s]}`, tt.checks[poll])
+				default:
+					http.NotFound(w, r)
+				}
+			})
+
+			opts := &mergeOptions{
+				streams:       &iostreams.IOStreams{Out: &bytes.Buffer{}, ErrOut: &bytes.Buffer{}},
+				prNumber:      7,
+				timeout:       5 * time.Second,
+				interval:      time.Millisecond,
+				allowNoChecks: tt.allowNoChecks,
+			}
+			err := waitForChecks(context.Background(), client, "ws", "repo", opts)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("waitForChecks() error: %v", err)
+				}
+				if poll != len(tt.polls)-1 {
+					t.Errorf("waitForChecks() polled %d times, want %d", poll+1, len(tt.polls))
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("waitForChecks() error = %v, want it to mention %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestWaitForChecks_Timeout(t *testing.T) {
+	client := newViewTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, "/statuses") {
+			fmt.Fprint(w, `{"values": [{"key": "build", "state": "INPROGRESS"}]}`)
+			return
+		}
+		fmt.Fprint(w, `{"id": 7, "state": "OPEN", "source": {"commit": {"hash": "aaa"}}}`)
+	})
+
+	opts := &mergeOptions{
+		streams:  &iostreams.IOStreams{Out: &bytes.Buffer{}, ErrOut: &bytes.Buffer{}},
+		prNumber: 7,
+		timeout:  50 * time.Millisecond,
+		interval: 10 * time.Millisecond,
+	}
+	err := waitForChecks(context.Background(), client, "ws", "repo", opts)
+	if err == nil || !strings.Contains(err.Error(), "timed out after 50ms") {
+		t.Errorf("waitForChecks() error = %v, want a timeout", err)
+	}
+}
+
+func TestWaitForChecks_NoChecksTimeout(t *testing.T) {
+	client := newViewTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, "/statuses") {
+			fmt.Fprint(w, `{"values": []}`)
+			return
+		}
+		fmt.Fprint(w, `{"id": 7, "state": "OPEN", "source": {"commit": {"hash": "aaa"}}}`)
+	})
+
+	opts := &mergeOptions{
+		streams:  &iostreams.IOStreams{Out: &bytes.Buffer{}, ErrOut: &bytes.Buffer{}},
+		prNumber: 7,
+		timeout:  50 * time.Millisecond,
+		interval: 10 * time.Millisecond,
+	}
+	err := waitForChecks(context.Background(), client, "ws", "repo", opts)
+	if err == nil || !strings.Contains(err.Error(), "timed out") {
+		t.Errorf("waitForChecks() error = %v, want a timeout rather than merging without checks", err)
+	}
+}
+
+func TestCheckMergeable(t *testing.T) {
+	const prPath = "/repositories/ws/repo/pullrequests/7"
+	newClient := func(restrictions int) *api.Client {
+		return newViewTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+			switch r.URL.Path {
+			case "/repositories/ws/repo/branch-restrictions":
+				if restrictions != http.StatusOK {
+					w.WriteHeader(restrictions)
+					fmt.Fprint(w, `{"type": "error", "error": {"message": "denied"}}`)
+					return
+				}
+				fmt.Fprint(w, `{"values": [
+					{"kind": "require_approvals_to_merge", "branch_match_kind": "glob", "pattern": "main", "value": 1},
+					{"kind": "require_tasks_to_be_completed", "branch_match_kind": "glob", "pattern": "main"},
+					{"kind": "enforce_merge_checks", "branch_match_kind": "glob", "pattern": "main"}
+				]}`)
+			case prPath + "/diffstat", prPath + "/statuses":
+				fmt.Fprint(w, `{"values": []}`)
+			default:
+				http.NotFound(w, r)
+			}
+		})
+	}
+	pr := func() *api.PullRequest {
+		return &api.PullRequest{ID: 7, Destination: api.PRRef{Branch: api.Branch{Name: "main"}}}
+	}
+
+	t.Run("blocking check refuses", func(t *testing.T) {
+		streams := &iostreams.IOStreams{Out: &bytes.Buffer{}, ErrOut: &bytes.Buffer{}}
+		err := checkMergeable(context.Background(), streams, newClient(http.StatusOK), "ws", "repo", pr(), false)
+		if err == nil || !strings.Contains(err.Error(), "Minimum approvals (0 of 1 approvals)") || !strings.Contains(err.Error(), "--force") {
+			t.Errorf("checkMergeable() error = %v, want the unmet approvals and a --force hint", err)
+		}
+	})
+
+	t.Run("force warns", func(t *testing.T) {
+		errOut := &bytes.Buffer{}
+		streams := &iostreams.IOStreams{Out: &bytes.Buffer{}, ErrOut: errOut}
+		p := pr()
+		if err := checkMergeable(context.Background(), streams, newClient(http.StatusOK), "ws", "repo", p, true); err != nil {
+			t.Fatalf("checkMergeable(force) error: %v", err)
+		}
+		if !strings.Contains(errOut.String(), "Merging despite failed merge check: Minimum approvals") {
+			t.Errorf("warnings = %q", errOut.String())
+		}
+		if len(p.MergeChecks) != 3 {
+			t.Errorf("MergeChecks = %+v, want 3 checks", p.MergeChecks)
+		}
+	})
+
+	t.Run("unreadable restrictions only check conflicts", func(t *testing.T) {
+		streams := &iostreams.IOStreams{Out: &bytes.Buffer{}, ErrOut: &bytes.Buffer{}}
+		p := pr()
+		if err := checkMergeable(context.Background(), streams, newClient(http.StatusForbidden), "ws", "repo", p, false); err != nil {
+			t.Fatalf("checkMergeable() error: %v", err)
+		}
+		if len(p.MergeChecks) != 1 || !p.MergeChecks[0].Passed {
+			t.Errorf("MergeChecks = %+v, want only a passing conflict check", p.MergeChecks)
+		}
+	})
+}
@@ -0,0 +1,115 @@
+package pr
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/rbansal42/bitbucket-cli/internal/api"
+	"github.com/rbansal42/bitbucket-cli/internal/iostreams"
+)
+
+// fetchMergeChecks works out the merge checks of pr from its diffstat, the
+// statuses of its latest commit and its repository's branch restrictions
+func fetchMergeChecks(ctx context.Context, client *api.Client, workspace, repoSlug string, pr *api.PullRequest) ([]api.MergeCheck, error) {
+	restrictions, err := listMergeRestrictions(ctx, client, workspace, repoSlug)
+	if err != nil {
+		return nil, err
+	}
+
+	diffStat, err := client.GetPullRequestDiffStat(ctx, workspace, repoSlug, pr.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get diffstat: %w", err)
+	}
+
+	statuses, err := fetchChecks(ctx, client, workspace, repoSlug, pr.ID)
+	if err != nil {
+		return nil, err
+	}
+	statuses = statusesForCommit(statuses, pr.Source.Commit.Hash)
+
+	return api.EvaluateMergeChecks(pr, restrictions, diffStat, statuses), nil
+}
+
+// listMergeRestrictions lists every branch restriction of a repository. Only
+// repository admins can read them, so a repository whose restrictions can't
+// be read is treated as having none.
+func listMergeRestrictions(ctx context.Context, client *api.Client, workspace, repoSlug string) ([]api.BranchRestriction, error) {
+	page, err := client.ListBranchRestrictions(ctx, workspace, repoSlug, &api.BranchRestrictionListOptions{Limit: 100})
+	var restrictions []api.BranchRestriction
+	for err == nil && page != nil {
+		restrictions = append(restrictions, page.Values...)
+		page, err = api.NextPage(ctx, client, page)
+	}
+
+	var apiErr *api.APIError
+	if errors.As(err, &apiErr) && (apiErr.StatusCode == http.StatusForbidden || apiErr.StatusCode == http.StatusNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to list branch restrictions: %w", err)
+	}
+	return restrictions, nil
+}
+
+// unmetMergeChecks splits the failed checks into those that block the merge
+// and those Bitbucket only warns about
+func unmetMergeChecks(checks []api.MergeCheck) (blocking, advisory []api.MergeCheck) {
+	for _, c := range checks {
+		switch {
+		case c.Passed:
+		case c.Blocking:
+			blocking = append(blocking, c)
+		default:
+			advisory = append(advisory, c)
+		}
+	}
+	return blocking, advisory
+}
+
+// describeMergeCheck is a check's name with its detail, e.g.
+// "Minimum approvals (1 of 2 approvals)"
+func describeMergeCheck(c api.MergeCheck) string {
+	if c.Detail == "" {
+		return c.Name
+	}
+	return fmt.Sprintf("%s (%s)", c.Name, c.Detail)
+}
+
+// checkMergeable refuses to merge pr while one of its blocking merge checks
+// fails, unless force is set, and warns about failed checks that don't
+// block. With force, a failure to work out the checks is only a warning too.
+func checkMergeable(ctx context.Context, streams *iostreams.IOStreams, client *api.Client, workspace, repoSlug string, pr *api.PullRequest, force bool) error {
+	checks, err := fetchMergeChecks(ctx, client, workspace, repoSlug, pr)
+	if err != nil {
+		if force {
+			streams.Warning("Could not check whether pull request #%d can be merged: %v", pr.ID, err)
+			return nil
+		}
+		return fmt.Errorf("could not check whether pull request #%d can be merged: %w; use --force to merge anyway", pr.ID, err)
+	}
+	pr.MergeChecks = checks
+
+	blocking, advisory := unmetMergeChecks(checks)
+	for _, c := range advisory {
+		streams.Warning("Merge check not met: %s", describeMergeCheck(c))
+	}
+	if len(blocking) == 0 {
+		return nil
+	}
+
+	if force {
+		for _, c := range blocking {
+			streams.Warning("Merging despite failed merge check: %s", describeMergeCheck(c))
+		}
+		return nil
+	}
+
+	failed := make([]string, len(blocking))
+	for i, c := range blocking {
+		failed[i] = describeMergeCheck(c)
+	}
+	return fmt.Errorf("pull request #%d can't be merged: %s; use --force to merge anyway", pr.ID, strings.Join(failed, ", "))
+}
@@ -0,0 +1,40 @@
+package pr
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/rbansal42/bitbucket-cli/internal/api"
+)
+
+func TestFetchMergeChecksReadsEveryDiffstatPage(t *testing.T) {
+	client := newViewTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/diffstat"):
+			// The conflict is only on the second page
+			if r.URL.Query().Get("page") == "2" {
+				fmt.Fprint(w, `{"values": [{"status": "merge conflict", "new": {"path": "late.go"}}]}`)
+				return
+			}
+			fmt.Fprintf(w, `{"values": [{"status": "modified", "new": {"path": "early.go"}}], "next": "http://%s%s?page=2"}`, r.Host, r.URL.Path)
+		case strings.HasSuffix(r.URL.Path, "/branch-restrictions"), strings.HasSuffix(r.URL.Path, "/statuses"):
+			fmt.Fprint(w, `{"values": []}`)
+		default:
+			t.Errorf("unexpected request %s", r.URL.Path)
+			http.NotFound(w, r)
+		}
+	})
+
+	checks, err := fetchMergeChecks(context.Background(), client, "ws", "repo", &api.PullRequest{ID: 7})
+	if err != nil {
+		t.Fatalf("fetchMergeChecks() error: %v", err)
+	}
+	blocking, _ := unmetMergeChecks(checks)
+	if len(blocking) != 1 || !strings.Contains(blocking[0].Detail, "late.go") {
+		t.Errorf("blocking checks = %+v, want the conflict in late.go", blocking)
+	}
+}
@@ -37,9 +37,14 @@ your changes are merged.`,
 	cmd.AddCommand(NewCmdClose(streams))
 	cmd.AddCommand(NewCmdReopen(streams))
 	cmd.AddCommand(NewCmdReview(streams))
+	cmd.AddCommand(NewCmdAssign(streams))
 	cmd.AddCommand(NewCmdDiff(streams))
 	cmd.AddCommand(NewCmdComment(streams))
+	cmd.AddCommand(NewCmdComments(streams))
+	cmd.AddCommand(NewCmdCommits(streams))
 	cmd.AddCommand(NewCmdChecks(streams))
+	cmd.AddCommand(NewCmdSubscribe(streams))
+	cmd.AddCommand(NewCmdUnsubscribe(streams))
 
 	return cmd
 }
@@ -186,30 +186,6 @@ func TestParseRepository(t *testing.T) {
 	}
 }
 
-func TestGetEditor(t *testing.T) {
-	// Test that getEditor returns a non-empty string
-	// The actual value depends on environment variables
-	editor := getEditor()
-
-	if editor == "" {
-		t.Error("getEditor() returned empty string")
-	}
-
-	// Should default to "vi" if no env vars are set
-	// This is an implementation detail test
-}
-
-func TestGetEditorPriority(t *testing.T) {
-	// Store original values
-	// Note: In a real test, we'd use t.Setenv() which automatically restores
-	// For now, just test that the function returns a non-empty value
-
-	editor := getEditor()
-	if editor == "" {
-		t.Error("expected non-empty editor")
-	}
-}
-
 // TestPullRequestTypes verifies the PR types can be used correctly
 func TestPullRequestTypes(t *testing.T) {
 	// Test that api.PullRequest struct can be instantiated
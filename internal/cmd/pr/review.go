@@ -91,7 +91,10 @@ func runReview(opts *reviewOptions, args []string) error {
 
 	// If comment flag is set and no body provided, open editor
 	if opts.comment && opts.body == "" {
-		body, err := openEditor("")
+		if !opts.streams.CanPrompt() {
+			return &cmdutil.NoPromptError{Hint: "pass --body"}
+		}
+		body, err := cmdutil.OpenEditor("")
 		if err != nil {
 			return fmt.Errorf("failed to get comment: %w", err)
 		}
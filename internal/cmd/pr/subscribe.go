@@ -0,0 +1,122 @@
+package pr
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/rbansal42/bitbucket-cli/internal/cmdutil"
+	"github.com/rbansal42/bitbucket-cli/internal/iostreams"
+)
+
+type subscribeOptions struct {
+	streams     *iostreams.IOStreams
+	repo        string
+	unsubscribe bool
+}
+
+// NewCmdSubscribe creates the subscribe command
+func NewCmdSubscribe(streams *iostreams.IOStreams) *cobra.Command {
+	opts := &subscribeOptions{
+		streams: streams,
+	}
+
+	cmd := &cobra.Command{
+		Use:   "subscribe <number>",
+		Short: "Get notified about a pull request",
+		Long: `Subscribe to notifications for a pull request.
+
+Bitbucket Cloud does not offer a way to watch a pull request through its API.
+Instead, this command adds you as a reviewer, which makes you a participant
+who is notified of comments, updates and merges. Use 'bb pr unsubscribe' to
+remove yourself again.
+
+The author of a pull request is always notified and cannot be added as a
+reviewer.`,
+		Example: `  # Subscribe to pull request #123
+  bb pr subscribe 123
+
+  # Subscribe to a PR in a specific repository
+  bb pr subscribe 123 --repo workspace/repo`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runSubscribe(opts, args)
+		},
+	}
+
+	cmd.Flags().StringVarP(&opts.repo, "repo", "R", "", "Repository in WORKSPACE/REPO format")
+
+	cmd.ValidArgsFunction = cmdutil.CompletePRNumbers
+	_ = cmd.RegisterFlagCompletionFunc("repo", cmdutil.CompleteRepoNames)
+
+	return cmd
+}
+
+// NewCmdUnsubscribe creates the unsubscribe command
+func NewCmdUnsubscribe(streams *iostreams.IOStreams) *cobra.Command {
+	opts := &subscribeOptions{
+		streams:     streams,
+		unsubscribe: true,
+	}
+
+	cmd := &cobra.Command{
+		Use:   "unsubscribe <number>",
+		Short: "Stop getting notified about a pull request",
+		Long: `Unsubscribe from a pull request by removing yourself as a reviewer.
+
+This reverses 'bb pr subscribe'. Note that it also removes you as a reviewer
+if you were added by someone else.`,
+		Example: `  # Unsubscribe from pull request #123
+  bb pr unsubscribe 123`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runSubscribe(opts, args)
+		},
+	}
+
+	cmd.Flags().StringVarP(&opts.repo, "repo", "R", "", "Repository in WORKSPACE/REPO format")
+
+	cmd.ValidArgsFunction = cmdutil.CompletePRNumbers
+	_ = cmd.RegisterFlagCompletionFunc("repo", cmdutil.CompleteRepoNames)
+
+	return cmd
+}
+
+func runSubscribe(opts *subscribeOptions, args []string) error {
+	prNum, err := parsePRNumber(args)
+	if err != nil {
+		return err
+	}
+
+	workspace, repoSlug, err := cmdutil.ParseRepository(opts.repo)
+	if err != nil {
+		return err
+	}
+
+	client, err := cmdutil.GetAPIClient()
+	if err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+
+	user, err := client.CurrentUser(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get current user: %w", err)
+	}
+
+	if opts.unsubscribe {
+		if _, err := client.UnwatchPullRequest(ctx, workspace, repoSlug, int64(prNum), user.UUID); err != nil {
+			return fmt.Errorf("failed to unsubscribe: %w", err)
+		}
+		opts.streams.Success("Unsubscribed from pull request #%d", prNum)
+		return nil
+	}
+
+	if _, err := client.WatchPullRequest(ctx, workspace, repoSlug, int64(prNum), user.UUID); err != nil {
+		return fmt.Errorf("failed to subscribe: %w", err)
+	}
+	opts.streams.Success("Subscribed to pull request #%d (added as reviewer)", prNum)
+	return nil
+}
@@ -11,6 +11,7 @@ import (
 	"time"
 
 	"github.com/spf13/cobra"
+	"golang.org/x/sync/errgroup"
 
 	"github.com/rbansal42/bitbucket-cli/internal/api"
 	"github.com/rbansal42/bitbucket-cli/internal/browser"
@@ -25,6 +26,7 @@ type viewOptions struct {
 	repo      string
 	web       bool
 	jsonOut   bool
+	activity  bool
 	workspace string
 	repoSlug  string
 }
@@ -42,7 +44,10 @@ func NewCmdView(streams *iostreams.IOStreams) *cobra.Command {
 
 With no arguments, the pull request for the current branch is displayed.
 
-You can specify a pull request by number, URL, or branch name.`,
+You can specify a pull request by number, URL, or branch name.
+
+Use --activity to add a timeline of what happened on the pull request:
+when it was opened, updated, approved, commented on, merged or declined.`,
 		Example: `  # View the PR for the current branch
   bb pr view
 
@@ -55,6 +60,9 @@ You can specify a pull request by number, URL, or branch name.`,
   # View PR by branch
   bb pr view feature/my-branch
 
+  # Show the activity timeline for a PR
+  bb pr view 123 --activity
+
   # Open PR in browser
   bb pr view --web
 
@@ -80,6 +88,7 @@ You can specify a pull request by number, URL, or branch name.`,
 
 	cmd.Flags().BoolVarP(&opts.web, "web", "w", false, "Open the pull request in a web browser")
 	cmd.Flags().BoolVar(&opts.jsonOut, "json", false, "Output in JSON format")
+	cmd.Flags().BoolVar(&opts.activity, "activity", false, "Show a timeline of approvals, comments and updates")
 	cmd.Flags().StringVarP(&opts.repo, "repo", "R", "", "Select a repository using the WORKSPACE/REPO format")
 
 	cmd.ValidArgsFunction = cmdutil.CompletePRNumbers
@@ -95,6 +104,7 @@ func runView(opts *viewOptions) error {
 	if err != nil {
 		return err
 	}
+	opts.streams.Verbose("Using repository %s/%s", opts.workspace, opts.repoSlug)
 
 	// Get authenticated client
 	client, err := cmdutil.GetAPIClient()
@@ -111,11 +121,15 @@ func runView(opts *viewOptions) error {
 		return err
 	}
 
-	// Fetch PR details
-	pr, err := client.GetPullRequest(ctx, opts.workspace, opts.repoSlug, int64(prNumber))
+	// The extras are only shown in the formatted view, so skip them for
+	// --web and --json
+	extras := !opts.web && !opts.jsonOut
+	opts.streams.Verbose("Fetching pull request #%d...", prNumber)
+	data, err := fetchViewData(ctx, client, opts.workspace, opts.repoSlug, int64(prNumber), extras, opts.activity && !opts.web)
 	if err != nil {
 		return err
 	}
+	pr := data.pr
 
 	// Handle --web flag
 	if opts.web {
@@ -126,13 +140,134 @@ func runView(opts *viewOptions) error {
 		return nil
 	}
 
-	// Handle --json flag
 	if opts.jsonOut {
-		return outputJSON(opts.streams, pr)
+		if !opts.activity {
+			return outputJSON(opts.streams, pr)
+		}
+		return cmdutil.PrintJSON(opts.streams, map[string]interface{}{
+			"pull_request": pr,
+			"activity":     data.activity,
+		})
+	}
+
+	for _, warning := range data.warnings() {
+		opts.streams.Warning("%s", warning)
+	}
+
+	if err := displayPR(opts.streams, data); err != nil {
+		return err
+	}
+	if !opts.activity {
+		return nil
+	}
+
+	fmt.Fprintln(opts.streams.Out)
+	fmt.Fprintln(opts.streams.Out, "Activity:")
+	if data.truncated {
+		fmt.Fprintln(opts.streams.Out, "  (older activity not shown)")
+	}
+	if len(data.activity) == 0 {
+		fmt.Fprintln(opts.streams.Out, "  (No activity)")
+		return nil
+	}
+	fmt.Fprint(opts.streams.Out, formatActivity(opts.streams, data.activity, !data.truncated))
+	return nil
+}
+
+// viewData is everything pr view can show. The status checks and diffstat
+// are optional: when fetching them fails the view is still shown, with a
+// warning, and the failure is kept in statusErr or diffStatErr.
+type viewData struct {
+	pr *api.PullRequest
+
+	statuses  []api.CommitStatus
+	statusErr error
+
+	diffStat    []api.DiffStatEntry
+	diffStatErr error
+
+	mergeChecksErr error
+
+	activity  []api.Activity
+	truncated bool
+}
+
+// warnings describes the optional data that could not be fetched
+func (d *viewData) warnings() []string {
+	var warnings []string
+	if d.statusErr != nil {
+		warnings = append(warnings, fmt.Sprintf("Could not fetch status checks: %v", d.statusErr))
+	}
+	if d.diffStatErr != nil {
+		warnings = append(warnings, fmt.Sprintf("Could not fetch diffstat: %v", d.diffStatErr))
+	}
+	if d.mergeChecksErr != nil {
+		warnings = append(warnings, fmt.Sprintf("Could not fetch merge checks: %v", d.mergeChecksErr))
+	}
+	return warnings
+}
+
+// fetchViewData fetches the pull request together with, when extras is set,
+// its status checks and diffstat, and when activity is set, its activity
+// timeline. The requests run concurrently. A failure to fetch the pull
+// request or its activity cancels the other requests and is returned; the
+// extras only record their errors. The merge checks of an open pull request
+// are worked out from the extras afterwards.
+func fetchViewData(ctx context.Context, client *api.Client, workspace, repoSlug string, prID int64, extras, activity bool) (*viewData, error) {
+	data := &viewData{}
+	g, gctx := errgroup.WithContext(ctx)
+
+	g.Go(func() error {
+		pr, err := client.GetPullRequest(gctx, workspace, repoSlug, prID)
+		if err != nil {
+			return err
+		}
+		data.pr = pr
+		return nil
+	})
+
+	if extras {
+		g.Go(func() error {
+			result, err := client.GetPullRequestStatuses(gctx, workspace, repoSlug, prID)
+			if err != nil {
+				data.statusErr = err
+				return nil
+			}
+			data.statuses = result.Values
+			return nil
+		})
+
+		g.Go(func() error {
+			data.diffStat, data.diffStatErr = client.GetPullRequestDiffStat(gctx, workspace, repoSlug, prID)
+			return nil
+		})
+	}
+
+	if activity {
+		g.Go(func() error {
+			entries, truncated, err := fetchActivity(gctx, client, workspace, repoSlug, prID)
+			if err != nil {
+				return fmt.Errorf("failed to get pull request activity: %w", err)
+			}
+			data.activity, data.truncated = entries, truncated
+			return nil
+		})
+	}
+
+	if err := g.Wait(); err != nil {
+		return nil, err
 	}
 
-	// Display formatted output
-	return displayPR(opts.streams, pr)
+	if extras && data.pr.State == api.PRStateOpen && data.statusErr == nil && data.diffStatErr == nil {
+		restrictions, err := listMergeRestrictions(ctx, client, workspace, repoSlug)
+		if err != nil {
+			data.mergeChecksErr = err
+		} else {
+			statuses := statusesForCommit(data.statuses, data.pr.Source.Commit.Hash)
+			data.pr.MergeChecks = api.EvaluateMergeChecks(data.pr, restrictions, data.diffStat, statuses)
+		}
+	}
+	return data, nil
 }
 
 func resolvePRNumber(ctx context.Context, opts *viewOptions) (int, error) {
@@ -178,8 +313,11 @@ func findPRForBranch(ctx context.Context, workspace, repoSlug, branch string) (i
 	}
 
 	// Use Bitbucket's query parameter to filter by source branch
+	var q api.Query
+	q.Equals("source.branch.name", branch)
+	q.Equals("state", "OPEN")
 	query := url.Values{}
-	query.Set("q", fmt.Sprintf(`source.branch.name="%s" AND state="OPEN"`, branch))
+	query.Set("q", q.String())
 	query.Set("pagelen", "1")
 
 	path := fmt.Sprintf("/repositories/%s/%s/pullrequests", workspace, repoSlug)
@@ -207,10 +345,12 @@ func outputJSON(streams *iostreams.IOStreams, pr *api.PullRequest) error {
 	return cmdutil.PrintJSON(streams, pr)
 }
 
-func displayPR(streams *iostreams.IOStreams, pr *api.PullRequest) error {
+func displayPR(streams *iostreams.IOStreams, data *viewData) error {
+	pr := data.pr
+
 	// Title and state
 	fmt.Fprintf(streams.Out, "Title: %s\n", pr.Title)
-	fmt.Fprintf(streams.Out, "State: %s\n", strings.ToUpper(string(pr.State)))
+	fmt.Fprintf(streams.Out, "State: %s\n", cmdutil.ColorState(streams, strings.ToUpper(string(pr.State))))
 
 	// Author
 	authorName := cmdutil.GetUserDisplayName(&pr.Author)
@@ -248,6 +388,30 @@ func displayPR(streams *iostreams.IOStreams, pr *api.PullRequest) error {
 		pr.Destination.Branch.Name,
 		pr.Source.Branch.Name)
 
+	// Changes and checks, when they could be fetched
+	if data.diffStatErr == nil && data.diffStat != nil {
+		fmt.Fprintf(streams.Out, "Changes: %s\n", summarizeDiffStat(data.diffStat))
+	}
+	if data.statusErr == nil && len(data.statuses) > 0 {
+		fmt.Fprintf(streams.Out, "Checks: %s\n", summarizeChecks(data.statuses))
+	}
+
+	// Merge checks, listing the unmet ones
+	if len(pr.MergeChecks) > 0 {
+		blocking, advisory := unmetMergeChecks(pr.MergeChecks)
+		if len(blocking)+len(advisory) == 0 {
+			fmt.Fprintln(streams.Out, "Merge checks: all passed")
+		} else {
+			fmt.Fprintf(streams.Out, "Merge checks: %d of %d not met\n", len(blocking)+len(advisory), len(pr.MergeChecks))
+			for _, c := range blocking {
+				fmt.Fprintf(streams.Out, "  %s: blocks merging\n", describeMergeCheck(c))
+			}
+			for _, c := range advisory {
+				fmt.Fprintf(streams.Out, "  %s\n", describeMergeCheck(c))
+			}
+		}
+	}
+
 	// Comments
 	fmt.Fprintf(streams.Out, "Comments: %d\n", pr.CommentCount)
 
@@ -256,3 +420,46 @@ func displayPR(streams *iostreams.IOStreams, pr *api.PullRequest) error {
 
 	return nil
 }
+
+// summarizeDiffStat describes the size of a change, e.g. "+12 -3 in 2 files"
+func summarizeDiffStat(entries []api.DiffStatEntry) string {
+	var added, removed int
+	for _, e := range entries {
+		added += e.LinesAdded
+		removed += e.LinesRemoved
+	}
+
+	files := fmt.Sprintf("%d files", len(entries))
+	if len(entries) == 1 {
+		files = "1 file"
+	}
+	return fmt.Sprintf("+%d -%d in %s", added, removed, files)
+}
+
+// summarizeChecks counts status checks by outcome, e.g. "2 passing, 1 failing"
+func summarizeChecks(statuses []api.CommitStatus) string {
+	var passing, failing, pending, stopped int
+	for _, s := range statuses {
+		switch s.State {
+		case "SUCCESSFUL":
+			passing++
+		case "FAILED":
+			failing++
+		case "STOPPED":
+			stopped++
+		default:
+			pending++
+		}
+	}
+
+	var parts []string
+	for _, c := range []struct {
+		n     int
+		label string
+	}{{passing, "passing"}, {failing, "failing"}, {pending, "pending"}, {stopped, "stopped"}} {
+		if c.n > 0 {
+			parts = append(parts, fmt.Sprintf("%d %s", c.n, c.label))
+		}
+	}
+	return strings.Join(parts, ", ")
+}
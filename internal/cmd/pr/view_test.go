@@ -0,0 +1,159 @@
+package pr
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/rbansal42/bitbucket-cli/internal/api"
+	"github.com/rbansal42/bitbucket-cli/internal/iostreams"
+)
+
+const viewPRPath = "/repositories/ws/repo/pullrequests/7"
+
+func newViewTestClient(t *testing.T, handler http.HandlerFunc) *api.Client {
+	t.Helper()
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+	return api.NewClient(api.WithBaseURL(server.URL), api.WithToken("test-token"))
+}
+
+func TestFetchViewData(t *testing.T) {
+	client := newViewTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case viewPRPath:
+			fmt.Fprint(w, `{"id": 7, "title": "Add feature"}`)
+		case viewPRPath + "/statuses":
+			fmt.Fprint(w, `{"values": [{"state": "SUCCESSFUL"}, {"state": "FAILED"}]}`)
+		case viewPRPath + "/diffstat":
+			if r.URL.Query().Get("page") == "2" {
+				fmt.Fprint(w, `{"values": [{"lines_added": 1}]}`)
+				return
+			}
+			fmt.Fprintf(w, `{"values": [{"lines_added": 10, "lines_removed": 2}], "next": "http://%s%s?page=2"}`, r.Host, r.URL.Path)
+		default:
+			http.NotFound(w, r)
+		}
+	})
+
+	data, err := fetchViewData(context.Background(), client, "ws", "repo", 7, true, false)
+	if err != nil {
+		t.Fatalf("fetchViewData() error: %v", err)
+	}
+	if data.pr.Title != "Add feature" {
+		t.Errorf("pr title = %q", data.pr.Title)
+	}
+	if len(data.statuses) != 2 || len(data.diffStat) != 2 {
+		t.Errorf("extras = %d statuses, %d diffstat entries, want 2 of each", len(data.statuses), len(data.diffStat))
+	}
+	if w := data.warnings(); len(w) != 0 {
+		t.Errorf("unexpected warnings: %v", w)
+	}
+}
+
+func TestFetchViewData_MergeChecks(t *testing.T) {
+	client := newViewTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case viewPRPath:
+			fmt.Fprint(w, `{"id": 7, "state": "OPEN", "destination": {"branch": {"name": "main"}}, "source": {"commit": {"hash": "aaa"}}}`)
+		case viewPRPath + "/statuses":
+			fmt.Fprint(w, `{"values": [{"state": "FAILED", "links": {"commit": {"href": "https://x/commit/aaa"}}}]}`)
+		case viewPRPath + "/diffstat":
+			fmt.Fprint(w, `{"values": [{"status": "merge conflict", "new": {"path": "go.mod"}}]}`)
+		case "/repositories/ws/repo/branch-restrictions":
+			fmt.Fprint(w, `{"values": [{"kind": "require_passing_builds_to_merge", "branch_match_kind": "glob", "pattern": "main", "value": 1}]}`)
+		default:
+			http.NotFound(w, r)
+		}
+	})
+
+	data, err := fetchViewData(context.Background(), client, "ws", "repo", 7, true, false)
+	if err != nil {
+		t.Fatalf("fetchViewData() error: %v", err)
+	}
+
+	out := &bytes.Buffer{}
+	if err := displayPR(&iostreams.IOStreams{Out: out, ErrOut: out}, data); err != nil {
+		t.Fatalf("displayPR() error: %v", err)
+	}
+	want := "Merge checks: 2 of 2 not met\n  No merge conflicts (conflicts in go.mod): blocks merging\n  Passing builds (1 failed)\n"
+	if !strings.Contains(out.String(), want) {
+		t.Errorf("displayPR() output:\n%s\nwant it to contain:\n%s", out.String(), want)
+	}
+}
+
+func TestFetchViewData_OptionalFailuresDegrade(t *testing.T) {
+	client := newViewTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case viewPRPath:
+			fmt.Fprint(w, `{"id": 7, "title": "Add feature"}`)
+		default:
+			w.WriteHeader(http.StatusInternalServerError)
+			fmt.Fprint(w, `{"type": "error", "error": {"message": "boom"}}`)
+		}
+	})
+
+	data, err := fetchViewData(context.Background(), client, "ws", "repo", 7, true, false)
+	if err != nil {
+		t.Fatalf("fetchViewData() error: %v", err)
+	}
+	if data.statusErr == nil || data.diffStatErr == nil {
+		t.Errorf("expected both extras to record errors, got %v and %v", data.statusErr, data.diffStatErr)
+	}
+	if w := data.warnings(); len(w) != 2 {
+		t.Errorf("warnings = %v, want 2", w)
+	}
+}
+
+func TestFetchViewData_PrimaryFailureCancelsOthers(t *testing.T) {
+	client := newViewTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == viewPRPath {
+			w.WriteHeader(http.StatusNotFound)
+			fmt.Fprint(w, `{"type": "error", "error": {"message": "not found"}}`)
+			return
+		}
+		// The extras hang until their request is cancelled
+		select {
+		case <-r.Context().Done():
+		case <-time.After(5 * time.Second):
+			t.Errorf("request for %s was not cancelled", r.URL.Path)
+		}
+	})
+
+	start := time.Now()
+	_, err := fetchViewData(context.Background(), client, "ws", "repo", 7, true, true)
+	var apiErr *api.APIError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusNotFound {
+		t.Fatalf("fetchViewData() error = %v, want the 404 from the pull request fetch", err)
+	}
+	if elapsed := time.Since(start); elapsed > 2*time.Second {
+		t.Errorf("fetchViewData() took %v; the other requests were not cancelled", elapsed)
+	}
+}
+
+func TestSummarizeDiffStat(t *testing.T) {
+	entries := []api.DiffStatEntry{{LinesAdded: 10, LinesRemoved: 2}, {LinesAdded: 1}}
+	if got := summarizeDiffStat(entries); got != "+11 -2 in 2 files" {
+		t.Errorf("summarizeDiffStat() = %q", got)
+	}
+	if got := summarizeDiffStat(entries[:1]); got != "+10 -2 in 1 file" {
+		t.Errorf("summarizeDiffStat(one file) = %q", got)
+	}
+}
+
+func TestSummarizeChecks(t *testing.T) {
+	statuses := []api.CommitStatus{{State: "SUCCESSFUL"}, {State: "SUCCESSFUL"}, {State: "FAILED"}, {State: "INPROGRESS"}}
+	got := summarizeChecks(statuses)
+	if want := "2 passing, 1 failing, 1 pending"; got != want {
+		t.Errorf("summarizeChecks() = %q, want %q", got, want)
+	}
+	if strings.Contains(got, "stopped") {
+		t.Error("summarizeChecks() should omit empty outcomes")
+	}
+}
@@ -0,0 +1,77 @@
+package project
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/rbansal42/bitbucket-cli/internal/cmdutil"
+	"github.com/rbansal42/bitbucket-cli/internal/iostreams"
+)
+
+type avatarOptions struct {
+	streams   *iostreams.IOStreams
+	workspace string
+	key       string
+	out       string
+}
+
+// NewCmdAvatar creates the project avatar command
+func NewCmdAvatar(streams *iostreams.IOStreams) *cobra.Command {
+	opts := &avatarOptions{
+		streams: streams,
+	}
+
+	cmd := &cobra.Command{
+		Use:   "avatar <project-key>",
+		Short: "Download a project's avatar",
+		Long: `Download the avatar image of a Bitbucket project.
+
+The image is saved to the --out file, or written to stdout when it is
+redirected.`,
+		Example: `  # Save a project's avatar
+  bb project avatar PROJ -w myworkspace --out proj.png`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts.key = args[0]
+
+			ws, err := cmdutil.ResolveWorkspace(cmd)
+			if err != nil {
+				return err
+			}
+			opts.workspace = ws
+			return runAvatar(cmd.Context(), opts)
+		},
+	}
+
+	cmd.Flags().StringVarP(&opts.workspace, "workspace", "w", "", "Workspace slug (defaults to the default workspace or the git remote's)")
+	cmd.Flags().StringVarP(&opts.out, "out", "o", "", "File to write the image to (\"-\" for stdout)")
+
+	_ = cmd.RegisterFlagCompletionFunc("workspace", cmdutil.CompleteWorkspaceNames)
+
+	return cmd
+}
+
+func runAvatar(ctx context.Context, opts *avatarOptions) error {
+	client, err := cmdutil.GetAPIClient()
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	project, err := client.GetProject(ctx, opts.workspace, opts.key)
+	if err != nil {
+		return fmt.Errorf("failed to get project: %w", err)
+	}
+
+	data, contentType, err := client.DownloadAvatar(ctx, project.Links.Avatar.Href)
+	if err != nil {
+		return fmt.Errorf("failed to download avatar: %w", err)
+	}
+
+	return cmdutil.WriteAvatar(opts.streams, data, contentType, opts.out)
+}
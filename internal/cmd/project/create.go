@@ -10,7 +10,6 @@ import (
 
 	"github.com/rbansal42/bitbucket-cli/internal/api"
 	"github.com/rbansal42/bitbucket-cli/internal/cmdutil"
-	"github.com/rbansal42/bitbucket-cli/internal/config"
 	"github.com/rbansal42/bitbucket-cli/internal/iostreams"
 )
 
@@ -48,15 +47,11 @@ identifier (e.g., "PROJ", "DEV", "CORE").`,
   # Create a project and output as JSON
   bb project create -w myworkspace -k CORE -n "Core" --json`,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			if opts.workspace == "" {
-				defaultWs, err := config.GetDefaultWorkspace()
-				if err == nil && defaultWs != "" {
-					opts.workspace = defaultWs
-				}
-			}
-			if opts.workspace == "" {
-				return fmt.Errorf("workspace is required. Use --workspace or -w to specify, or set a default with 'bb workspace set-default'")
+			ws, err := cmdutil.ResolveWorkspace(cmd)
+			if err != nil {
+				return err
 			}
+			opts.workspace = ws
 			if opts.key == "" {
 				return fmt.Errorf("project key is required. Use --key or -k to specify")
 			}
@@ -68,7 +63,7 @@ identifier (e.g., "PROJ", "DEV", "CORE").`,
 		},
 	}
 
-	cmd.Flags().StringVarP(&opts.workspace, "workspace", "w", "", "Workspace slug (required)")
+	cmd.Flags().StringVarP(&opts.workspace, "workspace", "w", "", "Workspace slug (defaults to the default workspace or the git remote's)")
 	cmd.Flags().StringVarP(&opts.key, "key", "k", "", "Project key (required)")
 	cmd.Flags().StringVarP(&opts.name, "name", "n", "", "Project name (required)")
 	cmd.Flags().StringVarP(&opts.description, "description", "d", "", "Project description")
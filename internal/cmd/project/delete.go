@@ -0,0 +1,99 @@
+package project
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/rbansal42/bitbucket-cli/internal/cmdutil"
+	"github.com/rbansal42/bitbucket-cli/internal/iostreams"
+)
+
+type deleteOptions struct {
+	streams   *iostreams.IOStreams
+	workspace string
+	key       string
+	yes       bool
+}
+
+// NewCmdDelete creates the project delete command
+func NewCmdDelete(streams *iostreams.IOStreams) *cobra.Command {
+	opts := &deleteOptions{
+		streams: streams,
+	}
+
+	cmd := &cobra.Command{
+		Use:   "delete <project-key>",
+		Short: "Delete a project",
+		Long: `Delete a Bitbucket project permanently.
+
+Bitbucket only deletes empty projects, so move or delete its repositories
+first. You will be asked to type the project key to confirm, unless the
+--yes flag is provided.`,
+		Example: `  # Delete a project (will prompt for confirmation)
+  bb project delete PROJ -w myworkspace
+
+  # Delete without confirmation
+  bb project delete PROJ -w myworkspace --yes`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts.key = args[0]
+
+			ws, err := cmdutil.ResolveWorkspace(cmd)
+			if err != nil {
+				return err
+			}
+			opts.workspace = ws
+
+			return runDelete(cmd.Context(), opts)
+		},
+	}
+
+	cmd.Flags().StringVarP(&opts.workspace, "workspace", "w", "", "Workspace slug (defaults to the default workspace or the git remote's)")
+	cmd.Flags().BoolVarP(&opts.yes, "yes", "y", false, "Skip confirmation prompt")
+
+	_ = cmd.RegisterFlagCompletionFunc("workspace", cmdutil.CompleteWorkspaceNames)
+
+	return cmd
+}
+
+func runDelete(ctx context.Context, opts *deleteOptions) error {
+	if !opts.yes && !opts.streams.CanPrompt() {
+		return &cmdutil.NoPromptError{Hint: "pass --yes to skip confirmation"}
+	}
+
+	// Get authenticated client
+	client, err := cmdutil.GetAPIClient()
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	// Look the project up first so a wrong key fails before the prompt
+	project, err := client.GetProject(ctx, opts.workspace, opts.key)
+	if err != nil {
+		return fmt.Errorf("failed to get project: %w", err)
+	}
+
+	if !opts.yes {
+		fmt.Fprintf(opts.streams.ErrOut, "! Deleting project %s (%s) from %s cannot be undone.\n", project.Name, project.Key, opts.workspace)
+		confirmed, err := cmdutil.ConfirmByTyping(opts.streams, project.Key)
+		if err != nil {
+			return err
+		}
+		if !confirmed {
+			return fmt.Errorf("deletion cancelled: project key did not match")
+		}
+	}
+
+	if err := client.DeleteProject(ctx, opts.workspace, project.Key); err != nil {
+		return fmt.Errorf("failed to delete project: %w", err)
+	}
+
+	opts.streams.Success("Deleted project %s from workspace %s", project.Key, opts.workspace)
+	return nil
+}
@@ -0,0 +1,109 @@
+package project
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/rbansal42/bitbucket-cli/internal/api"
+	"github.com/rbansal42/bitbucket-cli/internal/cmdutil"
+	"github.com/rbansal42/bitbucket-cli/internal/iostreams"
+)
+
+type editOptions struct {
+	streams     *iostreams.IOStreams
+	workspace   string
+	key         string
+	name        string
+	description string
+	private     bool
+	jsonOut     bool
+}
+
+// NewCmdEdit creates the project edit command
+func NewCmdEdit(streams *iostreams.IOStreams) *cobra.Command {
+	opts := &editOptions{
+		streams: streams,
+	}
+
+	cmd := &cobra.Command{
+		Use:   "edit <project-key>",
+		Short: "Edit a project",
+		Long: `Edit the name, description, or visibility of a Bitbucket project.
+
+Only the flags you pass are changed; all other project settings are left
+as they are.`,
+		Example: `  # Update the description
+  bb project edit PROJ -w myworkspace --description "Core services"
+
+  # Rename a project
+  bb project edit PROJ -w myworkspace --name "Platform"
+
+  # Make a project public
+  bb project edit PROJ -w myworkspace --private=false`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts.key = args[0]
+
+			ws, err := cmdutil.ResolveWorkspace(cmd)
+			if err != nil {
+				return err
+			}
+			opts.workspace = ws
+
+			updateOpts := &api.ProjectUpdateOptions{}
+			if cmd.Flags().Changed("name") {
+				if opts.name == "" {
+					return fmt.Errorf("project name cannot be empty")
+				}
+				updateOpts.Name = &opts.name
+			}
+			if cmd.Flags().Changed("description") {
+				updateOpts.Description = &opts.description
+			}
+			if cmd.Flags().Changed("private") {
+				updateOpts.IsPrivate = &opts.private
+			}
+			if updateOpts.Name == nil && updateOpts.Description == nil && updateOpts.IsPrivate == nil {
+				return fmt.Errorf("nothing to update. Use --name, --description, or --private")
+			}
+
+			return runEdit(cmd.Context(), opts, updateOpts)
+		},
+	}
+
+	cmd.Flags().StringVarP(&opts.workspace, "workspace", "w", "", "Workspace slug (defaults to the default workspace or the git remote's)")
+	cmd.Flags().StringVarP(&opts.name, "name", "n", "", "New project name")
+	cmd.Flags().StringVarP(&opts.description, "description", "d", "", "New project description")
+	cmd.Flags().BoolVarP(&opts.private, "private", "p", false, "Set project visibility (--private=false to make public)")
+	cmd.Flags().BoolVar(&opts.jsonOut, "json", false, "Output in JSON format")
+
+	_ = cmd.RegisterFlagCompletionFunc("workspace", cmdutil.CompleteWorkspaceNames)
+
+	return cmd
+}
+
+func runEdit(ctx context.Context, opts *editOptions, updateOpts *api.ProjectUpdateOptions) error {
+	// Get authenticated client
+	client, err := cmdutil.GetAPIClient()
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	project, err := client.EditProject(ctx, opts.workspace, opts.key, updateOpts)
+	if err != nil {
+		return fmt.Errorf("failed to update project: %w", err)
+	}
+
+	if opts.jsonOut {
+		return cmdutil.PrintJSON(opts.streams, project)
+	}
+
+	opts.streams.Success("Updated project %s in workspace %s", opts.key, opts.workspace)
+	return nil
+}
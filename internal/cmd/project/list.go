@@ -3,14 +3,12 @@ package project
 import (
 	"context"
 	"fmt"
-	"text/tabwriter"
 	"time"
 
 	"github.com/spf13/cobra"
 
 	"github.com/rbansal42/bitbucket-cli/internal/api"
 	"github.com/rbansal42/bitbucket-cli/internal/cmdutil"
-	"github.com/rbansal42/bitbucket-cli/internal/config"
 	"github.com/rbansal42/bitbucket-cli/internal/iostreams"
 )
 
@@ -19,6 +17,8 @@ type listOptions struct {
 	Workspace string
 	Limit     int
 	JSON      bool
+	ShowCount bool
+	ExitCode  bool
 	Streams   *iostreams.IOStreams
 }
 
@@ -33,7 +33,9 @@ func NewCmdList(streams *iostreams.IOStreams) *cobra.Command {
 		Short: "List projects in a workspace",
 		Long: `List projects in a Bitbucket workspace.
 
-This command shows projects you have access to in the specified workspace.`,
+This command shows projects you have access to in the specified workspace.
+The workspace comes from --workspace, then the default workspace, then the
+git remote of the current repository.`,
 		Example: `  # List projects in a workspace
   bb project list --workspace myworkspace
 
@@ -44,22 +46,21 @@ This command shows projects you have access to in the specified workspace.`,
   bb project list -w myworkspace --json`,
 		Aliases: []string{"ls"},
 		RunE: func(cmd *cobra.Command, args []string) error {
-			if opts.Workspace == "" {
-				defaultWs, err := config.GetDefaultWorkspace()
-				if err == nil && defaultWs != "" {
-					opts.Workspace = defaultWs
-				}
-			}
-			if opts.Workspace == "" {
-				return fmt.Errorf("workspace is required. Use --workspace or -w to specify, or set a default with 'bb workspace set-default'")
+			ws, err := cmdutil.ResolveWorkspace(cmd)
+			if err != nil {
+				return err
 			}
+			opts.Workspace = ws
 			return runList(cmd.Context(), opts)
 		},
 	}
 
-	cmd.Flags().StringVarP(&opts.Workspace, "workspace", "w", "", "Workspace slug (required)")
+	cmd.Flags().StringVarP(&opts.Workspace, "workspace", "w", "", "Workspace slug (defaults to the default workspace or the git remote's)")
 	cmd.Flags().IntVarP(&opts.Limit, "limit", "l", 30, "Maximum number of projects to list")
 	cmd.Flags().BoolVar(&opts.JSON, "json", false, "Output in JSON format")
+	cmdutil.AddShowCountFlag(cmd, &opts.ShowCount)
+	cmdutil.AddAllFlag(cmd, &opts.Limit)
+	cmdutil.AddExitCodeFlag(cmd, &opts.ExitCode)
 
 	_ = cmd.RegisterFlagCompletionFunc("workspace", cmdutil.CompleteWorkspaceNames)
 
@@ -79,29 +80,41 @@ func runList(ctx context.Context, opts *listOptions) error {
 
 	// Build list options
 	listOpts := &api.ProjectListOptions{
-		Limit: opts.Limit,
+		Limit: cmdutil.PageLen(opts.Limit, cmdutil.MaxPageLen),
 	}
 
 	// Fetch projects
 	result, err := client.ListProjects(ctx, opts.Workspace, listOpts)
+	if err == nil {
+		result, err = cmdutil.CollectPages(ctx, client, result, opts.Limit)
+	}
 	if err != nil {
 		return fmt.Errorf("failed to list projects: %w", err)
 	}
 
 	if len(result.Values) == 0 {
 		opts.Streams.Info("No projects found in workspace %s", opts.Workspace)
-		return nil
+		return cmdutil.NoResults(opts.ExitCode)
+	}
+
+	var count *cmdutil.PageCount
+	if opts.ShowCount {
+		count = cmdutil.NewPageCount(result, len(result.Values))
 	}
 
 	// Output results
 	if opts.JSON {
-		return outputListJSON(opts.Streams, result.Values)
+		return outputListJSON(opts.Streams, result.Values, count)
 	}
 
-	return outputListTable(opts.Streams, result.Values)
+	if err := outputListTable(opts.Streams, result.Values); err != nil {
+		return err
+	}
+	cmdutil.PrintPageCount(opts.Streams, count)
+	return nil
 }
 
-func outputListJSON(streams *iostreams.IOStreams, projects []api.ProjectFull) error {
+func outputListJSON(streams *iostreams.IOStreams, projects []api.ProjectFull, count *cmdutil.PageCount) error {
 	// Create simplified JSON output
 	output := make([]map[string]interface{}, len(projects))
 	for i, proj := range projects {
@@ -117,27 +130,20 @@ func outputListJSON(streams *iostreams.IOStreams, projects []api.ProjectFull) er
 		}
 	}
 
-	return cmdutil.PrintJSON(streams, output)
+	return cmdutil.PrintListJSON(streams, output, count)
 }
 
 func outputListTable(streams *iostreams.IOStreams, projects []api.ProjectFull) error {
-	w := tabwriter.NewWriter(streams.Out, 0, 0, 2, ' ', 0)
+	t := cmdutil.NewTableWriter(streams, "KEY", "NAME", "DESCRIPTION", "VISIBILITY")
+	t.SetFlexColumn(2)
+	t.SetMaxWidth(1, 30)
 
-	// Print header
-	header := "KEY\tNAME\tDESCRIPTION\tVISIBILITY"
-	cmdutil.PrintTableHeader(streams, w, header)
-
-	// Print rows
 	for _, proj := range projects {
-		key := proj.Key
-		name := cmdutil.TruncateString(proj.Name, 30)
-		desc := cmdutil.TruncateString(proj.Description, 40)
 		visibility := formatVisibility(streams, proj.IsPrivate)
-
-		fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", key, name, desc, visibility)
+		t.AddRow(proj.Key, proj.Name, proj.Description, visibility)
 	}
 
-	return w.Flush()
+	return t.Render()
 }
 
 func formatVisibility(streams *iostreams.IOStreams, isPrivate bool) string {
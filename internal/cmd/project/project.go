@@ -30,6 +30,10 @@ the project level.`,
 	cmd.AddCommand(NewCmdList(streams))
 	cmd.AddCommand(NewCmdView(streams))
 	cmd.AddCommand(NewCmdCreate(streams))
+	cmd.AddCommand(NewCmdEdit(streams))
+	cmd.AddCommand(NewCmdDelete(streams))
+	cmd.AddCommand(NewCmdRepos(streams))
+	cmd.AddCommand(NewCmdAvatar(streams))
 
 	return cmd
 }
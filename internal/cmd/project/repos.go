@@ -0,0 +1,116 @@
+package project
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/rbansal42/bitbucket-cli/internal/api"
+	"github.com/rbansal42/bitbucket-cli/internal/cmdutil"
+	"github.com/rbansal42/bitbucket-cli/internal/iostreams"
+)
+
+// reposOptions holds the options for the repos command
+type reposOptions struct {
+	Workspace string
+	Key       string
+	Limit     int
+	Sort      string
+	JSON      bool
+	ShowCount bool
+	ExitCode  bool
+	Streams   *iostreams.IOStreams
+}
+
+// NewCmdRepos creates the project repos command
+func NewCmdRepos(streams *iostreams.IOStreams) *cobra.Command {
+	opts := &reposOptions{
+		Streams: streams,
+	}
+
+	cmd := &cobra.Command{
+		Use:   "repos <project-key>",
+		Short: "List repositories in a project",
+		Long: `List the repositories that belong to a Bitbucket project.
+
+By default, repositories are sorted by last updated time.`,
+		Example: `  # List repositories in a project
+  bb project repos PROJ --workspace myworkspace
+
+  # Sort by name and limit the results
+  bb project repos PROJ -w myworkspace --sort name --limit 10
+
+  # Output as JSON
+  bb project repos PROJ -w myworkspace --json`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts.Key = args[0]
+
+			ws, err := cmdutil.ResolveWorkspace(cmd)
+			if err != nil {
+				return err
+			}
+			opts.Workspace = ws
+			return runRepos(cmd.Context(), opts)
+		},
+	}
+
+	cmd.Flags().StringVarP(&opts.Workspace, "workspace", "w", "", "Workspace slug (defaults to the default workspace or the git remote's)")
+	cmd.Flags().IntVarP(&opts.Limit, "limit", "l", 30, "Maximum number of repositories to list")
+	cmd.Flags().StringVarP(&opts.Sort, "sort", "s", "-updated_on", "Sort field (name, -updated_on)")
+	cmd.Flags().BoolVar(&opts.JSON, "json", false, "Output in JSON format")
+	cmdutil.AddShowCountFlag(cmd, &opts.ShowCount)
+	cmdutil.AddAllFlag(cmd, &opts.Limit)
+	cmdutil.AddExitCodeFlag(cmd, &opts.ExitCode)
+
+	_ = cmd.RegisterFlagCompletionFunc("workspace", cmdutil.CompleteWorkspaceNames)
+
+	return cmd
+}
+
+func runRepos(ctx context.Context, opts *reposOptions) error {
+	// Create timeout context
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	// Get API client
+	client, err := cmdutil.GetAPIClient()
+	if err != nil {
+		return err
+	}
+
+	listOpts := &api.RepositoryListOptions{
+		Sort:  opts.Sort,
+		Limit: cmdutil.PageLen(opts.Limit, cmdutil.MaxPageLen),
+	}
+
+	result, err := client.ListProjectRepositories(ctx, opts.Workspace, opts.Key, listOpts)
+	if err == nil {
+		result, err = cmdutil.CollectPages(ctx, client, result, opts.Limit)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to list project repositories: %w", err)
+	}
+
+	if len(result.Values) == 0 {
+		opts.Streams.Info("No repositories found in project %s", opts.Key)
+		return cmdutil.NoResults(opts.ExitCode)
+	}
+
+	var count *cmdutil.PageCount
+	if opts.ShowCount {
+		count = cmdutil.NewPageCount(result, len(result.Values))
+	}
+
+	if opts.JSON {
+		return cmdutil.PrintRepositoryListJSON(opts.Streams, result.Values, count)
+	}
+
+	if err := cmdutil.PrintRepositoryTable(opts.Streams, result.Values); err != nil {
+		return err
+	}
+	cmdutil.PrintPageCount(opts.Streams, count)
+	return nil
+}
@@ -11,7 +11,6 @@ import (
 	"github.com/rbansal42/bitbucket-cli/internal/api"
 	"github.com/rbansal42/bitbucket-cli/internal/browser"
 	"github.com/rbansal42/bitbucket-cli/internal/cmdutil"
-	"github.com/rbansal42/bitbucket-cli/internal/config"
 	"github.com/rbansal42/bitbucket-cli/internal/iostreams"
 )
 
@@ -48,21 +47,17 @@ short uppercase identifiers like "PROJ" or "DEV".`,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			opts.key = args[0]
 
-			if opts.workspace == "" {
-				defaultWs, err := config.GetDefaultWorkspace()
-				if err == nil && defaultWs != "" {
-					opts.workspace = defaultWs
-				}
-			}
-			if opts.workspace == "" {
-				return fmt.Errorf("workspace is required. Use --workspace or -w to specify, or set a default with 'bb workspace set-default'")
+			ws, err := cmdutil.ResolveWorkspace(cmd)
+			if err != nil {
+				return err
 			}
+			opts.workspace = ws
 
 			return runView(cmd.Context(), opts)
 		},
 	}
 
-	cmd.Flags().StringVarP(&opts.workspace, "workspace", "w", "", "Workspace slug (required)")
+	cmd.Flags().StringVarP(&opts.workspace, "workspace", "w", "", "Workspace slug (defaults to the default workspace or the git remote's)")
 	cmd.Flags().BoolVar(&opts.web, "web", false, "Open the project in a web browser")
 	cmd.Flags().BoolVar(&opts.jsonOut, "json", false, "Output in JSON format")
 
@@ -0,0 +1,395 @@
+package repo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/rbansal42/bitbucket-cli/internal/api"
+	"github.com/rbansal42/bitbucket-cli/internal/cmdutil"
+	"github.com/rbansal42/bitbucket-cli/internal/iostreams"
+)
+
+// accessPageLen is the page size used when fetching permission grants
+const accessPageLen = 100
+
+// maxAccessPages bounds how many pages of grants are fetched per principal
+// type
+const maxAccessPages = 20
+
+// NewCmdAccess creates the repo access command and its subcommands
+func NewCmdAccess(streams *iostreams.IOStreams) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "access <command>",
+		Short: "Manage who can access a repository",
+		Long: `Inspect and change the explicit user and group permissions on a repository.
+
+These are the grants made on the repository itself, on top of any access
+people get from their workspace or project membership. Managing them
+requires admin access to the repository.`,
+	}
+
+	cmd.AddCommand(NewCmdAccessList(streams))
+	cmd.AddCommand(NewCmdAccessGrant(streams))
+	cmd.AddCommand(NewCmdAccessRevoke(streams))
+
+	return cmd
+}
+
+type accessListOptions struct {
+	streams *iostreams.IOStreams
+	repo    string
+	jsonOut bool
+}
+
+// accessEntry is one user or group grant, as shown by repo access list
+type accessEntry struct {
+	Type       string `json:"type"` // user or group
+	Name       string `json:"name"`
+	ID         string `json:"id"` // user UUID or group slug
+	Permission string `json:"permission"`
+}
+
+// NewCmdAccessList creates the repo access list command
+func NewCmdAccessList(streams *iostreams.IOStreams) *cobra.Command {
+	opts := &accessListOptions{
+		streams: streams,
+	}
+
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "List users and groups with access to a repository",
+		Long: `List the users and groups that have been granted explicit permissions on a
+repository, highest permission first.
+
+Without --repo, the repository for the current directory is used.`,
+		Example: `  # Audit access to the current repository
+  bb repo access list
+
+  # Audit a specific repository as JSON
+  bb repo access list --repo myworkspace/myrepo --json`,
+		Aliases: []string{"ls"},
+		Args:    cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runAccessList(cmd.Context(), opts)
+		},
+	}
+
+	cmd.Flags().StringVarP(&opts.repo, "repo", "R", "", "Repository in WORKSPACE/REPO format")
+	cmd.Flags().BoolVar(&opts.jsonOut, "json", false, "Output in JSON format")
+
+	_ = cmd.RegisterFlagCompletionFunc("repo", cmdutil.CompleteRepoNames)
+
+	return cmd
+}
+
+func runAccessList(ctx context.Context, opts *accessListOptions) error {
+	workspace, repoSlug, err := cmdutil.ParseRepository(opts.repo)
+	if err != nil {
+		return err
+	}
+
+	client, err := cmdutil.GetAPIClient()
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 60*time.Second)
+	defer cancel()
+
+	listOpts := &api.RepoPermissionListOptions{Limit: accessPageLen}
+
+	var entries []accessEntry
+	users, err := client.ListRepoUserPermissions(ctx, workspace, repoSlug, listOpts)
+	for pages := 1; users != nil && err == nil; pages++ {
+		for _, p := range users.Values {
+			entries = append(entries, userAccessEntry(p))
+		}
+		if pages >= maxAccessPages {
+			break
+		}
+		users, err = api.NextPage(ctx, client, users)
+	}
+	if err != nil {
+		return accessError(err, "list repository permissions", workspace, repoSlug)
+	}
+
+	groups, err := client.ListRepoGroupPermissions(ctx, workspace, repoSlug, listOpts)
+	for pages := 1; groups != nil && err == nil; pages++ {
+		for _, p := range groups.Values {
+			entries = append(entries, groupAccessEntry(p))
+		}
+		if pages >= maxAccessPages {
+			break
+		}
+		groups, err = api.NextPage(ctx, client, groups)
+	}
+	if err != nil {
+		return accessError(err, "list repository permissions", workspace, repoSlug)
+	}
+
+	sortAccessEntries(entries)
+
+	if opts.jsonOut {
+		if entries == nil {
+			entries = []accessEntry{}
+		}
+		return cmdutil.PrintJSON(opts.streams, entries)
+	}
+
+	if len(entries) == 0 {
+		opts.streams.Info("No explicit user or group permissions on %s/%s", workspace, repoSlug)
+		return nil
+	}
+
+	t := cmdutil.NewTableWriter(opts.streams, "TYPE", "NAME", "ID", "PERMISSION")
+	t.SetFlexColumn(1)
+	for _, e := range entries {
+		t.AddRow(e.Type, e.Name, e.ID, e.Permission)
+	}
+	return t.Render()
+}
+
+// accessError explains a 403 from the permissions endpoints, which Bitbucket
+// returns when the caller is not a repository admin. action describes what
+// failed, e.g. "list repository permissions".
+func accessError(err error, action, workspace, repoSlug string) error {
+	var apiErr *api.APIError
+	if errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusForbidden {
+		return fmt.Errorf("you need admin access to %s/%s to %s: %w", workspace, repoSlug, action, err)
+	}
+	return fmt.Errorf("failed to %s: %w", action, err)
+}
+
+func userAccessEntry(p api.RepoUserPermission) accessEntry {
+	e := accessEntry{Type: "user", Name: "-", Permission: p.Permission}
+	if p.User != nil {
+		e.Name = cmdutil.GetUserDisplayName(p.User)
+		e.ID = p.User.UUID
+	}
+	return e
+}
+
+func groupAccessEntry(p api.RepoGroupPermission) accessEntry {
+	e := accessEntry{Type: "group", Name: "-", Permission: p.Permission}
+	if p.Group != nil {
+		e.Name = p.Group.Name
+		if e.Name == "" {
+			e.Name = p.Group.Slug
+		}
+		e.ID = p.Group.Slug
+	}
+	return e
+}
+
+// permissionRank orders permissions from most to least powerful
+var permissionRank = map[string]int{
+	api.PermissionAdmin: 0,
+	api.PermissionWrite: 1,
+	api.PermissionRead:  2,
+}
+
+// sortAccessEntries orders entries by permission, highest first, then
+// groups before users and by name
+func sortAccessEntries(entries []accessEntry) {
+	rank := func(p string) int {
+		if r, ok := permissionRank[p]; ok {
+			return r
+		}
+		return len(permissionRank)
+	}
+	sort.SliceStable(entries, func(i, j int) bool {
+		a, b := entries[i], entries[j]
+		if rank(a.Permission) != rank(b.Permission) {
+			return rank(a.Permission) < rank(b.Permission)
+		}
+		if a.Type != b.Type {
+			return a.Type == "group"
+		}
+		return strings.ToLower(a.Name) < strings.ToLower(b.Name)
+	})
+}
+
+type accessChangeOptions struct {
+	streams   *iostreams.IOStreams
+	repo      string
+	principal string
+	group     bool
+	level     string
+	yes       bool
+}
+
+// NewCmdAccessGrant creates the repo access grant command
+func NewCmdAccessGrant(streams *iostreams.IOStreams) *cobra.Command {
+	opts := &accessChangeOptions{
+		streams: streams,
+	}
+
+	cmd := &cobra.Command{
+		Use:   "grant <user>",
+		Short: "Grant a user or group a permission on a repository",
+		Long: `Grant a user or group an explicit read, write or admin permission on a
+repository. Any explicit permission they already have is replaced.
+
+Users can be given as a username, nickname, account ID or {UUID}. Use
+--group to grant a workspace group by its slug instead.`,
+		Example: `  # Give a user write access to the current repository
+  bb repo access grant alice --level write
+
+  # Give a group read access to a specific repository
+  bb repo access grant developers --group --level read --repo myworkspace/myrepo`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts.principal = args[0]
+			return runAccessGrant(cmd.Context(), opts)
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.level, "level", "", "Permission to grant: "+strings.Join(api.PermissionLevels, ", "))
+	cmd.Flags().BoolVar(&opts.group, "group", false, "Treat the argument as a workspace group slug")
+	cmd.Flags().StringVarP(&opts.repo, "repo", "R", "", "Repository in WORKSPACE/REPO format")
+	_ = cmd.MarkFlagRequired("level")
+
+	_ = cmd.RegisterFlagCompletionFunc("level", cmdutil.StaticFlagCompletion(api.PermissionLevels))
+	_ = cmd.RegisterFlagCompletionFunc("repo", cmdutil.CompleteRepoNames)
+
+	return cmd
+}
+
+// NewCmdAccessRevoke creates the repo access revoke command
+func NewCmdAccessRevoke(streams *iostreams.IOStreams) *cobra.Command {
+	opts := &accessChangeOptions{
+		streams: streams,
+	}
+
+	cmd := &cobra.Command{
+		Use:   "revoke <user>",
+		Short: "Remove a user's or group's permission on a repository",
+		Long: `Remove the explicit permission a user or group has on a repository.
+
+They may still have access through a group, their project or the
+workspace. You will be asked to confirm unless --yes is given.`,
+		Example: `  # Remove a user's explicit access to the current repository
+  bb repo access revoke alice
+
+  # Remove a group's access without confirmation
+  bb repo access revoke developers --group --yes --repo myworkspace/myrepo`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts.principal = args[0]
+			return runAccessRevoke(cmd.Context(), opts)
+		},
+	}
+
+	cmd.Flags().BoolVar(&opts.group, "group", false, "Treat the argument as a workspace group slug")
+	cmd.Flags().BoolVarP(&opts.yes, "yes", "y", false, "Skip the confirmation prompt")
+	cmd.Flags().StringVarP(&opts.repo, "repo", "R", "", "Repository in WORKSPACE/REPO format")
+
+	_ = cmd.RegisterFlagCompletionFunc("repo", cmdutil.CompleteRepoNames)
+
+	return cmd
+}
+
+// validatePermissionLevel normalizes level and checks it can be granted
+func validatePermissionLevel(level string) (string, error) {
+	level = strings.ToLower(strings.TrimSpace(level))
+	for _, l := range api.PermissionLevels {
+		if level == l {
+			return level, nil
+		}
+	}
+	return "", fmt.Errorf("invalid permission level %q: must be one of %s", level, strings.Join(api.PermissionLevels, ", "))
+}
+
+func runAccessGrant(ctx context.Context, opts *accessChangeOptions) error {
+	level, err := validatePermissionLevel(opts.level)
+	if err != nil {
+		return err
+	}
+
+	workspace, repoSlug, err := cmdutil.ParseRepository(opts.repo)
+	if err != nil {
+		return err
+	}
+
+	client, err := cmdutil.GetAPIClient()
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	if opts.group {
+		if _, err := client.SetRepoGroupPermission(ctx, workspace, repoSlug, opts.principal, level); err != nil {
+			return accessError(err, "grant permission", workspace, repoSlug)
+		}
+		opts.streams.Success("Granted group %s %s access to %s/%s", opts.principal, level, workspace, repoSlug)
+		return nil
+	}
+
+	uuid, err := cmdutil.UserResolverFor(client, workspace).Resolve(ctx, opts.principal)
+	if err != nil {
+		return err
+	}
+	if _, err := client.SetRepoUserPermission(ctx, workspace, repoSlug, uuid, level); err != nil {
+		return accessError(err, "grant permission", workspace, repoSlug)
+	}
+
+	opts.streams.Success("Granted %s %s access to %s/%s", opts.principal, level, workspace, repoSlug)
+	return nil
+}
+
+func runAccessRevoke(ctx context.Context, opts *accessChangeOptions) error {
+	workspace, repoSlug, err := cmdutil.ParseRepository(opts.repo)
+	if err != nil {
+		return err
+	}
+
+	kind := "user"
+	if opts.group {
+		kind = "group"
+	}
+
+	if !opts.yes {
+		question := fmt.Sprintf("Remove %s %s's access to %s/%s?", kind, opts.principal, workspace, repoSlug)
+		confirmed, err := cmdutil.Confirm(opts.streams, question, false, "pass --yes to skip confirmation")
+		if err != nil {
+			return err
+		}
+		if !confirmed {
+			return fmt.Errorf("revoke cancelled")
+		}
+	}
+
+	client, err := cmdutil.GetAPIClient()
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	if opts.group {
+		err = client.RemoveRepoGroupPermission(ctx, workspace, repoSlug, opts.principal)
+	} else {
+		var uuid string
+		uuid, err = cmdutil.UserResolverFor(client, workspace).Resolve(ctx, opts.principal)
+		if err != nil {
+			return err
+		}
+		err = client.RemoveRepoUserPermission(ctx, workspace, repoSlug, uuid)
+	}
+	if err != nil {
+		return accessError(err, "revoke permission", workspace, repoSlug)
+	}
+
+	opts.streams.Success("Removed %s %s's access to %s/%s", kind, opts.principal, workspace, repoSlug)
+	return nil
+}
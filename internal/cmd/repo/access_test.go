@@ -0,0 +1,106 @@
+package repo
+
+import (
+	"errors"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+
+	"github.com/rbansal42/bitbucket-cli/internal/api"
+	"github.com/rbansal42/bitbucket-cli/internal/iostreams"
+)
+
+func TestSortAccessEntries(t *testing.T) {
+	entries := []accessEntry{
+		{Type: "user", Name: "zoe", Permission: "read"},
+		{Type: "user", Name: "Bob", Permission: "admin"},
+		{Type: "group", Name: "developers", Permission: "write"},
+		{Type: "user", Name: "alice", Permission: "admin"},
+		{Type: "group", Name: "admins", Permission: "admin"},
+		{Type: "user", Name: "carol", Permission: "write"},
+	}
+
+	sortAccessEntries(entries)
+
+	var got []string
+	for _, e := range entries {
+		got = append(got, e.Permission+":"+e.Type+":"+e.Name)
+	}
+	want := []string{
+		"admin:group:admins",
+		"admin:user:alice",
+		"admin:user:Bob",
+		"write:group:developers",
+		"write:user:carol",
+		"read:user:zoe",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("sortAccessEntries() order = %v, want %v", got, want)
+	}
+}
+
+func TestGroupAccessEntry(t *testing.T) {
+	e := groupAccessEntry(api.RepoGroupPermission{Permission: "read", Group: &api.Group{Slug: "qa"}})
+	if e.Name != "qa" || e.ID != "qa" || e.Type != "group" {
+		t.Errorf("groupAccessEntry() = %+v, want name and id qa", e)
+	}
+}
+
+func TestAccessError(t *testing.T) {
+	err := accessError(&api.APIError{StatusCode: http.StatusForbidden}, "list repository permissions", "ws", "repo")
+	if !strings.Contains(err.Error(), "admin access to ws/repo to list repository permissions") {
+		t.Errorf("403 error = %q, want an admin access hint naming the action", err)
+	}
+	var apiErr *api.APIError
+	if !errors.As(err, &apiErr) {
+		t.Error("accessError should wrap the API error")
+	}
+
+	err = accessError(&api.APIError{StatusCode: http.StatusNotFound}, "grant permission", "ws", "repo")
+	if !strings.HasPrefix(err.Error(), "failed to grant permission") {
+		t.Errorf("404 error = %q, want a plain failure message", err)
+	}
+}
+
+func TestAccessCommandsTakeRepoFlag(t *testing.T) {
+	for _, cmd := range []*cobra.Command{
+		NewCmdAccessList(iostreams.New()),
+		NewCmdAccessGrant(iostreams.New()),
+		NewCmdAccessRevoke(iostreams.New()),
+	} {
+		if cmd.Flags().Lookup("repo") == nil {
+			t.Errorf("%s has no --repo flag", cmd.Name())
+		}
+	}
+
+	list := NewCmdAccessList(iostreams.New())
+	if err := list.Args(list, []string{"ws/repo"}); err == nil {
+		t.Error("access list should take the repository with --repo, not as an argument")
+	}
+}
+
+func TestValidatePermissionLevel(t *testing.T) {
+	for _, tt := range []struct {
+		in      string
+		want    string
+		wantErr bool
+	}{
+		{in: "read", want: "read"},
+		{in: "Write", want: "write"},
+		{in: " admin ", want: "admin"},
+		{in: "owner", wantErr: true},
+		{in: "", wantErr: true},
+	} {
+		got, err := validatePermissionLevel(tt.in)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("validatePermissionLevel(%q) error = %v, wantErr %v", tt.in, err, tt.wantErr)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("validatePermissionLevel(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,426 @@
+package repo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+
+	"github.com/rbansal42/bitbucket-cli/internal/api"
+	"github.com/rbansal42/bitbucket-cli/internal/cmdutil"
+	"github.com/rbansal42/bitbucket-cli/internal/iostreams"
+)
+
+// Steps performed by 'bb repo archive'
+const (
+	archiveStepRestrictPush = "restrict-push"
+	archiveStepPrivate      = "private"
+	archiveStepNoForks      = "no-forks"
+)
+
+var archiveSteps = []string{archiveStepRestrictPush, archiveStepPrivate, archiveStepNoForks}
+
+// archiveMarker is the file on the main branch holding the archive record
+const archiveMarker = ".bb"
+
+// archiveRecord is the state saved by 'bb repo archive' so that
+// 'bb repo unarchive' can undo exactly what was changed. Fields are only
+// set for the changes archive makes.
+type archiveRecord struct {
+	Repository     string    `yaml:"repository"`
+	ArchivedAt     time.Time `yaml:"archived_at"`
+	RestrictedPush bool      `yaml:"restricted_push,omitempty"` // push restriction added
+	WasPublic      bool      `yaml:"was_public,omitempty"`      // repository was made private
+	ForkPolicy     string    `yaml:"fork_policy,omitempty"`     // fork policy before archiving
+}
+
+func (r *archiveRecord) changed() bool {
+	return r.RestrictedPush || r.WasPublic || r.ForkPolicy != ""
+}
+
+type archiveOptions struct {
+	streams *iostreams.IOStreams
+	repoArg string
+	steps   []string
+	dryRun  bool
+}
+
+// NewCmdArchive creates the repo archive command
+func NewCmdArchive(streams *iostreams.IOStreams) *cobra.Command {
+	opts := &archiveOptions{
+		streams: streams,
+	}
+
+	cmd := &cobra.Command{
+		Use:   "archive [<workspace/repo>]",
+		Short: "Make a repository read-only",
+		Long: `Archive a repository by making it read-only.
+
+Bitbucket Cloud has no archive setting, so this is a composite action made of
+the following steps, all of which run by default:
+
+  restrict-push   add a branch restriction denying pushes to all branches (*)
+  private         make the repository private
+  no-forks        set the fork policy to no_forks
+
+Use --steps to choose which of them to run. Steps that are already in effect
+are skipped. Before changing anything, the previous settings are committed to
+the main branch in a .bb file, and 'bb repo unarchive' uses that record to
+undo only what was changed.`,
+		Example: `  # Archive the current repository
+  bb repo archive
+
+  # Only deny pushes, leaving visibility and forking alone
+  bb repo archive myworkspace/old-service --steps restrict-push
+
+  # Show what would change without changing anything
+  bb repo archive myworkspace/old-service --dry-run`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) > 0 {
+				opts.repoArg = args[0]
+			}
+			for _, step := range opts.steps {
+				if !slices.Contains(archiveSteps, step) {
+					return fmt.Errorf("invalid step %q: must be one of %s", step, strings.Join(archiveSteps, ", "))
+				}
+			}
+			return runArchive(cmd.Context(), opts)
+		},
+	}
+
+	cmd.Flags().StringSliceVar(&opts.steps, "steps", archiveSteps, "Archive steps to run: "+strings.Join(archiveSteps, ", "))
+	cmd.Flags().BoolVar(&opts.dryRun, "dry-run", false, "Show what would change without changing anything")
+
+	cmd.ValidArgsFunction = cmdutil.CompleteRepoNames
+	_ = cmd.RegisterFlagCompletionFunc("steps", cmdutil.StaticFlagCompletion(archiveSteps))
+
+	return cmd
+}
+
+// NewCmdUnarchive creates the repo unarchive command
+func NewCmdUnarchive(streams *iostreams.IOStreams) *cobra.Command {
+	opts := &archiveOptions{
+		streams: streams,
+	}
+
+	cmd := &cobra.Command{
+		Use:   "unarchive [<workspace/repo>]",
+		Short: "Restore a repository archived with 'bb repo archive'",
+		Long: `Undo 'bb repo archive' using the .bb file it committed.
+
+Only the changes made by archive are reverted: the push restriction it added
+is removed, and visibility and fork policy are set back to their previous
+values. The .bb file is deleted afterwards, in another commit.`,
+		Example: `  # Unarchive a repository
+  bb repo unarchive myworkspace/old-service`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) > 0 {
+				opts.repoArg = args[0]
+			}
+			return runUnarchive(cmd.Context(), opts)
+		},
+	}
+
+	cmd.Flags().BoolVar(&opts.dryRun, "dry-run", false, "Show what would change without changing anything")
+
+	cmd.ValidArgsFunction = cmdutil.CompleteRepoNames
+
+	return cmd
+}
+
+func runArchive(ctx context.Context, opts *archiveOptions) error {
+	workspace, repoSlug, err := cmdutil.ParseRepository(opts.repoArg)
+	if err != nil {
+		return err
+	}
+	fullName := workspace + "/" + repoSlug
+
+	client, err := cmdutil.GetAPIClient()
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 60*time.Second)
+	defer cancel()
+
+	repo, err := client.GetRepository(ctx, workspace, repoSlug)
+	if err != nil {
+		return fmt.Errorf("failed to get repository: %w", err)
+	}
+
+	if existing, err := loadArchiveRecord(ctx, client, workspace, repoSlug, repo); err != nil {
+		return err
+	} else if existing != nil {
+		return fmt.Errorf("%s was already archived on %s; run 'bb repo unarchive' first", fullName, existing.ArchivedAt.Format("2006-01-02"))
+	}
+
+	// Work out every change first, so the record is committed while pushes
+	// are still allowed and before anything it describes is changed
+	record := &archiveRecord{Repository: fullName, ArchivedAt: time.Now().UTC()}
+	var changes, skipped []string
+
+	if slices.Contains(opts.steps, archiveStepRestrictPush) {
+		existing, err := findPushRestriction(ctx, client, workspace, repoSlug)
+		if err != nil {
+			return fmt.Errorf("failed to check branch restrictions: %w", err)
+		}
+		if existing != nil {
+			skipped = append(skipped, fmt.Sprintf("pushes to all branches are already denied (restriction #%d)", existing.ID))
+		} else {
+			record.RestrictedPush = true
+		}
+	}
+
+	update := &api.RepositoryUpdateOptions{}
+	var updateChanges []string
+	if slices.Contains(opts.steps, archiveStepPrivate) {
+		if repo.IsPrivate {
+			skipped = append(skipped, "repository is already private")
+		} else {
+			private := true
+			update.IsPrivate = &private
+			record.WasPublic = true
+			updateChanges = append(updateChanges, "visibility: public -> private")
+		}
+	}
+	if slices.Contains(opts.steps, archiveStepNoForks) {
+		if repo.ForkPolicy == "no_forks" {
+			skipped = append(skipped, "forking is already disabled")
+		} else {
+			policy := "no_forks"
+			update.ForkPolicy = &policy
+			record.ForkPolicy = repo.ForkPolicy
+			updateChanges = append(updateChanges, fmt.Sprintf("fork policy: %s -> no_forks", repo.ForkPolicy))
+		}
+	}
+
+	if !record.changed() {
+		printArchiveSummary(opts.streams, fmt.Sprintf("Nothing to change; %s already looks archived:", fullName), nil, skipped)
+		return nil
+	}
+
+	if opts.dryRun {
+		changes = append(changes, fmt.Sprintf("commit %s to %s recording the previous settings", archiveMarker, mainBranchName(repo)))
+		if record.RestrictedPush {
+			changes = append(changes, "add a branch restriction denying pushes to all branches")
+		}
+		changes = append(changes, updateChanges...)
+		printArchiveSummary(opts.streams, fmt.Sprintf("Would archive %s:", fullName), changes, skipped)
+		return nil
+	}
+
+	if err := saveArchiveRecord(ctx, client, workspace, repoSlug, repo, record); err != nil {
+		return err
+	}
+	changes = append(changes, fmt.Sprintf("committed %s to %s recording the previous settings", archiveMarker, mainBranchName(repo)))
+
+	if len(updateChanges) > 0 {
+		if _, err := client.UpdateRepository(ctx, workspace, repoSlug, update); err != nil {
+			return fmt.Errorf("failed to update repository settings: %w", err)
+		}
+		changes = append(changes, updateChanges...)
+	}
+
+	// The restriction goes last, as it blocks the commit above
+	if record.RestrictedPush {
+		created, err := client.CreateBranchRestriction(ctx, workspace, repoSlug, &api.BranchRestriction{
+			Kind:            "push",
+			BranchMatchKind: "glob",
+			Pattern:         "*",
+		})
+		if err != nil {
+			return fmt.Errorf("failed to add push restriction: %w", err)
+		}
+		changes = append(changes, fmt.Sprintf("added branch restriction #%d denying pushes to all branches", created.ID))
+	}
+
+	opts.streams.Success("Archived %s", fullName)
+	printArchiveSummary(opts.streams, "", changes, skipped)
+	return nil
+}
+
+func runUnarchive(ctx context.Context, opts *archiveOptions) error {
+	workspace, repoSlug, err := cmdutil.ParseRepository(opts.repoArg)
+	if err != nil {
+		return err
+	}
+	fullName := workspace + "/" + repoSlug
+
+	client, err := cmdutil.GetAPIClient()
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 60*time.Second)
+	defer cancel()
+
+	repo, err := client.GetRepository(ctx, workspace, repoSlug)
+	if err != nil {
+		return fmt.Errorf("failed to get repository: %w", err)
+	}
+
+	record, err := loadArchiveRecord(ctx, client, workspace, repoSlug, repo)
+	if err != nil {
+		return err
+	}
+	if record == nil {
+		return fmt.Errorf("%s has no %s file on %s; only repositories archived with 'bb repo archive' can be unarchived", fullName, archiveMarker, mainBranchName(repo))
+	}
+
+	var changes, skipped []string
+	update := &api.RepositoryUpdateOptions{}
+	if record.WasPublic {
+		public := false
+		update.IsPrivate = &public
+		changes = append(changes, "visibility: private -> public")
+	}
+	if record.ForkPolicy != "" {
+		policy := record.ForkPolicy
+		update.ForkPolicy = &policy
+		changes = append(changes, fmt.Sprintf("fork policy: no_forks -> %s", policy))
+	}
+
+	if opts.dryRun {
+		if record.RestrictedPush {
+			changes = append([]string{"remove the branch restriction denying pushes to all branches"}, changes...)
+		}
+		changes = append(changes, fmt.Sprintf("delete %s from %s", archiveMarker, mainBranchName(repo)))
+		printArchiveSummary(opts.streams, fmt.Sprintf("Would unarchive %s:", fullName), changes, nil)
+		return nil
+	}
+
+	// The restriction goes first, so the record can be deleted afterwards
+	if record.RestrictedPush {
+		restriction, err := findPushRestriction(ctx, client, workspace, repoSlug)
+		if err != nil {
+			return fmt.Errorf("failed to check branch restrictions: %w", err)
+		}
+		if restriction == nil {
+			skipped = append(skipped, "the push restriction was already removed")
+		} else {
+			err := client.DeleteBranchRestriction(ctx, workspace, repoSlug, restriction.ID)
+			var apiErr *api.APIError
+			if errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusNotFound {
+				skipped = append(skipped, fmt.Sprintf("branch restriction #%d was already removed", restriction.ID))
+			} else if err != nil {
+				return fmt.Errorf("failed to remove push restriction: %w", err)
+			} else {
+				changes = append([]string{fmt.Sprintf("removed branch restriction #%d", restriction.ID)}, changes...)
+			}
+		}
+	}
+
+	if update.IsPrivate != nil || update.ForkPolicy != nil {
+		if _, err := client.UpdateRepository(ctx, workspace, repoSlug, update); err != nil {
+			return fmt.Errorf("failed to restore repository settings: %w", err)
+		}
+	}
+
+	if err := removeArchiveRecord(ctx, client, workspace, repoSlug, repo); err != nil {
+		opts.streams.Warning("Could not delete %s from %s: %v", archiveMarker, mainBranchName(repo), err)
+	} else {
+		changes = append(changes, fmt.Sprintf("deleted %s from %s", archiveMarker, mainBranchName(repo)))
+	}
+
+	opts.streams.Success("Unarchived %s", fullName)
+	printArchiveSummary(opts.streams, "", changes, skipped)
+	return nil
+}
+
+// findPushRestriction returns an existing restriction that denies pushes to
+// every branch for everyone, if there is one
+func findPushRestriction(ctx context.Context, client *api.Client, workspace, repoSlug string) (*api.BranchRestriction, error) {
+	result, err := client.ListBranchRestrictions(ctx, workspace, repoSlug, &api.BranchRestrictionListOptions{
+		Kind:    "push",
+		Pattern: "*",
+	})
+	if err != nil {
+		return nil, err
+	}
+	for i, r := range result.Values {
+		if r.Pattern == "*" && len(r.Users) == 0 && len(r.Groups) == 0 {
+			return &result.Values[i], nil
+		}
+	}
+	return nil, nil
+}
+
+func printArchiveSummary(streams *iostreams.IOStreams, header string, changes, skipped []string) {
+	if header != "" {
+		streams.Info("%s", header)
+	}
+	for _, c := range changes {
+		streams.Info("  - %s", c)
+	}
+	for _, s := range skipped {
+		streams.Info("  - skipped: %s", s)
+	}
+}
+
+// mainBranchName returns the name of the repository's main branch, or ""
+// for an empty repository
+func mainBranchName(repo *api.RepositoryFull) string {
+	if repo.MainBranch == nil {
+		return ""
+	}
+	return repo.MainBranch.Name
+}
+
+// loadArchiveRecord reads the archive record from the main branch,
+// returning nil if there is none
+func loadArchiveRecord(ctx context.Context, client *api.Client, workspace, repoSlug string, repo *api.RepositoryFull) (*archiveRecord, error) {
+	branch := mainBranchName(repo)
+	if branch == "" {
+		return nil, nil
+	}
+
+	data, err := client.GetFileContent(ctx, workspace, repoSlug, branch, archiveMarker)
+	var apiErr *api.APIError
+	if errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusNotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read archive record: %w", err)
+	}
+
+	var record archiveRecord
+	if err := yaml.Unmarshal(data, &record); err != nil {
+		return nil, fmt.Errorf("failed to parse archive record %s: %w", archiveMarker, err)
+	}
+	return &record, nil
+}
+
+// saveArchiveRecord commits the archive record to the main branch
+func saveArchiveRecord(ctx context.Context, client *api.Client, workspace, repoSlug string, repo *api.RepositoryFull, record *archiveRecord) error {
+	data, err := yaml.Marshal(record)
+	if err != nil {
+		return fmt.Errorf("failed to encode archive record: %w", err)
+	}
+
+	err = client.CommitFiles(ctx, workspace, repoSlug, &api.FileCommit{
+		Branch:  mainBranchName(repo),
+		Message: "Archive repository\n\nRecorded by 'bb repo archive' so 'bb repo unarchive' can restore the previous settings.",
+		Files:   map[string]string{archiveMarker: "# Written by 'bb repo archive'; removed by 'bb repo unarchive'\n" + string(data)},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to commit archive record %s: %w", archiveMarker, err)
+	}
+	return nil
+}
+
+// removeArchiveRecord deletes the archive record from the main branch
+func removeArchiveRecord(ctx context.Context, client *api.Client, workspace, repoSlug string, repo *api.RepositoryFull) error {
+	return client.CommitFiles(ctx, workspace, repoSlug, &api.FileCommit{
+		Branch:  mainBranchName(repo),
+		Message: "Unarchive repository",
+		Delete:  []string{archiveMarker},
+	})
+}
@@ -0,0 +1,89 @@
+package repo
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/rbansal42/bitbucket-cli/internal/api"
+)
+
+func TestArchiveRecordRoundTrip(t *testing.T) {
+	// The .bb file on main, as committed and deleted through the src endpoint
+	var marker *string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/repositories/myworkspace/myrepo/src/main/.bb":
+			if marker == nil {
+				http.Error(w, `{"error": {"message": "No such file"}}`, http.StatusNotFound)
+				return
+			}
+			w.Write([]byte(*marker))
+		case r.Method == http.MethodPost && r.URL.Path == "/repositories/myworkspace/myrepo/src":
+			if err := r.ParseForm(); err != nil {
+				t.Errorf("failed to parse form: %v", err)
+			}
+			if got := r.PostForm.Get("branch"); got != "main" {
+				t.Errorf("committed to branch %q, want main", got)
+			}
+			if content, ok := r.PostForm[".bb"]; ok {
+				marker = &content[0]
+			}
+			if r.PostForm.Get("files") == ".bb" {
+				marker = nil
+			}
+			w.WriteHeader(http.StatusCreated)
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	ctx := context.Background()
+	client := api.NewClient(api.WithBaseURL(server.URL), api.WithToken("test-token"))
+	repo := &api.RepositoryFull{MainBranch: &api.MainBranch{Name: "main"}}
+
+	record, err := loadArchiveRecord(ctx, client, "myworkspace", "myrepo", repo)
+	if err != nil || record != nil {
+		t.Fatalf("expected no record before saving, got %+v, %v", record, err)
+	}
+
+	saved := &archiveRecord{
+		Repository:     "myworkspace/myrepo",
+		ArchivedAt:     time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC),
+		RestrictedPush: true,
+		WasPublic:      true,
+		ForkPolicy:     "allow_forks",
+	}
+	if err := saveArchiveRecord(ctx, client, "myworkspace", "myrepo", repo, saved); err != nil {
+		t.Fatalf("failed to save record: %v", err)
+	}
+	if marker == nil || !strings.HasPrefix(*marker, "# Written by 'bb repo archive'") {
+		t.Errorf("unexpected .bb content %v", marker)
+	}
+
+	loaded, err := loadArchiveRecord(ctx, client, "myworkspace", "myrepo", repo)
+	if err != nil {
+		t.Fatalf("failed to load record: %v", err)
+	}
+	if loaded == nil || *loaded != *saved {
+		t.Fatalf("loaded record %+v, want %+v", loaded, saved)
+	}
+	if !loaded.changed() {
+		t.Error("expected record to report changes")
+	}
+
+	if err := removeArchiveRecord(ctx, client, "myworkspace", "myrepo", repo); err != nil {
+		t.Fatalf("failed to remove record: %v", err)
+	}
+	if record, err := loadArchiveRecord(ctx, client, "myworkspace", "myrepo", repo); err != nil || record != nil {
+		t.Errorf("expected no record after removing it, got %+v, %v", record, err)
+	}
+
+	if record, err := loadArchiveRecord(ctx, client, "myworkspace", "myrepo", &api.RepositoryFull{}); err != nil || record != nil {
+		t.Errorf("expected no record for an empty repository, got %+v, %v", record, err)
+	}
+}
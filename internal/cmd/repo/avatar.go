@@ -0,0 +1,80 @@
+package repo
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/rbansal42/bitbucket-cli/internal/cmdutil"
+	"github.com/rbansal42/bitbucket-cli/internal/iostreams"
+)
+
+type avatarOptions struct {
+	streams *iostreams.IOStreams
+	repoArg string
+	out     string
+}
+
+// NewCmdAvatar creates the repo avatar command
+func NewCmdAvatar(streams *iostreams.IOStreams) *cobra.Command {
+	opts := &avatarOptions{
+		streams: streams,
+	}
+
+	cmd := &cobra.Command{
+		Use:   "avatar [<workspace/repo>]",
+		Short: "Download a repository's avatar",
+		Long: `Download the avatar image of a repository.
+
+With no arguments, the repository for the current directory is used.
+The image is saved to the --out file, or written to stdout when it is
+redirected.`,
+		Example: `  # Save the current repository's avatar
+  bb repo avatar --out avatar.png
+
+  # Save another repository's avatar
+  bb repo avatar myworkspace/myrepo --out myrepo.png`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) > 0 {
+				opts.repoArg = args[0]
+			}
+			return runAvatar(cmd.Context(), opts)
+		},
+	}
+
+	cmd.Flags().StringVarP(&opts.out, "out", "o", "", "File to write the image to (\"-\" for stdout)")
+
+	cmd.ValidArgsFunction = cmdutil.CompleteRepoNames
+
+	return cmd
+}
+
+func runAvatar(ctx context.Context, opts *avatarOptions) error {
+	workspace, repoSlug, err := cmdutil.ParseRepository(opts.repoArg)
+	if err != nil {
+		return err
+	}
+
+	client, err := cmdutil.GetAPIClient()
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	repo, err := client.GetRepository(ctx, workspace, repoSlug)
+	if err != nil {
+		return fmt.Errorf("failed to get repository: %w", err)
+	}
+
+	data, contentType, err := client.DownloadAvatar(ctx, repo.Links.Avatar.Href)
+	if err != nil {
+		return fmt.Errorf("failed to download avatar: %w", err)
+	}
+
+	return cmdutil.WriteAvatar(opts.streams, data, contentType, opts.out)
+}
@@ -0,0 +1,108 @@
+package repo
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/rbansal42/bitbucket-cli/internal/api"
+	"github.com/rbansal42/bitbucket-cli/internal/cmdutil"
+	"github.com/rbansal42/bitbucket-cli/internal/iostreams"
+)
+
+type branchingModelOptions struct {
+	streams *iostreams.IOStreams
+	repoArg string
+	jsonOut bool
+}
+
+// NewCmdBranchingModel creates the repo branching-model command
+func NewCmdBranchingModel(streams *iostreams.IOStreams) *cobra.Command {
+	opts := &branchingModelOptions{
+		streams: streams,
+	}
+
+	cmd := &cobra.Command{
+		Use:   "branching-model [<workspace/repo>]",
+		Short: "Show a repository's branching model",
+		Long: `Show the branching model in effect for a repository: its development and
+production branches and the prefix used for each type of branch.
+
+The model may be inherited from the repository's project. 'bb pr create'
+targets the development branch by default, and 'bb branch create --type'
+uses the prefixes.`,
+		Example: `  # Show the branching model of the current repository
+  bb repo branching-model
+
+  # Print the feature branch prefix
+  bb repo branching-model myworkspace/myrepo --json | jq -r '.branch_types[] | select(.kind == "feature") | .prefix'`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) > 0 {
+				opts.repoArg = args[0]
+			}
+			workspace, repoSlug, err := cmdutil.ParseRepository(opts.repoArg)
+			if err != nil {
+				return err
+			}
+
+			client, err := cmdutil.GetAPIClient()
+			if err != nil {
+				return err
+			}
+
+			ctx, cancel := context.WithTimeout(cmd.Context(), 30*time.Second)
+			defer cancel()
+
+			return runBranchingModel(ctx, client, opts, workspace, repoSlug)
+		},
+	}
+
+	cmd.Flags().BoolVar(&opts.jsonOut, "json", false, "Output in JSON format")
+
+	cmd.ValidArgsFunction = cmdutil.CompleteRepoNames
+
+	return cmd
+}
+
+func runBranchingModel(ctx context.Context, client *api.Client, opts *branchingModelOptions, workspace, repoSlug string) error {
+	model, err := client.GetBranchingModel(ctx, workspace, repoSlug)
+	if err != nil {
+		return fmt.Errorf("failed to get branching model: %w", err)
+	}
+
+	if opts.jsonOut {
+		return cmdutil.PrintJSON(opts.streams, model)
+	}
+
+	fmt.Fprintf(opts.streams.Out, "Development: %s\n", describeModelBranch(model.Development))
+	fmt.Fprintf(opts.streams.Out, "Production:  %s\n", describeModelBranch(model.Production))
+
+	if len(model.BranchTypes) == 0 {
+		return nil
+	}
+
+	fmt.Fprintln(opts.streams.Out)
+	t := cmdutil.NewTableWriter(opts.streams, "TYPE", "PREFIX")
+	for _, bt := range model.BranchTypes {
+		t.AddRow(bt.Kind, bt.Prefix)
+	}
+	return t.Render()
+}
+
+// describeModelBranch formats the development or production branch of a
+// branching model
+func describeModelBranch(b *api.BranchingModelBranch) string {
+	switch {
+	case b == nil:
+		return "(not configured)"
+	case b.UseMainbranch:
+		return b.Name + " (default branch)"
+	case b.Branch == nil:
+		return b.Name + " (branch does not exist)"
+	default:
+		return b.Name
+	}
+}
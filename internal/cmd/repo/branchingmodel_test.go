@@ -0,0 +1,51 @@
+package repo
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/rbansal42/bitbucket-cli/internal/api"
+	"github.com/rbansal42/bitbucket-cli/internal/iostreams"
+)
+
+func TestRunBranchingModel(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/repositories/ws/repo/branching-model" {
+			http.NotFound(w, r)
+			return
+		}
+		fmt.Fprint(w, `{
+			"development": {"name": "develop", "branch": {"name": "develop"}},
+			"production": {"name": "main", "use_mainbranch": true, "branch": {"name": "main"}},
+			"branch_types": [{"kind": "feature", "prefix": "feature/"}, {"kind": "hotfix", "prefix": "hotfix/"}]
+		}`)
+	}))
+	defer server.Close()
+	client := api.NewClient(api.WithBaseURL(server.URL), api.WithToken("test-token"))
+
+	out := &bytes.Buffer{}
+	opts := &branchingModelOptions{streams: &iostreams.IOStreams{Out: out, ErrOut: &bytes.Buffer{}}}
+	if err := runBranchingModel(context.Background(), client, opts, "ws", "repo"); err != nil {
+		t.Fatalf("runBranchingModel() error: %v", err)
+	}
+
+	for _, want := range []string{"Development: develop\n", "Production:  main (default branch)", "feature/", "hotfix/"} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("output missing %q:\n%s", want, out.String())
+		}
+	}
+}
+
+func TestDescribeModelBranch(t *testing.T) {
+	if got := describeModelBranch(nil); got != "(not configured)" {
+		t.Errorf("describeModelBranch(nil) = %q", got)
+	}
+	if got := describeModelBranch(&api.BranchingModelBranch{Name: "develop"}); got != "develop (branch does not exist)" {
+		t.Errorf("describeModelBranch(missing) = %q", got)
+	}
+}
@@ -12,6 +12,7 @@ import (
 	"github.com/spf13/cobra"
 
 	"github.com/rbansal42/bitbucket-cli/internal/cmdutil"
+	"github.com/rbansal42/bitbucket-cli/internal/config"
 	"github.com/rbansal42/bitbucket-cli/internal/iostreams"
 )
 
@@ -21,6 +22,13 @@ type cloneOptions struct {
 	directory string
 	depth     int
 	branch    string
+	protocol  string
+
+	// --all
+	all         bool
+	workspace   string
+	concurrency int
+	update      bool
 }
 
 // NewCmdClone creates the repo clone command
@@ -30,16 +38,26 @@ func NewCmdClone(streams *iostreams.IOStreams) *cobra.Command {
 	}
 
 	cmd := &cobra.Command{
-		Use:   "clone <workspace/repo> [<directory>]",
+		Use:   "clone {<workspace/repo> [<directory>] | --all [<directory>]}",
 		Short: "Clone a repository",
 		Long: `Clone a Bitbucket repository to your local machine.
 
 You can specify a repository using the workspace/repo format, or provide
 a full Bitbucket URL (SSH or HTTPS).
 
-The clone URL protocol (SSH or HTTPS) is determined by the git_protocol
-setting in your configuration. Use 'bb config set git_protocol <ssh|https>'
-to change this preference.`,
+The clone URL protocol (SSH or HTTPS) is taken from --protocol, then the
+git_protocol set for the host ('bb config set git_protocol ssh --host
+<host>'), then the global git_protocol setting. With none of these set,
+SSH is used if an SSH key or agent is available and HTTPS otherwise.
+
+With --all, every repository in the workspace is cloned into its own
+directory, named after the repository's slug, under the given directory (the
+current directory by default). The workspace comes from --workspace, then
+the default workspace. Up to --concurrency repositories are cloned at a
+time. A repository whose directory already exists is skipped, or updated
+with 'git pull --ff-only' when --update is given. Each repository's result
+is reported and a failure doesn't stop the others; the command fails at the
+end if any repository failed.`,
 		Example: `  # Clone a repository
   bb repo clone myworkspace/myrepo
 
@@ -52,11 +70,45 @@ to change this preference.`,
   # Shallow clone (only latest commit)
   bb repo clone myworkspace/myrepo --depth 1
 
+  # Clone over HTTPS regardless of configuration
+  bb repo clone myworkspace/myrepo --protocol https
+
   # Clone using a full URL
   bb repo clone https://bitbucket.org/myworkspace/myrepo.git
-  bb repo clone git@bitbucket.org:myworkspace/myrepo.git`,
-		Args: cobra.RangeArgs(1, 2),
+  bb repo clone git@bitbucket.org:myworkspace/myrepo.git
+
+  # Clone every repository in a workspace into ~/src/myworkspace
+  bb repo clone --all --workspace myworkspace ~/src/myworkspace
+
+  # Clone the new ones and pull the ones already cloned
+  bb repo clone --all -w myworkspace ~/src/myworkspace --update`,
+		Args: func(cmd *cobra.Command, args []string) error {
+			if opts.all {
+				return cobra.MaximumNArgs(1)(cmd, args)
+			}
+			return cobra.RangeArgs(1, 2)(cmd, args)
+		},
 		RunE: func(cmd *cobra.Command, args []string) error {
+			if opts.all {
+				if opts.branch != "" {
+					return fmt.Errorf("--branch cannot be used with --all")
+				}
+				ws, err := cmdutil.ResolveWorkspace(cmd)
+				if err != nil {
+					return err
+				}
+				opts.workspace = ws
+				if len(args) > 0 {
+					opts.directory = args[0]
+				}
+				return runCloneAll(cmd.Context(), opts)
+			}
+			for _, flag := range []string{"workspace", "concurrency", "update"} {
+				if cmd.Flags().Changed(flag) {
+					return fmt.Errorf("--%s can only be used with --all", flag)
+				}
+			}
+
 			opts.repoArg = args[0]
 			if len(args) > 1 {
 				opts.directory = args[1]
@@ -68,6 +120,14 @@ to change this preference.`,
 
 	cmd.Flags().IntVar(&opts.depth, "depth", 0, "Create a shallow clone with a limited number of commits")
 	cmd.Flags().StringVarP(&opts.branch, "branch", "b", "", "Clone a specific branch")
+	cmd.Flags().StringVar(&opts.protocol, "protocol", "", "Protocol for the clone URL: https or ssh")
+	cmd.Flags().BoolVar(&opts.all, "all", false, "Clone every repository in a workspace")
+	cmd.Flags().StringVarP(&opts.workspace, "workspace", "w", "", "Workspace to clone with --all")
+	cmd.Flags().IntVar(&opts.concurrency, "concurrency", 4, "Maximum number of repositories cloned at a time with --all")
+	cmd.Flags().BoolVar(&opts.update, "update", false, "With --all, pull repositories that are already cloned")
+
+	_ = cmd.RegisterFlagCompletionFunc("protocol", cmdutil.StaticFlagCompletion(config.GitProtocols))
+	_ = cmd.RegisterFlagCompletionFunc("workspace", cmdutil.CompleteWorkspaceNames)
 
 	cmd.ValidArgsFunction = cmdutil.CompleteRepoNames
 
@@ -75,6 +135,10 @@ to change this preference.`,
 }
 
 func runClone(opts *cloneOptions) error {
+	if err := validateProtocol(opts.protocol); err != nil {
+		return err
+	}
+
 	var cloneURL string
 	var destDir string
 
@@ -103,13 +167,14 @@ func runClone(opts *cloneOptions) error {
 		defer cancel()
 
 		// Fetch repository details to get clone URLs
+		opts.streams.Verbose("Fetching repository %s/%s...", workspace, repoSlug)
 		repo, err := client.GetRepository(ctx, workspace, repoSlug)
 		if err != nil {
 			return fmt.Errorf("failed to get repository: %w", err)
 		}
 
 		// Get preferred protocol and clone URL
-		protocol := getPreferredProtocol()
+		protocol := getPreferredProtocol(repoHost(repo.Links), opts.protocol)
 		cloneURL = getCloneURL(repo.Links, protocol)
 		if cloneURL == "" {
 			return fmt.Errorf("no clone URL found for repository")
@@ -117,6 +182,7 @@ func runClone(opts *cloneOptions) error {
 
 		destDir = repoSlug
 	}
+	opts.streams.Verbose("Using clone URL %s", cloneURL)
 
 	// Use custom directory if specified
 	if opts.directory != "" {
@@ -0,0 +1,161 @@
+package repo
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+
+	"golang.org/x/sync/errgroup"
+
+	"github.com/rbansal42/bitbucket-cli/internal/api"
+	"github.com/rbansal42/bitbucket-cli/internal/cmdutil"
+)
+
+// cloneOutcome is what happened to one repository with --all
+type cloneOutcome int
+
+const (
+	cloneCloned cloneOutcome = iota
+	cloneUpdated
+	cloneSkipped
+	cloneFailed
+)
+
+// runCloneAll clones every repository of a workspace into its own
+// directory under opts.directory
+func runCloneAll(ctx context.Context, opts *cloneOptions) error {
+	if err := validateProtocol(opts.protocol); err != nil {
+		return err
+	}
+	if opts.concurrency < 1 {
+		return fmt.Errorf("--concurrency must be at least 1")
+	}
+
+	client, err := cmdutil.GetAPIClient()
+	if err != nil {
+		return err
+	}
+
+	opts.streams.Info("Listing repositories in %s...", opts.workspace)
+	var repos []api.RepositoryFull
+	for repo, err := range client.IterRepositories(ctx, opts.workspace, &api.RepositoryListOptions{Limit: 100}) {
+		if err != nil {
+			return fmt.Errorf("failed to list repositories: %w", err)
+		}
+		repos = append(repos, repo)
+	}
+	if len(repos) == 0 {
+		opts.streams.Info("No repositories in %s", opts.workspace)
+		return nil
+	}
+
+	dir := opts.directory
+	if dir == "" {
+		dir = "."
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return fmt.Errorf("failed to create directory: %w", err)
+	}
+
+	return cloneRepositories(ctx, opts, repos, dir)
+}
+
+// cloneRepositories clones each repository into dir/<slug>, at most
+// opts.concurrency at a time. A repository whose directory already exists is
+// skipped, or pulled with opts.update. Every repository is attempted and
+// reported; the error only summarizes the failures.
+func cloneRepositories(ctx context.Context, opts *cloneOptions, repos []api.RepositoryFull, dir string) error {
+	var (
+		mu     sync.Mutex
+		counts [cloneFailed + 1]int
+	)
+	report := func(outcome cloneOutcome, format string, a ...any) {
+		mu.Lock()
+		defer mu.Unlock()
+		counts[outcome]++
+		switch outcome {
+		case cloneFailed:
+			opts.streams.Error(format, a...)
+		case cloneSkipped:
+			opts.streams.Info(format, a...)
+		default:
+			opts.streams.Success(format, a...)
+		}
+	}
+
+	var g errgroup.Group
+	g.SetLimit(opts.concurrency)
+	for _, repo := range repos {
+		g.Go(func() error {
+			name := repo.FullName
+			if name == "" {
+				name = opts.workspace + "/" + repo.Slug
+			}
+			dest := filepath.Join(dir, repo.Slug)
+
+			if _, err := os.Stat(dest); err == nil {
+				if !opts.update {
+					report(cloneSkipped, "Skipped %s: %s already exists", name, dest)
+					return nil
+				}
+				if err := runGitQuiet(ctx, "-C", dest, "pull", "--ff-only"); err != nil {
+					report(cloneFailed, "Failed to update %s: %v", name, err)
+					return nil
+				}
+				report(cloneUpdated, "Updated %s", name)
+				return nil
+			}
+
+			cloneURL := getCloneURL(repo.Links, getPreferredProtocol(repoHost(repo.Links), opts.protocol))
+			if cloneURL == "" {
+				report(cloneFailed, "Failed to clone %s: no clone URL found", name)
+				return nil
+			}
+			args := []string{"clone"}
+			if opts.depth > 0 {
+				args = append(args, "--depth", strconv.Itoa(opts.depth))
+			}
+			args = append(args, cloneURL, dest)
+			if err := runGitQuiet(ctx, args...); err != nil {
+				report(cloneFailed, "Failed to clone %s: %v", name, err)
+				return nil
+			}
+			report(cloneCloned, "Cloned %s into %s", name, dest)
+			return nil
+		})
+	}
+	_ = g.Wait()
+
+	fmt.Fprintln(opts.streams.Out)
+	opts.streams.Info("%d cloned, %d updated, %d skipped, %d failed",
+		counts[cloneCloned], counts[cloneUpdated], counts[cloneSkipped], counts[cloneFailed])
+	if counts[cloneFailed] > 0 {
+		return fmt.Errorf("failed to clone or update %d of %d repositories", counts[cloneFailed], len(repos))
+	}
+	return nil
+}
+
+// runGitQuiet runs git without a terminal for prompts, so parallel clones
+// don't interleave their output. A failure is reported with the last line
+// git wrote.
+func runGitQuiet(ctx context.Context, args ...string) error {
+	cmd := exec.CommandContext(ctx, "git", args...)
+	cmd.Env = append(os.Environ(), "GIT_TERMINAL_PROMPT=0")
+	var out bytes.Buffer
+	cmd.Stdout = &out
+	cmd.Stderr = &out
+	if err := cmd.Run(); err != nil {
+		lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+		if last := strings.TrimSpace(lines[len(lines)-1]); last != "" {
+			return fmt.Errorf("%s", last)
+		}
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,87 @@
+package repo
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/rbansal42/bitbucket-cli/internal/api"
+	"github.com/rbansal42/bitbucket-cli/internal/iostreams"
+)
+
+// newBareRepo creates a bare repository with one commit and returns its path
+func newBareRepo(t *testing.T, dir, name string) string {
+	t.Helper()
+	work := filepath.Join(dir, "work-"+name)
+	bare := filepath.Join(dir, name+".git")
+	for _, args := range [][]string{
+		{"init", "-q", work},
+		{"-C", work, "-c", "user.name=t", "-c", "user.email=t@example.com", "commit", "-q", "--allow-empty", "-m", "init"},
+		{"clone", "-q", "--bare", work, bare},
+	} {
+		if out, err := exec.Command("git", args...).CombinedOutput(); err != nil {
+			t.Fatalf("git %v: %v\n%s", args, err, out)
+		}
+	}
+	return bare
+}
+
+func TestCloneRepositories(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not available")
+	}
+	src := t.TempDir()
+	dest := t.TempDir()
+
+	repoWithURL := func(slug, href string) api.RepositoryFull {
+		r := api.RepositoryFull{Slug: slug, FullName: "ws/" + slug}
+		if href != "" {
+			r.Links.Clone = []api.CloneLink{{Name: "https", Href: href}}
+		}
+		return r
+	}
+	repos := []api.RepositoryFull{
+		repoWithURL("alpha", newBareRepo(t, src, "alpha")),
+		repoWithURL("beta", newBareRepo(t, src, "beta")),
+		repoWithURL("missing", filepath.Join(src, "missing.git")),
+		repoWithURL("nourl", ""),
+	}
+	// beta is already cloned
+	if out, err := exec.Command("git", "clone", "-q", repos[1].Links.Clone[0].Href, filepath.Join(dest, "beta")).CombinedOutput(); err != nil {
+		t.Fatalf("git clone: %v\n%s", err, out)
+	}
+
+	for _, update := range []bool{false, true} {
+		var out bytes.Buffer
+		opts := &cloneOptions{
+			streams:     &iostreams.IOStreams{Out: &out, ErrOut: &out},
+			workspace:   "ws",
+			protocol:    "https",
+			concurrency: 2,
+			update:      update,
+		}
+		err := cloneRepositories(context.Background(), opts, repos, dest)
+		if err == nil || !strings.Contains(err.Error(), "2 of 4") {
+			t.Errorf("update=%v: cloneRepositories() error = %v, want 2 of 4 failed", update, err)
+		}
+
+		output := out.String()
+		wantBeta := "Skipped ws/beta"
+		if update {
+			wantBeta = "Updated ws/beta"
+		}
+		for _, want := range []string{wantBeta, "Failed to clone ws/missing", "Failed to clone ws/nourl: no clone URL found"} {
+			if !strings.Contains(output, want) {
+				t.Errorf("update=%v: output missing %q:\n%s", update, want, output)
+			}
+		}
+	}
+
+	if _, err := os.Stat(filepath.Join(dest, "alpha", ".git")); err != nil {
+		t.Errorf("alpha was not cloned: %v", err)
+	}
+}
@@ -0,0 +1,290 @@
+package repo
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/rbansal42/bitbucket-cli/internal/api"
+	"github.com/rbansal42/bitbucket-cli/internal/cmdutil"
+	"github.com/rbansal42/bitbucket-cli/internal/iostreams"
+)
+
+type commitsOptions struct {
+	streams   *iostreams.IOStreams
+	repoArg   string
+	branch    string
+	since     string
+	until     string
+	limit     int
+	graph     bool
+	jsonOut   bool
+	showCount bool
+	exitCode  bool
+}
+
+// NewCmdCommits creates the repo commits command
+func NewCmdCommits(streams *iostreams.IOStreams) *cobra.Command {
+	opts := &commitsOptions{
+		streams: streams,
+	}
+
+	cmd := &cobra.Command{
+		Use:   "commits [<workspace/repo>]",
+		Short: "List commits in a repository",
+		Long: `List recent commits in a repository, newest first.
+
+With no arguments, the repository for the current directory is used.
+By default the history of the main branch is shown; use --branch for another
+branch, tag, or commit.
+
+Use --since and --until to list commits in a date window. Dates are
+YYYY-MM-DD, YYYY-MM-DDTHH:MM:SS or RFC3339; values without a time zone are
+UTC, which is how Bitbucket compares them. --since is inclusive and --until
+is exclusive. The window is applied to each page of history in turn, so a
+narrow window on a long history can take many requests.
+
+Use --graph to draw the history as an ASCII graph of branches and merges,
+like 'git log --graph --oneline'. Only the commits fetched are drawn, so
+branches that fork before the oldest one end in open lines.`,
+		Example: `  # List recent commits on the main branch
+  bb repo commits
+
+  # List commits on a branch in January 2024
+  bb repo commits --branch release/1.0 --since 2024-01-01 --until 2024-02-01
+
+  # Show the last 50 commits as a graph
+  bb repo commits --graph --limit 50
+
+  # Output as JSON
+  bb repo commits myworkspace/myrepo --json`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) > 0 {
+				opts.repoArg = args[0]
+			}
+			return runCommits(cmd.Context(), opts)
+		},
+	}
+
+	cmd.Flags().StringVarP(&opts.branch, "branch", "b", "", "Branch, tag, or commit to list history from")
+	cmd.Flags().StringVar(&opts.since, "since", "", "Only commits on or after this date (UTC)")
+	cmd.Flags().StringVar(&opts.until, "until", "", "Only commits before this date (UTC)")
+	cmd.Flags().IntVarP(&opts.limit, "limit", "l", 30, "Maximum number of commits to list")
+	cmd.Flags().BoolVar(&opts.graph, "graph", false, "Draw the history as an ASCII graph")
+	cmd.Flags().BoolVar(&opts.jsonOut, "json", false, "Output in JSON format")
+	cmdutil.AddShowCountFlag(cmd, &opts.showCount)
+	cmdutil.AddAllFlag(cmd, &opts.limit)
+	cmdutil.AddExitCodeFlag(cmd, &opts.exitCode)
+	cmd.MarkFlagsMutuallyExclusive("graph", "json")
+
+	cmd.ValidArgsFunction = cmdutil.CompleteRepoNames
+	_ = cmd.RegisterFlagCompletionFunc("branch", cmdutil.CompleteBranchNames)
+
+	return cmd
+}
+
+func runCommits(ctx context.Context, opts *commitsOptions) error {
+	since, until, err := cmdutil.ParseDateRange(opts.since, opts.until)
+	if err != nil {
+		return err
+	}
+
+	workspace, repoSlug, err := cmdutil.ParseRepository(opts.repoArg)
+	if err != nil {
+		return err
+	}
+
+	client, err := cmdutil.GetAPIClient()
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	result, err := client.ListCommits(ctx, workspace, repoSlug, &api.CommitListOptions{
+		Branch: opts.branch,
+		Since:  since,
+		Until:  until,
+		Limit:  cmdutil.PageLen(opts.limit, cmdutil.MaxPageLen),
+	})
+	if err == nil {
+		result, err = collectCommits(ctx, client, result, since, until, opts.limit)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to list commits: %w", err)
+	}
+
+	if len(result.Values) == 0 {
+		opts.streams.Info("No commits found in %s/%s", workspace, repoSlug)
+		return cmdutil.NoResults(opts.exitCode)
+	}
+
+	var count *cmdutil.PageCount
+	if opts.showCount {
+		count = cmdutil.NewPageCount(result, len(result.Values))
+	}
+
+	if opts.jsonOut {
+		return cmdutil.PrintListJSON(opts.streams, result.Values, count)
+	}
+
+	if opts.graph {
+		for _, line := range commitGraph(result.Values) {
+			fmt.Fprintln(opts.streams.Out, line)
+		}
+		cmdutil.PrintPageCount(opts.streams, count)
+		return nil
+	}
+
+	t := cmdutil.NewTableWriter(opts.streams, "COMMIT", "MESSAGE", "AUTHOR", "DATE")
+	t.SetFlexColumn(1)
+	t.SetMaxWidth(2, 25)
+	for _, c := range result.Values {
+		t.AddRow(cmdutil.ShortHash(c.Hash), cmdutil.CommitSubject(c.Message), cmdutil.CommitAuthorName(c.Author), cmdutil.TimeAgo(c.Date))
+	}
+	if err := t.Render(); err != nil {
+		return err
+	}
+	cmdutil.PrintPageCount(opts.streams, count)
+	return nil
+}
+
+// collectCommits follows pages from first until limit commits dated within
+// [since, until) are collected. The commits endpoint does not document q
+// support, so the window is enforced locally. Every page is read rather than
+// stopping at the first commit before since: history is listed in topological
+// order, so commits merged from a branch can be older than ones after them.
+func collectCommits(ctx context.Context, client *api.Client, first *api.Paginated[api.Commit], since, until time.Time, limit int) (*api.Paginated[api.Commit], error) {
+	result := &api.Paginated[api.Commit]{Size: first.Size}
+	page := first
+	for page != nil {
+		result.Values = append(result.Values, filterCommitsByDate(page.Values, since, until)...)
+		result.Next = page.Next
+
+		if limit > 0 && len(result.Values) >= limit {
+			if len(result.Values) > limit && result.Size == 0 && result.Next == "" {
+				// Commits were dropped from the last page, so there are more
+				// than shown even without a next link
+				result.Size = len(result.Values)
+			}
+			result.Values = result.Values[:limit]
+			break
+		}
+		if limit < 1 {
+			break
+		}
+
+		var err error
+		page, err = api.NextPage(ctx, client, page)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return result, nil
+}
+
+// filterCommitsByDate keeps commits dated within [since, until); a zero
+// bound is open
+func filterCommitsByDate(commits []api.Commit, since, until time.Time) []api.Commit {
+	if since.IsZero() && until.IsZero() {
+		return commits
+	}
+	kept := commits[:0]
+	for _, c := range commits {
+		if !since.IsZero() && c.Date.Before(since) {
+			continue
+		}
+		if !until.IsZero() && !c.Date.Before(until) {
+			continue
+		}
+		kept = append(kept, c)
+	}
+	return kept
+}
+
+// commitGraph draws commits, newest first, as an ASCII graph like
+// 'git log --graph --oneline'. Each lane is a line of history waiting for
+// the commit it names; a commit is drawn in its lane, merges open a lane for
+// each further parent, and lanes waiting for the same commit join it.
+func commitGraph(commits []api.Commit) []string {
+	var lines []string
+	var lanes []string
+	for _, c := range commits {
+		col := slices.Index(lanes, c.Hash)
+		if col < 0 {
+			lanes = append(lanes, c.Hash)
+			col = len(lanes) - 1
+		}
+		// Other branches that lead to this commit join its lane
+		for i := len(lanes) - 1; i > col; i-- {
+			if lanes[i] == c.Hash {
+				lines = append(lines, graphClose(len(lanes), i, true))
+				lanes = slices.Delete(lanes, i, i+1)
+			}
+		}
+
+		row := make([]string, len(lanes))
+		for i := range lanes {
+			row[i] = "|"
+		}
+		row[col] = "*"
+		lines = append(lines, fmt.Sprintf("%s %s %s", strings.Join(row, " "), cmdutil.ShortHash(c.Hash), cmdutil.CommitSubject(c.Message)))
+
+		if len(c.Parents) == 0 {
+			// A root commit ends its lane
+			if col < len(lanes)-1 {
+				lines = append(lines, graphClose(len(lanes), col, false))
+			}
+			lanes = slices.Delete(lanes, col, col+1)
+			continue
+		}
+		lanes[col] = c.Parents[0].Hash
+		at := col + 1
+		for _, p := range c.Parents[1:] {
+			if slices.Contains(lanes, p.Hash) {
+				continue
+			}
+			lines = append(lines, graphFork(len(lanes), at))
+			lanes = slices.Insert(lanes, at, p.Hash)
+			at++
+		}
+	}
+	return lines
+}
+
+// graphClose draws n lanes where lane i closes, joining the lane to its
+// left if join is set, and the lanes to its right move one place left
+func graphClose(n, i int, join bool) string {
+	line := []byte(strings.Repeat(" ", 2*n))
+	for j := 0; j < n; j++ {
+		switch {
+		case j < i:
+			line[2*j] = '|'
+		case j > i || join:
+			line[2*j-1] = '/'
+		}
+	}
+	return strings.TrimRight(string(line), " ")
+}
+
+// graphFork draws n lanes where a new lane opens at position at, branching
+// from the lane to its left, and the lanes from at onwards move one place
+// right
+func graphFork(n, at int) string {
+	line := []byte(strings.Repeat(" ", 2*n+2))
+	for j := 0; j < n; j++ {
+		if j < at {
+			line[2*j] = '|'
+		} else {
+			line[2*j+1] = '\\'
+		}
+	}
+	line[2*at-1] = '\\'
+	return strings.TrimRight(string(line), " ")
+}
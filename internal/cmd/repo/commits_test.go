@@ -0,0 +1,146 @@
+package repo
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/rbansal42/bitbucket-cli/internal/api"
+)
+
+func TestFilterCommitsByDate(t *testing.T) {
+	day := func(d int) time.Time { return time.Date(2024, 1, d, 0, 0, 0, 0, time.UTC) }
+	commits := []api.Commit{
+		{Hash: "a", Date: day(1)},
+		{Hash: "b", Date: day(15)},
+		{Hash: "c", Date: day(31)},
+	}
+
+	tests := []struct {
+		name  string
+		since time.Time
+		until time.Time
+		want  []string
+	}{
+		{name: "no bounds", want: []string{"a", "b", "c"}},
+		{name: "since is inclusive", since: day(15), want: []string{"b", "c"}},
+		{name: "until is exclusive", until: day(15), want: []string{"a"}},
+		{name: "window", since: day(2), until: day(31), want: []string{"b"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			input := append([]api.Commit(nil), commits...)
+			got := filterCommitsByDate(input, tt.since, tt.until)
+			if len(got) != len(tt.want) {
+				t.Fatalf("got %d commits, want %d", len(got), len(tt.want))
+			}
+			for i, c := range got {
+				if c.Hash != tt.want[i] {
+					t.Errorf("commit %d = %q, want %q", i, c.Hash, tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestCollectCommits(t *testing.T) {
+	// Three pages of two commits from January 6 back to January 1, newest
+	// first except for c1, merged from a branch and listed before c3 and c2
+	pages := [][2]int{{6, 5}, {4, 1}, {3, 2}}
+	requested := map[string]bool{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		page := r.URL.Query().Get("page")
+		requested[page] = true
+		var n int
+		fmt.Sscan(page, &n)
+		if n == 0 {
+			n = 1
+		}
+		next := ""
+		if n < 3 {
+			next = fmt.Sprintf(`, "next": "http://%s/repositories/ws/repo/commits?page=%d"`, r.Host, n+1)
+		}
+		a, b := pages[n-1][0], pages[n-1][1]
+		fmt.Fprintf(w, `{"values": [{"hash": "c%d", "date": "2024-01-%02dT00:00:00Z"}, {"hash": "c%d", "date": "2024-01-%02dT00:00:00Z"}]%s}`,
+			a, a, b, b, next)
+	}))
+	defer server.Close()
+	client := api.NewClient(api.WithBaseURL(server.URL), api.WithToken("test-token"))
+
+	day := func(d int) time.Time { return time.Date(2024, 1, d, 0, 0, 0, 0, time.UTC) }
+	tests := []struct {
+		name      string
+		since     time.Time
+		until     time.Time
+		limit     int
+		want      string
+		wantPages []string
+	}{
+		{name: "window across pages", since: day(4), until: day(6), limit: 30, want: "c5 c4", wantPages: []string{"", "2", "3"}},
+		{name: "reads past older merged commits", since: day(2), until: day(6), limit: 30, want: "c5 c4 c3 c2", wantPages: []string{"", "2", "3"}},
+		{name: "stops at the limit", until: day(6), limit: 2, want: "c5 c4", wantPages: []string{"", "2"}},
+		{name: "reads to the last page", until: day(3), limit: 30, want: "c1 c2", wantPages: []string{"", "2", "3"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clear(requested)
+			first, err := client.ListCommits(context.Background(), "ws", "repo", nil)
+			if err != nil {
+				t.Fatalf("ListCommits() error: %v", err)
+			}
+			result, err := collectCommits(context.Background(), client, first, tt.since, tt.until, tt.limit)
+			if err != nil {
+				t.Fatalf("collectCommits() error: %v", err)
+			}
+			var hashes []string
+			for _, c := range result.Values {
+				hashes = append(hashes, c.Hash)
+			}
+			if got := strings.Join(hashes, " "); got != tt.want {
+				t.Errorf("commits = %q, want %q", got, tt.want)
+			}
+			if len(requested) != len(tt.wantPages) {
+				t.Errorf("requested pages %v, want %q", requested, tt.wantPages)
+			}
+			for _, p := range tt.wantPages {
+				if !requested[p] {
+					t.Errorf("page %q was not requested", p)
+				}
+			}
+		})
+	}
+}
+
+func TestCommitGraph(t *testing.T) {
+	commit := func(hash, message string, parents ...string) api.Commit {
+		c := api.Commit{Hash: hash, Message: message}
+		for _, p := range parents {
+			c.Parents = append(c.Parents, api.Commit{Hash: p})
+		}
+		return c
+	}
+	commits := []api.Commit{
+		commit("mmmmmmm1", "Merge feature", "aaaaaaa1", "bbbbbbb1"),
+		commit("aaaaaaa1", "Fix typo", "ccccccc1"),
+		commit("bbbbbbb1", "Add feature\n\nDetails", "ccccccc1"),
+		commit("ccccccc1", "Initial commit"),
+	}
+
+	want := []string{
+		"* mmmmmmm Merge feature",
+		`|\`,
+		"* | aaaaaaa Fix typo",
+		"| * bbbbbbb Add feature",
+		"|/",
+		"* ccccccc Initial commit",
+	}
+	got := commitGraph(commits)
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("commitGraph() =\n%s\nwant\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+}
@@ -0,0 +1,131 @@
+package repo
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/rbansal42/bitbucket-cli/internal/cmdutil"
+	"github.com/rbansal42/bitbucket-cli/internal/iostreams"
+)
+
+type compareOptions struct {
+	streams *iostreams.IOStreams
+	repo    string
+	stat    bool
+	noColor bool
+	output  string
+}
+
+// NewCmdCompare creates the repo compare command
+func NewCmdCompare(streams *iostreams.IOStreams) *cobra.Command {
+	opts := &compareOptions{
+		streams: streams,
+	}
+
+	cmd := &cobra.Command{
+		Use:   "compare <base>..<head>",
+		Short: "Show the diff between two branches, tags or commits",
+		Long: `Show the changes on head since it diverged from base, as a pull request
+from head into base would show them. Either side can be a branch, tag or
+commit; "..." may be used in place of "..".
+
+This is the counterpart of 'bb pr diff' for comparisons without a pull
+request. Color output is enabled by default when stdout is a terminal.`,
+		Example: `  # Compare a feature branch with main
+  bb repo compare main..feature/login
+
+  # Show a per-file summary of the changes since a release
+  bb repo compare v1.2.0..main --stat
+
+  # Write the diff to a file
+  bb repo compare main..develop --output changes.diff`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runCompare(cmd.Context(), opts, args[0])
+		},
+	}
+
+	cmd.Flags().BoolVar(&opts.stat, "stat", false, "Show a summary of changed files instead of the full diff")
+	cmd.Flags().BoolVar(&opts.noColor, "no-color", false, "Disable color output")
+	cmd.Flags().StringVarP(&opts.output, "output", "o", "", "Write the diff to a file instead of standard output")
+	cmd.Flags().StringVarP(&opts.repo, "repo", "R", "", "Repository in WORKSPACE/REPO format")
+
+	_ = cmd.RegisterFlagCompletionFunc("repo", cmdutil.CompleteRepoNames)
+
+	return cmd
+}
+
+// parseCompareRange splits a base..head or base...head argument
+func parseCompareRange(arg string) (base, head string, err error) {
+	base, head, found := strings.Cut(arg, "...")
+	if !found {
+		base, head, found = strings.Cut(arg, "..")
+	}
+	base, head = strings.TrimSpace(base), strings.TrimSpace(head)
+	if !found || base == "" || head == "" {
+		return "", "", fmt.Errorf("invalid range %q: expected <base>..<head>", arg)
+	}
+	return base, head, nil
+}
+
+func runCompare(ctx context.Context, opts *compareOptions, arg string) error {
+	base, head, err := parseCompareRange(arg)
+	if err != nil {
+		return err
+	}
+
+	workspace, repoSlug, err := cmdutil.ParseRepository(opts.repo)
+	if err != nil {
+		return err
+	}
+
+	client, err := cmdutil.GetAPIClient()
+	if err != nil {
+		return err
+	}
+
+	// Bitbucket's spec names the changes first and what they are compared
+	// with second, the reverse of git
+	spec := head + ".." + base
+	useColor := cmdutil.IsStdoutPath(opts.output) && opts.streams.IsStdoutTTY() && !opts.noColor
+
+	if opts.stat {
+		entries, err := client.GetDiffStat(ctx, workspace, repoSlug, spec)
+		if err != nil {
+			return fmt.Errorf("failed to get diffstat: %w", err)
+		}
+		if len(entries) == 0 {
+			opts.streams.Info("No differences between %s and %s", base, head)
+			return nil
+		}
+		out, err := cmdutil.OutputWriter(opts.streams, opts.output)
+		if err != nil {
+			return err
+		}
+		if _, err := io.WriteString(out, cmdutil.FormatDiffStat(entries, useColor)); err != nil {
+			out.Abort()
+			return fmt.Errorf("failed to write diffstat: %w", err)
+		}
+		return out.Close()
+	}
+
+	diff, err := client.GetDiff(ctx, workspace, repoSlug, spec)
+	if err != nil {
+		return fmt.Errorf("failed to fetch diff: %w", err)
+	}
+	defer diff.Close()
+
+	out, err := cmdutil.OutputWriter(opts.streams, opts.output)
+	if err != nil {
+		return err
+	}
+	if err := cmdutil.CopyDiff(out, diff, useColor); err != nil {
+		out.Abort()
+		return fmt.Errorf("failed to read diff: %w", err)
+	}
+	return out.Close()
+}
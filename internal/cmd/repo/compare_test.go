@@ -0,0 +1,37 @@
+package repo
+
+import "testing"
+
+func TestParseCompareRange(t *testing.T) {
+	tests := []struct {
+		arg      string
+		wantBase string
+		wantHead string
+		wantErr  bool
+	}{
+		{arg: "main..feature/login", wantBase: "main", wantHead: "feature/login"},
+		{arg: "v1.2.0...main", wantBase: "v1.2.0", wantHead: "main"},
+		{arg: "abc123..def456", wantBase: "abc123", wantHead: "def456"},
+		{arg: "main", wantErr: true},
+		{arg: "..main", wantErr: true},
+		{arg: "main..", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.arg, func(t *testing.T) {
+			base, head, err := parseCompareRange(tt.arg)
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("expected an error, got %q..%q", base, head)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if base != tt.wantBase || head != tt.wantHead {
+				t.Errorf("got %q..%q, want %q..%q", base, head, tt.wantBase, tt.wantHead)
+			}
+		})
+	}
+}
@@ -1,11 +1,12 @@
 package repo
 
 import (
-	"bufio"
 	"context"
+	"errors"
 	"fmt"
-	"io"
-	"os"
+	"net/http"
+	"os/exec"
+	"path/filepath"
 	"strings"
 	"time"
 
@@ -27,7 +28,10 @@ type createOptions struct {
 	workspace   string
 	project     string
 	clone       bool
+	source      string
 	gitignore   string
+	template    string
+	squash      bool
 }
 
 // NewCmdCreate creates the repo create command
@@ -38,12 +42,28 @@ func NewCmdCreate(streams *iostreams.IOStreams) *cobra.Command {
 	}
 
 	cmd := &cobra.Command{
-		Use:   "create [<name>]",
+		Use:   "create [<workspace>/]<name>",
 		Short: "Create a new repository",
 		Long: `Create a new repository in a Bitbucket workspace.
 
-The repository name can be provided as an argument or with the --name flag.
-If no name is provided, you will be prompted to enter one interactively.
+The repository name can be provided as an argument, optionally prefixed
+with the workspace, or with the --name flag. If no name is provided, you will
+be prompted to enter one interactively, unless --source is given, in which
+case the directory name is used.
+
+Use --clone to clone the new repository, or --source to publish an existing
+local repository: the new repository is added to it as the "origin" remote
+and the current branch is pushed.
+
+Use --from-template to seed the new repository from another one: its
+default branch is cloned to a temporary directory and pushed to the new
+repository. Add --squash-template to replace the template's history with a
+single commit. An existing repository is only reused if it is empty.
+
+Without --project, the repository goes into the project set by the
+default_project key of a .bb.yml file in the current directory or
+repository root, or else by 'bb config set default_project'. bb checks that
+the project exists in the workspace before creating the repository.
 
 By default, repositories are created as private. Use --public to create
 a public repository instead.`,
@@ -63,14 +83,43 @@ a public repository instead.`,
   bb repo create myrepo -w myworkspace
 
   # Create and clone the repository
-  bb repo create myrepo --clone
+  bb repo create myworkspace/myrepo --clone
+
+  # Publish the repository in the current directory
+  bb repo create myrepo --source .
 
   # Create a repository in a project
-  bb repo create myrepo -p PROJ`,
+  bb repo create myrepo -p PROJ
+
+  # Create repositories in the PROJ project unless --project is given
+  bb config set default_project PROJ
+
+  # Create a repository from a template, without its history
+  bb repo create myworkspace/service --from-template myworkspace/service-template --squash-template`,
 		Args: cobra.MaximumNArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			if len(args) > 0 {
-				opts.name = args[0]
+				workspace, name, err := splitCreateName(args[0])
+				if err != nil {
+					return err
+				}
+				if workspace != "" && opts.workspace != "" && workspace != opts.workspace {
+					return fmt.Errorf("workspace %q in the repository name conflicts with --workspace %q", workspace, opts.workspace)
+				}
+				if workspace != "" {
+					opts.workspace = workspace
+				}
+				opts.name = name
+			}
+
+			if opts.clone && opts.source != "" {
+				return fmt.Errorf("cannot specify both --clone and --source")
+			}
+			if opts.template != "" && opts.source != "" {
+				return fmt.Errorf("cannot specify both --from-template and --source")
+			}
+			if opts.squash && opts.template == "" {
+				return fmt.Errorf("--squash-template requires --from-template")
 			}
 
 			// Handle conflicting flags - only error if both were explicitly set
@@ -96,14 +145,37 @@ a public repository instead.`,
 	cmd.Flags().StringVarP(&opts.workspace, "workspace", "w", "", "Workspace to create repository in")
 	cmd.Flags().StringVarP(&opts.project, "project", "p", "", "Project key to assign repository to")
 	cmd.Flags().BoolVarP(&opts.clone, "clone", "c", false, "Clone the repository after creation")
+	cmd.Flags().StringVar(&opts.source, "source", "", "Add the repository as origin of the local git repository at this path and push the current branch")
 	cmd.Flags().StringVar(&opts.gitignore, "gitignore", "", "Initialize with gitignore template")
+	cmd.Flags().StringVar(&opts.template, "from-template", "", "Seed the repository from the default branch of this `WORKSPACE/REPO`")
+	cmd.Flags().BoolVar(&opts.squash, "squash-template", false, "Replace the template's history with a single commit")
 
 	_ = cmd.RegisterFlagCompletionFunc("workspace", cmdutil.CompleteWorkspaceNames)
+	_ = cmd.RegisterFlagCompletionFunc("from-template", cmdutil.CompleteRepoNames)
 
 	return cmd
 }
 
 func runCreate(opts *createOptions) error {
+	// Check the source repository before creating anything, so a mistake
+	// doesn't leave an empty repository behind
+	var sourceBranch string
+	if opts.source != "" {
+		branch, err := checkSourceRepo(opts.source)
+		if err != nil {
+			return err
+		}
+		sourceBranch = branch
+
+		if opts.name == "" {
+			abs, err := filepath.Abs(opts.source)
+			if err != nil {
+				return fmt.Errorf("could not resolve %s: %w", opts.source, err)
+			}
+			opts.name = filepath.Base(abs)
+		}
+	}
+
 	// Get authenticated client
 	client, err := cmdutil.GetAPIClient()
 	if err != nil {
@@ -131,11 +203,7 @@ func runCreate(opts *createOptions) error {
 
 	// Prompt for name if not provided
 	if opts.name == "" {
-		if !opts.streams.IsStdinTTY() {
-			return fmt.Errorf("repository name is required when not running interactively")
-		}
-
-		name, err := promptForName(opts.streams)
+		name, err := cmdutil.Prompt(opts.streams, "Repository name: ", "pass the repository name as an argument or with --name")
 		if err != nil {
 			return err
 		}
@@ -145,6 +213,15 @@ func runCreate(opts *createOptions) error {
 		opts.name = name
 	}
 
+	// Check the template and the target before creating anything
+	var template, existing *api.RepositoryFull
+	if opts.template != "" {
+		template, existing, err = prepareTemplate(ctx, client, opts.template, workspace, opts.name)
+		if err != nil {
+			return err
+		}
+	}
+
 	// Build create options
 	createOpts := &api.RepositoryCreateOptions{
 		Name:        opts.name,
@@ -152,26 +229,60 @@ func runCreate(opts *createOptions) error {
 		IsPrivate:   opts.private,
 	}
 
+	if opts.project == "" {
+		key, source, err := configuredDefaultProject()
+		if err != nil {
+			return err
+		}
+		if key != "" {
+			if err := checkDefaultProject(ctx, client, workspace, key, source); err != nil {
+				return err
+			}
+			opts.streams.Info("Using project %s from %s", key, source)
+			opts.project = key
+		}
+	}
 	if opts.project != "" {
 		createOpts.Project = &api.Project{Key: opts.project}
 	}
 
-	opts.streams.Info("Creating repository %s/%s...", workspace, opts.name)
+	repo := existing
+	if repo != nil {
+		opts.streams.Info("Using existing empty repository %s", repo.FullName)
+	} else {
+		opts.streams.Info("Creating repository %s/%s...", workspace, opts.name)
 
-	// Create the repository
-	repo, err := client.CreateRepository(ctx, workspace, createOpts)
-	if err != nil {
-		return fmt.Errorf("failed to create repository: %w", err)
-	}
+		// Create the repository
+		repo, err = client.CreateRepository(ctx, workspace, createOpts)
+		if err != nil {
+			return fmt.Errorf("failed to create repository: %w", err)
+		}
 
-	// Success message
-	opts.streams.Success("Created repository %s", repo.FullName)
+		// Success message
+		opts.streams.Success("Created repository %s", repo.FullName)
+	}
 	fmt.Fprintln(opts.streams.Out)
 
 	// Get preferred protocol for clone URL
-	protocol := getPreferredProtocol()
+	protocol := getPreferredProtocol(repoHost(repo.Links), "")
 	cloneURL := getCloneURL(repo.Links, protocol)
 	fmt.Fprintf(opts.streams.Out, "Clone URL: %s\n", cloneURL)
+	if repo.Links.HTML.Href != "" {
+		fmt.Fprintf(opts.streams.Out, "URL:       %s\n", repo.Links.HTML.Href)
+	}
+
+	if opts.source != "" {
+		fmt.Fprintln(opts.streams.Out)
+		return publishSource(opts, cloneURL, sourceBranch)
+	}
+
+	if template != nil {
+		fmt.Fprintln(opts.streams.Out)
+		templateURL := getCloneURL(template.Links, protocol)
+		if err := seedFromTemplate(opts.streams, template.FullName, templateURL, cloneURL, opts.squash); err != nil {
+			return err
+		}
+	}
 
 	// Clone if requested
 	if opts.clone {
@@ -188,13 +299,114 @@ func runCreate(opts *createOptions) error {
 	return nil
 }
 
+// splitCreateName splits a "workspace/name" argument. The workspace is
+// empty when arg is a plain name.
+func splitCreateName(arg string) (workspace, name string, err error) {
+	workspace, name, found := strings.Cut(arg, "/")
+	if !found {
+		return "", arg, nil
+	}
+	if workspace == "" || name == "" || strings.Contains(name, "/") {
+		return "", "", fmt.Errorf("invalid repository name %q: expected NAME or WORKSPACE/NAME", arg)
+	}
+	return workspace, name, nil
+}
+
+// checkSourceRepo verifies that dir is a git repository without an origin
+// remote and returns its current branch, or "" if nothing has been
+// committed yet
+func checkSourceRepo(dir string) (string, error) {
+	if err := exec.Command("git", "-C", dir, "rev-parse", "--git-dir").Run(); err != nil {
+		return "", fmt.Errorf("%s is not a git repository", dir)
+	}
+	if err := exec.Command("git", "-C", dir, "remote", "get-url", "origin").Run(); err == nil {
+		return "", fmt.Errorf("%s already has an \"origin\" remote", dir)
+	}
+
+	out, err := exec.Command("git", "-C", dir, "rev-parse", "--abbrev-ref", "HEAD").Output()
+	if err != nil {
+		// An unborn branch: there is nothing to push yet
+		return "", nil
+	}
+	branch := strings.TrimSpace(string(out))
+	if branch == "HEAD" {
+		return "", fmt.Errorf("%s has a detached HEAD; check out a branch to push", dir)
+	}
+	return branch, nil
+}
+
+// publishSource adds the new repository as origin of the source repository
+// and pushes its current branch
+func publishSource(opts *createOptions, cloneURL, branch string) error {
+	if err := exec.Command("git", "-C", opts.source, "remote", "add", "origin", cloneURL).Run(); err != nil {
+		return fmt.Errorf("failed to add remote origin: %w", err)
+	}
+	opts.streams.Success("Added remote origin")
+
+	if branch == "" {
+		opts.streams.Info("Nothing to push yet; commit and run 'git push -u origin HEAD'")
+		return nil
+	}
+
+	opts.streams.Info("Pushing %s...", branch)
+	push := exec.Command("git", "-C", opts.source, "push", "--set-upstream", "origin", branch)
+	push.Stdout = opts.streams.ErrOut
+	push.Stderr = opts.streams.ErrOut
+	if err := push.Run(); err != nil {
+		return fmt.Errorf("failed to push %s: %w", branch, err)
+	}
+
+	opts.streams.Success("Pushed %s to origin", branch)
+	return nil
+}
+
+// configuredDefaultProject returns the default project key from .bb.yml in
+// the current directory or repository root, or else from the global config,
+// along with where it was found. It returns an empty key if none is set.
+func configuredDefaultProject() (string, string, error) {
+	key, path, err := cmdutil.LocalConfigValue(func(c *cmdutil.LocalConfig) string { return c.DefaultProject })
+	if err != nil || key != "" {
+		return key, path, err
+	}
+
+	if key, err := config.GetDefaultProject(); err == nil && key != "" {
+		return key, "bb config", nil
+	}
+	return "", "", nil
+}
+
+// checkDefaultProject makes sure a configured default project exists in
+// the workspace, so a stale setting is reported with the projects to choose
+// from rather than as a failed creation
+func checkDefaultProject(ctx context.Context, client *api.Client, workspace, key, source string) error {
+	_, err := client.GetProject(ctx, workspace, key)
+	if err == nil {
+		return nil
+	}
+
+	var apiErr *api.APIError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusNotFound {
+		return fmt.Errorf("failed to check default project %s: %w", key, err)
+	}
+
+	msg := fmt.Sprintf("default_project %s from %s does not exist in workspace %s", key, source, workspace)
+	if projects, err := client.GetWorkspaceProjects(ctx, workspace); err == nil && len(projects) > 0 {
+		keys := make([]string, len(projects))
+		for i, p := range projects {
+			keys[i] = p.Key
+		}
+		msg += fmt.Sprintf(" (projects: %s)", strings.Join(keys, ", "))
+	}
+	return fmt.Errorf("%s; pass --project or change the default", msg)
+}
+
 // getDefaultWorkspace attempts to get the default workspace for the user
 func getDefaultWorkspace(ctx context.Context, client *api.Client, streams *iostreams.IOStreams) (string, error) {
 	// First, try to get from hosts config (active user)
 	hosts, err := config.LoadHostsConfig()
 	if err == nil {
 		// Get active user's workspace (often same as username)
-		user := hosts.GetActiveUser(config.DefaultHost)
+		user := hosts.GetActiveUser(cmdutil.Host(hosts))
 		if user != "" {
 			// Try to use username as workspace (common pattern)
 			return user, nil
@@ -202,7 +414,7 @@ func getDefaultWorkspace(ctx context.Context, client *api.Client, streams *iostr
 	}
 
 	// Try to get current user from API and use their workspace
-	user, err := client.GetCurrentUser(ctx)
+	user, err := client.CurrentUser(ctx)
 	if err != nil {
 		return "", fmt.Errorf("could not get current user: %w", err)
 	}
@@ -213,22 +425,3 @@ func getDefaultWorkspace(ctx context.Context, client *api.Client, streams *iostr
 
 	return "", fmt.Errorf("could not determine default workspace")
 }
-
-// promptForName prompts the user to enter a repository name
-func promptForName(streams *iostreams.IOStreams) (string, error) {
-	fmt.Fprint(streams.Out, "Repository name: ")
-
-	var reader *bufio.Reader
-	if r, ok := streams.In.(io.Reader); ok {
-		reader = bufio.NewReader(r)
-	} else {
-		reader = bufio.NewReader(os.Stdin)
-	}
-
-	name, err := reader.ReadString('\n')
-	if err != nil {
-		return "", err
-	}
-
-	return strings.TrimSpace(name), nil
-}
@@ -0,0 +1,106 @@
+package repo
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os/exec"
+	"strings"
+	"testing"
+
+	"github.com/rbansal42/bitbucket-cli/internal/api"
+)
+
+func TestSplitCreateName(t *testing.T) {
+	tests := []struct {
+		arg           string
+		wantWorkspace string
+		wantName      string
+		wantErr       bool
+	}{
+		{arg: "myrepo", wantName: "myrepo"},
+		{arg: "ws/myrepo", wantWorkspace: "ws", wantName: "myrepo"},
+		{arg: "/myrepo", wantErr: true},
+		{arg: "ws/", wantErr: true},
+		{arg: "ws/a/b", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		ws, name, err := splitCreateName(tt.arg)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("splitCreateName(%q) error = %v, wantErr %v", tt.arg, err, tt.wantErr)
+			continue
+		}
+		if ws != tt.wantWorkspace || name != tt.wantName {
+			t.Errorf("splitCreateName(%q) = %q, %q; want %q, %q", tt.arg, ws, name, tt.wantWorkspace, tt.wantName)
+		}
+	}
+}
+
+func TestCheckSourceRepo(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not available")
+	}
+
+	git := func(dir string, args ...string) {
+		t.Helper()
+		cmd := exec.Command("git", append([]string{"-C", dir}, args...)...)
+		cmd.Env = append(cmd.Environ(), "GIT_AUTHOR_NAME=t", "GIT_AUTHOR_EMAIL=t@example.com", "GIT_COMMITTER_NAME=t", "GIT_COMMITTER_EMAIL=t@example.com")
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v: %v\n%s", args, err, out)
+		}
+	}
+
+	if _, err := checkSourceRepo(t.TempDir()); err == nil {
+		t.Error("checkSourceRepo() expected an error outside a git repository")
+	}
+
+	dir := t.TempDir()
+	git(dir, "init", "-q", "-b", "trunk")
+	branch, err := checkSourceRepo(dir)
+	if err != nil || branch != "" {
+		t.Errorf("checkSourceRepo(unborn) = %q, %v; want no branch to push", branch, err)
+	}
+
+	git(dir, "commit", "-q", "--allow-empty", "-m", "initial")
+	branch, err = checkSourceRepo(dir)
+	if err != nil || branch != "trunk" {
+		t.Errorf("checkSourceRepo() = %q, %v; want trunk", branch, err)
+	}
+
+	git(dir, "remote", "add", "origin", "https://example.com/repo.git")
+	if _, err := checkSourceRepo(dir); err == nil {
+		t.Error("checkSourceRepo() expected an error when origin already exists")
+	}
+}
+
+func TestCheckDefaultProject(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/workspaces/ws/projects/CORE":
+			fmt.Fprint(w, `{"key": "CORE"}`)
+		case "/workspaces/ws/projects":
+			fmt.Fprint(w, `{"values": [{"key": "CORE"}, {"key": "WEB"}]}`)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			fmt.Fprint(w, `{"type": "error", "error": {"message": "not found"}}`)
+		}
+	}))
+	defer server.Close()
+	client := api.NewClient(api.WithBaseURL(server.URL), api.WithToken("test-token"))
+
+	if err := checkDefaultProject(context.Background(), client, "ws", "CORE", "bb config"); err != nil {
+		t.Errorf("checkDefaultProject(CORE) error: %v", err)
+	}
+
+	err := checkDefaultProject(context.Background(), client, "ws", "OLD", ".bb.yml")
+	if err == nil {
+		t.Fatal("checkDefaultProject(OLD) succeeded, want an error")
+	}
+	for _, want := range []string{"default_project OLD from .bb.yml", "workspace ws", "projects: CORE, WEB", "--project"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("error %q doesn't mention %q", err, want)
+		}
+	}
+}
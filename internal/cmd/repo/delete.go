@@ -3,20 +3,24 @@ package repo
 import (
 	"context"
 	"fmt"
+	"io"
 	"time"
 
 	"github.com/spf13/cobra"
 
+	"github.com/rbansal42/bitbucket-cli/internal/api"
 	"github.com/rbansal42/bitbucket-cli/internal/cmdutil"
 	"github.com/rbansal42/bitbucket-cli/internal/iostreams"
 )
 
 type deleteOptions struct {
-	streams   *iostreams.IOStreams
-	repoArg   string
-	yes       bool
-	workspace string
-	repoSlug  string
+	streams     *iostreams.IOStreams
+	repoArg     string
+	repoFlag    string
+	yes         bool
+	confirmName string
+	workspace   string
+	repoSlug    string
 }
 
 // NewCmdDelete creates the delete command
@@ -33,49 +37,54 @@ func NewCmdDelete(streams *iostreams.IOStreams) *cobra.Command {
 WARNING: This action cannot be undone. The repository and all its data
 (commits, branches, pull requests, issues, etc.) will be permanently deleted.
 
-You will be prompted to type the repository name to confirm deletion,
-unless the --yes flag is provided.`,
+The repository must be named explicitly, as an argument or with --repo; it
+is never taken from the current directory. Its size and last update are
+shown, and you must type its full WORKSPACE/REPO name to confirm.
+
+In scripts, pass --yes together with --confirm-name set to the full
+repository name instead.`,
 		Example: `  # Delete a repository (will prompt for confirmation)
   bb repo delete myworkspace/myrepo
 
-  # Delete without confirmation prompt
-  bb repo delete myworkspace/myrepo --yes`,
-		Args: cobra.ExactArgs(1),
+  # Delete without a prompt, e.g. in a script
+  bb repo delete myworkspace/myrepo --yes --confirm-name myworkspace/myrepo`,
+		Args: cobra.MaximumNArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			opts.repoArg = args[0]
+			if len(args) > 0 {
+				opts.repoArg = args[0]
+			}
 			return runDelete(opts)
 		},
 	}
 
-	cmd.Flags().BoolVarP(&opts.yes, "yes", "y", false, "Skip confirmation prompt")
+	cmd.Flags().StringVarP(&opts.repoFlag, "repo", "R", "", "Repository to delete in WORKSPACE/REPO format")
+	cmd.Flags().BoolVarP(&opts.yes, "yes", "y", false, "Skip the confirmation prompt; requires --confirm-name")
+	cmd.Flags().StringVar(&opts.confirmName, "confirm-name", "", "Full WORKSPACE/REPO name of the repository, to confirm --yes")
 
 	cmd.ValidArgsFunction = cmdutil.CompleteRepoNames
+	_ = cmd.RegisterFlagCompletionFunc("repo", cmdutil.CompleteRepoNames)
 
 	return cmd
 }
 
 func runDelete(opts *deleteOptions) error {
-	// Parse the repository argument
-	var err error
-	opts.workspace, opts.repoSlug, err = cmdutil.ParseRepository(opts.repoArg)
+	repoArg, err := deleteTarget(opts.repoArg, opts.repoFlag)
 	if err != nil {
 		return err
 	}
 
-	// If not auto-confirmed, show warning and prompt
-	if !opts.yes {
-		// Require TTY for interactive confirmation
-		if !opts.streams.IsStdinTTY() {
-			return fmt.Errorf("cannot confirm deletion: stdin is not a terminal\nUse --yes flag to skip confirmation in non-interactive mode")
-		}
-
-		printDeleteWarning(opts.streams.ErrOut)
-
-		fmt.Fprintf(opts.streams.Out, "Type '%s' to confirm deletion: ", opts.repoSlug)
+	// Parse the repository argument
+	opts.workspace, opts.repoSlug, err = cmdutil.ParseRepository(repoArg)
+	if err != nil {
+		return err
+	}
+	fullName := opts.workspace + "/" + opts.repoSlug
 
-		if !confirmDeletion(opts.repoSlug, opts.streams.In) {
-			return fmt.Errorf("deletion cancelled: repository name did not match")
-		}
+	if err := checkDeleteBypass(opts.yes, opts.confirmName, fullName); err != nil {
+		return err
+	}
+	if !opts.yes && !opts.streams.CanPrompt() {
+		return &cmdutil.NoPromptError{Hint: "pass --yes --confirm-name " + fullName}
 	}
 
 	// Get authenticated client
@@ -87,11 +96,71 @@ func runDelete(opts *deleteOptions) error {
 	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
 	defer cancel()
 
+	// Look the repository up first, so a typo fails before any prompt and
+	// the user sees what they are about to delete
+	repo, err := client.GetRepository(ctx, opts.workspace, opts.repoSlug)
+	if err != nil {
+		return fmt.Errorf("failed to get repository: %w", err)
+	}
+
+	printDeleteSummary(opts.streams.ErrOut, repo)
+
+	// If not auto-confirmed, show warning and prompt
+	if !opts.yes {
+		printDeleteWarning(opts.streams.ErrOut)
+
+		confirmed, err := cmdutil.ConfirmByTyping(opts.streams, fullName)
+		if err != nil {
+			return err
+		}
+		if !confirmed {
+			return fmt.Errorf("deletion cancelled: repository name did not match")
+		}
+	}
+
 	// Delete the repository
 	if err := client.DeleteRepository(ctx, opts.workspace, opts.repoSlug); err != nil {
 		return fmt.Errorf("failed to delete repository: %w", err)
 	}
 
-	opts.streams.Success("Deleted repository %s/%s", opts.workspace, opts.repoSlug)
+	opts.streams.Success("Deleted repository %s", fullName)
+	return nil
+}
+
+// deleteTarget returns the repository named by the argument or --repo. One of
+// them is required: unlike other commands, delete never falls back to the
+// repository of the current directory.
+func deleteTarget(arg, flag string) (string, error) {
+	switch {
+	case arg != "" && flag != "" && arg != flag:
+		return "", cmdutil.NewFlagError(fmt.Errorf("repository given twice: %s and --repo %s", arg, flag))
+	case arg != "":
+		return arg, nil
+	case flag != "":
+		return flag, nil
+	default:
+		return "", cmdutil.NewFlagError(fmt.Errorf("specify the repository to delete as an argument or with --repo"))
+	}
+}
+
+// checkDeleteBypass validates --yes and --confirm-name: skipping the prompt
+// needs both, and the name must be the repository's full name
+func checkDeleteBypass(yes bool, confirmName, fullName string) error {
+	if yes && confirmName == "" {
+		return cmdutil.NewFlagError(fmt.Errorf("--yes requires --confirm-name %s", fullName))
+	}
+	if confirmName != "" && confirmName != fullName {
+		return cmdutil.NewFlagError(fmt.Errorf("--confirm-name %q does not match %s", confirmName, fullName))
+	}
 	return nil
 }
+
+// printDeleteSummary describes the repository about to be deleted
+func printDeleteSummary(w io.Writer, repo *api.RepositoryFull) {
+	fmt.Fprintf(w, "Repository:   %s\n", repo.FullName)
+	fmt.Fprintf(w, "Size:         %s\n", formatSize(repo.Size))
+	if !repo.UpdatedOn.IsZero() {
+		fmt.Fprintf(w, "Last updated: %s (%s)\n", repo.UpdatedOn.Format("Jan 02, 2006"), cmdutil.TimeAgo(repo.UpdatedOn))
+	}
+	fmt.Fprintln(w)
+}
@@ -114,59 +114,66 @@ func TestGetRepoName(t *testing.T) {
 	}
 }
 
-func TestConfirmDeletion(t *testing.T) {
+func TestDeleteWarningMessage(t *testing.T) {
+	var buf bytes.Buffer
+	printDeleteWarning(&buf)
+
+	output := buf.String()
+	
+	// Should contain warning about permanent deletion
+	if !strings.Contains(output, "cannot be undone") {
+		t.Errorf("warning should mention deletion cannot be undone, got: %s", output)
+	}
+}
+
+func TestDeleteTarget(t *testing.T) {
 	tests := []struct {
-		name         string
-		repoName     string
-		input        string
-		wantConfirm  bool
+		name    string
+		arg     string
+		flag    string
+		want    string
+		wantErr bool
 	}{
-		{
-			name:        "correct confirmation",
-			repoName:    "myrepo",
-			input:       "myrepo\n",
-			wantConfirm: true,
-		},
-		{
-			name:        "wrong input",
-			repoName:    "myrepo",
-			input:       "wrongname\n",
-			wantConfirm: false,
-		},
-		{
-			name:        "empty input",
-			repoName:    "myrepo",
-			input:       "\n",
-			wantConfirm: false,
-		},
-		{
-			name:        "extra whitespace in input",
-			repoName:    "myrepo",
-			input:       "  myrepo  \n",
-			wantConfirm: true,
-		},
+		{name: "argument", arg: "ws/repo", want: "ws/repo"},
+		{name: "flag", flag: "ws/repo", want: "ws/repo"},
+		{name: "same in both", arg: "ws/repo", flag: "ws/repo", want: "ws/repo"},
+		{name: "different in both", arg: "ws/repo", flag: "ws/other", wantErr: true},
+		{name: "never from context", wantErr: true},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			reader := strings.NewReader(tt.input)
-			confirmed := confirmDeletion(tt.repoName, reader)
-
-			if confirmed != tt.wantConfirm {
-				t.Errorf("confirmDeletion() = %v, want %v", confirmed, tt.wantConfirm)
+			got, err := deleteTarget(tt.arg, tt.flag)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("deleteTarget() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("deleteTarget() = %q, want %q", got, tt.want)
 			}
 		})
 	}
 }
 
-func TestDeleteWarningMessage(t *testing.T) {
-	var buf bytes.Buffer
-	printDeleteWarning(&buf)
+func TestCheckDeleteBypass(t *testing.T) {
+	tests := []struct {
+		name        string
+		yes         bool
+		confirmName string
+		wantErr     bool
+	}{
+		{name: "prompt"},
+		{name: "yes with matching name", yes: true, confirmName: "ws/repo"},
+		{name: "yes without name", yes: true, wantErr: true},
+		{name: "yes with slug only", yes: true, confirmName: "repo", wantErr: true},
+		{name: "mismatched name without yes", confirmName: "ws/other", wantErr: true},
+	}
 
-	output := buf.String()
-	
-	// Should contain warning about permanent deletion
-	if !strings.Contains(output, "cannot be undone") {
-		t.Errorf("warning should mention deletion cannot be undone, got: %s", output)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := checkDeleteBypass(tt.yes, tt.confirmName, "ws/repo")
+			if (err != nil) != tt.wantErr {
+				t.Errorf("checkDeleteBypass() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
 	}
 }
@@ -111,7 +111,7 @@ func runFork(opts *forkOptions) error {
 	}
 	if destWorkspace == "" {
 		// Try to get current user's workspace
-		user, err := client.GetCurrentUser(ctx)
+		user, err := client.CurrentUser(ctx)
 		if err != nil {
 			return fmt.Errorf("could not determine destination workspace: %w\nUse --workspace to specify or run 'bb workspace set-default'", err)
 		}
@@ -143,7 +143,7 @@ func runFork(opts *forkOptions) error {
 		fmt.Fprintln(opts.streams.Out)
 		opts.streams.Info("Cloning fork...")
 
-		protocol := getPreferredProtocol()
+		protocol := getPreferredProtocol(repoHost(fork.Links), "")
 		cloneURL := getCloneURL(fork.Links, protocol)
 
 		if err := git.Clone(cloneURL, forkName); err != nil {
@@ -153,7 +153,7 @@ func runFork(opts *forkOptions) error {
 		opts.streams.Success("Cloned to %s/", forkName)
 
 		// Optionally add the original repo as upstream remote
-		if err := addUpstreamRemote(forkName, workspace, repoSlug); err != nil {
+		if err := addUpstreamRemote(forkName, repoHost(fork.Links), workspace, repoSlug); err != nil {
 			opts.streams.Warning("Could not add upstream remote: %v", err)
 		} else {
 			opts.streams.Success("Added upstream remote for %s/%s", workspace, repoSlug)
@@ -161,7 +161,7 @@ func runFork(opts *forkOptions) error {
 
 	} else if inExistingRepo && opts.remoteName != "" {
 		// Add the fork as a new remote in the existing repo
-		protocol := getPreferredProtocol()
+		protocol := getPreferredProtocol(repoHost(fork.Links), "")
 		cloneURL := getCloneURL(fork.Links, protocol)
 
 		fmt.Fprintln(opts.streams.Out)
@@ -180,13 +180,13 @@ func runFork(opts *forkOptions) error {
 }
 
 // addUpstreamRemote adds the original repository as an "upstream" remote
-func addUpstreamRemote(repoDir, workspace, repoSlug string) error {
-	protocol := getPreferredProtocol()
+func addUpstreamRemote(repoDir, host, workspace, repoSlug string) error {
+	protocol := getPreferredProtocol(host, "")
 	var upstreamURL string
 	if protocol == "ssh" {
-		upstreamURL = fmt.Sprintf("git@bitbucket.org:%s/%s.git", workspace, repoSlug)
+		upstreamURL = fmt.Sprintf("git@%s:%s/%s.git", host, workspace, repoSlug)
 	} else {
-		upstreamURL = fmt.Sprintf("https://bitbucket.org/%s/%s.git", workspace, repoSlug)
+		upstreamURL = fmt.Sprintf("https://%s/%s/%s.git", host, workspace, repoSlug)
 	}
 
 	cmd := exec.Command("git", "-C", repoDir, "remote", "add", "upstream", upstreamURL)
@@ -0,0 +1,122 @@
+package repo
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/rbansal42/bitbucket-cli/internal/api"
+	"github.com/rbansal42/bitbucket-cli/internal/cmdutil"
+	"github.com/rbansal42/bitbucket-cli/internal/iostreams"
+)
+
+type forksOptions struct {
+	streams   *iostreams.IOStreams
+	repoArg   string
+	sort      string
+	limit     int
+	jsonOut   bool
+	showCount bool
+	exitCode  bool
+}
+
+// NewCmdForks creates the repo forks command
+func NewCmdForks(streams *iostreams.IOStreams) *cobra.Command {
+	opts := &forksOptions{
+		streams: streams,
+	}
+
+	cmd := &cobra.Command{
+		Use:   "forks [<workspace/repo>]",
+		Short: "List forks of a repository",
+		Long: `List the forks of a repository, showing each fork's full name and owner.
+
+With no arguments, the repository for the current directory is used.
+Only forks you have access to are listed. Use 'bb repo fork' to create one.`,
+		Example: `  # List forks of the current repository, newest first
+  bb repo forks
+
+  # List forks of a specific repository sorted by name
+  bb repo forks myworkspace/myrepo --sort name
+
+  # Output as JSON
+  bb repo forks myworkspace/myrepo --json`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) > 0 {
+				opts.repoArg = args[0]
+			}
+			return runForks(cmd.Context(), opts)
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.sort, "sort", "-created_on", "Sort field, prefix with - for descending (e.g. -created_on, name)")
+	cmd.Flags().IntVarP(&opts.limit, "limit", "l", 30, "Maximum number of forks to list")
+	cmd.Flags().BoolVar(&opts.jsonOut, "json", false, "Output in JSON format")
+	cmdutil.AddShowCountFlag(cmd, &opts.showCount)
+	cmdutil.AddAllFlag(cmd, &opts.limit)
+	cmdutil.AddExitCodeFlag(cmd, &opts.exitCode)
+
+	cmd.ValidArgsFunction = cmdutil.CompleteRepoNames
+	_ = cmd.RegisterFlagCompletionFunc("sort", cmdutil.StaticFlagCompletion([]string{"-created_on", "created_on", "-updated_on", "updated_on", "name", "-name"}))
+
+	return cmd
+}
+
+func runForks(ctx context.Context, opts *forksOptions) error {
+	workspace, repoSlug, err := cmdutil.ParseRepository(opts.repoArg)
+	if err != nil {
+		return err
+	}
+
+	client, err := cmdutil.GetAPIClient()
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	result, err := client.ListRepositoryForks(ctx, workspace, repoSlug, &api.ForkListOptions{
+		Sort:  opts.sort,
+		Limit: cmdutil.PageLen(opts.limit, cmdutil.MaxPageLen),
+	})
+	if err == nil {
+		result, err = cmdutil.CollectPages(ctx, client, result, opts.limit)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to list forks: %w", err)
+	}
+
+	if len(result.Values) == 0 {
+		opts.streams.Info("%s/%s has no forks", workspace, repoSlug)
+		return cmdutil.NoResults(opts.exitCode)
+	}
+
+	var count *cmdutil.PageCount
+	if opts.showCount {
+		count = cmdutil.NewPageCount(result, len(result.Values))
+	}
+
+	if opts.jsonOut {
+		return cmdutil.PrintRepositoryListJSON(opts.streams, result.Values, count)
+	}
+
+	t := cmdutil.NewTableWriter(opts.streams, "NAME", "OWNER", "CREATED")
+	t.SetFlexColumn(0)
+	t.SetMaxWidth(1, 30)
+	for _, fork := range result.Values {
+		owner := "-"
+		if fork.Owner != nil {
+			owner = cmdutil.GetUserDisplayName(fork.Owner)
+		}
+		t.AddRow(fork.FullName, owner, cmdutil.TimeAgo(fork.CreatedOn))
+	}
+	if err := t.Render(); err != nil {
+		return err
+	}
+	cmdutil.PrintPageCount(opts.streams, count)
+	return nil
+}
@@ -1,27 +1,40 @@
 package repo
 
 import (
+	"cmp"
 	"context"
 	"fmt"
-	"text/tabwriter"
+	"slices"
+	"strings"
 
 	"github.com/spf13/cobra"
+	"golang.org/x/sync/errgroup"
 
 	"github.com/rbansal42/bitbucket-cli/internal/api"
 	"github.com/rbansal42/bitbucket-cli/internal/cmdutil"
-	"github.com/rbansal42/bitbucket-cli/internal/config"
 	"github.com/rbansal42/bitbucket-cli/internal/iostreams"
 )
 
 // ListOptions holds the options for the list command
 type ListOptions struct {
-	Workspace string
-	Limit     int
-	Sort      string
-	JSON      bool
-	Streams   *iostreams.IOStreams
+	Workspace     string
+	AllWorkspaces bool
+	Role          string
+	Writable      bool
+	Limit         int
+	Sort          string
+	SortSet       bool // --sort was given rather than defaulted
+	JSON          bool
+	ShowCount     bool
+	ExitCode      bool
+	Web           bool
+	NoBrowser     bool
+	Streams       *iostreams.IOStreams
 }
 
+// repositoryRoles are the values accepted by --role
+var repositoryRoles = []string{"owner", "admin", "contributor", "member"}
+
 // NewCmdList creates the repo list command
 func NewCmdList(streams *iostreams.IOStreams) *cobra.Command {
 	opts := &ListOptions{
@@ -34,7 +47,22 @@ func NewCmdList(streams *iostreams.IOStreams) *cobra.Command {
 		Long: `List repositories in a Bitbucket workspace.
 
 This command shows repositories you have access to in the specified workspace.
-By default, repositories are sorted by last updated time.`,
+By default, repositories are sorted by last updated time.
+
+With --all-workspaces, repositories from every workspace you belong to are
+listed together. Workspaces are queried in parallel and the results merged
+and sorted; a workspace that can't be listed is reported as a warning.
+
+--writable only lists repositories you can push to: those where your
+effective permission is write or admin, whether granted to you directly,
+through a group, or by the workspace. --role owner is narrower, and --role
+contributor only counts permissions granted to you explicitly. If your
+permissions can't be determined, every repository is listed with a
+warning.
+
+--web opens the repository page of the workspace in your browser instead,
+without calling the API. The URL is printed instead when --no-browser is
+given or the output is not a terminal.`,
 		Example: `  # List repositories in a workspace
   bb repo list --workspace myworkspace
 
@@ -45,28 +73,63 @@ By default, repositories are sorted by last updated time.`,
   bb repo list -w myworkspace --sort name
 
   # Output as JSON
-  bb repo list -w myworkspace --json`,
+  bb repo list -w myworkspace --json
+
+  # Show how many repositories were listed out of the total
+  bb repo list -w myworkspace --show-count
+
+  # List the repositories you administer across all your workspaces
+  bb repo list --all-workspaces --role admin --sort name
+
+  # List the repositories you can push to
+  bb repo list -w myworkspace --writable
+
+  # Open the workspace's repositories in the browser
+  bb repo list -w myworkspace --web`,
 		Aliases: []string{"ls"},
 		RunE: func(cmd *cobra.Command, args []string) error {
-			if opts.Workspace == "" {
-				defaultWs, err := config.GetDefaultWorkspace()
-				if err == nil && defaultWs != "" {
-					opts.Workspace = defaultWs
+			opts.SortSet = cmd.Flags().Changed("sort")
+			if opts.Role != "" && !slices.Contains(repositoryRoles, opts.Role) {
+				return fmt.Errorf("invalid role %q: must be one of %s", opts.Role, strings.Join(repositoryRoles, ", "))
+			}
+			if opts.AllWorkspaces {
+				if opts.Web {
+					return fmt.Errorf("--web opens a single workspace; use --workspace instead of --all-workspaces")
+				}
+				if opts.Workspace != "" {
+					return fmt.Errorf("cannot specify both --workspace and --all-workspaces")
 				}
+				return runList(cmd.Context(), opts)
+			}
+
+			ws, err := cmdutil.ResolveWorkspace(cmd)
+			if err != nil {
+				return err
 			}
-			if opts.Workspace == "" {
-				return fmt.Errorf("workspace is required. Use --workspace or -w to specify, or set a default with 'bb workspace set-default'")
+			opts.Workspace = ws
+			if opts.Web {
+				return cmdutil.OpenWeb(opts.Streams, cmdutil.WebURL(nil, ws, "workspace", "repositories"), opts.NoBrowser)
 			}
 			return runList(cmd.Context(), opts)
 		},
 	}
 
 	cmd.Flags().StringVarP(&opts.Workspace, "workspace", "w", "", "Workspace slug (required)")
+	cmd.Flags().BoolVar(&opts.AllWorkspaces, "all-workspaces", false, "List repositories from every workspace you belong to")
+	cmd.Flags().StringVar(&opts.Role, "role", "", "Only list repositories where you have this role (owner, admin, contributor, member)")
+	cmd.Flags().BoolVar(&opts.Writable, "writable", false, "Only list repositories you have write or admin permission on")
 	cmd.Flags().IntVarP(&opts.Limit, "limit", "l", 30, "Maximum number of repositories to list")
 	cmd.Flags().StringVarP(&opts.Sort, "sort", "s", "-updated_on", "Sort field (name, -updated_on)")
 	cmd.Flags().BoolVar(&opts.JSON, "json", false, "Output in JSON format")
+	cmdutil.AddShowCountFlag(cmd, &opts.ShowCount)
+	cmdutil.AddAllFlag(cmd, &opts.Limit)
+	cmdutil.AddExitCodeFlag(cmd, &opts.ExitCode)
+	cmd.Flags().BoolVar(&opts.Web, "web", false, "Open the workspace's repository list in the browser")
+	cmd.Flags().BoolVar(&opts.NoBrowser, "no-browser", false, "With --web, print the URL instead of opening the browser")
+	cmd.MarkFlagsMutuallyExclusive("web", "json")
 
 	_ = cmd.RegisterFlagCompletionFunc("workspace", cmdutil.CompleteWorkspaceNames)
+	_ = cmd.RegisterFlagCompletionFunc("role", cmdutil.StaticFlagCompletion(repositoryRoles))
 
 	return cmd
 }
@@ -80,78 +143,259 @@ func runList(ctx context.Context, opts *ListOptions) error {
 
 	// Build list options
 	listOpts := &api.RepositoryListOptions{
+		Role:  opts.Role,
 		Sort:  opts.Sort,
-		Limit: opts.Limit,
+		Limit: cmdutil.PageLen(opts.Limit, cmdutil.MaxPageLen),
+	}
+	// Bitbucket Server lists repositories by name and can't sort them by
+	// update time, so the default order is by name there
+	if client.IsServer() && !opts.SortSet {
+		listOpts.Sort = "name"
 	}
 
-	// Fetch repositories
-	result, err := client.ListRepositories(ctx, opts.Workspace, listOpts)
-	if err != nil {
-		return fmt.Errorf("failed to list repositories: %w", err)
+	// A nil set means every repository is listed
+	var writable map[string]bool
+	if opts.Writable {
+		writable, err = fetchWritableRepos(ctx, client, opts.Streams)
+		if err != nil {
+			opts.Streams.Warning("Could not determine which repositories you can write to; listing all repositories: %v", err)
+		}
+	}
+
+	var repos []api.RepositoryFull
+	var pageCount *cmdutil.PageCount
+	if opts.AllWorkspaces {
+		repos, pageCount, err = listAllWorkspaces(ctx, client, opts.Streams, listOpts, writable, opts.Limit)
+		if err != nil {
+			return err
+		}
+		if len(repos) == 0 {
+			opts.Streams.Info("No repositories found in any of your workspaces")
+			return cmdutil.NoResults(opts.ExitCode)
+		}
+	} else {
+		// Fetch repositories
+		result, err := client.ListRepositories(ctx, opts.Workspace, listOpts)
+		if err != nil {
+			return fmt.Errorf("failed to list repositories: %w", err)
+		}
+		if writable != nil {
+			repos, pageCount, err = listWritable(ctx, client, opts.Streams, result, writable, opts.Limit)
+		} else if result, err = cmdutil.CollectPages(ctx, client, result, opts.Limit); err == nil {
+			repos = result.Values
+			pageCount = cmdutil.NewPageCount(result, len(repos))
+		}
+		if err != nil {
+			return fmt.Errorf("failed to list repositories: %w", err)
+		}
+		if len(repos) == 0 {
+			opts.Streams.Info("No repositories found in workspace %s", opts.Workspace)
+			return cmdutil.NoResults(opts.ExitCode)
+		}
 	}
 
-	if len(result.Values) == 0 {
-		opts.Streams.Info("No repositories found in workspace %s", opts.Workspace)
-		return nil
+	var count *cmdutil.PageCount
+	if opts.ShowCount {
+		count = pageCount
 	}
 
 	// Output results
 	if opts.JSON {
-		return outputListJSON(opts.Streams, result.Values)
+		return cmdutil.PrintRepositoryListJSON(opts.Streams, repos, count)
 	}
 
-	return outputTable(opts.Streams, result.Values)
+	if err := cmdutil.PrintRepositoryTable(opts.Streams, repos); err != nil {
+		return err
+	}
+	cmdutil.PrintPageCount(opts.Streams, count)
+	return nil
 }
 
-func outputListJSON(streams *iostreams.IOStreams, repos []api.RepositoryFull) error {
-	// Create simplified JSON output
-	output := make([]map[string]interface{}, len(repos))
-	for i, repo := range repos {
-		output[i] = map[string]interface{}{
-			"name":        repo.Name,
-			"full_name":   repo.FullName,
-			"slug":        repo.Slug,
-			"description": repo.Description,
-			"is_private":  repo.IsPrivate,
-			"language":    repo.Language,
-			"updated_on":  repo.UpdatedOn,
-			"url":         repo.Links.HTML.Href,
+// allWorkspacesConcurrency bounds how many workspaces --all-workspaces lists
+// at once
+const allWorkspacesConcurrency = 4
+
+// maxWorkspacePages bounds how many pages of workspaces are fetched
+const maxWorkspacePages = 10
+
+// listAllWorkspaces lists repositories in every workspace the user belongs
+// to and merges them, sorted by listOpts.Sort and cut to limit. A non-nil
+// writable set filters each workspace before the merged list is cut.
+// Workspaces that fail are reported as warnings; it only fails if all do.
+func listAllWorkspaces(ctx context.Context, client *api.Client, streams *iostreams.IOStreams, listOpts *api.RepositoryListOptions, writable map[string]bool, limit int) ([]api.RepositoryFull, *cmdutil.PageCount, error) {
+	var slugs []string
+	page, err := client.ListWorkspaces(ctx, &api.WorkspaceListOptions{Limit: 100})
+	for pages := 1; page != nil && err == nil; pages++ {
+		for _, m := range page.Values {
+			if m.Workspace != nil && m.Workspace.Slug != "" {
+				slugs = append(slugs, m.Workspace.Slug)
+			}
 		}
+		if pages >= maxWorkspacePages {
+			break
+		}
+		page, err = api.NextPage(ctx, client, page)
+	}
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to list workspaces: %w", err)
 	}
 
-	return cmdutil.PrintJSON(streams, output)
-}
+	results := make([][]api.RepositoryFull, len(slugs))
+	counts := make([]*cmdutil.PageCount, len(slugs))
+	errs := make([]error, len(slugs))
 
-func outputTable(streams *iostreams.IOStreams, repos []api.RepositoryFull) error {
-	w := tabwriter.NewWriter(streams.Out, 0, 0, 2, ' ', 0)
+	var g errgroup.Group
+	g.SetLimit(allWorkspacesConcurrency)
+	for i, slug := range slugs {
+		g.Go(func() error {
+			results[i], counts[i], errs[i] = listWorkspaceRepos(ctx, client, streams, slug, listOpts, writable, limit)
+			return nil
+		})
+	}
+	_ = g.Wait()
+
+	var repos []api.RepositoryFull
+	count := &cmdutil.PageCount{}
+	failed := 0
+	for i, slug := range slugs {
+		if errs[i] != nil {
+			failed++
+			streams.Warning("Could not list repositories in %s: %v", slug, errs[i])
+			continue
+		}
+		repos = append(repos, results[i]...)
+		count.Total += max(counts[i].Total, counts[i].Shown)
+		count.HasMore = count.HasMore || counts[i].HasMore
+	}
+	if len(slugs) > 0 && failed == len(slugs) {
+		return nil, nil, fmt.Errorf("failed to list repositories in any workspace: %w", errs[0])
+	}
+
+	sortRepositories(repos, listOpts.Sort)
+	if limit > 0 && len(repos) > limit {
+		repos = repos[:limit]
+	}
+
+	count.Shown = len(repos)
+	count.HasMore = count.HasMore || count.Shown < count.Total
+	return repos, count, nil
+}
 
-	// Print header
-	header := "NAME\tDESCRIPTION\tVISIBILITY\tUPDATED"
-	cmdutil.PrintTableHeader(streams, w, header)
+// listWorkspaceRepos lists up to limit repositories in a workspace, only
+// those in the writable set when it is non-nil
+func listWorkspaceRepos(ctx context.Context, client *api.Client, streams *iostreams.IOStreams, workspace string, listOpts *api.RepositoryListOptions, writable map[string]bool, limit int) ([]api.RepositoryFull, *cmdutil.PageCount, error) {
+	result, err := client.ListRepositories(ctx, workspace, listOpts)
+	if err != nil {
+		return nil, nil, err
+	}
+	if writable != nil {
+		return listWritable(ctx, client, streams, result, writable, limit)
+	}
+	result, err = cmdutil.CollectPages(ctx, client, result, limit)
+	if err != nil {
+		return nil, nil, err
+	}
+	return result.Values, cmdutil.NewPageCount(result, len(result.Values)), nil
+}
 
-	// Print rows
-	for _, repo := range repos {
-		name := cmdutil.TruncateString(repo.FullName, 40)
-		desc := cmdutil.TruncateString(repo.Description, 40)
-		visibility := formatVisibility(streams, repo.IsPrivate)
-		updated := cmdutil.TimeAgo(repo.UpdatedOn)
+// sortRepositories sorts repositories merged from several workspaces by a
+// Bitbucket sort expression such as "name" or "-updated_on". Unknown fields
+// leave the order unchanged.
+func sortRepositories(repos []api.RepositoryFull, sortBy string) {
+	field, desc := strings.CutPrefix(sortBy, "-")
 
-		fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", name, desc, visibility, updated)
+	var compare func(a, b *api.RepositoryFull) int
+	switch field {
+	case "name", "slug", "full_name":
+		compare = func(a, b *api.RepositoryFull) int {
+			return strings.Compare(strings.ToLower(a.FullName), strings.ToLower(b.FullName))
+		}
+	case "updated_on":
+		compare = func(a, b *api.RepositoryFull) int { return a.UpdatedOn.Compare(b.UpdatedOn) }
+	case "created_on":
+		compare = func(a, b *api.RepositoryFull) int { return a.CreatedOn.Compare(b.CreatedOn) }
+	case "size":
+		compare = func(a, b *api.RepositoryFull) int { return cmp.Compare(a.Size, b.Size) }
+	default:
+		return
 	}
 
-	return w.Flush()
+	slices.SortStableFunc(repos, func(a, b api.RepositoryFull) int {
+		if desc {
+			return compare(&b, &a)
+		}
+		return compare(&a, &b)
+	})
+}
+
+// maxPermissionPages bounds how many pages of permissions or repositories
+// --writable reads
+const maxPermissionPages = 10
+
+// fetchWritableRepos returns the full names, lowercased, of the
+// repositories the user has write or admin permission on. Only the first
+// maxPermissionPages pages are read, with a warning if there are more.
+func fetchWritableRepos(ctx context.Context, client *api.Client, streams *iostreams.IOStreams) (map[string]bool, error) {
+	writable := make(map[string]bool)
+	var q api.Query
+	q.AnyOf("permission", api.PermissionWrite, api.PermissionAdmin)
+	page, err := client.ListUserRepoPermissions(ctx, &api.RepoPermissionListOptions{
+		Query: q.String(),
+		Limit: 100,
+	})
+	for pages := 1; page != nil && err == nil; pages++ {
+		for _, p := range page.Values {
+			if p.Repository != nil && (p.Permission == api.PermissionWrite || p.Permission == api.PermissionAdmin) {
+				writable[strings.ToLower(p.Repository.FullName)] = true
+			}
+		}
+		if pages >= maxPermissionPages {
+			if page.Next != "" {
+				streams.Warning("Only the first %d pages of your repository permissions were read; some repositories you can write to may be missing", maxPermissionPages)
+			}
+			break
+		}
+		page, err = api.NextPage(ctx, client, page)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return writable, nil
 }
 
-func formatVisibility(streams *iostreams.IOStreams, isPrivate bool) string {
-	if isPrivate {
-		if streams.ColorEnabled() {
-			return iostreams.Yellow + "private" + iostreams.Reset
+// filterWritable keeps the repositories in the writable set
+func filterWritable(repos []api.RepositoryFull, writable map[string]bool) []api.RepositoryFull {
+	var kept []api.RepositoryFull
+	for _, r := range repos {
+		if writable[strings.ToLower(r.FullName)] {
+			kept = append(kept, r)
 		}
-		return "private"
 	}
+	return kept
+}
 
-	if streams.ColorEnabled() {
-		return iostreams.Green + "public" + iostreams.Reset
+// listWritable filters a workspace's repositories to the writable set,
+// following further pages until limit repositories are found. Only
+// maxPermissionPages pages are read, with a warning if there are more.
+func listWritable(ctx context.Context, client *api.Client, streams *iostreams.IOStreams, page *api.Paginated[api.RepositoryFull], writable map[string]bool, limit int) ([]api.RepositoryFull, *cmdutil.PageCount, error) {
+	var repos []api.RepositoryFull
+	for pages := 1; ; pages++ {
+		repos = append(repos, filterWritable(page.Values, writable)...)
+		if limit > 0 && len(repos) >= limit {
+			return repos[:limit], &cmdutil.PageCount{Shown: limit, HasMore: len(repos) > limit || page.Next != ""}, nil
+		}
+		if page.Next == "" {
+			break
+		}
+		if pages >= maxPermissionPages {
+			streams.Warning("Only the first %d pages of repositories were checked for write access; more may be available", maxPermissionPages)
+			break
+		}
+		next, err := api.NextPage(ctx, client, page)
+		if err != nil {
+			return nil, nil, err
+		}
+		page = next
 	}
-	return "public"
+	return repos, &cmdutil.PageCount{Shown: len(repos), HasMore: page.Next != ""}, nil
 }
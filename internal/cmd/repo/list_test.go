@@ -0,0 +1,229 @@
+package repo
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/rbansal42/bitbucket-cli/internal/api"
+	"github.com/rbansal42/bitbucket-cli/internal/iostreams"
+)
+
+func TestListAllWorkspaces(t *testing.T) {
+	var serverURL string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/user/permissions/workspaces":
+			fmt.Fprint(w, `{"values": [
+				{"workspace": {"slug": "alpha"}},
+				{"workspace": {"slug": "broken"}},
+				{"workspace": {"slug": "gamma"}}
+			]}`)
+		case "/repositories/alpha":
+			if got := r.URL.Query().Get("role"); got != "admin" {
+				t.Errorf("role = %q, want admin", got)
+			}
+			fmt.Fprint(w, `{"size": 2, "values": [
+				{"full_name": "alpha/zeta", "updated_on": "2026-01-03T00:00:00Z"},
+				{"full_name": "alpha/apple", "updated_on": "2026-01-01T00:00:00Z"}
+			]}`)
+		case "/repositories/gamma":
+			if r.URL.Query().Get("page") == "2" {
+				fmt.Fprintf(w, `{"size": 5, "next": "%s/repositories/gamma?page=3", "values": [
+					{"full_name": "gamma/kiwi", "updated_on": "2025-12-01T00:00:00Z"}
+				]}`, serverURL)
+				return
+			}
+			fmt.Fprintf(w, `{"size": 5, "next": "%s/repositories/gamma?page=2", "values": [
+				{"full_name": "gamma/mango", "updated_on": "2026-01-02T00:00:00Z"}
+			]}`, serverURL)
+		default:
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	}))
+	defer server.Close()
+	serverURL = server.URL
+
+	client := api.NewClient(api.WithBaseURL(server.URL), api.WithToken("test-token"))
+	errOut := &bytes.Buffer{}
+	streams := &iostreams.IOStreams{Out: &bytes.Buffer{}, ErrOut: errOut}
+
+	repos, count, err := listAllWorkspaces(context.Background(), client, streams, &api.RepositoryListOptions{Role: "admin", Sort: "-updated_on", Limit: 1}, nil, 2)
+	if err != nil {
+		t.Fatalf("listAllWorkspaces() error: %v", err)
+	}
+
+	var names []string
+	for _, r := range repos {
+		names = append(names, r.FullName)
+	}
+	if got := strings.Join(names, ","); got != "alpha/zeta,gamma/mango" {
+		t.Errorf("repositories = %s, want the two most recently updated across workspaces", got)
+	}
+	if count.Shown != 2 || count.Total != 7 || !count.HasMore {
+		t.Errorf("count = %+v", count)
+	}
+	if !strings.Contains(errOut.String(), "broken") {
+		t.Errorf("expected a warning about the failing workspace, got %q", errOut.String())
+	}
+}
+
+func TestListAllWorkspaces_Writable(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/user/permissions/workspaces":
+			fmt.Fprint(w, `{"values": [{"workspace": {"slug": "alpha"}}, {"workspace": {"slug": "beta"}}]}`)
+		case r.URL.Path == "/repositories/alpha" && r.URL.Query().Get("page") == "2":
+			fmt.Fprint(w, `{"values": [{"full_name": "alpha/two", "updated_on": "2026-01-03T00:00:00Z"}]}`)
+		case r.URL.Path == "/repositories/alpha":
+			fmt.Fprintf(w, `{"next": "http://%s/repositories/alpha?page=2", "values": [
+				{"full_name": "alpha/one", "updated_on": "2026-01-05T00:00:00Z"}
+			]}`, r.Host)
+		case r.URL.Path == "/repositories/beta":
+			fmt.Fprint(w, `{"values": [{"full_name": "beta/one", "updated_on": "2026-01-01T00:00:00Z"}]}`)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	client := api.NewClient(api.WithBaseURL(server.URL), api.WithToken("test-token"))
+	streams := &iostreams.IOStreams{Out: &bytes.Buffer{}, ErrOut: &bytes.Buffer{}}
+	writable := map[string]bool{"alpha/two": true, "beta/one": true}
+
+	// The most recently updated repository isn't writable, so filtering
+	// after the list is cut would leave only one
+	repos, count, err := listAllWorkspaces(context.Background(), client, streams, &api.RepositoryListOptions{Sort: "-updated_on"}, writable, 2)
+	if err != nil {
+		t.Fatalf("listAllWorkspaces() error: %v", err)
+	}
+	var names []string
+	for _, r := range repos {
+		names = append(names, r.FullName)
+	}
+	if got := strings.Join(names, ","); got != "alpha/two,beta/one" {
+		t.Errorf("repositories = %s, want the two writable ones", got)
+	}
+	if count.Shown != 2 || count.HasMore {
+		t.Errorf("count = %+v", count)
+	}
+}
+
+func TestSortRepositories(t *testing.T) {
+	day := func(d int) time.Time { return time.Date(2026, 1, d, 0, 0, 0, 0, time.UTC) }
+	repos := []api.RepositoryFull{
+		{FullName: "b/Two", UpdatedOn: day(1)},
+		{FullName: "a/one", UpdatedOn: day(3)},
+		{FullName: "c/three", UpdatedOn: day(2)},
+	}
+
+	tests := []struct {
+		sort string
+		want string
+	}{
+		{"name", "a/one,b/Two,c/three"},
+		{"-name", "c/three,b/Two,a/one"},
+		{"-updated_on", "a/one,c/three,b/Two"},
+		{"unknown", "b/Two,a/one,c/three"},
+	}
+
+	for _, tt := range tests {
+		sorted := append([]api.RepositoryFull(nil), repos...)
+		sortRepositories(sorted, tt.sort)
+		var names []string
+		for _, r := range sorted {
+			names = append(names, r.FullName)
+		}
+		if got := strings.Join(names, ","); got != tt.want {
+			t.Errorf("sortRepositories(%q) = %s, want %s", tt.sort, got, tt.want)
+		}
+	}
+}
+
+func TestListWritable(t *testing.T) {
+	var baseURL string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/user/permissions/repositories":
+			if q := r.URL.Query().Get("q"); !strings.Contains(q, `permission="write"`) || !strings.Contains(q, `permission="admin"`) {
+				t.Errorf("q = %q, want write and admin permissions", q)
+			}
+			fmt.Fprint(w, `{"values": [
+				{"permission": "write", "repository": {"full_name": "ws/One"}},
+				{"permission": "admin", "repository": {"full_name": "ws/three"}},
+				{"permission": "admin", "repository": {"full_name": "other/four"}}
+			]}`)
+		case r.URL.Path == "/repositories/ws" && r.URL.Query().Get("page") == "2":
+			fmt.Fprint(w, `{"values": [{"full_name": "ws/three"}, {"full_name": "ws/five"}]}`)
+		case r.URL.Path == "/repositories/ws":
+			fmt.Fprintf(w, `{"values": [{"full_name": "ws/one"}, {"full_name": "ws/two"}], "next": "%s/repositories/ws?page=2"}`, baseURL)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+	baseURL = server.URL
+
+	client := api.NewClient(api.WithBaseURL(server.URL), api.WithToken("test-token"))
+	ctx := context.Background()
+
+	streams := &iostreams.IOStreams{Out: &bytes.Buffer{}, ErrOut: &bytes.Buffer{}}
+	writable, err := fetchWritableRepos(ctx, client, streams)
+	if err != nil {
+		t.Fatalf("fetchWritableRepos() error: %v", err)
+	}
+	if len(writable) != 3 || !writable["ws/one"] {
+		t.Errorf("writable = %v", writable)
+	}
+
+	first, err := client.ListRepositories(ctx, "ws", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	repos, count, err := listWritable(ctx, client, streams, first, writable, 30)
+	if err != nil {
+		t.Fatalf("listWritable() error: %v", err)
+	}
+	if len(repos) != 2 || repos[0].FullName != "ws/one" || repos[1].FullName != "ws/three" {
+		t.Errorf("repos = %+v, want ws/one and ws/three across both pages", repos)
+	}
+	if count.Shown != 2 || count.HasMore {
+		t.Errorf("count = %+v", count)
+	}
+
+	repos, count, err = listWritable(ctx, client, streams, first, writable, 1)
+	if err != nil || len(repos) != 1 || !count.HasMore {
+		t.Errorf("listWritable(limit 1) = %d repos, %+v, %v", len(repos), count, err)
+	}
+}
+
+func TestFetchWritableRepos_PageCap(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		fmt.Fprintf(w, `{"next": "http://%s/user/permissions/repositories?page=%d", "values": [
+			{"permission": "write", "repository": {"full_name": "ws/repo%d"}}
+		]}`, r.Host, requests+1, requests)
+	}))
+	defer server.Close()
+
+	client := api.NewClient(api.WithBaseURL(server.URL), api.WithToken("test-token"))
+	errOut := &bytes.Buffer{}
+	streams := &iostreams.IOStreams{Out: &bytes.Buffer{}, ErrOut: errOut}
+
+	writable, err := fetchWritableRepos(context.Background(), client, streams)
+	if err != nil {
+		t.Fatalf("fetchWritableRepos() error: %v", err)
+	}
+	if requests != maxPermissionPages || len(writable) != maxPermissionPages {
+		t.Errorf("read %d pages and %d repositories, want %d", requests, len(writable), maxPermissionPages)
+	}
+	if !strings.Contains(errOut.String(), "some repositories you can write to may be missing") {
+		t.Errorf("stderr = %q, want a warning about the page cap", errOut.String())
+	}
+}
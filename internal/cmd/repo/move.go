@@ -0,0 +1,115 @@
+package repo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/rbansal42/bitbucket-cli/internal/api"
+	"github.com/rbansal42/bitbucket-cli/internal/cmdutil"
+	"github.com/rbansal42/bitbucket-cli/internal/iostreams"
+)
+
+type moveOptions struct {
+	streams *iostreams.IOStreams
+	repoArg string
+	project string
+}
+
+// NewCmdMove creates the repo move command
+func NewCmdMove(streams *iostreams.IOStreams) *cobra.Command {
+	opts := &moveOptions{
+		streams: streams,
+	}
+
+	cmd := &cobra.Command{
+		Use:   "move [<workspace/repo>] --project <key>",
+		Short: "Move a repository to another project",
+		Long: `Move a repository to another project in the same workspace.
+
+The target project is looked up first, so a mistyped key is reported before
+anything changes. Moving a repository requires admin access to it.`,
+		Example: `  # Move the current repository to the PLATFORM project
+  bb repo move --project PLATFORM
+
+  # Move a specific repository
+  bb repo move myworkspace/old-service --project ARCHIVE`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) > 0 {
+				opts.repoArg = args[0]
+			}
+			workspace, repoSlug, err := cmdutil.ParseRepository(opts.repoArg)
+			if err != nil {
+				return err
+			}
+
+			client, err := cmdutil.GetAPIClient()
+			if err != nil {
+				return err
+			}
+
+			ctx, cancel := context.WithTimeout(cmd.Context(), 30*time.Second)
+			defer cancel()
+
+			return runMove(ctx, client, opts, workspace, repoSlug)
+		},
+	}
+
+	cmd.Flags().StringVarP(&opts.project, "project", "p", "", "Key of the project to move the repository to (required)")
+	_ = cmd.MarkFlagRequired("project")
+
+	cmd.ValidArgsFunction = cmdutil.CompleteRepoNames
+
+	return cmd
+}
+
+func runMove(ctx context.Context, client *api.Client, opts *moveOptions, workspace, repoSlug string) error {
+	fullName := workspace + "/" + repoSlug
+	key := strings.ToUpper(strings.TrimSpace(opts.project))
+	if key == "" {
+		return fmt.Errorf("a project key is required")
+	}
+
+	repo, err := client.GetRepository(ctx, workspace, repoSlug)
+	if err != nil {
+		return fmt.Errorf("failed to get repository: %w", err)
+	}
+
+	target, err := client.GetProject(ctx, workspace, key)
+	if err != nil {
+		var apiErr *api.APIError
+		if errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusNotFound {
+			return fmt.Errorf("project %q not found in workspace %s", key, workspace)
+		}
+		return fmt.Errorf("failed to get project: %w", err)
+	}
+
+	from := "(none)"
+	if repo.Project != nil {
+		if strings.EqualFold(repo.Project.Key, target.Key) {
+			opts.streams.Info("%s is already in project %s", fullName, target.Key)
+			return nil
+		}
+		from = repo.Project.Key
+	}
+
+	_, err = client.UpdateRepository(ctx, workspace, repoSlug, &api.RepositoryUpdateOptions{
+		Project: &api.ProjectRef{Key: target.Key},
+	})
+	if err != nil {
+		var apiErr *api.APIError
+		if errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusForbidden {
+			return fmt.Errorf("you need admin access to %s to move it: %w", fullName, err)
+		}
+		return fmt.Errorf("failed to move repository: %w", err)
+	}
+
+	opts.streams.Success("Moved %s from project %s to %s", fullName, from, target.Key)
+	return nil
+}
@@ -0,0 +1,81 @@
+package repo
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/rbansal42/bitbucket-cli/internal/api"
+	"github.com/rbansal42/bitbucket-cli/internal/iostreams"
+)
+
+func newMoveTestServer(t *testing.T, updateStatus int, body *map[string]interface{}) *api.Client {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/workspaces/ws/projects/NEW":
+			fmt.Fprint(w, `{"key": "NEW", "name": "New project"}`)
+		case r.URL.Path == "/repositories/ws/repo" && r.Method == http.MethodGet:
+			fmt.Fprint(w, `{"full_name": "ws/repo", "project": {"key": "OLD"}}`)
+		case r.URL.Path == "/repositories/ws/repo" && r.Method == http.MethodPut:
+			if err := json.NewDecoder(r.Body).Decode(body); err != nil {
+				t.Errorf("failed to decode body: %v", err)
+			}
+			w.WriteHeader(updateStatus)
+			if updateStatus == http.StatusOK {
+				fmt.Fprint(w, `{"full_name": "ws/repo", "project": {"key": "NEW"}}`)
+			} else {
+				fmt.Fprint(w, `{"type": "error", "error": {"message": "forbidden"}}`)
+			}
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(server.Close)
+	return api.NewClient(api.WithBaseURL(server.URL), api.WithToken("test-token"))
+}
+
+func TestRunMove(t *testing.T) {
+	var body map[string]interface{}
+	client := newMoveTestServer(t, http.StatusOK, &body)
+	out := &bytes.Buffer{}
+	opts := &moveOptions{streams: &iostreams.IOStreams{Out: out, ErrOut: &bytes.Buffer{}}, project: "new"}
+
+	if err := runMove(context.Background(), client, opts, "ws", "repo"); err != nil {
+		t.Fatalf("runMove() error: %v", err)
+	}
+
+	project, _ := body["project"].(map[string]interface{})
+	if len(body) != 1 || project["key"] != "NEW" {
+		t.Errorf("PUT body = %v, want only the project key NEW", body)
+	}
+	if !strings.Contains(out.String(), "from project OLD to NEW") {
+		t.Errorf("output = %q, want the old and new project", out.String())
+	}
+}
+
+func TestRunMove_Errors(t *testing.T) {
+	var body map[string]interface{}
+	client := newMoveTestServer(t, http.StatusForbidden, &body)
+	opts := &moveOptions{streams: &iostreams.IOStreams{Out: &bytes.Buffer{}, ErrOut: &bytes.Buffer{}}}
+
+	opts.project = "MISSING"
+	err := runMove(context.Background(), client, opts, "ws", "repo")
+	if err == nil || !strings.Contains(err.Error(), `project "MISSING" not found`) {
+		t.Errorf("missing project error = %v", err)
+	}
+	if body != nil {
+		t.Error("repository was updated even though the project does not exist")
+	}
+
+	opts.project = "NEW"
+	err = runMove(context.Background(), client, opts, "ws", "repo")
+	if err == nil || !strings.Contains(err.Error(), "admin access to ws/repo") {
+		t.Errorf("forbidden error = %v, want an admin access hint", err)
+	}
+}
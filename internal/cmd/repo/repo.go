@@ -34,9 +34,21 @@ history. You can discuss and manage your project's work within the repository.`,
 	cmd.AddCommand(NewCmdClone(streams))
 	cmd.AddCommand(NewCmdCreate(streams))
 	cmd.AddCommand(NewCmdFork(streams))
+	cmd.AddCommand(NewCmdForks(streams))
 	cmd.AddCommand(NewCmdDelete(streams))
+	cmd.AddCommand(NewCmdMove(streams))
+	cmd.AddCommand(NewCmdArchive(streams))
+	cmd.AddCommand(NewCmdUnarchive(streams))
 	cmd.AddCommand(NewCmdSync(streams))
 	cmd.AddCommand(NewCmdSetDefault(streams))
+	cmd.AddCommand(NewCmdAvatar(streams))
+	cmd.AddCommand(NewCmdWatch(streams))
+	cmd.AddCommand(NewCmdUnwatch(streams))
+	cmd.AddCommand(NewCmdWatchers(streams))
+	cmd.AddCommand(NewCmdCommits(streams))
+	cmd.AddCommand(NewCmdCompare(streams))
+	cmd.AddCommand(NewCmdAccess(streams))
+	cmd.AddCommand(NewCmdBranchingModel(streams))
 
 	return cmd
 }
@@ -5,6 +5,7 @@ import (
 
 	"github.com/rbansal42/bitbucket-cli/internal/api"
 	"github.com/rbansal42/bitbucket-cli/internal/cmdutil"
+	"github.com/rbansal42/bitbucket-cli/internal/config"
 )
 
 func TestParseRepositoryFormat(t *testing.T) {
@@ -345,13 +346,66 @@ func TestNewCmdRepo(t *testing.T) {
 	}
 }
 
-// Test getPreferredProtocol returns a valid protocol
+// Test getPreferredProtocol honours the flag, the per-host setting and the
+// global setting, in that order
 func TestGetPreferredProtocol(t *testing.T) {
-	protocol := getPreferredProtocol()
+	t.Setenv("BB_CONFIG_DIR", t.TempDir())
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("SSH_AUTH_SOCK", "")
 
-	// Should return either "https" or "ssh"
-	if protocol != "https" && protocol != "ssh" {
-		t.Errorf("expected protocol to be 'https' or 'ssh', got %q", protocol)
+	links := api.RepositoryLinks{
+		Clone: []api.CloneLink{
+			{Href: "https://bitbucket.org/workspace/repo.git", Name: "https"},
+			{Href: "git@bitbucket.org:workspace/repo.git", Name: "ssh"},
+		},
+	}
+
+	// No configuration and no SSH key
+	if got := getCloneURL(links, getPreferredProtocol("bitbucket.org", "")); got != links.Clone[0].Href {
+		t.Errorf("unconfigured clone URL = %q, want the HTTPS one", got)
+	}
+
+	if err := config.SaveConfig(&config.Config{GitProtocol: "ssh"}); err != nil {
+		t.Fatal(err)
+	}
+	if got := getCloneURL(links, getPreferredProtocol("bitbucket.org", "")); got != links.Clone[1].Href {
+		t.Errorf("global ssh clone URL = %q, want the SSH one", got)
+	}
+
+	hosts := config.HostsConfig{}
+	hosts.SetGitProtocol("bitbucket.org", "https")
+	if err := config.SaveHostsConfig(hosts); err != nil {
+		t.Fatal(err)
+	}
+	if got := getPreferredProtocol("bitbucket.org", ""); got != "https" {
+		t.Errorf("per-host protocol = %q, want https", got)
+	}
+	if got := getPreferredProtocol("bitbucket.example.com", ""); got != "ssh" {
+		t.Errorf("protocol for another host = %q, want the global ssh", got)
+	}
+	if got := getPreferredProtocol("bitbucket.org", "ssh"); got != "ssh" {
+		t.Errorf("--protocol override = %q, want ssh", got)
+	}
+}
+
+func TestRepoHost(t *testing.T) {
+	links := api.RepositoryLinks{HTML: api.Link{Href: "https://bitbucket.example.com/projects/ws/repos/repo"}}
+	if got := repoHost(links); got != "bitbucket.example.com" {
+		t.Errorf("repoHost() = %q", got)
+	}
+	if got := repoHost(api.RepositoryLinks{}); got != config.DefaultHost {
+		t.Errorf("repoHost() without links = %q, want %q", got, config.DefaultHost)
+	}
+}
+
+func TestOrderCloneLinks(t *testing.T) {
+	links := []api.CloneLink{{Name: "https"}, {Name: "ssh"}}
+	got := orderCloneLinks(links, "ssh")
+	if len(got) != 2 || got[0].Name != "ssh" || got[1].Name != "https" {
+		t.Errorf("orderCloneLinks() = %v, want ssh first", got)
+	}
+	if links[0].Name != "https" {
+		t.Error("orderCloneLinks() modified its input")
 	}
 }
 
@@ -1,10 +1,8 @@
 package repo
 
 import (
-	"bufio"
 	"context"
 	"fmt"
-	"io"
 	"os"
 	"os/exec"
 	"path/filepath"
@@ -19,11 +17,6 @@ import (
 	"github.com/rbansal42/bitbucket-cli/internal/iostreams"
 )
 
-// LocalConfig represents the .bb.yml file structure
-type LocalConfig struct {
-	DefaultRepo string `yaml:"default_repo,omitempty"`
-}
-
 // SetDefaultOptions holds the options for the set-default command
 type SetDefaultOptions struct {
 	RepoArg string
@@ -112,14 +105,14 @@ func runSetDefault(ctx context.Context, opts *SetDefaultOptions) error {
 		workspace = remote.Workspace
 		repoSlug = remote.RepoSlug
 
-		// Require TTY for interactive confirmation
-		if !opts.Streams.IsStdinTTY() {
-			return fmt.Errorf("cannot confirm: stdin is not a terminal\nProvide repository as argument: bb repo set-default <workspace/repo>")
-		}
-
 		// Confirm with user
 		fullRepo := fmt.Sprintf("%s/%s", workspace, repoSlug)
-		if !confirmSetDefault(opts.Streams, fullRepo) {
+		confirmed, err := cmdutil.Confirm(opts.Streams, fmt.Sprintf("Set default repository to %s?", fullRepo), true,
+			"provide the repository as an argument: bb repo set-default <workspace/repo>")
+		if err != nil {
+			return err
+		}
+		if !confirmed {
 			opts.Streams.Info("Aborted")
 			return nil
 		}
@@ -241,21 +234,7 @@ func unsetGitConfig() error {
 }
 
 func setLocalConfig(repo string) error {
-	config := LocalConfig{
-		DefaultRepo: repo,
-	}
-
-	data, err := yaml.Marshal(config)
-	if err != nil {
-		return fmt.Errorf("failed to marshal config: %w", err)
-	}
-
-	configPath := filepath.Join(".", ".bb.yml")
-	if err := os.WriteFile(configPath, data, 0644); err != nil {
-		return fmt.Errorf("failed to write .bb.yml: %w", err)
-	}
-
-	return nil
+	return cmdutil.UpdateLocalConfig(func(c *cmdutil.LocalConfig) { c.DefaultRepo = repo })
 }
 
 func getLocalConfig() (string, error) {
@@ -266,7 +245,7 @@ func getLocalConfig() (string, error) {
 		return "", err
 	}
 
-	var config LocalConfig
+	var config cmdutil.LocalConfig
 	if err := yaml.Unmarshal(data, &config); err != nil {
 		return "", err
 	}
@@ -288,7 +267,7 @@ func removeLocalConfig() error {
 		return err
 	}
 
-	var config LocalConfig
+	var config cmdutil.LocalConfig
 	if err := yaml.Unmarshal(data, &config); err != nil {
 		// File exists but invalid, just remove it
 		return os.Remove(configPath)
@@ -307,22 +286,6 @@ func removeLocalConfig() error {
 	return os.WriteFile(configPath, newData, 0644)
 }
 
-func confirmSetDefault(streams *iostreams.IOStreams, repo string) bool {
-	fmt.Fprintf(streams.Out, "Set default repository to %s? [Y/n] ", repo)
-
-	var reader *bufio.Reader
-	if r, ok := streams.In.(io.Reader); ok {
-		reader = bufio.NewReader(r)
-	} else {
-		reader = bufio.NewReader(os.Stdin)
-	}
-
-	input, _ := reader.ReadString('\n')
-	input = strings.TrimSpace(strings.ToLower(input))
-
-	return input == "" || input == "y" || input == "yes"
-}
-
 // execCommand is a wrapper for exec.Command to allow testing
 var execCommand = execCommandImpl
 
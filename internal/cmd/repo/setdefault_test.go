@@ -0,0 +1,39 @@
+package repo
+
+import (
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestSetLocalConfigKeepsOtherSettings(t *testing.T) {
+	t.Chdir(t.TempDir())
+
+	if err := os.WriteFile(".bb.yml", []byte("merge_strategy: squash\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := setLocalConfig("myworkspace/myrepo"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	data, err := os.ReadFile(".bb.yml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{"default_repo: myworkspace/myrepo", "merge_strategy: squash"} {
+		if !strings.Contains(string(data), want) {
+			t.Errorf(".bb.yml = %q, want it to contain %q", data, want)
+		}
+	}
+
+	if err := removeLocalConfig(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	data, err = os.ReadFile(".bb.yml")
+	if err != nil {
+		t.Fatalf("expected .bb.yml to be kept for merge_strategy: %v", err)
+	}
+	if strings.Contains(string(data), "default_repo") || !strings.Contains(string(data), "merge_strategy: squash") {
+		t.Errorf("unexpected .bb.yml after unset: %q", data)
+	}
+}
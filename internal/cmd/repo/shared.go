@@ -1,12 +1,14 @@
 package repo
 
 import (
-	"bufio"
 	"fmt"
 	"io"
+	"net/url"
+	"slices"
 	"strings"
 
 	"github.com/rbansal42/bitbucket-cli/internal/api"
+	"github.com/rbansal42/bitbucket-cli/internal/cmdutil"
 	"github.com/rbansal42/bitbucket-cli/internal/config"
 )
 
@@ -26,28 +28,46 @@ func getCloneURL(links api.RepositoryLinks, protocol string) string {
 	return ""
 }
 
-// getPreferredProtocol returns the user's preferred git protocol
-func getPreferredProtocol() string {
-	cfg, err := config.LoadConfig()
-	if err != nil {
-		return "https" // default to https
+// orderCloneLinks returns links with those for protocol first
+func orderCloneLinks(links []api.CloneLink, protocol string) []api.CloneLink {
+	ordered := make([]api.CloneLink, 0, len(links))
+	for _, clone := range links {
+		if clone.Name == protocol {
+			ordered = append(ordered, clone)
+		}
+	}
+	for _, clone := range links {
+		if clone.Name != protocol {
+			ordered = append(ordered, clone)
+		}
 	}
+	return ordered
+}
 
-	if cfg.GitProtocol != "" {
-		return cfg.GitProtocol
+// getPreferredProtocol returns the git protocol to use for host. override
+// comes from a --protocol flag and wins over the configuration.
+func getPreferredProtocol(host, override string) string {
+	if override != "" {
+		return override
 	}
+	return config.PreferredGitProtocol(host)
+}
 
-	return "https"
+// validateProtocol checks a --protocol flag value
+func validateProtocol(protocol string) error {
+	if protocol == "" || slices.Contains(config.GitProtocols, protocol) {
+		return nil
+	}
+	return cmdutil.NewFlagError(fmt.Errorf("invalid protocol %q: must be one of %s", protocol, strings.Join(config.GitProtocols, ", ")))
 }
 
-// confirmDeletion prompts the user to confirm deletion by typing the repository name
-func confirmDeletion(repoName string, reader io.Reader) bool {
-	scanner := bufio.NewScanner(reader)
-	if scanner.Scan() {
-		input := strings.TrimSpace(scanner.Text())
-		return input == repoName
+// repoHost returns the Bitbucket host a repository lives on, taken from its
+// web link
+func repoHost(links api.RepositoryLinks) string {
+	if u, err := url.Parse(links.HTML.Href); err == nil && u.Hostname() != "" {
+		return u.Hostname()
 	}
-	return false
+	return config.DefaultHost
 }
 
 // printDeleteWarning prints a warning message about repository deletion
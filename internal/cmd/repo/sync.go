@@ -1,11 +1,11 @@
 package repo
 
 import (
-	"bufio"
 	"bytes"
 	"context"
+	"errors"
 	"fmt"
-	"io"
+	"net/http"
 	"os/exec"
 	"strings"
 	"time"
@@ -18,8 +18,12 @@ import (
 	"github.com/rbansal42/bitbucket-cli/internal/iostreams"
 )
 
+// maxSyncLogLines bounds how many synced commits are listed
+const maxSyncLogLines = 10
+
 type syncOptions struct {
 	streams   *iostreams.IOStreams
+	repoArg   string
 	branch    string
 	force     bool
 	workspace string
@@ -33,23 +37,38 @@ func NewCmdSync(streams *iostreams.IOStreams) *cobra.Command {
 	}
 
 	cmd := &cobra.Command{
-		Use:   "sync",
+		Use:   "sync [<workspace/repo>]",
 		Short: "Sync fork with upstream repository",
-		Long: `Sync the current fork with its upstream (parent) repository.
+		Long: `Sync a fork with its upstream (parent) repository.
+
+When run inside a clone of the fork, the branch is updated locally: bb
+fetches it from the upstream repository, fast-forwards the local branch and
+pushes it to the fork. The branch does not need to be checked out.
 
-This command fetches changes from the upstream repository and updates
-the local branch. The repository must be a fork.
+When the fork is given as an argument and there is no local clone of it, the
+sync happens on Bitbucket instead: bb opens a pull request from the upstream
+branch into the fork and merges it with a fast-forward. This only works when
+the fork has no commits of its own on that branch.
 
-By default, the main branch is synced. Use --branch to specify a different branch.`,
+By default, the main branch is synced. Use --branch to specify a different
+branch, and --force to reset the branch to upstream, discarding commits that
+are only in the fork. --force needs a local clone.`,
 		Example: `  # Sync the default branch with upstream
   bb repo sync
 
   # Sync a specific branch
   bb repo sync --branch develop
 
+  # Sync a fork without cloning it
+  bb repo sync myworkspace/my-fork
+
   # Force sync (reset to upstream, discarding local changes)
   bb repo sync --force`,
+		Args: cobra.MaximumNArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) > 0 {
+				opts.repoArg = args[0]
+			}
 			return runSync(opts)
 		},
 	}
@@ -57,19 +76,32 @@ By default, the main branch is synced. Use --branch to specify a different branc
 	cmd.Flags().StringVarP(&opts.branch, "branch", "b", "", "Branch to sync (default: main branch)")
 	cmd.Flags().BoolVarP(&opts.force, "force", "f", false, "Force update (reset to upstream, discarding local changes)")
 
+	cmd.ValidArgsFunction = cmdutil.CompleteRepoNames
 	_ = cmd.RegisterFlagCompletionFunc("branch", cmdutil.CompleteBranchNames)
 
 	return cmd
 }
 
 func runSync(opts *syncOptions) error {
-	// Detect current repository from git
-	remote, err := git.GetDefaultRemote()
-	if err != nil {
-		return fmt.Errorf("could not detect repository: %w", err)
+	// A local clone of the fork is used when there is one
+	remote, remoteErr := git.GetDefaultRemote()
+	if opts.repoArg == "" {
+		if remoteErr != nil {
+			return fmt.Errorf("could not detect repository: %w", remoteErr)
+		}
+		opts.workspace = remote.Workspace
+		opts.repoSlug = remote.RepoSlug
+	} else {
+		workspace, repoSlug, err := cmdutil.ParseRepository(opts.repoArg)
+		if err != nil {
+			return err
+		}
+		opts.workspace = workspace
+		opts.repoSlug = repoSlug
+		if remoteErr != nil || !strings.EqualFold(remote.Workspace+"/"+remote.RepoSlug, workspace+"/"+repoSlug) {
+			remote = nil
+		}
 	}
-	opts.workspace = remote.Workspace
-	opts.repoSlug = remote.RepoSlug
 
 	// Get authenticated client
 	client, err := cmdutil.GetAPIClient()
@@ -90,23 +122,26 @@ func runSync(opts *syncOptions) error {
 	if repo.Parent == nil {
 		return fmt.Errorf("this repository is not a fork; nothing to sync with")
 	}
+	if repo.Parent.Workspace == nil {
+		return fmt.Errorf("parent repository has no workspace information")
+	}
+
+	parentFullName := fmt.Sprintf("%s/%s", repo.Parent.Workspace.Slug, repo.Parent.Slug)
 
 	// Determine branch to sync
 	branch := detectDefaultBranch(getMainBranchName(repo), opts.branch)
 
-	// Build parent repository URL
-	if repo.Parent.Workspace == nil {
-		return fmt.Errorf("parent repository has no workspace information")
+	if remote == nil {
+		return syncOnServer(ctx, client, opts, parentFullName, branch)
 	}
-	parentWorkspace := repo.Parent.Workspace.Slug
-	parentSlug := repo.Parent.Slug
-	parentFullName := fmt.Sprintf("%s/%s", parentWorkspace, parentSlug)
+	return syncLocal(opts, remote.Name, parentFullName, buildParentURL(repo.Parent), branch)
+}
 
+// syncLocal updates branch in the local clone from the upstream repository
+// and pushes it to the fork's remote
+func syncLocal(opts *syncOptions, originRemote, parentFullName, parentURL, branch string) error {
 	// Setup upstream remote if needed
 	upstreamRemote := getUpstreamRemoteName()
-	parentURL := buildParentURL(repo.Parent)
-
-	// Add upstream remote if it doesn't exist
 	if err := ensureUpstreamRemote(upstreamRemote, parentURL); err != nil {
 		return fmt.Errorf("failed to set up upstream remote: %w", err)
 	}
@@ -118,34 +153,160 @@ func runSync(opts *syncOptions) error {
 		return fmt.Errorf("failed to fetch from upstream: %w", err)
 	}
 
-	// Merge or reset
-	if opts.force {
-		// Require confirmation for force reset (destructive operation)
-		if !opts.streams.IsStdinTTY() {
-			return fmt.Errorf("cannot confirm force sync: stdin is not a terminal\nForce sync requires interactive confirmation as it discards local changes")
+	upstreamRef := upstreamRemote + "/" + branch
+	oldHead, _ := runGit("rev-parse", "--verify", "--quiet", "refs/heads/"+branch)
+	newHead, err := runGit("rev-parse", "--verify", upstreamRef)
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %w", upstreamRef, err)
+	}
+	// The fork can be behind even when the local branch isn't, so it is
+	// compared too, as last fetched or pushed
+	forkHead, _ := runGit("rev-parse", "--verify", "--quiet", "refs/remotes/"+originRemote+"/"+branch)
+	if oldHead == newHead && forkHead == newHead {
+		opts.streams.Success("%s is already up to date with upstream %s", branch, parentFullName)
+		return nil
+	}
+
+	currentBranch, _ := git.GetCurrentBranch()
+
+	// Merge or reset, unless only the fork needs updating
+	if oldHead != newHead {
+		if opts.force {
+			// Require confirmation for force reset (destructive operation)
+			question := fmt.Sprintf("This will discard ALL local changes on branch '%s'. Are you sure you want to force sync?", branch)
+			confirmed, err := cmdutil.Confirm(opts.streams, question, false, "force sync discards local changes and always asks for confirmation")
+			if err != nil {
+				return err
+			}
+			if !confirmed {
+				return fmt.Errorf("force sync cancelled")
+			}
+
+			if currentBranch == branch {
+				err = resetToUpstream(upstreamRemote, branch)
+			} else {
+				_, err = runGit("branch", "--force", branch, upstreamRef)
+			}
+			if err != nil {
+				return fmt.Errorf("failed to reset to upstream: %w", err)
+			}
+		} else {
+			if currentBranch == branch {
+				err = mergeUpstream(upstreamRemote, branch)
+			} else {
+				// Fetching from the local repository into the branch updates it
+				// without a checkout, and only if it is a fast-forward
+				_, err = runGit("fetch", ".", upstreamRef+":refs/heads/"+branch)
+			}
+			if err != nil {
+				return fmt.Errorf("failed to merge upstream changes: %w", err)
+			}
 		}
+	}
+
+	pushArgs := []string{"push", originRemote, "refs/heads/" + branch + ":refs/heads/" + branch}
+	if opts.force {
+		pushArgs = append(pushArgs, "--force")
+	}
+	opts.streams.Info("Pushing %s to %s...", branch, originRemote)
+	if _, err := runGit(pushArgs...); err != nil {
+		return fmt.Errorf("failed to push %s: %w", branch, err)
+	}
+
+	opts.streams.Success("Synced %s with upstream %s", branch, parentFullName)
+	if oldHead == newHead {
+		// The commits are new to the fork rather than to the local branch
+		oldHead = forkHead
+	}
+	printSyncedCommits(opts.streams, oldHead, newHead)
+	return nil
+}
 
-		opts.streams.Warning("This will discard ALL local changes on branch '%s'", branch)
-		fmt.Fprintf(opts.streams.Out, "Are you sure you want to force sync? [y/N] ")
+// printSyncedCommits lists the commits between oldHead and newHead. When the
+// branch was reset rather than fast-forwarded only the two heads are shown.
+func printSyncedCommits(streams *iostreams.IOStreams, oldHead, newHead string) {
+	if oldHead == "" {
+		fmt.Fprintf(streams.Out, "  created at %s\n", cmdutil.ShortHash(newHead))
+		return
+	}
+	if _, err := runGit("merge-base", "--is-ancestor", oldHead, newHead); err != nil {
+		fmt.Fprintf(streams.Out, "  reset from %s to %s\n", cmdutil.ShortHash(oldHead), cmdutil.ShortHash(newHead))
+		return
+	}
 
-		if !confirmForceSync(opts.streams.In) {
-			return fmt.Errorf("force sync cancelled")
+	fmt.Fprintf(streams.Out, "  %s..%s\n", cmdutil.ShortHash(oldHead), cmdutil.ShortHash(newHead))
+	log, err := runGit("log", "--oneline", "--no-decorate", oldHead+".."+newHead)
+	if err != nil || log == "" {
+		return
+	}
+	lines := strings.Split(log, "\n")
+	for i, line := range lines {
+		if i == maxSyncLogLines {
+			fmt.Fprintf(streams.Out, "  ... and %d more\n", len(lines)-maxSyncLogLines)
+			break
 		}
+		fmt.Fprintf(streams.Out, "  %s\n", line)
+	}
+}
+
+// syncOnServer brings branch in the fork up to date without a local clone,
+// by opening a pull request from the upstream branch and fast-forwarding it
+func syncOnServer(ctx context.Context, client *api.Client, opts *syncOptions, parentFullName, branch string) error {
+	fullName := opts.workspace + "/" + opts.repoSlug
+	if opts.force {
+		return fmt.Errorf("--force needs a local clone of %s; Bitbucket cannot reset a branch to upstream on the server", fullName)
+	}
+
+	var oldHead string
+	if b, err := client.GetBranch(ctx, opts.workspace, opts.repoSlug, branch); err == nil && b.Target != nil {
+		oldHead = b.Target.Hash
+	}
 
-		if err := resetToUpstream(upstreamRemote, branch); err != nil {
-			return fmt.Errorf("failed to reset to upstream: %w", err)
+	opts.streams.Info("Syncing %s:%s with upstream %s on Bitbucket...", fullName, branch, parentFullName)
+	pr, err := client.CreatePullRequest(ctx, opts.workspace, opts.repoSlug, &api.PRCreateOptions{
+		Title:             fmt.Sprintf("Sync %s with %s", branch, parentFullName),
+		Description:       "Opened by `bb repo sync` to bring the fork up to date with its upstream repository.",
+		SourceBranch:      branch,
+		SourceRepo:        parentFullName,
+		DestinationBranch: branch,
+	})
+	if err != nil {
+		if isNoChangesError(err) {
+			opts.streams.Success("%s is already up to date with upstream %s", branch, parentFullName)
+			return nil
 		}
-	} else {
-		if err := mergeUpstream(upstreamRemote, branch); err != nil {
-			return fmt.Errorf("failed to merge upstream changes: %w", err)
+		return fmt.Errorf("failed to open sync pull request: %w", err)
+	}
+
+	merged, err := client.MergePullRequest(ctx, opts.workspace, opts.repoSlug, pr.ID, &api.PRMergeOptions{
+		MergeStrategy: api.MergeStrategyFastForward,
+	})
+	if err != nil {
+		if _, declineErr := client.DeclinePullRequest(ctx, opts.workspace, opts.repoSlug, pr.ID); declineErr != nil {
+			opts.streams.Warning("Could not decline sync pull request #%d: %v", pr.ID, declineErr)
 		}
+		return fmt.Errorf("could not fast-forward %s to upstream; the fork may have commits of its own, so sync from a local clone instead (use --force to discard them): %w", branch, err)
 	}
 
-	opts.streams.Success("Synced with upstream %s", parentFullName)
-	fmt.Fprintf(opts.streams.Out, "  %s is now up to date\n", branch)
+	opts.streams.Success("Synced %s:%s with upstream %s (pull request #%d)", fullName, branch, parentFullName, pr.ID)
+	newHead := ""
+	if merged.MergeCommit != nil {
+		newHead = merged.MergeCommit.Hash
+	}
+	if oldHead != "" && newHead != "" {
+		fmt.Fprintf(opts.streams.Out, "  %s..%s\n", cmdutil.ShortHash(oldHead), cmdutil.ShortHash(newHead))
+	}
 	return nil
 }
 
+// isNoChangesError reports whether a pull request could not be created
+// because the source has nothing the destination lacks
+func isNoChangesError(err error) bool {
+	var apiErr *api.APIError
+	return errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusBadRequest &&
+		strings.Contains(strings.ToLower(apiErr.Error()), "no changes")
+}
+
 // detectDefaultBranch determines which branch to sync
 func detectDefaultBranch(mainBranch, flagBranch string) string {
 	if flagBranch != "" {
@@ -197,67 +358,34 @@ func ensureUpstreamRemote(remoteName, url string) error {
 
 // fetchUpstream fetches from the upstream remote
 func fetchUpstream(remote, refspec string) error {
-	cmd := exec.Command("git", "fetch", remote, refspec)
-	var stderr bytes.Buffer
-	cmd.Stderr = &stderr
-	if err := cmd.Run(); err != nil {
-		if stderr.Len() > 0 {
-			return fmt.Errorf("%w: %s", err, stderr.String())
-		}
-		return err
-	}
-	return nil
+	_, err := runGit("fetch", remote, refspec)
+	return err
 }
 
 // mergeUpstream merges changes from upstream
 func mergeUpstream(remote, branch string) error {
-	ref := fmt.Sprintf("%s/%s", remote, branch)
-	cmd := exec.Command("git", "merge", ref, "--ff-only")
-	var stderr bytes.Buffer
-	cmd.Stderr = &stderr
-	if err := cmd.Run(); err != nil {
-		if stderr.Len() > 0 {
-			return fmt.Errorf("%w: %s", err, stderr.String())
-		}
-		return err
-	}
-	return nil
+	_, err := runGit("merge", fmt.Sprintf("%s/%s", remote, branch), "--ff-only")
+	return err
 }
 
 // resetToUpstream resets the current branch to upstream
 func resetToUpstream(remote, branch string) error {
-	ref := fmt.Sprintf("%s/%s", remote, branch)
-	cmd := exec.Command("git", "reset", "--hard", ref)
-	var stderr bytes.Buffer
+	_, err := runGit("reset", "--hard", fmt.Sprintf("%s/%s", remote, branch))
+	return err
+}
+
+// runGit runs git and returns its trimmed output, including stderr in the
+// error when it fails
+func runGit(args ...string) (string, error) {
+	cmd := exec.Command("git", args...)
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
 	cmd.Stderr = &stderr
 	if err := cmd.Run(); err != nil {
 		if stderr.Len() > 0 {
-			return fmt.Errorf("%w: %s", err, stderr.String())
+			return "", fmt.Errorf("%w: %s", err, strings.TrimSpace(stderr.String()))
 		}
-		return err
-	}
-	return nil
-}
-
-// confirmForceSync prompts the user to confirm force sync operation
-func confirmForceSync(in interface{}) bool {
-	var reader *bufio.Reader
-
-	// Handle different input types
-	switch r := in.(type) {
-	case *bufio.Reader:
-		reader = r
-	case io.Reader:
-		reader = bufio.NewReader(r)
-	default:
-		return false
+		return "", err
 	}
-
-	input, err := reader.ReadString('\n')
-	if err != nil {
-		return false
-	}
-
-	input = strings.TrimSpace(strings.ToLower(input))
-	return input == "y" || input == "yes"
+	return strings.TrimSpace(stdout.String()), nil
 }
@@ -1,7 +1,20 @@
 package repo
 
 import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os/exec"
+	"path/filepath"
+	"strings"
 	"testing"
+
+	"github.com/rbansal42/bitbucket-cli/internal/api"
+	"github.com/rbansal42/bitbucket-cli/internal/cmdutil"
+	"github.com/rbansal42/bitbucket-cli/internal/iostreams"
 )
 
 func TestDetectDefaultBranch(t *testing.T) {
@@ -111,3 +124,150 @@ func TestBuildFetchRefspec(t *testing.T) {
 		})
 	}
 }
+
+func TestSyncLocal(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not available")
+	}
+
+	git := func(dir string, args ...string) string {
+		t.Helper()
+		cmd := exec.Command("git", append([]string{"-C", dir}, args...)...)
+		cmd.Env = append(cmd.Environ(), "GIT_AUTHOR_NAME=t", "GIT_AUTHOR_EMAIL=t@example.com", "GIT_COMMITTER_NAME=t", "GIT_COMMITTER_EMAIL=t@example.com")
+		out, err := cmd.CombinedOutput()
+		if err != nil {
+			t.Fatalf("git %v: %v\n%s", args, err, out)
+		}
+		return strings.TrimSpace(string(out))
+	}
+
+	root := t.TempDir()
+	upstream := filepath.Join(root, "upstream")
+	fork := filepath.Join(root, "fork.git")
+	work := filepath.Join(root, "work")
+
+	git(root, "init", "-q", "-b", "main", upstream)
+	git(upstream, "commit", "-q", "--allow-empty", "-m", "initial")
+	git(root, "clone", "-q", "--bare", upstream, fork)
+	git(root, "clone", "-q", fork, work)
+	git(work, "checkout", "-q", "-b", "feature")
+	git(upstream, "commit", "-q", "--allow-empty", "-m", "upstream change")
+
+	t.Chdir(work)
+	out := &bytes.Buffer{}
+	opts := &syncOptions{streams: &iostreams.IOStreams{Out: out, ErrOut: &bytes.Buffer{}}}
+	if err := syncLocal(opts, "origin", "up/repo", upstream, "main"); err != nil {
+		t.Fatalf("syncLocal() error: %v", err)
+	}
+
+	if got, want := git(fork, "rev-parse", "main"), git(upstream, "rev-parse", "main"); got != want {
+		t.Errorf("fork main = %s, want upstream main %s", got, want)
+	}
+	if branch := git(work, "rev-parse", "--abbrev-ref", "HEAD"); branch != "feature" {
+		t.Errorf("current branch = %s, want feature to stay checked out", branch)
+	}
+	if !strings.Contains(out.String(), "upstream change") {
+		t.Errorf("output = %q, want the synced commit listed", out.String())
+	}
+
+	out.Reset()
+	if err := syncLocal(opts, "origin", "up/repo", upstream, "main"); err != nil {
+		t.Fatalf("second syncLocal() error: %v", err)
+	}
+	if !strings.Contains(out.String(), "already up to date") {
+		t.Errorf("output = %q, want an up to date message", out.String())
+	}
+
+	// A local branch that is already current still updates a fork that
+	// is behind
+	git(upstream, "commit", "-q", "--allow-empty", "-m", "second upstream change")
+	git(work, "fetch", "-q", "upstream", "main")
+	git(work, "branch", "-q", "-f", "main", "upstream/main")
+	out.Reset()
+	if err := syncLocal(opts, "origin", "up/repo", upstream, "main"); err != nil {
+		t.Fatalf("third syncLocal() error: %v", err)
+	}
+	if got, want := git(fork, "rev-parse", "main"), git(upstream, "rev-parse", "main"); got != want {
+		t.Errorf("fork main = %s, want upstream main %s", got, want)
+	}
+	if !strings.Contains(out.String(), "second upstream change") {
+		t.Errorf("output = %q, want the commit pushed to the fork listed", out.String())
+	}
+
+	// --force asks before discarding local changes, and can't run without
+	// a prompt
+	git(upstream, "commit", "-q", "--allow-empty", "-m", "third upstream change")
+	mainBefore := git(work, "rev-parse", "main")
+	forceOpts := &syncOptions{force: true, streams: &iostreams.IOStreams{In: strings.NewReader("n\n"), Out: out, ErrOut: &bytes.Buffer{}}}
+	forceOpts.streams.SetStdinTTY(true)
+	if err := syncLocal(forceOpts, "origin", "up/repo", upstream, "main"); err == nil || !strings.Contains(err.Error(), "cancelled") {
+		t.Errorf("declined force sync error = %v, want cancelled", err)
+	}
+	forceOpts.streams.SetStdinTTY(false)
+	var noPrompt *cmdutil.NoPromptError
+	if err := syncLocal(forceOpts, "origin", "up/repo", upstream, "main"); !errors.As(err, &noPrompt) {
+		t.Errorf("non-interactive force sync error = %v, want a NoPromptError", err)
+	}
+	if got := git(work, "rev-parse", "main"); got != mainBefore {
+		t.Errorf("main moved to %s without confirmation", got)
+	}
+}
+
+func TestSyncOnServer(t *testing.T) {
+	var created map[string]interface{}
+	var mergeStrategy string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/repositories/me/fork/refs/branches/main":
+			fmt.Fprint(w, `{"name": "main", "target": {"hash": "1111111aaaa"}}`)
+		case "/repositories/me/fork/pullrequests":
+			if err := json.NewDecoder(r.Body).Decode(&created); err != nil {
+				t.Errorf("failed to decode body: %v", err)
+			}
+			fmt.Fprint(w, `{"id": 5}`)
+		case "/repositories/me/fork/pullrequests/5/merge":
+			var body map[string]interface{}
+			_ = json.NewDecoder(r.Body).Decode(&body)
+			mergeStrategy, _ = body["merge_strategy"].(string)
+			fmt.Fprint(w, `{"id": 5, "state": "MERGED", "merge_commit": {"hash": "2222222bbbb"}}`)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	client := api.NewClient(api.WithBaseURL(server.URL), api.WithToken("test-token"))
+	out := &bytes.Buffer{}
+	opts := &syncOptions{streams: &iostreams.IOStreams{Out: out, ErrOut: &bytes.Buffer{}}, workspace: "me", repoSlug: "fork"}
+
+	if err := syncOnServer(t.Context(), client, opts, "up/repo", "main"); err != nil {
+		t.Fatalf("syncOnServer() error: %v", err)
+	}
+
+	source, _ := created["source"].(map[string]interface{})
+	repo, _ := source["repository"].(map[string]interface{})
+	if repo["full_name"] != "up/repo" {
+		t.Errorf("pull request source = %v, want the upstream repository", source)
+	}
+	if mergeStrategy != "fast_forward" {
+		t.Errorf("merge strategy = %q, want fast_forward", mergeStrategy)
+	}
+	if !strings.Contains(out.String(), "1111111..2222222") {
+		t.Errorf("output = %q, want the old and new heads", out.String())
+	}
+
+	opts.force = true
+	if err := syncOnServer(t.Context(), client, opts, "up/repo", "main"); err == nil {
+		t.Error("syncOnServer() with --force expected an error")
+	}
+}
+
+func TestIsNoChangesError(t *testing.T) {
+	err := &api.APIError{StatusCode: http.StatusBadRequest, Message: "There are no changes to be pulled"}
+	if !isNoChangesError(err) {
+		t.Error("isNoChangesError() = false for a no changes response")
+	}
+	if isNoChangesError(&api.APIError{StatusCode: http.StatusBadRequest, Message: "branch not found"}) {
+		t.Error("isNoChangesError() = true for an unrelated error")
+	}
+}
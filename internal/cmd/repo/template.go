@@ -0,0 +1,132 @@
+package repo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/rbansal42/bitbucket-cli/internal/api"
+	"github.com/rbansal42/bitbucket-cli/internal/cmdutil"
+	"github.com/rbansal42/bitbucket-cli/internal/iostreams"
+)
+
+// prepareTemplate looks up the template repository and checks that the
+// target can be seeded from it. It returns the template and, if the target
+// already exists and is empty, the target.
+func prepareTemplate(ctx context.Context, client *api.Client, templateArg, workspace, name string) (template, existing *api.RepositoryFull, err error) {
+	templateWs, templateSlug, err := cmdutil.ParseRepoArg(templateArg)
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid --from-template: %w", err)
+	}
+	if templateWs == workspace && strings.EqualFold(templateSlug, name) {
+		return nil, nil, fmt.Errorf("a repository cannot be created from itself")
+	}
+
+	template, err = client.GetRepository(ctx, templateWs, templateSlug)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get template %s/%s: %w", templateWs, templateSlug, err)
+	}
+
+	existing, err = checkTemplateTarget(ctx, client, workspace, name)
+	if err != nil {
+		return nil, nil, err
+	}
+	return template, existing, nil
+}
+
+// checkTemplateTarget returns workspace/name if it already exists, or nil
+// if it does not. An existing repository is only accepted if it has no
+// branches, so seeding it from a template cannot overwrite anything.
+func checkTemplateTarget(ctx context.Context, client *api.Client, workspace, name string) (*api.RepositoryFull, error) {
+	repo, err := client.GetRepository(ctx, workspace, name)
+	var apiErr *api.APIError
+	if errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusNotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to check %s/%s: %w", workspace, name, err)
+	}
+
+	branches, err := client.ListBranches(ctx, workspace, name, &api.BranchListOptions{Limit: 1})
+	if err != nil {
+		return nil, fmt.Errorf("failed to check %s/%s: %w", workspace, name, err)
+	}
+	if len(branches.Values) > 0 {
+		return nil, fmt.Errorf("repository %s/%s already exists and is not empty", workspace, name)
+	}
+	return repo, nil
+}
+
+// seedFromTemplate clones the default branch of the template into a
+// temporary directory, points it at the new repository, and pushes it. With
+// squash, the template's history is replaced by a single commit. The
+// temporary clone is always removed.
+func seedFromTemplate(streams *iostreams.IOStreams, templateName, templateURL, targetURL string, squash bool) error {
+	dir, err := os.MkdirTemp("", "bb-template-")
+	if err != nil {
+		return fmt.Errorf("failed to create temporary directory: %w", err)
+	}
+	defer os.RemoveAll(dir)
+
+	streams.Info("Cloning template %s...", templateName)
+	if err := runTemplateGit(streams, "", "clone", "--quiet", "--single-branch", templateURL, dir); err != nil {
+		return fmt.Errorf("failed to clone template: %w", err)
+	}
+
+	out, err := exec.Command("git", "-C", dir, "rev-parse", "--abbrev-ref", "HEAD").Output()
+	if err != nil {
+		return fmt.Errorf("template %s has no commits to copy", templateName)
+	}
+	branch := strings.TrimSpace(string(out))
+
+	if squash {
+		streams.Info("Squashing template history...")
+		if err := squashHistory(streams, dir, branch, templateName); err != nil {
+			return err
+		}
+	}
+
+	if err := runTemplateGit(streams, dir, "remote", "set-url", "origin", targetURL); err != nil {
+		return fmt.Errorf("failed to point the clone at the new repository: %w", err)
+	}
+
+	streams.Info("Pushing %s...", branch)
+	if err := runTemplateGit(streams, dir, "push", "--quiet", "origin", branch); err != nil {
+		return fmt.Errorf("failed to push %s: %w", branch, err)
+	}
+
+	streams.Success("Seeded %s from template %s", branch, templateName)
+	return nil
+}
+
+// squashHistory replaces the history of branch with a single commit
+// holding its current tree
+func squashHistory(streams *iostreams.IOStreams, dir, branch, templateName string) error {
+	steps := [][]string{
+		{"checkout", "--quiet", "--orphan", "bb-template-squash"},
+		{"commit", "--quiet", "--allow-empty", "-m", fmt.Sprintf("Initial commit from template %s", templateName)},
+		{"branch", "-M", branch},
+	}
+	for _, args := range steps {
+		if err := runTemplateGit(streams, dir, args...); err != nil {
+			return fmt.Errorf("failed to squash template history: %w", err)
+		}
+	}
+	return nil
+}
+
+// runTemplateGit runs git in dir, or the current directory if dir is
+// empty, sending its output to stderr
+func runTemplateGit(streams *iostreams.IOStreams, dir string, args ...string) error {
+	if dir != "" {
+		args = append([]string{"-C", dir}, args...)
+	}
+	cmd := exec.Command("git", args...)
+	cmd.Stdout = streams.ErrOut
+	cmd.Stderr = streams.ErrOut
+	return cmd.Run()
+}
@@ -0,0 +1,88 @@
+package repo
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os/exec"
+	"strings"
+	"testing"
+
+	"github.com/rbansal42/bitbucket-cli/internal/api"
+	"github.com/rbansal42/bitbucket-cli/internal/iostreams"
+)
+
+func TestCheckTemplateTarget(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/repositories/ws/empty", "/repositories/ws/full":
+			fmt.Fprintf(w, `{"full_name": "ws/%s"}`, strings.TrimPrefix(r.URL.Path, "/repositories/ws/"))
+		case "/repositories/ws/empty/refs/branches":
+			fmt.Fprint(w, `{"values": []}`)
+		case "/repositories/ws/full/refs/branches":
+			fmt.Fprint(w, `{"values": [{"name": "main"}]}`)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			fmt.Fprint(w, `{"type": "error", "error": {"message": "not found"}}`)
+		}
+	}))
+	defer server.Close()
+	client := api.NewClient(api.WithBaseURL(server.URL), api.WithToken("test-token"))
+	ctx := context.Background()
+
+	if repo, err := checkTemplateTarget(ctx, client, "ws", "missing"); err != nil || repo != nil {
+		t.Errorf("checkTemplateTarget(missing) = %v, %v; want nil, nil", repo, err)
+	}
+	if repo, err := checkTemplateTarget(ctx, client, "ws", "empty"); err != nil || repo == nil || repo.FullName != "ws/empty" {
+		t.Errorf("checkTemplateTarget(empty) = %v, %v; want the existing repository", repo, err)
+	}
+	if _, err := checkTemplateTarget(ctx, client, "ws", "full"); err == nil || !strings.Contains(err.Error(), "not empty") {
+		t.Errorf("checkTemplateTarget(full) error = %v, want a not-empty error", err)
+	}
+}
+
+func TestSeedFromTemplate(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not available")
+	}
+	for _, v := range []string{"GIT_AUTHOR_NAME", "GIT_COMMITTER_NAME"} {
+		t.Setenv(v, "t")
+	}
+	for _, v := range []string{"GIT_AUTHOR_EMAIL", "GIT_COMMITTER_EMAIL"} {
+		t.Setenv(v, "t@example.com")
+	}
+
+	git := func(dir string, args ...string) string {
+		t.Helper()
+		out, err := exec.Command("git", append([]string{"-C", dir}, args...)...).CombinedOutput()
+		if err != nil {
+			t.Fatalf("git %v: %v\n%s", args, err, out)
+		}
+		return strings.TrimSpace(string(out))
+	}
+
+	template := t.TempDir()
+	git(template, "init", "-q", "-b", "trunk")
+	git(template, "commit", "-q", "--allow-empty", "-m", "first")
+	git(template, "commit", "-q", "--allow-empty", "-m", "second")
+
+	for _, squash := range []bool{false, true} {
+		target := t.TempDir()
+		git(target, "init", "-q", "--bare")
+
+		streams := &iostreams.IOStreams{Out: &bytes.Buffer{}, ErrOut: &bytes.Buffer{}}
+		if err := seedFromTemplate(streams, "ws/template", template, target, squash); err != nil {
+			t.Fatalf("seedFromTemplate(squash %v) error: %v", squash, err)
+		}
+
+		want := "2"
+		if squash {
+			want = "1"
+		}
+		if got := git(target, "rev-list", "--count", "trunk"); got != want {
+			t.Errorf("seedFromTemplate(squash %v) pushed %s commits, want %s", squash, got, want)
+		}
+	}
+}
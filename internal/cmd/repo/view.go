@@ -12,6 +12,7 @@ import (
 	"github.com/rbansal42/bitbucket-cli/internal/api"
 	"github.com/rbansal42/bitbucket-cli/internal/browser"
 	"github.com/rbansal42/bitbucket-cli/internal/cmdutil"
+	"github.com/rbansal42/bitbucket-cli/internal/config"
 	"github.com/rbansal42/bitbucket-cli/internal/iostreams"
 )
 
@@ -20,6 +21,7 @@ type viewOptions struct {
 	repoArg   string
 	web       bool
 	jsonOut   bool
+	protocol  string
 	workspace string
 	repoSlug  string
 }
@@ -49,7 +51,10 @@ You can specify a repository using the workspace/repo format.`,
   bb repo view --web
 
   # Output as JSON
-  bb repo view --json`,
+  bb repo view --json
+
+  # List the SSH clone URL first
+  bb repo view --protocol ssh`,
 		Args: cobra.MaximumNArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			if len(args) > 0 {
@@ -62,6 +67,9 @@ You can specify a repository using the workspace/repo format.`,
 
 	cmd.Flags().BoolVarP(&opts.web, "web", "w", false, "Open the repository in a web browser")
 	cmd.Flags().BoolVar(&opts.jsonOut, "json", false, "Output in JSON format")
+	cmd.Flags().StringVar(&opts.protocol, "protocol", "", "Preferred clone URL protocol: https or ssh")
+
+	_ = cmd.RegisterFlagCompletionFunc("protocol", cmdutil.StaticFlagCompletion(config.GitProtocols))
 
 	cmd.ValidArgsFunction = cmdutil.CompleteRepoNames
 
@@ -69,6 +77,10 @@ You can specify a repository using the workspace/repo format.`,
 }
 
 func runView(opts *viewOptions) error {
+	if err := validateProtocol(opts.protocol); err != nil {
+		return err
+	}
+
 	// Resolve repository
 	var err error
 	opts.workspace, opts.repoSlug, err = cmdutil.ParseRepository(opts.repoArg)
@@ -86,6 +98,7 @@ func runView(opts *viewOptions) error {
 	defer cancel()
 
 	// Fetch repository details
+	opts.streams.Verbose("Fetching repository %s/%s...", opts.workspace, opts.repoSlug)
 	repo, err := client.GetRepository(ctx, opts.workspace, opts.repoSlug)
 	if err != nil {
 		return fmt.Errorf("failed to get repository: %w", err)
@@ -106,7 +119,7 @@ func runView(opts *viewOptions) error {
 	}
 
 	// Display formatted output
-	return displayRepo(opts.streams, repo)
+	return displayRepo(opts.streams, repo, getPreferredProtocol(repoHost(repo.Links), opts.protocol))
 }
 
 func outputJSON(streams *iostreams.IOStreams, repo *api.RepositoryFull) error {
@@ -118,7 +131,7 @@ func outputJSON(streams *iostreams.IOStreams, repo *api.RepositoryFull) error {
 	return nil
 }
 
-func displayRepo(streams *iostreams.IOStreams, repo *api.RepositoryFull) error {
+func displayRepo(streams *iostreams.IOStreams, repo *api.RepositoryFull, protocol string) error {
 	// Header - workspace/repo
 	fmt.Fprintf(streams.Out, "%s\n\n", repo.FullName)
 
@@ -154,10 +167,10 @@ func displayRepo(streams *iostreams.IOStreams, repo *api.RepositoryFull) error {
 		fmt.Fprintf(streams.Out, "Project:     %s\n", repo.Project.Key)
 	}
 
-	// Clone URLs
+	// Clone URLs, preferred protocol first
 	fmt.Fprintln(streams.Out)
 	fmt.Fprintln(streams.Out, "Clone URLs:")
-	for _, clone := range repo.Links.Clone {
+	for _, clone := range orderCloneLinks(repo.Links.Clone, protocol) {
 		name := strings.ToUpper(clone.Name)
 		fmt.Fprintf(streams.Out, "  %-5s  %s\n", name+":", clone.Href)
 	}
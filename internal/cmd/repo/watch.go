@@ -0,0 +1,240 @@
+package repo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/rbansal42/bitbucket-cli/internal/api"
+	"github.com/rbansal42/bitbucket-cli/internal/cmdutil"
+	"github.com/rbansal42/bitbucket-cli/internal/iostreams"
+)
+
+type watchOptions struct {
+	streams *iostreams.IOStreams
+	repoArg string
+	unwatch bool
+}
+
+// NewCmdWatch creates the repo watch command
+func NewCmdWatch(streams *iostreams.IOStreams) *cobra.Command {
+	opts := &watchOptions{
+		streams: streams,
+	}
+
+	cmd := &cobra.Command{
+		Use:   "watch [<workspace/repo>]",
+		Short: "Watch a repository",
+		Long: `Start watching a repository to follow its activity.
+
+With no arguments, the repository for the current directory is used.
+
+Bitbucket documents the watchers API as read-only. If it rejects the change,
+this command reports that watching must be done with the Watch button in the
+web UI; 'bb repo watchers' keeps working either way.`,
+		Example: `  # Watch the current repository
+  bb repo watch
+
+  # Watch a specific repository
+  bb repo watch myworkspace/myrepo`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) > 0 {
+				opts.repoArg = args[0]
+			}
+			return runWatch(cmd.Context(), opts)
+		},
+	}
+
+	cmd.ValidArgsFunction = cmdutil.CompleteRepoNames
+
+	return cmd
+}
+
+// NewCmdUnwatch creates the repo unwatch command
+func NewCmdUnwatch(streams *iostreams.IOStreams) *cobra.Command {
+	opts := &watchOptions{
+		streams: streams,
+		unwatch: true,
+	}
+
+	cmd := &cobra.Command{
+		Use:   "unwatch [<workspace/repo>]",
+		Short: "Stop watching a repository",
+		Long: `Stop watching a repository.
+
+This reverses 'bb repo watch' and has the same API limitations.`,
+		Example: `  # Stop watching a repository
+  bb repo unwatch myworkspace/myrepo`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) > 0 {
+				opts.repoArg = args[0]
+			}
+			return runWatch(cmd.Context(), opts)
+		},
+	}
+
+	cmd.ValidArgsFunction = cmdutil.CompleteRepoNames
+
+	return cmd
+}
+
+func runWatch(ctx context.Context, opts *watchOptions) error {
+	workspace, repoSlug, err := cmdutil.ParseRepository(opts.repoArg)
+	if err != nil {
+		return err
+	}
+
+	client, err := cmdutil.GetAPIClient()
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	user, err := client.CurrentUser(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get current user: %w", err)
+	}
+
+	fullName := workspace + "/" + repoSlug
+
+	if opts.unwatch {
+		wasWatching, err := client.UnwatchRepository(ctx, workspace, repoSlug, user.UUID)
+		if err != nil {
+			return watchError(err, "unwatch")
+		}
+		if !wasWatching {
+			opts.streams.Info("You are not watching %s", fullName)
+			return nil
+		}
+		opts.streams.Success("Stopped watching %s", fullName)
+		return nil
+	}
+
+	alreadyWatching, err := client.WatchRepository(ctx, workspace, repoSlug, user.UUID)
+	if err != nil {
+		return watchError(err, "watch")
+	}
+	if alreadyWatching {
+		opts.streams.Info("Already watching %s", fullName)
+		return nil
+	}
+	opts.streams.Success("Now watching %s", fullName)
+	return nil
+}
+
+func watchError(err error, action string) error {
+	if errors.Is(err, api.ErrWatchNotSupported) {
+		return err
+	}
+	return fmt.Errorf("failed to %s repository: %w", action, err)
+}
+
+type watchersOptions struct {
+	streams   *iostreams.IOStreams
+	repoArg   string
+	limit     int
+	jsonOut   bool
+	showCount bool
+	exitCode  bool
+}
+
+// NewCmdWatchers creates the repo watchers command
+func NewCmdWatchers(streams *iostreams.IOStreams) *cobra.Command {
+	opts := &watchersOptions{
+		streams: streams,
+	}
+
+	cmd := &cobra.Command{
+		Use:   "watchers [<workspace/repo>]",
+		Short: "List users watching a repository",
+		Long: `List the users watching a repository.
+
+With no arguments, the repository for the current directory is used.`,
+		Example: `  # List watchers of the current repository
+  bb repo watchers
+
+  # List watchers of a specific repository as JSON
+  bb repo watchers myworkspace/myrepo --json`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) > 0 {
+				opts.repoArg = args[0]
+			}
+			return runWatchers(cmd.Context(), opts)
+		},
+	}
+
+	cmd.Flags().IntVarP(&opts.limit, "limit", "l", 30, "Maximum number of watchers to list")
+	cmd.Flags().BoolVar(&opts.jsonOut, "json", false, "Output in JSON format")
+	cmdutil.AddShowCountFlag(cmd, &opts.showCount)
+	cmdutil.AddAllFlag(cmd, &opts.limit)
+	cmdutil.AddExitCodeFlag(cmd, &opts.exitCode)
+
+	cmd.ValidArgsFunction = cmdutil.CompleteRepoNames
+
+	return cmd
+}
+
+func runWatchers(ctx context.Context, opts *watchersOptions) error {
+	workspace, repoSlug, err := cmdutil.ParseRepository(opts.repoArg)
+	if err != nil {
+		return err
+	}
+
+	client, err := cmdutil.GetAPIClient()
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	result, err := client.ListWatchers(ctx, workspace, repoSlug, &api.WatcherListOptions{Limit: cmdutil.PageLen(opts.limit, cmdutil.MaxPageLen)})
+	if err == nil {
+		result, err = cmdutil.CollectPages(ctx, client, result, opts.limit)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to list watchers: %w", err)
+	}
+
+	if len(result.Values) == 0 {
+		opts.streams.Info("No one is watching %s/%s", workspace, repoSlug)
+		return cmdutil.NoResults(opts.exitCode)
+	}
+
+	var count *cmdutil.PageCount
+	if opts.showCount {
+		count = cmdutil.NewPageCount(result, len(result.Values))
+	}
+
+	if opts.jsonOut {
+		output := make([]map[string]interface{}, len(result.Values))
+		for i, u := range result.Values {
+			output[i] = map[string]interface{}{
+				"uuid":         u.UUID,
+				"display_name": u.DisplayName,
+				"nickname":     u.Nickname,
+				"account_id":   u.AccountID,
+			}
+		}
+		return cmdutil.PrintListJSON(opts.streams, output, count)
+	}
+
+	t := cmdutil.NewTableWriter(opts.streams, "NAME", "NICKNAME")
+	t.SetFlexColumn(0)
+	for _, u := range result.Values {
+		t.AddRow(cmdutil.GetUserDisplayName(&u), u.Nickname)
+	}
+	if err := t.Render(); err != nil {
+		return err
+	}
+	cmdutil.PrintPageCount(opts.streams, count)
+	return nil
+}
@@ -1,14 +1,19 @@
 package cmd
 
 import (
-	"fmt"
+	"errors"
+	"os"
+	"os/exec"
+	"strings"
 
 	"github.com/spf13/cobra"
 
+	"github.com/rbansal42/bitbucket-cli/internal/cmd/alias"
 	"github.com/rbansal42/bitbucket-cli/internal/cmd/api"
 	"github.com/rbansal42/bitbucket-cli/internal/cmd/auth"
 	"github.com/rbansal42/bitbucket-cli/internal/cmd/branch"
 	"github.com/rbansal42/bitbucket-cli/internal/cmd/browse"
+	"github.com/rbansal42/bitbucket-cli/internal/cmd/commit"
 	"github.com/rbansal42/bitbucket-cli/internal/cmd/completion"
 	bbconfigcmd "github.com/rbansal42/bitbucket-cli/internal/cmd/config"
 	"github.com/rbansal42/bitbucket-cli/internal/cmd/issue"
@@ -17,8 +22,10 @@ import (
 	"github.com/rbansal42/bitbucket-cli/internal/cmd/project"
 	"github.com/rbansal42/bitbucket-cli/internal/cmd/repo"
 	"github.com/rbansal42/bitbucket-cli/internal/cmd/snippet"
+	"github.com/rbansal42/bitbucket-cli/internal/cmd/user"
 	"github.com/rbansal42/bitbucket-cli/internal/cmd/workspace"
 	"github.com/rbansal42/bitbucket-cli/internal/cmdutil"
+	"github.com/rbansal42/bitbucket-cli/internal/config"
 	"github.com/rbansal42/bitbucket-cli/internal/iostreams"
 )
 
@@ -26,6 +33,9 @@ var (
 	// Version is set at build time
 	Version = "dev"
 
+	// Commit is the git commit, set at build time
+	Commit = "none"
+
 	// BuildDate is set at build time
 	BuildDate = "unknown"
 )
@@ -45,7 +55,15 @@ To get started, authenticate with Bitbucket:
 Then you can start using commands like:
   bb pr list
   bb repo clone workspace/repo
-  bb issue create`,
+  bb issue create
+
+Use --no-prompt in scripts to make any command that would ask for input
+fail straight away instead; prompts are also disabled when stdin is not a
+terminal. Use --verbose to see what bb is doing; progress messages go to
+stderr and never mix with command output.
+
+Exit codes: 0 success, 1 error, 2 usage error or missing input,
+3 not found, 4 authentication failure, 8 network error or timeout.`,
 	SilenceUsage:  true,
 	SilenceErrors: true,
 }
@@ -57,35 +75,115 @@ var streams *iostreams.IOStreams
 func Execute() error {
 	streams = iostreams.New()
 
-	err := rootCmd.Execute()
-	if err != nil {
-		streams.Error("%s", err)
+	cmd, err := execute(os.Args[1:])
+
+	// A failing shell alias has already reported its own error, and an
+	// empty --exit-code listing has nothing to report; only the exit status
+	// is passed on
+	var exitErr *exec.ExitError
+	if err != nil && !errors.As(err, &exitErr) && !errors.Is(err, cmdutil.ErrNoResults) {
+		if jsonRequested(cmd) {
+			cmdutil.PrintErrorJSON(streams, err)
+		} else {
+			streams.Error("%s", err)
+		}
 	}
 	return err
 }
 
+// jsonRequested reports whether cmd was run with its --json output flag, so
+// a failure should be reported as JSON too. bb api's --json, which sets
+// request body fields, is not an output flag.
+func jsonRequested(cmd *cobra.Command) bool {
+	if cmd == nil {
+		return false
+	}
+	f := cmd.Flags().Lookup("json")
+	return f != nil && f.Changed && f.Value.Type() == "bool"
+}
+
+// execute runs the command named by args, expanding a user-defined alias
+// first when args doesn't start with a bb command. It returns the command
+// that ran, which is nil for a shell alias.
+func execute(args []string) (*cobra.Command, error) {
+	if len(args) > 0 && !strings.HasPrefix(args[0], "-") {
+		if c, _, err := rootCmd.Find(args); err != nil || c == rootCmd {
+			// An unreadable config file is reported by whichever command
+			// needs it; it shouldn't turn every unknown command into an error
+			if cfg, err := config.LoadConfig(); err == nil {
+				expanded, shellCmd, ok, err := alias.Expand(cfg.Aliases, args)
+				if err != nil {
+					return nil, err
+				}
+				if ok && shellCmd != "" {
+					return nil, runShellAlias(shellCmd, expanded)
+				}
+				if ok {
+					args = expanded
+				}
+			}
+		}
+	}
+
+	rootCmd.SetArgs(args)
+	return rootCmd.ExecuteC()
+}
+
+// runShellAlias runs a shell alias expansion with sh, passing args as the
+// positional parameters so the expansion can use $1 and $@
+func runShellAlias(script string, args []string) error {
+	cmd := exec.Command("sh", append([]string{"-c", script, "--"}, args...)...)
+	cmd.Stdin = GetStreams().In
+	cmd.Stdout = GetStreams().Out
+	cmd.Stderr = GetStreams().ErrOut
+	return cmd.Run()
+}
+
 func init() {
 	// Global flags
-	rootCmd.PersistentFlags().StringP("repo", "R", "", "Select a repository using the WORKSPACE/REPO format")
+	rootCmd.PersistentFlags().StringP("repo", "R", "", "Select a repository using the WORKSPACE/REPO format or a Bitbucket URL")
 	// Register completion for the persistent --repo flag. Subcommands that define
 	// their own local --repo flag will shadow this with their own registration.
 	_ = rootCmd.RegisterFlagCompletionFunc("repo", cmdutil.CompleteRepoNames)
-
-	// Version command
-	rootCmd.AddCommand(&cobra.Command{
-		Use:   "version",
-		Short: "Print the version number of bb",
-		Run: func(cmd *cobra.Command, args []string) {
-			fmt.Printf("bb version %s (%s)\n", Version, BuildDate)
-		},
+	// No shorthand: several subcommands already use -w, either for their own
+	// --workspace flag (which shadows this one) or for unrelated flags like --web.
+	rootCmd.PersistentFlags().String("workspace", "", "Select a workspace (defaults to the configured default or the git remote)")
+	_ = rootCmd.RegisterFlagCompletionFunc("workspace", cmdutil.CompleteWorkspaceNames)
+	rootCmd.PersistentFlags().Bool("no-prompt", false, "Never prompt for input; fail with a hint about the flag to pass instead")
+	rootCmd.PersistentFlags().BoolP("verbose", "v", false, "Print progress messages to stderr")
+	rootCmd.PersistentFlags().Bool("insecure", false, "Skip TLS certificate verification (unsafe; prefer ca_cert in hosts.yml)")
+	rootCmd.PersistentFlags().String("host", "", "Bitbucket host to use, e.g. a Bitbucket Server hostname (default: $BB_HOST or bitbucket.org)")
+	rootCmd.PersistentFlags().Var(cmdutil.PerPageFlag{}, "per-page", "Results to request per page from list endpoints (1-100; overrides per_page)")
+
+	// Flags are parsed by the time initializers run, so --no-prompt is known
+	// before any command reads from stdin
+	cobra.OnInitialize(func() {
+		cmdutil.SetStreams(GetStreams())
+		if noPrompt, _ := rootCmd.PersistentFlags().GetBool("no-prompt"); noPrompt {
+			GetStreams().SetNeverPrompt(true)
+		}
+		// Without --verbose, BB_DEBUG may still have turned verbose output on
+		if verbose, _ := rootCmd.PersistentFlags().GetBool("verbose"); verbose {
+			GetStreams().SetVerbose(true)
+		}
+		if insecure, _ := rootCmd.PersistentFlags().GetBool("insecure"); insecure {
+			cmdutil.SetInsecure(true)
+		}
+		if host, _ := rootCmd.PersistentFlags().GetString("host"); host != "" {
+			cmdutil.SetHost(host)
+		}
 	})
 
+	rootCmd.AddCommand(newCmdVersion(GetStreams()))
+
 	// Add subcommands
+	rootCmd.AddCommand(alias.NewCmdAlias(GetStreams()))
 	rootCmd.AddCommand(auth.NewCmdAuth(GetStreams()))
 	rootCmd.AddCommand(api.NewCmdAPI(GetStreams()))
 	rootCmd.AddCommand(branch.NewCmdBranch(GetStreams()))
 	rootCmd.AddCommand(completion.NewCmdCompletion(GetStreams()))
 	rootCmd.AddCommand(browse.NewCmdBrowse(GetStreams()))
+	rootCmd.AddCommand(commit.NewCmdCommit(GetStreams()))
 	rootCmd.AddCommand(bbconfigcmd.NewCmdConfig(GetStreams()))
 	rootCmd.AddCommand(issue.NewCmdIssue(GetStreams()))
 	rootCmd.AddCommand(pipeline.NewCmdPipeline(GetStreams()))
@@ -93,7 +191,10 @@ func init() {
 	rootCmd.AddCommand(project.NewCmdProject(GetStreams()))
 	rootCmd.AddCommand(repo.NewCmdRepo(GetStreams()))
 	rootCmd.AddCommand(snippet.NewCmdSnippet(GetStreams()))
+	rootCmd.AddCommand(user.NewCmdUser(GetStreams()))
 	rootCmd.AddCommand(workspace.NewCmdWorkspace(GetStreams()))
+
+	markUsageErrors(rootCmd)
 }
 
 // GetStreams returns the global IOStreams instance
@@ -0,0 +1,47 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/spf13/cobra"
+)
+
+func TestJSONRequested(t *testing.T) {
+	newCmd := func(jsonType string) *cobra.Command {
+		cmd := &cobra.Command{Use: "test"}
+		switch jsonType {
+		case "bool":
+			cmd.Flags().Bool("json", false, "")
+		case "field":
+			cmd.Flags().StringArray("json", nil, "")
+		}
+		return cmd
+	}
+
+	tests := []struct {
+		name     string
+		jsonType string
+		args     []string
+		want     bool
+	}{
+		{name: "json output", jsonType: "bool", args: []string{"--json"}, want: true},
+		{name: "json output not given", jsonType: "bool", want: false},
+		{name: "json body field", jsonType: "field", args: []string{"--json", "title=x"}, want: false},
+		{name: "no json flag", want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd := newCmd(tt.jsonType)
+			if err := cmd.ParseFlags(tt.args); err != nil {
+				t.Fatal(err)
+			}
+			if got := jsonRequested(cmd); got != tt.want {
+				t.Errorf("jsonRequested() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	if jsonRequested(nil) {
+		t.Error("expected no JSON for a shell alias")
+	}
+}
@@ -4,9 +4,6 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
-	"io"
-	"os"
-	"path/filepath"
 	"time"
 
 	"github.com/spf13/cobra"
@@ -23,6 +20,7 @@ type CreateOptions struct {
 	Title     string
 	Private   bool
 	Files     []string // File paths to include
+	Filename  string   // Name for content read from stdin
 	Streams   *iostreams.IOStreams
 	JSON      bool
 }
@@ -32,21 +30,30 @@ func NewCmdCreate(streams *iostreams.IOStreams) *cobra.Command {
 	opts := &CreateOptions{Streams: streams}
 
 	cmd := &cobra.Command{
-		Use:   "create",
+		Use:   "create [<file>...]",
 		Short: "Create a new snippet",
 		Long: `Create a new snippet in a Bitbucket workspace.
 
-Specify files to include with --file/-f flag (can be used multiple times).
-If no files are specified, reads from stdin.`,
+Specify files to include as arguments or with the --file/-f flag (can be
+used multiple times). Each file is stored under its base name. Use "-" to
+read one file from stdin, named with --filename. If no files are specified,
+reads from stdin.`,
 		Example: `  # Create a snippet with one file
   bb snippet create --title "My Snippet" --file script.py --workspace myworkspace
 
   # Create a private snippet with multiple files
   bb snippet create --title "Config files" --file config.json --file setup.py --private --workspace myworkspace
 
+  # Create from files given as arguments
+  bb snippet create --title "Scripts" main.go helper.py
+
   # Create from stdin
-  echo "print('hello')" | bb snippet create --title "Hello" --workspace myworkspace`,
+  echo "print('hello')" | bb snippet create --title "Hello" --workspace myworkspace
+
+  # Create from stdin with a file name
+  pbpaste | bb snippet create --title "Hello" --file - --filename hello.py`,
 		RunE: func(cmd *cobra.Command, args []string) error {
+			opts.Files = append(opts.Files, args...)
 			return runCreate(cmd.Context(), opts)
 		},
 	}
@@ -54,7 +61,8 @@ If no files are specified, reads from stdin.`,
 	cmd.Flags().StringVarP(&opts.Workspace, "workspace", "w", "", "Workspace slug (required)")
 	cmd.Flags().StringVarP(&opts.Title, "title", "t", "", "Snippet title (required)")
 	cmd.Flags().BoolVarP(&opts.Private, "private", "p", false, "Make snippet private")
-	cmd.Flags().StringArrayVarP(&opts.Files, "file", "f", nil, "File to include (can be repeated)")
+	cmd.Flags().StringArrayVarP(&opts.Files, "file", "f", nil, "File to include, or - for stdin (can be repeated)")
+	cmd.Flags().StringVar(&opts.Filename, "filename", "", "File name for content read from stdin (default \"snippet.txt\")")
 	cmd.Flags().BoolVar(&opts.JSON, "json", false, "Output in JSON format")
 
 	cmd.MarkFlagRequired("title")
@@ -92,33 +100,28 @@ func runCreate(ctx context.Context, opts *CreateOptions) error {
 	defer cancel()
 
 	// Collect file contents
-	files := make(map[string]string)
-
-	if len(opts.Files) > 0 {
-		// Read from specified files
-		for _, filePath := range opts.Files {
-			content, err := os.ReadFile(filePath)
-			if err != nil {
-				return fmt.Errorf("failed to read file %s: %w", filePath, err)
-			}
-			filename := filepath.Base(filePath)
-			files[filename] = string(content)
+	paths := opts.Files
+	if len(paths) == 0 {
+		if opts.Streams.IsStdinTTY() {
+			return fmt.Errorf("no files specified. Pass files as arguments, use --file, or pipe content to stdin")
 		}
-	} else {
-		// Read from stdin
-		if !opts.Streams.IsStdinTTY() {
-			content, err := io.ReadAll(opts.Streams.In)
-			if err != nil {
-				return fmt.Errorf("failed to read from stdin: %w", err)
-			}
-			if len(content) == 0 {
-				return fmt.Errorf("no content provided. Use --file to specify files or pipe content to stdin")
-			}
-			files["snippet.txt"] = string(content)
-		} else {
-			return fmt.Errorf("no files specified. Use --file to specify files or pipe content to stdin")
+		paths = []string{"-"}
+	}
+
+	files, err := readSnippetFiles(paths, opts.Streams.In, opts.Filename)
+	if err != nil {
+		return err
+	}
+
+	nonEmpty := 0
+	for _, content := range files {
+		if content != "" {
+			nonEmpty++
 		}
 	}
+	if nonEmpty == 0 {
+		return fmt.Errorf("no content provided. A snippet needs at least one non-empty file")
+	}
 
 	// Create snippet
 	snippet, err := client.CreateSnippet(ctx, opts.Workspace, opts.Title, opts.Private, files)
@@ -1,11 +1,9 @@
 package snippet
 
 import (
-	"bufio"
 	"context"
 	"encoding/json"
 	"fmt"
-	"strings"
 	"time"
 
 	"github.com/spf13/cobra"
@@ -78,21 +76,12 @@ func runDelete(ctx context.Context, opts *DeleteOptions) error {
 
 	// If not forced, prompt for confirmation
 	if !opts.Force {
-		// Require TTY for interactive confirmation
-		if !opts.Streams.IsStdinTTY() {
-			return fmt.Errorf("cannot confirm deletion in non-interactive mode\nUse --force flag to skip confirmation")
-		}
-
-		fmt.Fprintf(opts.Streams.Out, "Delete snippet %s from %s? [y/N]: ", opts.SnippetID, opts.Workspace)
-
-		reader := bufio.NewReader(opts.Streams.In)
-		response, err := reader.ReadString('\n')
+		question := fmt.Sprintf("Delete snippet %s from %s?", opts.SnippetID, opts.Workspace)
+		confirmed, err := cmdutil.Confirm(opts.Streams, question, false, "pass --force to skip confirmation")
 		if err != nil {
-			return fmt.Errorf("failed to read response: %w", err)
+			return err
 		}
-
-		response = strings.TrimSpace(strings.ToLower(response))
-		if response != "y" && response != "yes" {
+		if !confirmed {
 			opts.Streams.Info("Deletion cancelled")
 			return nil
 		}
@@ -0,0 +1,237 @@
+package snippet
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/rbansal42/bitbucket-cli/internal/api"
+	"github.com/rbansal42/bitbucket-cli/internal/cmdutil"
+	"github.com/rbansal42/bitbucket-cli/internal/iostreams"
+)
+
+// downloadAttempts is how many times each file is tried before it is
+// reported as failed
+const downloadAttempts = 3
+
+// downloadRetryDelay is the pause before retrying a failed file
+var downloadRetryDelay = 2 * time.Second
+
+// DownloadOptions holds the options for the download command
+type DownloadOptions struct {
+	Workspace string
+	SnippetID string
+	Dir       string
+	Force     bool
+	Streams   *iostreams.IOStreams
+}
+
+// NewCmdDownload creates the snippet download command
+func NewCmdDownload(streams *iostreams.IOStreams) *cobra.Command {
+	opts := &DownloadOptions{
+		Streams: streams,
+	}
+
+	cmd := &cobra.Command{
+		Use:   "download <snippet-id>",
+		Short: "Download a snippet's files",
+		Long: `Download every file in a snippet into a directory, named after the
+snippet ID unless --dir is given.
+
+Downloads can be safely re-run after an interruption: files already on disk
+with the same size as in the snippet are skipped, and each file is written
+to a temporary file that is only renamed into place once it is complete.
+Files that fail are retried, and the rest are still downloaded. Use --force
+to download every file again.`,
+		Example: `  # Download a snippet into ./abc123
+  bb snippet download abc123 --workspace myworkspace
+
+  # Download into a specific directory
+  bb snippet download abc123 --workspace myworkspace --dir scripts
+
+  # Download every file again, even those already complete
+  bb snippet download abc123 --workspace myworkspace --force`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ws, err := cmdutil.ResolveWorkspace(cmd)
+			if err != nil {
+				return err
+			}
+			opts.Workspace = ws
+			opts.SnippetID = args[0]
+			return runDownload(cmd.Context(), opts)
+		},
+	}
+
+	cmd.Flags().StringVarP(&opts.Workspace, "workspace", "w", "", "Workspace slug (uses default if set)")
+	cmd.Flags().StringVarP(&opts.Dir, "dir", "d", "", "Directory to download into (default: the snippet ID)")
+	cmd.Flags().BoolVar(&opts.Force, "force", false, "Download files even if they are already complete")
+
+	_ = cmd.RegisterFlagCompletionFunc("workspace", cmdutil.CompleteWorkspaceNames)
+
+	return cmd
+}
+
+func runDownload(ctx context.Context, opts *DownloadOptions) error {
+	if _, err := cmdutil.ParseWorkspace(opts.Workspace); err != nil {
+		return err
+	}
+
+	client, err := cmdutil.GetAPIClient()
+	if err != nil {
+		return err
+	}
+
+	lookupCtx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	snippet, err := client.GetSnippet(lookupCtx, opts.Workspace, opts.SnippetID)
+	cancel()
+	if err != nil {
+		return fmt.Errorf("failed to get snippet: %w", err)
+	}
+
+	if len(snippet.Files) == 0 {
+		opts.Streams.Info("No files in this snippet")
+		return nil
+	}
+
+	dir := opts.Dir
+	if dir == "" {
+		dir = opts.SnippetID
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return fmt.Errorf("failed to create %s: %w", dir, err)
+	}
+
+	filenames := make([]string, 0, len(snippet.Files))
+	for filename := range snippet.Files {
+		filenames = append(filenames, filename)
+	}
+	sort.Strings(filenames)
+
+	var fetched, skipped, failed []string
+	for _, filename := range filenames {
+		done, err := downloadSnippetFile(ctx, client, opts, dir, filename)
+		switch {
+		case err != nil:
+			opts.Streams.Error("%s: %v", filename, err)
+			failed = append(failed, filename)
+		case done:
+			opts.Streams.Info("Skipped %s (already complete)", filename)
+			skipped = append(skipped, filename)
+		default:
+			opts.Streams.Info("Downloaded %s", filename)
+			fetched = append(fetched, filename)
+		}
+		if ctx.Err() != nil {
+			break
+		}
+	}
+
+	opts.Streams.Info("%d downloaded, %d skipped, %d failed into %s", len(fetched), len(skipped), len(failed), dir)
+	if remaining := len(filenames) - len(fetched) - len(skipped) - len(failed); remaining > 0 {
+		opts.Streams.Warning("Interrupted; %d files were not downloaded", remaining)
+	}
+	if len(failed) > 0 {
+		return fmt.Errorf("failed to download %s; run the command again to retry", strings.Join(failed, ", "))
+	}
+	return ctx.Err()
+}
+
+// downloadSnippetFile downloads one file into dir, trying it up to
+// downloadAttempts times. It returns true without downloading if the file
+// is already on disk at the size Bitbucket reports.
+func downloadSnippetFile(ctx context.Context, client *api.Client, opts *DownloadOptions, dir, filename string) (bool, error) {
+	if !filepath.IsLocal(filename) {
+		return false, fmt.Errorf("refusing to write outside %s", dir)
+	}
+	dest := filepath.Join(dir, filename)
+
+	var err error
+	for attempt := 1; attempt <= downloadAttempts; attempt++ {
+		if attempt > 1 {
+			opts.Streams.Verbose("Retrying %s (attempt %d of %d)...", filename, attempt, downloadAttempts)
+			select {
+			case <-ctx.Done():
+				return false, ctx.Err()
+			case <-time.After(downloadRetryDelay):
+			}
+		}
+
+		if !opts.Force {
+			var complete bool
+			complete, err = isComplete(ctx, client, opts, dest, filename)
+			if err != nil {
+				continue
+			}
+			if complete {
+				return true, nil
+			}
+		}
+
+		if err = fetchToFile(ctx, client, opts, dest, filename); err == nil {
+			return false, nil
+		}
+		if ctx.Err() != nil {
+			return false, ctx.Err()
+		}
+	}
+	return false, err
+}
+
+// isComplete reports whether dest exists at the size of the snippet file.
+// A size Bitbucket doesn't report can't be checked, so the file counts as
+// incomplete.
+func isComplete(ctx context.Context, client *api.Client, opts *DownloadOptions, dest, filename string) (bool, error) {
+	info, err := os.Stat(dest)
+	if err != nil || !info.Mode().IsRegular() {
+		return false, nil
+	}
+
+	size, err := client.GetSnippetFileSize(ctx, opts.Workspace, opts.SnippetID, filename)
+	if err != nil {
+		return false, err
+	}
+	return size >= 0 && size == info.Size(), nil
+}
+
+// fetchToFile streams a snippet file into a temporary file next to dest and
+// renames it into place once it is complete, so an interrupted download
+// never leaves a partial file under the real name
+func fetchToFile(ctx context.Context, client *api.Client, opts *DownloadOptions, dest, filename string) error {
+	if err := os.MkdirAll(filepath.Dir(dest), 0755); err != nil {
+		return err
+	}
+
+	content, err := client.GetSnippetFileReader(ctx, opts.Workspace, opts.SnippetID, filename)
+	if err != nil {
+		return err
+	}
+	defer content.Close()
+
+	tmp, err := os.CreateTemp(filepath.Dir(dest), "."+filepath.Base(dest)+".*.part")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := io.Copy(tmp, content); err != nil {
+		tmp.Close()
+		return fmt.Errorf("download interrupted: %w", err)
+	}
+	// CreateTemp makes the file private; give it the usual permissions
+	if err := tmp.Chmod(0644); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), dest)
+}
@@ -0,0 +1,102 @@
+package snippet
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"testing"
+
+	"github.com/rbansal42/bitbucket-cli/internal/api"
+	"github.com/rbansal42/bitbucket-cli/internal/iostreams"
+)
+
+func TestDownloadSnippetFile(t *testing.T) {
+	delay := downloadRetryDelay
+	downloadRetryDelay = 0
+	t.Cleanup(func() { downloadRetryDelay = delay })
+
+	files := map[string]string{
+		"done.txt":  "already here",
+		"stale.txt": "the full content",
+		"flaky.txt": "eventually works",
+	}
+	var mu sync.Mutex
+	gets := map[string]int{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		name := filepath.Base(r.URL.Path)
+		content, ok := files[name]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Content-Length", strconv.Itoa(len(content)))
+		if r.Method == http.MethodHead {
+			return
+		}
+
+		mu.Lock()
+		gets[name]++
+		n := gets[name]
+		mu.Unlock()
+		if name == "flaky.txt" && n == 1 {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		w.Write([]byte(content))
+	}))
+	defer server.Close()
+
+	client := api.NewClient(api.WithBaseURL(server.URL), api.WithToken("test-token"))
+	streams := &iostreams.IOStreams{Out: &bytes.Buffer{}, ErrOut: &bytes.Buffer{}}
+	opts := &DownloadOptions{Workspace: "ws", SnippetID: "abc123", Streams: streams}
+	dir := t.TempDir()
+	ctx := context.Background()
+
+	// A complete file is skipped; a partial one is downloaded again
+	os.WriteFile(filepath.Join(dir, "done.txt"), []byte(files["done.txt"]), 0644)
+	os.WriteFile(filepath.Join(dir, "stale.txt"), []byte("the fu"), 0644)
+
+	tests := []struct {
+		name        string
+		wantSkipped bool
+	}{
+		{"done.txt", true},
+		{"stale.txt", false},
+		{"flaky.txt", false},
+	}
+	for _, tt := range tests {
+		skipped, err := downloadSnippetFile(ctx, client, opts, dir, tt.name)
+		if err != nil {
+			t.Fatalf("downloadSnippetFile(%s) error: %v", tt.name, err)
+		}
+		if skipped != tt.wantSkipped {
+			t.Errorf("downloadSnippetFile(%s) skipped = %v, want %v", tt.name, skipped, tt.wantSkipped)
+		}
+		got, _ := os.ReadFile(filepath.Join(dir, tt.name))
+		if string(got) != files[tt.name] {
+			t.Errorf("%s = %q, want %q", tt.name, got, files[tt.name])
+		}
+	}
+	if gets["done.txt"] != 0 || gets["flaky.txt"] != 2 {
+		t.Errorf("GET counts = %v, want done.txt skipped and flaky.txt retried once", gets)
+	}
+
+	// A file that never downloads fails without leaving anything behind
+	if _, err := downloadSnippetFile(ctx, client, opts, dir, "missing.txt"); err == nil {
+		t.Error("downloadSnippetFile(missing.txt) succeeded, want an error")
+	}
+	entries, _ := os.ReadDir(dir)
+	if len(entries) != 3 {
+		t.Errorf("directory has %d entries, want only the 3 complete files", len(entries))
+	}
+
+	// Names that would escape the directory are refused
+	if _, err := downloadSnippetFile(ctx, client, opts, dir, "../evil.txt"); err == nil {
+		t.Error("downloadSnippetFile(../evil.txt) succeeded, want an error")
+	}
+}
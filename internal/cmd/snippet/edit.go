@@ -4,8 +4,6 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
-	"os"
-	"path/filepath"
 	"time"
 
 	"github.com/spf13/cobra"
@@ -21,7 +19,9 @@ type EditOptions struct {
 	Workspace string
 	SnippetID string
 	Title     string
-	Files     []string // File paths to update
+	Files     []string // File paths to add or replace
+	Add       []string // File paths to add or replace (alias for Files)
+	Delete    []string // File names to delete
 	JSON      bool
 	Streams   *iostreams.IOStreams
 }
@@ -37,7 +37,8 @@ func NewCmdEdit(streams *iostreams.IOStreams) *cobra.Command {
 		Short: "Edit an existing snippet",
 		Long: `Edit an existing snippet in a Bitbucket workspace.
 
-You can update the title and/or add/update files.`,
+You can update the title, add or replace files, and delete files. Files
+that are not mentioned are left untouched.`,
 		Example: `  # Update snippet title
   bb snippet edit abc123 --title "New Title" --workspace myworkspace
 
@@ -45,7 +46,10 @@ You can update the title and/or add/update files.`,
   bb snippet edit abc123 --file updated.py --workspace myworkspace
 
   # Update both title and files
-  bb snippet edit abc123 --title "New Title" --file updated.py --workspace myworkspace`,
+  bb snippet edit abc123 --title "New Title" --file updated.py --workspace myworkspace
+
+  # Add one file and delete another
+  bb snippet edit abc123 --add new.go --delete old.go`,
 		Args: cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			opts.SnippetID = args[0]
@@ -55,7 +59,9 @@ You can update the title and/or add/update files.`,
 
 	cmd.Flags().StringVarP(&opts.Workspace, "workspace", "w", "", "Workspace slug")
 	cmd.Flags().StringVarP(&opts.Title, "title", "t", "", "New snippet title")
-	cmd.Flags().StringArrayVarP(&opts.Files, "file", "f", nil, "File to update (can be repeated)")
+	cmd.Flags().StringArrayVarP(&opts.Files, "file", "f", nil, "File to add or replace (can be repeated)")
+	cmd.Flags().StringArrayVar(&opts.Add, "add", nil, "File to add or replace (same as --file)")
+	cmd.Flags().StringArrayVar(&opts.Delete, "delete", nil, "Name of a file to delete from the snippet (can be repeated)")
 	cmd.Flags().BoolVar(&opts.JSON, "json", false, "Output in JSON format")
 
 	_ = cmd.RegisterFlagCompletionFunc("workspace", cmdutil.CompleteWorkspaceNames)
@@ -80,9 +86,11 @@ func runEdit(ctx context.Context, opts *EditOptions) error {
 		return err
 	}
 
+	opts.Files = append(opts.Files, opts.Add...)
+
 	// Must have something to update
-	if opts.Title == "" && len(opts.Files) == 0 {
-		return fmt.Errorf("nothing to update. Specify --title and/or --file")
+	if opts.Title == "" && len(opts.Files) == 0 && len(opts.Delete) == 0 {
+		return fmt.Errorf("nothing to update. Specify --title, --add/--file and/or --delete")
 	}
 
 	// Get API client
@@ -96,18 +104,18 @@ func runEdit(ctx context.Context, opts *EditOptions) error {
 	defer cancel()
 
 	// Collect file contents
-	files := make(map[string]string)
-	for _, filePath := range opts.Files {
-		content, err := os.ReadFile(filePath)
-		if err != nil {
-			return fmt.Errorf("failed to read file %s: %w", filePath, err)
-		}
-		filename := filepath.Base(filePath)
-		files[filename] = string(content)
+	files, err := readSnippetFiles(opts.Files, opts.Streams.In, "")
+	if err != nil {
+		return err
 	}
 
-	// Update snippet
-	snippet, err := client.UpdateSnippet(ctx, opts.Workspace, opts.SnippetID, opts.Title, files)
+	// Update snippet; deletions need the current file list to validate against
+	var snippet *api.Snippet
+	if len(opts.Delete) > 0 {
+		snippet, err = client.EditSnippetFiles(ctx, opts.Workspace, opts.SnippetID, opts.Title, files, opts.Delete)
+	} else {
+		snippet, err = client.UpdateSnippet(ctx, opts.Workspace, opts.SnippetID, opts.Title, files)
+	}
 	if err != nil {
 		return fmt.Errorf("failed to update snippet: %w", err)
 	}
@@ -0,0 +1,121 @@
+package snippet
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/rbansal42/bitbucket-cli/internal/api"
+	"github.com/rbansal42/bitbucket-cli/internal/cmdutil"
+	"github.com/rbansal42/bitbucket-cli/internal/iostreams"
+)
+
+// HistoryOptions holds the options for the history command
+type HistoryOptions struct {
+	Workspace string
+	SnippetID string
+	Limit     int
+	JSON      bool
+	Streams   *iostreams.IOStreams
+}
+
+// NewCmdHistory creates the snippet history command
+func NewCmdHistory(streams *iostreams.IOStreams) *cobra.Command {
+	opts := &HistoryOptions{
+		Streams: streams,
+	}
+
+	cmd := &cobra.Command{
+		Use:   "history <snippet-id>",
+		Short: "Show a snippet's revision history",
+		Long: `Show how a snippet has changed over time, newest revision first.
+
+Snippets are stored as git repositories, so every edit is a commit. Each
+revision is listed with its commit hash, message, author, and date.`,
+		Example: `  # Show a snippet's revisions
+  bb snippet history abc123 --workspace myworkspace
+
+  # Show only the last 5 revisions
+  bb snippet history abc123 --workspace myworkspace --limit 5
+
+  # Output as JSON
+  bb snippet history abc123 --workspace myworkspace --json`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ws, err := cmdutil.ResolveWorkspace(cmd)
+			if err != nil {
+				return err
+			}
+			opts.Workspace = ws
+			opts.SnippetID = args[0]
+			return runHistory(cmd.Context(), opts)
+		},
+	}
+
+	cmd.Flags().StringVarP(&opts.Workspace, "workspace", "w", "", "Workspace slug (uses default if set)")
+	cmd.Flags().IntVarP(&opts.Limit, "limit", "l", 30, "Maximum number of revisions to show")
+	cmd.Flags().BoolVar(&opts.JSON, "json", false, "Output in JSON format")
+
+	_ = cmd.RegisterFlagCompletionFunc("workspace", cmdutil.CompleteWorkspaceNames)
+
+	return cmd
+}
+
+func runHistory(ctx context.Context, opts *HistoryOptions) error {
+	if _, err := cmdutil.ParseWorkspace(opts.Workspace); err != nil {
+		return err
+	}
+	if opts.Limit < 1 {
+		return fmt.Errorf("--limit must be at least 1")
+	}
+
+	client, err := cmdutil.GetAPIClient()
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	commits, err := fetchSnippetCommits(ctx, client, opts.Workspace, opts.SnippetID, opts.Limit)
+	if err != nil {
+		return fmt.Errorf("failed to get snippet history: %w", err)
+	}
+
+	if opts.JSON {
+		return cmdutil.PrintJSON(opts.Streams, commits)
+	}
+
+	if len(commits) == 0 {
+		opts.Streams.Info("No revisions found for snippet %s", opts.SnippetID)
+		return nil
+	}
+
+	t := cmdutil.NewTableWriter(opts.Streams, "COMMIT", "MESSAGE", "AUTHOR", "DATE")
+	t.SetFlexColumn(1)
+	t.SetMaxWidth(2, 25)
+	for _, c := range commits {
+		t.AddRow(cmdutil.ShortHash(c.Hash), cmdutil.CommitSubject(c.Message), cmdutil.CommitAuthorName(c.Author), cmdutil.TimeAgo(c.Date))
+	}
+	return t.Render()
+}
+
+// fetchSnippetCommits follows the commit pages of a snippet until limit
+// revisions have been collected or the history runs out
+func fetchSnippetCommits(ctx context.Context, client *api.Client, workspace, snippetID string, limit int) ([]api.Commit, error) {
+	var commits []api.Commit
+	page, err := client.ListSnippetCommits(ctx, workspace, snippetID)
+	for page != nil && err == nil {
+		commits = append(commits, page.Values...)
+		if len(commits) >= limit {
+			return commits[:limit], nil
+		}
+		page, err = api.NextPage(ctx, client, page)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return commits, nil
+}
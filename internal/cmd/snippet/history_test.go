@@ -0,0 +1,48 @@
+package snippet
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/rbansal42/bitbucket-cli/internal/api"
+)
+
+func TestFetchSnippetCommits(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/snippets/ws/abc123/commits" {
+			http.NotFound(w, r)
+			return
+		}
+		if r.URL.Query().Get("page") == "2" {
+			fmt.Fprint(w, `{"values": [{"hash": "c3"}, {"hash": "c4"}]}`)
+			return
+		}
+		fmt.Fprintf(w, `{"values": [{"hash": "c1"}, {"hash": "c2"}], "next": "http://%s/snippets/ws/abc123/commits?page=2"}`, r.Host)
+	}))
+	t.Cleanup(server.Close)
+	client := api.NewClient(api.WithBaseURL(server.URL), api.WithToken("test-token"))
+
+	tests := []struct {
+		limit int
+		want  int
+	}{
+		{limit: 1, want: 1},
+		{limit: 3, want: 3},
+		{limit: 30, want: 4},
+	}
+	for _, tt := range tests {
+		commits, err := fetchSnippetCommits(context.Background(), client, "ws", "abc123", tt.limit)
+		if err != nil {
+			t.Fatalf("fetchSnippetCommits(limit %d) error: %v", tt.limit, err)
+		}
+		if len(commits) != tt.want {
+			t.Errorf("fetchSnippetCommits(limit %d) returned %d commits, want %d", tt.limit, len(commits), tt.want)
+		}
+		if len(commits) > 0 && commits[0].Hash != "c1" {
+			t.Errorf("first commit = %q, want c1", commits[0].Hash)
+		}
+	}
+}
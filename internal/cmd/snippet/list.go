@@ -3,14 +3,12 @@ package snippet
 import (
 	"context"
 	"fmt"
-	"text/tabwriter"
 	"time"
 
 	"github.com/spf13/cobra"
 
 	"github.com/rbansal42/bitbucket-cli/internal/api"
 	"github.com/rbansal42/bitbucket-cli/internal/cmdutil"
-	"github.com/rbansal42/bitbucket-cli/internal/config"
 	"github.com/rbansal42/bitbucket-cli/internal/iostreams"
 )
 
@@ -20,6 +18,8 @@ type ListOptions struct {
 	Role      string // owner, contributor, member
 	Limit     int
 	JSON      bool
+	ShowCount bool
+	ExitCode  bool
 	Streams   *iostreams.IOStreams
 }
 
@@ -48,6 +48,11 @@ Snippets are workspace-scoped and can be filtered by your role.`,
   bb snippet list --workspace myworkspace --json`,
 		Aliases: []string{"ls"},
 		RunE: func(cmd *cobra.Command, args []string) error {
+			ws, err := cmdutil.ResolveWorkspace(cmd)
+			if err != nil {
+				return err
+			}
+			opts.Workspace = ws
 			return runList(cmd.Context(), opts)
 		},
 	}
@@ -56,6 +61,9 @@ Snippets are workspace-scoped and can be filtered by your role.`,
 	cmd.Flags().StringVar(&opts.Role, "role", "", "Filter by role: owner, contributor, member")
 	cmd.Flags().IntVarP(&opts.Limit, "limit", "l", 30, "Maximum number of snippets to list")
 	cmd.Flags().BoolVar(&opts.JSON, "json", false, "Output in JSON format")
+	cmdutil.AddShowCountFlag(cmd, &opts.ShowCount)
+	cmdutil.AddAllFlag(cmd, &opts.Limit)
+	cmdutil.AddExitCodeFlag(cmd, &opts.ExitCode)
 
 	_ = cmd.RegisterFlagCompletionFunc("workspace", cmdutil.CompleteWorkspaceNames)
 	_ = cmd.RegisterFlagCompletionFunc("role", cmdutil.StaticFlagCompletion([]string{
@@ -73,17 +81,6 @@ var validRoles = map[string]bool{
 }
 
 func runList(ctx context.Context, opts *ListOptions) error {
-	// Fall back to default workspace if not specified
-	if opts.Workspace == "" {
-		defaultWs, err := config.GetDefaultWorkspace()
-		if err == nil && defaultWs != "" {
-			opts.Workspace = defaultWs
-		}
-	}
-	if opts.Workspace == "" {
-		return fmt.Errorf("workspace is required. Use --workspace or -w to specify, or set a default with 'bb workspace set-default'")
-	}
-
 	// Validate workspace
 	if _, err := cmdutil.ParseWorkspace(opts.Workspace); err != nil {
 		return err
@@ -107,29 +104,41 @@ func runList(ctx context.Context, opts *ListOptions) error {
 	// Build list options
 	listOpts := &api.SnippetListOptions{
 		Role:  opts.Role,
-		Limit: opts.Limit,
+		Limit: cmdutil.PageLen(opts.Limit, cmdutil.MaxPageLen),
 	}
 
 	// Fetch snippets
 	result, err := client.ListSnippets(ctx, opts.Workspace, listOpts)
+	if err == nil {
+		result, err = cmdutil.CollectPages(ctx, client, result, opts.Limit)
+	}
 	if err != nil {
 		return fmt.Errorf("failed to list snippets: %w", err)
 	}
 
 	if len(result.Values) == 0 {
 		opts.Streams.Info("No snippets found in workspace %s", opts.Workspace)
-		return nil
+		return cmdutil.NoResults(opts.ExitCode)
+	}
+
+	var count *cmdutil.PageCount
+	if opts.ShowCount {
+		count = cmdutil.NewPageCount(result, len(result.Values))
 	}
 
 	// Output results
 	if opts.JSON {
-		return outputListJSON(opts.Streams, result.Values)
+		return outputListJSON(opts.Streams, result.Values, count)
 	}
 
-	return outputListTable(opts.Streams, result.Values)
+	if err := outputListTable(opts.Streams, result.Values); err != nil {
+		return err
+	}
+	cmdutil.PrintPageCount(opts.Streams, count)
+	return nil
 }
 
-func outputListJSON(streams *iostreams.IOStreams, snippets []api.Snippet) error {
+func outputListJSON(streams *iostreams.IOStreams, snippets []api.Snippet, count *cmdutil.PageCount) error {
 	// Create simplified JSON output
 	output := make([]map[string]interface{}, len(snippets))
 	for i, snippet := range snippets {
@@ -144,20 +153,16 @@ func outputListJSON(streams *iostreams.IOStreams, snippets []api.Snippet) error
 		}
 	}
 
-	return cmdutil.PrintJSON(streams, output)
+	return cmdutil.PrintListJSON(streams, output, count)
 }
 
 func outputListTable(streams *iostreams.IOStreams, snippets []api.Snippet) error {
-	w := tabwriter.NewWriter(streams.Out, 0, 0, 2, ' ', 0)
-
-	// Print header
-	header := "ID\tTITLE\tVISIBILITY\tUPDATED"
-	cmdutil.PrintTableHeader(streams, w, header)
+	t := cmdutil.NewTableWriter(streams, "ID", "TITLE", "VISIBILITY", "UPDATED")
+	t.SetFlexColumn(1)
 
-	// Print rows
 	for _, snippet := range snippets {
 		id := fmt.Sprintf("%d", snippet.ID)
-		title := cmdutil.TruncateString(snippet.Title, 40)
+		title := snippet.Title
 		if title == "" {
 			title = "(untitled)"
 		}
@@ -169,8 +174,8 @@ func outputListTable(streams *iostreams.IOStreams, snippets []api.Snippet) error
 
 		updated := cmdutil.TimeAgoFromString(snippet.UpdatedOn)
 
-		fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", id, title, visibility, updated)
+		t.AddRow(id, title, visibility, updated)
 	}
 
-	return w.Flush()
+	return t.Render()
 }
@@ -1,7 +1,89 @@
 // Package snippet provides commands for managing Bitbucket snippets.
 package snippet
 
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+)
+
 // This package uses shared utilities from cmdutil for:
 // - cmdutil.GetAPIClient() - authenticated API client
 // - cmdutil.ParseWorkspace() - workspace validation
 // - cmdutil.TruncateString() - string truncation
+
+// defaultStdinFilename is the snippet file name used for stdin content when
+// --filename is not given
+const defaultStdinFilename = "snippet.txt"
+
+// readSnippetFiles reads each path into a filename→content map keyed by the
+// base file name. The path "-" reads stdin into stdinName.
+func readSnippetFiles(paths []string, stdin io.Reader, stdinName string) (map[string]string, error) {
+	if stdinName == "" {
+		stdinName = defaultStdinFilename
+	}
+
+	files := make(map[string]string, len(paths))
+	readStdin := false
+	for _, p := range paths {
+		var (
+			name    string
+			content []byte
+			err     error
+		)
+
+		if p == "-" {
+			if readStdin {
+				return nil, fmt.Errorf("stdin (-) can only be used once")
+			}
+			readStdin = true
+			name = stdinName
+			content, err = io.ReadAll(stdin)
+			if err != nil {
+				return nil, fmt.Errorf("failed to read from stdin: %w", err)
+			}
+		} else {
+			name = filepath.Base(p)
+			content, err = os.ReadFile(p)
+			if err != nil {
+				return nil, fmt.Errorf("failed to read file %s: %w", p, err)
+			}
+		}
+
+		if _, dup := files[name]; dup {
+			return nil, fmt.Errorf("more than one file is named %q; snippet file names must be unique", name)
+		}
+		files[name] = string(content)
+	}
+
+	return files, nil
+}
+
+// copyEndingWithNewline copies r to w, adding a final newline if the
+// content does not end with one, for clean output between files
+func copyEndingWithNewline(w io.Writer, r io.Reader) error {
+	lw := &lastByteWriter{w: w}
+	n, err := io.Copy(lw, r)
+	if err != nil {
+		return err
+	}
+	if n > 0 && lw.last != '\n' {
+		_, err = io.WriteString(w, "\n")
+	}
+	return err
+}
+
+// lastByteWriter remembers the last byte written through it
+type lastByteWriter struct {
+	w    io.Writer
+	last byte
+}
+
+func (l *lastByteWriter) Write(p []byte) (int, error) {
+	n, err := l.w.Write(p)
+	if n > 0 {
+		l.last = p[n-1]
+	}
+	return n, err
+}
@@ -0,0 +1,87 @@
+package snippet
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestReadSnippetFiles(t *testing.T) {
+	dir := t.TempDir()
+	goFile := filepath.Join(dir, "main.go")
+	pyFile := filepath.Join(dir, "sub", "script.py")
+	if err := os.MkdirAll(filepath.Dir(pyFile), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(goFile, []byte("package main"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(pyFile, []byte("print('hi')"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("files keyed by base name", func(t *testing.T) {
+		files, err := readSnippetFiles([]string{goFile, pyFile}, nil, "")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if files["main.go"] != "package main" || files["script.py"] != "print('hi')" {
+			t.Errorf("unexpected files: %v", files)
+		}
+	})
+
+	t.Run("stdin with default name", func(t *testing.T) {
+		files, err := readSnippetFiles([]string{"-"}, strings.NewReader("from stdin"), "")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if files[defaultStdinFilename] != "from stdin" {
+			t.Errorf("unexpected files: %v", files)
+		}
+	})
+
+	t.Run("stdin with custom name alongside a file", func(t *testing.T) {
+		files, err := readSnippetFiles([]string{goFile, "-"}, strings.NewReader("x = 1"), "x.py")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(files) != 2 || files["x.py"] != "x = 1" {
+			t.Errorf("unexpected files: %v", files)
+		}
+	})
+
+	t.Run("duplicate base names", func(t *testing.T) {
+		other := filepath.Join(dir, "sub", "main.go")
+		if err := os.WriteFile(other, []byte("package sub"), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := readSnippetFiles([]string{goFile, other}, nil, ""); err == nil {
+			t.Error("expected error for duplicate file names")
+		}
+	})
+
+	t.Run("missing file", func(t *testing.T) {
+		if _, err := readSnippetFiles([]string{filepath.Join(dir, "nope.txt")}, nil, ""); err == nil {
+			t.Error("expected error for missing file")
+		}
+	})
+}
+
+func TestCopyEndingWithNewline(t *testing.T) {
+	tests := map[string]string{
+		"":           "",
+		"text":       "text\n",
+		"text\n":     "text\n",
+		"two\nlines": "two\nlines\n",
+	}
+	for input, want := range tests {
+		var out strings.Builder
+		if err := copyEndingWithNewline(&out, strings.NewReader(input)); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if out.String() != want {
+			t.Errorf("copyEndingWithNewline(%q) = %q, want %q", input, out.String(), want)
+		}
+	}
+}
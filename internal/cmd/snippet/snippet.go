@@ -31,6 +31,9 @@ code that can be shared and versioned.`,
 	cmd.AddCommand(NewCmdCreate(streams))
 	cmd.AddCommand(NewCmdEdit(streams))
 	cmd.AddCommand(NewCmdDelete(streams))
+	cmd.AddCommand(NewCmdHistory(streams))
+	cmd.AddCommand(NewCmdDownload(streams))
+	cmd.AddCommand(NewCmdSetVisibility(streams))
 
 	return cmd
 }
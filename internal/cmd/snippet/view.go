@@ -4,8 +4,8 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"io"
 	"sort"
-	"strings"
 	"time"
 
 	"github.com/spf13/cobra"
@@ -23,7 +23,8 @@ type ViewOptions struct {
 	SnippetID string
 	Web       bool
 	JSON      bool
-	Raw       bool // Show raw file content
+	Raw       bool   // Show raw file content
+	Output    string // Write raw file content to this file
 	Streams   *iostreams.IOStreams
 }
 
@@ -36,13 +37,18 @@ func NewCmdView(streams *iostreams.IOStreams) *cobra.Command {
 		Short: "View a snippet's details",
 		Long: `View details of a Bitbucket snippet.
 
-By default, shows snippet metadata. Use --raw to view file contents.`,
+By default, shows snippet metadata. Use --raw to view file contents, or
+--output to write them to a file. A single-file snippet is written to the
+file exactly as stored.`,
 		Example: `  # View snippet details
   bb snippet view abc123 --workspace myworkspace
 
   # View snippet file contents
   bb snippet view abc123 --workspace myworkspace --raw
 
+  # Save a snippet's contents to a file
+  bb snippet view abc123 --workspace myworkspace --output scripts/deploy.sh
+
   # Open snippet in browser
   bb snippet view abc123 --workspace myworkspace --web
 
@@ -59,6 +65,10 @@ By default, shows snippet metadata. Use --raw to view file contents.`,
 	cmd.Flags().BoolVar(&opts.Web, "web", false, "Open snippet in browser")
 	cmd.Flags().BoolVar(&opts.JSON, "json", false, "Output in JSON format")
 	cmd.Flags().BoolVar(&opts.Raw, "raw", false, "Show raw file contents")
+	cmd.Flags().StringVarP(&opts.Output, "output", "o", "", "Write the raw file contents to a file (implies --raw)")
+
+	cmd.MarkFlagsMutuallyExclusive("json", "output")
+	cmd.MarkFlagsMutuallyExclusive("web", "output")
 
 	_ = cmd.RegisterFlagCompletionFunc("workspace", cmdutil.CompleteWorkspaceNames)
 
@@ -117,7 +127,7 @@ func runView(ctx context.Context, opts *ViewOptions) error {
 	}
 
 	// Raw file contents
-	if opts.Raw {
+	if opts.Raw || opts.Output != "" {
 		return outputRawFiles(ctx, client, opts, snippet)
 	}
 
@@ -207,33 +217,52 @@ func outputRawFiles(ctx context.Context, client *api.Client, opts *ViewOptions,
 	}
 	sort.Strings(filenames)
 
+	out, err := cmdutil.OutputWriter(opts.Streams, opts.Output)
+	if err != nil {
+		return err
+	}
+	defer out.Abort()
+
+	// A lone file saved with --output is written exactly as stored
+	if !cmdutil.IsStdoutPath(opts.Output) && len(filenames) == 1 {
+		content, err := client.GetSnippetFileReader(ctx, opts.Workspace, opts.SnippetID, filenames[0])
+		if err != nil {
+			return fmt.Errorf("failed to get %s: %w", filenames[0], err)
+		}
+		defer content.Close()
+		if _, err := io.Copy(out, content); err != nil {
+			return fmt.Errorf("failed to read %s: %w", filenames[0], err)
+		}
+		return out.Close()
+	}
+
 	// Fetch and display each file's content
 	isFirst := true
 	for _, filename := range filenames {
 		if !isFirst {
-			fmt.Fprintln(opts.Streams.Out) // Blank line between files
+			fmt.Fprintln(out) // Blank line between files
 		}
 		isFirst = false
 
 		// Print file header
-		fmt.Fprintf(opts.Streams.Out, "==> %s <==\n", filename)
+		fmt.Fprintf(out, "==> %s <==\n", filename)
 
-		content, err := client.GetSnippetFileContent(ctx, opts.Workspace, opts.SnippetID, filename)
+		content, err := client.GetSnippetFileReader(ctx, opts.Workspace, opts.SnippetID, filename)
 		if err != nil {
 			fmt.Fprintf(opts.Streams.ErrOut, "Error fetching %s: %v\n", filename, err)
 			continue
 		}
 
-		// Print file content
-		contentStr := string(content)
-		// Ensure content ends with newline for clean output
-		if !strings.HasSuffix(contentStr, "\n") {
-			contentStr += "\n"
+		// Stream the content rather than buffering it, since snippet files
+		// can be large
+		err = copyEndingWithNewline(out, content)
+		content.Close()
+		if err != nil {
+			return fmt.Errorf("failed to read %s: %w", filename, err)
 		}
-		fmt.Fprint(opts.Streams.Out, contentStr)
 	}
 
-	return nil
+	return out.Close()
 }
 
 // formatTimestamp formats an ISO 8601 timestamp into a human-readable format
@@ -0,0 +1,101 @@
+package snippet
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/rbansal42/bitbucket-cli/internal/cmdutil"
+	"github.com/rbansal42/bitbucket-cli/internal/iostreams"
+)
+
+// VisibilityOptions holds the options for the set-visibility command
+type VisibilityOptions struct {
+	Workspace  string
+	SnippetID  string
+	Visibility string
+	JSON       bool
+	Streams    *iostreams.IOStreams
+}
+
+// NewCmdSetVisibility creates the snippet set-visibility command
+func NewCmdSetVisibility(streams *iostreams.IOStreams) *cobra.Command {
+	opts := &VisibilityOptions{
+		Streams: streams,
+	}
+
+	cmd := &cobra.Command{
+		Use:   "set-visibility <snippet-id> {public|private}",
+		Short: "Make a snippet public or private",
+		Long: `Make a snippet public or private.
+
+Only the visibility is changed; the snippet's title and files are not
+re-uploaded.`,
+		Example: `  # Share a snippet publicly
+  bb snippet set-visibility abc123 public --workspace myworkspace
+
+  # Make it private again
+  bb snippet set-visibility abc123 private`,
+		Args: cobra.ExactArgs(2),
+		ValidArgsFunction: func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+			if len(args) == 1 {
+				return []string{"public", "private"}, cobra.ShellCompDirectiveNoFileComp
+			}
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ws, err := cmdutil.ResolveWorkspace(cmd)
+			if err != nil {
+				return err
+			}
+			opts.Workspace = ws
+			opts.SnippetID = args[0]
+			opts.Visibility = args[1]
+			return runSetVisibility(cmd.Context(), opts)
+		},
+	}
+
+	cmd.Flags().StringVarP(&opts.Workspace, "workspace", "w", "", "Workspace slug (uses default if set)")
+	cmd.Flags().BoolVar(&opts.JSON, "json", false, "Output in JSON format")
+
+	_ = cmd.RegisterFlagCompletionFunc("workspace", cmdutil.CompleteWorkspaceNames)
+
+	return cmd
+}
+
+func runSetVisibility(ctx context.Context, opts *VisibilityOptions) error {
+	var isPrivate bool
+	switch opts.Visibility {
+	case "public":
+	case "private":
+		isPrivate = true
+	default:
+		return fmt.Errorf("invalid visibility %q: must be public or private", opts.Visibility)
+	}
+
+	if _, err := cmdutil.ParseWorkspace(opts.Workspace); err != nil {
+		return err
+	}
+
+	client, err := cmdutil.GetAPIClient()
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	snippet, err := client.SetSnippetVisibility(ctx, opts.Workspace, opts.SnippetID, isPrivate)
+	if err != nil {
+		return fmt.Errorf("failed to update snippet: %w", err)
+	}
+
+	if opts.JSON {
+		return outputEditJSON(opts.Streams, snippet)
+	}
+
+	opts.Streams.Success("Snippet %s is now %s", opts.SnippetID, opts.Visibility)
+	return nil
+}
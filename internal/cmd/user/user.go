@@ -0,0 +1,25 @@
+package user
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/rbansal42/bitbucket-cli/internal/iostreams"
+)
+
+// NewCmdUser creates the user command and its subcommands
+func NewCmdUser(streams *iostreams.IOStreams) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "user <command>",
+		Short: "Look up Bitbucket users",
+		Long:  `Look up Bitbucket users, for example to check who a reviewer is.`,
+		Example: `  # View a user by account ID
+  bb user view 557058:12345678-90ab-cdef-1234-567890abcdef
+
+  # View a workspace member by nickname
+  bb user view jdoe --workspace myworkspace`,
+	}
+
+	cmd.AddCommand(NewCmdView(streams))
+
+	return cmd
+}
@@ -0,0 +1,164 @@
+package user
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/rbansal42/bitbucket-cli/internal/api"
+	"github.com/rbansal42/bitbucket-cli/internal/cmdutil"
+	"github.com/rbansal42/bitbucket-cli/internal/iostreams"
+)
+
+type viewOptions struct {
+	streams   *iostreams.IOStreams
+	name      string
+	workspace string
+	jsonOut   bool
+}
+
+// NewCmdView creates the user view command
+func NewCmdView(streams *iostreams.IOStreams) *cobra.Command {
+	opts := &viewOptions{
+		streams: streams,
+	}
+
+	cmd := &cobra.Command{
+		Use:   "view <user>",
+		Short: "View a user's profile",
+		Long: `Display a Bitbucket user's display name, nickname, account ID, UUID and
+the date the account was created.
+
+The user can be given as a UUID ({...}), an account ID, a username, or "me"
+for yourself. Bitbucket no longer looks most users up by username, so when
+it cannot find one the members of the workspace are searched by username,
+nickname and display name. The workspace comes from --workspace, the
+default workspace, or the current git remote.`,
+		Example: `  # View yourself
+  bb user view me
+
+  # View a user by UUID
+  bb user view "{1a2b3c4d-5e6f-7a8b-9c0d-1e2f3a4b5c6d}"
+
+  # View a member of a workspace by nickname
+  bb user view jdoe --workspace myworkspace
+
+  # Output as JSON
+  bb user view jdoe --json`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts.name = strings.TrimSpace(args[0])
+			// The workspace is only needed to search members, so a missing
+			// one is not an error
+			opts.workspace, _ = cmdutil.ResolveWorkspace(cmd)
+			return runView(cmd.Context(), opts)
+		},
+	}
+
+	cmd.Flags().StringVarP(&opts.workspace, "workspace", "w", "", "Workspace whose members to search (uses default if set)")
+	cmd.Flags().BoolVar(&opts.jsonOut, "json", false, "Output in JSON format")
+
+	_ = cmd.RegisterFlagCompletionFunc("workspace", cmdutil.CompleteWorkspaceNames)
+
+	return cmd
+}
+
+func runView(ctx context.Context, opts *viewOptions) error {
+	if opts.name == "" {
+		return fmt.Errorf("user is required")
+	}
+
+	client, err := cmdutil.GetAPIClient()
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	user, err := lookupUser(ctx, client, opts.name, opts.workspace)
+	if err != nil {
+		return err
+	}
+
+	if opts.jsonOut {
+		return cmdutil.PrintJSON(opts.streams, user)
+	}
+
+	displayUser(opts.streams, user)
+	return nil
+}
+
+// lookupUser fetches a user by UUID, account ID or username, falling back
+// to the members of workspace for names Bitbucket no longer resolves
+func lookupUser(ctx context.Context, client *api.Client, name, workspace string) (*api.User, error) {
+	if cmdutil.IsSelfReference(name) {
+		user, err := client.CurrentUser(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get current user: %w", err)
+		}
+		return user, nil
+	}
+
+	user, err := client.GetUser(ctx, name)
+	if err == nil {
+		return user, nil
+	}
+	if !errors.Is(err, api.ErrUserNotFound) {
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+
+	isUUID := strings.HasPrefix(name, "{") && strings.HasSuffix(name, "}")
+	if isUUID || workspace == "" {
+		if isUUID {
+			return nil, fmt.Errorf("user %s not found: %w", name, err)
+		}
+		return nil, fmt.Errorf("user %q not found; Bitbucket no longer looks up most users by username, so use --workspace to search a workspace's members: %w", name, err)
+	}
+
+	uuid, resolveErr := cmdutil.UserResolverFor(client, workspace).Resolve(ctx, name)
+	if resolveErr != nil {
+		var apiErr *api.APIError
+		if errors.As(resolveErr, &apiErr) {
+			// The members could not be listed, e.g. for lack of access
+			return nil, resolveErr
+		}
+		return nil, fmt.Errorf("user %q not found in workspace %s: %w", name, workspace, err)
+	}
+	user, err = client.GetUser(ctx, uuid)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+	return user, nil
+}
+
+func displayUser(streams *iostreams.IOStreams, user *api.User) {
+	name := user.DisplayName
+	if name == "" {
+		name = user.Nickname
+	}
+	fmt.Fprintf(streams.Out, "%s\n\n", name)
+
+	if user.Nickname != "" {
+		fmt.Fprintf(streams.Out, "Nickname:   %s\n", user.Nickname)
+	}
+	if user.Username != "" {
+		fmt.Fprintf(streams.Out, "Username:   %s\n", user.Username)
+	}
+	if user.AccountID != "" {
+		fmt.Fprintf(streams.Out, "Account ID: %s\n", user.AccountID)
+	}
+	fmt.Fprintf(streams.Out, "UUID:       %s\n", user.UUID)
+	if !user.CreatedOn.IsZero() {
+		fmt.Fprintf(streams.Out, "Created:    %s\n", user.CreatedOn.Format("Jan 02, 2006"))
+	}
+
+	if user.Links.HTML.Href != "" {
+		fmt.Fprintln(streams.Out)
+		fmt.Fprintf(streams.Out, "View in browser: %s\n", user.Links.HTML.Href)
+	}
+}
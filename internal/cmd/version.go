@@ -0,0 +1,85 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"runtime"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/rbansal42/bitbucket-cli/internal/config"
+	"github.com/rbansal42/bitbucket-cli/internal/iostreams"
+	"github.com/rbansal42/bitbucket-cli/internal/update"
+)
+
+// newCmdVersion creates the version command
+func newCmdVersion(streams *iostreams.IOStreams) *cobra.Command {
+	var check bool
+
+	cmd := &cobra.Command{
+		Use:   "version",
+		Short: "Print the version number of bb",
+		Long: `Print the version, git commit, build date, and Go version of bb.
+
+With --check, bb also asks the release URL whether a newer version is
+available. The result is cached for 24 hours. No network request is made
+without --check. The release URL defaults to the GitHub releases API and
+can be changed with 'bb config set update_url <url>'.`,
+		Example: `  # Print version information
+  bb version
+
+  # Check for a newer release
+  bb version --check`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			fmt.Fprint(streams.Out, formatVersion(Version, Commit, BuildDate))
+
+			if check {
+				return checkForUpdate(cmd.Context(), streams)
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVar(&check, "check", false, "Check whether a newer release is available")
+
+	return cmd
+}
+
+// formatVersion renders the build metadata printed by bb version
+func formatVersion(version, commit, buildDate string) string {
+	return fmt.Sprintf("bb version %s (%s)\ncommit: %s\ngo: %s %s/%s\n",
+		version, buildDate, commit, runtime.Version(), runtime.GOOS, runtime.GOARCH)
+}
+
+func checkForUpdate(ctx context.Context, streams *iostreams.IOStreams) error {
+	checker := &update.Checker{}
+
+	if cfg, err := config.LoadConfig(); err == nil {
+		checker.ReleaseURL = cfg.UpdateURL
+	}
+	if dir, err := config.ConfigDir(); err == nil {
+		checker.StateFile = filepath.Join(dir, update.StateFileName)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	release, err := checker.LatestRelease(ctx)
+	if err != nil {
+		return err
+	}
+
+	if update.IsNewer(release.Version, Version) {
+		streams.Warning("A new release of bb is available: %s → %s", Version, release.Version)
+		if release.URL != "" {
+			fmt.Fprintln(streams.ErrOut, release.URL)
+		}
+		return nil
+	}
+
+	streams.Success("bb is up to date (latest release: %s)", release.Version)
+	return nil
+}
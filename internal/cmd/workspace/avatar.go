@@ -0,0 +1,76 @@
+package workspace
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/rbansal42/bitbucket-cli/internal/cmdutil"
+	"github.com/rbansal42/bitbucket-cli/internal/iostreams"
+)
+
+type avatarOptions struct {
+	streams       *iostreams.IOStreams
+	workspaceSlug string
+	out           string
+}
+
+// NewCmdAvatar creates the workspace avatar command
+func NewCmdAvatar(streams *iostreams.IOStreams) *cobra.Command {
+	opts := &avatarOptions{
+		streams: streams,
+	}
+
+	cmd := &cobra.Command{
+		Use:   "avatar [<workspace>]",
+		Short: "Download a workspace's avatar",
+		Long: `Download the avatar image of a Bitbucket workspace.
+
+If no workspace is given, the --workspace flag, the default workspace, or
+the workspace of the current git remote is used. The image is saved to the
+--out file, or written to stdout when it is redirected.`,
+		Example: `  # Save a workspace's avatar
+  bb workspace avatar myworkspace --out team.png`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) > 0 {
+				opts.workspaceSlug = args[0]
+			} else {
+				ws, err := cmdutil.ResolveWorkspace(cmd)
+				if err != nil {
+					return err
+				}
+				opts.workspaceSlug = ws
+			}
+			return runAvatar(cmd.Context(), opts)
+		},
+	}
+
+	cmd.Flags().StringVarP(&opts.out, "out", "o", "", "File to write the image to (\"-\" for stdout)")
+
+	return cmd
+}
+
+func runAvatar(ctx context.Context, opts *avatarOptions) error {
+	client, err := cmdutil.GetAPIClient()
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	ws, err := client.GetWorkspace(ctx, opts.workspaceSlug)
+	if err != nil {
+		return fmt.Errorf("failed to get workspace: %w", err)
+	}
+
+	data, contentType, err := client.DownloadAvatar(ctx, ws.Links.Avatar.Href)
+	if err != nil {
+		return fmt.Errorf("failed to download avatar: %w", err)
+	}
+
+	return cmdutil.WriteAvatar(opts.streams, data, contentType, opts.out)
+}
@@ -15,10 +15,12 @@ import (
 
 // ListOptions holds the options for the list command
 type ListOptions struct {
-	Role    string
-	Limit   int
-	JSON    bool
-	Streams *iostreams.IOStreams
+	Role      string
+	Limit     int
+	JSON      bool
+	ShowCount bool
+	ExitCode  bool
+	Streams   *iostreams.IOStreams
 }
 
 // NewCmdList creates the workspace list command
@@ -53,6 +55,9 @@ You can filter by your role in the workspace (owner, collaborator, or member).`,
 	cmd.Flags().StringVarP(&opts.Role, "role", "r", "", "Filter by role (owner, collaborator, member)")
 	cmd.Flags().IntVarP(&opts.Limit, "limit", "l", 30, "Maximum number of workspaces to list")
 	cmd.Flags().BoolVar(&opts.JSON, "json", false, "Output in JSON format")
+	cmdutil.AddShowCountFlag(cmd, &opts.ShowCount)
+	cmdutil.AddAllFlag(cmd, &opts.Limit)
+	cmdutil.AddExitCodeFlag(cmd, &opts.ExitCode)
 
 	_ = cmd.RegisterFlagCompletionFunc("role", cmdutil.StaticFlagCompletion([]string{
 		"owner", "collaborator", "member",
@@ -75,29 +80,41 @@ func runList(ctx context.Context, opts *ListOptions) error {
 	// Build list options
 	listOpts := &api.WorkspaceListOptions{
 		Role:  opts.Role,
-		Limit: opts.Limit,
+		Limit: cmdutil.PageLen(opts.Limit, cmdutil.MaxWidePageLen),
 	}
 
 	// Fetch workspaces
 	result, err := client.ListWorkspaces(ctx, listOpts)
+	if err == nil {
+		result, err = cmdutil.CollectPages(ctx, client, result, opts.Limit)
+	}
 	if err != nil {
 		return fmt.Errorf("failed to list workspaces: %w", err)
 	}
 
 	if len(result.Values) == 0 {
 		opts.Streams.Info("No workspaces found")
-		return nil
+		return cmdutil.NoResults(opts.ExitCode)
+	}
+
+	var count *cmdutil.PageCount
+	if opts.ShowCount {
+		count = cmdutil.NewPageCount(result, len(result.Values))
 	}
 
 	// Output results
 	if opts.JSON {
-		return outputListJSON(opts.Streams, result.Values)
+		return outputListJSON(opts.Streams, result.Values, count)
 	}
 
-	return outputListTable(opts.Streams, result.Values)
+	if err := outputListTable(opts.Streams, result.Values); err != nil {
+		return err
+	}
+	cmdutil.PrintPageCount(opts.Streams, count)
+	return nil
 }
 
-func outputListJSON(streams *iostreams.IOStreams, memberships []api.WorkspaceMembership) error {
+func outputListJSON(streams *iostreams.IOStreams, memberships []api.WorkspaceMembership, count *cmdutil.PageCount) error {
 	// Create simplified JSON output
 	output := make([]map[string]interface{}, len(memberships))
 	for i, m := range memberships {
@@ -114,7 +131,7 @@ func outputListJSON(streams *iostreams.IOStreams, memberships []api.WorkspaceMem
 		}
 	}
 
-	return cmdutil.PrintJSON(streams, output)
+	return cmdutil.PrintListJSON(streams, output, count)
 }
 
 func outputListTable(streams *iostreams.IOStreams, memberships []api.WorkspaceMembership) error {
@@ -68,11 +68,14 @@ func runMembers(ctx context.Context, opts *MembersOptions) error {
 
 	// Build list options
 	listOpts := &api.WorkspaceMemberListOptions{
-		Limit: opts.Limit,
+		Limit: cmdutil.PageLen(opts.Limit, cmdutil.MaxWidePageLen),
 	}
 
 	// Fetch members
 	result, err := client.ListWorkspaceMembers(ctx, opts.WorkspaceSlug, listOpts)
+	if err == nil {
+		result, err = cmdutil.CollectPages(ctx, client, result, opts.Limit)
+	}
 	if err != nil {
 		return fmt.Errorf("failed to list workspace members: %w", err)
 	}
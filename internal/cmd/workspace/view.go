@@ -4,9 +4,11 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"strconv"
 	"time"
 
 	"github.com/spf13/cobra"
+	"golang.org/x/sync/errgroup"
 
 	"github.com/rbansal42/bitbucket-cli/internal/api"
 	"github.com/rbansal42/bitbucket-cli/internal/browser"
@@ -28,23 +30,38 @@ func NewCmdView(streams *iostreams.IOStreams) *cobra.Command {
 	}
 
 	cmd := &cobra.Command{
-		Use:   "view <workspace>",
+		Use:   "view [<workspace>]",
 		Short: "View workspace details",
 		Long: `Display the details of a Bitbucket workspace.
 
 Shows workspace name, slug, UUID, type, privacy setting, creation date,
-and the browser URL.`,
+the number of projects and repositories, and the browser URL. If the counts
+cannot be fetched, the rest of the view is still shown.
+
+If no workspace is given, the --workspace flag, the default workspace, or
+the workspace of the current git remote is used.`,
 		Example: `  # View a workspace
   bb workspace view myworkspace
 
+  # View the default workspace
+  bb workspace view
+
   # Open workspace in browser
   bb workspace view myworkspace --web
 
   # Output as JSON
   bb workspace view myworkspace --json`,
-		Args: cobra.ExactArgs(1),
+		Args: cobra.MaximumNArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			opts.workspaceSlug = args[0]
+			if len(args) > 0 {
+				opts.workspaceSlug = args[0]
+			} else {
+				ws, err := cmdutil.ResolveWorkspace(cmd)
+				if err != nil {
+					return err
+				}
+				opts.workspaceSlug = ws
+			}
 			return runView(cmd.Context(), opts)
 		},
 	}
@@ -66,11 +83,13 @@ func runView(ctx context.Context, opts *viewOptions) error {
 	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
 	defer cancel()
 
-	// Fetch workspace details
-	ws, err := client.GetWorkspace(ctx, opts.workspaceSlug)
+	// Fetch workspace details, and the counts only the formatted view shows
+	counts := !opts.web && !opts.jsonOut
+	data, err := fetchViewData(ctx, client, opts.workspaceSlug, counts)
 	if err != nil {
 		return fmt.Errorf("failed to get workspace: %w", err)
 	}
+	ws := data.workspace
 
 	// Handle --web flag
 	if opts.web {
@@ -90,8 +109,103 @@ func runView(ctx context.Context, opts *viewOptions) error {
 		return outputViewJSON(opts.streams, ws)
 	}
 
+	for _, warning := range data.warnings() {
+		opts.streams.Warning("%s", warning)
+	}
+
 	// Display formatted output
-	return displayWorkspace(opts.streams, ws)
+	return displayWorkspace(opts.streams, data)
+}
+
+// viewData is everything workspace view can show. The project and
+// repository counts are optional: when counting fails the view is still
+// shown, with a warning, and the failure is kept in projectErr or repoErr.
+// A count is unknownCount when Bitbucket doesn't report it.
+type viewData struct {
+	workspace *api.WorkspaceFull
+
+	projects   int
+	projectErr error
+
+	repos   int
+	repoErr error
+}
+
+// unknownCount marks a count Bitbucket left out of its response
+const unknownCount = -1
+
+// pageTotal returns the total number of items a page reports, or
+// unknownCount when the size is left out of a page that isn't empty
+func pageTotal[T any](page *api.Paginated[T]) int {
+	if page.Size == 0 && (len(page.Values) > 0 || page.Next != "") {
+		return unknownCount
+	}
+	return page.Size
+}
+
+// formatCount shows a count, or "unknown" for unknownCount
+func formatCount(n int) string {
+	if n == unknownCount {
+		return "unknown"
+	}
+	return strconv.Itoa(n)
+}
+
+// warnings describes the counts that could not be fetched
+func (d *viewData) warnings() []string {
+	var warnings []string
+	if d.projectErr != nil {
+		warnings = append(warnings, fmt.Sprintf("Could not count projects: %v", d.projectErr))
+	}
+	if d.repoErr != nil {
+		warnings = append(warnings, fmt.Sprintf("Could not count repositories: %v", d.repoErr))
+	}
+	return warnings
+}
+
+// fetchViewData fetches the workspace together with, when counts is set, the
+// number of projects and repositories in it. The counts come from the size
+// of a one-item page, so each is a single small request. The requests run
+// concurrently; only a failure to fetch the workspace is returned.
+func fetchViewData(ctx context.Context, client *api.Client, slug string, counts bool) (*viewData, error) {
+	data := &viewData{}
+	g, gctx := errgroup.WithContext(ctx)
+
+	g.Go(func() error {
+		ws, err := client.GetWorkspace(gctx, slug)
+		if err != nil {
+			return err
+		}
+		data.workspace = ws
+		return nil
+	})
+
+	if counts {
+		g.Go(func() error {
+			result, err := client.ListProjects(gctx, slug, &api.ProjectListOptions{Limit: 1})
+			if err != nil {
+				data.projectErr = err
+				return nil
+			}
+			data.projects = pageTotal(result)
+			return nil
+		})
+
+		g.Go(func() error {
+			result, err := client.ListRepositories(gctx, slug, &api.RepositoryListOptions{Limit: 1})
+			if err != nil {
+				data.repoErr = err
+				return nil
+			}
+			data.repos = pageTotal(result)
+			return nil
+		})
+	}
+
+	if err := g.Wait(); err != nil {
+		return nil, err
+	}
+	return data, nil
 }
 
 func outputViewJSON(streams *iostreams.IOStreams, ws *api.WorkspaceFull) error {
@@ -103,7 +217,9 @@ func outputViewJSON(streams *iostreams.IOStreams, ws *api.WorkspaceFull) error {
 	return nil
 }
 
-func displayWorkspace(streams *iostreams.IOStreams, ws *api.WorkspaceFull) error {
+func displayWorkspace(streams *iostreams.IOStreams, data *viewData) error {
+	ws := data.workspace
+
 	// Header - workspace name
 	fmt.Fprintf(streams.Out, "%s\n\n", ws.Name)
 
@@ -125,6 +241,14 @@ func displayWorkspace(streams *iostreams.IOStreams, ws *api.WorkspaceFull) error
 		fmt.Fprintf(streams.Out, "Created:  %s\n", ws.CreatedOn.Format("Jan 02, 2006"))
 	}
 
+	// Counts, when they could be fetched
+	if data.projectErr == nil {
+		fmt.Fprintf(streams.Out, "Projects: %s\n", formatCount(data.projects))
+	}
+	if data.repoErr == nil {
+		fmt.Fprintf(streams.Out, "Repos:    %s\n", formatCount(data.repos))
+	}
+
 	// Browser URL
 	url := ws.Links.HTML.Href
 	if url == "" {
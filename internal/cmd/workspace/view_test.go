@@ -0,0 +1,111 @@
+package workspace
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/rbansal42/bitbucket-cli/internal/api"
+	"github.com/rbansal42/bitbucket-cli/internal/iostreams"
+)
+
+func newViewTestClient(t *testing.T, handler http.HandlerFunc) *api.Client {
+	t.Helper()
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+	return api.NewClient(api.WithBaseURL(server.URL), api.WithToken("test-token"))
+}
+
+func TestFetchViewData(t *testing.T) {
+	client := newViewTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/workspaces/ws":
+			fmt.Fprint(w, `{"slug": "ws", "name": "My Workspace"}`)
+		case "/workspaces/ws/projects":
+			if got := r.URL.Query().Get("pagelen"); got != "1" {
+				t.Errorf("pagelen = %q, want 1", got)
+			}
+			fmt.Fprint(w, `{"size": 3, "values": [{"key": "PROJ"}]}`)
+		case "/repositories/ws":
+			// No size reported
+			fmt.Fprintf(w, `{"values": [{"full_name": "ws/repo"}], "next": "http://%s/repositories/ws?page=2"}`, r.Host)
+		default:
+			http.NotFound(w, r)
+		}
+	})
+
+	data, err := fetchViewData(context.Background(), client, "ws", true)
+	if err != nil {
+		t.Fatalf("fetchViewData() error: %v", err)
+	}
+	if data.workspace.Name != "My Workspace" {
+		t.Errorf("workspace name = %q", data.workspace.Name)
+	}
+	if data.projects != 3 || data.repos != unknownCount {
+		t.Errorf("counts = %d projects, %d repos, want 3 and unknown", data.projects, data.repos)
+	}
+
+	out := &bytes.Buffer{}
+	if err := displayWorkspace(&iostreams.IOStreams{Out: out, ErrOut: &bytes.Buffer{}}, data); err != nil {
+		t.Fatalf("displayWorkspace() error: %v", err)
+	}
+	for _, want := range []string{"Projects: 3\n", "Repos:    unknown\n"} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("output = %q, want %q", out.String(), want)
+		}
+	}
+}
+
+func TestFetchViewData_CountErrors(t *testing.T) {
+	client := newViewTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/workspaces/ws":
+			fmt.Fprint(w, `{"slug": "ws", "name": "My Workspace"}`)
+		case "/workspaces/ws/projects":
+			fmt.Fprint(w, `{"size": 0, "values": []}`)
+		default:
+			w.WriteHeader(http.StatusForbidden)
+		}
+	})
+
+	data, err := fetchViewData(context.Background(), client, "ws", true)
+	if err != nil {
+		t.Fatalf("fetchViewData() error: %v", err)
+	}
+	if data.projects != 0 || data.projectErr != nil {
+		t.Errorf("projects = %d, %v, want an empty workspace", data.projects, data.projectErr)
+	}
+	if w := data.warnings(); len(w) != 1 || !strings.Contains(w[0], "Could not count repositories") {
+		t.Errorf("warnings = %v, want one about the repositories", w)
+	}
+
+	out := &bytes.Buffer{}
+	if err := displayWorkspace(&iostreams.IOStreams{Out: out, ErrOut: &bytes.Buffer{}}, data); err != nil {
+		t.Fatalf("displayWorkspace() error: %v", err)
+	}
+	if strings.Contains(out.String(), "Repos:") || !strings.Contains(out.String(), "Projects: 0\n") {
+		t.Errorf("output = %q, want the project count only", out.String())
+	}
+}
+
+func TestFetchViewData_WithoutCounts(t *testing.T) {
+	client := newViewTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/workspaces/ws" {
+			t.Errorf("unexpected request for %s", r.URL.Path)
+		}
+		fmt.Fprint(w, `{"slug": "ws"}`)
+	})
+
+	if _, err := fetchViewData(context.Background(), client, "ws", false); err != nil {
+		t.Fatalf("fetchViewData() error: %v", err)
+	}
+
+	client = newViewTestClient(t, http.NotFound)
+	if _, err := fetchViewData(context.Background(), client, "ws", true); err == nil {
+		t.Error("fetchViewData() succeeded, want the workspace error")
+	}
+}
@@ -30,6 +30,7 @@ your team. Each workspace can contain multiple repositories and projects.`,
 	cmd.AddCommand(NewCmdView(streams))
 	cmd.AddCommand(NewCmdMembers(streams))
 	cmd.AddCommand(NewCmdSetDefault(streams))
+	cmd.AddCommand(NewCmdAvatar(streams))
 
 	return cmd
 }
@@ -0,0 +1,26 @@
+package cmdutil
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/rbansal42/bitbucket-cli/internal/iostreams"
+)
+
+// WriteAvatar saves downloaded avatar bytes to out, or writes them to stdout
+// when out is "-" or stdout is not a terminal.
+func WriteAvatar(streams *iostreams.IOStreams, data []byte, contentType, out string) error {
+	if out == "-" || (out == "" && !streams.IsStdoutTTY()) {
+		_, err := streams.Out.Write(data)
+		return err
+	}
+	if out == "" {
+		return fmt.Errorf("refusing to write image data to the terminal. Use --out FILE to save the avatar")
+	}
+
+	if err := os.WriteFile(out, data, 0644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", out, err)
+	}
+	streams.Success("Saved avatar to %s (%s, %d bytes)", out, contentType, len(data))
+	return nil
+}
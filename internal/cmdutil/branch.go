@@ -0,0 +1,43 @@
+package cmdutil
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/rbansal42/bitbucket-cli/internal/api"
+)
+
+var (
+	defaultBranchesMu sync.Mutex
+	defaultBranches   = make(map[string]string) // lowercased "workspace/repo" -> branch
+)
+
+// DefaultBranch returns the repository's main branch as configured on
+// Bitbucket. The first successful lookup for a repository is cached for the
+// rest of the process; failures are not, so a later call can retry.
+func DefaultBranch(ctx context.Context, client *api.Client, workspace, repoSlug string) (string, error) {
+	key := strings.ToLower(workspace + "/" + repoSlug)
+
+	defaultBranchesMu.Lock()
+	branch, ok := defaultBranches[key]
+	defaultBranchesMu.Unlock()
+	if ok {
+		return branch, nil
+	}
+
+	repo, err := client.GetRepository(ctx, workspace, repoSlug)
+	if err != nil {
+		return "", err
+	}
+	if repo.MainBranch == nil || repo.MainBranch.Name == "" {
+		return "", fmt.Errorf("repository %s/%s has no default branch", workspace, repoSlug)
+	}
+
+	defaultBranchesMu.Lock()
+	defaultBranches[key] = repo.MainBranch.Name
+	defaultBranchesMu.Unlock()
+
+	return repo.MainBranch.Name, nil
+}
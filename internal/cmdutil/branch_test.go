@@ -0,0 +1,54 @@
+package cmdutil
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync/atomic"
+	"testing"
+)
+
+func TestDefaultBranch_Caches(t *testing.T) {
+	var calls atomic.Int32
+	client := newResolverServer(t, func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		if r.URL.Path != "/repositories/ws/cached-repo" {
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+		fmt.Fprint(w, `{"slug": "cached-repo", "mainbranch": {"name": "develop"}}`)
+	})
+
+	for i := 0; i < 2; i++ {
+		got, err := DefaultBranch(context.Background(), client, "ws", "cached-repo")
+		if err != nil {
+			t.Fatalf("DefaultBranch() error: %v", err)
+		}
+		if got != "develop" {
+			t.Errorf("DefaultBranch() = %q, want develop", got)
+		}
+	}
+	if n := calls.Load(); n != 1 {
+		t.Errorf("repository fetched %d times, want 1", n)
+	}
+}
+
+func TestDefaultBranch_DoesNotGuess(t *testing.T) {
+	var calls atomic.Int32
+	client := newResolverServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) == 1 {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		fmt.Fprint(w, `{"slug": "flaky-repo"}`)
+	})
+
+	if _, err := DefaultBranch(context.Background(), client, "ws", "flaky-repo"); err == nil {
+		t.Error("DefaultBranch() expected an error when the request fails")
+	}
+	if _, err := DefaultBranch(context.Background(), client, "ws", "flaky-repo"); err == nil {
+		t.Error("DefaultBranch() expected an error when the repository has no main branch")
+	}
+	if n := calls.Load(); n != 2 {
+		t.Errorf("repository fetched %d times, want failures to be retried", n)
+	}
+}
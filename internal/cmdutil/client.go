@@ -2,14 +2,98 @@
 package cmdutil
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"os"
 	"strings"
+	"sync"
 
 	"github.com/rbansal42/bitbucket-cli/internal/api"
 	"github.com/rbansal42/bitbucket-cli/internal/config"
+	"github.com/rbansal42/bitbucket-cli/internal/iostreams"
 )
 
+// globalFlags holds the root command flags that apply to every API client
+// or list command
+var globalFlags struct {
+	insecure bool
+	perPage  int
+	host     string
+}
+
+// insecureWarning makes sure the --insecure warning is printed only once
+var insecureWarning sync.Once
+
+// streams is where shared helpers print warnings; see SetStreams
+var streams *iostreams.IOStreams
+
+// SetInsecure records the global --insecure flag, which turns off TLS
+// certificate verification for every API client
+func SetInsecure(insecure bool) {
+	globalFlags.insecure = insecure
+}
+
+// SetStreams records the streams of the running command, so warnings from
+// shared helpers such as HostOptions go to the same place as its output
+func SetStreams(ios *iostreams.IOStreams) {
+	streams = ios
+}
+
+// warningStreams returns the streams set with SetStreams, or the process
+// streams when none were set
+func warningStreams() *iostreams.IOStreams {
+	if streams == nil {
+		return iostreams.New()
+	}
+	return streams
+}
+
+// SetHost records the global --host flag, which selects the Bitbucket host
+// whose credentials and API every client uses
+func SetHost(host string) {
+	globalFlags.host = host
+}
+
+// Host returns the Bitbucket host commands talk to: the --host flag, then
+// the BB_HOST environment variable, then the host most recently logged in
+// to, then bitbucket.org, unless the only host logged in to is another one
+func Host(hosts config.HostsConfig) string {
+	for _, host := range []string{globalFlags.host, os.Getenv("BB_HOST")} {
+		if host = normalizeHost(host); host != "" {
+			return host
+		}
+	}
+
+	if active := hosts.ActiveHost(); active != "" {
+		return active
+	}
+	if hosts.GetActiveUser(config.DefaultHost) == "" {
+		if loggedIn := hosts.AuthenticatedHosts(); len(loggedIn) == 1 {
+			return loggedIn[0]
+		}
+	}
+	return config.DefaultHost
+}
+
+// normalizeHost reduces a host given as a URL, such as
+// https://bitbucket.example.com/, to its hostname
+func normalizeHost(host string) string {
+	host = strings.TrimSpace(strings.ToLower(host))
+	if _, rest, ok := strings.Cut(host, "://"); ok {
+		host = rest
+	}
+	host, _, _ = strings.Cut(host, "/")
+	return host
+}
+
+// hostChosen reports whether the host was picked with --host or BB_HOST
+// rather than by default
+func hostChosen() bool {
+	return normalizeHost(globalFlags.host) != "" || normalizeHost(os.Getenv("BB_HOST")) != ""
+}
+
 // GetAPIClient creates an authenticated API client.
 // This is the canonical implementation used by all commands.
 func GetAPIClient() (*api.Client, error) {
@@ -18,14 +102,30 @@ func GetAPIClient() (*api.Client, error) {
 		return nil, fmt.Errorf("failed to load hosts config: %w", err)
 	}
 
-	user := hosts.GetActiveUser(config.DefaultHost)
-	if user == "" {
-		return nil, fmt.Errorf("not logged in. Run 'bb auth login' to authenticate")
+	host := Host(hosts)
+
+	// Only bitbucket.org works without a hosts.yml entry; another host's API
+	// URL and TLS settings are unknown, so its token must not go to the
+	// Cloud API
+	if host != config.DefaultHost && hosts[host] == nil {
+		return nil, NewAuthError("unknown host %s. Run 'bb auth login --hostname %s' to add it", host, host)
 	}
 
-	tokenData, _, err := config.GetTokenFromEnvOrKeyring(config.DefaultHost, user)
+	// A token in the environment works without a logged-in user, which is
+	// how scripts and CI usually authenticate
+	user := hosts.GetActiveUser(host)
+	tokenData, source, err := config.GetTokenFromEnvOrKeyring(host, user)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get token: %w", err)
+		if errors.Is(err, config.ErrTokenFile) || errors.Is(err, config.ErrCredentialHelper) {
+			return nil, NewAuthError("%w", err)
+		}
+		if user == "" && hostChosen() {
+			return nil, NewAuthError("no credentials for %s. Run 'bb auth login --hostname %s' to authenticate", host, host)
+		}
+		if user == "" {
+			return nil, NewAuthError("not logged in. Run 'bb auth login' to authenticate")
+		}
+		return nil, NewAuthError("failed to get token: %w", err)
 	}
 
 	// Check if this is Basic Auth credentials (prefixed with "basic:")
@@ -33,19 +133,80 @@ func GetAPIClient() (*api.Client, error) {
 		credentials := strings.TrimPrefix(tokenData, "basic:")
 		parts := strings.SplitN(credentials, ":", 2)
 		if len(parts) != 2 {
-			return nil, fmt.Errorf("invalid stored credentials format")
+			return nil, NewAuthError("invalid stored credentials format")
 		}
-		return api.NewClient(api.WithBasicAuth(parts[0], parts[1])), nil
+		return api.NewClient(append(HostOptions(hosts, host), api.WithBasicAuth(parts[0], parts[1]))...), nil
 	}
 
 	// Try to parse as JSON (OAuth token) or use as plain token (Bearer)
-	var tokenResp struct {
-		AccessToken string `json:"access_token"`
-	}
+	var stored config.KeyringToken
 	token := tokenData
-	if err := json.Unmarshal([]byte(tokenData), &tokenResp); err == nil && tokenResp.AccessToken != "" {
-		token = tokenResp.AccessToken
+	if err := json.Unmarshal([]byte(tokenData), &stored); err == nil && stored.AccessToken != "" {
+		token = stored.AccessToken
+	}
+
+	opts := append(HostOptions(hosts, host), api.WithToken(token))
+	if stored.RefreshToken != "" && source == "keyring" && hosts.GetAPIVersion(host) != api.APIVersionServer {
+		opts = append(opts, api.WithTokenRefresher(oauthRefresher(host, user, stored)))
+	}
+	return api.NewClient(opts...), nil
+}
+
+// oauthRefresher returns a refresher that exchanges the refresh token of
+// stored for a new access token and saves it to the keyring. The consumer
+// saved at login is used, or BB_OAUTH_CLIENT_ID and BB_OAUTH_CLIENT_SECRET
+// for tokens stored before consumers were saved.
+func oauthRefresher(host, user string, stored config.KeyringToken) api.TokenRefresher {
+	return func(ctx context.Context) (string, error) {
+		clientID, clientSecret := stored.ClientID, stored.ClientSecret
+		if clientID == "" || clientSecret == "" {
+			clientID, clientSecret = os.Getenv("BB_OAUTH_CLIENT_ID"), os.Getenv("BB_OAUTH_CLIENT_SECRET")
+		}
+		if clientID == "" || clientSecret == "" {
+			return "", fmt.Errorf("no OAuth consumer to refresh the token with")
+		}
+
+		fresh, err := api.RefreshOAuthToken(ctx, api.OAuthTokenURL, clientID, clientSecret, stored.RefreshToken)
+		if err != nil {
+			return "", err
+		}
+
+		stored.AccessToken = fresh.AccessToken
+		stored.ExpiresIn = fresh.ExpiresIn
+		stored.Scopes = fresh.Scopes
+		if fresh.RefreshToken != "" {
+			stored.RefreshToken = fresh.RefreshToken
+		}
+		// A token that can't be saved still works for this command
+		if data, err := json.Marshal(stored); err == nil {
+			_ = config.SetToken(host, user, string(data))
+		}
+		return fresh.AccessToken, nil
+	}
+}
+
+// HostOptions returns the client options for talking to host: Bitbucket
+// Server hosts get their own base URL and the Server API translation, and
+// the host's TLS settings are applied
+func HostOptions(hosts config.HostsConfig, host string) []api.ClientOption {
+	var opts []api.ClientOption
+	if hosts.GetAPIVersion(host) == api.APIVersionServer {
+		opts = append(opts,
+			api.WithBaseURL(api.ServerBaseURL(host)),
+			api.WithAPIVersion(api.APIVersionServer),
+		)
 	}
 
-	return api.NewClient(api.WithToken(token)), nil
+	hostConfig := hosts[host]
+	if hostConfig != nil && hostConfig.CACert != "" {
+		opts = append(opts, api.WithCACert(hostConfig.CACert))
+	}
+	if globalFlags.insecure || (hostConfig != nil && hostConfig.InsecureSkipVerify) {
+		insecureWarning.Do(func() {
+			warningStreams().Warning("WARNING: TLS certificate verification is disabled for %s. "+
+				"Your token and data can be intercepted; set ca_cert in hosts.yml instead.", host)
+		})
+		opts = append(opts, api.WithInsecureSkipVerify())
+	}
+	return opts
 }
@@ -0,0 +1,141 @@
+package cmdutil
+
+import (
+	"bytes"
+	"context"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/rbansal42/bitbucket-cli/internal/api"
+	"github.com/rbansal42/bitbucket-cli/internal/config"
+	"github.com/rbansal42/bitbucket-cli/internal/iostreams"
+)
+
+func TestHostOptions(t *testing.T) {
+	var gotPath string
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPath = r.URL.Path
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+	host := strings.TrimPrefix(server.URL, "https://")
+
+	caCert := filepath.Join(t.TempDir(), "ca.pem")
+	cert := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+	if err := os.WriteFile(caCert, cert, 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Cleanup(func() {
+		SetInsecure(false)
+		SetStreams(nil)
+	})
+
+	tests := []struct {
+		name     string
+		hosts    config.HostsConfig
+		insecure bool
+		wantErr  bool
+		wantWarn bool
+	}{
+		{name: "certificate verified", hosts: config.HostsConfig{host: {APIVersion: "1.0"}}, wantErr: true},
+		{name: "ca cert", hosts: config.HostsConfig{host: {APIVersion: "1.0", CACert: caCert}}},
+		{name: "insecure in config", hosts: config.HostsConfig{host: {APIVersion: "1.0", InsecureSkipVerify: true}}, wantWarn: true},
+		{name: "insecure flag", hosts: config.HostsConfig{host: {APIVersion: "1.0"}}, insecure: true, wantWarn: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			SetInsecure(tt.insecure)
+			insecureWarning = sync.Once{}
+			errOut := &bytes.Buffer{}
+			SetStreams(&iostreams.IOStreams{Out: &bytes.Buffer{}, ErrOut: errOut})
+			gotPath = ""
+
+			client := api.NewClient(append(HostOptions(tt.hosts, host), api.WithToken("test-token"))...)
+			_, err := client.Get(context.Background(), "/user", nil)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("expected a certificate error from a self-signed server")
+				}
+			} else {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				if !strings.HasPrefix(gotPath, "/rest/api/1.0/") {
+					t.Errorf("request went to %s, want the Server API base URL", gotPath)
+				}
+			}
+
+			warned := strings.Contains(errOut.String(), "TLS certificate verification is disabled for "+host)
+			if warned != tt.wantWarn {
+				t.Errorf("warning printed = %v, want %v (stderr %q)", warned, tt.wantWarn, errOut.String())
+			}
+		})
+	}
+
+	// bitbucket.org keeps the client defaults
+	SetInsecure(false)
+	if got := HostOptions(config.HostsConfig{}, config.DefaultHost); len(got) != 0 {
+		t.Errorf("HostOptions() returned %d options for bitbucket.org, want none", len(got))
+	}
+}
+
+func TestHost(t *testing.T) {
+	t.Cleanup(func() { SetHost("") })
+
+	cloud := config.HostsConfig{"bitbucket.org": {User: "me"}, "bitbucket.example.com": {User: "jdoe"}}
+	serverOnly := config.HostsConfig{"bitbucket.example.com": {User: "jdoe"}}
+	serverActive := config.HostsConfig{"bitbucket.org": {User: "me"}, "bitbucket.example.com": {User: "jdoe", Active: true}}
+	loggedOut := config.HostsConfig{"bitbucket.org": {User: "me"}, "bitbucket.example.com": {Active: true}}
+
+	tests := []struct {
+		name  string
+		flag  string
+		env   string
+		hosts config.HostsConfig
+		want  string
+	}{
+		{name: "default", hosts: cloud, want: "bitbucket.org"},
+		{name: "only logged in to another host", hosts: serverOnly, want: "bitbucket.example.com"},
+		{name: "not logged in", hosts: config.HostsConfig{}, want: "bitbucket.org"},
+		{name: "most recent login", hosts: serverActive, want: "bitbucket.example.com"},
+		{name: "most recent host logged out", hosts: loggedOut, want: "bitbucket.org"},
+		{name: "flag beats most recent login", flag: "bitbucket.org", hosts: serverActive, want: "bitbucket.org"},
+		{name: "environment", env: "bitbucket.example.com", hosts: cloud, want: "bitbucket.example.com"},
+		{name: "flag beats environment", flag: "bitbucket.org", env: "bitbucket.example.com", hosts: cloud, want: "bitbucket.org"},
+		{name: "url", flag: "https://Bitbucket.Example.com/", hosts: cloud, want: "bitbucket.example.com"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			SetHost(tt.flag)
+			t.Setenv("BB_HOST", tt.env)
+			if got := Host(tt.hosts); got != tt.want {
+				t.Errorf("Host() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGetAPIClient_UnknownHost(t *testing.T) {
+	t.Setenv("BB_CONFIG_DIR", t.TempDir())
+	t.Setenv("BB_TOKEN", "env-token")
+	t.Cleanup(func() { SetHost("") })
+
+	SetHost("bitbucket.example.com")
+	_, err := GetAPIClient()
+	if err == nil || !strings.Contains(err.Error(), "unknown host bitbucket.example.com") {
+		t.Errorf("GetAPIClient() error = %v, want an unknown host error", err)
+	}
+
+	SetHost("")
+	if _, err := GetAPIClient(); err != nil {
+		t.Errorf("GetAPIClient() for bitbucket.org error: %v", err)
+	}
+}
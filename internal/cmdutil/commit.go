@@ -0,0 +1,51 @@
+package cmdutil
+
+import (
+	"strings"
+
+	"github.com/rbansal42/bitbucket-cli/internal/api"
+	"github.com/rbansal42/bitbucket-cli/internal/iostreams"
+)
+
+// ShortHash abbreviates a commit hash the way git does by default
+func ShortHash(hash string) string {
+	if len(hash) > 7 {
+		return hash[:7]
+	}
+	return hash
+}
+
+// CommitSubject returns the first line of a commit message
+func CommitSubject(message string) string {
+	subject, _, _ := strings.Cut(strings.TrimSpace(message), "\n")
+	return subject
+}
+
+// CommitAuthorName prefers the Bitbucket account name over the raw git
+// author, dropping the email address from the latter
+func CommitAuthorName(author *api.CommitAuthor) string {
+	if author == nil {
+		return "-"
+	}
+	if author.User != nil {
+		return GetUserDisplayName(author.User)
+	}
+	name, _, _ := strings.Cut(author.Raw, "<")
+	if name = strings.TrimSpace(name); name != "" {
+		return name
+	}
+	return author.Raw
+}
+
+// SignatureBadge returns "verified" or "unverified" for a signed commit, in
+// color when it is enabled. It returns "" when Bitbucket reported no
+// signature, so unsigned commits are not flagged as unverified.
+func SignatureBadge(streams *iostreams.IOStreams, sig *api.CommitSignature) string {
+	if sig == nil {
+		return ""
+	}
+	if sig.Verified {
+		return ColorState(streams, "verified")
+	}
+	return ColorState(streams, "unverified")
+}
@@ -0,0 +1,48 @@
+package cmdutil
+
+import (
+	"testing"
+
+	"github.com/rbansal42/bitbucket-cli/internal/api"
+	"github.com/rbansal42/bitbucket-cli/internal/iostreams"
+)
+
+func TestCommitSubject(t *testing.T) {
+	if got := CommitSubject("Fix bug\n\nLonger description"); got != "Fix bug" {
+		t.Errorf("CommitSubject() = %q, want %q", got, "Fix bug")
+	}
+}
+
+func TestCommitAuthorName(t *testing.T) {
+	tests := []struct {
+		author *api.CommitAuthor
+		want   string
+	}{
+		{nil, "-"},
+		{&api.CommitAuthor{Raw: "Dev <dev@example.com>"}, "Dev"},
+		{&api.CommitAuthor{Raw: "<dev@example.com>"}, "<dev@example.com>"},
+		{&api.CommitAuthor{Raw: "Dev <dev@example.com>", User: &api.User{DisplayName: "Dev Eloper"}}, "Dev Eloper"},
+	}
+	for _, tt := range tests {
+		if got := CommitAuthorName(tt.author); got != tt.want {
+			t.Errorf("CommitAuthorName(%+v) = %q, want %q", tt.author, got, tt.want)
+		}
+	}
+}
+
+func TestSignatureBadge(t *testing.T) {
+	streams := &iostreams.IOStreams{}
+	tests := []struct {
+		sig  *api.CommitSignature
+		want string
+	}{
+		{nil, ""},
+		{&api.CommitSignature{Verified: true}, "verified"},
+		{&api.CommitSignature{Verified: false, Reason: "unknown key"}, "unverified"},
+	}
+	for _, tt := range tests {
+		if got := SignatureBadge(streams, tt.sig); got != tt.want {
+			t.Errorf("SignatureBadge(%+v) = %q, want %q", tt.sig, got, tt.want)
+		}
+	}
+}
@@ -7,7 +7,6 @@ import (
 	"time"
 
 	"github.com/rbansal42/bitbucket-cli/internal/api"
-	"github.com/rbansal42/bitbucket-cli/internal/config"
 	"github.com/rbansal42/bitbucket-cli/internal/git"
 	"github.com/spf13/cobra"
 )
@@ -15,7 +14,8 @@ import (
 // completionTimeout is the maximum time allowed for completion API calls.
 const completionTimeout = 5 * time.Second
 
-// completionListPageSize is the page size for list-type API calls during completion.
+// completionListPageSize is the page size for list-type API calls during
+// completion. A smaller --per-page or per_page setting takes precedence.
 const completionListPageSize = 50
 
 // completionDetailPageSize is the page size for PR/issue API calls during completion.
@@ -68,24 +68,8 @@ func completionRepo(cmd *cobra.Command) (workspace, repoSlug string) {
 // completionWorkspace resolves the workspace from the --workspace flag,
 // then default config, then git remote. Returns empty string on failure.
 func completionWorkspace(cmd *cobra.Command) string {
-	// Try --workspace flag first
-	ws, _ := cmd.Flags().GetString("workspace")
-	if ws != "" {
-		return ws
-	}
-
-	// Try default workspace from config
-	ws, err := config.GetDefaultWorkspace()
-	if err == nil && ws != "" {
-		return ws
-	}
-
-	// Try git remote
-	remote, err := git.GetDefaultRemote()
-	if err != nil {
-		return ""
-	}
-	return remote.Workspace
+	ws, _ := ResolveWorkspace(cmd)
+	return ws
 }
 
 // CompleteWorkspaceNames provides completion for workspace names.
@@ -98,7 +82,7 @@ func CompleteWorkspaceNames(cmd *cobra.Command, args []string, toComplete string
 	ctx, cancel := completionCtx()
 	defer cancel()
 
-	result, err := client.ListWorkspaces(ctx, &api.WorkspaceListOptions{Limit: completionListPageSize})
+	result, err := client.ListWorkspaces(ctx, &api.WorkspaceListOptions{Limit: PageLen(completionListPageSize, MaxPageLen)})
 	if err != nil {
 		return nil, cobra.ShellCompDirectiveNoFileComp
 	}
@@ -128,7 +112,7 @@ func CompleteRepoNames(cmd *cobra.Command, args []string, toComplete string) ([]
 	ctx, cancel := completionCtx()
 	defer cancel()
 
-	result, err := client.ListRepositories(ctx, ws, &api.RepositoryListOptions{Limit: completionListPageSize})
+	result, err := client.ListRepositories(ctx, ws, &api.RepositoryListOptions{Limit: PageLen(completionListPageSize, MaxPageLen)})
 	if err != nil {
 		return nil, cobra.ShellCompDirectiveNoFileComp
 	}
@@ -156,7 +140,7 @@ func CompleteBranchNames(cmd *cobra.Command, args []string, toComplete string) (
 	ctx, cancel := completionCtx()
 	defer cancel()
 
-	result, err := client.ListBranches(ctx, ws, slug, &api.BranchListOptions{Limit: completionListPageSize})
+	result, err := client.ListBranches(ctx, ws, slug, &api.BranchListOptions{Limit: PageLen(completionListPageSize, MaxPageLen)})
 	if err != nil {
 		return nil, cobra.ShellCompDirectiveNoFileComp
 	}
@@ -184,7 +168,7 @@ func CompletePRNumbers(cmd *cobra.Command, args []string, toComplete string) ([]
 	ctx, cancel := completionCtx()
 	defer cancel()
 
-	result, err := client.ListPullRequests(ctx, ws, slug, &api.PRListOptions{State: api.PRStateOpen, Limit: completionDetailPageSize})
+	result, err := client.ListPullRequests(ctx, ws, slug, &api.PRListOptions{State: api.PRStateOpen, Limit: PageLen(completionDetailPageSize, MaxPageLen)})
 	if err != nil {
 		return nil, cobra.ShellCompDirectiveNoFileComp
 	}
@@ -212,7 +196,7 @@ func CompleteIssueIDs(cmd *cobra.Command, args []string, toComplete string) ([]s
 	ctx, cancel := completionCtx()
 	defer cancel()
 
-	result, err := client.ListIssues(ctx, ws, slug, &api.IssueListOptions{Limit: completionDetailPageSize})
+	result, err := client.ListIssues(ctx, ws, slug, &api.IssueListOptions{Limit: PageLen(completionDetailPageSize, MaxPageLen)})
 	if err != nil {
 		return nil, cobra.ShellCompDirectiveNoFileComp
 	}
@@ -240,7 +224,7 @@ func CompleteWorkspaceMembers(cmd *cobra.Command, args []string, toComplete stri
 	ctx, cancel := completionCtx()
 	defer cancel()
 
-	result, err := client.ListWorkspaceMembers(ctx, ws, &api.WorkspaceMemberListOptions{Limit: completionListPageSize})
+	result, err := client.ListWorkspaceMembers(ctx, ws, &api.WorkspaceMemberListOptions{Limit: PageLen(completionListPageSize, MaxPageLen)})
 	if err != nil {
 		return nil, cobra.ShellCompDirectiveNoFileComp
 	}
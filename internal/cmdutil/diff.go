@@ -0,0 +1,269 @@
+package cmdutil
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/rbansal42/bitbucket-cli/internal/api"
+	"github.com/rbansal42/bitbucket-cli/internal/iostreams"
+)
+
+// CopyDiff copies a diff from r to w line by line, adding ANSI colors if
+// useColor is set
+func CopyDiff(w io.Writer, r io.Reader, useColor bool) error {
+	if !useColor {
+		_, err := io.Copy(w, r)
+		return err
+	}
+
+	br := bufio.NewReader(r)
+	for {
+		line, err := br.ReadString('\n')
+		if line != "" {
+			text, newline := strings.CutSuffix(line, "\n")
+			out := colorizeDiffLine(text)
+			if newline {
+				out += "\n"
+			}
+			if _, werr := io.WriteString(w, out); werr != nil {
+				return werr
+			}
+		}
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// colorizeDiffLine adds ANSI colors to a single diff line
+func colorizeDiffLine(line string) string {
+	switch {
+	case strings.HasPrefix(line, "+++ ") || strings.HasPrefix(line, "--- "):
+		// File headers - bold
+		return iostreams.Bold + line + iostreams.Reset
+	case strings.HasPrefix(line, "+"):
+		// Additions - green
+		return iostreams.Green + line + iostreams.Reset
+	case strings.HasPrefix(line, "-"):
+		// Deletions - red
+		return iostreams.Red + line + iostreams.Reset
+	case strings.HasPrefix(line, "@@"):
+		// Hunk headers - cyan
+		return iostreams.Cyan + line + iostreams.Reset
+	case strings.HasPrefix(line, "diff "):
+		// Diff headers - bold blue
+		return iostreams.BoldBlue + line + iostreams.Reset
+	default:
+		return line
+	}
+}
+
+// diffStatBarWidth is the maximum number of +/- characters drawn per file
+const diffStatBarWidth = 40
+
+// FormatDiffStat renders diffstat entries in the style of git diff --stat
+func FormatDiffStat(entries []api.DiffStatEntry, useColor bool) string {
+	var b strings.Builder
+
+	names := make([]string, len(entries))
+	nameWidth, countWidth, maxChanges := 0, 1, 0
+	totalAdded, totalRemoved := 0, 0
+	for i, e := range entries {
+		name := e.Path()
+		if e.Status == "renamed" && e.Old != nil && e.New != nil && e.Old.Path != e.New.Path {
+			name = e.Old.Path + " => " + e.New.Path
+		}
+		names[i] = name
+
+		changes := e.LinesAdded + e.LinesRemoved
+		nameWidth = max(nameWidth, len(name))
+		countWidth = max(countWidth, len(strconv.Itoa(changes)))
+		maxChanges = max(maxChanges, changes)
+		totalAdded += e.LinesAdded
+		totalRemoved += e.LinesRemoved
+	}
+
+	for i, e := range entries {
+		added, removed := e.LinesAdded, e.LinesRemoved
+		if maxChanges > diffStatBarWidth {
+			added = scaleDiffStat(added, maxChanges)
+			removed = scaleDiffStat(removed, maxChanges)
+		}
+		plus := strings.Repeat("+", added)
+		minus := strings.Repeat("-", removed)
+		if useColor {
+			plus = iostreams.Green + plus + iostreams.Reset
+			minus = iostreams.Red + minus + iostreams.Reset
+		}
+
+		line := fmt.Sprintf(" %-*s | %*d %s%s", nameWidth, names[i], countWidth, e.LinesAdded+e.LinesRemoved, plus, minus)
+		b.WriteString(strings.TrimRight(line, " ") + "\n")
+	}
+
+	files := "files"
+	if len(entries) == 1 {
+		files = "file"
+	}
+	fmt.Fprintf(&b, " %d %s changed, %d insertions(+), %d deletions(-)\n", len(entries), files, totalAdded, totalRemoved)
+
+	return b.String()
+}
+
+// scaleDiffStat scales n to the bar width, keeping non-zero counts visible
+func scaleDiffStat(n, maxChanges int) int {
+	if n == 0 {
+		return 0
+	}
+	return max(1, n*diffStatBarWidth/maxChanges)
+}
+
+// FileDiff is the part of a unified git diff that changes one file
+type FileDiff struct {
+	OldPath string // empty for an added file
+	NewPath string // empty for a deleted file
+	Binary  bool   // the diff has no text hunks for the file
+	Text    string // the file's section of the diff, from its "diff --git" line
+}
+
+// Path returns the path the file has after the change: the new path, or the
+// old one for a deleted file
+func (f *FileDiff) Path() string {
+	if f.NewPath != "" {
+		return f.NewPath
+	}
+	return f.OldPath
+}
+
+// SplitDiff reads a unified git diff and calls each with the section for
+// every file, in order, without holding more than one file's section in
+// memory. Text before the first "diff --git" line is ignored.
+func SplitDiff(r io.Reader, each func(*FileDiff) error) error {
+	var cur *FileDiff
+	var text strings.Builder
+	flush := func() error {
+		if cur == nil {
+			return nil
+		}
+		cur.Text = text.String()
+		text.Reset()
+		f := cur
+		cur = nil
+		return each(f)
+	}
+
+	br := bufio.NewReader(r)
+	inHunks := false
+	for {
+		line, err := br.ReadString('\n')
+		if line != "" {
+			body := strings.TrimSuffix(line, "\n")
+			if strings.HasPrefix(body, "diff --git ") {
+				if ferr := flush(); ferr != nil {
+					return ferr
+				}
+				cur = &FileDiff{}
+				cur.OldPath, cur.NewPath = parseDiffGitLine(body)
+				inHunks = false
+			}
+			if cur != nil {
+				text.WriteString(line)
+				if !inHunks {
+					inHunks = parseDiffHeaderLine(cur, body)
+				}
+			}
+		}
+		if err == io.EOF {
+			return flush()
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// parseDiffHeaderLine records what a line of a file's extended header says
+// about its paths. It returns true at the first hunk, after which lines are
+// content rather than header.
+func parseDiffHeaderLine(f *FileDiff, line string) bool {
+	switch {
+	case strings.HasPrefix(line, "@@"):
+		return true
+	case strings.HasPrefix(line, "new file mode"):
+		f.OldPath = ""
+	case strings.HasPrefix(line, "deleted file mode"):
+		f.NewPath = ""
+	case strings.HasPrefix(line, "rename from "):
+		f.OldPath = unquoteDiffPath(strings.TrimPrefix(line, "rename from "))
+	case strings.HasPrefix(line, "rename to "):
+		f.NewPath = unquoteDiffPath(strings.TrimPrefix(line, "rename to "))
+	case strings.HasPrefix(line, "--- "):
+		f.OldPath = diffSidePath(strings.TrimPrefix(line, "--- "), "a/")
+	case strings.HasPrefix(line, "+++ "):
+		f.NewPath = diffSidePath(strings.TrimPrefix(line, "+++ "), "b/")
+	case strings.HasPrefix(line, "Binary files ") || line == "GIT binary patch":
+		f.Binary = true
+	}
+	return false
+}
+
+// parseDiffGitLine takes the paths from a "diff --git a/<old> b/<new>"
+// line. Unquoted paths with spaces are ambiguous; the split that gives equal
+// paths is preferred, and the headers that follow settle renames.
+func parseDiffGitLine(line string) (oldPath, newPath string) {
+	rest := strings.TrimPrefix(line, "diff --git ")
+	if strings.HasPrefix(rest, `"`) {
+		if end := closingQuote(rest); end > 0 {
+			return diffSidePath(rest[:end+1], "a/"), diffSidePath(strings.TrimSpace(rest[end+1:]), "b/")
+		}
+	}
+	if n := len(rest); n%2 == 1 {
+		if a, b := rest[:n/2], rest[n/2+1:]; strings.TrimPrefix(a, "a/") == strings.TrimPrefix(b, "b/") {
+			return diffSidePath(a, "a/"), diffSidePath(b, "b/")
+		}
+	}
+	if i := strings.LastIndex(rest, " b/"); i >= 0 {
+		return diffSidePath(rest[:i], "a/"), diffSidePath(rest[i+1:], "b/")
+	}
+	return "", ""
+}
+
+// diffSidePath strips the a/ or b/ prefix from one side of a diff, which
+// git may quote. /dev/null gives an empty path.
+func diffSidePath(s, prefix string) string {
+	s = strings.TrimRight(s, "\t")
+	s = unquoteDiffPath(s)
+	if s == "/dev/null" {
+		return ""
+	}
+	return strings.TrimPrefix(s, prefix)
+}
+
+// unquoteDiffPath undoes git's C-style quoting of unusual paths
+func unquoteDiffPath(s string) string {
+	if strings.HasPrefix(s, `"`) {
+		if unquoted, err := strconv.Unquote(s); err == nil {
+			return unquoted
+		}
+	}
+	return s
+}
+
+// closingQuote returns the index of the quote ending the quoted string at
+// the start of s, or -1
+func closingQuote(s string) int {
+	for i := 1; i < len(s); i++ {
+		switch s[i] {
+		case '\\':
+			i++
+		case '"':
+			return i
+		}
+	}
+	return -1
+}
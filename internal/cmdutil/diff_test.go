@@ -0,0 +1,159 @@
+package cmdutil
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/rbansal42/bitbucket-cli/internal/api"
+	"github.com/rbansal42/bitbucket-cli/internal/iostreams"
+)
+
+func TestFormatDiffStat(t *testing.T) {
+	entries := []api.DiffStatEntry{
+		{Status: "modified", LinesAdded: 3, LinesRemoved: 1, Old: &api.DiffStatFile{Path: "main.go"}, New: &api.DiffStatFile{Path: "main.go"}},
+		{Status: "renamed", LinesAdded: 0, LinesRemoved: 0, Old: &api.DiffStatFile{Path: "a.go"}, New: &api.DiffStatFile{Path: "b.go"}},
+		{Status: "removed", LinesAdded: 0, LinesRemoved: 2, Old: &api.DiffStatFile{Path: "old.go"}},
+	}
+
+	got := FormatDiffStat(entries, false)
+
+	lines := strings.Split(strings.TrimRight(got, "\n"), "\n")
+	if len(lines) != 4 {
+		t.Fatalf("expected 4 lines, got %d:\n%s", len(lines), got)
+	}
+	if !strings.Contains(lines[0], "main.go") || !strings.HasSuffix(lines[0], "| 4 +++-") {
+		t.Errorf("unexpected line for main.go: %q", lines[0])
+	}
+	if !strings.Contains(lines[1], "a.go => b.go") {
+		t.Errorf("expected rename arrow, got %q", lines[1])
+	}
+	if !strings.HasSuffix(lines[2], "| 2 --") {
+		t.Errorf("unexpected line for old.go: %q", lines[2])
+	}
+	if lines[3] != " 3 files changed, 3 insertions(+), 3 deletions(-)" {
+		t.Errorf("unexpected totals line: %q", lines[3])
+	}
+}
+
+func TestFormatDiffStatScalesLargeChanges(t *testing.T) {
+	entries := []api.DiffStatEntry{
+		{Status: "modified", LinesAdded: 400, LinesRemoved: 0, New: &api.DiffStatFile{Path: "big.go"}},
+		{Status: "modified", LinesAdded: 1, LinesRemoved: 0, New: &api.DiffStatFile{Path: "small.go"}},
+	}
+
+	lines := strings.Split(FormatDiffStat(entries, false), "\n")
+	if n := strings.Count(lines[0], "+"); n != diffStatBarWidth {
+		t.Errorf("expected big change scaled to %d chars, got %d", diffStatBarWidth, n)
+	}
+	if n := strings.Count(lines[1], "+"); n != 1 {
+		t.Errorf("expected small change to keep one char, got %d", n)
+	}
+}
+
+func TestCopyDiff(t *testing.T) {
+	diff := "diff --git a/f b/f\n--- a/f\n+++ b/f\n@@ -1 +1 @@\n-old\n+new\n context"
+
+	var plain strings.Builder
+	if err := CopyDiff(&plain, strings.NewReader(diff), false); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if plain.String() != diff {
+		t.Errorf("uncolored diff changed:\n%q", plain.String())
+	}
+
+	var colored strings.Builder
+	if err := CopyDiff(&colored, strings.NewReader(diff), true); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := iostreams.BoldBlue + "diff --git a/f b/f" + iostreams.Reset + "\n" +
+		iostreams.Bold + "--- a/f" + iostreams.Reset + "\n" +
+		iostreams.Bold + "+++ b/f" + iostreams.Reset + "\n" +
+		iostreams.Cyan + "@@ -1 +1 @@" + iostreams.Reset + "\n" +
+		iostreams.Red + "-old" + iostreams.Reset + "\n" +
+		iostreams.Green + "+new" + iostreams.Reset + "\n" +
+		" context"
+	if colored.String() != want {
+		t.Errorf("colored diff = %q, want %q", colored.String(), want)
+	}
+}
+
+func TestSplitDiff(t *testing.T) {
+	diff := `diff --git a/main.go b/main.go
+index 1111111..2222222 100644
+--- a/main.go
++++ b/main.go
+@@ -1,3 +1,3 @@
+--- not a header
+-old
++new
+diff --git a/old name.txt b/new name.txt
+similarity index 90%
+rename from old name.txt
+rename to new name.txt
+--- a/old name.txt
++++ b/new name.txt
+@@ -1 +1 @@
+-a
++b
+diff --git a/logo.png b/logo.png
+new file mode 100644
+index 0000000..3333333
+Binary files /dev/null and b/logo.png differ
+diff --git a/gone.go b/gone.go
+deleted file mode 100644
+--- a/gone.go
++++ /dev/null
+@@ -1 +0,0 @@
+-package gone
+diff --git "a/caf\303\251.md" "b/caf\303\251.md"
+--- "a/caf\303\251.md"
++++ "b/caf\303\251.md"
+@@ -1 +1 @@
+-x
++y
+`
+
+	var files []*FileDiff
+	err := SplitDiff(strings.NewReader(diff), func(f *FileDiff) error {
+		files = append(files, f)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("SplitDiff() error: %v", err)
+	}
+
+	want := []struct {
+		old, new string
+		binary   bool
+	}{
+		{"main.go", "main.go", false},
+		{"old name.txt", "new name.txt", false},
+		{"", "logo.png", true},
+		{"gone.go", "", false},
+		{"café.md", "café.md", false},
+	}
+	if len(files) != len(want) {
+		t.Fatalf("SplitDiff() found %d files, want %d", len(files), len(want))
+	}
+	for i, w := range want {
+		f := files[i]
+		if f.OldPath != w.old || f.NewPath != w.new || f.Binary != w.binary {
+			t.Errorf("file %d = %q -> %q (binary %v), want %q -> %q (binary %v)", i, f.OldPath, f.NewPath, f.Binary, w.old, w.new, w.binary)
+		}
+	}
+
+	if !strings.HasPrefix(files[0].Text, "diff --git a/main.go") || !strings.HasSuffix(files[0].Text, "+new\n") {
+		t.Errorf("first file's text = %q", files[0].Text)
+	}
+	if files[3].Path() != "gone.go" {
+		t.Errorf("deleted file Path() = %q, want the old path", files[3].Path())
+	}
+
+	var joined strings.Builder
+	for _, f := range files {
+		joined.WriteString(f.Text)
+	}
+	if joined.String() != diff {
+		t.Error("the file sections don't add up to the whole diff")
+	}
+}
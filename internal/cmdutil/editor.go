@@ -0,0 +1,83 @@
+package cmdutil
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/rbansal42/bitbucket-cli/internal/config"
+)
+
+// OpenEditor opens the user's preferred editor on initialContent and returns
+// the edited text without surrounding whitespace
+func OpenEditor(initialContent string) (string, error) {
+	// Create temp file
+	tmpFile, err := os.CreateTemp("", "bb-*.md")
+	if err != nil {
+		return "", fmt.Errorf("failed to create temp file: %w", err)
+	}
+	defer os.Remove(tmpFile.Name())
+
+	// Write initial content
+	if initialContent != "" {
+		if _, err := tmpFile.WriteString(initialContent); err != nil {
+			return "", fmt.Errorf("failed to write to temp file: %w", err)
+		}
+	}
+	tmpFile.Close()
+
+	// Open editor
+	if err := EditFile(tmpFile.Name()); err != nil {
+		return "", err
+	}
+
+	// Read content back
+	content, err := os.ReadFile(tmpFile.Name())
+	if err != nil {
+		return "", fmt.Errorf("failed to read temp file: %w", err)
+	}
+
+	return strings.TrimSpace(string(content)), nil
+}
+
+// EditFile opens the user's preferred editor on the file at path and waits
+// for it to exit. The file is left in place, so whatever the editor saved
+// survives an editor crash.
+func EditFile(path string) error {
+	cmd := exec.Command(Editor(), path)
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("editor exited with error: %w", err)
+	}
+	return nil
+}
+
+// Editor returns the user's preferred editor: BB_EDITOR, the editor config
+// setting, VISUAL, EDITOR, and finally vi
+func Editor() string {
+	// Check BB_EDITOR first
+	if editor := os.Getenv("BB_EDITOR"); editor != "" {
+		return editor
+	}
+
+	// Check config
+	cfg, err := config.LoadConfig()
+	if err == nil && cfg.Editor != "" {
+		return cfg.Editor
+	}
+
+	// Check standard environment variables
+	if editor := os.Getenv("VISUAL"); editor != "" {
+		return editor
+	}
+	if editor := os.Getenv("EDITOR"); editor != "" {
+		return editor
+	}
+
+	// Default to vi
+	return "vi"
+}
@@ -0,0 +1,42 @@
+package cmdutil
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/rbansal42/bitbucket-cli/internal/config"
+)
+
+func TestEditor(t *testing.T) {
+	tests := []struct {
+		name   string
+		env    map[string]string
+		config string
+		want   string
+	}{
+		{name: "default", want: "vi"},
+		{name: "EDITOR", env: map[string]string{"EDITOR": "nano"}, want: "nano"},
+		{name: "VISUAL before EDITOR", env: map[string]string{"VISUAL": "code --wait", "EDITOR": "nano"}, want: "code --wait"},
+		{name: "config before VISUAL", env: map[string]string{"VISUAL": "code --wait"}, config: "emacs", want: "emacs"},
+		{name: "BB_EDITOR first", env: map[string]string{"BB_EDITOR": "hx", "VISUAL": "code --wait"}, config: "emacs", want: "hx"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			t.Setenv("BB_CONFIG_DIR", dir)
+			for _, key := range []string{"BB_EDITOR", "VISUAL", "EDITOR"} {
+				t.Setenv(key, tt.env[key])
+			}
+			if tt.config != "" {
+				if err := os.WriteFile(filepath.Join(dir, config.ConfigFileName), []byte("editor: "+tt.config+"\n"), 0600); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			if got := Editor(); got != tt.want {
+				t.Errorf("Editor() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,43 @@
+package cmdutil
+
+import (
+	"fmt"
+)
+
+// AuthError indicates that a command failed because the user is not
+// authenticated or their credentials could not be loaded.
+type AuthError struct {
+	err error
+}
+
+// NewAuthError returns an AuthError with a formatted message.
+func NewAuthError(format string, args ...any) *AuthError {
+	return &AuthError{err: fmt.Errorf(format, args...)}
+}
+
+func (e *AuthError) Error() string {
+	return e.err.Error()
+}
+
+func (e *AuthError) Unwrap() error {
+	return e.err
+}
+
+// FlagError indicates that a command was invoked incorrectly, such as
+// with an unknown flag or the wrong number of arguments.
+type FlagError struct {
+	err error
+}
+
+// NewFlagError wraps err as a FlagError.
+func NewFlagError(err error) *FlagError {
+	return &FlagError{err: err}
+}
+
+func (e *FlagError) Error() string {
+	return e.err.Error()
+}
+
+func (e *FlagError) Unwrap() error {
+	return e.err
+}
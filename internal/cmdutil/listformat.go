@@ -0,0 +1,201 @@
+package cmdutil
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"text/template"
+
+	"github.com/spf13/cobra"
+
+	"github.com/rbansal42/bitbucket-cli/internal/api"
+	"github.com/rbansal42/bitbucket-cli/internal/iostreams"
+)
+
+// ListFormat holds the --format and --template flags shared by list
+// commands. Both work on the same fields as --json, so a script can switch
+// between them without learning new names.
+type ListFormat struct {
+	Format   string // "csv" or "tsv"
+	Template string // Go template executed once per item
+}
+
+// AddListFormatFlags registers the shared --format and --template flags on a
+// list command.
+func AddListFormatFlags(cmd *cobra.Command, f *ListFormat) {
+	cmd.Flags().StringVar(&f.Format, "format", "", "Output format: csv or tsv")
+	cmd.Flags().StringVar(&f.Template, "template", "", "Format each item with a Go template, using the --json field names")
+	_ = cmd.RegisterFlagCompletionFunc("format", StaticFlagCompletion([]string{"csv", "tsv"}))
+}
+
+// Enabled reports whether --format or --template was given
+func (f *ListFormat) Enabled() bool {
+	return f.Format != "" || f.Template != ""
+}
+
+// Validate checks the flags. They cannot be combined with each other or
+// with --json.
+func (f *ListFormat) Validate(jsonOutput bool) error {
+	switch f.Format {
+	case "", "csv", "tsv":
+	default:
+		return fmt.Errorf("invalid --format %q: must be csv or tsv", f.Format)
+	}
+	if f.Format != "" && f.Template != "" {
+		return fmt.Errorf("--format and --template cannot be used together")
+	}
+	if jsonOutput && f.Enabled() {
+		return fmt.Errorf("--json cannot be used with --format or --template")
+	}
+	if f.Template != "" {
+		if _, err := template.New("item").Parse(f.Template); err != nil {
+			return fmt.Errorf("invalid --template: %w", err)
+		}
+	}
+	return nil
+}
+
+// Print writes items, which must marshal to a JSON array of objects, as
+// CSV or TSV with one column per field in fields, or through the template.
+func (f *ListFormat) Print(streams *iostreams.IOStreams, fields []string, items any) error {
+	objects, err := jsonObjects(items)
+	if err != nil {
+		return err
+	}
+
+	if f.Template != "" {
+		return printTemplate(streams, f.Template, objects)
+	}
+
+	if f.Format == "tsv" {
+		return printTSV(streams, fields, objects)
+	}
+
+	w := csv.NewWriter(streams.Out)
+	if err := w.Write(fields); err != nil {
+		return err
+	}
+	for _, obj := range objects {
+		row := make([]string, len(fields))
+		for i, field := range fields {
+			row[i] = formatField(obj[field])
+		}
+		if err := w.Write(row); err != nil {
+			return err
+		}
+	}
+	w.Flush()
+	return w.Error()
+}
+
+// tsvCleaner replaces the tabs and newlines TSV readers split on; they don't
+// unquote, so cells are written as they are otherwise
+var tsvCleaner = strings.NewReplacer("\t", " ", "\r\n", " ", "\n", " ", "\r", " ")
+
+// printTSV writes one tab-separated line per object, with no quoting
+func printTSV(streams *iostreams.IOStreams, fields []string, objects []map[string]any) error {
+	if _, err := fmt.Fprintln(streams.Out, strings.Join(fields, "\t")); err != nil {
+		return err
+	}
+	row := make([]string, len(fields))
+	for _, obj := range objects {
+		for i, field := range fields {
+			row[i] = tsvCleaner.Replace(formatField(obj[field]))
+		}
+		if _, err := fmt.Fprintln(streams.Out, strings.Join(row, "\t")); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// printTemplate executes tmpl once per object, ending each with a newline
+// unless the template already does
+func printTemplate(streams *iostreams.IOStreams, tmpl string, objects []map[string]any) error {
+	t, err := template.New("item").Parse(tmpl)
+	if err != nil {
+		return fmt.Errorf("invalid --template: %w", err)
+	}
+
+	var sb strings.Builder
+	for _, obj := range objects {
+		sb.Reset()
+		if err := t.Execute(&sb, obj); err != nil {
+			return fmt.Errorf("failed to execute --template: %w", err)
+		}
+		out := sb.String()
+		if !strings.HasSuffix(out, "\n") {
+			out += "\n"
+		}
+		fmt.Fprint(streams.Out, out)
+	}
+	return nil
+}
+
+// jsonObjects round-trips items through JSON, so the output uses exactly
+// the fields and names of --json
+func jsonObjects(items any) ([]map[string]any, error) {
+	data, err := json.Marshal(items)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal JSON: %w", err)
+	}
+	var objects []map[string]any
+	if err := json.Unmarshal(data, &objects); err != nil {
+		return nil, fmt.Errorf("failed to convert output: %w", err)
+	}
+	return objects, nil
+}
+
+// formatField renders one JSON value as a CSV or TSV cell
+func formatField(v any) string {
+	switch v := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case bool:
+		return strconv.FormatBool(v)
+	default:
+		data, _ := json.Marshal(v)
+		return string(data)
+	}
+}
+
+// CollectPages follows the next links from first until limit values have
+// been collected or the results run out. The returned page holds the
+// collected values and keeps the size of the whole result and the next
+// link of the last page fetched, so NewPageCount reports whether more
+// results are available. A limit below 1 returns first unchanged.
+func CollectPages[T any](ctx context.Context, client *api.Client, first *api.Paginated[T], limit int) (*api.Paginated[T], error) {
+	if limit < 1 {
+		return first, nil
+	}
+
+	result := &api.Paginated[T]{Size: first.Size}
+	page := first
+	for page != nil {
+		result.Values = append(result.Values, page.Values...)
+		result.Next = page.Next
+		if len(result.Values) >= limit {
+			if len(result.Values) > limit && result.Size == 0 && result.Next == "" {
+				// Values were dropped from the last page, so there are more
+				// than shown even without a next link
+				result.Size = len(result.Values)
+			}
+			result.Values = result.Values[:limit]
+			break
+		}
+
+		var err error
+		page, err = api.NextPage(ctx, client, page)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return result, nil
+}
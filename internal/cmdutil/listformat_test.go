@@ -0,0 +1,121 @@
+package cmdutil
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/rbansal42/bitbucket-cli/internal/api"
+	"github.com/rbansal42/bitbucket-cli/internal/iostreams"
+)
+
+func TestListFormatValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		format  ListFormat
+		json    bool
+		wantErr bool
+	}{
+		{"none", ListFormat{}, false, false},
+		{"csv", ListFormat{Format: "csv"}, false, false},
+		{"tsv", ListFormat{Format: "tsv"}, false, false},
+		{"template", ListFormat{Template: "{{.id}}"}, false, false},
+		{"json alone", ListFormat{}, true, false},
+		{"unknown format", ListFormat{Format: "xml"}, false, true},
+		{"format and template", ListFormat{Format: "csv", Template: "{{.id}}"}, false, true},
+		{"format and json", ListFormat{Format: "csv"}, true, true},
+		{"bad template", ListFormat{Template: "{{.id"}, false, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.format.Validate(tt.json)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestListFormatPrint(t *testing.T) {
+	items := []map[string]any{
+		{"id": 1, "title": "Fix login, again", "open": true, "owner": nil},
+		{"id": 22, "title": "Tabs\tand\nnewlines", "open": false, "owner": "alice"},
+		{"id": 3, "title": `Say "hi"`, "open": true, "owner": "bob"},
+	}
+	fields := []string{"id", "title", "open", "owner"}
+
+	tests := []struct {
+		name   string
+		format ListFormat
+		want   string
+	}{
+		{
+			name:   "csv",
+			format: ListFormat{Format: "csv"},
+			want:   "id,title,open,owner\n1,\"Fix login, again\",true,\n22,\"Tabs\tand\nnewlines\",false,alice\n3,\"Say \"\"hi\"\"\",true,bob\n",
+		},
+		{
+			name:   "tsv",
+			format: ListFormat{Format: "tsv"},
+			want:   "id\ttitle\topen\towner\n1\tFix login, again\ttrue\t\n22\tTabs and newlines\tfalse\talice\n3\tSay \"hi\"\ttrue\tbob\n",
+		},
+		{
+			name:   "template",
+			format: ListFormat{Template: "#{{.id}} {{.title}}"},
+			want:   "#1 Fix login, again\n#22 Tabs\tand\nnewlines\n#3 Say \"hi\"\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out := &bytes.Buffer{}
+			streams := &iostreams.IOStreams{Out: out, ErrOut: &bytes.Buffer{}}
+			if err := tt.format.Print(streams, fields, items); err != nil {
+				t.Fatalf("Print() error: %v", err)
+			}
+			if out.String() != tt.want {
+				t.Errorf("Print() = %q, want %q", out.String(), tt.want)
+			}
+		})
+	}
+}
+
+func TestCollectPages(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Query().Get("page") {
+		case "2":
+			fmt.Fprintf(w, `{"size": 5, "values": [3, 4], "next": "http://%s/items?page=3"}`, r.Host)
+		case "3":
+			fmt.Fprint(w, `{"size": 5, "values": [5]}`)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+	client := api.NewClient(api.WithBaseURL(server.URL), api.WithToken("test-token"))
+	first := &api.Paginated[int]{Size: 5, Values: []int{1, 2}, Next: server.URL + "/items?page=2"}
+
+	all, err := CollectPages(context.Background(), client, first, 30)
+	if err != nil {
+		t.Fatalf("CollectPages() error: %v", err)
+	}
+	if fmt.Sprint(all.Values) != "[1 2 3 4 5]" || all.Next != "" {
+		t.Errorf("CollectPages(30) = %v, next %q", all.Values, all.Next)
+	}
+	if count := NewPageCount(all, len(all.Values)); count.HasMore || count.Total != 5 {
+		t.Errorf("count = %+v, want all 5 shown", count)
+	}
+
+	some, err := CollectPages(context.Background(), client, first, 3)
+	if err != nil {
+		t.Fatalf("CollectPages() error: %v", err)
+	}
+	if fmt.Sprint(some.Values) != "[1 2 3]" {
+		t.Errorf("CollectPages(3) = %v", some.Values)
+	}
+	if count := NewPageCount(some, len(some.Values)); !count.HasMore {
+		t.Errorf("count = %+v, want more available", count)
+	}
+}
@@ -0,0 +1,69 @@
+package cmdutil
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/rbansal42/bitbucket-cli/internal/git"
+)
+
+// LocalConfigFile is the per-directory config written by 'bb repo set-default'
+const LocalConfigFile = ".bb.yml"
+
+// LocalConfig represents the .bb.yml file structure
+type LocalConfig struct {
+	DefaultRepo    string `yaml:"default_repo,omitempty"`
+	MergeStrategy  string `yaml:"merge_strategy,omitempty"`  // default for 'bb pr merge'
+	DefaultProject string `yaml:"default_project,omitempty"` // project key for 'bb repo create'
+}
+
+// LocalConfigValue looks for a setting in .bb.yml in the current directory
+// and then in the repository root. key picks the setting from a file; the
+// first non-empty value is returned with the path of its file, or empty
+// strings if neither file sets it.
+func LocalConfigValue(key func(*LocalConfig) string) (string, string, error) {
+	dirs := []string{"."}
+	if root, err := git.GetRepoRoot(); err == nil {
+		dirs = append(dirs, root)
+	}
+
+	for _, dir := range dirs {
+		path := filepath.Join(dir, LocalConfigFile)
+		data, err := os.ReadFile(path)
+		if err != nil {
+			continue
+		}
+		var local LocalConfig
+		if err := yaml.Unmarshal(data, &local); err != nil {
+			return "", "", fmt.Errorf("failed to parse %s: %w", path, err)
+		}
+		if value := key(&local); value != "" {
+			return value, path, nil
+		}
+	}
+	return "", "", nil
+}
+
+// UpdateLocalConfig applies update to .bb.yml in the current directory,
+// creating the file if needed and keeping the settings update leaves alone
+func UpdateLocalConfig(update func(*LocalConfig)) error {
+	var local LocalConfig
+	if data, err := os.ReadFile(LocalConfigFile); err == nil {
+		if err := yaml.Unmarshal(data, &local); err != nil {
+			return fmt.Errorf("failed to parse %s: %w", LocalConfigFile, err)
+		}
+	}
+	update(&local)
+
+	data, err := yaml.Marshal(local)
+	if err != nil {
+		return fmt.Errorf("failed to marshal config: %w", err)
+	}
+	if err := os.WriteFile(LocalConfigFile, data, 0644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", LocalConfigFile, err)
+	}
+	return nil
+}
@@ -0,0 +1,54 @@
+package cmdutil
+
+import (
+	"os"
+	"testing"
+)
+
+func TestLocalConfigValue(t *testing.T) {
+	t.Chdir(t.TempDir())
+	project := func(c *LocalConfig) string { return c.DefaultProject }
+
+	value, path, err := LocalConfigValue(project)
+	if err != nil || value != "" || path != "" {
+		t.Fatalf("LocalConfigValue() without a file = %q, %q, %v, want nothing", value, path, err)
+	}
+
+	if err := os.WriteFile(LocalConfigFile, []byte("default_repo: ws/repo\ndefault_project: PROJ\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	value, path, err = LocalConfigValue(project)
+	if err != nil || value != "PROJ" || path != LocalConfigFile {
+		t.Errorf("LocalConfigValue() = %q, %q, %v, want PROJ from %s", value, path, err, LocalConfigFile)
+	}
+	if value, _, err := LocalConfigValue(func(c *LocalConfig) string { return c.MergeStrategy }); err != nil || value != "" {
+		t.Errorf("LocalConfigValue() for an unset key = %q, %v, want nothing", value, err)
+	}
+
+	if err := os.WriteFile(LocalConfigFile, []byte("default_project: [\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := LocalConfigValue(project); err == nil {
+		t.Error("LocalConfigValue() succeeded for an invalid file, want an error")
+	}
+}
+
+func TestUpdateLocalConfig(t *testing.T) {
+	t.Chdir(t.TempDir())
+
+	if err := os.WriteFile(LocalConfigFile, []byte("merge_strategy: squash\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := UpdateLocalConfig(func(c *LocalConfig) { c.DefaultRepo = "ws/repo" }); err != nil {
+		t.Fatalf("UpdateLocalConfig() error: %v", err)
+	}
+
+	for want, key := range map[string]func(*LocalConfig) string{
+		"ws/repo": func(c *LocalConfig) string { return c.DefaultRepo },
+		"squash":  func(c *LocalConfig) string { return c.MergeStrategy },
+	} {
+		if value, _, err := LocalConfigValue(key); err != nil || value != want {
+			t.Errorf("LocalConfigValue() after update = %q, %v, want %q", value, err, want)
+		}
+	}
+}
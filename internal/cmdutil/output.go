@@ -1,13 +1,15 @@
 package cmdutil
 
 import (
-	"bufio"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
-	"strings"
+	"os"
+	"path/filepath"
 	"text/tabwriter"
 
+	"github.com/rbansal42/bitbucket-cli/internal/api"
 	"github.com/rbansal42/bitbucket-cli/internal/iostreams"
 )
 
@@ -21,6 +23,27 @@ func PrintJSON(streams *iostreams.IOStreams, v any) error {
 	return nil
 }
 
+// jsonError is how a command run with --json reports that it failed
+type jsonError struct {
+	Error      string `json:"error"`
+	StatusCode int    `json:"status_code,omitempty"`
+	RequestID  string `json:"request_id,omitempty"`
+}
+
+// PrintErrorJSON writes err as a JSON object to streams.ErrOut, for commands
+// run with --json. A failed API request adds its status code and the
+// request ID Atlassian support asks for.
+func PrintErrorJSON(streams *iostreams.IOStreams, err error) {
+	out := jsonError{Error: err.Error()}
+	var apiErr *api.APIError
+	if errors.As(err, &apiErr) {
+		out.StatusCode = apiErr.StatusCode
+		out.RequestID = apiErr.RequestID
+	}
+	data, _ := json.MarshalIndent(out, "", "  ")
+	fmt.Fprintln(streams.ErrOut, string(data))
+}
+
 // PrintTableHeader writes a bold header line to a tabwriter if color is enabled,
 // otherwise writes a plain header.
 func PrintTableHeader(streams *iostreams.IOStreams, w *tabwriter.Writer, header string) {
@@ -31,12 +54,92 @@ func PrintTableHeader(streams *iostreams.IOStreams, w *tabwriter.Writer, header
 	}
 }
 
-// ConfirmPrompt reads a line from reader and returns true if user typed y/yes.
-func ConfirmPrompt(reader io.Reader) bool {
-	scanner := bufio.NewScanner(reader)
-	if scanner.Scan() {
-		input := strings.TrimSpace(strings.ToLower(scanner.Text()))
-		return input == "y" || input == "yes"
+// IsStdoutPath reports whether an --output path means standard output
+func IsStdoutPath(path string) bool {
+	return path == "" || path == "-"
+}
+
+// Output is where a command writes its result for an --output flag
+type Output interface {
+	io.WriteCloser
+
+	// Abort discards a partly written result after a failure: an output
+	// file is closed and removed without being reported. It does nothing
+	// once Close has been called, so it can be deferred.
+	Abort()
+}
+
+// OutputWriter returns where a command should write its result for an
+// --output flag: streams.Out when path is empty or "-", otherwise the file at
+// path, created along with any missing parent directories. Closing a file
+// reports how many bytes were written to it, unless a write failed, in which
+// case the file is removed and Close returns the error.
+func OutputWriter(streams *iostreams.IOStreams, path string) (Output, error) {
+	if IsStdoutPath(path) {
+		return nopWriteCloser{streams.Out}, nil
+	}
+
+	if dir := filepath.Dir(path); dir != "." {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return nil, fmt.Errorf("failed to create %s: %w", dir, err)
+		}
+	}
+	f, err := os.Create(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create output file: %w", err)
+	}
+	return &outputFile{file: f, path: path, streams: streams}, nil
+}
+
+type nopWriteCloser struct {
+	io.Writer
+}
+
+func (nopWriteCloser) Close() error { return nil }
+
+func (nopWriteCloser) Abort() {}
+
+// outputFile counts the bytes written to an --output file
+type outputFile struct {
+	file     *os.File
+	path     string
+	streams  *iostreams.IOStreams
+	written  int64
+	writeErr error
+	closed   bool
+}
+
+func (o *outputFile) Write(p []byte) (int, error) {
+	n, err := o.file.Write(p)
+	o.written += int64(n)
+	if err != nil && o.writeErr == nil {
+		o.writeErr = err
+	}
+	return n, err
+}
+
+func (o *outputFile) Close() error {
+	if o.closed {
+		return nil
+	}
+	o.closed = true
+	err := o.file.Close()
+	if o.writeErr != nil {
+		err = o.writeErr
+	}
+	if err != nil {
+		os.Remove(o.path)
+		return fmt.Errorf("failed to write %s: %w", o.path, err)
+	}
+	o.streams.Success("Wrote %d bytes to %s", o.written, o.path)
+	return nil
+}
+
+func (o *outputFile) Abort() {
+	if o.closed {
+		return
 	}
-	return false
+	o.closed = true
+	o.file.Close()
+	os.Remove(o.path)
 }
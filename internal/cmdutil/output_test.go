@@ -0,0 +1,127 @@
+package cmdutil
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/rbansal42/bitbucket-cli/internal/api"
+	"github.com/rbansal42/bitbucket-cli/internal/iostreams"
+)
+
+func TestOutputWriter_Stdout(t *testing.T) {
+	out := &bytes.Buffer{}
+	streams := &iostreams.IOStreams{Out: out, ErrOut: &bytes.Buffer{}}
+
+	for _, path := range []string{"", "-"} {
+		w, err := OutputWriter(streams, path)
+		if err != nil {
+			t.Fatalf("OutputWriter(%q) error: %v", path, err)
+		}
+		io.WriteString(w, "data\n")
+		if err := w.Close(); err != nil {
+			t.Errorf("Close() error: %v", err)
+		}
+	}
+	if out.String() != "data\ndata\n" {
+		t.Errorf("stdout = %q, want the data twice and nothing else", out.String())
+	}
+}
+
+func TestOutputWriter_File(t *testing.T) {
+	out := &bytes.Buffer{}
+	streams := &iostreams.IOStreams{Out: out, ErrOut: &bytes.Buffer{}}
+	path := filepath.Join(t.TempDir(), "nested", "dir", "changes.diff")
+
+	w, err := OutputWriter(streams, path)
+	if err != nil {
+		t.Fatalf("OutputWriter() error: %v", err)
+	}
+	io.WriteString(w, "hello")
+	if err := w.Close(); err != nil {
+		t.Fatalf("Close() error: %v", err)
+	}
+	if err := w.Close(); err != nil {
+		t.Errorf("second Close() error: %v", err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != "hello" {
+		t.Errorf("file contents = %q", data)
+	}
+	if got := out.String(); strings.Count(got, "Wrote 5 bytes to "+path) != 1 {
+		t.Errorf("report = %q, want one line with the byte count", got)
+	}
+}
+
+func TestOutputWriter_Abort(t *testing.T) {
+	out := &bytes.Buffer{}
+	streams := &iostreams.IOStreams{Out: out, ErrOut: out}
+	path := filepath.Join(t.TempDir(), "changes.diff")
+
+	w, err := OutputWriter(streams, path)
+	if err != nil {
+		t.Fatalf("OutputWriter() error: %v", err)
+	}
+	io.WriteString(w, "partial")
+	w.Abort()
+	if err := w.Close(); err != nil {
+		t.Errorf("Close() after Abort() error: %v", err)
+	}
+
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Errorf("partly written file was left behind: %v", err)
+	}
+	if out.Len() != 0 {
+		t.Errorf("output = %q, want nothing reported for an aborted file", out.String())
+	}
+}
+
+func TestPrintErrorJSON(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want map[string]any
+	}{
+		{
+			name: "api error",
+			err:  fmt.Errorf("failed to merge pull request: %w", &api.APIError{StatusCode: 500, Message: "Something went wrong", RequestID: "9f1c2b7e4d"}),
+			want: map[string]any{
+				"error":       "failed to merge pull request: API error 500: Something went wrong (request ID 9f1c2b7e4d)",
+				"status_code": float64(500),
+				"request_id":  "9f1c2b7e4d",
+			},
+		},
+		{
+			name: "other error",
+			err:  errors.New("not a git repository"),
+			want: map[string]any{"error": "not a git repository"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out, errOut := &bytes.Buffer{}, &bytes.Buffer{}
+			PrintErrorJSON(&iostreams.IOStreams{Out: out, ErrOut: errOut}, tt.err)
+
+			if out.Len() != 0 {
+				t.Errorf("stdout = %q, want nothing", out.String())
+			}
+			var got map[string]any
+			if err := json.Unmarshal(errOut.Bytes(), &got); err != nil {
+				t.Fatalf("stderr is not JSON: %v\n%s", err, errOut.String())
+			}
+			if fmt.Sprint(got) != fmt.Sprint(tt.want) {
+				t.Errorf("PrintErrorJSON() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
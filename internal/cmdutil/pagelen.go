@@ -0,0 +1,89 @@
+package cmdutil
+
+import (
+	"fmt"
+	"strconv"
+	"sync"
+
+	"github.com/rbansal42/bitbucket-cli/internal/config"
+)
+
+// MaxPageLen is the largest page size every Bitbucket list endpoint accepts
+const MaxPageLen = 50
+
+// MaxWidePageLen is the largest page size of the endpoints that accept more
+// than MaxPageLen, such as workspaces and workspace members
+const MaxWidePageLen = 100
+
+// PerPageFlag is the value of the global --per-page flag. It is checked as
+// the flag is parsed, so an out of range size is reported as a usage error.
+type PerPageFlag struct{}
+
+// String returns the page size given with --per-page, or "" if none was
+func (PerPageFlag) String() string {
+	if globalFlags.perPage == 0 {
+		return ""
+	}
+	return strconv.Itoa(globalFlags.perPage)
+}
+
+// Set validates and records a --per-page value
+func (PerPageFlag) Set(value string) error {
+	n, err := strconv.Atoi(value)
+	if err != nil {
+		return fmt.Errorf("must be a number")
+	}
+	if err := ValidatePerPage(n); err != nil {
+		return err
+	}
+	globalFlags.perPage = n
+	return nil
+}
+
+// Type returns the flag type shown in help
+func (PerPageFlag) Type() string {
+	return "int"
+}
+
+// ValidatePerPage checks a --per-page or per_page value against the largest
+// page size Bitbucket accepts
+func ValidatePerPage(n int) error {
+	if n < 1 || n > MaxWidePageLen {
+		return fmt.Errorf("must be between 1 and %d", MaxWidePageLen)
+	}
+	return nil
+}
+
+// PageLen returns the page size to request from an endpoint that accepts at
+// most maxLen items per page, for a command showing up to limit results (0
+// for no limit). The size comes from --per-page, then the per_page setting,
+// and otherwise is limit itself; it is never more than limit or maxLen.
+// CollectPages follows further pages until limit results are found. A
+// result of 0 leaves the page size to Bitbucket.
+func PageLen(limit, maxLen int) int {
+	n := perPage()
+	if n == 0 || (limit > 0 && limit < n) {
+		n = limit
+	}
+	return min(n, maxLen)
+}
+
+// perPage returns the page size chosen with --per-page or the per_page
+// setting, or 0 if neither is set
+func perPage() int {
+	if globalFlags.perPage > 0 {
+		return globalFlags.perPage
+	}
+	return configPerPage()
+}
+
+// configPerPage returns the per_page setting, or 0 if it isn't set. The
+// config is read once, as commands that follow pages ask for every page.
+var configPerPage = sync.OnceValue(loadConfigPerPage)
+
+func loadConfigPerPage() int {
+	if cfg, err := config.LoadConfig(); err == nil && ValidatePerPage(cfg.PerPage) == nil {
+		return cfg.PerPage
+	}
+	return 0
+}
@@ -0,0 +1,76 @@
+package cmdutil
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/rbansal42/bitbucket-cli/internal/config"
+)
+
+func TestPageLen(t *testing.T) {
+	t.Setenv("BB_CONFIG_DIR", t.TempDir())
+	configPerPage = sync.OnceValue(loadConfigPerPage)
+	t.Cleanup(func() {
+		globalFlags.perPage = 0
+		configPerPage = sync.OnceValue(loadConfigPerPage)
+	})
+
+	tests := []struct {
+		limit, maxLen, want int
+	}{
+		{30, MaxPageLen, 30},
+		{500, MaxPageLen, 50},
+		{500, MaxWidePageLen, 100},
+		{0, MaxPageLen, 0},
+	}
+	for _, tt := range tests {
+		if got := PageLen(tt.limit, tt.maxLen); got != tt.want {
+			t.Errorf("PageLen(%d, %d) = %d, want %d", tt.limit, tt.maxLen, got, tt.want)
+		}
+	}
+
+	if err := config.SaveConfig(&config.Config{PerPage: 20}); err != nil {
+		t.Fatal(err)
+	}
+	// The setting is read once per run
+	if got := PageLen(500, MaxPageLen); got != MaxPageLen {
+		t.Errorf("PageLen after the setting changed = %d, want the value read first", got)
+	}
+	configPerPage = sync.OnceValue(loadConfigPerPage)
+	if got := PageLen(500, MaxPageLen); got != 20 {
+		t.Errorf("PageLen with per_page 20 = %d, want 20", got)
+	}
+	if got := PageLen(0, MaxPageLen); got != 20 {
+		t.Errorf("PageLen(0) with per_page 20 = %d, want 20", got)
+	}
+	if got := PageLen(5, MaxPageLen); got != 5 {
+		t.Errorf("PageLen(5) with per_page 20 = %d, want the limit", got)
+	}
+
+	// --per-page overrides the setting but not the endpoint maximum
+	if err := (PerPageFlag{}).Set("80"); err != nil {
+		t.Fatal(err)
+	}
+	if got := PageLen(500, MaxWidePageLen); got != 80 {
+		t.Errorf("PageLen with --per-page 80 = %d, want 80", got)
+	}
+	if got := PageLen(500, MaxPageLen); got != MaxPageLen {
+		t.Errorf("PageLen with --per-page 80 = %d, want %d", got, MaxPageLen)
+	}
+}
+
+func TestPerPageFlagSet(t *testing.T) {
+	t.Cleanup(func() { globalFlags.perPage = 0 })
+
+	for _, value := range []string{"0", "101", "-5", "ten"} {
+		if err := (PerPageFlag{}).Set(value); err == nil {
+			t.Errorf("Set(%q) succeeded, want an error", value)
+		}
+	}
+	if err := (PerPageFlag{}).Set("100"); err != nil {
+		t.Errorf("Set(100) error: %v", err)
+	}
+	if got := (PerPageFlag{}).String(); got != "100" {
+		t.Errorf("String() = %q, want 100", got)
+	}
+}
@@ -0,0 +1,32 @@
+package cmdutil
+
+import (
+	"os"
+
+	"github.com/rbansal42/bitbucket-cli/internal/config"
+	"github.com/rbansal42/bitbucket-cli/internal/iostreams"
+)
+
+// PagerCommand returns the pager to use: BB_PAGER, then the pager config
+// setting, then PAGER, then less
+func PagerCommand() string {
+	if p, ok := os.LookupEnv("BB_PAGER"); ok {
+		return p
+	}
+	if cfg, err := config.LoadConfig(); err == nil && cfg.Pager != "" {
+		return cfg.Pager
+	}
+	if p, ok := os.LookupEnv("PAGER"); ok {
+		return p
+	}
+	return "less"
+}
+
+// StartPager pages streams.Out through the configured pager when it is a
+// terminal. A pager that fails to start is reported and output goes
+// straight to the terminal instead. Callers must defer streams.StopPager().
+func StartPager(streams *iostreams.IOStreams) {
+	if err := streams.StartPager(PagerCommand()); err != nil {
+		streams.Warning("%v", err)
+	}
+}
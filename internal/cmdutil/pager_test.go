@@ -0,0 +1,19 @@
+package cmdutil
+
+import "testing"
+
+func TestPagerCommand(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	t.Setenv("BB_CONFIG_DIR", t.TempDir())
+
+	t.Setenv("PAGER", "more")
+	if got := PagerCommand(); got != "more" {
+		t.Errorf("PagerCommand() = %q, want PAGER", got)
+	}
+
+	t.Setenv("BB_PAGER", "cat")
+	if got := PagerCommand(); got != "cat" {
+		t.Errorf("PagerCommand() = %q, want BB_PAGER", got)
+	}
+}
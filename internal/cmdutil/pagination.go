@@ -0,0 +1,133 @@
+package cmdutil
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+
+	"github.com/spf13/cobra"
+
+	"github.com/rbansal42/bitbucket-cli/internal/api"
+	"github.com/rbansal42/bitbucket-cli/internal/iostreams"
+)
+
+// PageCount describes how many results a list command showed out of the
+// total the API reported.
+type PageCount struct {
+	Shown   int  `json:"shown"`
+	Total   int  `json:"total,omitempty"` // 0 when the API did not report a size
+	HasMore bool `json:"has_more"`
+}
+
+// NewPageCount builds a PageCount from a paginated response. shown is the
+// number of items actually displayed, which may differ from len(page.Values)
+// when the command filters results client-side.
+func NewPageCount[T any](page *api.Paginated[T], shown int) *PageCount {
+	count := &PageCount{
+		Shown:   shown,
+		Total:   page.Size,
+		HasMore: page.Next != "" || (page.Size > 0 && shown < page.Size),
+	}
+	if count.Total == 0 && !count.HasMore {
+		count.Total = shown
+	}
+	return count
+}
+
+// AddShowCountFlag registers the shared --show-count flag on a list command.
+func AddShowCountFlag(cmd *cobra.Command, showCount *bool) {
+	cmd.Flags().BoolVar(showCount, "show-count", false, "Show how many results were listed out of the total available")
+}
+
+// AddAllFlag registers the shared --all flag on a list command, which lifts
+// its --limit so every result is fetched. The two can't be combined.
+func AddAllFlag(cmd *cobra.Command, limit *int) {
+	cmd.Flags().Var(&allFlag{limit: limit}, "all", "Fetch every result instead of stopping at --limit")
+	cmd.Flags().Lookup("all").NoOptDefVal = "true"
+	cmd.MarkFlagsMutuallyExclusive("all", "limit")
+}
+
+// allFlag is the value of --all. Setting it raises the command's limit past
+// any result count, so the limit is followed until the results run out.
+type allFlag struct {
+	limit *int
+	set   bool
+}
+
+// String reports whether --all was given
+func (f *allFlag) String() string {
+	return strconv.FormatBool(f.set)
+}
+
+// Set records --all and lifts the limit
+func (f *allFlag) Set(value string) error {
+	all, err := strconv.ParseBool(value)
+	if err != nil {
+		return fmt.Errorf("must be true or false")
+	}
+	f.set = all
+	if all {
+		*f.limit = math.MaxInt
+	}
+	return nil
+}
+
+// Type returns the flag type shown in help
+func (f *allFlag) Type() string {
+	return "bool"
+}
+
+// IsBoolFlag lets --all be given without a value
+func (f *allFlag) IsBoolFlag() bool {
+	return true
+}
+
+// PrintPageCount writes a "Showing N of TOTAL" footer, with a hint when more
+// results are available than were shown.
+func PrintPageCount(streams *iostreams.IOStreams, count *PageCount) {
+	if count == nil {
+		return
+	}
+
+	fmt.Fprintln(streams.Out)
+	if count.Total > 0 {
+		fmt.Fprintf(streams.Out, "Showing %d of %d\n", count.Shown, count.Total)
+	} else {
+		fmt.Fprintf(streams.Out, "Showing %d\n", count.Shown)
+	}
+	if count.HasMore {
+		fmt.Fprintln(streams.Out, "More results are available; use --all to fetch everything")
+	}
+}
+
+// PrintListJSON prints values as JSON. When count is non-nil the values are
+// wrapped in an object alongside the pagination metadata.
+func PrintListJSON(streams *iostreams.IOStreams, values any, count *PageCount) error {
+	if count == nil {
+		return PrintJSON(streams, values)
+	}
+
+	return PrintJSON(streams, struct {
+		*PageCount
+		Values any `json:"values"`
+	}{count, values})
+}
+
+// ErrNoResults is returned by a list command run with --exit-code when
+// nothing matched. It is not printed; bb just exits with status 1.
+var ErrNoResults = errors.New("no results")
+
+// AddExitCodeFlag registers the shared --exit-code flag on a list command.
+func AddExitCodeFlag(cmd *cobra.Command, exitCode *bool) {
+	cmd.Flags().BoolVar(exitCode, "exit-code", false, "Exit with status 1 when there are no results")
+}
+
+// NoResults is what a list command returns after reporting that nothing
+// matched: ErrNoResults with --exit-code, nil otherwise.
+func NoResults(exitCode bool) error {
+	if exitCode {
+		return ErrNoResults
+	}
+	return nil
+}
@@ -0,0 +1,150 @@
+package cmdutil
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+
+	"github.com/rbansal42/bitbucket-cli/internal/api"
+	"github.com/rbansal42/bitbucket-cli/internal/iostreams"
+)
+
+func TestNewPageCount(t *testing.T) {
+	tests := []struct {
+		name        string
+		page        *api.Paginated[int]
+		shown       int
+		wantTotal   int
+		wantHasMore bool
+	}{
+		{
+			name:        "single complete page",
+			page:        &api.Paginated[int]{Size: 3, Values: []int{1, 2, 3}},
+			shown:       3,
+			wantTotal:   3,
+			wantHasMore: false,
+		},
+		{
+			name:        "more pages available",
+			page:        &api.Paginated[int]{Size: 120, Next: "https://example.com?page=2", Values: []int{1, 2}},
+			shown:       2,
+			wantTotal:   120,
+			wantHasMore: true,
+		},
+		{
+			name:        "size omitted with next page",
+			page:        &api.Paginated[int]{Next: "https://example.com?page=2", Values: []int{1}},
+			shown:       1,
+			wantTotal:   0,
+			wantHasMore: true,
+		},
+		{
+			name:        "size omitted on last page",
+			page:        &api.Paginated[int]{Values: []int{1, 2}},
+			shown:       2,
+			wantTotal:   2,
+			wantHasMore: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			count := NewPageCount(tt.page, tt.shown)
+			if count.Shown != tt.shown {
+				t.Errorf("Shown = %d, want %d", count.Shown, tt.shown)
+			}
+			if count.Total != tt.wantTotal {
+				t.Errorf("Total = %d, want %d", count.Total, tt.wantTotal)
+			}
+			if count.HasMore != tt.wantHasMore {
+				t.Errorf("HasMore = %v, want %v", count.HasMore, tt.wantHasMore)
+			}
+		})
+	}
+}
+
+func TestPrintPageCount(t *testing.T) {
+	out := &bytes.Buffer{}
+	streams := &iostreams.IOStreams{Out: out, ErrOut: &bytes.Buffer{}}
+
+	PrintPageCount(streams, &PageCount{Shown: 30, Total: 120, HasMore: true})
+
+	got := out.String()
+	if !strings.Contains(got, "Showing 30 of 120") {
+		t.Errorf("expected count line, got %q", got)
+	}
+	if !strings.Contains(got, "--all") {
+		t.Errorf("expected hint about more results, got %q", got)
+	}
+}
+
+func TestAddAllFlag(t *testing.T) {
+	newCmd := func(limit *int) *cobra.Command {
+		cmd := &cobra.Command{Use: "list", RunE: func(*cobra.Command, []string) error { return nil }}
+		cmd.Flags().IntVarP(limit, "limit", "l", 30, "")
+		AddAllFlag(cmd, limit)
+		return cmd
+	}
+
+	var limit int
+	cmd := newCmd(&limit)
+	cmd.SetArgs([]string{"--all"})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("--all error: %v", err)
+	}
+	if limit != math.MaxInt {
+		t.Errorf("limit = %d after --all, want no limit", limit)
+	}
+
+	cmd = newCmd(&limit)
+	cmd.SetArgs(nil)
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if limit != 30 {
+		t.Errorf("limit = %d without --all, want the default 30", limit)
+	}
+
+	cmd = newCmd(&limit)
+	cmd.SetArgs([]string{"--all", "--limit", "5"})
+	cmd.SilenceErrors, cmd.SilenceUsage = true, true
+	if err := cmd.Execute(); err == nil {
+		t.Error("expected --all and --limit to be rejected together")
+	}
+}
+
+func TestPrintListJSONWithCount(t *testing.T) {
+	out := &bytes.Buffer{}
+	streams := &iostreams.IOStreams{Out: out, ErrOut: &bytes.Buffer{}}
+
+	if err := PrintListJSON(streams, []string{"a", "b"}, &PageCount{Shown: 2, Total: 5, HasMore: true}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var got struct {
+		Shown   int      `json:"shown"`
+		Total   int      `json:"total"`
+		HasMore bool     `json:"has_more"`
+		Values  []string `json:"values"`
+	}
+	if err := json.Unmarshal(out.Bytes(), &got); err != nil {
+		t.Fatalf("invalid JSON output: %v", err)
+	}
+	if got.Shown != 2 || got.Total != 5 || !got.HasMore || len(got.Values) != 2 {
+		t.Errorf("unexpected output: %+v", got)
+	}
+}
+
+func TestNoResults(t *testing.T) {
+	if err := NoResults(false); err != nil {
+		t.Errorf("NoResults(false) = %v, want nil", err)
+	}
+	if err := NoResults(true); !errors.Is(err, ErrNoResults) {
+		t.Errorf("NoResults(true) = %v, want ErrNoResults", err)
+	}
+}
@@ -0,0 +1,295 @@
+package cmdutil
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"golang.org/x/term"
+
+	"github.com/rbansal42/bitbucket-cli/internal/iostreams"
+)
+
+// ErrNotInteractive is returned by interactive prompts when stdin or stderr
+// is not a terminal
+var ErrNotInteractive = errors.New("not running in an interactive terminal")
+
+// ErrSelectionCancelled is returned when the user aborts a prompt with
+// Ctrl-C, Esc or q
+var ErrSelectionCancelled = errors.New("selection cancelled")
+
+// NoPromptError is returned by Prompt and Confirm when a command would need
+// to ask for input but prompting is disabled, either with --no-prompt or
+// because stdin is not a terminal. Hint tells the user what to pass instead.
+type NoPromptError struct {
+	Hint string
+}
+
+func (e *NoPromptError) Error() string {
+	return "cannot prompt for input in non-interactive mode: " + e.Hint
+}
+
+// Unwrap lets callers match a NoPromptError with errors.Is(err, ErrNotInteractive)
+func (e *NoPromptError) Unwrap() error {
+	return ErrNotInteractive
+}
+
+// Prompt writes label and reads one line of input, returning it without
+// surrounding whitespace. When prompting is not possible it returns a
+// NoPromptError carrying hint, e.g. "pass --title".
+func Prompt(streams *iostreams.IOStreams, label, hint string) (string, error) {
+	if !streams.CanPrompt() {
+		return "", &NoPromptError{Hint: hint}
+	}
+
+	fmt.Fprint(streams.Out, label)
+	line, err := readLine(streams.In)
+	if err != nil {
+		return "", fmt.Errorf("failed to read input: %w", err)
+	}
+	return strings.TrimSpace(line), nil
+}
+
+// Confirm asks a yes/no question. An empty answer returns defaultYes. When
+// prompting is not possible it returns a NoPromptError carrying hint, e.g.
+// "pass --yes to confirm".
+func Confirm(streams *iostreams.IOStreams, question string, defaultYes bool, hint string) (bool, error) {
+	suffix := " [y/N] "
+	if defaultYes {
+		suffix = " [Y/n] "
+	}
+
+	answer, err := Prompt(streams, question+suffix, hint)
+	if err != nil {
+		return false, err
+	}
+
+	switch strings.ToLower(answer) {
+	case "":
+		return defaultYes, nil
+	case "y", "yes":
+		return true, nil
+	default:
+		return false, nil
+	}
+}
+
+// ConfirmByTyping guards a destructive action by asking the user to type
+// expected, such as a repository name. It returns true only when the typed
+// line matches exactly; an empty answer or end of input returns false. When
+// prompting is not possible it returns false with a NoPromptError, so
+// commands must check their bypass flag, e.g. --yes, before calling it.
+func ConfirmByTyping(streams *iostreams.IOStreams, expected string) (bool, error) {
+	answer, err := Prompt(streams, fmt.Sprintf("Type '%s' to confirm: ", expected), "pass the flag that skips confirmation")
+	if errors.Is(err, io.EOF) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return answer == expected, nil
+}
+
+// readLine reads up to and including the next newline one byte at a time,
+// so that consecutive prompts never lose input to a read-ahead buffer
+func readLine(r io.Reader) (string, error) {
+	var sb strings.Builder
+	buf := make([]byte, 1)
+	for {
+		n, err := r.Read(buf)
+		if n > 0 {
+			if buf[0] == '\n' {
+				return sb.String(), nil
+			}
+			sb.WriteByte(buf[0])
+		}
+		if err == io.EOF && sb.Len() > 0 {
+			return sb.String(), nil
+		}
+		if err != nil {
+			return "", err
+		}
+	}
+}
+
+// multiSelectPageSize is the number of items shown at once; longer lists
+// scroll with the cursor
+const multiSelectPageSize = 15
+
+// MultiSelect shows items as a checkbox list on the terminal and returns
+// the indices of the chosen items in ascending order. Arrow keys (or j/k)
+// move, space toggles, a toggles all and enter confirms. It requires an
+// interactive terminal and returns ErrNotInteractive otherwise.
+func MultiSelect(prompt string, items []string) ([]int, error) {
+	if len(items) == 0 {
+		return nil, nil
+	}
+
+	in, out := os.Stdin, os.Stderr
+	if !term.IsTerminal(int(in.Fd())) || !term.IsTerminal(int(out.Fd())) {
+		return nil, ErrNotInteractive
+	}
+
+	state, err := term.MakeRaw(int(in.Fd()))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read from terminal: %w", err)
+	}
+	defer func() { _ = term.Restore(int(in.Fd()), state) }()
+
+	return runMultiSelect(in, out, prompt, items, multiSelectPageSize)
+}
+
+type selectKey int
+
+const (
+	keyOther selectKey = iota
+	keyUp
+	keyDown
+	keyToggle
+	keyToggleAll
+	keyConfirm
+	keyCancel
+)
+
+// readSelectKey reads one keypress from a terminal in raw mode
+func readSelectKey(r *bufio.Reader) (selectKey, error) {
+	b, err := r.ReadByte()
+	if err != nil {
+		return keyOther, err
+	}
+
+	switch b {
+	case '\r', '\n':
+		return keyConfirm, nil
+	case ' ':
+		return keyToggle, nil
+	case 'a':
+		return keyToggleAll, nil
+	case 'k':
+		return keyUp, nil
+	case 'j':
+		return keyDown, nil
+	case 'q', 3, 4: // q, Ctrl-C, Ctrl-D
+		return keyCancel, nil
+	case 27: // Esc, or the start of an arrow key sequence
+		if r.Buffered() == 0 {
+			return keyCancel, nil
+		}
+		if next, _ := r.ReadByte(); next != '[' && next != 'O' {
+			return keyOther, nil
+		}
+		switch final, _ := r.ReadByte(); final {
+		case 'A':
+			return keyUp, nil
+		case 'B':
+			return keyDown, nil
+		}
+	}
+	return keyOther, nil
+}
+
+type multiSelectState struct {
+	prompt   string
+	items    []string
+	selected []bool
+	cursor   int
+	offset   int // index of the first visible item
+	pageSize int
+	drawn    int // number of lines written by the last render
+}
+
+// runMultiSelect drives the prompt from r, drawing to w. It is separate
+// from MultiSelect so the key handling can be exercised without a terminal.
+func runMultiSelect(r io.Reader, w io.Writer, prompt string, items []string, pageSize int) ([]int, error) {
+	s := &multiSelectState{
+		prompt:   prompt,
+		items:    items,
+		selected: make([]bool, len(items)),
+		pageSize: min(pageSize, len(items)),
+	}
+	reader := bufio.NewReader(r)
+
+	for {
+		s.render(w)
+
+		key, err := readSelectKey(reader)
+		if err != nil {
+			s.clear(w)
+			return nil, fmt.Errorf("failed to read selection: %w", err)
+		}
+
+		switch key {
+		case keyUp:
+			if s.cursor > 0 {
+				s.cursor--
+			}
+		case keyDown:
+			if s.cursor < len(s.items)-1 {
+				s.cursor++
+			}
+		case keyToggle:
+			s.selected[s.cursor] = !s.selected[s.cursor]
+		case keyToggleAll:
+			all := true
+			for _, sel := range s.selected {
+				all = all && sel
+			}
+			for i := range s.selected {
+				s.selected[i] = !all
+			}
+		case keyCancel:
+			s.clear(w)
+			return nil, ErrSelectionCancelled
+		case keyConfirm:
+			var chosen []int
+			for i, sel := range s.selected {
+				if sel {
+					chosen = append(chosen, i)
+				}
+			}
+			s.clear(w)
+			fmt.Fprintf(w, "? %s: %d selected\r\n", s.prompt, len(chosen))
+			return chosen, nil
+		}
+
+		// Keep the cursor inside the visible window
+		if s.cursor < s.offset {
+			s.offset = s.cursor
+		} else if s.cursor >= s.offset+s.pageSize {
+			s.offset = s.cursor - s.pageSize + 1
+		}
+	}
+}
+
+// render redraws the prompt in place. Lines end in \r\n because the
+// terminal is in raw mode.
+func (s *multiSelectState) render(w io.Writer) {
+	if s.drawn > 0 {
+		fmt.Fprintf(w, "\033[%dA", s.drawn)
+	}
+
+	fmt.Fprintf(w, "\r\033[2K? %s [space: toggle, a: all, enter: confirm]\r\n", s.prompt)
+	for i := s.offset; i < s.offset+s.pageSize; i++ {
+		pointer := " "
+		if i == s.cursor {
+			pointer = ">"
+		}
+		check := " "
+		if s.selected[i] {
+			check = "x"
+		}
+		fmt.Fprintf(w, "\r\033[2K%s [%s] %s\r\n", pointer, check, s.items[i])
+	}
+	s.drawn = s.pageSize + 1
+}
+
+// clear erases the lines written by render
+func (s *multiSelectState) clear(w io.Writer) {
+	if s.drawn > 0 {
+		fmt.Fprintf(w, "\033[%dA\r\033[J", s.drawn)
+		s.drawn = 0
+	}
+}
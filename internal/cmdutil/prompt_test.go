@@ -0,0 +1,187 @@
+package cmdutil
+
+import (
+	"bytes"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/rbansal42/bitbucket-cli/internal/iostreams"
+)
+
+func TestRunMultiSelect(t *testing.T) {
+	items := []string{"one", "two", "three", "four"}
+
+	tests := []struct {
+		name    string
+		input   string
+		want    []int
+		wantErr error
+	}{
+		{name: "nothing selected", input: "\r", want: nil},
+		{name: "toggle first", input: " \r", want: []int{0}},
+		{name: "move with j and k", input: "jjk \r", want: []int{1}},
+		{name: "arrow keys", input: "\033[B\033[B \033[A \r", want: []int{1, 2}},
+		{name: "toggle twice deselects", input: "  \r", want: nil},
+		{name: "cursor stops at the end", input: "jjjjjjj \r", want: []int{3}},
+		{name: "toggle all", input: "a\r", want: []int{0, 1, 2, 3}},
+		{name: "toggle all when all selected clears", input: "aa\r", want: nil},
+		{name: "ctrl-c cancels", input: " \x03", wantErr: ErrSelectionCancelled},
+		{name: "q cancels", input: "q", wantErr: ErrSelectionCancelled},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var out bytes.Buffer
+			got, err := runMultiSelect(strings.NewReader(tt.input), &out, "Pick", items, 2)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("expected error %v, got %v", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("runMultiSelect() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRunMultiSelectEOF(t *testing.T) {
+	var out bytes.Buffer
+	if _, err := runMultiSelect(strings.NewReader(" "), &out, "Pick", []string{"one"}, 5); err == nil {
+		t.Error("expected an error when input ends before confirming")
+	}
+}
+
+func TestPrompt_NonInteractive(t *testing.T) {
+	for _, tc := range []struct {
+		name        string
+		tty         bool
+		neverPrompt bool
+	}{
+		{name: "stdin not a terminal", tty: false},
+		{name: "--no-prompt", tty: true, neverPrompt: true},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			out := &bytes.Buffer{}
+			streams := &iostreams.IOStreams{In: strings.NewReader("answer\n"), Out: out, ErrOut: &bytes.Buffer{}}
+			streams.SetStdinTTY(tc.tty)
+			streams.SetNeverPrompt(tc.neverPrompt)
+
+			_, err := Prompt(streams, "Title: ", "pass --title")
+			var noPrompt *NoPromptError
+			if !errors.As(err, &noPrompt) {
+				t.Fatalf("Prompt() error = %v, want NoPromptError", err)
+			}
+			if !errors.Is(err, ErrNotInteractive) {
+				t.Error("NoPromptError should match ErrNotInteractive")
+			}
+			if !strings.Contains(err.Error(), "pass --title") {
+				t.Errorf("error %q does not include the hint", err)
+			}
+			if out.Len() != 0 {
+				t.Errorf("Prompt() wrote %q before failing", out.String())
+			}
+		})
+	}
+}
+
+func TestPrompt_ReadsOneLinePerCall(t *testing.T) {
+	streams := &iostreams.IOStreams{In: strings.NewReader("  first  \nsecond"), Out: &bytes.Buffer{}, ErrOut: &bytes.Buffer{}}
+	streams.SetStdinTTY(true)
+
+	for _, want := range []string{"first", "second"} {
+		got, err := Prompt(streams, "> ", "")
+		if err != nil {
+			t.Fatalf("Prompt() error: %v", err)
+		}
+		if got != want {
+			t.Errorf("Prompt() = %q, want %q", got, want)
+		}
+	}
+}
+
+func TestConfirm(t *testing.T) {
+	tests := []struct {
+		input      string
+		defaultYes bool
+		want       bool
+	}{
+		{"y\n", false, true},
+		{"YES\n", false, true},
+		{"n\n", true, false},
+		{"\n", false, false},
+		{"\n", true, true},
+		{"maybe\n", true, false},
+	}
+	for _, tt := range tests {
+		out := &bytes.Buffer{}
+		streams := &iostreams.IOStreams{In: strings.NewReader(tt.input), Out: out, ErrOut: &bytes.Buffer{}}
+		streams.SetStdinTTY(true)
+
+		got, err := Confirm(streams, "Continue?", tt.defaultYes, "pass --yes")
+		if err != nil {
+			t.Fatalf("Confirm(%q) error: %v", tt.input, err)
+		}
+		if got != tt.want {
+			t.Errorf("Confirm(%q, defaultYes=%v) = %v, want %v", tt.input, tt.defaultYes, got, tt.want)
+		}
+		wantSuffix := " [y/N] "
+		if tt.defaultYes {
+			wantSuffix = " [Y/n] "
+		}
+		if out.String() != "Continue?"+wantSuffix {
+			t.Errorf("Confirm() printed %q", out.String())
+		}
+	}
+}
+
+func TestConfirmByTyping(t *testing.T) {
+	tests := []struct {
+		input string
+		want  bool
+	}{
+		{"ws/repo\n", true},
+		{"  ws/repo  \n", true},
+		{"ws/repo", true},
+		{"repo\n", false},
+		{"WS/REPO\n", false},
+		{"\n", false},
+		{"", false},
+	}
+	for _, tt := range tests {
+		out := &bytes.Buffer{}
+		streams := &iostreams.IOStreams{In: strings.NewReader(tt.input), Out: out, ErrOut: &bytes.Buffer{}}
+		streams.SetStdinTTY(true)
+
+		got, err := ConfirmByTyping(streams, "ws/repo")
+		if err != nil {
+			t.Fatalf("ConfirmByTyping(%q) error: %v", tt.input, err)
+		}
+		if got != tt.want {
+			t.Errorf("ConfirmByTyping(%q) = %v, want %v", tt.input, got, tt.want)
+		}
+		if out.String() != "Type 'ws/repo' to confirm: " {
+			t.Errorf("ConfirmByTyping() printed %q", out.String())
+		}
+	}
+}
+
+func TestConfirmByTyping_NonInteractive(t *testing.T) {
+	streams := &iostreams.IOStreams{In: strings.NewReader("ws/repo\n"), Out: &bytes.Buffer{}, ErrOut: &bytes.Buffer{}}
+	streams.SetStdinTTY(false)
+
+	got, err := ConfirmByTyping(streams, "ws/repo")
+	if got {
+		t.Error("ConfirmByTyping() confirmed without a terminal")
+	}
+	var noPrompt *NoPromptError
+	if !errors.As(err, &noPrompt) {
+		t.Errorf("ConfirmByTyping() error = %v, want a NoPromptError", err)
+	}
+}
@@ -0,0 +1,64 @@
+package cmdutil
+
+import (
+	"strings"
+
+	"github.com/rbansal42/bitbucket-cli/internal/api"
+	"github.com/rbansal42/bitbucket-cli/internal/iostreams"
+)
+
+// PrintRepositoryTable writes repositories as the table used by repository
+// list commands.
+func PrintRepositoryTable(streams *iostreams.IOStreams, repos []api.RepositoryFull) error {
+	t := NewTableWriter(streams, "NAME", "DESCRIPTION", "VISIBILITY", "UPDATED")
+	t.SetFlexColumn(1)
+	t.SetMaxWidth(0, 40)
+
+	for _, repo := range repos {
+		visibility := formatRepoVisibility(streams, repo.IsPrivate)
+		updated := TimeAgo(repo.UpdatedOn)
+
+		t.AddRow(repo.FullName, repo.Description, visibility, updated)
+	}
+
+	return t.Render()
+}
+
+// PrintRepositoryListJSON writes a simplified JSON view of repositories,
+// wrapped with page counts when count is non-nil.
+func PrintRepositoryListJSON(streams *iostreams.IOStreams, repos []api.RepositoryFull, count *PageCount) error {
+	output := make([]map[string]interface{}, len(repos))
+	for i, repo := range repos {
+		workspace, _, _ := strings.Cut(repo.FullName, "/")
+		if repo.Workspace != nil && repo.Workspace.Slug != "" {
+			workspace = repo.Workspace.Slug
+		}
+		output[i] = map[string]interface{}{
+			"name":        repo.Name,
+			"workspace":   workspace,
+			"full_name":   repo.FullName,
+			"slug":        repo.Slug,
+			"description": repo.Description,
+			"is_private":  repo.IsPrivate,
+			"language":    repo.Language,
+			"updated_on":  repo.UpdatedOn,
+			"url":         repo.Links.HTML.Href,
+		}
+	}
+
+	return PrintListJSON(streams, output, count)
+}
+
+func formatRepoVisibility(streams *iostreams.IOStreams, isPrivate bool) string {
+	if isPrivate {
+		if streams.ColorEnabled() {
+			return iostreams.Yellow + "private" + iostreams.Reset
+		}
+		return "private"
+	}
+
+	if streams.ColorEnabled() {
+		return iostreams.Green + "public" + iostreams.Reset
+	}
+	return "public"
+}
@@ -2,24 +2,22 @@ package cmdutil
 
 import (
 	"fmt"
+	"net/url"
+	"regexp"
 	"strings"
 
+	"github.com/spf13/cobra"
+
+	"github.com/rbansal42/bitbucket-cli/internal/config"
 	"github.com/rbansal42/bitbucket-cli/internal/git"
 )
 
-// ParseRepository parses a repository string in WORKSPACE/REPO format,
-// or detects the repository from the current git remote if not specified.
+// ParseRepository parses a repository given as WORKSPACE/REPO or as a
+// Bitbucket URL (see ParseRepoArg), or detects the repository from the
+// current git remote if not specified.
 func ParseRepository(repoFlag string) (workspace, repoSlug string, err error) {
 	if repoFlag != "" {
-		parts := strings.SplitN(repoFlag, "/", 2)
-		if len(parts) != 2 {
-			return "", "", fmt.Errorf("invalid repository format: %s (expected workspace/repo)", repoFlag)
-		}
-		// Validate both parts are non-empty
-		if parts[0] == "" || parts[1] == "" {
-			return "", "", fmt.Errorf("invalid repository format: %s (workspace and repo cannot be empty)", repoFlag)
-		}
-		return parts[0], parts[1], nil
+		return ParseRepoArg(repoFlag)
 	}
 
 	// Detect from git
@@ -31,6 +29,76 @@ func ParseRepository(repoFlag string) (workspace, repoSlug string, err error) {
 	return remote.Workspace, remote.RepoSlug, nil
 }
 
+// bitbucketHosts are the hosts accepted in repository URLs
+var bitbucketHosts = map[string]bool{
+	"bitbucket.org":     true,
+	"www.bitbucket.org": true,
+}
+
+// ParseRepoArg parses a repository argument. Besides WORKSPACE/REPO it
+// accepts URLs copied from the browser or a clone dialog:
+//
+//	https://bitbucket.org/WORKSPACE/REPO
+//	https://bitbucket.org/WORKSPACE/REPO/pull-requests/1 (any deeper path)
+//	https://bitbucket.org/WORKSPACE/REPO.git
+//	git@bitbucket.org:WORKSPACE/REPO.git
+//	ssh://git@bitbucket.org/WORKSPACE/REPO.git
+func ParseRepoArg(arg string) (workspace, repoSlug string, err error) {
+	arg = strings.TrimSpace(arg)
+
+	if strings.Contains(arg, "://") {
+		return parseRepoURL(arg)
+	}
+	if rest, ok := strings.CutPrefix(arg, "git@"); ok {
+		host, path, found := strings.Cut(rest, ":")
+		if !found || !bitbucketHosts[strings.ToLower(host)] {
+			return "", "", fmt.Errorf("not a Bitbucket repository URL: %s", arg)
+		}
+		return repoFromURLPath(arg, path)
+	}
+
+	parts := strings.SplitN(arg, "/", 2)
+	if len(parts) != 2 {
+		return "", "", fmt.Errorf("invalid repository format: %s (expected workspace/repo)", arg)
+	}
+	// Validate both parts are non-empty
+	if parts[0] == "" || parts[1] == "" {
+		return "", "", fmt.Errorf("invalid repository format: %s (workspace and repo cannot be empty)", arg)
+	}
+	return parts[0], parts[1], nil
+}
+
+// parseRepoURL extracts the repository from an http(s) or ssh URL
+func parseRepoURL(raw string) (string, string, error) {
+	u, err := url.Parse(raw)
+	if err != nil {
+		return "", "", fmt.Errorf("invalid repository URL %s: %w", raw, err)
+	}
+	switch u.Scheme {
+	case "https", "http", "ssh":
+	default:
+		return "", "", fmt.Errorf("unsupported repository URL scheme %q: %s", u.Scheme, raw)
+	}
+	if !bitbucketHosts[strings.ToLower(u.Hostname())] {
+		return "", "", fmt.Errorf("not a Bitbucket repository URL: %s", raw)
+	}
+	return repoFromURLPath(raw, u.Path)
+}
+
+// repoFromURLPath takes the workspace and repository from the first two
+// segments of a URL path, ignoring anything after them and a .git suffix
+func repoFromURLPath(raw, path string) (string, string, error) {
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+	if len(segments) < 2 || segments[0] == "" || segments[1] == "" {
+		return "", "", fmt.Errorf("invalid repository URL: %s (expected a path like /workspace/repo)", raw)
+	}
+	repoSlug := strings.TrimSuffix(segments[1], ".git")
+	if repoSlug == "" {
+		return "", "", fmt.Errorf("invalid repository URL: %s (expected a path like /workspace/repo)", raw)
+	}
+	return segments[0], repoSlug, nil
+}
+
 // ParseWorkspace validates a workspace string.
 // Returns the trimmed workspace or an error if empty.
 func ParseWorkspace(workspace string) (string, error) {
@@ -40,3 +108,33 @@ func ParseWorkspace(workspace string) (string, error) {
 	}
 	return workspace, nil
 }
+
+// ResolveWorkspace returns the workspace for a command, taken from the
+// --workspace flag, then the default workspace in config, then the current
+// git remote.
+func ResolveWorkspace(cmd *cobra.Command) (string, error) {
+	if flag := cmd.Flags().Lookup("workspace"); flag != nil {
+		if ws := strings.TrimSpace(flag.Value.String()); ws != "" {
+			return ws, nil
+		}
+	}
+
+	if ws, err := config.GetDefaultWorkspace(); err == nil && ws != "" {
+		return ws, nil
+	}
+
+	if remote, err := git.GetDefaultRemote(); err == nil && remote.Workspace != "" {
+		return remote.Workspace, nil
+	}
+
+	return "", fmt.Errorf("workspace is required. Use --workspace or -w to specify, or set a default with 'bb workspace set-default'")
+}
+
+// projectKeyPattern matches Bitbucket project keys
+var projectKeyPattern = regexp.MustCompile(`^[A-Za-z][A-Za-z0-9_]*$`)
+
+// IsProjectKey reports whether s has the form of a project key: letters,
+// digits and underscores, starting with a letter
+func IsProjectKey(s string) bool {
+	return projectKeyPattern.MatchString(s)
+}
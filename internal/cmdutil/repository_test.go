@@ -0,0 +1,92 @@
+package cmdutil
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+)
+
+func TestResolveWorkspaceFromFlag(t *testing.T) {
+	root := &cobra.Command{Use: "root"}
+	root.PersistentFlags().String("workspace", "", "")
+	child := &cobra.Command{Use: "child", Run: func(*cobra.Command, []string) {}}
+	root.AddCommand(child)
+
+	root.SetArgs([]string{"child", "--workspace", " myteam "})
+	if err := root.Execute(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	ws, err := ResolveWorkspace(child)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if ws != "myteam" {
+		t.Errorf("expected workspace %q, got %q", "myteam", ws)
+	}
+}
+
+func TestParseRepoArg(t *testing.T) {
+	tests := []struct {
+		name          string
+		arg           string
+		wantWorkspace string
+		wantRepo      string
+		wantErr       string
+	}{
+		{name: "short form", arg: "myworkspace/myrepo", wantWorkspace: "myworkspace", wantRepo: "myrepo"},
+		{name: "https", arg: "https://bitbucket.org/myworkspace/myrepo", wantWorkspace: "myworkspace", wantRepo: "myrepo"},
+		{name: "https trailing slash", arg: "https://bitbucket.org/myworkspace/myrepo/", wantWorkspace: "myworkspace", wantRepo: "myrepo"},
+		{name: "https deeper path", arg: "https://bitbucket.org/myworkspace/myrepo/pull-requests/42/diff", wantWorkspace: "myworkspace", wantRepo: "myrepo"},
+		{name: "https source view", arg: "https://bitbucket.org/myworkspace/myrepo/src/main/README.md", wantWorkspace: "myworkspace", wantRepo: "myrepo"},
+		{name: "https query and fragment", arg: "https://bitbucket.org/myworkspace/myrepo?at=main#readme", wantWorkspace: "myworkspace", wantRepo: "myrepo"},
+		{name: "https clone URL with user", arg: "https://alice@bitbucket.org/myworkspace/myrepo.git", wantWorkspace: "myworkspace", wantRepo: "myrepo"},
+		{name: "www host", arg: "https://www.bitbucket.org/myworkspace/myrepo", wantWorkspace: "myworkspace", wantRepo: "myrepo"},
+		{name: "scp-style ssh", arg: "git@bitbucket.org:myworkspace/myrepo.git", wantWorkspace: "myworkspace", wantRepo: "myrepo"},
+		{name: "scp-style ssh without .git", arg: "git@bitbucket.org:myworkspace/myrepo", wantWorkspace: "myworkspace", wantRepo: "myrepo"},
+		{name: "ssh URL", arg: "ssh://git@bitbucket.org/myworkspace/myrepo.git", wantWorkspace: "myworkspace", wantRepo: "myrepo"},
+		{name: "surrounding whitespace", arg: "  https://bitbucket.org/myworkspace/myrepo\n", wantWorkspace: "myworkspace", wantRepo: "myrepo"},
+		{name: "missing slash", arg: "workspace-only", wantErr: "expected workspace/repo"},
+		{name: "empty workspace", arg: "/repo", wantErr: "cannot be empty"},
+		{name: "other host", arg: "https://github.com/owner/repo", wantErr: "not a Bitbucket repository URL"},
+		{name: "other ssh host", arg: "git@github.com:owner/repo.git", wantErr: "not a Bitbucket repository URL"},
+		{name: "workspace URL only", arg: "https://bitbucket.org/myworkspace", wantErr: "expected a path like /workspace/repo"},
+		{name: "bare .git repo segment", arg: "https://bitbucket.org/myworkspace/.git", wantErr: "expected a path like /workspace/repo"},
+		{name: "unsupported scheme", arg: "ftp://bitbucket.org/myworkspace/myrepo", wantErr: "unsupported repository URL scheme"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			workspace, repo, err := ParseRepoArg(tt.arg)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("ParseRepoArg(%q) error = %v, want error containing %q", tt.arg, err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseRepoArg(%q) unexpected error: %v", tt.arg, err)
+			}
+			if workspace != tt.wantWorkspace || repo != tt.wantRepo {
+				t.Errorf("ParseRepoArg(%q) = %s/%s, want %s/%s", tt.arg, workspace, repo, tt.wantWorkspace, tt.wantRepo)
+			}
+		})
+	}
+}
+
+func TestIsProjectKey(t *testing.T) {
+	for key, want := range map[string]bool{
+		"CORE":   true,
+		"web_2":  true,
+		"A":      true,
+		"":       false,
+		"2FAST":  false,
+		"MY-KEY": false,
+		"a b":    false,
+	} {
+		if got := IsProjectKey(key); got != want {
+			t.Errorf("IsProjectKey(%q) = %v, want %v", key, got, want)
+		}
+	}
+}
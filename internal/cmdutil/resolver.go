@@ -0,0 +1,321 @@
+package cmdutil
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+	"sync"
+
+	"golang.org/x/sync/errgroup"
+
+	"github.com/rbansal42/bitbucket-cli/internal/api"
+)
+
+// memberPageLen is the page size used when loading workspace members. It is
+// the largest page Bitbucket accepts for the permissions endpoint.
+const memberPageLen = 100
+
+// maxMemberPages bounds how many pages of workspace members a resolver will
+// load, so very large workspaces fall back to per-user lookups instead of
+// walking thousands of members.
+const maxMemberPages = 20
+
+// UserResolver resolves usernames to Bitbucket user UUIDs. Workspace members
+// are fetched in one batch on the first lookup and every result, including
+// failures, is cached for the lifetime of the resolver.
+//
+// Bitbucket has deprecated looking users up by username through
+// /users/{username}, so the resolver prefers the workspace member list and
+// only uses that endpoint for users it could not find there.
+type UserResolver struct {
+	client    *api.Client
+	workspace string
+
+	mu           sync.Mutex
+	loaded       bool
+	members      map[string]string   // lowercased username, nickname or account ID -> UUID
+	displayNames map[string][]string // lowercased display name -> UUIDs of the members with it
+	membersErr   error
+	resolved     map[string]string
+	failed       map[string]error
+}
+
+// selfKey is the cache key for the authenticated user, however it was named
+const selfKey = "@me"
+
+// NewUserResolver creates a resolver for users in the given workspace.
+func NewUserResolver(client *api.Client, workspace string) *UserResolver {
+	return &UserResolver{
+		client:    client,
+		workspace: workspace,
+		resolved:  make(map[string]string),
+		failed:    make(map[string]error),
+	}
+}
+
+type resolverKey struct {
+	client    *api.Client
+	workspace string
+}
+
+var (
+	resolversMu sync.Mutex
+	resolvers   = make(map[resolverKey]*UserResolver)
+)
+
+// UserResolverFor returns the process-wide resolver for a client and
+// workspace, creating it on first use, so that commands resolving several
+// users share one member fetch and one cache.
+func UserResolverFor(client *api.Client, workspace string) *UserResolver {
+	resolversMu.Lock()
+	defer resolversMu.Unlock()
+
+	key := resolverKey{client: client, workspace: workspace}
+	r, ok := resolvers[key]
+	if !ok {
+		r = NewUserResolver(client, workspace)
+		resolvers[key] = r
+	}
+	return r
+}
+
+// IsSelfReference reports whether name refers to the authenticated user.
+func IsSelfReference(name string) bool {
+	return name == "me" || name == "@me"
+}
+
+// ResolvePrincipal returns value unchanged unless it is "me" or "@me", in
+// which case it returns the authenticated user's username, or their UUID if
+// the account has no username. Commands pass user filters such as --author
+// and --assignee through it so that @me works the same everywhere.
+func ResolvePrincipal(ctx context.Context, client *api.Client, value string) (string, error) {
+	if !IsSelfReference(strings.TrimSpace(value)) {
+		return value, nil
+	}
+	if !client.Authenticated() {
+		return "", NewAuthError("%s refers to the logged-in user, but you are not logged in. Run 'bb auth login' to authenticate", value)
+	}
+
+	user, err := client.CurrentUser(ctx)
+	if err != nil {
+		var apiErr *api.APIError
+		if errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusUnauthorized {
+			return "", NewAuthError("%s refers to the logged-in user, but your credentials were rejected. Run 'bb auth login' to re-authenticate", value)
+		}
+		return "", fmt.Errorf("failed to get current user: %w", err)
+	}
+	if user.Username != "" {
+		return user.Username, nil
+	}
+	if user.UUID != "" {
+		return user.UUID, nil
+	}
+	return "", fmt.Errorf("could not determine the username of the logged-in user")
+}
+
+// Resolve returns the UUID for username. A value that is already a UUID
+// ({...}) is returned unchanged, and "me" or "@me" resolve to the
+// authenticated user. A display name only resolves when a single member has
+// it; members who share one must be named by account ID or UUID.
+func (r *UserResolver) Resolve(ctx context.Context, username string) (string, error) {
+	username = strings.TrimSpace(username)
+	if username == "" {
+		return "", fmt.Errorf("username is required")
+	}
+	if IsUUID(username) {
+		return username, nil
+	}
+
+	key := strings.ToLower(username)
+	if IsSelfReference(username) {
+		key = selfKey
+	}
+
+	r.mu.Lock()
+	if uuid, ok := r.resolved[key]; ok {
+		r.mu.Unlock()
+		return uuid, nil
+	}
+	if err, ok := r.failed[key]; ok {
+		r.mu.Unlock()
+		return "", err
+	}
+	var membersErr error
+	if key != selfKey {
+		if !r.loaded {
+			r.loadMembers(ctx)
+		}
+		if uuid, ok, err := r.memberUUID(key, username); ok {
+			if err != nil {
+				r.failed[key] = err
+			} else {
+				r.resolved[key] = uuid
+			}
+			r.mu.Unlock()
+			return uuid, err
+		}
+		membersErr = r.membersErr
+	}
+	r.mu.Unlock()
+
+	// The API lookups run without the lock so that ResolveAll can look up
+	// several users at once
+	var uuid string
+	var err error
+	if key == selfKey {
+		uuid, err = r.currentUserUUID(ctx)
+	} else {
+		uuid, err = r.lookupUser(ctx, username, membersErr)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if err != nil {
+		r.failed[key] = err
+		return "", err
+	}
+	r.resolved[key] = uuid
+	return uuid, nil
+}
+
+// memberUUID looks key up among the workspace members, by username,
+// nickname or account ID first and then by display name. ok is false when
+// no member matches. r.mu must be held.
+func (r *UserResolver) memberUUID(key, username string) (uuid string, ok bool, err error) {
+	if uuid, ok := r.members[key]; ok {
+		return uuid, true, nil
+	}
+	switch uuids := r.displayNames[key]; len(uuids) {
+	case 0:
+		return "", false, nil
+	case 1:
+		return uuids[0], true, nil
+	default:
+		return "", true, fmt.Errorf("%d members of workspace %q are named %q; use their account ID or UUID instead", len(uuids), r.workspace, username)
+	}
+}
+
+// currentUserUUID returns the UUID of the authenticated user
+func (r *UserResolver) currentUserUUID(ctx context.Context) (string, error) {
+	user, err := r.client.CurrentUser(ctx)
+	if err != nil {
+		return "", fmt.Errorf("failed to get current user: %w", err)
+	}
+	return user.UUID, nil
+}
+
+// resolveConcurrency bounds how many users ResolveAll looks up at once
+const resolveConcurrency = 4
+
+// UnresolvedUsersError is returned by ResolveAll when some users could not
+// be resolved. Errs holds the error for each name in Names.
+type UnresolvedUsersError struct {
+	Names []string
+	Errs  []error
+}
+
+func (e *UnresolvedUsersError) Error() string {
+	msgs := make([]string, len(e.Errs))
+	for i, err := range e.Errs {
+		msgs[i] = err.Error()
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// ResolveAll resolves usernames concurrently. It returns the UUIDs that could
+// be resolved, in the order given and without duplicates, along with an
+// *UnresolvedUsersError naming any that could not.
+func (r *UserResolver) ResolveAll(ctx context.Context, usernames []string) ([]string, error) {
+	uuids := make([]string, len(usernames))
+	errs := make([]error, len(usernames))
+
+	var g errgroup.Group
+	g.SetLimit(resolveConcurrency)
+	for i, username := range usernames {
+		g.Go(func() error {
+			uuids[i], errs[i] = r.Resolve(ctx, username)
+			return nil
+		})
+	}
+	_ = g.Wait()
+
+	var resolved []string
+	seen := make(map[string]bool)
+	unresolved := &UnresolvedUsersError{}
+	for i, username := range usernames {
+		if errs[i] != nil {
+			unresolved.Names = append(unresolved.Names, username)
+			unresolved.Errs = append(unresolved.Errs, errs[i])
+			continue
+		}
+		if !seen[uuids[i]] {
+			seen[uuids[i]] = true
+			resolved = append(resolved, uuids[i])
+		}
+	}
+
+	if len(unresolved.Names) > 0 {
+		return resolved, unresolved
+	}
+	return resolved, nil
+}
+
+// lookupUser resolves a username that is not a workspace member through the
+// deprecated per-user endpoint. It still works for users outside the
+// workspace and for workspaces too large to load. membersErr is the error
+// from loading the member list, if any.
+func (r *UserResolver) lookupUser(ctx context.Context, username string, membersErr error) (string, error) {
+	if user, err := r.client.GetUser(ctx, username); err == nil && user.UUID != "" {
+		return user.UUID, nil
+	}
+
+	if membersErr != nil {
+		return "", fmt.Errorf("could not resolve user %q: %w", username, membersErr)
+	}
+	return "", fmt.Errorf("user %q not found in workspace %q", username, r.workspace)
+}
+
+// loadMembers fetches the workspace member list once. A failure is recorded
+// rather than returned so lookups can still use the per-user fallback.
+// r.mu must be held.
+func (r *UserResolver) loadMembers(ctx context.Context) {
+	r.loaded = true
+	r.members = make(map[string]string)
+	r.displayNames = make(map[string][]string)
+
+	page, err := r.client.ListWorkspaceMembers(ctx, r.workspace, &api.WorkspaceMemberListOptions{Limit: memberPageLen})
+	for pages := 1; page != nil && err == nil; pages++ {
+		for _, m := range page.Values {
+			r.addMember(m.User)
+		}
+		if pages >= maxMemberPages {
+			break
+		}
+		page, err = api.NextPage(ctx, r.client, page)
+	}
+	if err != nil {
+		r.membersErr = fmt.Errorf("failed to list workspace members: %w", err)
+	}
+}
+
+// addMember indexes a member under every name a user might type. Display
+// names are kept apart, as several members may share one.
+func (r *UserResolver) addMember(u *api.User) {
+	if u == nil || u.UUID == "" {
+		return
+	}
+	for _, name := range []string{u.Username, u.Nickname, u.AccountID} {
+		if name != "" {
+			r.members[strings.ToLower(name)] = u.UUID
+		}
+	}
+	if u.DisplayName != "" {
+		key := strings.ToLower(u.DisplayName)
+		if !slices.Contains(r.displayNames[key], u.UUID) {
+			r.displayNames[key] = append(r.displayNames[key], u.UUID)
+		}
+	}
+}
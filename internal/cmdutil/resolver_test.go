@@ -0,0 +1,350 @@
+package cmdutil
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/rbansal42/bitbucket-cli/internal/api"
+)
+
+func newResolverServer(t *testing.T, handler http.HandlerFunc) *api.Client {
+	t.Helper()
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+	return api.NewClient(api.WithBaseURL(server.URL), api.WithToken("test-token"))
+}
+
+func TestUserResolver_CachesMembers(t *testing.T) {
+	var memberCalls, userCalls atomic.Int32
+	client := newResolverServer(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/workspaces/ws/permissions":
+			memberCalls.Add(1)
+			if got := r.URL.Query().Get("pagelen"); got != "100" {
+				t.Errorf("pagelen = %q, want 100", got)
+			}
+			fmt.Fprint(w, `{"values": [
+				{"user": {"uuid": "{alice}", "username": "alice", "display_name": "Alice A"}},
+				{"user": {"uuid": "{bob}", "nickname": "bobby", "account_id": "557058:bob"}}
+			]}`)
+		default:
+			userCalls.Add(1)
+			http.NotFound(w, r)
+		}
+	})
+
+	r := NewUserResolver(client, "ws")
+	ctx := context.Background()
+
+	tests := []struct {
+		name string
+		want string
+	}{
+		{"alice", "{alice}"},
+		{"ALICE", "{alice}"},
+		{"Alice A", "{alice}"},
+		{"bobby", "{bob}"},
+		{"557058:bob", "{bob}"},
+		{"alice", "{alice}"},
+	}
+	for _, tt := range tests {
+		got, err := r.Resolve(ctx, tt.name)
+		if err != nil {
+			t.Fatalf("Resolve(%q) error: %v", tt.name, err)
+		}
+		if got != tt.want {
+			t.Errorf("Resolve(%q) = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+
+	if n := memberCalls.Load(); n != 1 {
+		t.Errorf("member list fetched %d times, want 1", n)
+	}
+	if n := userCalls.Load(); n != 0 {
+		t.Errorf("/users endpoint called %d times, want 0", n)
+	}
+}
+
+func TestUserResolver_FallsBackToUsersEndpoint(t *testing.T) {
+	var userCalls atomic.Int32
+	client := newResolverServer(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/workspaces/ws/permissions":
+			fmt.Fprint(w, `{"values": [{"user": {"uuid": "{alice}", "username": "alice"}}]}`)
+		case "/users/carol":
+			userCalls.Add(1)
+			fmt.Fprint(w, `{"uuid": "{carol}", "username": "carol"}`)
+		default:
+			userCalls.Add(1)
+			http.NotFound(w, r)
+		}
+	})
+
+	r := NewUserResolver(client, "ws")
+	ctx := context.Background()
+
+	for i := 0; i < 2; i++ {
+		got, err := r.Resolve(ctx, "carol")
+		if err != nil {
+			t.Fatalf("Resolve(carol) error: %v", err)
+		}
+		if got != "{carol}" {
+			t.Errorf("Resolve(carol) = %q, want {carol}", got)
+		}
+	}
+
+	for i := 0; i < 2; i++ {
+		if _, err := r.Resolve(ctx, "nobody"); err == nil {
+			t.Error("Resolve(nobody) expected an error")
+		}
+	}
+
+	if n := userCalls.Load(); n != 2 {
+		t.Errorf("/users endpoint called %d times, want 2 (one per distinct user)", n)
+	}
+}
+
+func TestUserResolver_DisplayNames(t *testing.T) {
+	client := newResolverServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/workspaces/ws/permissions" {
+			http.NotFound(w, r)
+			return
+		}
+		// Sam appears twice, as members with several permissions can, and
+		// a display name matches another member's username
+		fmt.Fprint(w, `{"values": [
+			{"user": {"uuid": "{alex1}", "account_id": "557058:alex1", "display_name": "Alex Kim"}},
+			{"user": {"uuid": "{sam}", "display_name": "Sam Lee"}},
+			{"user": {"uuid": "{alex2}", "account_id": "557058:alex2", "display_name": "Alex Kim"}},
+			{"user": {"uuid": "{sam}", "display_name": "Sam Lee"}},
+			{"user": {"uuid": "{impostor}", "display_name": "dana"}},
+			{"user": {"uuid": "{dana}", "username": "dana"}}
+		]}`)
+	})
+
+	r := NewUserResolver(client, "ws")
+	ctx := context.Background()
+
+	tests := []struct {
+		name string
+		want string
+	}{
+		{"Sam Lee", "{sam}"},
+		{"557058:alex2", "{alex2}"},
+		{"{alex1}", "{alex1}"},
+		{"dana", "{dana}"},
+	}
+	for _, tt := range tests {
+		got, err := r.Resolve(ctx, tt.name)
+		if err != nil {
+			t.Fatalf("Resolve(%q) error: %v", tt.name, err)
+		}
+		if got != tt.want {
+			t.Errorf("Resolve(%q) = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+
+	_, err := r.Resolve(ctx, "alex kim")
+	if err == nil || !strings.Contains(err.Error(), "2 members") || !strings.Contains(err.Error(), "account ID or UUID") {
+		t.Errorf("Resolve(alex kim) error = %v, want an ambiguous name error", err)
+	}
+}
+
+func TestUserResolver_CachesCurrentUser(t *testing.T) {
+	var userCalls atomic.Int32
+	client := newResolverServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/user" {
+			t.Errorf("unexpected request %s", r.URL.Path)
+			http.NotFound(w, r)
+			return
+		}
+		userCalls.Add(1)
+		fmt.Fprint(w, `{"uuid": "{me}", "username": "me-user"}`)
+	})
+
+	r := NewUserResolver(client, "ws")
+	ctx := context.Background()
+	for _, name := range []string{"me", "@me", "me"} {
+		got, err := r.Resolve(ctx, name)
+		if err != nil {
+			t.Fatalf("Resolve(%q) error: %v", name, err)
+		}
+		if got != "{me}" {
+			t.Errorf("Resolve(%q) = %q, want {me}", name, got)
+		}
+	}
+	if got, err := ResolvePrincipal(ctx, client, "@me"); err != nil || got != "me-user" {
+		t.Errorf("ResolvePrincipal(@me) = %q, %v, want me-user", got, err)
+	}
+
+	if n := userCalls.Load(); n != 1 {
+		t.Errorf("/user called %d times, want 1", n)
+	}
+}
+
+func TestUserResolver_MemberListFailure(t *testing.T) {
+	client := newResolverServer(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/users/alice":
+			fmt.Fprint(w, `{"uuid": "{alice}"}`)
+		default:
+			w.WriteHeader(http.StatusForbidden)
+			fmt.Fprint(w, `{"type": "error", "error": {"message": "forbidden"}}`)
+		}
+	})
+
+	r := NewUserResolver(client, "ws")
+	got, err := r.Resolve(context.Background(), "alice")
+	if err != nil {
+		t.Fatalf("Resolve(alice) error: %v", err)
+	}
+	if got != "{alice}" {
+		t.Errorf("Resolve(alice) = %q, want {alice}", got)
+	}
+}
+
+func TestUserResolver_ResolveAll(t *testing.T) {
+	client := newResolverServer(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/workspaces/ws/permissions":
+			fmt.Fprint(w, `{"values": [{"user": {"uuid": "{alice}", "username": "alice"}}]}`)
+		default:
+			http.NotFound(w, r)
+		}
+	})
+
+	r := NewUserResolver(client, "ws")
+	uuids, err := r.ResolveAll(context.Background(), []string{"alice", "ghost", "{raw-uuid}", "ALICE", "bob"})
+	if err == nil {
+		t.Error("ResolveAll expected an error for the unknown user")
+	}
+	if len(uuids) != 2 || uuids[0] != "{alice}" || uuids[1] != "{raw-uuid}" {
+		t.Errorf("ResolveAll = %v, want [{alice} {raw-uuid}]", uuids)
+	}
+
+	var unresolved *UnresolvedUsersError
+	if !errors.As(err, &unresolved) {
+		t.Fatalf("ResolveAll error = %T, want *UnresolvedUsersError", err)
+	}
+	if len(unresolved.Names) != 2 || unresolved.Names[0] != "ghost" || unresolved.Names[1] != "bob" {
+		t.Errorf("unresolved names = %v, want [ghost bob]", unresolved.Names)
+	}
+	if !strings.Contains(err.Error(), `"ghost"`) || !strings.Contains(err.Error(), `"bob"`) {
+		t.Errorf("error = %q, want both names", err)
+	}
+}
+
+func TestUserResolver_ResolveAllConcurrent(t *testing.T) {
+	var inFlight, maxInFlight atomic.Int32
+	release := make(chan struct{})
+	client := newResolverServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/workspaces/ws/permissions" {
+			fmt.Fprint(w, `{"values": []}`)
+			return
+		}
+		n := inFlight.Add(1)
+		defer inFlight.Add(-1)
+		for {
+			m := maxInFlight.Load()
+			if n <= m || maxInFlight.CompareAndSwap(m, n) {
+				break
+			}
+		}
+		<-release
+		name := strings.TrimPrefix(r.URL.Path, "/users/")
+		fmt.Fprintf(w, `{"uuid": "{%s}"}`, name)
+	})
+
+	names := []string{"u1", "u2", "u3", "u4", "u5", "u6"}
+	done := make(chan []string)
+	go func() {
+		uuids, _ := NewUserResolver(client, "ws").ResolveAll(context.Background(), names)
+		done <- uuids
+	}()
+
+	// Wait until the lookups have started before letting them finish
+	for i := 0; i < 100 && inFlight.Load() < resolveConcurrency; i++ {
+		time.Sleep(10 * time.Millisecond)
+	}
+	close(release)
+
+	uuids := <-done
+	if len(uuids) != len(names) || uuids[0] != "{u1}" || uuids[5] != "{u6}" {
+		t.Errorf("ResolveAll = %v, want one UUID per name in order", uuids)
+	}
+	if n := maxInFlight.Load(); n < 2 || n > resolveConcurrency {
+		t.Errorf("max concurrent lookups = %d, want between 2 and %d", n, resolveConcurrency)
+	}
+}
+
+func TestUserResolverFor_SharesInstance(t *testing.T) {
+	client := api.NewClient()
+	if UserResolverFor(client, "ws") != UserResolverFor(client, "ws") {
+		t.Error("UserResolverFor returned different resolvers for the same workspace")
+	}
+	if UserResolverFor(client, "ws") == UserResolverFor(client, "other") {
+		t.Error("UserResolverFor shared a resolver across workspaces")
+	}
+}
+
+func TestResolvePrincipal(t *testing.T) {
+	var userCalls atomic.Int32
+	client := newResolverServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/user" {
+			http.NotFound(w, r)
+			return
+		}
+		userCalls.Add(1)
+		fmt.Fprint(w, `{"uuid": "{alice}", "username": "alice"}`)
+	})
+	ctx := context.Background()
+
+	for _, value := range []string{"@me", "me", "johndoe", ""} {
+		want := value
+		if IsSelfReference(value) {
+			want = "alice"
+		}
+		got, err := ResolvePrincipal(ctx, client, value)
+		if err != nil {
+			t.Fatalf("ResolvePrincipal(%q) error: %v", value, err)
+		}
+		if got != want {
+			t.Errorf("ResolvePrincipal(%q) = %q, want %q", value, got, want)
+		}
+	}
+	if n := userCalls.Load(); n != 1 {
+		t.Errorf("/user fetched %d times, want 1", n)
+	}
+}
+
+func TestResolvePrincipal_FallsBackToUUID(t *testing.T) {
+	client := newResolverServer(t, func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"uuid": "{alice}"}`)
+	})
+
+	got, err := ResolvePrincipal(context.Background(), client, "@me")
+	if err != nil {
+		t.Fatalf("ResolvePrincipal() error: %v", err)
+	}
+	if got != "{alice}" {
+		t.Errorf("ResolvePrincipal() = %q, want the UUID", got)
+	}
+}
+
+func TestResolvePrincipal_RequiresLogin(t *testing.T) {
+	_, err := ResolvePrincipal(context.Background(), api.NewClient(), "@me")
+	var authErr *AuthError
+	if !errors.As(err, &authErr) {
+		t.Fatalf("ResolvePrincipal() error = %v, want an AuthError", err)
+	}
+	if !strings.Contains(err.Error(), "bb auth login") {
+		t.Errorf("error = %q, want a login hint", err)
+	}
+}
@@ -0,0 +1,61 @@
+package cmdutil
+
+import (
+	"strings"
+
+	"github.com/rbansal42/bitbucket-cli/internal/iostreams"
+)
+
+// stateColors maps normalized pull request, issue, pipeline and commit status
+// states to the color they are rendered in. Keys are lowercased with spaces,
+// underscores and hyphens removed, so "IN_PROGRESS", "INPROGRESS" and
+// "on hold"/"ON_HOLD" share an entry.
+var stateColors = map[string]string{
+	// Pull requests
+	"open":       iostreams.Green,
+	"merged":     iostreams.Magenta,
+	"declined":   iostreams.Red,
+	"superseded": iostreams.Yellow,
+	"draft":      iostreams.Cyan,
+
+	// Issues
+	"new":       iostreams.Cyan,
+	"resolved":  iostreams.Magenta,
+	"closed":    iostreams.Magenta,
+	"onhold":    iostreams.Yellow,
+	"invalid":   iostreams.Red,
+	"duplicate": iostreams.Red,
+	"wontfix":   iostreams.Red,
+
+	// Pipelines and commit statuses
+	"successful": iostreams.Green,
+	"failed":     iostreams.Red,
+	"error":      iostreams.Red,
+	"stopped":    iostreams.Yellow,
+	"inprogress": iostreams.Yellow,
+	"running":    iostreams.Yellow,
+	"paused":     iostreams.Yellow,
+	"pending":    iostreams.Cyan,
+
+	// Commit signatures
+	"verified":   iostreams.Green,
+	"unverified": iostreams.Yellow,
+}
+
+// ColorState returns state wrapped in the color used for it across commands,
+// such as green for OPEN and red for DECLINED. Unknown states, and all states
+// when color is disabled, are returned unchanged.
+func ColorState(streams *iostreams.IOStreams, state string) string {
+	if !streams.ColorEnabled() {
+		return state
+	}
+	color, ok := stateColors[normalizeState(state)]
+	if !ok {
+		return state
+	}
+	return color + state + iostreams.Reset
+}
+
+func normalizeState(state string) string {
+	return strings.NewReplacer(" ", "", "_", "", "-", "").Replace(strings.ToLower(state))
+}
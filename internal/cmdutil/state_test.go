@@ -0,0 +1,35 @@
+package cmdutil
+
+import (
+	"testing"
+
+	"github.com/rbansal42/bitbucket-cli/internal/iostreams"
+)
+
+func TestColorState(t *testing.T) {
+	streams, _ := newTestStreams(true, 80)
+	streams.SetColorEnabled(true)
+
+	tests := []struct {
+		state string
+		want  string
+	}{
+		{"OPEN", iostreams.Green + "OPEN" + iostreams.Reset},
+		{"MERGED", iostreams.Magenta + "MERGED" + iostreams.Reset},
+		{"DECLINED", iostreams.Red + "DECLINED" + iostreams.Reset},
+		{"INPROGRESS", iostreams.Yellow + "INPROGRESS" + iostreams.Reset},
+		{"IN_PROGRESS", iostreams.Yellow + "IN_PROGRESS" + iostreams.Reset},
+		{"on hold", iostreams.Yellow + "on hold" + iostreams.Reset},
+		{"somethingelse", "somethingelse"},
+	}
+	for _, tt := range tests {
+		if got := ColorState(streams, tt.state); got != tt.want {
+			t.Errorf("ColorState(%q) = %q, want %q", tt.state, got, tt.want)
+		}
+	}
+
+	streams.SetColorEnabled(false)
+	if got := ColorState(streams, "OPEN"); got != "OPEN" {
+		t.Errorf("ColorState() with color disabled = %q, want plain OPEN", got)
+	}
+}
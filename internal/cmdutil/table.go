@@ -0,0 +1,154 @@
+package cmdutil
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/rbansal42/bitbucket-cli/internal/iostreams"
+)
+
+const (
+	// tableColumnGap is the number of spaces between columns
+	tableColumnGap = 2
+	// minFlexWidth is the narrowest the flexible column is squeezed to
+	minFlexWidth = 10
+)
+
+var ansiPattern = regexp.MustCompile(`\x1b\[[0-9;]*m`)
+
+// TableWriter renders aligned columns sized to fit the terminal. On a TTY the
+// flexible column (usually a title) is truncated so rows fit the terminal
+// width and per-column maximum widths are applied; when output is not a
+// terminal nothing is truncated.
+type TableWriter struct {
+	streams   *iostreams.IOStreams
+	header    []string
+	rows      [][]string
+	flexCol   int
+	maxWidths map[int]int
+}
+
+// NewTableWriter creates a TableWriter with the given column headers
+func NewTableWriter(streams *iostreams.IOStreams, header ...string) *TableWriter {
+	return &TableWriter{
+		streams:   streams,
+		header:    header,
+		flexCol:   -1,
+		maxWidths: make(map[int]int),
+	}
+}
+
+// SetFlexColumn marks the column that absorbs the remaining terminal width
+func (t *TableWriter) SetFlexColumn(col int) {
+	t.flexCol = col
+}
+
+// SetMaxWidth caps the width of a column when writing to a terminal
+func (t *TableWriter) SetMaxWidth(col, width int) {
+	t.maxWidths[col] = width
+}
+
+// AddRow appends a row. Cells may contain color codes; newlines are folded
+// into spaces.
+func (t *TableWriter) AddRow(fields ...string) {
+	row := make([]string, len(fields))
+	for i, f := range fields {
+		row[i] = strings.Join(strings.Fields(f), " ")
+	}
+	t.rows = append(t.rows, row)
+}
+
+// Render writes the header and rows to the output stream
+func (t *TableWriter) Render() error {
+	isTTY := t.streams.IsStdoutTTY()
+	widths := t.columnWidths(isTTY)
+
+	header := t.formatRow(t.header, widths, isTTY)
+	if t.streams.ColorEnabled() {
+		header = iostreams.Bold + header + iostreams.Reset
+	}
+	if _, err := fmt.Fprintln(t.streams.Out, header); err != nil {
+		return err
+	}
+
+	for _, row := range t.rows {
+		if _, err := fmt.Fprintln(t.streams.Out, t.formatRow(row, widths, isTTY)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// columnWidths computes the width of every column from the data, applying
+// terminal bounds when fit is true
+func (t *TableWriter) columnWidths(fit bool) []int {
+	widths := make([]int, len(t.header))
+	for i, h := range t.header {
+		widths[i] = displayWidth(h)
+	}
+	for _, row := range t.rows {
+		for i, cell := range row {
+			if i >= len(widths) {
+				widths = append(widths, 0)
+			}
+			widths[i] = max(widths[i], displayWidth(cell))
+		}
+	}
+
+	if !fit {
+		return widths
+	}
+
+	for col, limit := range t.maxWidths {
+		if col < len(widths) && widths[col] > limit {
+			widths[col] = limit
+		}
+	}
+
+	if t.flexCol >= 0 && t.flexCol < len(widths) {
+		total := tableColumnGap * (len(widths) - 1)
+		for _, w := range widths {
+			total += w
+		}
+		if excess := total - t.streams.TerminalWidth(); excess > 0 {
+			widths[t.flexCol] = max(minFlexWidth, widths[t.flexCol]-excess)
+		}
+	}
+
+	return widths
+}
+
+// formatRow pads (and on a terminal, truncates) cells to the column widths
+func (t *TableWriter) formatRow(row []string, widths []int, truncate bool) string {
+	var b strings.Builder
+	for i, cell := range row {
+		if truncate && displayWidth(cell) > widths[i] {
+			cell = truncateDisplay(cell, widths[i])
+		}
+		b.WriteString(cell)
+		if i < len(row)-1 {
+			b.WriteString(strings.Repeat(" ", widths[i]-displayWidth(cell)+tableColumnGap))
+		}
+	}
+	return b.String()
+}
+
+// displayWidth returns the number of visible characters in s, ignoring color codes
+func displayWidth(s string) int {
+	return utf8.RuneCountInString(ansiPattern.ReplaceAllString(s, ""))
+}
+
+// truncateDisplay shortens s to width visible characters, adding "..." when
+// there is room. Color codes are dropped from truncated cells.
+func truncateDisplay(s string, width int) string {
+	runes := []rune(ansiPattern.ReplaceAllString(s, ""))
+	if len(runes) <= width {
+		return string(runes)
+	}
+	if width <= 3 {
+		return string(runes[:width])
+	}
+	return string(runes[:width-3]) + "..."
+}
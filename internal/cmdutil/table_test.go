@@ -0,0 +1,81 @@
+package cmdutil
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/rbansal42/bitbucket-cli/internal/iostreams"
+)
+
+func newTestStreams(tty bool, width int) (*iostreams.IOStreams, *bytes.Buffer) {
+	out := &bytes.Buffer{}
+	streams := &iostreams.IOStreams{Out: out, ErrOut: &bytes.Buffer{}}
+	streams.SetStdoutTTY(tty)
+	streams.SetTerminalWidth(width)
+	return streams, out
+}
+
+func TestTableWriterFitsTerminal(t *testing.T) {
+	streams, out := newTestStreams(true, 30)
+
+	tw := NewTableWriter(streams, "ID", "TITLE", "STATE")
+	tw.SetFlexColumn(1)
+	tw.AddRow("1", "A very long pull request title that will not fit", "OPEN")
+	tw.AddRow("22", "Short", "MERGED")
+	if err := tw.Render(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	lines := strings.Split(strings.TrimRight(out.String(), "\n"), "\n")
+	if len(lines) != 3 {
+		t.Fatalf("expected 3 lines, got %d:\n%s", len(lines), out.String())
+	}
+	for _, line := range lines {
+		if w := displayWidth(line); w > 30 {
+			t.Errorf("line exceeds terminal width (%d): %q", w, line)
+		}
+	}
+	if !strings.Contains(lines[1], "...") {
+		t.Errorf("expected long title to be truncated, got %q", lines[1])
+	}
+	if !strings.HasPrefix(lines[2], "22  Short") {
+		t.Errorf("expected aligned columns, got %q", lines[2])
+	}
+}
+
+func TestTableWriterNoTruncationWhenNotTTY(t *testing.T) {
+	streams, out := newTestStreams(false, 30)
+
+	title := "A very long pull request title that will not fit"
+	tw := NewTableWriter(streams, "ID", "TITLE", "STATE")
+	tw.SetFlexColumn(1)
+	tw.SetMaxWidth(2, 2)
+	tw.AddRow("1", title, "OPEN")
+	if err := tw.Render(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !strings.Contains(out.String(), title) || !strings.Contains(out.String(), "OPEN") {
+		t.Errorf("expected untruncated output, got %q", out.String())
+	}
+}
+
+func TestTableWriterIgnoresColorCodesInWidth(t *testing.T) {
+	streams, out := newTestStreams(true, 80)
+
+	tw := NewTableWriter(streams, "STATUS", "NAME")
+	tw.AddRow(iostreams.Green+"open"+iostreams.Reset, "first")
+	tw.AddRow("closed", "second")
+	if err := tw.Render(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	lines := strings.Split(strings.TrimRight(out.String(), "\n"), "\n")
+	if got := strings.Index(ansiPattern.ReplaceAllString(lines[1], ""), "first"); got != 8 {
+		t.Errorf("expected NAME column at offset 8, got %d in %q", got, lines[1])
+	}
+	if got := strings.Index(lines[2], "second"); got != 8 {
+		t.Errorf("expected NAME column at offset 8, got %d in %q", got, lines[2])
+	}
+}
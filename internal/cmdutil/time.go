@@ -2,6 +2,7 @@ package cmdutil
 
 import (
 	"fmt"
+	"strings"
 	"time"
 )
 
@@ -72,3 +73,49 @@ func TimeAgoFromString(isoTime string) string {
 
 	return TimeAgo(t)
 }
+
+// dateLayouts are the formats accepted by ParseDate, most specific first
+var dateLayouts = []string{
+	time.RFC3339,
+	"2006-01-02T15:04:05",
+	"2006-01-02T15:04",
+	"2006-01-02 15:04:05",
+	"2006-01-02 15:04",
+	"2006-01-02",
+}
+
+// ParseDate parses a date given on the command line, either as a date
+// (2024-01-31), a date and time, or RFC3339. Values without a time zone
+// are taken to be UTC, matching how Bitbucket compares dates.
+func ParseDate(s string) (time.Time, error) {
+	s = strings.TrimSpace(s)
+	for _, layout := range dateLayouts {
+		if t, err := time.ParseInLocation(layout, s, time.UTC); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("invalid date %q: use YYYY-MM-DD, YYYY-MM-DDTHH:MM:SS, or RFC3339", s)
+}
+
+// ParseDateRange parses --since and --until values, either of which may be
+// empty, and checks that the range is not inverted.
+func ParseDateRange(since, until string) (time.Time, time.Time, error) {
+	var start, end time.Time
+	var err error
+
+	if since != "" {
+		if start, err = ParseDate(since); err != nil {
+			return start, end, fmt.Errorf("--since: %w", err)
+		}
+	}
+	if until != "" {
+		if end, err = ParseDate(until); err != nil {
+			return start, end, fmt.Errorf("--until: %w", err)
+		}
+	}
+	if !start.IsZero() && !end.IsZero() && !start.Before(end) {
+		return start, end, fmt.Errorf("--since must be earlier than --until")
+	}
+
+	return start, end, nil
+}
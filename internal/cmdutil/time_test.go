@@ -0,0 +1,53 @@
+package cmdutil
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseDate(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    time.Time
+		wantErr bool
+	}{
+		{input: "2024-01-31", want: time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC)},
+		{input: "2024-01-31T15:04:05", want: time.Date(2024, 1, 31, 15, 4, 5, 0, time.UTC)},
+		{input: "2024-01-31 15:04", want: time.Date(2024, 1, 31, 15, 4, 0, 0, time.UTC)},
+		{input: "2024-01-31T15:04:05+02:00", want: time.Date(2024, 1, 31, 13, 4, 5, 0, time.UTC)},
+		{input: "31/01/2024", wantErr: true},
+		{input: "yesterday", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := ParseDate(tt.input)
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("expected error, got %v", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !got.Equal(tt.want) {
+				t.Errorf("ParseDate(%q) = %v, want %v", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseDateRange(t *testing.T) {
+	if _, _, err := ParseDateRange("2024-02-01", "2024-01-01"); err == nil {
+		t.Error("expected error for inverted range")
+	}
+
+	since, until, err := ParseDateRange("2024-01-01", "")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if since.IsZero() || !until.IsZero() {
+		t.Errorf("expected open-ended range, got %v..%v", since, until)
+	}
+}
@@ -0,0 +1,51 @@
+package cmdutil
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/rbansal42/bitbucket-cli/internal/browser"
+	"github.com/rbansal42/bitbucket-cli/internal/config"
+	"github.com/rbansal42/bitbucket-cli/internal/iostreams"
+)
+
+// WebURL returns the web page at the slash-separated path elements, such as
+// a workspace and repository followed by "pull-requests", with query
+// appended if it has any values. The page is on the host commands talk to,
+// as chosen by Host.
+func WebURL(query url.Values, elems ...string) string {
+	// An unreadable hosts file leaves the choice to --host, BB_HOST or the
+	// default host
+	hosts, _ := config.LoadHostsConfig()
+	u := "https://" + Host(hosts)
+	for _, e := range elems {
+		if e = strings.Trim(e, "/"); e != "" {
+			u += "/" + e
+		}
+	}
+	if len(query) > 0 {
+		u += "?" + query.Encode()
+	}
+	return u
+}
+
+// OpenWeb opens url in the browser. The URL is printed instead when
+// printOnly is set or stdout is not a terminal, so scripts can capture it.
+func OpenWeb(streams *iostreams.IOStreams, url string, printOnly bool) error {
+	if printOnly || !streams.IsStdoutTTY() {
+		fmt.Fprintln(streams.Out, url)
+		return nil
+	}
+	if err := browser.Open(url); err != nil {
+		return fmt.Errorf("could not open browser: %w", err)
+	}
+	streams.Success("Opened %s in your browser", url)
+	return nil
+}
+
+// IsUUID reports whether s is a Bitbucket account or object UUID in braces,
+// the only form the web UI's filters accept
+func IsUUID(s string) bool {
+	return len(s) > 2 && strings.HasPrefix(s, "{") && strings.HasSuffix(s, "}")
+}
@@ -0,0 +1,44 @@
+package cmdutil
+
+import (
+	"net/url"
+	"testing"
+)
+
+func TestWebURL(t *testing.T) {
+	tests := []struct {
+		name  string
+		query url.Values
+		elems []string
+		want  string
+	}{
+		{"repository", nil, []string{"ws", "repo"}, "https://bitbucket.org/ws/repo"},
+		{"trailing slash", nil, []string{"ws", "repo", "pull-requests/"}, "https://bitbucket.org/ws/repo/pull-requests"},
+		{"query", url.Values{"state": {"MERGED"}, "author": {"{abc}"}}, []string{"ws", "repo", "pull-requests"}, "https://bitbucket.org/ws/repo/pull-requests?author=%7Babc%7D&state=MERGED"},
+		{"empty elements", nil, []string{"ws", ""}, "https://bitbucket.org/ws"},
+	}
+	t.Setenv("BB_CONFIG_DIR", t.TempDir())
+	t.Setenv("BB_HOST", "")
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := WebURL(tt.query, tt.elems...); got != tt.want {
+				t.Errorf("WebURL() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWebURLHost(t *testing.T) {
+	t.Setenv("BB_CONFIG_DIR", t.TempDir())
+	t.Cleanup(func() { SetHost("") })
+
+	t.Setenv("BB_HOST", "bitbucket.example.com")
+	if got, want := WebURL(nil, "ws", "repo"), "https://bitbucket.example.com/ws/repo"; got != want {
+		t.Errorf("WebURL() with BB_HOST = %q, want %q", got, want)
+	}
+
+	SetHost("git.example.org")
+	if got, want := WebURL(nil, "ws", "repo"), "https://git.example.org/ws/repo"; got != want {
+		t.Errorf("WebURL() with --host = %q, want %q", got, want)
+	}
+}
@@ -28,6 +28,17 @@ type Config struct {
 	Browser          string `yaml:"browser,omitempty"`
 	HTTPTimeout      int    `yaml:"http_timeout,omitempty"`
 	DefaultWorkspace string `yaml:"default_workspace,omitempty"`
+	DefaultProject   string `yaml:"default_project,omitempty"` // project key for 'bb repo create'
+	UpdateURL        string `yaml:"update_url,omitempty"`
+	MergeStrategy    string `yaml:"merge_strategy,omitempty"`
+	PerPage          int    `yaml:"per_page,omitempty"`
+
+	// CredentialHelper is a command that prints the token for a host; see
+	// GetTokenFromEnvOrKeyring
+	CredentialHelper string `yaml:"credential_helper,omitempty"`
+
+	// Aliases maps alias names to their expansions; see 'bb alias set'
+	Aliases map[string]string `yaml:"aliases,omitempty"`
 }
 
 // HostConfig represents per-host configuration
@@ -35,6 +46,19 @@ type HostConfig struct {
 	Users       map[string]*UserConfig `yaml:"users,omitempty"`
 	User        string                 `yaml:"user,omitempty"`
 	GitProtocol string                 `yaml:"git_protocol,omitempty"`
+
+	// APIVersion is "1.0" for Bitbucket Server and Data Center hosts and
+	// empty or "2.0" for Bitbucket Cloud
+	APIVersion string `yaml:"api_version,omitempty"`
+
+	// CACert is a PEM bundle of extra CA certificates to trust for the host
+	CACert string `yaml:"ca_cert,omitempty"`
+
+	// InsecureSkipVerify turns off TLS certificate verification for the host
+	InsecureSkipVerify bool `yaml:"insecure_skip_verify,omitempty"`
+
+	// Active marks the host most recently logged in to
+	Active bool `yaml:"active,omitempty"`
 }
 
 // UserConfig represents per-user configuration
@@ -83,6 +107,22 @@ func EnsureConfigDir() (string, error) {
 
 // LoadConfig loads the main config file
 func LoadConfig() (*Config, error) {
+	config, err := readConfigFile()
+	if err != nil {
+		return nil, err
+	}
+
+	// Return default config if file doesn't exist
+	if config == nil {
+		return defaultConfig(), nil
+	}
+
+	return config, nil
+}
+
+// readConfigFile reads the main config file, returning nil if it doesn't
+// exist so callers can tell unset values from defaults
+func readConfigFile() (*Config, error) {
 	dir, err := ConfigDir()
 	if err != nil {
 		return nil, err
@@ -90,9 +130,8 @@ func LoadConfig() (*Config, error) {
 
 	configPath := filepath.Join(dir, ConfigFileName)
 
-	// Return default config if file doesn't exist
 	if _, err := os.Stat(configPath); os.IsNotExist(err) {
-		return defaultConfig(), nil
+		return nil, nil
 	}
 
 	data, err := os.ReadFile(configPath)
@@ -185,7 +224,7 @@ func (h HostsConfig) GetActiveUser(host string) string {
 	return ""
 }
 
-// SetActiveUser sets the active user for a host
+// SetActiveUser sets the active user for a host and makes it the active host
 func (h HostsConfig) SetActiveUser(host, user string) {
 	if _, ok := h[host]; !ok {
 		h[host] = &HostConfig{
@@ -193,6 +232,9 @@ func (h HostsConfig) SetActiveUser(host, user string) {
 		}
 	}
 	h[host].User = user
+	for name, hostConfig := range h {
+		hostConfig.Active = name == host
+	}
 
 	// Ensure user exists in users map
 	if h[host].Users == nil {
@@ -203,6 +245,17 @@ func (h HostsConfig) SetActiveUser(host, user string) {
 	}
 }
 
+// ActiveHost returns the host most recently logged in to, or "" if it has
+// since been logged out of
+func (h HostsConfig) ActiveHost() string {
+	for host, hostConfig := range h {
+		if hostConfig.Active && hostConfig.User != "" {
+			return host
+		}
+	}
+	return ""
+}
+
 // GetGitProtocol returns the git protocol for a host
 func (h HostsConfig) GetGitProtocol(host string) string {
 	if hostConfig, ok := h[host]; ok && hostConfig.GitProtocol != "" {
@@ -211,9 +264,27 @@ func (h HostsConfig) GetGitProtocol(host string) string {
 	return "ssh" // default to ssh
 }
 
+// GetAPIVersion returns the REST API version of a host, "2.0" unless the
+// host is configured as a Bitbucket Server
+func (h HostsConfig) GetAPIVersion(host string) string {
+	if hostConfig, ok := h[host]; ok && hostConfig.APIVersion != "" {
+		return hostConfig.APIVersion
+	}
+	return "2.0"
+}
+
+// SetGitProtocol sets the git protocol for a host
+func (h HostsConfig) SetGitProtocol(host, protocol string) {
+	if _, ok := h[host]; !ok {
+		h[host] = &HostConfig{}
+	}
+	h[host].GitProtocol = protocol
+}
+
+// defaultConfig leaves git_protocol unset, so PreferredGitProtocol can
+// pick one from the SSH keys available
 func defaultConfig() *Config {
 	return &Config{
-		GitProtocol: "ssh",
 		Prompt:      "enabled",
 		HTTPTimeout: 30,
 	}
@@ -248,3 +319,12 @@ func SetDefaultWorkspace(workspace string) error {
 	config.DefaultWorkspace = workspace
 	return SaveConfig(config)
 }
+
+// GetDefaultProject returns the default project key from config
+func GetDefaultProject() (string, error) {
+	config, err := LoadConfig()
+	if err != nil {
+		return "", err
+	}
+	return config.DefaultProject, nil
+}
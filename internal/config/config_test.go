@@ -84,9 +84,9 @@ func TestDefaultConfig(t *testing.T) {
 		t.Fatal("defaultConfig() returned nil")
 	}
 
-	// Check GitProtocol default
-	if config.GitProtocol != "ssh" {
-		t.Errorf("defaultConfig().GitProtocol = %q, want %q", config.GitProtocol, "ssh")
+	// GitProtocol is left for PreferredGitProtocol to choose
+	if config.GitProtocol != "" {
+		t.Errorf("defaultConfig().GitProtocol = %q, want empty string", config.GitProtocol)
 	}
 
 	// Check Prompt default
@@ -164,6 +164,27 @@ func TestHostsConfig_SetActiveUser_ExistingHost(t *testing.T) {
 	}
 }
 
+func TestHostsConfig_ActiveHost(t *testing.T) {
+	hosts := HostsConfig{"bitbucket.org": {User: "me"}}
+	if got := hosts.ActiveHost(); got != "" {
+		t.Errorf("ActiveHost() before any login = %q, want empty", got)
+	}
+
+	hosts.SetActiveUser("bitbucket.example.com", "jdoe")
+	hosts.SetActiveUser("bitbucket.org", "me")
+	if got := hosts.ActiveHost(); got != "bitbucket.org" {
+		t.Errorf("ActiveHost() = %q, want the last host logged in to", got)
+	}
+	if hosts["bitbucket.example.com"].Active {
+		t.Error("SetActiveUser left the previous host active")
+	}
+
+	hosts["bitbucket.org"].User = ""
+	if got := hosts.ActiveHost(); got != "" {
+		t.Errorf("ActiveHost() after logout = %q, want empty", got)
+	}
+}
+
 func TestHostsConfig_GetActiveUser(t *testing.T) {
 	hosts := make(HostsConfig)
 	hosts["bitbucket.org"] = &HostConfig{User: "activeuser"}
@@ -294,3 +315,16 @@ func TestHostsConfig_SetActiveUser_NilUsersMap(t *testing.T) {
 		t.Error("SetActiveUser did not add user to Users map")
 	}
 }
+
+func TestHostsConfig_GetAPIVersion(t *testing.T) {
+	hosts := HostsConfig{
+		"git.example.com": &HostConfig{APIVersion: "1.0"},
+	}
+
+	if got := hosts.GetAPIVersion("git.example.com"); got != "1.0" {
+		t.Errorf("GetAPIVersion() for a Server host = %q, want 1.0", got)
+	}
+	if got := hosts.GetAPIVersion(DefaultHost); got != "2.0" {
+		t.Errorf("GetAPIVersion() for an unconfigured host = %q, want 2.0", got)
+	}
+}
@@ -0,0 +1,63 @@
+package config
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+// ErrCredentialHelper is returned when the configured credential helper
+// fails
+var ErrCredentialHelper = errors.New("credential helper failed")
+
+// getHelperToken runs the credential_helper command from the config file
+// with sh, writing the host to its standard input, and returns the token it
+// prints along with a description of its source. Only the command's name is
+// reported, never its arguments. Like a git credential helper, a helper that
+// succeeds without printing anything has no token for the host, and an empty
+// token is returned so the keyring is tried next. A helper that fails is an
+// error rather than a quiet fallback to other credentials.
+func getHelperToken(host string) (string, string, error) {
+	cfg, err := readConfigFile()
+	if err != nil {
+		return "", "", err
+	}
+	if cfg == nil || strings.TrimSpace(cfg.CredentialHelper) == "" {
+		return "", "", nil
+	}
+	helper := strings.TrimSpace(cfg.CredentialHelper)
+	name := helperName(helper)
+	source := strings.TrimSpace("credential helper " + name)
+
+	var stdout bytes.Buffer
+	cmd := exec.Command("sh", "-c", helper)
+	cmd.Stdin = strings.NewReader(host + "\n")
+	cmd.Stdout = &stdout
+	// The helper may need to prompt, e.g. to log in to the secrets manager
+	cmd.Stderr = os.Stderr
+	if err := cmd.Run(); err != nil {
+		if name == "" {
+			return "", "", fmt.Errorf("%w: %w", ErrCredentialHelper, err)
+		}
+		return "", "", fmt.Errorf("%w: %s: %w", ErrCredentialHelper, name, err)
+	}
+	return strings.TrimSpace(stdout.String()), source, nil
+}
+
+// helperName returns the name of the program a credential_helper command
+// runs, without its arguments or directory, which may hold secrets, or ""
+// if it has none
+func helperName(helper string) string {
+	for _, field := range strings.Fields(helper) {
+		// Skip environment assignments such as VAULT_TOKEN=... before it
+		if strings.Contains(field, "=") {
+			continue
+		}
+		return filepath.Base(field)
+	}
+	return ""
+}
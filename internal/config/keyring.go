@@ -1,8 +1,10 @@
 package config
 
 import (
+	"errors"
 	"fmt"
 	"os"
+	"strings"
 
 	"github.com/zalando/go-keyring"
 )
@@ -18,6 +20,11 @@ type KeyringToken struct {
 	RefreshToken string `json:"refresh_token,omitempty"`
 	TokenType    string `json:"token_type,omitempty"`
 	ExpiresIn    int    `json:"expires_in,omitempty"`
+	Scopes       string `json:"scopes,omitempty"`
+
+	// The OAuth consumer the token was issued to, needed to refresh it
+	ClientID     string `json:"client_id,omitempty"`
+	ClientSecret string `json:"client_secret,omitempty"`
 }
 
 // keyringKey generates the keyring key for a host and user
@@ -60,16 +67,36 @@ func HasToken(host, user string) bool {
 	return err == nil
 }
 
-// GetTokenFromEnvOrKeyring tries to get a token from environment variable first,
-// then falls back to the keyring
+// GetTokenFromEnvOrKeyring tries to get a token from environment variables
+// first, then from the file named by BB_TOKEN_FILE, then from the configured
+// credential helper, and then falls back to the keyring. The second result
+// describes where the token came from.
 func GetTokenFromEnvOrKeyring(host, user string) (string, string, error) {
 	// Check environment variable first
 	if token := getEnvToken(); token != "" {
 		return token, "environment", nil
 	}
 
+	// Then a mounted secret file, as used by Docker and Kubernetes
+	token, path, err := getFileToken()
+	if err != nil {
+		return "", "", err
+	}
+	if token != "" {
+		return token, "file " + path, nil
+	}
+
+	// Then an external command, such as one reading from a secrets manager
+	token, source, err := getHelperToken(host)
+	if err != nil {
+		return "", "", err
+	}
+	if token != "" {
+		return token, source, nil
+	}
+
 	// Fall back to keyring
-	token, err := GetToken(host, user)
+	token, err = GetToken(host, user)
 	if err != nil {
 		return "", "", err
 	}
@@ -92,6 +119,34 @@ func getEnvToken() string {
 	return ""
 }
 
+// ErrTokenFile is returned when BB_TOKEN_FILE is set but the token cannot be
+// read from it
+var ErrTokenFile = errors.New("cannot read token file")
+
+// getFileToken reads the token from the file named by BB_TOKEN_FILE or
+// BITBUCKET_TOKEN_FILE, returning the token and the file's path. It returns
+// an empty token when neither is set, and an error when the file cannot be
+// read or is empty, rather than quietly falling back to other credentials.
+func getFileToken() (string, string, error) {
+	path := lookupEnv("BB_TOKEN_FILE")
+	if path == "" {
+		path = lookupEnv("BITBUCKET_TOKEN_FILE")
+	}
+	if path == "" {
+		return "", "", nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", "", fmt.Errorf("%w: %w", ErrTokenFile, err)
+	}
+	token := strings.TrimSpace(string(data))
+	if token == "" {
+		return "", "", fmt.Errorf("%w: %s is empty", ErrTokenFile, path)
+	}
+	return token, path, nil
+}
+
 func lookupEnv(key string) string {
 	return os.Getenv(key)
 }
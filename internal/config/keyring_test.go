@@ -1,6 +1,10 @@
 package config
 
 import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 )
 
@@ -149,3 +153,109 @@ func TestKeyringKey_Format(t *testing.T) {
 		t.Error("keyringKey should use ':' as separator between host and user")
 	}
 }
+
+func TestGetTokenFromEnvOrKeyring_TokenFile(t *testing.T) {
+	t.Setenv("BB_TOKEN", "")
+	t.Setenv("BITBUCKET_TOKEN", "")
+	t.Setenv("BITBUCKET_TOKEN_FILE", "")
+
+	path := filepath.Join(t.TempDir(), "token")
+	if err := os.WriteFile(path, []byte("  file-token-value\n"), 0600); err != nil {
+		t.Fatalf("failed to write token file: %v", err)
+	}
+	t.Setenv("BB_TOKEN_FILE", path)
+
+	token, source, err := GetTokenFromEnvOrKeyring("bitbucket.org", "")
+	if err != nil {
+		t.Fatalf("GetTokenFromEnvOrKeyring() error: %v", err)
+	}
+	if token != "file-token-value" {
+		t.Errorf("token = %q, want the trimmed file contents", token)
+	}
+	if source != "file "+path {
+		t.Errorf("source = %q, want the file path", source)
+	}
+
+	// An environment token still takes precedence
+	t.Setenv("BB_TOKEN", "env-token")
+	if token, _, _ := GetTokenFromEnvOrKeyring("bitbucket.org", ""); token != "env-token" {
+		t.Errorf("token = %q, want BB_TOKEN to take precedence", token)
+	}
+}
+
+func TestGetTokenFromEnvOrKeyring_TokenFileErrors(t *testing.T) {
+	t.Setenv("BB_TOKEN", "")
+	t.Setenv("BITBUCKET_TOKEN", "")
+	t.Setenv("BB_TOKEN_FILE", "")
+
+	empty := filepath.Join(t.TempDir(), "empty")
+	if err := os.WriteFile(empty, []byte("\n"), 0600); err != nil {
+		t.Fatalf("failed to write token file: %v", err)
+	}
+
+	for _, path := range []string{empty, filepath.Join(t.TempDir(), "missing")} {
+		t.Setenv("BITBUCKET_TOKEN_FILE", path)
+		_, _, err := GetTokenFromEnvOrKeyring("bitbucket.org", "")
+		if !errors.Is(err, ErrTokenFile) {
+			t.Errorf("GetTokenFromEnvOrKeyring() with %s error = %v, want ErrTokenFile", path, err)
+		}
+	}
+}
+
+func TestGetTokenFromEnvOrKeyring_CredentialHelper(t *testing.T) {
+	t.Setenv("BB_TOKEN", "")
+	t.Setenv("BITBUCKET_TOKEN", "")
+	t.Setenv("BB_TOKEN_FILE", "")
+	t.Setenv("BITBUCKET_TOKEN_FILE", "")
+	dir := t.TempDir()
+	t.Setenv("BB_CONFIG_DIR", dir)
+
+	// The fake helper prints a token for the host it reads from stdin, and
+	// nothing for other hosts
+	script := filepath.Join(dir, "helper.sh")
+	helper := `#!/bin/sh
+read -r host
+case "$host" in
+bitbucket.org) echo "  helper-token-$host" ;;
+fail.example.com) exit 3 ;;
+esac
+`
+	if err := os.WriteFile(script, []byte(helper), 0700); err != nil {
+		t.Fatalf("failed to write helper: %v", err)
+	}
+	// Arguments, such as a secret's path, are never reported
+	if err := SaveConfig(&Config{CredentialHelper: "VAULT_ADDR=https://vault.internal " + script + " --secret team/bb-token"}); err != nil {
+		t.Fatalf("SaveConfig() error: %v", err)
+	}
+
+	token, source, err := GetTokenFromEnvOrKeyring("bitbucket.org", "")
+	if err != nil {
+		t.Fatalf("GetTokenFromEnvOrKeyring() error: %v", err)
+	}
+	if token != "helper-token-bitbucket.org" {
+		t.Errorf("token = %q, want the trimmed helper output", token)
+	}
+	if source != "credential helper helper.sh" {
+		t.Errorf("source = %q, want the credential helper's name only", source)
+	}
+
+	_, _, err = GetTokenFromEnvOrKeyring("fail.example.com", "")
+	if !errors.Is(err, ErrCredentialHelper) {
+		t.Errorf("GetTokenFromEnvOrKeyring() with a failing helper error = %v, want ErrCredentialHelper", err)
+	}
+	if err != nil && (strings.Contains(err.Error(), "team/bb-token") || strings.Contains(err.Error(), "vault.internal") || strings.Contains(err.Error(), dir)) {
+		t.Errorf("error %q reveals the helper's command line", err)
+	}
+
+	// A helper with no token for the host is skipped
+	token, _, err = getHelperToken("other.example.com")
+	if err != nil || token != "" {
+		t.Errorf("getHelperToken() = %q, %v, want no token and no error", token, err)
+	}
+
+	// An environment token still takes precedence
+	t.Setenv("BB_TOKEN", "env-token")
+	if token, _, _ := GetTokenFromEnvOrKeyring("bitbucket.org", ""); token != "env-token" {
+		t.Errorf("token = %q, want BB_TOKEN to take precedence", token)
+	}
+}
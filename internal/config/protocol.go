@@ -0,0 +1,63 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+)
+
+// Git protocols that can be used for clone URLs
+const (
+	GitProtocolHTTPS = "https"
+	GitProtocolSSH   = "ssh"
+)
+
+// GitProtocols lists the valid values for git_protocol
+var GitProtocols = []string{GitProtocolHTTPS, GitProtocolSSH}
+
+// sshKeyFiles are the default private keys ssh offers, relative to ~/.ssh
+var sshKeyFiles = []string{"id_ed25519", "id_ecdsa", "id_rsa", "id_ed25519_sk", "id_ecdsa_sk"}
+
+// PreferredGitProtocol returns the protocol to use for clone URLs on host.
+// A git_protocol set for the host in hosts.yml wins over the one in
+// config.yml; with neither set, ssh is used only when an SSH key or agent
+// is available.
+func PreferredGitProtocol(host string) string {
+	if hosts, err := LoadHostsConfig(); err == nil {
+		if hostConfig, ok := hosts[host]; ok && hostConfig.GitProtocol != "" {
+			return hostConfig.GitProtocol
+		}
+	}
+
+	if cfg, err := readConfigFile(); err == nil && cfg != nil && cfg.GitProtocol != "" {
+		return cfg.GitProtocol
+	}
+
+	return DefaultGitProtocol()
+}
+
+// DefaultGitProtocol returns ssh if an SSH agent is running or a default
+// SSH key exists, and https otherwise
+func DefaultGitProtocol() string {
+	if hasSSHKey() {
+		return GitProtocolSSH
+	}
+	return GitProtocolHTTPS
+}
+
+func hasSSHKey() bool {
+	if os.Getenv("SSH_AUTH_SOCK") != "" {
+		return true
+	}
+
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return false
+	}
+
+	for _, name := range sshKeyFiles {
+		if _, err := os.Stat(filepath.Join(home, ".ssh", name)); err == nil {
+			return true
+		}
+	}
+	return false
+}
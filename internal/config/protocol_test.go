@@ -0,0 +1,85 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestPreferredGitProtocol(t *testing.T) {
+	t.Setenv("BB_CONFIG_DIR", t.TempDir())
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("SSH_AUTH_SOCK", "")
+
+	if got := PreferredGitProtocol(DefaultHost); got != GitProtocolHTTPS {
+		t.Errorf("without an SSH key = %q, want https", got)
+	}
+
+	if err := os.MkdirAll(filepath.Join(home, ".ssh"), 0700); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(home, ".ssh", "id_ed25519"), []byte("key"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if got := PreferredGitProtocol(DefaultHost); got != GitProtocolSSH {
+		t.Errorf("with an SSH key = %q, want ssh", got)
+	}
+
+	// Saving another setting doesn't pin the protocol
+	cfg, err := LoadConfig()
+	if err != nil {
+		t.Fatal(err)
+	}
+	cfg.Editor = "vim"
+	if err := SaveConfig(cfg); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Remove(filepath.Join(home, ".ssh", "id_ed25519")); err != nil {
+		t.Fatal(err)
+	}
+	if got := PreferredGitProtocol(DefaultHost); got != GitProtocolHTTPS {
+		t.Errorf("after saving the editor, without an SSH key = %q, want https", got)
+	}
+	if err := os.WriteFile(filepath.Join(home, ".ssh", "id_ed25519"), []byte("key"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := SaveConfig(&Config{GitProtocol: GitProtocolHTTPS}); err != nil {
+		t.Fatal(err)
+	}
+	if got := PreferredGitProtocol(DefaultHost); got != GitProtocolHTTPS {
+		t.Errorf("with global git_protocol = %q, want https", got)
+	}
+
+	hosts := HostsConfig{}
+	hosts.SetGitProtocol("bitbucket.example.com", GitProtocolSSH)
+	if err := SaveHostsConfig(hosts); err != nil {
+		t.Fatal(err)
+	}
+	if got := PreferredGitProtocol("bitbucket.example.com"); got != GitProtocolSSH {
+		t.Errorf("with per-host git_protocol = %q, want ssh", got)
+	}
+	if got := PreferredGitProtocol(DefaultHost); got != GitProtocolHTTPS {
+		t.Errorf("other host = %q, want the global https", got)
+	}
+}
+
+func TestDefaultGitProtocol_SSHAgent(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("SSH_AUTH_SOCK", "/tmp/agent.sock")
+
+	if got := DefaultGitProtocol(); got != GitProtocolSSH {
+		t.Errorf("DefaultGitProtocol() with an agent = %q, want ssh", got)
+	}
+}
+
+func TestHostsConfig_SetGitProtocol(t *testing.T) {
+	hosts := HostsConfig{}
+	hosts.SetActiveUser(DefaultHost, "alice")
+	hosts.SetGitProtocol(DefaultHost, GitProtocolHTTPS)
+
+	if hosts[DefaultHost].User != "alice" || hosts[DefaultHost].GitProtocol != GitProtocolHTTPS {
+		t.Errorf("SetGitProtocol() = %+v", hosts[DefaultHost])
+	}
+}
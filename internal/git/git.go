@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"os/exec"
 	"regexp"
+	"strconv"
 	"strings"
 )
 
@@ -190,6 +191,35 @@ func GetRepoRoot() (string, error) {
 	return strings.TrimSpace(stdout.String()), nil
 }
 
+// RefExists reports whether ref resolves to a commit in the local repository
+func RefExists(ref string) bool {
+	cmd := exec.Command("git", "rev-parse", "--verify", "--quiet", ref+"^{commit}")
+	return cmd.Run() == nil
+}
+
+// CountCommits returns the number of commits reachable from head but not
+// from base
+func CountCommits(base, head string) (int, error) {
+	out, err := exec.Command("git", "rev-list", "--count", base+".."+head).Output()
+	if err != nil {
+		return 0, fmt.Errorf("failed to count commits in %s..%s: %w", base, head, err)
+	}
+	return strconv.Atoi(strings.TrimSpace(string(out)))
+}
+
+// MergeBase returns the best common ancestor of two commits
+func MergeBase(a, b string) (string, error) {
+	cmd := exec.Command("git", "merge-base", a, b)
+	var stdout bytes.Buffer
+	cmd.Stdout = &stdout
+
+	if err := cmd.Run(); err != nil {
+		return "", fmt.Errorf("failed to find merge base of %s and %s: %w", a, b, err)
+	}
+
+	return strings.TrimSpace(stdout.String()), nil
+}
+
 // Checkout checks out a branch
 func Checkout(branch string) error {
 	cmd := exec.Command("git", "checkout", branch)
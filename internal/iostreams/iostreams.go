@@ -18,6 +18,11 @@ type IOStreams struct {
 	colorEnabled  bool
 	is256enabled  bool
 	terminalWidth int
+	stdoutTTY     *bool
+	stdinTTY      *bool
+	neverPrompt   bool
+	verbose       bool
+	pager         *pager
 }
 
 // New creates a new IOStreams with default stdin/stdout/stderr
@@ -32,11 +37,19 @@ func New() *IOStreams {
 	io.colorEnabled = io.shouldEnableColor()
 	io.is256enabled = io.shouldEnable256Color()
 
+	// BB_DEBUG is the environment equivalent of --verbose
+	if os.Getenv("BB_DEBUG") != "" {
+		io.verbose = true
+	}
+
 	return io
 }
 
 // IsStdoutTTY returns true if stdout is a terminal
 func (s *IOStreams) IsStdoutTTY() bool {
+	if s.stdoutTTY != nil {
+		return *s.stdoutTTY
+	}
 	if f, ok := s.Out.(*os.File); ok {
 		return term.IsTerminal(int(f.Fd()))
 	}
@@ -53,6 +66,9 @@ func (s *IOStreams) IsStderrTTY() bool {
 
 // IsStdinTTY returns true if stdin is a terminal
 func (s *IOStreams) IsStdinTTY() bool {
+	if s.stdinTTY != nil {
+		return *s.stdinTTY
+	}
 	if f, ok := s.In.(*os.File); ok {
 		return term.IsTerminal(int(f.Fd()))
 	}
@@ -85,6 +101,47 @@ func (s *IOStreams) TerminalWidth() int {
 	return 80 // default width
 }
 
+// SetColorEnabled overrides color detection, mainly for tests
+func (s *IOStreams) SetColorEnabled(enabled bool) {
+	s.colorEnabled = enabled
+}
+
+// SetStdoutTTY overrides terminal detection for stdout, mainly for tests
+func (s *IOStreams) SetStdoutTTY(isTTY bool) {
+	s.stdoutTTY = &isTTY
+}
+
+// SetStdinTTY overrides terminal detection for stdin, mainly for tests
+func (s *IOStreams) SetStdinTTY(isTTY bool) {
+	s.stdinTTY = &isTTY
+}
+
+// SetNeverPrompt disables interactive prompts, as set by --no-prompt
+func (s *IOStreams) SetNeverPrompt(never bool) {
+	s.neverPrompt = never
+}
+
+// CanPrompt returns true if commands may ask the user for input: stdin is a
+// terminal and prompting has not been disabled with --no-prompt
+func (s *IOStreams) CanPrompt() bool {
+	return !s.neverPrompt && s.IsStdinTTY()
+}
+
+// SetVerbose turns verbose progress messages on, as set by --verbose
+func (s *IOStreams) SetVerbose(verbose bool) {
+	s.verbose = verbose
+}
+
+// IsVerbose returns true if verbose progress messages are shown
+func (s *IOStreams) IsVerbose() bool {
+	return s.verbose
+}
+
+// SetTerminalWidth overrides the detected terminal width
+func (s *IOStreams) SetTerminalWidth(width int) {
+	s.terminalWidth = width
+}
+
 func (s *IOStreams) shouldEnableColor() bool {
 	// Check NO_COLOR environment variable
 	if os.Getenv("NO_COLOR") != "" {
@@ -178,3 +235,12 @@ func (s *IOStreams) Warning(format string, a ...interface{}) {
 func (s *IOStreams) Info(format string, a ...interface{}) {
 	fmt.Fprintf(s.Out, format+"\n", a...)
 }
+
+// Verbose prints a progress message to stderr when verbose output is enabled,
+// e.g. "Fetching pull request #42..."
+func (s *IOStreams) Verbose(format string, a ...interface{}) {
+	if !s.IsVerbose() {
+		return
+	}
+	fmt.Fprintf(s.ErrOut, format+"\n", a...)
+}
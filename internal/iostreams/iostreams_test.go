@@ -0,0 +1,37 @@
+package iostreams
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestVerbose(t *testing.T) {
+	out, errOut := &bytes.Buffer{}, &bytes.Buffer{}
+	s := &IOStreams{Out: out, ErrOut: errOut}
+
+	s.Verbose("Fetching pull request #%d...", 42)
+	if errOut.Len() != 0 {
+		t.Errorf("Verbose() wrote %q without verbose output enabled", errOut.String())
+	}
+
+	s.SetVerbose(true)
+	s.Verbose("Fetching pull request #%d...", 42)
+	if got := errOut.String(); got != "Fetching pull request #42...\n" {
+		t.Errorf("Verbose() wrote %q to stderr", got)
+	}
+	if out.Len() != 0 {
+		t.Errorf("Verbose() wrote %q to stdout, want nothing", out.String())
+	}
+}
+
+func TestNewVerboseFromEnv(t *testing.T) {
+	t.Setenv("BB_DEBUG", "")
+	if New().IsVerbose() {
+		t.Error("New() is verbose without BB_DEBUG")
+	}
+
+	t.Setenv("BB_DEBUG", "1")
+	if !New().IsVerbose() {
+		t.Error("New() is not verbose with BB_DEBUG set")
+	}
+}
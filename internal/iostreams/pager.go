@@ -0,0 +1,74 @@
+package iostreams
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"strings"
+)
+
+// pager is a running pager process that Out is piped through
+type pager struct {
+	cmd    *exec.Cmd
+	stdin  io.WriteCloser
+	out    io.Writer
+	width  int
+	stdout *bool
+}
+
+// StartPager pipes Out through the pager command, such as "less -R", until
+// StopPager is called. It does nothing when Out is not a terminal or command
+// is empty or "cat".
+func (s *IOStreams) StartPager(command string) error {
+	if s.pager != nil || !s.IsStdoutTTY() {
+		return nil
+	}
+	args := strings.Fields(command)
+	if len(args) == 0 || args[0] == "cat" {
+		return nil
+	}
+
+	cmd := exec.Command(args[0], args[1:]...)
+	cmd.Stdout = s.Out
+	cmd.Stderr = s.ErrOut
+	cmd.Env = os.Environ()
+	if os.Getenv("LESS") == "" {
+		// Quit if the output fits on one screen and pass colors through
+		cmd.Env = append(cmd.Env, "LESS=FRX")
+	}
+
+	stdin, err := cmd.StdinPipe()
+	if err != nil {
+		return fmt.Errorf("failed to start pager: %w", err)
+	}
+	if err := cmd.Start(); err != nil {
+		return fmt.Errorf("failed to start pager: %w", err)
+	}
+
+	// Out stops being a terminal once it is a pipe, so keep reporting what
+	// the real stdout is while paging
+	p := &pager{cmd: cmd, stdin: stdin, out: s.Out, width: s.terminalWidth, stdout: s.stdoutTTY}
+	s.terminalWidth = s.TerminalWidth()
+	isTTY := true
+	s.stdoutTTY = &isTTY
+	s.Out = stdin
+	s.pager = p
+	return nil
+}
+
+// StopPager closes the pager's input and waits for the user to quit it
+func (s *IOStreams) StopPager() {
+	if s.pager == nil {
+		return
+	}
+	p := s.pager
+	s.pager = nil
+
+	_ = p.stdin.Close()
+	_ = p.cmd.Wait()
+
+	s.Out = p.out
+	s.terminalWidth = p.width
+	s.stdoutTTY = p.stdout
+}
@@ -0,0 +1,210 @@
+// Package update checks whether a newer release of bb is available.
+package update
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	// DefaultReleaseURL is the GitHub API endpoint for the latest bb release
+	DefaultReleaseURL = "https://api.github.com/repos/rbansal42/bitbucket-cli/releases/latest"
+
+	// CacheTTL is how long a release check result is reused before
+	// the release URL is queried again
+	CacheTTL = 24 * time.Hour
+
+	// StateFileName is the name of the file caching the last release check
+	StateFileName = "update-check.yml"
+)
+
+// ReleaseInfo describes a published release
+type ReleaseInfo struct {
+	Version string `yaml:"version" json:"tag_name"`
+	URL     string `yaml:"url" json:"html_url"`
+}
+
+// stateEntry is the cached result of the last release check
+type stateEntry struct {
+	CheckedAt  time.Time   `yaml:"checked_at"`
+	ReleaseURL string      `yaml:"release_url"`
+	Release    ReleaseInfo `yaml:"latest_release"`
+}
+
+// Checker looks up the latest release, caching the result on disk
+type Checker struct {
+	HTTPClient *http.Client
+	ReleaseURL string
+	StateFile  string
+	Now        func() time.Time
+}
+
+// LatestRelease returns the latest release, using the cached result when
+// it is younger than CacheTTL and came from the same release URL
+func (c *Checker) LatestRelease(ctx context.Context) (*ReleaseInfo, error) {
+	now := time.Now
+	if c.Now != nil {
+		now = c.Now
+	}
+
+	entry, err := readState(c.StateFile)
+	if err == nil && entry.ReleaseURL == c.releaseURL() && now().Sub(entry.CheckedAt) < CacheTTL {
+		return &entry.Release, nil
+	}
+
+	release, err := c.fetchLatest(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// Failing to cache only means the next check hits the network again
+	_ = writeState(c.StateFile, &stateEntry{CheckedAt: now(), ReleaseURL: c.releaseURL(), Release: *release})
+
+	return release, nil
+}
+
+// releaseURL returns the URL queried for the latest release
+func (c *Checker) releaseURL() string {
+	if c.ReleaseURL == "" {
+		return DefaultReleaseURL
+	}
+	return c.ReleaseURL
+}
+
+func (c *Checker) fetchLatest(ctx context.Context) (*ReleaseInfo, error) {
+	httpClient := c.HTTPClient
+	if httpClient == nil {
+		httpClient = &http.Client{Timeout: 10 * time.Second}
+	}
+	releaseURL := c.releaseURL()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, releaseURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("could not create request: %w", err)
+	}
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("could not check for updates: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("could not read release information: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("could not check for updates: %s returned %s", releaseURL, resp.Status)
+	}
+
+	var release ReleaseInfo
+	if err := json.Unmarshal(body, &release); err != nil {
+		return nil, fmt.Errorf("could not parse release information: %w", err)
+	}
+	if release.Version == "" {
+		return nil, fmt.Errorf("could not parse release information: no version found")
+	}
+
+	return &release, nil
+}
+
+func readState(path string) (*stateEntry, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var entry stateEntry
+	if err := yaml.Unmarshal(data, &entry); err != nil {
+		return nil, err
+	}
+	return &entry, nil
+}
+
+func writeState(path string, entry *stateEntry) error {
+	if path == "" {
+		return nil
+	}
+
+	data, err := yaml.Marshal(entry)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0600)
+}
+
+// IsNewer reports whether version latest is newer than current. Versions
+// that are not of the form [v]MAJOR.MINOR.PATCH (such as "dev") never
+// compare as older. A git describe version such as v1.2.3-4-gabc1234 is a
+// build after v1.2.3, so it compares as v1.2.3 rather than a pre-release.
+func IsNewer(latest, current string) bool {
+	l, ok := parseVersion(latest)
+	if !ok {
+		return false
+	}
+	c, ok := parseVersion(current)
+	if !ok {
+		return false
+	}
+
+	for i := range l.parts {
+		if l.parts[i] != c.parts[i] {
+			return l.parts[i] > c.parts[i]
+		}
+	}
+
+	// A release is newer than a pre-release of the same version
+	return l.pre == "" && c.pre != ""
+}
+
+// describeSuffix matches what git describe adds to the tag a build was made
+// after: the number of commits since it, the commit and a dirty marker
+var describeSuffix = regexp.MustCompile(`(-\d+-g[0-9a-f]+)?(-dirty)?$`)
+
+type version struct {
+	parts [3]int
+	pre   string
+}
+
+func parseVersion(s string) (version, bool) {
+	var v version
+
+	s = strings.TrimPrefix(strings.TrimSpace(s), "v")
+	s = describeSuffix.ReplaceAllString(s, "")
+	if i := strings.IndexByte(s, '+'); i >= 0 {
+		s = s[:i]
+	}
+	if i := strings.IndexByte(s, '-'); i >= 0 {
+		v.pre = s[i+1:]
+		s = s[:i]
+	}
+
+	fields := strings.Split(s, ".")
+	if len(fields) != 3 {
+		return v, false
+	}
+	for i, f := range fields {
+		n, err := strconv.Atoi(f)
+		if err != nil || n < 0 {
+			return v, false
+		}
+		v.parts[i] = n
+	}
+
+	return v, true
+}
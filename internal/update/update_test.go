@@ -0,0 +1,124 @@
+package update
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestIsNewer(t *testing.T) {
+	tests := []struct {
+		latest  string
+		current string
+		want    bool
+	}{
+		{"v1.2.0", "v1.1.9", true},
+		{"v1.2.0", "1.2.0", false},
+		{"v1.10.0", "v1.9.0", true},
+		{"v1.1.0", "v1.2.0", false},
+		{"v2.0.0", "v2.0.0-rc.1", true},
+		{"v2.0.0-rc.1", "v2.0.0", false},
+		{"v1.2.0", "dev", false},
+		{"nightly", "v1.0.0", false},
+		{"v1.0.1", "v1.0.0-3-gabcdef-dirty", true},
+		{"v1.2.3", "v1.2.3-4-gabc1234", false},
+		{"v1.2.3", "v1.2.3-dirty", false},
+		{"v1.2.3", "v1.2.3-rc.1-2-gabc1234", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.latest+"_vs_"+tt.current, func(t *testing.T) {
+			if got := IsNewer(tt.latest, tt.current); got != tt.want {
+				t.Errorf("IsNewer(%q, %q) = %v, want %v", tt.latest, tt.current, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCheckerLatestReleaseCaches(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"tag_name": "v1.4.0", "html_url": "https://example.com/releases/v1.4.0"}`))
+	}))
+	defer server.Close()
+
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	checker := &Checker{
+		ReleaseURL: server.URL,
+		StateFile:  filepath.Join(t.TempDir(), StateFileName),
+		Now:        func() time.Time { return now },
+	}
+
+	for i := 0; i < 2; i++ {
+		release, err := checker.LatestRelease(context.Background())
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if release.Version != "v1.4.0" || release.URL != "https://example.com/releases/v1.4.0" {
+			t.Errorf("unexpected release: %+v", release)
+		}
+	}
+	if requests != 1 {
+		t.Errorf("expected 1 request within the cache window, got %d", requests)
+	}
+
+	now = now.Add(CacheTTL + time.Minute)
+	if _, err := checker.LatestRelease(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if requests != 2 {
+		t.Errorf("expected cache to expire after %s, got %d requests", CacheTTL, requests)
+	}
+}
+
+func TestCheckerLatestReleaseKeyedByURL(t *testing.T) {
+	newServer := func(version string) *httptest.Server {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			w.Write([]byte(`{"tag_name": "` + version + `"}`))
+		}))
+		t.Cleanup(server.Close)
+		return server
+	}
+	stable, beta := newServer("v1.4.0"), newServer("v1.5.0-beta.1")
+	stateFile := filepath.Join(t.TempDir(), StateFileName)
+
+	for _, tt := range []struct {
+		url  string
+		want string
+	}{
+		{stable.URL, "v1.4.0"},
+		{beta.URL, "v1.5.0-beta.1"},
+		{stable.URL, "v1.4.0"},
+	} {
+		checker := &Checker{ReleaseURL: tt.url, StateFile: stateFile}
+		release, err := checker.LatestRelease(context.Background())
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if release.Version != tt.want {
+			t.Errorf("release from %s = %s, want %s", tt.url, release.Version, tt.want)
+		}
+	}
+}
+
+func TestCheckerLatestReleaseError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+	}))
+	defer server.Close()
+
+	checker := &Checker{
+		ReleaseURL: server.URL,
+		StateFile:  filepath.Join(t.TempDir(), StateFileName),
+	}
+
+	if _, err := checker.LatestRelease(context.Background()); err == nil {
+		t.Error("expected error but got nil")
+	}
+}